@@ -4,6 +4,8 @@ import (
 	"sync"
 
 	"github.com/alibaba/pouch/pkg/utils/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
@@ -13,6 +15,10 @@ func init() {
 
 const (
 	subsystemPouch = "daemon"
+
+	// namespacePouch is the prefix of the metrics whose names are stable,
+	// so that they can be used in the alerts and dashboards.
+	namespacePouch = "pouch"
 )
 
 var (
@@ -41,6 +47,65 @@ var (
 	EngineVersion = metrics.NewLabelGauge(subsystemPouch, "engine", "The version and commit information of the engine process", "commit")
 )
 
+var (
+	// APIRequestsCounter records the number of API requests by route and status code.
+	APIRequestsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespacePouch,
+		Name:      "api_requests_total",
+		Help:      "The number of API requests by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	// APIRequestDuration records the latency of API requests by route and status code.
+	APIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespacePouch,
+		Name:      "api_request_duration_seconds",
+		Help:      "The number of seconds it takes to serve the API requests by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "code"})
+
+	// ImagePullDuration records the time cost of image pulls.
+	ImagePullDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespacePouch,
+		Name:      "image_pull_duration_seconds",
+		Help:      "The number of seconds it takes to pull an image.",
+		Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12),
+	})
+
+	// ImagePullBytes records the number of bytes downloaded from registries.
+	ImagePullBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespacePouch,
+		Name:      "image_pull_bytes_total",
+		Help:      "The number of bytes downloaded from registries when pulling images.",
+	})
+
+	// ContainerdRPCErrorsCounter records the number of failed rpc calls to containerd.
+	ContainerdRPCErrorsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespacePouch,
+		Name:      "containerd_rpc_errors_total",
+		Help:      "The number of failed rpc calls to containerd by method and grpc code.",
+	}, []string{"method", "code"})
+
+	// LogDriverDroppedMessagesCounter records the number of log messages
+	// dropped by the log drivers which buffer the messages, such as fluentd.
+	LogDriverDroppedMessagesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespacePouch,
+		Name:      "log_driver_dropped_messages_total",
+		Help:      "The number of log messages dropped by log driver when its buffer is full.",
+	}, []string{"driver"})
+
+	// ContainersDesc describes the number of containers by state, which is
+	// collected when the metrics are scraped.
+	ContainersDesc = prometheus.NewDesc(namespacePouch+"_containers", "The number of containers by state.", []string{"state"}, nil)
+
+	// ImagesDesc describes the number of images, which is collected when the
+	// metrics are scraped.
+	ImagesDesc = prometheus.NewDesc(namespacePouch+"_images", "The number of images.", nil, nil)
+
+	// EventsQueueDepthDesc describes the number of events waiting to be sent
+	// to the subscribers, which is collected when the metrics are scraped.
+	EventsQueueDepthDesc = prometheus.NewDesc(namespacePouch+"_events_queue_depth", "The number of events waiting to be sent to the subscribers.", nil, nil)
+)
+
 var registerMetrics sync.Once
 
 // Register all metrics.
@@ -57,5 +122,11 @@ func Register() {
 		registry.MustRegister(ImageSuccessActionsCounter)
 		registry.MustRegister(ContainerActionsTimer)
 		registry.MustRegister(ImageActionsTimer)
+		registry.MustRegister(APIRequestsCounter)
+		registry.MustRegister(APIRequestDuration)
+		registry.MustRegister(ImagePullDuration)
+		registry.MustRegister(ImagePullBytes)
+		registry.MustRegister(ContainerdRPCErrorsCounter)
+		registry.MustRegister(LogDriverDroppedMessagesCounter)
 	})
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/alibaba/pouch/apis/types"
 
@@ -43,7 +44,7 @@ func (u *Ulimit) Type() string {
 	return "ulimit"
 }
 
-// Value return ulimit values as type Ulimit
+// Value return ulimit values as type Ulimit sorted by name
 func (u *Ulimit) Value() []*types.Ulimit {
 	var ulimit []*types.Ulimit
 	for _, ul := range u.values {
@@ -54,5 +55,8 @@ func (u *Ulimit) Value() []*types.Ulimit {
 		})
 	}
 
+	sort.Slice(ulimit, func(i, j int) bool {
+		return ulimit[i].Name < ulimit[j].Name
+	})
 	return ulimit
 }
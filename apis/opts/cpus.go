@@ -0,0 +1,28 @@
+package opts
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ParseCPUs parses the fractional number of cpus into nano cpus, such as
+// 1.5 into 1500000000.
+func ParseCPUs(cpus string) (int64, error) {
+	if cpus == "" {
+		return 0, nil
+	}
+
+	cpu, ok := new(big.Rat).SetString(cpus)
+	if !ok {
+		return 0, fmt.Errorf("invalid cpus %s: failed to parse as a rational number", cpus)
+	}
+
+	nano := cpu.Mul(cpu, big.NewRat(1e9, 1))
+	if !nano.IsInt() {
+		return 0, fmt.Errorf("invalid cpus %s: value is too precise", cpus)
+	}
+	if nano.Sign() < 0 {
+		return 0, fmt.Errorf("invalid cpus %s: value should not be negative", cpus)
+	}
+	return nano.Num().Int64(), nil
+}
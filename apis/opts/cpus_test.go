@@ -0,0 +1,32 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCPUs(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected int64
+		err      bool
+	}{
+		{input: "", expected: 0},
+		{input: "1", expected: 1000000000},
+		{input: "1.5", expected: 1500000000},
+		{input: "0.01", expected: 10000000},
+		{input: "3/2", expected: 1500000000},
+		{input: "0.0000000001", err: true},
+		{input: "-1", err: true},
+		{input: "foo", err: true},
+	} {
+		nano, err := ParseCPUs(tc.input)
+		if tc.err {
+			assert.Error(t, err, tc.input)
+			continue
+		}
+		assert.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, nano, tc.input)
+	}
+}
@@ -2,6 +2,8 @@ package opts
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
@@ -57,6 +59,56 @@ func parseDevice(device string) (*types.DeviceMapping, error) {
 	return deviceMapping, nil
 }
 
+// deviceCgroupRuleRegexp matches the device cgroup rule, such as "c 13:* rwm".
+var deviceCgroupRuleRegexp = regexp.MustCompile(`^([acb]) ([0-9]+|\*):([0-9]+|\*) ([rwm]{1,3})$`)
+
+// DeviceCgroupRule represents a raw rule of devices cgroup, nil Major or
+// Minor means all the major or minor numbers.
+type DeviceCgroupRule struct {
+	Type   string
+	Major  *int64
+	Minor  *int64
+	Access string
+}
+
+// ParseDeviceCgroupRule parses the device cgroup rule in the format of
+// "type major:minor access", such as "c 13:* rwm" or "b 8:0 r".
+func ParseDeviceCgroupRule(rule string) (*DeviceCgroupRule, error) {
+	parts := deviceCgroupRuleRegexp.FindStringSubmatch(rule)
+	if parts == nil || !ValidateDeviceMode(parts[4]) {
+		return nil, fmt.Errorf("invalid device cgroup rule %q, format should be like 'c 13:* rwm'", rule)
+	}
+
+	major, err := parseDeviceNumber(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid major number of device cgroup rule %q: %v", rule, err)
+	}
+	minor, err := parseDeviceNumber(parts[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid minor number of device cgroup rule %q: %v", rule, err)
+	}
+
+	return &DeviceCgroupRule{
+		Type:   parts[1],
+		Major:  major,
+		Minor:  minor,
+		Access: parts[4],
+	}, nil
+}
+
+// parseDeviceNumber parses the major or minor number of device, * means all.
+func parseDeviceNumber(number string) (*int64, error) {
+	if number == "*" {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
 // ValidateDeviceMode checks if the mode for device is valid or not.
 // valid mode is a composition of r (read), w (write), and m (mknod).
 func ValidateDeviceMode(mode string) bool {
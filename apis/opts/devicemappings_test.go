@@ -164,3 +164,31 @@ func TestValidateDeviceMode(t *testing.T) {
 		assert.Equal(t, modeCase.expected, isValid, modeCase.input)
 	}
 }
+
+func TestParseDeviceCgroupRule(t *testing.T) {
+	n := func(i int64) *int64 { return &i }
+
+	for _, tc := range []struct {
+		input    string
+		expected *DeviceCgroupRule
+		err      bool
+	}{
+		{input: "c 13:* rwm", expected: &DeviceCgroupRule{Type: "c", Major: n(13), Access: "rwm"}},
+		{input: "b 8:0 r", expected: &DeviceCgroupRule{Type: "b", Major: n(8), Minor: n(0), Access: "r"}},
+		{input: "a *:* m", expected: &DeviceCgroupRule{Type: "a", Access: "m"}},
+		{input: "c 13:* rr", err: true},
+		{input: "x 13:* rwm", err: true},
+		{input: "c 13 rwm", err: true},
+		{input: "c 13:1", err: true},
+		{input: "c 99999999999999999999:1 rwm", err: true},
+		{input: "", err: true},
+	} {
+		rule, err := ParseDeviceCgroupRule(tc.input)
+		if tc.err {
+			assert.Error(t, err, tc.input)
+			continue
+		}
+		assert.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, rule, tc.input)
+	}
+}
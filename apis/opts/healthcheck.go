@@ -0,0 +1,75 @@
+package opts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// minHealthcheckDuration is the minimum value of interval, timeout and start
+// period of healthcheck, except 0 which means inherit.
+const minHealthcheckDuration = time.Millisecond
+
+// ParseHealthcheck parses the healthcheck params of container, nil is
+// returned if no param is set so that the healthcheck of image is inherited.
+func ParseHealthcheck(cmd string, interval, timeout, startPeriod time.Duration, retries int, disable bool) (*types.HealthConfig, error) {
+	if disable {
+		if cmd != "" || interval != 0 || timeout != 0 || startPeriod != 0 || retries != 0 {
+			return nil, fmt.Errorf("--no-healthcheck conflicts with --health-* options")
+		}
+		return &types.HealthConfig{Test: []string{"NONE"}}, nil
+	}
+
+	if cmd == "" && interval == 0 && timeout == 0 && startPeriod == 0 && retries == 0 {
+		return nil, nil
+	}
+
+	config := &types.HealthConfig{
+		Interval:    int64(interval),
+		Timeout:     int64(timeout),
+		StartPeriod: int64(startPeriod),
+		Retries:     int64(retries),
+	}
+	if cmd != "" {
+		config.Test = []string{"CMD-SHELL", cmd}
+	}
+	return config, nil
+}
+
+// ValidateHealthcheck verifies the correctness of healthcheck of container.
+func ValidateHealthcheck(config *types.HealthConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	if len(config.Test) > 0 {
+		switch config.Test[0] {
+		case "NONE":
+		case "CMD", "CMD-SHELL":
+			if len(config.Test) == 1 {
+				return fmt.Errorf("healthcheck test %s requires a command", config.Test[0])
+			}
+		default:
+			return fmt.Errorf("invalid healthcheck test type %s, should be one of NONE, CMD or CMD-SHELL", config.Test[0])
+		}
+	}
+
+	for _, d := range []struct {
+		name  string
+		value int64
+	}{
+		{"interval", config.Interval},
+		{"timeout", config.Timeout},
+		{"start period", config.StartPeriod},
+	} {
+		if d.value != 0 && time.Duration(d.value) < minHealthcheckDuration {
+			return fmt.Errorf("healthcheck %s should be 0 or at least %v", d.name, minHealthcheckDuration)
+		}
+	}
+
+	if config.Retries < 0 {
+		return fmt.Errorf("healthcheck retries can not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package opts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHealthcheck(t *testing.T) {
+	config, err := ParseHealthcheck("", 0, 0, 0, 0, false)
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = ParseHealthcheck("true", time.Second, 2*time.Second, 3*time.Second, 4, false)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.HealthConfig{
+		Test:        []string{"CMD-SHELL", "true"},
+		Interval:    int64(time.Second),
+		Timeout:     int64(2 * time.Second),
+		StartPeriod: int64(3 * time.Second),
+		Retries:     4,
+	}, config)
+
+	// only override the interval of image's healthcheck
+	config, err = ParseHealthcheck("", time.Second, 0, 0, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.HealthConfig{Interval: int64(time.Second)}, config)
+
+	config, err = ParseHealthcheck("", 0, 0, 0, 0, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"NONE"}, config.Test)
+
+	_, err = ParseHealthcheck("true", 0, 0, 0, 0, true)
+	assert.Error(t, err)
+}
+
+func TestValidateHealthcheck(t *testing.T) {
+	for _, tc := range []struct {
+		config *types.HealthConfig
+		valid  bool
+	}{
+		{config: nil, valid: true},
+		{config: &types.HealthConfig{}, valid: true},
+		{config: &types.HealthConfig{Test: []string{"NONE"}}, valid: true},
+		{config: &types.HealthConfig{Test: []string{"CMD", "true"}, Interval: int64(time.Millisecond)}, valid: true},
+		{config: &types.HealthConfig{Test: []string{"CMD-SHELL"}}, valid: false},
+		{config: &types.HealthConfig{Test: []string{"EXEC", "true"}}, valid: false},
+		{config: &types.HealthConfig{Interval: int64(time.Microsecond)}, valid: false},
+		{config: &types.HealthConfig{Timeout: -1}, valid: false},
+		{config: &types.HealthConfig{StartPeriod: int64(time.Microsecond)}, valid: false},
+		{config: &types.HealthConfig{Retries: -1}, valid: false},
+	} {
+		err := ValidateHealthcheck(tc.config)
+		assert.Equal(t, tc.valid, err == nil, "%+v: %v", tc.config, err)
+	}
+}
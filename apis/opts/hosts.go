@@ -0,0 +1,33 @@
+package opts
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ValidateExtraHosts validates the extra hosts of container, which are in
+// format of host:ip, the ip can be IPv4 or IPv6.
+func ValidateExtraHosts(hosts []string) error {
+	for _, host := range hosts {
+		// the ip may contain colons if it is IPv6, so split by the first one.
+		fields := strings.SplitN(host, ":", 2)
+		if len(fields) != 2 || fields[0] == "" {
+			return fmt.Errorf("invalid extra host %s: extra host must be in format of host:ip", host)
+		}
+		if net.ParseIP(fields[1]) == nil {
+			return fmt.Errorf("invalid extra host %s: %s is not a valid ip address", host, fields[1])
+		}
+	}
+	return nil
+}
+
+// ValidateDNS validates the DNS servers of container are ip addresses.
+func ValidateDNS(dns []string) error {
+	for _, d := range dns {
+		if net.ParseIP(d) == nil {
+			return fmt.Errorf("invalid dns server %s: not a valid ip address", d)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateExtraHosts(t *testing.T) {
+	for _, tc := range []struct {
+		hosts []string
+		valid bool
+	}{
+		{hosts: nil, valid: true},
+		{hosts: []string{"foo:1.2.3.4", "bar:127.0.0.1"}, valid: true},
+		{hosts: []string{"ipv6:2001:db8::1"}, valid: true},
+		{hosts: []string{"foo"}, valid: false},
+		{hosts: []string{":1.2.3.4"}, valid: false},
+		{hosts: []string{"foo:"}, valid: false},
+		{hosts: []string{"foo:1.2.3.4", "bar:1.2.3"}, valid: false},
+		{hosts: []string{"foo:bar"}, valid: false},
+	} {
+		err := ValidateExtraHosts(tc.hosts)
+		assert.Equal(t, tc.valid, err == nil, "%v: %v", tc.hosts, err)
+	}
+}
+
+func TestValidateDNS(t *testing.T) {
+	for _, tc := range []struct {
+		dns   []string
+		valid bool
+	}{
+		{dns: nil, valid: true},
+		{dns: []string{"1.2.3.4", "2001:db8::1"}, valid: true},
+		{dns: []string{"1.2.3.4", "example.com"}, valid: false},
+		{dns: []string{""}, valid: false},
+	} {
+		err := ValidateDNS(tc.dns)
+		assert.Equal(t, tc.valid, err == nil, "%v: %v", tc.dns, err)
+	}
+}
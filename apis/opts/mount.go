@@ -0,0 +1,188 @@
+package opts
+
+import (
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	units "github.com/docker/go-units"
+)
+
+// ParseMounts parses the mount params of container, each one is in format of
+// type=<bind|volume|tmpfs>,source=<src>,target=<dst>[,readonly][,<option>=<value>].
+func ParseMounts(mounts []string) ([]*types.Mount, error) {
+	var results []*types.Mount
+	for _, mount := range mounts {
+		m, err := ParseMount(mount)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, m)
+	}
+
+	if err := ValidateMounts(results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ParseMount parses a mount param of container, the type is volume if it is
+// not specified. The fields are comma separated values, and the field
+// containing comma can be quoted.
+func ParseMount(mount string) (*types.Mount, error) {
+	fields, err := csv.NewReader(strings.NewReader(mount)).Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid mount %q: %v", mount, err)
+	}
+
+	m := &types.Mount{Type: "volume"}
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(strings.TrimSpace(parts[0]))
+
+		if len(parts) == 1 {
+			switch key {
+			case "readonly", "ro":
+				m.ReadOnly = true
+				continue
+			case "volume-nocopy":
+				volumeOptions(m).NoCopy = true
+				continue
+			}
+			return nil, fmt.Errorf("invalid field %q in mount %q, it must be in format of key=value", field, mount)
+		}
+
+		value := parts[1]
+		switch key {
+		case "type":
+			m.Type = value
+		case "source", "src":
+			m.Source = value
+		case "target", "destination", "dst":
+			m.Target = value
+		case "readonly", "ro":
+			if m.ReadOnly, err = strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("invalid value %q of %s in mount %q", value, key, mount)
+			}
+		case "bind-propagation":
+			bindOptions(m).Propagation = strings.ToLower(value)
+		case "volume-nocopy":
+			if volumeOptions(m).NoCopy, err = strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("invalid value %q of %s in mount %q", value, key, mount)
+			}
+		case "tmpfs-size":
+			size, err := units.RAMInBytes(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q of %s in mount %q: %v", value, key, mount, err)
+			}
+			tmpfsOptions(m).SizeBytes = size
+		case "tmpfs-mode":
+			mode, err := strconv.ParseUint(value, 8, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q of %s in mount %q: %v", value, key, mount, err)
+			}
+			tmpfsOptions(m).Mode = uint32(mode)
+		default:
+			return nil, fmt.Errorf("unknown field %q in mount %q", key, mount)
+		}
+	}
+
+	return m, nil
+}
+
+func bindOptions(m *types.Mount) *types.MountBindOptions {
+	if m.BindOptions == nil {
+		m.BindOptions = &types.MountBindOptions{}
+	}
+	return m.BindOptions
+}
+
+func volumeOptions(m *types.Mount) *types.MountVolumeOptions {
+	if m.VolumeOptions == nil {
+		m.VolumeOptions = &types.MountVolumeOptions{}
+	}
+	return m.VolumeOptions
+}
+
+func tmpfsOptions(m *types.Mount) *types.MountTmpfsOptions {
+	if m.TmpfsOptions == nil {
+		m.TmpfsOptions = &types.MountTmpfsOptions{}
+	}
+	return m.TmpfsOptions
+}
+
+// ValidateMounts verifies the correctness of mounts of container.
+func ValidateMounts(mounts []*types.Mount) error {
+	targets := map[string]struct{}{}
+	for _, m := range mounts {
+		if err := validateMount(m); err != nil {
+			return err
+		}
+
+		target := filepath.Clean(m.Target)
+		if _, exist := targets[target]; exist {
+			return fmt.Errorf("duplicate mount target: %s", m.Target)
+		}
+		targets[target] = struct{}{}
+	}
+	return nil
+}
+
+func validateMount(m *types.Mount) error {
+	if m == nil {
+		return fmt.Errorf("mount cannot be empty")
+	}
+
+	if m.Target == "" {
+		return fmt.Errorf("target of mount cannot be empty")
+	}
+	if !filepath.IsAbs(m.Target) {
+		return fmt.Errorf("target of mount must be an absolute path: %s", m.Target)
+	}
+
+	if m.BindOptions != nil && m.Type != "bind" {
+		return fmt.Errorf("bind options cannot be used with mount type %s", m.Type)
+	}
+	if m.VolumeOptions != nil && m.Type != "volume" {
+		return fmt.Errorf("volume options cannot be used with mount type %s", m.Type)
+	}
+	if m.TmpfsOptions != nil && m.Type != "tmpfs" {
+		return fmt.Errorf("tmpfs options cannot be used with mount type %s", m.Type)
+	}
+
+	switch m.Type {
+	case "bind":
+		if m.Source == "" {
+			return fmt.Errorf("source of bind mount cannot be empty")
+		}
+		if !filepath.IsAbs(m.Source) {
+			return fmt.Errorf("source of bind mount must be an absolute path: %s", m.Source)
+		}
+		if m.BindOptions != nil {
+			switch m.BindOptions.Propagation {
+			case "", "private", "rprivate", "slave", "rslave", "shared", "rshared":
+			default:
+				return fmt.Errorf("invalid bind propagation: %s", m.BindOptions.Propagation)
+			}
+		}
+	case "volume":
+		if filepath.IsAbs(m.Source) {
+			return fmt.Errorf("source of volume mount must be a volume name: %s", m.Source)
+		}
+	case "tmpfs":
+		if m.Source != "" {
+			return fmt.Errorf("source of tmpfs mount must be empty")
+		}
+		if m.TmpfsOptions != nil && m.TmpfsOptions.SizeBytes < 0 {
+			return fmt.Errorf("size of tmpfs mount cannot be negative")
+		}
+	default:
+		return fmt.Errorf("invalid mount type: %s", m.Type)
+	}
+
+	return nil
+}
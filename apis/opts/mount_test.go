@@ -0,0 +1,86 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMount(t *testing.T) {
+	for _, tc := range []struct {
+		mount    string
+		expected *types.Mount
+		err      bool
+	}{
+		{
+			mount:    "target=/data",
+			expected: &types.Mount{Type: "volume", Target: "/data"},
+		},
+		{
+			mount:    "type=volume,src=myvolume,dst=/data,volume-nocopy",
+			expected: &types.Mount{Type: "volume", Source: "myvolume", Target: "/data", VolumeOptions: &types.MountVolumeOptions{NoCopy: true}},
+		},
+		{
+			mount: "type=bind,source=/tmp,target=/data,readonly,bind-propagation=rshared",
+			expected: &types.Mount{Type: "bind", Source: "/tmp", Target: "/data", ReadOnly: true,
+				BindOptions: &types.MountBindOptions{Propagation: "rshared"}},
+		},
+		{
+			mount:    "type=bind,source=/tmp,destination=/data,ro=false",
+			expected: &types.Mount{Type: "bind", Source: "/tmp", Target: "/data"},
+		},
+		{
+			mount:    "type=tmpfs,dst=/run,tmpfs-size=64m,tmpfs-mode=1770",
+			expected: &types.Mount{Type: "tmpfs", Target: "/run", TmpfsOptions: &types.MountTmpfsOptions{SizeBytes: 64 * 1024 * 1024, Mode: 01770}},
+		},
+		{
+			mount:    `type=bind,"source=/a,b",target=/data`,
+			expected: &types.Mount{Type: "bind", Source: "/a,b", Target: "/data"},
+		},
+		{mount: "type=bind,source", err: true},
+		{mount: "type=bind,foo=bar", err: true},
+		{mount: "readonly=maybe", err: true},
+		{mount: "type=tmpfs,tmpfs-size=abc", err: true},
+		{mount: "type=tmpfs,tmpfs-mode=999", err: true},
+	} {
+		m, err := ParseMount(tc.mount)
+		if tc.err {
+			assert.Error(t, err, tc.mount)
+			continue
+		}
+		assert.NoError(t, err, tc.mount)
+		assert.Equal(t, tc.expected, m, tc.mount)
+	}
+}
+
+func TestValidateMounts(t *testing.T) {
+	for _, tc := range []struct {
+		mounts []*types.Mount
+		err    bool
+	}{
+		{mounts: nil},
+		{mounts: []*types.Mount{{Type: "volume", Target: "/data"}}},
+		{mounts: []*types.Mount{{Type: "volume", Source: "myvolume", Target: "/data"}, {Type: "bind", Source: "/tmp", Target: "/tmp"}}},
+		{mounts: []*types.Mount{{Type: "tmpfs", Target: "/run", TmpfsOptions: &types.MountTmpfsOptions{SizeBytes: 1024}}}},
+		{mounts: []*types.Mount{nil}, err: true},
+		{mounts: []*types.Mount{{Type: "volume"}}, err: true},
+		{mounts: []*types.Mount{{Type: "volume", Target: "data"}}, err: true},
+		{mounts: []*types.Mount{{Type: "volume", Source: "/tmp", Target: "/data"}}, err: true},
+		{mounts: []*types.Mount{{Type: "bind", Target: "/data"}}, err: true},
+		{mounts: []*types.Mount{{Type: "bind", Source: "tmp", Target: "/data"}}, err: true},
+		{mounts: []*types.Mount{{Type: "bind", Source: "/tmp", Target: "/data", BindOptions: &types.MountBindOptions{Propagation: "foo"}}}, err: true},
+		{mounts: []*types.Mount{{Type: "tmpfs", Source: "tmpfs", Target: "/run"}}, err: true},
+		{mounts: []*types.Mount{{Type: "tmpfs", Target: "/run", VolumeOptions: &types.MountVolumeOptions{NoCopy: true}}}, err: true},
+		{mounts: []*types.Mount{{Type: "foo", Target: "/data"}}, err: true},
+		{mounts: []*types.Mount{{Type: "volume", Target: "/data"}, {Type: "tmpfs", Target: "/data/"}}, err: true},
+	} {
+		err := ValidateMounts(tc.mounts)
+		if tc.err {
+			assert.Error(t, err, "%+v", tc.mounts)
+		} else {
+			assert.NoError(t, err, "%+v", tc.mounts)
+		}
+	}
+}
@@ -43,6 +43,79 @@ func ParseNetworks(networks []string) (*types.NetworkingConfig, string, error) {
 	return networkingConfig, networkMode, nil
 }
 
+// ParseNetworkAliases sets the network-scoped aliases of container on the
+// network of network mode.
+func ParseNetworkAliases(nwConfig *types.NetworkingConfig, networkMode string, aliases []string) error {
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	for _, alias := range aliases {
+		if alias == "" {
+			return fmt.Errorf("invalid network alias: cannot be empty")
+		}
+	}
+
+	if networkMode == "" || strings.Contains(networkMode, ":") {
+		return fmt.Errorf("invalid network alias: network-scoped alias is supported only for containers in user defined networks")
+	}
+
+	if nwConfig.EndpointsConfig == nil {
+		nwConfig.EndpointsConfig = map[string]*types.EndpointSettings{}
+	}
+	ep, ok := nwConfig.EndpointsConfig[networkMode]
+	if !ok || ep == nil {
+		ep = &types.EndpointSettings{}
+		nwConfig.EndpointsConfig[networkMode] = ep
+	}
+	ep.Aliases = append(ep.Aliases, aliases...)
+
+	return nil
+}
+
+// ParseNetworkIPs sets the static IPv4 and IPv6 addresses of container on
+// the network of network mode, which are kept across restarts.
+func ParseNetworkIPs(nwConfig *types.NetworkingConfig, networkMode, ipv4, ipv6 string) error {
+	if ipv4 == "" && ipv6 == "" {
+		return nil
+	}
+
+	if ipv4 != "" && (net.ParseIP(ipv4) == nil || net.ParseIP(ipv4).To4() == nil) {
+		return fmt.Errorf("invalid IPv4 address: %s", ipv4)
+	}
+	if ipv6 != "" && (net.ParseIP(ipv6) == nil || net.ParseIP(ipv6).To4() != nil) {
+		return fmt.Errorf("invalid IPv6 address: %s", ipv6)
+	}
+
+	if networkMode == "" || strings.Contains(networkMode, ":") {
+		return fmt.Errorf("invalid ip address: user specified IP address is supported only for containers in user defined networks")
+	}
+
+	if nwConfig.EndpointsConfig == nil {
+		nwConfig.EndpointsConfig = map[string]*types.EndpointSettings{}
+	}
+	ep, ok := nwConfig.EndpointsConfig[networkMode]
+	if !ok || ep == nil {
+		ep = &types.EndpointSettings{}
+		nwConfig.EndpointsConfig[networkMode] = ep
+	}
+	if ep.IPAMConfig == nil {
+		ep.IPAMConfig = &types.EndpointIPAMConfig{}
+	}
+
+	if ipv4 != "" {
+		if ep.IPAMConfig.IPV4Address != "" && ep.IPAMConfig.IPV4Address != ipv4 {
+			return fmt.Errorf("conflicting IPv4 address: %s is set by --net, but --ip is %s", ep.IPAMConfig.IPV4Address, ipv4)
+		}
+		ep.IPAMConfig.IPV4Address = ipv4
+	}
+	if ipv6 != "" {
+		ep.IPAMConfig.IPV6Address = ipv6
+	}
+
+	return nil
+}
+
 // network format as below:
 // [network]:[ip_address], such as: mynetwork:172.17.0.2 or mynetwork(ip alloc by ipam) or 172.17.0.2(default network is bridge)
 // [network_mode]:[parameter], such as: host(use host network) or container:containerID(use exist container network)
@@ -109,7 +182,9 @@ func ValidateNetworks(nwConfig *types.NetworkingConfig) error {
 			if v.IPAMConfig.IPV4Address != "" && net.ParseIP(v.IPAMConfig.IPV4Address).To4() == nil {
 				return fmt.Errorf("invalid IPv4 address: %s", v.IPAMConfig.IPV4Address)
 			}
-			// TODO: check IPv6Address
+			if ip := v.IPAMConfig.IPV6Address; ip != "" && (net.ParseIP(ip) == nil || net.ParseIP(ip).To4() != nil) {
+				return fmt.Errorf("invalid IPv6 address: %s", ip)
+			}
 		}
 	}
 
@@ -101,6 +101,55 @@ func TestParseNetworks(t *testing.T) {
 	}
 }
 
+func TestParseNetworkAliases(t *testing.T) {
+	nwConfig := &types.NetworkingConfig{
+		EndpointsConfig: map[string]*types.EndpointSettings{
+			"net1": {IPAddress: "10.0.0.5"},
+		},
+	}
+	assert.NoError(t, ParseNetworkAliases(nwConfig, "net1", []string{"db", "mysql"}))
+	assert.Equal(t, &types.EndpointSettings{IPAddress: "10.0.0.5", Aliases: []string{"db", "mysql"}}, nwConfig.EndpointsConfig["net1"])
+
+	nwConfig = &types.NetworkingConfig{}
+	assert.NoError(t, ParseNetworkAliases(nwConfig, "net2", []string{"web"}))
+	assert.Equal(t, []string{"web"}, nwConfig.EndpointsConfig["net2"].Aliases)
+
+	nwConfig = &types.NetworkingConfig{}
+	assert.NoError(t, ParseNetworkAliases(nwConfig, "net2", nil))
+	assert.Empty(t, nwConfig.EndpointsConfig)
+
+	assert.Error(t, ParseNetworkAliases(&types.NetworkingConfig{}, "net2", []string{""}))
+	assert.Error(t, ParseNetworkAliases(&types.NetworkingConfig{}, "container:foo", []string{"web"}))
+}
+
+func TestParseNetworkIPs(t *testing.T) {
+	nwConfig := &types.NetworkingConfig{
+		EndpointsConfig: map[string]*types.EndpointSettings{
+			"net1": {Aliases: []string{"db"}},
+		},
+	}
+	assert.NoError(t, ParseNetworkIPs(nwConfig, "net1", "10.0.0.5", "2001:db8::5"))
+	assert.Equal(t, &types.EndpointSettings{
+		Aliases:    []string{"db"},
+		IPAMConfig: &types.EndpointIPAMConfig{IPV4Address: "10.0.0.5", IPV6Address: "2001:db8::5"},
+	}, nwConfig.EndpointsConfig["net1"])
+
+	nwConfig = &types.NetworkingConfig{}
+	assert.NoError(t, ParseNetworkIPs(nwConfig, "net1", "", ""))
+	assert.Empty(t, nwConfig.EndpointsConfig)
+
+	// the address set by --net net1:ip is kept if --ip is the same.
+	nwConfig, networkMode, err := ParseNetworks([]string{"net1:10.0.0.5"})
+	assert.NoError(t, err)
+	assert.NoError(t, ParseNetworkIPs(nwConfig, networkMode, "10.0.0.5", ""))
+	assert.Error(t, ParseNetworkIPs(nwConfig, networkMode, "10.0.0.6", ""))
+
+	assert.Error(t, ParseNetworkIPs(&types.NetworkingConfig{}, "net1", "10.0.0.256", ""))
+	assert.Error(t, ParseNetworkIPs(&types.NetworkingConfig{}, "net1", "2001:db8::5", ""))
+	assert.Error(t, ParseNetworkIPs(&types.NetworkingConfig{}, "net1", "", "10.0.0.5"))
+	assert.Error(t, ParseNetworkIPs(&types.NetworkingConfig{}, "container:foo", "10.0.0.5", ""))
+}
+
 func TestVerifyNetworks(t *testing.T) {
 	type args struct {
 		nwConfig *types.NetworkingConfig
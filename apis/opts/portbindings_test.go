@@ -56,6 +56,30 @@ func TestParsePortBinding(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "testRangeBinding",
+			args: args{ports: []string{"8000-8002:9000-9002"}},
+			want: map[string][]types.PortBinding{
+				"9000/tcp": {{HostPort: "8000"}},
+				"9001/tcp": {{HostPort: "8001"}},
+				"9002/tcp": {{HostPort: "8002"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "testRandomPublicBinding",
+			args: args{ports: []string{"127.0.0.1::80"}},
+			want: map[string][]types.PortBinding{
+				"80/tcp": {{HostIP: "127.0.0.1"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "testMismatchedRangeBinding",
+			args:    args{ports: []string{"8000-8002:9000-9001"}},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "testUnExistedProtoBinding",
 			args:    args{ports: []string{"80:80/abc"}},
@@ -2,9 +2,26 @@ package opts
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
+var (
+	// namespacedSysctls are the sysctls isolated by ipc namespace.
+	namespacedSysctls = map[string]bool{
+		"kernel.sem": true,
+	}
+
+	// namespacedSysctlPrefixes are the prefixes of sysctls isolated by ipc
+	// or network namespace.
+	namespacedSysctlPrefixes = []string{
+		"kernel.msg",
+		"kernel.shm",
+		"fs.mqueue.",
+		"net.",
+	}
+)
+
 // ParseSysctls parses the sysctl params of container
 func ParseSysctls(sysctls []string) (map[string]string, error) {
 	results := make(map[string]string)
@@ -26,3 +43,34 @@ func parseSysctl(sysctl string) ([]string, error) {
 	}
 	return fields, nil
 }
+
+// ValidateSysctls validates the sysctls of container are namespaced, since
+// the other sysctls are shared by all the containers and the host.
+func ValidateSysctls(sysctls map[string]string) error {
+	keys := make([]string, 0, len(sysctls))
+	for k := range sysctls {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if !IsNamespacedSysctl(k) {
+			return fmt.Errorf("invalid sysctl %s: it is not namespaced and would change the host, only kernel.msg*, kernel.sem, kernel.shm*, fs.mqueue.* and net.* are allowed", k)
+		}
+	}
+	return nil
+}
+
+// IsNamespacedSysctl returns true if the sysctl is isolated by the ipc or
+// network namespace of container.
+func IsNamespacedSysctl(sysctl string) bool {
+	if namespacedSysctls[sysctl] {
+		return true
+	}
+	for _, prefix := range namespacedSysctlPrefixes {
+		if strings.HasPrefix(sysctl, prefix) {
+			return true
+		}
+	}
+	return false
+}
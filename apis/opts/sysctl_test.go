@@ -40,3 +40,25 @@ func TestParseSysctls(t *testing.T) {
 		assert.Equal(t, testCase.expect.err, err)
 	}
 }
+
+func TestValidateSysctls(t *testing.T) {
+	for _, tc := range []struct {
+		sysctls map[string]string
+		valid   bool
+	}{
+		{sysctls: nil, valid: true},
+		{sysctls: map[string]string{"net.ipv4.ip_forward": "1", "net.core.somaxconn": "1024"}, valid: true},
+		{sysctls: map[string]string{"kernel.msgmax": "65536", "kernel.sem": "250 32000 100 128", "kernel.shmmax": "1"}, valid: true},
+		{sysctls: map[string]string{"fs.mqueue.msg_max": "10"}, valid: true},
+		{sysctls: map[string]string{"kernel.panic": "1"}, valid: false},
+		{sysctls: map[string]string{"vm.swappiness": "0"}, valid: false},
+		{sysctls: map[string]string{"fs.file-max": "1024"}, valid: false},
+		{sysctls: map[string]string{"net.ipv4.ip_forward": "1", "kernel.domainname": "foo"}, valid: false},
+	} {
+		err := ValidateSysctls(tc.sysctls)
+		assert.Equal(t, tc.valid, err == nil, "%v", tc.sysctls)
+	}
+
+	err := ValidateSysctls(map[string]string{"kernel.panic": "1"})
+	assert.Contains(t, err.Error(), "not namespaced")
+}
@@ -0,0 +1,67 @@
+package opts
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ParseTmpfs parses the tmpfs mounts of container, each one is in format of
+// /path[:options], and the options are comma separated, such as
+// /run:rw,noexec,size=64m.
+func ParseTmpfs(tmpfs []string) (map[string]string, error) {
+	if len(tmpfs) == 0 {
+		return nil, nil
+	}
+
+	results := make(map[string]string)
+	for _, t := range tmpfs {
+		parts := strings.SplitN(t, ":", 2)
+		dest := filepath.Clean(parts[0])
+		if _, exist := results[dest]; exist {
+			return nil, fmt.Errorf("invalid tmpfs %s: duplicate mount point %s", t, dest)
+		}
+
+		options := ""
+		if len(parts) == 2 {
+			options = parts[1]
+		}
+		results[dest] = options
+	}
+
+	if err := ValidateTmpfs(results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ValidateTmpfs validates the tmpfs mounts of container, the destination must
+// be an absolute path other than /, and the options can't be empty strings.
+func ValidateTmpfs(tmpfs map[string]string) error {
+	dests := make([]string, 0, len(tmpfs))
+	for dest := range tmpfs {
+		dests = append(dests, dest)
+	}
+	sort.Strings(dests)
+
+	for _, dest := range dests {
+		if !filepath.IsAbs(dest) {
+			return fmt.Errorf("invalid tmpfs %s: destination must be an absolute path", dest)
+		}
+		if filepath.Clean(dest) == "/" {
+			return fmt.Errorf("invalid tmpfs %s: destination can't be /", dest)
+		}
+
+		options := tmpfs[dest]
+		if options == "" {
+			continue
+		}
+		for _, o := range strings.Split(options, ",") {
+			if strings.TrimSpace(o) == "" {
+				return fmt.Errorf("invalid tmpfs %s: options %q contain empty option", dest, options)
+			}
+		}
+	}
+	return nil
+}
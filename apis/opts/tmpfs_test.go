@@ -0,0 +1,42 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTmpfs(t *testing.T) {
+	for _, tc := range []struct {
+		input    []string
+		expected map[string]string
+		err      string
+	}{
+		{input: nil, expected: nil},
+		{input: []string{"/run"}, expected: map[string]string{"/run": ""}},
+		{
+			input:    []string{"/run:rw,noexec,size=64m", "/tmp/:mode=1777"},
+			expected: map[string]string{"/run": "rw,noexec,size=64m", "/tmp": "mode=1777"},
+		},
+		{input: []string{"run"}, err: "destination must be an absolute path"},
+		{input: []string{"/"}, err: "destination can't be /"},
+		{input: []string{"/run", "/run/:size=1m"}, err: "duplicate mount point /run"},
+		{input: []string{"/run:rw,,size=1m"}, err: "contain empty option"},
+	} {
+		tmpfs, err := ParseTmpfs(tc.input)
+		if tc.err != "" {
+			assert.Error(t, err, "%v", tc.input)
+			assert.Contains(t, err.Error(), tc.err, "%v", tc.input)
+			continue
+		}
+		assert.NoError(t, err, "%v", tc.input)
+		assert.Equal(t, tc.expected, tmpfs, "%v", tc.input)
+	}
+}
+
+func TestValidateTmpfs(t *testing.T) {
+	assert.NoError(t, ValidateTmpfs(nil))
+	assert.NoError(t, ValidateTmpfs(map[string]string{"/run": "size=64m"}))
+	assert.Error(t, ValidateTmpfs(map[string]string{"run": ""}))
+	assert.Error(t, ValidateTmpfs(map[string]string{"/run": ","}))
+}
@@ -0,0 +1,57 @@
+package opts
+
+import (
+	"fmt"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	units "github.com/docker/go-units"
+)
+
+// ValidateUlimits validates the ulimits are known by kernel, such as nofile,
+// nproc and memlock, and the soft limit is not larger than the hard limit.
+func ValidateUlimits(ulimits []*types.Ulimit) error {
+	names := make(map[string]bool, len(ulimits))
+	for _, ul := range ulimits {
+		if ul == nil {
+			continue
+		}
+
+		if _, err := (&units.Ulimit{Name: ul.Name}).GetRlimit(); err != nil {
+			return fmt.Errorf("invalid ulimit type: %s", ul.Name)
+		}
+		if names[ul.Name] {
+			return fmt.Errorf("ulimit %s is set more than once", ul.Name)
+		}
+		names[ul.Name] = true
+
+		if ul.Soft > ul.Hard {
+			return fmt.Errorf("ulimit soft limit must be less than or equal to hard limit of %s: %d > %d", ul.Name, ul.Soft, ul.Hard)
+		}
+	}
+	return nil
+}
+
+// MergeUlimits merges the default ulimits into ulimits, the one in ulimits
+// wins if both of them set the same kind of limit.
+func MergeUlimits(defaults, ulimits []*types.Ulimit) []*types.Ulimit {
+	if len(defaults) == 0 {
+		return ulimits
+	}
+
+	names := make(map[string]bool, len(ulimits))
+	for _, ul := range ulimits {
+		if ul != nil {
+			names[ul.Name] = true
+		}
+	}
+
+	merged := append([]*types.Ulimit{}, ulimits...)
+	for _, ul := range defaults {
+		if ul == nil || names[ul.Name] {
+			continue
+		}
+		merged = append(merged, &types.Ulimit{Name: ul.Name, Soft: ul.Soft, Hard: ul.Hard})
+	}
+	return merged
+}
@@ -0,0 +1,51 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUlimits(t *testing.T) {
+	for _, tc := range []struct {
+		ulimits []*types.Ulimit
+		err     string
+	}{
+		{ulimits: nil},
+		{ulimits: []*types.Ulimit{{Name: "nofile", Soft: 1024, Hard: 2048}, {Name: "memlock", Soft: -1, Hard: -1}}},
+		{ulimits: []*types.Ulimit{{Name: "nproc", Soft: 10, Hard: 10}, {Name: "core", Soft: 0, Hard: 0}}},
+		{ulimits: []*types.Ulimit{{Name: "foo", Soft: 1, Hard: 1}}, err: "invalid ulimit type: foo"},
+		{ulimits: []*types.Ulimit{{Name: "nofile", Soft: 2048, Hard: 1024}}, err: "soft limit must be less than or equal to hard limit"},
+		{ulimits: []*types.Ulimit{{Name: "nofile", Soft: 1, Hard: 1}, {Name: "nofile", Soft: 2, Hard: 2}}, err: "more than once"},
+	} {
+		err := ValidateUlimits(tc.ulimits)
+		if tc.err == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), tc.err)
+	}
+}
+
+func TestMergeUlimits(t *testing.T) {
+	defaults := []*types.Ulimit{
+		{Name: "nofile", Soft: 1024, Hard: 1024},
+		{Name: "memlock", Soft: 64, Hard: 64},
+	}
+
+	assert.Equal(t, []*types.Ulimit(nil), MergeUlimits(nil, nil))
+	assert.Equal(t, defaults, MergeUlimits(defaults, nil))
+
+	merged := MergeUlimits(defaults, []*types.Ulimit{{Name: "nofile", Soft: 65535, Hard: 65535}})
+	assert.Equal(t, []*types.Ulimit{
+		{Name: "nofile", Soft: 65535, Hard: 65535},
+		{Name: "memlock", Soft: 64, Hard: 64},
+	}, merged)
+
+	// the defaults are not shared with merged ulimits
+	merged[1].Soft = 0
+	assert.Equal(t, int64(64), defaults[1].Soft)
+}
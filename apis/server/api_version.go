@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/alibaba/pouch/pkg/httputils"
+	"github.com/alibaba/pouch/version"
+)
+
+// apiVersionHeader is the header of response which tells client the latest
+// api version daemon serves, so that client can negotiate with it.
+const apiVersionHeader = "API-Version"
+
+// apiVersionKey is the key of api version requested by client in context.
+type apiVersionKey struct{}
+
+// withAPIVersion stores the api version requested by client in context.
+func withAPIVersion(ctx context.Context, v string) context.Context {
+	return context.WithValue(ctx, apiVersionKey{}, v)
+}
+
+// apiVersion returns the api version requested by client, which is the latest
+// one if the version isn't in the request path.
+func apiVersion(ctx context.Context) string {
+	if v, ok := ctx.Value(apiVersionKey{}).(string); ok && v != "" {
+		return v
+	}
+	return version.APIVersion
+}
+
+// checkAPIVersion returns the bad request error if the api version requested
+// by client isn't served by daemon.
+func checkAPIVersion(v string) error {
+	if version.APIVersionLessThan(v, version.MinAPIVersion) {
+		return httputils.NewHTTPError(fmt.Errorf("client version %s is too old, minimum supported API version is %s", v, version.MinAPIVersion), http.StatusBadRequest)
+	}
+	if version.APIVersionLessThan(version.APIVersion, v) {
+		return httputils.NewHTTPError(fmt.Errorf("client version %s is too new, maximum supported API version is %s", v, version.APIVersion), http.StatusBadRequest)
+	}
+	return nil
+}
+
+// addedFields is the json fields added to a response in each api version,
+// keyed by the version.
+type addedFields map[string][]string
+
+var (
+	// systemInfoFields is the fields added to the response of /info.
+	systemInfoFields = addedFields{
+		"1.25": {"ContainerdAddress", "Warnings"},
+	}
+
+	// systemVersionFields is the fields added to the response of /version.
+	systemVersionFields = addedFields{
+		"1.25": {"MinAPIVersion"},
+	}
+)
+
+// encodeVersionedResponse encodes the response in json like EncodeResponse,
+// but the fields added after the api version requested by client are omitted,
+// so that the old client doesn't get the zero values of unknown fields.
+func encodeVersionedResponse(ctx context.Context, rw http.ResponseWriter, statusCode int, data interface{}, fields addedFields) error {
+	var omitted []string
+	for v, names := range fields {
+		if version.APIVersionLessThan(apiVersion(ctx), v) {
+			omitted = append(omitted, names...)
+		}
+	}
+	if len(omitted) == 0 {
+		return EncodeResponse(rw, statusCode, data)
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return err
+	}
+	for _, name := range omitted {
+		delete(obj, name)
+	}
+	return EncodeResponse(rw, statusCode, obj)
+}
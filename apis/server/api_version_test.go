@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/version"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterAPIVersion(t *testing.T) {
+	var got string
+	handler := func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		got = apiVersion(ctx)
+		return nil
+	}
+
+	r := mux.NewRouter()
+	r.Path(versionMatcher + "/info").Handler(filter(handler, &Server{}))
+	r.Path("/info").Handler(filter(handler, &Server{}))
+
+	for _, tc := range []struct {
+		path     string
+		code     int
+		expected string
+	}{
+		{path: "/info", code: http.StatusOK, expected: version.APIVersion},
+		{path: "/v" + version.MinAPIVersion + "/info", code: http.StatusOK, expected: version.MinAPIVersion},
+		{path: "/v1.12/info", code: http.StatusBadRequest},
+		{path: "/v99.0/info", code: http.StatusBadRequest},
+	} {
+		got = ""
+		rw := httptest.NewRecorder()
+		r.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+		assert.Equal(t, tc.code, rw.Code, tc.path)
+		assert.Equal(t, version.APIVersion, rw.Header().Get(apiVersionHeader), tc.path)
+		assert.Equal(t, tc.expected, got, tc.path)
+		if tc.code == http.StatusBadRequest {
+			assert.Contains(t, rw.Body.String(), `"message":"client version`)
+		}
+	}
+}
+
+func TestEncodeVersionedResponse(t *testing.T) {
+	info := types.SystemInfo{ContainerdAddress: "/run/containerd.sock", ID: "abc"}
+
+	rw := httptest.NewRecorder()
+	assert.NoError(t, encodeVersionedResponse(context.Background(), rw, http.StatusOK, info, systemInfoFields))
+	assert.Contains(t, rw.Body.String(), `"ContainerdAddress"`)
+	assert.Contains(t, rw.Body.String(), `"Warnings"`)
+
+	// the fields added in 1.25 are omitted rather than zero-filled.
+	rw = httptest.NewRecorder()
+	ctx := withAPIVersion(context.Background(), "1.24")
+	assert.NoError(t, encodeVersionedResponse(ctx, rw, http.StatusOK, info, systemInfoFields))
+	assert.NotContains(t, rw.Body.String(), `"ContainerdAddress"`)
+	assert.NotContains(t, rw.Body.String(), `"Warnings"`)
+	assert.Contains(t, rw.Body.String(), `"ID":"abc"`)
+}
@@ -2,23 +2,29 @@ package server
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	apifilters "github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/metrics"
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/daemon/mgr"
 	"github.com/alibaba/pouch/pkg/httputils"
 	"github.com/alibaba/pouch/pkg/streams"
+	"github.com/alibaba/pouch/pkg/term"
 	"github.com/alibaba/pouch/pkg/utils"
 	"github.com/alibaba/pouch/pkg/utils/filters"
 	util_metrics "github.com/alibaba/pouch/pkg/utils/metrics"
 
+	"github.com/docker/docker/pkg/signal"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/go-openapi/strfmt"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -94,6 +100,11 @@ func (s *Server) getContainer(ctx context.Context, rw http.ResponseWriter, req *
 		hostRootPath = mergedDir
 	}
 
+	capabilities, err := c.EffectiveCapabilities()
+	if err != nil {
+		logrus.Warnf("failed to get effective capabilities of container %s: %v", c.ID, err)
+	}
+
 	container := types.ContainerJSON{
 		ID:           c.ID,
 		Name:         c.Name,
@@ -120,7 +131,11 @@ func (s *Server) getContainer(ctx context.Context, rw http.ResponseWriter, req *
 		Driver:          c.Driver,
 		MountLabel:      c.MountLabel,
 		ProcessLabel:    c.ProcessLabel,
+		AppArmorProfile: c.AppArmorProfile,
+		SeccompProfile:  c.SeccompProfile,
 		ExecIds:         c.ExecIds,
+
+		EffectiveCapabilities: capabilities,
 	}
 
 	return EncodeResponse(rw, http.StatusOK, container)
@@ -131,6 +146,14 @@ func (s *Server) getContainers(ctx context.Context, rw http.ResponseWriter, req
 		All: httputils.BoolValue(req, "all"),
 	}
 
+	if v := req.FormValue("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return httputils.NewHTTPError(fmt.Errorf("invalid limit %q: %v", v, err), http.StatusBadRequest)
+		}
+		option.Limit = limit
+	}
+
 	filters, err := filters.FromURLParam(req.FormValue("filters"))
 	if err != nil {
 		return err
@@ -159,6 +182,7 @@ func (s *Server) getContainers(ctx context.Context, rw http.ResponseWriter, req
 		if c.NetworkSettings != nil {
 			netSettings = &types.ContainerNetworkSettings{
 				Networks: c.NetworkSettings.Networks,
+				Ports:    c.NetworkSettings.Ports,
 			}
 		}
 
@@ -168,6 +192,7 @@ func (s *Server) getContainers(ctx context.Context, rw http.ResponseWriter, req
 			Image:           c.Config.Image,
 			ImageID:         c.Image,
 			Command:         strings.Join(c.Config.Cmd, " "),
+			State:           string(c.State.Status),
 			Status:          status,
 			Created:         t.UnixNano(),
 			Labels:          c.Config.Labels,
@@ -264,6 +289,26 @@ func (s *Server) stopContainer(ctx context.Context, rw http.ResponseWriter, req
 	return nil
 }
 
+func (s *Server) killContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	sig := syscall.SIGKILL
+	if v := req.FormValue("signal"); v != "" {
+		parsed, err := signal.ParseSignal(v)
+		if err != nil {
+			return httputils.NewHTTPError(err, http.StatusBadRequest)
+		}
+		sig = parsed
+	}
+
+	name := mux.Vars(req)["name"]
+
+	if err := s.ContainerMgr.Kill(ctx, name, sig); err != nil {
+		return err
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 func (s *Server) pauseContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	name := mux.Vars(req)["name"]
 
@@ -310,8 +355,21 @@ func (s *Server) attachContainer(ctx context.Context, rw http.ResponseWriter, re
 	name := mux.Vars(req)["name"]
 	_, upgrade := req.Header["Upgrade"]
 
+	c, err := s.ContainerMgr.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	keys := req.FormValue("detachKeys")
+	if keys == "" {
+		keys = term.DefaultDetachKeys
+	}
+	detachKeys, err := term.ToDetachKeys(keys)
+	if err != nil {
+		return httputils.NewHTTPError(err, http.StatusBadRequest)
+	}
+
 	var (
-		err     error
 		closeFn func() error
 		attach  = new(streams.AttachConfig)
 		stdin   io.ReadCloser
@@ -334,13 +392,20 @@ func (s *Server) attachContainer(ctx context.Context, rw http.ResponseWriter, re
 
 	attach.UseStdin = httputils.BoolValue(req, "stdin")
 	attach.Stdin = stdin
+	attach.DetachKeys = detachKeys
 	attach.UseStdout = true
-	attach.Stdout = stdout
 	attach.UseStderr = true
-	attach.Stderr = stdout
 
-	if err := s.ContainerMgr.AttachContainerIO(ctx, name, attach); err != nil {
-		stdout.Write([]byte(err.Error() + "\r\n"))
+	// the stdout and stderr are multiplexed if the container has no tty.
+	if c.Config.Tty {
+		attach.Stdout, attach.Stderr = stdout, stdout
+	} else {
+		attach.Stdout = stdcopy.NewStdWriter(stdout, stdcopy.Stdout)
+		attach.Stderr = stdcopy.NewStdWriter(stdout, stdcopy.Stderr)
+	}
+
+	if err := s.ContainerMgr.AttachContainerIO(ctx, c.ID, attach); err != nil {
+		attach.Stderr.Write([]byte(err.Error() + "\r\n"))
 	}
 	return nil
 }
@@ -426,6 +491,33 @@ func (s *Server) topContainer(ctx context.Context, rw http.ResponseWriter, req *
 	return EncodeResponse(rw, http.StatusOK, procList)
 }
 
+func (s *Server) getContainerChanges(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	name := mux.Vars(req)["name"]
+
+	changes, err := s.ContainerMgr.Changes(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return EncodeResponse(rw, http.StatusOK, changes)
+}
+
+func (s *Server) exportContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	name := mux.Vars(req)["name"]
+
+	r, err := s.ContainerMgr.Export(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	rw.Header().Set("Content-Type", "application/x-tar")
+
+	output := newWriteFlusher(rw)
+	_, err = io.Copy(output, r)
+	return err
+}
+
 func (s *Server) logsContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	opts := &types.ContainerLogsOptions{
 		ShowStdout: httputils.BoolValue(req, "stdout"),
@@ -517,9 +609,39 @@ func (s *Server) removeContainers(ctx context.Context, rw http.ResponseWriter, r
 	return nil
 }
 
+func (s *Server) pruneContainers(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	filter, err := apifilters.FromParam(req.FormValue("filters"))
+	if err != nil {
+		return err
+	}
+
+	label := util_metrics.ActionPruneLabel
+	defer func(start time.Time) {
+		metrics.ContainerActionsCounter.WithLabelValues(label).Inc()
+		metrics.ContainerActionsTimer.WithLabelValues(label).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	resp, err := s.ContainerMgr.Prune(ctx, filter)
+	if err != nil {
+		logrus.Errorf("failed to prune containers: %v", err)
+		return err
+	}
+
+	metrics.ContainerSuccessActionsCounter.WithLabelValues(label).Inc()
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
+
 func (s *Server) waitContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	name := mux.Vars(req)["name"]
 
+	switch condition := req.FormValue("condition"); condition {
+	case "", "not-running":
+	case "next-exit":
+		return s.waitContainerNextExit(ctx, rw, name)
+	default:
+		return httputils.NewHTTPError(fmt.Errorf("invalid condition %q", condition), http.StatusBadRequest)
+	}
+
 	waitStatus, err := s.ContainerMgr.Wait(ctx, name)
 
 	if err != nil {
@@ -529,6 +651,28 @@ func (s *Server) waitContainer(ctx context.Context, rw http.ResponseWriter, req
 	return EncodeResponse(rw, http.StatusOK, &waitStatus)
 }
 
+// waitContainerNextExit sends the response header once the daemon starts
+// waiting, and the exit status is sent after the container exits.
+func (s *Server) waitContainerNextExit(ctx context.Context, rw http.ResponseWriter, name string) error {
+	statusCh, err := s.ContainerMgr.WaitNextExit(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	if f, ok := rw.(flusher); ok {
+		f.Flush()
+	}
+
+	waitStatus, ok := <-statusCh
+	if !ok {
+		// the client is gone.
+		return nil
+	}
+	return json.NewEncoder(rw).Encode(&waitStatus)
+}
+
 func (s *Server) createContainerCheckpoint(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	name := mux.Vars(req)["name"]
 
@@ -587,11 +731,18 @@ func (s *Server) deleteContainerCheckpoint(ctx context.Context, rw http.Response
 }
 
 func (s *Server) commitContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	if err := req.ParseForm(); err != nil {
+		return httputils.NewHTTPError(err, http.StatusBadRequest)
+	}
+
 	options := &types.ContainerCommitOptions{
 		Repository: req.FormValue("repo"),
 		Tag:        req.FormValue("tag"),
 		Author:     req.FormValue("author"),
 		Comment:    req.FormValue("comment"),
+		Changes:    req.Form["changes"],
+		// like docker, the running container is paused by default.
+		Pause: req.FormValue("pause") == "" || httputils.BoolValue(req, "pause"),
 	}
 
 	id, err := s.ContainerMgr.Commit(ctx, req.FormValue("container"), options)
@@ -601,3 +752,76 @@ func (s *Server) commitContainer(ctx context.Context, rw http.ResponseWriter, re
 
 	return EncodeResponse(rw, http.StatusCreated, id)
 }
+
+// containerPathStatHeader is the header of response which contains the
+// information of path in container, it is same as docker.
+const containerPathStatHeader = "X-Docker-Container-Path-Stat"
+
+// setContainerPathStatHeader encodes the stat into the header of response.
+func setContainerPathStatHeader(header http.Header, stat *types.ContainerPathStat) error {
+	data, err := json.Marshal(stat)
+	if err != nil {
+		return err
+	}
+
+	header.Set(containerPathStatHeader, base64.StdEncoding.EncodeToString(data))
+	return nil
+}
+
+func (s *Server) headContainerArchive(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	name := mux.Vars(req)["name"]
+	path := req.FormValue("path")
+	if path == "" {
+		return httputils.NewHTTPError(fmt.Errorf("path should not be empty"), http.StatusBadRequest)
+	}
+
+	stat, err := s.ContainerMgr.StatPath(ctx, name, path)
+	if err != nil {
+		return err
+	}
+
+	if err := setContainerPathStatHeader(rw.Header(), stat); err != nil {
+		return err
+	}
+	rw.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Server) getContainerArchive(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	name := mux.Vars(req)["name"]
+	path := req.FormValue("path")
+	if path == "" {
+		return httputils.NewHTTPError(fmt.Errorf("path should not be empty"), http.StatusBadRequest)
+	}
+
+	r, stat, err := s.ContainerMgr.ArchivePath(ctx, name, path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := setContainerPathStatHeader(rw.Header(), stat); err != nil {
+		return err
+	}
+	rw.Header().Set("Content-Type", "application/x-tar")
+
+	output := newWriteFlusher(rw)
+	_, err = io.Copy(output, r)
+	return err
+}
+
+func (s *Server) putContainerArchive(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	name := mux.Vars(req)["name"]
+	path := req.FormValue("path")
+	if path == "" {
+		return httputils.NewHTTPError(fmt.Errorf("path should not be empty"), http.StatusBadRequest)
+	}
+
+	noOverwriteDirNonDir := httputils.BoolValue(req, "noOverwriteDirNonDir")
+	if err := s.ContainerMgr.ExtractToDir(ctx, name, path, noOverwriteDirNonDir, req.Body); err != nil {
+		return err
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	return nil
+}
@@ -7,16 +7,20 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/metrics"
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
 	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/httputils"
 	util_metrics "github.com/alibaba/pouch/pkg/utils/metrics"
 
+	"github.com/containerd/containerd/platforms"
 	"github.com/gorilla/mux"
 	"github.com/opencontainers/go-digest"
 	"github.com/sirupsen/logrus"
@@ -36,6 +40,21 @@ func (s *Server) pullImage(ctx context.Context, rw http.ResponseWriter, req *htt
 		image = image + ":" + tag
 	}
 
+	platform := req.FormValue("platform")
+	if platform != "" {
+		if _, err := platforms.Parse(platform); err != nil {
+			return httputils.NewHTTPError(fmt.Errorf("invalid platform %s: %v", platform, err), http.StatusBadRequest)
+		}
+	}
+
+	if v := req.FormValue("maxConcurrentDownloads"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return httputils.NewHTTPError(fmt.Errorf("invalid maxConcurrentDownloads %s: should be positive number", v), http.StatusBadRequest)
+		}
+		ctx = ctrd.WithPullMaxConcurrentDownloads(ctx, n)
+	}
+
 	label := util_metrics.ActionPullLabel
 
 	// record the time spent during image pull procedure.
@@ -55,7 +74,7 @@ func (s *Server) pullImage(ctx context.Context, rw http.ResponseWriter, req *htt
 		}
 	}
 	// Error information has be sent to client, so no need call resp.Write
-	if err := s.ImageMgr.PullImage(ctx, image, &authConfig, newWriteFlusher(rw)); err != nil {
+	if err := s.ImageMgr.PullImage(ctx, image, platform, &authConfig, newWriteFlusher(rw)); err != nil {
 		logrus.Errorf("failed to pull image %s: %v", image, err)
 		return nil
 	}
@@ -89,11 +108,30 @@ func (s *Server) listImages(ctx context.Context, rw http.ResponseWriter, req *ht
 	return EncodeResponse(rw, http.StatusOK, imageList)
 }
 
+// searchImages searches the images from registry.
 func (s *Server) searchImages(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	searchPattern := req.FormValue("term")
 	registry := req.FormValue("registry")
 
-	searchResultItem, err := s.ImageMgr.SearchImages(ctx, searchPattern, registry)
+	var limit int
+	if v := req.FormValue("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			return httputils.NewHTTPError(fmt.Errorf("invalid limit %q: %v", v, err), http.StatusBadRequest)
+		}
+	}
+
+	// get registry auth from Request header
+	authStr := req.Header.Get("X-Registry-Auth")
+	authConfig := types.AuthConfig{}
+	if authStr != "" {
+		data := base64.NewDecoder(base64.URLEncoding, strings.NewReader(authStr))
+		if err := json.NewDecoder(data).Decode(&authConfig); err != nil {
+			return err
+		}
+	}
+
+	searchResultItem, err := s.ImageMgr.SearchImages(ctx, searchPattern, registry, limit, &authConfig)
 	if err != nil {
 		logrus.Errorf("failed to search images from registry: %v", err)
 		return err
@@ -101,6 +139,39 @@ func (s *Server) searchImages(ctx context.Context, rw http.ResponseWriter, req *
 	return EncodeResponse(rw, http.StatusOK, searchResultItem)
 }
 
+// pruneImages deletes the images which are not used by any container.
+func (s *Server) pruneImages(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	filter, err := filters.FromParam(req.FormValue("filters"))
+	if err != nil {
+		return err
+	}
+
+	label := util_metrics.ActionPruneLabel
+	defer func(start time.Time) {
+		metrics.ImageActionsCounter.WithLabelValues(label).Inc()
+		metrics.ImageActionsTimer.WithLabelValues(label).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	containers, err := s.ContainerMgr.List(ctx, &mgr.ContainerListOption{All: true})
+	if err != nil {
+		return err
+	}
+
+	usedImages := make(map[string]struct{}, len(containers))
+	for _, c := range containers {
+		usedImages[c.Image] = struct{}{}
+	}
+
+	resp, err := s.ImageMgr.PruneImages(ctx, filter, usedImages)
+	if err != nil {
+		logrus.Errorf("failed to prune images: %v", err)
+		return err
+	}
+
+	metrics.ImageSuccessActionsCounter.WithLabelValues(label).Inc()
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
+
 // removeImage deletes an image by reference.
 func (s *Server) removeImage(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	name := mux.Vars(req)["name"]
@@ -134,17 +205,21 @@ func (s *Server) removeImage(ctx context.Context, rw http.ResponseWriter, req *h
 		}
 
 		if !isForce && len(containers) > 0 {
-			return fmt.Errorf("Unable to remove the image %q (must force) - container (%s, %s) is using this image", image.ID, containers[0].ID, containers[0].Name)
+			using := make([]string, 0, len(containers))
+			for _, c := range containers {
+				using = append(using, fmt.Sprintf("(%s, %s)", c.ID, c.Name))
+			}
+			return fmt.Errorf("Unable to remove the image %q (must force) - container %s is using this image", image.ID, strings.Join(using, ", "))
 		}
 	}
 
-	if err := s.ImageMgr.RemoveImage(ctx, name, isForce); err != nil {
+	items, err := s.ImageMgr.RemoveImage(ctx, name, isForce)
+	if err != nil {
 		return err
 	}
 
 	metrics.ImageSuccessActionsCounter.WithLabelValues(label).Inc()
-	rw.WriteHeader(http.StatusNoContent)
-	return nil
+	return EncodeResponse(rw, http.StatusOK, items)
 }
 
 // postImageTag adds tag for the existing image.
@@ -167,35 +242,63 @@ func (s *Server) postImageTag(ctx context.Context, rw http.ResponseWriter, req *
 // loadImage loads an image by http tar stream.
 func (s *Server) loadImage(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	imageName := req.FormValue("name")
-	if imageName == "" {
-		imageName = "unknown/unknown"
-	}
 
-	if err := s.ImageMgr.LoadImage(ctx, imageName, req.Body); err != nil {
-		return err
+	// NOTE: the progress is sent during reading the tar stream only if the
+	// http server supports full duplex, otherwise it's held until the tar
+	// stream has been read, since the unread request body will be closed
+	// once the response is written.
+	body, output, release := newBodyHoldWriter(req.Body, rw)
+	defer release()
+
+	if err := s.ImageMgr.LoadImage(ctx, imageName, body, output); err != nil {
+		// the invalid parameter is reported before the progress, and the
+		// other error information has been sent to client through stream.
+		if errtypes.IsInvalidParam(err) {
+			return err
+		}
+		logrus.Errorf("failed to load image: %v", err)
 	}
-
-	rw.WriteHeader(http.StatusOK)
 	return nil
 }
 
 // saveImage saves an image by http tar stream.
 func (s *Server) saveImage(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
-	imageName := req.FormValue("name")
-
-	rw.Header().Set("Content-Type", "application/x-tar")
+	if err := req.ParseForm(); err != nil {
+		return err
+	}
 
-	r, err := s.ImageMgr.SaveImage(ctx, imageName)
+	r, err := s.ImageMgr.SaveImage(ctx, req.Form["name"])
 	if err != nil {
 		return err
 	}
 	defer r.Close()
 
+	rw.Header().Set("Content-Type", "application/x-tar")
+
 	output := newWriteFlusher(rw)
 	_, err = io.Copy(output, r)
 	return err
 }
 
+// importImage creates an image from the tar archive of rootfs.
+func (s *Server) importImage(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	if err := req.ParseForm(); err != nil {
+		return httputils.NewHTTPError(err, http.StatusBadRequest)
+	}
+
+	ref := req.FormValue("repo")
+	if tag := req.FormValue("tag"); tag != "" {
+		ref = ref + ":" + tag
+	}
+
+	imageInfo, err := s.ImageMgr.ImportImage(ctx, ref, req.FormValue("message"), req.Form["changes"], req.Body)
+	if err != nil {
+		return err
+	}
+
+	return EncodeResponse(rw, http.StatusOK, imageInfo)
+}
+
 // getImageHistory gets image history.
 func (s *Server) getImageHistory(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	imageName := mux.Vars(req)["name"]
@@ -230,3 +333,25 @@ func (s *Server) pushImage(ctx context.Context, rw http.ResponseWriter, req *htt
 
 	return nil
 }
+
+// listRemoteTags lists all the tags of repository from a specified registry.
+func (s *Server) listRemoteTags(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	name := mux.Vars(req)["name"]
+
+	// get registry auth from Request header
+	authStr := req.Header.Get("X-Registry-Auth")
+	authConfig := types.AuthConfig{}
+	if authStr != "" {
+		data := base64.NewDecoder(base64.URLEncoding, strings.NewReader(authStr))
+		if err := json.NewDecoder(data).Decode(&authConfig); err != nil {
+			return err
+		}
+	}
+
+	tags, err := s.ImageMgr.ListRemoteTags(ctx, name, &authConfig)
+	if err != nil {
+		logrus.Errorf("failed to list tags of %s: %v", name, err)
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, tags)
+}
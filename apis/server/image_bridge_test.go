@@ -13,17 +13,18 @@ import (
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
 	"github.com/alibaba/pouch/daemon/mgr"
 	"github.com/stretchr/testify/assert"
 )
 
 type mockImgePull struct {
 	mgr.ImageMgr
-	handler func(ctx context.Context, imageRef string, authConfig *types.AuthConfig, out io.Writer) error
+	handler func(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error
 }
 
-func (m *mockImgePull) PullImage(ctx context.Context, imageRef string, authConfig *types.AuthConfig, out io.Writer) error {
-	return m.handler(ctx, imageRef, authConfig, out)
+func (m *mockImgePull) PullImage(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error {
+	return m.handler(ctx, imageRef, platform, authConfig, out)
 }
 
 func Test_pullImage_without_tag(t *testing.T) {
@@ -31,7 +32,7 @@ func Test_pullImage_without_tag(t *testing.T) {
 
 	s.ImageMgr = &mockImgePull{
 		ImageMgr: &mgr.ImageManager{},
-		handler: func(ctx context.Context, imageRef string, authConfig *types.AuthConfig, out io.Writer) error {
+		handler: func(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error {
 			assert.Equal(t, "reg.abc.com/base/os:7.2", imageRef)
 			return nil
 		},
@@ -43,6 +44,69 @@ func Test_pullImage_without_tag(t *testing.T) {
 	s.pullImage(context.Background(), nil, req)
 }
 
+func Test_pullImage_with_platform(t *testing.T) {
+	var s Server
+
+	called := false
+	s.ImageMgr = &mockImgePull{
+		ImageMgr: &mgr.ImageManager{},
+		handler: func(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error {
+			called = true
+			assert.Equal(t, "linux/arm64", platform)
+			return nil
+		},
+	}
+	req := &http.Request{
+		Form:   map[string][]string{"fromImage": {"reg.abc.com/base/os:7.2"}, "platform": {"linux/arm64"}},
+		Header: map[string][]string{},
+	}
+	assert.NoError(t, s.pullImage(context.Background(), nil, req))
+	assert.True(t, called)
+
+	// invalid platform should be refused before pull
+	called = false
+	req = &http.Request{
+		Form:   map[string][]string{"fromImage": {"reg.abc.com/base/os:7.2"}, "platform": {"linux/arm64/v8/extra"}},
+		Header: map[string][]string{},
+	}
+	err := s.pullImage(context.Background(), nil, req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid platform")
+	assert.False(t, called)
+}
+
+func Test_pullImage_with_max_concurrent_downloads(t *testing.T) {
+	var s Server
+
+	called := false
+	s.ImageMgr = &mockImgePull{
+		ImageMgr: &mgr.ImageManager{},
+		handler: func(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error {
+			called = true
+			assert.Equal(t, 1, ctrd.GetPullMaxConcurrentDownloads(ctx))
+			return nil
+		},
+	}
+	req := &http.Request{
+		Form:   map[string][]string{"fromImage": {"reg.abc.com/base/os:7.2"}, "maxConcurrentDownloads": {"1"}},
+		Header: map[string][]string{},
+	}
+	assert.NoError(t, s.pullImage(context.Background(), nil, req))
+	assert.True(t, called)
+
+	for _, v := range []string{"0", "-1", "abc"} {
+		called = false
+		req = &http.Request{
+			Form:   map[string][]string{"fromImage": {"reg.abc.com/base/os:7.2"}, "maxConcurrentDownloads": {v}},
+			Header: map[string][]string{},
+		}
+		err := s.pullImage(context.Background(), nil, req)
+		assert.Error(t, err, v)
+		assert.Contains(t, err.Error(), "invalid maxConcurrentDownloads", v)
+		assert.False(t, called, v)
+	}
+}
+
 func Test_pullImage_counter(t *testing.T) {
 	var s Server
 	ctx := context.Background()
@@ -55,7 +119,7 @@ func Test_pullImage_counter(t *testing.T) {
 	go func() {
 		s.ImageMgr = &mockImgePull{
 			ImageMgr: &mgr.ImageManager{},
-			handler: func(ctx context.Context, imageRef string, authConfig *types.AuthConfig, out io.Writer) error {
+			handler: func(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error {
 				assert.Equal(t, "reg.abc.com/base/os:7.2", imageRef)
 				time.Sleep(2 * time.Second)
 				return nil
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alibaba/pouch/apis/metrics"
+	util_metrics "github.com/alibaba/pouch/pkg/utils/metrics"
+
+	"github.com/gorilla/mux"
+)
+
+// newMetricsRouter returns the handler of metrics address, which only serves
+// the metrics, so that the API isn't exposed to the monitoring systems.
+func newMetricsRouter() http.Handler {
+	r := mux.NewRouter()
+	r.Path("/metrics").Methods(http.MethodGet).Handler(withMetrics("/metrics", util_metrics.GetPrometheusHandler()))
+	r.NotFoundHandler = errorHandler(http.StatusNotFound, "page not found")
+	r.MethodNotAllowedHandler = errorHandler(http.StatusMethodNotAllowed, "method not allowed")
+	return r
+}
+
+// withMetrics records the number and latency of requests to the route, which
+// is the path template of handler, so that the requests to the different
+// objects are counted in the same series.
+func withMetrics(route string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		rw := &statusRecorder{ResponseWriter: w}
+		h.ServeHTTP(rw, req)
+
+		code := strconv.Itoa(rw.statusCode())
+		metrics.APIRequestsCounter.WithLabelValues(req.Method, route, code).Inc()
+		metrics.APIRequestDuration.WithLabelValues(req.Method, route, code).Observe(time.Since(start).Seconds())
+	})
+}
+
+// statusRecorder records the status code of response. It implements the
+// optional interfaces of http.ResponseWriter used by the handlers, such as
+// http.Hijacker for attach and http.Flusher for the streaming responses.
+type statusRecorder struct {
+	http.ResponseWriter
+
+	code     int
+	hijacked bool
+}
+
+// statusCode returns the status code of response. The status of hijacked
+// connection is written by handler directly, so it is recorded as 101.
+func (rw *statusRecorder) statusCode() int {
+	switch {
+	case rw.code != 0:
+		return rw.code
+	case rw.hijacked:
+		return http.StatusSwitchingProtocols
+	default:
+		return http.StatusOK
+	}
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (rw *statusRecorder) WriteHeader(code int) {
+	if rw.code == 0 {
+		rw.code = code
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+// Write implements http.ResponseWriter.
+func (rw *statusRecorder) Write(p []byte) (int, error) {
+	if rw.code == 0 {
+		rw.code = http.StatusOK
+	}
+	return rw.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher.
+func (rw *statusRecorder) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// CloseNotify implements http.CloseNotifier.
+func (rw *statusRecorder) CloseNotify() <-chan bool {
+	if n, ok := rw.ResponseWriter.(http.CloseNotifier); ok {
+		return n.CloseNotify()
+	}
+	// never notified if the underlying writer doesn't support it.
+	return make(chan bool)
+}
+
+// EnableFullDuplex lets the response be written while reading request body
+// if the underlying writer supports it.
+func (rw *statusRecorder) EnableFullDuplex() error {
+	if fd, ok := rw.ResponseWriter.(fullDuplexer); ok {
+		return fd.EnableFullDuplex()
+	}
+	return fmt.Errorf("full duplex is not supported")
+}
+
+// Hijack implements http.Hijacker.
+func (rw *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("not a hijack connection")
+	}
+	rw.hijacked = true
+	return h.Hijack()
+}
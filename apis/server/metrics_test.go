@@ -0,0 +1,132 @@
+package server
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	util_metrics "github.com/alibaba/pouch/pkg/utils/metrics"
+
+	"github.com/gorilla/mux"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+// scrape returns the metric families served by the metrics handler.
+func scrape(t *testing.T) map[string]*dto.MetricFamily {
+	rw := httptest.NewRecorder()
+	util_metrics.GetPrometheusHandler().ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	families, err := new(expfmt.TextParser).TextToMetricFamilies(rw.Body)
+	assert.NoError(t, err)
+	return families
+}
+
+// findMetric returns the metric of family with the labels.
+func findMetric(family *dto.MetricFamily, labels map[string]string) *dto.Metric {
+	for _, m := range family.GetMetric() {
+		matched := 0
+		for _, l := range m.GetLabel() {
+			if v, ok := labels[l.GetName()]; ok && v == l.GetValue() {
+				matched++
+			}
+		}
+		if matched == len(labels) {
+			return m
+		}
+	}
+	return nil
+}
+
+func TestWithMetrics(t *testing.T) {
+	route := "/test-metrics/{name:.*}/json"
+	r := mux.NewRouter()
+	r.Path(route).Handler(withMetrics(route, errorHandler(http.StatusNotFound, "not found")))
+
+	// the requests to different objects are counted in the same series.
+	for _, name := range []string{"foo", "bar"} {
+		rw := httptest.NewRecorder()
+		r.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/test-metrics/"+name+"/json", nil))
+		assert.Equal(t, http.StatusNotFound, rw.Code)
+	}
+
+	families := scrape(t)
+	for _, name := range []string{
+		"pouch_api_requests_total",
+		"pouch_api_request_duration_seconds",
+		"pouch_image_pull_duration_seconds",
+		"pouch_image_pull_bytes_total",
+	} {
+		assert.Contains(t, families, name)
+	}
+
+	labels := map[string]string{"method": "GET", "route": route, "code": "404"}
+	requests := findMetric(families["pouch_api_requests_total"], labels)
+	if assert.NotNil(t, requests) {
+		assert.Equal(t, float64(2), requests.GetCounter().GetValue())
+	}
+	duration := findMetric(families["pouch_api_request_duration_seconds"], labels)
+	if assert.NotNil(t, duration) {
+		assert.Equal(t, uint64(2), duration.GetHistogram().GetSampleCount())
+	}
+}
+
+func TestStatusRecorder(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		handler http.HandlerFunc
+		code    int
+	}{
+		{
+			name:    "nothing written",
+			handler: func(w http.ResponseWriter, req *http.Request) {},
+			code:    http.StatusOK,
+		},
+		{
+			name: "status written",
+			handler: func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+				w.Write(nil)
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			name: "body written",
+			handler: func(w http.ResponseWriter, req *http.Request) {
+				w.Write([]byte("ok"))
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "hijacked",
+			handler: func(w http.ResponseWriter, req *http.Request) {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					return
+				}
+				conn.Write([]byte("HTTP/1.1 101 UPGRADED\r\nConnection: Upgrade\r\n\r\n"))
+				conn.Close()
+			},
+			code: http.StatusSwitchingProtocols,
+		},
+	} {
+		got := make(chan int, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			rw := &statusRecorder{ResponseWriter: w}
+			tc.handler(rw, req)
+			got <- rw.statusCode()
+		}))
+
+		resp, err := http.Get(server.URL)
+		if assert.NoError(t, err, tc.name) {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		assert.Equal(t, tc.code, <-got, tc.name)
+		server.Close()
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
 	networktypes "github.com/alibaba/pouch/network/types"
 	"github.com/alibaba/pouch/pkg/httputils"
@@ -12,6 +13,7 @@ import (
 	"github.com/docker/libnetwork"
 	"github.com/go-openapi/strfmt"
 	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 )
 
 func (s *Server) createNetwork(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
@@ -49,12 +51,18 @@ func (s *Server) getNetwork(ctx context.Context, rw http.ResponseWriter, req *ht
 	}
 
 	networkResp := buildNetworkInspectResp(network)
+	networkResp.Containers = s.buildNetworkContainers(ctx, network)
 
 	return EncodeResponse(rw, http.StatusOK, networkResp)
 }
 
 func (s *Server) listNetwork(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
-	networks, err := s.NetworkMgr.List(ctx, map[string]string{})
+	filter, err := filters.FromParam(req.FormValue("filters"))
+	if err != nil {
+		return httputils.NewHTTPError(err, http.StatusBadRequest)
+	}
+
+	networks, err := s.NetworkMgr.List(ctx, filter)
 	if err != nil {
 		return err
 	}
@@ -76,6 +84,20 @@ func (s *Server) deleteNetwork(ctx context.Context, rw http.ResponseWriter, req
 	return nil
 }
 
+func (s *Server) pruneNetworks(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	filter, err := filters.FromParam(req.FormValue("filters"))
+	if err != nil {
+		return httputils.NewHTTPError(err, http.StatusBadRequest)
+	}
+
+	resp, err := s.NetworkMgr.Prune(ctx, filter)
+	if err != nil {
+		logrus.Errorf("failed to prune networks: %v", err)
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
+
 func (s *Server) connectToNetwork(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	networkIDOrName := mux.Vars(req)["id"]
 	connectConfig := &types.NetworkConnect{}
@@ -128,6 +150,41 @@ func buildNetworkInspectResp(n *networktypes.Network) *types.NetworkInspectResp
 	return network
 }
 
+// buildNetworkContainers returns the endpoints of containers connected to
+// the network, keyed by container ID.
+func (s *Server) buildNetworkContainers(ctx context.Context, n *networktypes.Network) map[string]types.EndpointResource {
+	containers := make(map[string]types.EndpointResource)
+	for _, ep := range n.Network.Endpoints() {
+		info := ep.Info()
+		sb := info.Sandbox()
+		if sb == nil {
+			continue
+		}
+
+		r := types.EndpointResource{
+			EndpointID: ep.ID(),
+		}
+		if c, err := s.ContainerMgr.Get(ctx, sb.ContainerID()); err == nil {
+			r.Name = c.Name
+		} else {
+			logrus.Warnf("failed to get container %s connected to network %s: %v", sb.ContainerID(), n.Name, err)
+		}
+		if iface := info.Iface(); iface != nil {
+			if iface.MacAddress() != nil {
+				r.MacAddress = iface.MacAddress().String()
+			}
+			if iface.Address() != nil {
+				r.IPV4Address = iface.Address().String()
+			}
+			if iface.AddressIPv6() != nil && iface.AddressIPv6().IP != nil {
+				r.IPV6Address = iface.AddressIPv6().String()
+			}
+		}
+		containers[sb.ContainerID()] = r
+	}
+	return containers
+}
+
 func buildNetworkResource(n *networktypes.Network) types.NetworkResource {
 	r := types.NetworkResource{}
 	if n == nil {
@@ -10,9 +10,9 @@ import (
 
 	serverTypes "github.com/alibaba/pouch/apis/server/types"
 	"github.com/alibaba/pouch/apis/types"
-	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/httputils"
 	"github.com/alibaba/pouch/pkg/utils"
+	"github.com/alibaba/pouch/version"
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
@@ -31,6 +31,8 @@ func initRoute(s *Server) *mux.Router {
 		{Method: http.MethodGet, Path: "/version", HandlerFunc: s.version},
 		{Method: http.MethodPost, Path: "/auth", HandlerFunc: s.auth},
 		{Method: http.MethodGet, Path: "/events", HandlerFunc: withCancelHandler(s.events)},
+		{Method: http.MethodGet, Path: "/system/df", HandlerFunc: withCancelHandler(s.systemDataUsage)},
+		{Method: http.MethodPost, Path: "/system/gc", HandlerFunc: s.systemGC},
 
 		// daemon, we still list this API into system manager.
 		{Method: http.MethodPost, Path: "/daemon/update", HandlerFunc: s.updateDaemon},
@@ -44,6 +46,7 @@ func initRoute(s *Server) *mux.Router {
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/stop", HandlerFunc: s.stopContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/attach", HandlerFunc: s.attachContainer},
 		{Method: http.MethodGet, Path: "/containers/json", HandlerFunc: s.getContainers},
+		{Method: http.MethodPost, Path: "/containers/prune", HandlerFunc: s.pruneContainers},
 		{Method: http.MethodGet, Path: "/containers/{name:.*}/json", HandlerFunc: s.getContainer},
 		{Method: http.MethodDelete, Path: "/containers/{name:.*}", HandlerFunc: s.removeContainers},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/exec", HandlerFunc: s.createContainerExec},
@@ -52,6 +55,7 @@ func initRoute(s *Server) *mux.Router {
 		{Method: http.MethodPost, Path: "/exec/{name:.*}/resize", HandlerFunc: s.resizeExec},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/rename", HandlerFunc: s.renameContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/restart", HandlerFunc: s.restartContainer},
+		{Method: http.MethodPost, Path: "/containers/{name:.*}/kill", HandlerFunc: s.killContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/pause", HandlerFunc: s.pauseContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/unpause", HandlerFunc: s.unpauseContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/update", HandlerFunc: s.updateContainer},
@@ -62,29 +66,41 @@ func initRoute(s *Server) *mux.Router {
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/resize", HandlerFunc: s.resizeContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/restart", HandlerFunc: s.restartContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/wait", HandlerFunc: withCancelHandler(s.waitContainer)},
+		{Method: http.MethodHead, Path: "/containers/{name:.*}/archive", HandlerFunc: s.headContainerArchive},
+		{Method: http.MethodGet, Path: "/containers/{name:.*}/archive", HandlerFunc: withCancelHandler(s.getContainerArchive)},
+		{Method: http.MethodPut, Path: "/containers/{name:.*}/archive", HandlerFunc: withCancelHandler(s.putContainerArchive)},
+		{Method: http.MethodGet, Path: "/containers/{name:.*}/changes", HandlerFunc: s.getContainerChanges},
+		{Method: http.MethodGet, Path: "/containers/{name:.*}/export", HandlerFunc: withCancelHandler(s.exportContainer)},
 		{Method: http.MethodPost, Path: "/commit", HandlerFunc: withCancelHandler(s.commitContainer)},
 
 		// image
-		{Method: http.MethodPost, Path: "/images/create", HandlerFunc: s.pullImage},
+		{Method: http.MethodPost, Path: "/images/create", HandlerFunc: withCancelHandler(s.pullImage)},
+		{Method: http.MethodGet, Path: "/images/search", HandlerFunc: s.searchImages},
+		// POST is kept for the compatibility of the clients using it.
 		{Method: http.MethodPost, Path: "/images/search", HandlerFunc: s.searchImages},
+		{Method: http.MethodPost, Path: "/images/prune", HandlerFunc: s.pruneImages},
 		{Method: http.MethodGet, Path: "/images/json", HandlerFunc: s.listImages},
 		{Method: http.MethodDelete, Path: "/images/{name:.*}", HandlerFunc: s.removeImage},
 		{Method: http.MethodGet, Path: "/images/{name:.*}/json", HandlerFunc: s.getImage},
 		{Method: http.MethodPost, Path: "/images/{name:.*}/tag", HandlerFunc: s.postImageTag},
 		{Method: http.MethodPost, Path: "/images/load", HandlerFunc: withCancelHandler(s.loadImage)},
 		{Method: http.MethodGet, Path: "/images/save", HandlerFunc: withCancelHandler(s.saveImage)},
+		{Method: http.MethodPost, Path: "/images/import", HandlerFunc: withCancelHandler(s.importImage)},
 		{Method: http.MethodGet, Path: "/images/{name:.*}/history", HandlerFunc: s.getImageHistory},
 		{Method: http.MethodPost, Path: "/images/{name:.*}/push", HandlerFunc: s.pushImage},
+		{Method: http.MethodGet, Path: "/images/{name:.*}/tags", HandlerFunc: s.listRemoteTags},
 
 		// volume
 		{Method: http.MethodGet, Path: "/volumes", HandlerFunc: s.listVolume},
 		{Method: http.MethodPost, Path: "/volumes/create", HandlerFunc: s.createVolume},
+		{Method: http.MethodPost, Path: "/volumes/prune", HandlerFunc: s.pruneVolumes},
 		{Method: http.MethodGet, Path: "/volumes/{name:.*}", HandlerFunc: s.getVolume},
 		{Method: http.MethodDelete, Path: "/volumes/{name:.*}", HandlerFunc: s.removeVolume},
 
 		// network
 		{Method: http.MethodGet, Path: "/networks", HandlerFunc: s.listNetwork},
 		{Method: http.MethodPost, Path: "/networks/create", HandlerFunc: s.createNetwork},
+		{Method: http.MethodPost, Path: "/networks/prune", HandlerFunc: s.pruneNetworks},
 		{Method: http.MethodGet, Path: "/networks/{id:.*}", HandlerFunc: s.getNetwork},
 		{Method: http.MethodDelete, Path: "/networks/{id:.*}", HandlerFunc: s.deleteNetwork},
 		{Method: http.MethodPost, Path: "/networks/{id:.*}/connect", HandlerFunc: s.connectToNetwork},
@@ -109,17 +125,29 @@ func initRoute(s *Server) *mux.Router {
 	// register API
 	for _, h := range handlers {
 		if h != nil {
-			r.Path(versionMatcher + h.Path).Methods(h.Method).Handler(filter(h.HandlerFunc, s))
-			r.Path(h.Path).Methods(h.Method).Handler(filter(h.HandlerFunc, s))
+			r.Path(versionMatcher + h.Path).Methods(h.Method).Handler(withMetrics(h.Path, filter(h.HandlerFunc, s)))
+			r.Path(h.Path).Methods(h.Method).Handler(withMetrics(h.Path, filter(h.HandlerFunc, s)))
 		}
 	}
 
+	// the unmatched request also gets the json error body like handlers.
+	r.NotFoundHandler = errorHandler(http.StatusNotFound, "page not found")
+	r.MethodNotAllowedHandler = errorHandler(http.StatusMethodNotAllowed, "method not allowed")
+
 	if s.Config.Debug || s.Config.EnableProfiler {
 		profilerSetup(r)
 	}
 	return r
 }
 
+// errorHandler returns the handler which always responds the error message
+// with the status code.
+func errorHandler(code int, msg string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		HandleErrorResponse(w, httputils.NewHTTPError(fmt.Errorf("%s %s: %s", req.Method, req.URL.Path, msg), code))
+	})
+}
+
 func profilerSetup(mainRouter *mux.Router) {
 	var r = mainRouter.PathPrefix("/debug/").Subrouter()
 	r.HandleFunc("/pprof/", pprof.Index)
@@ -172,12 +200,22 @@ func filter(handler serverTypes.Handler, s *Server) http.HandlerFunc {
 		ctx, cancel := context.WithCancel(pctx)
 		defer cancel()
 
+		// tell client the latest api version, and reject the version which
+		// isn't served any more.
+		w.Header().Set(apiVersionHeader, version.APIVersion)
+		if v := mux.Vars(req)["version"]; v != "" {
+			if err := checkAPIVersion(v); err != nil {
+				HandleErrorResponse(w, err)
+				return
+			}
+			ctx = withAPIVersion(ctx, v)
+		}
+
 		s.lock.RLock()
 		if len(s.ManagerWhiteList) > 0 && req.TLS != nil && len(req.TLS.PeerCertificates) > 0 {
 			if _, isManager := s.ManagerWhiteList[req.TLS.PeerCertificates[0].Subject.CommonName]; !isManager {
 				s.lock.RUnlock()
-				w.WriteHeader(http.StatusForbidden)
-				w.Write([]byte("tls verified error."))
+				HandleErrorResponse(w, httputils.NewHTTPError(fmt.Errorf("tls verified error"), http.StatusForbidden))
 				return
 			}
 		}
@@ -226,27 +264,9 @@ func EncodeResponse(rw http.ResponseWriter, statusCode int, data interface{}) er
 
 // HandleErrorResponse handles err from daemon side and constructs response for client side.
 func HandleErrorResponse(w http.ResponseWriter, err error) {
-	var (
-		code   int
-		errMsg string
-	)
-
-	// By default, daemon side returns code 500 if error happens.
-	code = http.StatusInternalServerError
-	errMsg = err.Error()
-
-	httpErr, ok := err.(httputils.HTTPError)
-	if ok {
-		code = httpErr.Code()
-	} else if errtypes.IsNotfound(err) {
-		code = http.StatusNotFound
-	} else if errtypes.IsInvalidParam(err) {
-		code = http.StatusBadRequest
-	} else if errtypes.IsAlreadyExisted(err) {
-		code = http.StatusConflict
-	} else if errtypes.IsNotModified(err) {
-		code = http.StatusNotModified
-	}
+	// the status code is decided by the type of error, and 500 by default.
+	code := httputils.StatusCode(err)
+	errMsg := err.Error()
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
@@ -68,6 +68,9 @@ func (s *Server) Start(readyCh chan bool) (err error) {
 			return err
 		}
 		logrus.Infof("start to listen to: %s", one)
+		if strings.HasPrefix(one, "tcp://") && !s.Config.TLS.VerifyRemote {
+			logrus.Warnf("listening on %s without verifying client certs, everyone reaching it can control pouchd, use --tlsverify to protect it", one)
+		}
 		s.listeners = append(s.listeners, l)
 
 		go func(l net.Listener) {
@@ -82,6 +85,20 @@ func (s *Server) Start(readyCh chan bool) (err error) {
 		}(l)
 	}
 
+	if s.Config.MetricsAddress != "" {
+		l, err := net.Listen("tcp", s.Config.MetricsAddress)
+		if err != nil {
+			readyCh <- false
+			return err
+		}
+		logrus.Infof("start to serve metrics on: %s", s.Config.MetricsAddress)
+		s.listeners = append(s.listeners, l)
+
+		go func(l net.Listener) {
+			errCh <- http.Serve(l, newMetricsRouter())
+		}(l)
+	}
+
 	// the http server has set up, send Ready
 	readyCh <- true
 
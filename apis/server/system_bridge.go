@@ -9,6 +9,7 @@ import (
 
 	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/mgr"
 	"github.com/alibaba/pouch/pkg/httputils"
 	"github.com/alibaba/pouch/pkg/utils"
 	"github.com/alibaba/pouch/pkg/utils/metrics"
@@ -30,15 +31,59 @@ func (s *Server) info(ctx context.Context, rw http.ResponseWriter, req *http.Req
 	if err != nil {
 		return err
 	}
-	return EncodeResponse(rw, http.StatusOK, info)
+	return encodeVersionedResponse(ctx, rw, http.StatusOK, info, systemInfoFields)
 }
 
 func (s *Server) version(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
-	version, err := s.SystemMgr.Version()
+	version, err := s.SystemMgr.Version(ctx)
 	if err != nil {
 		return err
 	}
-	return EncodeResponse(rw, http.StatusOK, version)
+	return encodeVersionedResponse(ctx, rw, http.StatusOK, version, systemVersionFields)
+}
+
+// systemDataUsage reports the disk space used by images, containers and volumes.
+func (s *Server) systemDataUsage(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	containers, err := s.ContainerMgr.List(ctx, &mgr.ContainerListOption{All: true})
+	if err != nil {
+		return err
+	}
+
+	imageContainers := make(map[string]int64, len(containers))
+	for _, c := range containers {
+		imageContainers[c.Image]++
+	}
+
+	images, layersSize, err := s.ImageMgr.DiskUsage(ctx, imageContainers)
+	if err != nil {
+		return err
+	}
+
+	containerUsages, err := s.ContainerMgr.DiskUsage(ctx)
+	if err != nil {
+		return err
+	}
+
+	volumes, err := s.VolumeMgr.DiskUsage(ctx)
+	if err != nil {
+		return err
+	}
+
+	return EncodeResponse(rw, http.StatusOK, &types.DiskUsage{
+		LayersSize: layersSize,
+		Images:     images,
+		Containers: containerUsages,
+		Volumes:    volumes,
+	})
+}
+
+// systemGC triggers the garbage collection of containerd.
+func (s *Server) systemGC(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	resp, err := s.SystemMgr.GarbageCollect(ctx)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, resp)
 }
 
 func (s *Server) updateDaemon(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
@@ -70,8 +115,7 @@ func (s *Server) auth(ctx context.Context, rw http.ResponseWriter, req *http.Req
 
 	token, err := s.SystemMgr.Auth(&auth)
 	if err != nil {
-		rw.WriteHeader(http.StatusUnauthorized)
-		return
+		return httputils.NewHTTPError(err, http.StatusUnauthorized)
 	}
 
 	authResp := types.AuthResponse{
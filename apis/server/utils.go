@@ -1,12 +1,15 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/daemon/logger"
@@ -46,6 +49,93 @@ func newWriteFlusher(w io.Writer) io.Writer {
 	return w
 }
 
+// fullDuplexer is implemented by the response writer of http server since
+// go1.21, which lets the handler write the response of HTTP/1.x while the
+// request body is being read.
+type fullDuplexer interface {
+	EnableFullDuplex() error
+}
+
+// bodyHoldWriter holds the data until the request body has been read,
+// because the http server will close the unread request body once the
+// response is written.
+type bodyHoldWriter struct {
+	sync.Mutex
+
+	w        io.Writer
+	buf      bytes.Buffer
+	released bool
+}
+
+// Write writes the data into buffer if the writer hasn't been released.
+func (hw *bodyHoldWriter) Write(p []byte) (int, error) {
+	hw.Lock()
+	defer hw.Unlock()
+
+	if !hw.released {
+		return hw.buf.Write(p)
+	}
+	return hw.w.Write(p)
+}
+
+// release writes the holding data and lets the following data go through.
+func (hw *bodyHoldWriter) release() error {
+	hw.Lock()
+	defer hw.Unlock()
+
+	if hw.released {
+		return nil
+	}
+	hw.released = true
+
+	if hw.buf.Len() == 0 {
+		return nil
+	}
+	_, err := hw.w.Write(hw.buf.Bytes())
+	hw.buf.Reset()
+	return err
+}
+
+// bodyEOFReader calls the onEOF callback when the body has been read.
+type bodyEOFReader struct {
+	r     io.ReadCloser
+	onEOF func()
+}
+
+// Read implements io.Reader interface.
+func (br *bodyEOFReader) Read(p []byte) (int, error) {
+	n, err := br.r.Read(p)
+	if err != nil {
+		br.onEOF()
+	}
+	return n, err
+}
+
+// Close implements io.Closer interface.
+func (br *bodyEOFReader) Close() error {
+	return br.r.Close()
+}
+
+// newBodyHoldWriter returns the request body and writer which flushes data
+// after every write. If the response can be written while reading request
+// body, the data goes through once it's written, and only the status of
+// response is held until the first write. Otherwise, the data will be held
+// until the returned body has been read or the returned release function is
+// called.
+func newBodyHoldWriter(body io.ReadCloser, rw http.ResponseWriter) (io.ReadCloser, io.Writer, func() error) {
+	w := newWriteFlusher(rw)
+	if fd, ok := rw.(fullDuplexer); ok && fd.EnableFullDuplex() == nil {
+		return body, w, func() error { return nil }
+	}
+
+	hw := &bodyHoldWriter{w: w}
+	br := &bodyEOFReader{
+		r:     body,
+		onEOF: func() { hw.release() },
+	}
+	return br, hw, hw.release
+}
+
 // writeLogStream will convert to WriteFlusher to writer log.
 func writeLogStream(ctx context.Context, w io.Writer, tty bool, opt *types.ContainerLogsOptions, msgs <-chan *logger.LogMessage) {
 	// NOTE: The default HTTP/1.x and HTTP/2 ResponseWriter implementations Flusher.
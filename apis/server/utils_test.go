@@ -0,0 +1,52 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyHoldWriterFullDuplex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, output, release := newBodyHoldWriter(req.Body, &statusRecorder{ResponseWriter: w})
+		defer release()
+
+		// the progress goes through before the body has been read.
+		fmt.Fprintln(output, "loading")
+		data, err := ioutil.ReadAll(body)
+		assert.NoError(t, err)
+		fmt.Fprintln(output, string(data))
+	}))
+	defer server.Close()
+
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	go pw.Write([]byte("foo"))
+
+	req, err := http.NewRequest("POST", server.URL, pr)
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+
+	r := bufio.NewReader(resp.Body)
+	line, err := r.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "loading\n", line)
+
+	pw.Write([]byte("bar"))
+	pw.Close()
+
+	line, err = r.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "foobar\n", line)
+}
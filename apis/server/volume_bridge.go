@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-openapi/strfmt"
 	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 )
 
 func (s *Server) createVolume(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
@@ -137,3 +138,17 @@ func (s *Server) removeVolume(ctx context.Context, rw http.ResponseWriter, req *
 	rw.WriteHeader(http.StatusNoContent)
 	return nil
 }
+
+func (s *Server) pruneVolumes(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	filter, err := filters.FromParam(req.FormValue("filters"))
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.VolumeMgr.Prune(ctx, filter)
+	if err != nil {
+		logrus.Errorf("failed to prune volumes: %v", err)
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
@@ -19,6 +19,9 @@ type Checkpoint struct {
 
 	// container ID
 	ContainerID string `json:"ContainerID,omitempty"`
+
+	// the checkpoint contains established TCP connections, which are restored too
+	TCPEstablished bool `json:"TCPEstablished,omitempty"`
 }
 
 // Validate validates this checkpoint
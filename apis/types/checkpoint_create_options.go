@@ -22,6 +22,9 @@ type CheckpointCreateOptions struct {
 
 	// exit
 	Exit bool `json:"Exit,omitempty"`
+
+	// checkpoint the container with established TCP connections, otherwise it is refused
+	TCPEstablished bool `json:"TCPEstablished,omitempty"`
 }
 
 // Validate validates this checkpoint create options
@@ -170,6 +170,9 @@ type ContainerNetworkSettings struct {
 
 	// networks
 	Networks map[string]*EndpointSettings `json:"Networks,omitempty"`
+
+	// The port mappings of the running container, including the random host ports of published ports.
+	Ports PortMap `json:"Ports,omitempty"`
 }
 
 // Validate validates this container network settings
@@ -180,6 +183,10 @@ func (m *ContainerNetworkSettings) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePorts(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -208,6 +215,22 @@ func (m *ContainerNetworkSettings) validateNetworks(formats strfmt.Registry) err
 	return nil
 }
 
+func (m *ContainerNetworkSettings) validatePorts(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Ports) { // not required
+		return nil
+	}
+
+	if err := m.Ports.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("NetworkSettings" + "." + "Ports")
+		}
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *ContainerNetworkSettings) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -17,9 +17,15 @@ type ContainerCommitOptions struct {
 	// author is the one build the image
 	Author string `json:"Author,omitempty"`
 
+	// changes are the Dockerfile instructions applied to the image config, only ENV, CMD, ENTRYPOINT and EXPOSE are supported
+	Changes []string `json:"Changes"`
+
 	// comment is external information add for the image
 	Comment string `json:"Comment,omitempty"`
 
+	// pause is whether to pause the running container during commit
+	Pause bool `json:"Pause,omitempty"`
+
 	// repository is the image name
 	Repository string `json:"Repository,omitempty"`
 
@@ -34,6 +34,11 @@ type ContainerJSON struct {
 	// driver
 	Driver string `json:"Driver,omitempty"`
 
+	// The capabilities of container process, which are all capabilities if the container is privileged,
+	// otherwise the default capabilities with `CapAdd` added and `CapDrop` dropped.
+	//
+	EffectiveCapabilities []string `json:"EffectiveCapabilities"`
+
 	// exec ids of container
 	ExecIds []string `json:"ExecIDs"`
 
@@ -85,6 +90,11 @@ type ContainerJSON struct {
 	// the container's restart time
 	RestartCount int64 `json:"RestartCount,omitempty"`
 
+	// The seccomp profile applied to the container, which is `unconfined` if the container is privileged,
+	// `pouch/default` for the default profile, or the path of the custom profile.
+	//
+	SeccompProfile string `json:"SeccompProfile,omitempty"`
+
 	// The total size of all the files in this container.
 	SizeRootFs *int64 `json:"SizeRootFs,omitempty"`
 
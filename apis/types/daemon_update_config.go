@@ -14,6 +14,9 @@ import (
 // swagger:model DaemonUpdateConfig
 type DaemonUpdateConfig struct {
 
+	// Switch daemon log level to DEBUG mode if it is true, and back to INFO mode if it is false. The log level isn't changed if it is omitted.
+	Debug *bool `json:"Debug,omitempty"`
+
 	// Image proxy used to pull image.
 	ImageProxy string `json:"ImageProxy,omitempty"`
 
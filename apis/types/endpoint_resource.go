@@ -10,7 +10,7 @@ import (
 	"github.com/go-openapi/swag"
 )
 
-// EndpointResource NetworkResource is the body of the "get network" http response message
+// EndpointResource EndpointResource contains the network resources of the container connected to the network
 // swagger:model EndpointResource
 type EndpointResource struct {
 
@@ -26,7 +26,7 @@ type EndpointResource struct {
 	// MacAddress represents the enpoint's mac address
 	MacAddress string `json:"MacAddress,omitempty"`
 
-	// Name is the requested name of the network
+	// Name is the name of the container
 	Name string `json:"Name,omitempty"`
 }
 
@@ -19,7 +19,7 @@ import (
 // swagger:model HostConfig
 type HostConfig struct {
 
-	// Automatically remove the container when the container's process exits. This has no effect if `RestartPolicy` is set.
+	// Automatically remove the container and its anonymous volumes when the container's process exits. It can't be used with `RestartPolicy` other than `no`.
 	AutoRemove bool `json:"AutoRemove,omitempty"`
 
 	// A list of volume bindings for this container. Each volume binding is a string in one of these forms:
@@ -67,6 +67,9 @@ type HostConfig struct {
 	// A list of additional groups that the container process will run as.
 	GroupAdd []string `json:"GroupAdd"`
 
+	// Run an init inside the container that forwards signals and reaps processes. The default of daemon is used if it is omitted.
+	Init *bool `json:"Init,omitempty"`
+
 	// Initial script executed in container. The script will be executed before entrypoint or command
 	InitScript string `json:"InitScript,omitempty"`
 
@@ -94,6 +97,9 @@ type HostConfig struct {
 	// Masks over the provided paths inside the container.
 	MaskedPaths []string `json:"MaskedPaths"`
 
+	// Specification for mounts to be added to the container.
+	Mounts []*Mount `json:"Mounts"`
+
 	// Network mode to use for this container. Supported standard values are: `bridge`, `host`, `none`, and `container:<name|id>`. Any other value is taken as a custom network's name to which this container should connect to.
 	NetworkMode string `json:"NetworkMode,omitempty"`
 
@@ -202,6 +208,8 @@ func (m *HostConfig) UnmarshalJSON(raw []byte) error {
 
 		GroupAdd []string `json:"GroupAdd"`
 
+		Init *bool `json:"Init,omitempty"`
+
 		InitScript string `json:"InitScript,omitempty"`
 
 		IpcMode string `json:"IpcMode,omitempty"`
@@ -214,6 +222,8 @@ func (m *HostConfig) UnmarshalJSON(raw []byte) error {
 
 		MaskedPaths []string `json:"MaskedPaths"`
 
+		Mounts []*Mount `json:"Mounts"`
+
 		NetworkMode string `json:"NetworkMode,omitempty"`
 
 		OomScoreAdj int64 `json:"OomScoreAdj,omitempty"`
@@ -286,6 +296,8 @@ func (m *HostConfig) UnmarshalJSON(raw []byte) error {
 
 	m.GroupAdd = dataAO0.GroupAdd
 
+	m.Init = dataAO0.Init
+
 	m.InitScript = dataAO0.InitScript
 
 	m.IpcMode = dataAO0.IpcMode
@@ -298,6 +310,8 @@ func (m *HostConfig) UnmarshalJSON(raw []byte) error {
 
 	m.MaskedPaths = dataAO0.MaskedPaths
 
+	m.Mounts = dataAO0.Mounts
+
 	m.NetworkMode = dataAO0.NetworkMode
 
 	m.OomScoreAdj = dataAO0.OomScoreAdj
@@ -381,6 +395,8 @@ func (m HostConfig) MarshalJSON() ([]byte, error) {
 
 		GroupAdd []string `json:"GroupAdd"`
 
+		Init *bool `json:"Init,omitempty"`
+
 		InitScript string `json:"InitScript,omitempty"`
 
 		IpcMode string `json:"IpcMode,omitempty"`
@@ -393,6 +409,8 @@ func (m HostConfig) MarshalJSON() ([]byte, error) {
 
 		MaskedPaths []string `json:"MaskedPaths"`
 
+		Mounts []*Mount `json:"Mounts"`
+
 		NetworkMode string `json:"NetworkMode,omitempty"`
 
 		OomScoreAdj int64 `json:"OomScoreAdj,omitempty"`
@@ -462,6 +480,8 @@ func (m HostConfig) MarshalJSON() ([]byte, error) {
 
 	dataAO0.GroupAdd = m.GroupAdd
 
+	dataAO0.Init = m.Init
+
 	dataAO0.InitScript = m.InitScript
 
 	dataAO0.IpcMode = m.IpcMode
@@ -474,6 +494,8 @@ func (m HostConfig) MarshalJSON() ([]byte, error) {
 
 	dataAO0.MaskedPaths = m.MaskedPaths
 
+	dataAO0.Mounts = m.Mounts
+
 	dataAO0.NetworkMode = m.NetworkMode
 
 	dataAO0.OomScoreAdj = m.OomScoreAdj
@@ -547,6 +569,10 @@ func (m *HostConfig) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateMounts(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateOomScoreAdj(formats); err != nil {
 		res = append(res, err)
 	}
@@ -660,6 +686,31 @@ func (m *HostConfig) validateLogConfig(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *HostConfig) validateMounts(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Mounts) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Mounts); i++ {
+		if swag.IsZero(m.Mounts[i]) { // not required
+			continue
+		}
+
+		if m.Mounts[i] != nil {
+			if err := m.Mounts[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Mounts" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *HostConfig) validateOomScoreAdj(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.OomScoreAdj) { // not required
@@ -42,6 +42,9 @@ type ImageInfo struct {
 
 	// size of image's taking disk space.
 	Size int64 `json:"Size,omitempty"`
+
+	// the variant of the CPU architecture, which is specified when pulling the image.
+	Variant string `json:"Variant,omitempty"`
 }
 
 // Validate validates this image info
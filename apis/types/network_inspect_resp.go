@@ -9,12 +9,16 @@ import (
 	"github.com/go-openapi/errors"
 	strfmt "github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // NetworkInspectResp is the expected body of the 'GET networks/{id}'' http request message
 // swagger:model NetworkInspectResp
 type NetworkInspectResp struct {
 
+	// Containers contains the endpoints of containers connected to the network, keyed by container ID.
+	Containers map[string]EndpointResource `json:"Containers,omitempty"`
+
 	// Driver means the network's driver.
 	Driver string `json:"Driver,omitempty"`
 
@@ -47,6 +51,10 @@ type NetworkInspectResp struct {
 func (m *NetworkInspectResp) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateContainers(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateIPAM(formats); err != nil {
 		res = append(res, err)
 	}
@@ -57,6 +65,28 @@ func (m *NetworkInspectResp) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *NetworkInspectResp) validateContainers(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Containers) { // not required
+		return nil
+	}
+
+	for k := range m.Containers {
+
+		if err := validate.Required("Containers"+"."+k, "body", m.Containers[k]); err != nil {
+			return err
+		}
+		if val, ok := m.Containers[k]; ok {
+			if err := val.Validate(formats); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *NetworkInspectResp) validateIPAM(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.IPAM) { // not required
@@ -30,9 +30,18 @@ type SystemInfo struct {
 	// Enum: [cgroupfs systemd]
 	CgroupDriver string `json:"CgroupDriver,omitempty"`
 
+	// Address of containerd which pouchd connects to
+	ContainerdAddress string `json:"ContainerdAddress,omitempty"`
+
 	// containerd commit
 	ContainerdCommit *Commit `json:"ContainerdCommit,omitempty"`
 
+	// Connectivity of containerd, `connected` or `disconnected`
+	ContainerdStatus string `json:"ContainerdStatus,omitempty"`
+
+	// Version of containerd, `<unknown>` if containerd is disconnected
+	ContainerdVersion string `json:"ContainerdVersion,omitempty"`
+
 	// Total number of containers on the host.
 	Containers int64 `json:"Containers,omitempty"`
 
@@ -146,6 +155,10 @@ type SystemInfo struct {
 	//
 	LxcfsEnabled bool `json:"LxcfsEnabled,omitempty"`
 
+	// The max number of layers downloaded at the same time by all the pulls.
+	//
+	MaxConcurrentDownloads int64 `json:"MaxConcurrentDownloads,omitempty"`
+
 	// Total amount of physical memory available on the host, in kilobytes (kB).
 	//
 	MemTotal int64 `json:"MemTotal,omitempty"`
@@ -214,6 +227,11 @@ type SystemInfo struct {
 	// The list of volume drivers which the pouchd supports
 	//
 	VolumeDrivers []string `json:"VolumeDrivers"`
+
+	// Warnings of the configuration of daemon and host, such as the
+	// missing cgroup support and the unprotected tcp listener.
+	//
+	Warnings []string `json:"Warnings"`
 }
 
 // Validate validates this system info
@@ -23,6 +23,9 @@ type SystemVersion struct {
 	// The time when this binary of daemon is built
 	BuildTime string `json:"BuildTime,omitempty"`
 
+	// version of containerd used by daemon
+	ContainerdVersion string `json:"ContainerdVersion,omitempty"`
+
 	// Commit ID held by the latest commit operation
 	GitCommit string `json:"GitCommit,omitempty"`
 
@@ -32,9 +35,15 @@ type SystemVersion struct {
 	// Operating system kernel version
 	KernelVersion string `json:"KernelVersion,omitempty"`
 
+	// The oldest Api Version still served by daemon, added in 1.25
+	MinAPIVersion string `json:"MinAPIVersion,omitempty"`
+
 	// Operating system type of underlying system
 	Os string `json:"Os,omitempty"`
 
+	// version of the default OCI runtime used by daemon
+	RuncVersion string `json:"RuncVersion,omitempty"`
+
 	// version of Pouch Daemon
 	Version string `json:"Version,omitempty"`
 }
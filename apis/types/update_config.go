@@ -25,7 +25,7 @@ type UpdateConfig struct {
 	//
 	Env []string `json:"Env"`
 
-	// List of labels set to container.
+	// Labels of container are immutable after creation, the update is rejected if it is not empty.
 	Label []string `json:"Label"`
 
 	// restart policy
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/alibaba/pouch/pkg/ioutils"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// attachDescription is used to describe attach command in detail and auto generate command doc.
+var attachDescription = "Attach local standard input, output, and error streams to a running container. " +
+	"The key sequence of detach keys (default ctrl-p,ctrl-q) can be used to detach from the container " +
+	"and leave it running. Attaching to a container which is not running will fail."
+
+// AttachCommand use to implement 'attach' command.
+type AttachCommand struct {
+	baseCommand
+	noStdin    bool
+	detachKeys string
+}
+
+// Init initialize attach command.
+func (ac *AttachCommand) Init(c *Cli) {
+	ac.cli = c
+	ac.cmd = &cobra.Command{
+		Use:   "attach [OPTIONS] CONTAINER",
+		Short: "Attach local standard input, output, and error streams to a running container",
+		Long:  attachDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ac.runAttach(args)
+		},
+		Example: attachExample(),
+	}
+	ac.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (ac *AttachCommand) addFlags() {
+	flagSet := ac.cmd.Flags()
+	flagSet.BoolVar(&ac.noStdin, "no-stdin", false, "Do not attach STDIN")
+	flagSet.StringVar(&ac.detachKeys, "detach-keys", "", "Override the key sequence for detaching a container")
+}
+
+// runAttach is the entry of attach command.
+func (ac *AttachCommand) runAttach(args []string) error {
+	ctx := ac.cli.Context()
+	apiClient := ac.cli.Client()
+	name := args[0]
+	detachKeys := ac.cli.DetachKeys(ac.detachKeys)
+
+	c, err := apiClient.ContainerGet(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if !c.State.Running {
+		return fmt.Errorf("cannot attach to container %s which is %s, start it first", name, c.State.Status)
+	}
+
+	stdin := !ac.noStdin && c.Config.OpenStdin
+	if err := checkTty(stdin, c.Config.Tty, os.Stdin.Fd()); err != nil {
+		return err
+	}
+
+	if stdin && c.Config.Tty {
+		in, out, err := setRawMode(true, false)
+		if err != nil {
+			return fmt.Errorf("failed to set raw mode")
+		}
+		defer func() {
+			if err := restoreMode(in, out); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to restore term mode")
+			}
+		}()
+	}
+
+	conn, br, err := apiClient.ContainerAttach(ctx, name, stdin, detachKeys)
+	if err != nil {
+		return errors.Wrap(err, "failed to attach container")
+	}
+	defer conn.Close()
+
+	if c.Config.Tty {
+		stopResize := monitorTtySize(func(height, width string) error {
+			return apiClient.ContainerResize(ctx, name, height, width)
+		})
+		defer stopResize()
+	}
+
+	if stdin {
+		go func() {
+			io.Copy(conn, os.Stdin)
+			// close write if receive CTRL-D
+			if cw, ok := conn.(ioutils.CloseWriter); ok {
+				cw.CloseWrite()
+			}
+		}()
+	}
+
+	// the output ends when the container exits or the client detaches.
+	if err := copyAttachedOutput(ctx, conn, br, c.Config.Tty); err != nil {
+		return err
+	}
+
+	info, err := apiClient.ContainerGet(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if code := info.State.ExitCode; !info.State.Running && code != 0 {
+		return ExitError{Code: int(code)}
+	}
+	return nil
+}
+
+// copyAttachedOutput copies the output of the attached container to stdout
+// and stderr, which are multiplexed in one stream if the container has no tty.
+// The hijacked connection doesn't follow the context, so it's closed to stop
+// the copy once the context is cancelled, such as by Ctrl-C.
+func copyAttachedOutput(ctx context.Context, conn net.Conn, r io.Reader, tty bool) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	var err error
+	if tty {
+		_, err = io.Copy(os.Stdout, r)
+	} else {
+		_, err = stdcopy.StdCopy(os.Stdout, os.Stderr, r)
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// monitorTtySize resizes the remote tty to the size of local terminal, and
+// keeps them in sync when the local terminal is resized. The returned
+// function stops the monitor.
+func monitorTtySize(resize func(height, width string) error) func() {
+	var lastWidth, lastHeight int
+	resizeFn := func() {
+		width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			logrus.Debugf("failed to get terminal size: %v", err)
+			return
+		}
+		if width == lastWidth && height == lastHeight {
+			return
+		}
+		if err := resize(strconv.Itoa(height), strconv.Itoa(width)); err != nil {
+			logrus.Debugf("failed to resize tty: %v", err)
+			return
+		}
+		lastWidth, lastHeight = width, height
+	}
+	resizeFn()
+
+	return notifyResize(resizeFn)
+}
+
+// attachExample shows examples in attach command, and is used in auto-generated cli docs.
+func attachExample() string {
+	return `$ pouch run -d -t --name foo busybox top
+$ pouch attach foo
+Mem: 1767532K used, 279800K free, 5612K shrd, 60364K buff, 1320388K cached
+CPU:  0.0% usr  0.0% sys  0.0% nic 99.9% idle  0.0% io  0.0% irq  0.0% sirq
+Load average: 0.00 0.01 0.05 1/290 6
+  PID  PPID USER     STAT   VSZ %VSZ CPU %CPU COMMAND
+    1     0 root     R     1296  0.0   1  0.0 top`
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyAttachedOutputCancelled(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer peer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- copyAttachedOutput(ctx, conn, bufio.NewReader(conn), true)
+	}()
+
+	// the copy is blocked since the container has no output.
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("copy should stop once the context is cancelled")
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"os"
 
@@ -43,8 +42,9 @@ var checkpointCreateDescription = "Create a checkpoint from a running container
 type CheckpointCreateCommand struct {
 	CheckpointCommand
 
-	leaveRunning bool
-	cpDir        string
+	leaveRunning   bool
+	cpDir          string
+	tcpEstablished bool
 }
 
 // Init initialize checkpoint create command.
@@ -65,13 +65,14 @@ func (cc *CheckpointCreateCommand) Init(c *Cli) {
 
 // runCheckpoint is the entry of checkpoint create command.
 func (cc *CheckpointCreateCommand) runCheckpointCreate(args []string) error {
-	ctx := context.Background()
+	ctx := cc.cli.Context()
 	apiClient := cc.cli.Client()
 
 	if err := apiClient.ContainerCheckpointCreate(ctx, args[0], types.CheckpointCreateOptions{
-		CheckpointID:  args[1],
-		CheckpointDir: cc.cpDir,
-		Exit:          !cc.leaveRunning,
+		CheckpointID:   args[1],
+		CheckpointDir:  cc.cpDir,
+		Exit:           !cc.leaveRunning,
+		TCPEstablished: cc.tcpEstablished,
 	}); err != nil {
 		return err
 	}
@@ -85,6 +86,7 @@ func (cc *CheckpointCreateCommand) addFlags() {
 	flagSet := cc.cmd.Flags()
 	flagSet.BoolVar(&cc.leaveRunning, "leave-running", false, "keep container running after creating checkpoint")
 	flagSet.StringVar(&cc.cpDir, "checkpoint-dir", "", "directory to store checkpoints images")
+	flagSet.BoolVar(&cc.tcpEstablished, "tcp-established", false, "checkpoint the container with established TCP connections, which are restored too")
 }
 
 // checkpointCreateExample shows examples in checkpoint create command, and is used in auto-generated cli docs.
@@ -120,7 +122,7 @@ func (cc *CheckpointListCommand) Init(c *Cli) {
 
 // runCheckpoint is the entry of checkpoint list command.
 func (cc *CheckpointListCommand) runCheckpointList(args []string) error {
-	ctx := context.Background()
+	ctx := cc.cli.Context()
 	apiClient := cc.cli.Client()
 
 	list, err := apiClient.ContainerCheckpointList(ctx, args[0], types.CheckpointListOptions{
@@ -175,7 +177,7 @@ func (cc *CheckpointDelCommand) Init(c *Cli) {
 
 // runCheckpoint is the entry of checkpoint delete command.
 func (cc *CheckpointDelCommand) runCheckpointDelete(args []string) error {
-	ctx := context.Background()
+	ctx := cc.cli.Context()
 	apiClient := cc.cli.Client()
 
 	if err := apiClient.ContainerCheckpointDelete(ctx, args[0], types.CheckpointDeleteOptions{
@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strconv"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
+	"github.com/alibaba/pouch/cli/formatter"
 	"github.com/alibaba/pouch/client"
+	"github.com/alibaba/pouch/credential"
 
 	"github.com/fatih/structs"
 	"github.com/sirupsen/logrus"
@@ -17,13 +23,42 @@ import (
 // pouchDescription is used to describe pouch command in detail and auto generate command doc.
 var pouchDescription = "pouch is a client side tool pouch to interact with daemon side process pouchd. " +
 	"Flags and arguments can be input to do what actually you wish. " +
-	"Then pouch parses the flags and arguments and sends a RESTful request to daemon side pouchd."
+	"Then pouch parses the flags and arguments and sends a RESTful request to daemon side pouchd. " +
+	"The exit status is 2 if the object is not found, 3 if the operation conflicts with the state of object, " +
+	"125 if daemon can't be reached and 1 for the other failures. " +
+	"The commands running container in the foreground, such as run and exec, exit with the exit status of container, " +
+	"or 127 if the command is not found and 126 if it can't be invoked."
+
+// envHost is the environment variable of the address of daemon, which is
+// used if --host is not set.
+const envHost = "POUCH_HOST"
+
+const (
+	// envTLSVerify enables TLS and verifies daemon if it is not empty, which
+	// is used if --tlsverify is not set.
+	envTLSVerify = "POUCH_TLS_VERIFY"
+
+	// envCertPath is the directory of ca.pem, cert.pem and key.pem, which
+	// are used if --tlscacert, --tlscert and --tlskey are not set.
+	envCertPath = "POUCH_CERT_PATH"
+)
+
+const (
+	// envDebug enables the debug mode if it is true, which is used if
+	// --debug is not set.
+	envDebug = "POUCH_DEBUG"
+
+	// envDebugBodyLimit is the max number of bytes of request and response
+	// body printed in debug mode.
+	envDebugBodyLimit = "POUCH_DEBUG_BODY_LIMIT"
+)
 
 // Option uses to define the global options.
 type Option struct {
-	host  string
-	Debug bool
-	TLS   client.TLSConfig
+	host    string
+	Debug   bool
+	TLS     client.TLSConfig
+	noColor bool
 }
 
 // Cli is the client's core struct, it will be used to manage all subcommand, send http request
@@ -33,6 +68,18 @@ type Cli struct {
 	rootCmd   *cobra.Command
 	APIClient client.CommonAPIClient
 	padding   int
+
+	// config is the config file of CLI, whose values are used if the
+	// flags aren't set.
+	config *credential.ConfigFile
+
+	// daemonRegistry caches the default registry of daemon, which is used
+	// to find the credential of image without registry.
+	daemonRegistry string
+
+	// ctx is the root context of subcommands, which is cancelled when CLI
+	// receives SIGINT or SIGTERM.
+	ctx context.Context
 }
 
 // NewCli creates an instance of 'Cli'.
@@ -52,27 +99,129 @@ func NewCli() *Cli {
 // SetFlags sets all global options.
 func (c *Cli) SetFlags() *Cli {
 	flags := c.rootCmd.PersistentFlags()
-	flags.StringVarP(&c.Option.host, "host", "H", "unix:///var/run/pouchd.sock", "Specify connecting address of Pouch CLI")
-	flags.BoolVarP(&c.Option.Debug, "debug", "D", false, "Switch client log level to DEBUG mode")
-	flags.StringVar(&c.Option.TLS.Key, "tlskey", "", "Specify key file of TLS")
-	flags.StringVar(&c.Option.TLS.Cert, "tlscert", "", "Specify cert file of TLS")
-	flags.StringVar(&c.Option.TLS.CA, "tlscacert", "", "Specify CA file of TLS")
-	flags.BoolVar(&c.Option.TLS.VerifyRemote, "tlsverify", false, "Use TLS and verify remote")
+	flags.StringVarP(&c.Option.host, "host", "H", client.DefaultHost, "Specify connecting address of Pouch CLI, such as unix:///var/run/pouchd.sock or tcp://10.0.0.5:2377, "+envHost+" or host in config file is used if not set")
+	flags.BoolVarP(&c.Option.Debug, "debug", "D", false, "Switch client log level to DEBUG mode and print the API requests to stderr, enabled if "+envDebug+" is true")
+	flags.BoolVar(&c.Option.TLS.Enable, "tls", false, "Use TLS, implied by --tlsverify, --tlscert and --tlskey")
+	flags.StringVar(&c.Option.TLS.Key, "tlskey", "", "Specify key file of TLS, key.pem in "+envCertPath+" is used if not set")
+	flags.StringVar(&c.Option.TLS.Cert, "tlscert", "", "Specify cert file of TLS, cert.pem in "+envCertPath+" is used if not set")
+	flags.StringVar(&c.Option.TLS.CA, "tlscacert", "", "Specify CA file of TLS, ca.pem in "+envCertPath+" is used if not set")
+	flags.BoolVar(&c.Option.TLS.VerifyRemote, "tlsverify", false, "Use TLS and verify remote, enabled if "+envTLSVerify+" is set")
+	flags.BoolVar(&c.Option.noColor, "no-color", false, "Disable the colorized output, which is also disabled if "+formatter.EnvNoColor+" is set or the output is not a terminal")
 	return c
 }
 
-// NewAPIClient initializes the API client in Cli.
-func (c *Cli) NewAPIClient() {
-	client, err := client.NewAPIClient(c.Option.host, c.Option.TLS)
+// LoadConfigFile loads the config file of CLI, which is ~/.pouch/config.json
+// or set by POUCH_CONFIG.
+func (c *Cli) LoadConfigFile() error {
+	config, err := credential.Load()
 	if err != nil {
-		logrus.Fatal(err)
+		return err
 	}
+	c.config = config
+	return nil
+}
 
-	c.APIClient = client
+// ConfigFile returns the config file of CLI, and the empty config is
+// returned if it isn't loaded.
+func (c *Cli) ConfigFile() *credential.ConfigFile {
+	if c.config == nil {
+		return &credential.ConfigFile{}
+	}
+	return c.config
+}
+
+// DetachKeys returns the key sequence for detaching a container, the value
+// in config file is used if the flag isn't set.
+func (c *Cli) DetachKeys(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	return c.ConfigFile().DetachKeys
+}
+
+// NewAPIClient initializes the API client in Cli, the address of daemon is
+// got from --host, POUCH_HOST, the host in config file and the default unix
+// socket in order.
+func (c *Cli) NewAPIClient() error {
+	host := c.Option.host
+	if !c.rootCmd.PersistentFlags().Changed("host") {
+		if env := os.Getenv(envHost); env != "" {
+			host = env
+		} else if c.ConfigFile().Host != "" {
+			host = c.ConfigFile().Host
+		}
+	}
+
+	tlsConfig := c.tlsConfig()
+	apiClient, err := client.NewAPIClient(host, tlsConfig)
+	if err != nil {
+		return err
+	}
+
+	// print the requests and responses to debug the daemon.
+	if ac, ok := apiClient.(*client.APIClient); ok && c.Option.Debug {
+		ac.HTTPCli.Transport = client.NewDebugTransport(ac.HTTPCli.Transport, os.Stderr, debugBodyLimit())
+	}
+
+	c.Option.host = host
+	c.Option.TLS = tlsConfig
+	c.APIClient = apiClient
+	return nil
+}
+
+// debugBodyLimit returns the max number of bytes of body printed in debug
+// mode, which is set by POUCH_DEBUG_BODY_LIMIT.
+func debugBodyLimit() int {
+	if limit, err := strconv.Atoi(os.Getenv(envDebugBodyLimit)); err == nil && limit >= 0 {
+		return limit
+	}
+	return client.DefaultDebugBodyLimit
+}
+
+// tlsConfig returns the TLS config of API client, the flags not set are
+// filled by POUCH_TLS_VERIFY and the files existing in POUCH_CERT_PATH.
+func (c *Cli) tlsConfig() client.TLSConfig {
+	flags := c.rootCmd.PersistentFlags()
+	config := c.Option.TLS
+
+	if !flags.Changed("tlsverify") && os.Getenv(envTLSVerify) != "" {
+		config.VerifyRemote = true
+	}
+
+	if certPath := os.Getenv(envCertPath); certPath != "" {
+		for _, f := range []struct {
+			flag, name string
+			file       *string
+		}{
+			{"tlscacert", "ca.pem", &config.CA},
+			{"tlscert", "cert.pem", &config.Cert},
+			{"tlskey", "key.pem", &config.Key},
+		} {
+			path := filepath.Join(certPath, f.name)
+			if flags.Changed(f.flag) {
+				continue
+			}
+			if _, err := os.Stat(path); err == nil {
+				*f.file = path
+			}
+		}
+	}
+	return config
+}
+
+// Host returns the address of daemon which the API client connects to.
+func (c *Cli) Host() string {
+	return c.Option.host
 }
 
 // InitLog initializes log Level and log format of client.
 func (c *Cli) InitLog() {
+	if !c.rootCmd.PersistentFlags().Changed("debug") {
+		if debug, err := strconv.ParseBool(os.Getenv(envDebug)); err == nil && debug {
+			c.Option.Debug = true
+		}
+	}
+
 	if c.Option.Debug {
 		logrus.SetLevel(logrus.DebugLevel)
 		logrus.Infof("start client at debug level")
@@ -90,9 +239,41 @@ func (c *Cli) Client() client.CommonAPIClient {
 	return c.APIClient
 }
 
+// Context returns the root context of subcommands, the requests to daemon
+// made with it are cancelled if user hits Ctrl-C.
+func (c *Cli) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 // Run executes the client program.
 func (c *Cli) Run() error {
-	return c.rootCmd.Execute()
+	ctx, cancel := contextWithInterrupt(context.Background())
+	defer cancel()
+	c.ctx = ctx
+
+	return toExitError(ctx, c.rootCmd.Execute())
+}
+
+// contextWithInterrupt returns the context which will be cancelled when the
+// process receives SIGINT or SIGTERM.
+func contextWithInterrupt(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		defer signal.Stop(sigc)
+
+		select {
+		case <-sigc:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
 }
 
 // AddCommand add a subcommand.
@@ -107,9 +288,12 @@ func (c *Cli) AddCommand(parent, child Command) {
 	childCmd.SilenceErrors = true
 	childCmd.DisableFlagsInUseLine = true
 
-	childCmd.PreRun = func(cmd *cobra.Command, args []string) {
+	childCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		c.InitLog()
-		c.NewAPIClient()
+		if err := c.LoadConfigFile(); err != nil {
+			return err
+		}
+		return c.NewAPIClient()
 	}
 
 	parentCmd.AddCommand(childCmd)
@@ -121,6 +305,17 @@ func (c *Cli) NewTableDisplay() *Display {
 	return &Display{w}
 }
 
+// NewFormatter creates a formatter which renders the rows of list commands
+// to stdout, and the cells are colorized only if stdout is a terminal.
+func (c *Cli) NewFormatter(format string) *formatter.Formatter {
+	return &formatter.Formatter{
+		Output:  os.Stdout,
+		Format:  format,
+		Color:   formatter.ColorEnabled(os.Stdout, c.Option.noColor),
+		Padding: c.padding,
+	}
+}
+
 // Print outputs the obj's fields.
 func (c *Cli) Print(obj interface{}) {
 	display := c.NewTableDisplay()
@@ -158,14 +353,3 @@ func (c *Cli) Print(obj interface{}) {
 
 	display.Flush()
 }
-
-// ExitError defines exit error produce by cli commands.
-type ExitError struct {
-	Code   int
-	Status string
-}
-
-// Error implements error interface.
-func (e ExitError) Error() string {
-	return fmt.Sprintf("Exit Code: %d, Status: %s", e.Code, e.Status)
-}
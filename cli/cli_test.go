@@ -0,0 +1,131 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/pouch/client"
+	"github.com/alibaba/pouch/credential"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIClientHost(t *testing.T) {
+	defer os.Unsetenv(envHost)
+
+	c := NewCli().SetFlags()
+	os.Unsetenv(envHost)
+	assert.NoError(t, c.NewAPIClient())
+	assert.Equal(t, "unix:///var/run/pouchd.sock", c.Host())
+
+	// POUCH_HOST is used if --host is not set.
+	c = NewCli().SetFlags()
+	os.Setenv(envHost, "tcp://10.0.0.5:2377")
+	assert.NoError(t, c.NewAPIClient())
+	assert.Equal(t, "tcp://10.0.0.5:2377", c.Host())
+
+	c = NewCli().SetFlags()
+	assert.NoError(t, c.rootCmd.PersistentFlags().Set("host", "unix:///run/pouchd.sock"))
+	assert.NoError(t, c.NewAPIClient())
+	assert.Equal(t, "unix:///run/pouchd.sock", c.Host())
+
+	// the invalid host is reported before any API call.
+	c = NewCli().SetFlags()
+	os.Setenv(envHost, "tcp://10.0.0.5")
+	assert.Error(t, c.NewAPIClient())
+	assert.Nil(t, c.APIClient)
+}
+
+func TestNewAPIClientTLS(t *testing.T) {
+	defer os.Unsetenv(envTLSVerify)
+	defer os.Unsetenv(envCertPath)
+
+	dir, err := ioutil.TempDir("", "cli-tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "ca.pem"), nil, 0644))
+
+	// POUCH_TLS_VERIFY and the existing files in POUCH_CERT_PATH are used
+	// if the flags are not set.
+	c := NewCli().SetFlags()
+	os.Setenv(envTLSVerify, "1")
+	os.Setenv(envCertPath, dir)
+	tls := c.tlsConfig()
+	assert.True(t, tls.VerifyRemote)
+	assert.Equal(t, filepath.Join(dir, "ca.pem"), tls.CA)
+	assert.Equal(t, "", tls.Cert)
+	assert.Equal(t, "", tls.Key)
+
+	c = NewCli().SetFlags()
+	flags := c.rootCmd.PersistentFlags()
+	assert.NoError(t, flags.Set("tlsverify", "false"))
+	assert.NoError(t, flags.Set("tlscacert", "/etc/pouch/ca.pem"))
+	tls = c.tlsConfig()
+	assert.False(t, tls.VerifyRemote)
+	assert.Equal(t, "/etc/pouch/ca.pem", tls.CA)
+}
+
+func TestCliConfigFile(t *testing.T) {
+	defer os.Unsetenv(credential.EnvConfig)
+
+	dir, err := ioutil.TempDir("", "cli-config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "config.json")
+	os.Setenv(credential.EnvConfig, fileName)
+	assert.NoError(t, ioutil.WriteFile(fileName, []byte(`{"host": "tcp://10.0.0.5:2377", "detachKeys": "ctrl-x,x"}`), 0600))
+
+	// the values in config file are used if the flags aren't set.
+	c := NewCli().SetFlags()
+	assert.NoError(t, c.LoadConfigFile())
+	assert.NoError(t, c.NewAPIClient())
+	assert.Equal(t, "tcp://10.0.0.5:2377", c.Host())
+	assert.Equal(t, "ctrl-x,x", c.DetachKeys(""))
+	assert.Equal(t, "ctrl-p,ctrl-q", c.DetachKeys("ctrl-p,ctrl-q"))
+
+	c = NewCli().SetFlags()
+	assert.NoError(t, c.rootCmd.PersistentFlags().Set("host", "unix:///run/pouchd.sock"))
+	assert.NoError(t, c.LoadConfigFile())
+	assert.NoError(t, c.NewAPIClient())
+	assert.Equal(t, "unix:///run/pouchd.sock", c.Host())
+
+	// the invalid config file is reported instead of using the defaults.
+	assert.NoError(t, ioutil.WriteFile(fileName, []byte(`{"host": }`), 0600))
+	c = NewCli().SetFlags()
+	err = c.LoadConfigFile()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), fileName)
+	}
+}
+
+func TestDebugFromEnv(t *testing.T) {
+	defer os.Unsetenv(envDebug)
+	defer os.Unsetenv(envDebugBodyLimit)
+	defer logrus.SetLevel(logrus.GetLevel())
+
+	// POUCH_DEBUG enables the debug mode if --debug is not set.
+	c := NewCli().SetFlags()
+	os.Setenv(envDebug, "1")
+	c.InitLog()
+	assert.True(t, c.Option.Debug)
+
+	c = NewCli().SetFlags()
+	assert.NoError(t, c.rootCmd.PersistentFlags().Set("debug", "false"))
+	c.InitLog()
+	assert.False(t, c.Option.Debug)
+
+	// the requests are printed by the debug transport in debug mode.
+	c = NewCli().SetFlags()
+	c.Option.Debug = true
+	assert.NoError(t, c.NewAPIClient())
+	_, ok := c.APIClient.(*client.APIClient).HTTPCli.Transport.(*client.DebugTransport)
+	assert.True(t, ok)
+
+	assert.Equal(t, client.DefaultDebugBodyLimit, debugBodyLimit())
+	os.Setenv(envDebugBodyLimit, "4096")
+	assert.Equal(t, 4096, debugBodyLimit())
+}
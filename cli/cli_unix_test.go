@@ -0,0 +1,53 @@
+// +build !windows
+
+package main
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWithInterrupt(t *testing.T) {
+	ctx, cancel := contextWithInterrupt(context.Background())
+	defer cancel()
+
+	assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGINT))
+	select {
+	case <-ctx.Done():
+		assert.Equal(t, context.Canceled, ctx.Err())
+	case <-time.After(5 * time.Second):
+		t.Fatal("context should be cancelled by SIGINT")
+	}
+}
+
+func TestCliContextCancelled(t *testing.T) {
+	c := NewCli()
+	assert.Equal(t, context.Background(), c.Context())
+
+	// the command fails because of Ctrl-C exits with the cancelled code.
+	c.rootCmd.SilenceErrors = true
+	c.rootCmd.SilenceUsage = true
+	c.rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+			return err
+		}
+		select {
+		case <-c.Context().Done():
+			return c.Context().Err()
+		case <-time.After(5 * time.Second):
+			return nil
+		}
+	}
+	c.rootCmd.SetArgs([]string{})
+
+	err := c.Run()
+	exitErr, ok := err.(ExitError)
+	assert.True(t, ok)
+	assert.Equal(t, exitCodeCancelled, exitErr.Code)
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"os"
 
@@ -12,13 +11,18 @@ import (
 )
 
 // commitDescription is used to describe commit command in detail and auto generate command doc.
-var commitDescription = "commit an image from a container."
+var commitDescription = "commit an image from a container. The changes of container's filesystem " +
+	"are exported as a new layer on top of the container's image. The running container is paused " +
+	"during commit unless --pause=false is given. The Dockerfile instructions ENV, CMD, ENTRYPOINT " +
+	"and EXPOSE can be applied to the config of new image by --change."
 
 // CommitCommand is used to implement 'commit' command.
 type CommitCommand struct {
 	baseCommand
 	author  string
 	message string
+	changes []string
+	pause   bool
 }
 
 // Init initializes CommitCommand command.
@@ -43,11 +47,13 @@ func (cc *CommitCommand) addFlags() {
 
 	flagSet.StringVarP(&cc.author, "author", "a", "", "Image author, eg.(name <email@email.com>)")
 	flagSet.StringVarP(&cc.message, "message", "m", "", "Commit message")
+	flagSet.StringArrayVarP(&cc.changes, "change", "c", nil, "Apply Dockerfile instruction to the created image, only ENV, CMD, ENTRYPOINT and EXPOSE are supported")
+	flagSet.BoolVarP(&cc.pause, "pause", "p", true, "Pause container during commit")
 }
 
 // runCommit is the entry of CommitCommand command.
 func (cc *CommitCommand) runCommit(args []string) error {
-	ctx := context.Background()
+	ctx := cc.cli.Context()
 	apiClient := cc.cli.Client()
 
 	// create commit process.
@@ -73,6 +79,8 @@ func (cc *CommitCommand) runCommit(args []string) error {
 		Tag:        tag,
 		Comment:    cc.message,
 		Author:     cc.author,
+		Changes:    cc.changes,
+		Pause:      cc.pause,
 	}
 
 	respCommit, err := apiClient.ContainerCommit(ctx, id, commitConfig)
@@ -88,5 +96,7 @@ func (cc *CommitCommand) runCommit(args []string) error {
 func commitExample() string {
 	return `$ pouch commit 25bf50 test:image
 1c7e415csa333
+$ pouch commit -m "added tooling" -c "ENV DEBUG=true" -c 'CMD ["top"]' -c "EXPOSE 8080" 25bf50 test:tooling
+d5c3ae14f7c2
 `
 }
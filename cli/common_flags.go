@@ -8,7 +8,7 @@ import (
 )
 
 func addCommonFlags(flagSet *pflag.FlagSet) *container {
-	c := &container{}
+	c := &container{flagSet: flagSet}
 
 	// please add the following flag by name in alphabetical order
 	// blkio
@@ -20,43 +20,58 @@ func addCommonFlags(flagSet *pflag.FlagSet) *container {
 	flagSet.Var(&c.blkioDeviceWriteIOps, "device-write-iops", "Limit write rate (IO per second) from a device")
 
 	// capbilities
-	flagSet.StringSliceVar(&c.capAdd, "cap-add", nil, "Add Linux capabilities")
-	flagSet.StringSliceVar(&c.capDrop, "cap-drop", nil, "Drop Linux capabilities")
+	flagSet.StringSliceVar(&c.capAdd, "cap-add", nil, "Add Linux capabilities, the name is case insensitive with or without CAP_ prefix, ALL adds all capabilities")
+	flagSet.StringSliceVar(&c.capDrop, "cap-drop", nil, "Drop Linux capabilities, the name is case insensitive with or without CAP_ prefix, ALL drops all capabilities")
 
 	// cpu
 	flagSet.Int64Var(&c.cpushare, "cpu-shares", 0, "CPU shares (relative weight)")
+	flagSet.StringVar(&c.cpus, "cpus", "", "Number of CPUs, such as 1.5, which is converted into CPU CFS quota of 100ms period and can't be used with --cpu-quota or --cpu-period")
 	flagSet.StringVar(&c.cpusetcpus, "cpuset-cpus", "", "CPUs in which to allow execution (0-3, 0,1)")
 	flagSet.StringVar(&c.cpusetmems, "cpuset-mems", "", "MEMs in which to allow execution (0-3, 0,1)")
 	flagSet.Int64Var(&c.cpuperiod, "cpu-period", 0, "Limit CPU CFS (Completely Fair Scheduler) period, range is in [1000(1ms),1000000(1s)]")
 	flagSet.Int64Var(&c.cpuquota, "cpu-quota", 0, "Limit CPU CFS (Completely Fair Scheduler) quota, range is in [1000,∞)")
 
 	// device related options
-	flagSet.StringSliceVarP(&c.devices, "device", "", nil, "Add a host device to the container")
+	flagSet.StringSliceVarP(&c.devices, "device", "", nil, "Add a host device to the container, format is PATH_ON_HOST[:PATH_IN_CONTAINER[:PERMISSIONS]], such as /dev/fuse:/dev/fuse:rwm")
+	flagSet.StringArrayVar(&c.deviceCgroupRules, "device-cgroup-rule", nil, "Add a rule to the cgroup allowed devices list, such as 'c 13:* rwm'")
+
+	flagSet.StringArrayVar(&c.extraHosts, "add-host", nil, "Add a custom host-to-IP mapping to /etc/hosts of container, format is host:ip")
 
 	// dns
-	flagSet.StringArrayVar(&c.dns, "dns", nil, "Set DNS servers")
+	flagSet.StringArrayVar(&c.dns, "dns", nil, "Set DNS servers, the nameservers of host are used if not set")
 	flagSet.StringSliceVar(&c.dnsOptions, "dns-option", nil, "Set DNS options")
 	flagSet.StringArrayVar(&c.dnsSearch, "dns-search", nil, "Set DNS search domains")
 
 	flagSet.BoolVar(&c.enableLxcfs, "enableLxcfs", false, "Enable lxcfs for the container, only effective when enable-lxcfs switched on in Pouchd")
-	flagSet.StringVar(&c.entrypoint, "entrypoint", "", "Overwrite the default ENTRYPOINT of the image")
-	flagSet.StringArrayVarP(&c.env, "env", "e", nil, "Set environment variables for container('--env A=' means setting env A to empty, '--env B' means removing env B from container env inherited from image)")
+	flagSet.StringVar(&c.entrypoint, "entrypoint", "", "Overwrite the default ENTRYPOINT of the image, an empty string resets it")
+	flagSet.StringArrayVarP(&c.env, "env", "e", nil, "Set environment variables for container('--env A=' means setting env A to empty, '--env B' means passing env B from client, or removing it from container env inherited from image if client doesn't have it)")
+	flagSet.StringArrayVar(&c.envFile, "env-file", nil, "Read environment variables for container from file of KEY=VALUE lines, the envs set by --env override the ones in file")
 	flagSet.StringVar(&c.hostname, "hostname", "", "Set container's hostname")
+
+	// healthcheck
+	flagSet.StringVar(&c.healthCmd, "health-cmd", "", "Command to run to check health")
+	flagSet.DurationVar(&c.healthInterval, "health-interval", 0, "Time between running the check (ms|s|m|h), default 30s")
+	flagSet.DurationVar(&c.healthTimeout, "health-timeout", 0, "Maximum time to allow one check to run (ms|s|m|h), default 30s")
+	flagSet.DurationVar(&c.healthStartPeriod, "health-start-period", 0, "Start period for the container to initialize before counting retries towards unstable (ms|s|m|h)")
+	flagSet.IntVar(&c.healthRetries, "health-retries", 0, "Consecutive failures needed to report unhealthy, default 3")
+	flagSet.BoolVar(&c.noHealthcheck, "no-healthcheck", false, "Disable any container-specified HEALTHCHECK")
 	flagSet.BoolVar(&c.disableNetworkFiles, "disable-network-files", false, "Disable the generation of network files(/etc/hostname, /etc/hosts and /etc/resolv.conf) for container. If true, no network files will be generated. Default false")
 
 	// Intel RDT
 	flagSet.StringVar(&c.IntelRdtL3Cbm, "intel-rdt-l3-cbm", "", "Limit container resource for Intel RDT/CAT which introduced in Linux 4.10 kernel")
 
-	flagSet.StringVar(&c.ipcMode, "ipc", "", "IPC namespace to use")
-	flagSet.StringArrayVarP(&c.labels, "label", "l", nil, "Set labels for a container")
+	flagSet.StringVar(&c.ipcMode, "ipc", "", "IPC namespace to use, valid choices are host, private, shareable (default) or container:<id|name> to join the IPC namespace of a running container")
+	flagSet.StringArrayVarP(&c.labels, "label", "l", nil, "Set labels for a container, the labels are immutable after the container is created")
+	flagSet.StringArrayVar(&c.labelFiles, "label-file", nil, "Read labels for a container from file of key=value lines, the labels set by --label override the ones in file")
 
 	// log driver and log options
 	flagSet.StringVar(&c.logDriver, "log-driver", types.LogConfigLogDriverJSONFile, "Logging driver for the container")
 	flagSet.StringArrayVar(&c.logOpts, "log-opt", nil, "Log driver options")
 
 	// memory
-	flagSet.StringVarP(&c.memory, "memory", "m", "", "Memory limit")
-	flagSet.StringVar(&c.memorySwap, "memory-swap", "", "Swap limit equal to memory + swap, '-1' to enable unlimited swap")
+	flagSet.StringVarP(&c.memory, "memory", "m", "", "Memory limit, such as 512m or 2g")
+	flagSet.StringVar(&c.memorySwap, "memory-swap", "", "Swap limit equal to memory + swap which should not be less than memory, '-1' to enable unlimited swap")
+	flagSet.StringVar(&c.memoryReservation, "memory-reservation", "", "Memory soft limit, which should not be greater than memory")
 	flagSet.Int64Var(&c.memorySwappiness, "memory-swappiness", 0, "Container memory swappiness [0, 100]")
 	flagSet.StringVar(&c.kernelMemory, "kernel-memory", "", "Kernel memory limit (in bytes)")
 	// for alikernel isolation options
@@ -66,40 +81,47 @@ func addCommonFlags(flagSet *pflag.FlagSet) *container {
 	flagSet.StringVar(&c.name, "name", "", "Specify name of container")
 	flagSet.StringVar(&c.specificID, "specific-id", "", "Specify id of container, length of id should be 64, characters of id should be in '0123456789abcdef'")
 
-	flagSet.StringSliceVar(&c.networks, "net", nil, "Set networks to container")
-	flagSet.StringSliceVarP(&c.ports, "publish", "p", nil, "Set container ports mapping")
+	flagSet.StringSliceVar(&c.networks, "net", nil, "Set networks to container, such as bridge, host, none or container:<id|name> to join the network namespace of a running container")
+	flagSet.StringSliceVar(&c.networkAliases, "network-alias", nil, "Add network-scoped alias for the container, which is resolved by the containers on the same user defined network")
+	flagSet.StringSliceVarP(&c.ports, "publish", "p", nil, "Publish container ports to host, format is: [ip:][hostPort[-hostPortEnd]:]containerPort[-containerPortEnd][/proto], a random host port is used if hostPort is omitted")
 	flagSet.StringSliceVar(&c.expose, "expose", nil, "Set expose container's ports")
 	flagSet.BoolVarP(&c.publishAll, "publish-all", "P", false, "Publish all exposed ports to random ports")
-	flagSet.StringVar(&c.macAddress, "mac-address", "", "Set mac address of container endpoint")
+	flagSet.StringVar(&c.ipAddress, "ip", "", "Set static IPv4 address of container on the user defined network, which is kept across restarts")
+	flagSet.StringVar(&c.ipv6Address, "ip6", "", "Set static IPv6 address of container on the user defined network, which is kept across restarts")
+	flagSet.StringVar(&c.macAddress, "mac-address", "", "Set mac address of container endpoint, such as 02:42:ac:11:00:02")
 
-	flagSet.StringVar(&c.pidMode, "pid", "", "PID namespace to use")
-	flagSet.BoolVar(&c.privileged, "privileged", false, "Give extended privileges to the container")
+	flagSet.StringVar(&c.pidMode, "pid", "", "PID namespace to use, valid choices are host or container:<id|name> to join the PID namespace of a running container")
+	flagSet.BoolVar(&c.privileged, "privileged", false, "Give extended privileges to the container, with all capabilities, access to all host devices, and no seccomp or apparmor profile")
 
-	flagSet.StringVar(&c.restartPolicy, "restart", "", "Restart policy to apply when container exits")
-	flagSet.StringVar(&c.runtime, "runtime", "", "OCI runtime to use for this container")
+	flagSet.StringVar(&c.restartPolicy, "restart", "", "Restart policy to apply when container exits, no, always, on-failure[:max-retries] or unless-stopped")
+	flagSet.StringVar(&c.runtime, "runtime", "", "OCI runtime to use for this container, which must be registered to daemon and listed by pouch info, the default runtime of daemon is used if not set")
 
-	flagSet.StringSliceVar(&c.securityOpt, "security-opt", nil, "Security Options")
+	flagSet.StringSliceVar(&c.securityOpt, "security-opt", nil, "Security options, such as seccomp=/path/to/profile.json, seccomp=unconfined, apparmor=profile and no-new-privileges")
 
-	flagSet.StringSliceVar(&c.sysctls, "sysctl", nil, "Sysctl options")
+	flagSet.StringArrayVar(&c.sysctls, "sysctl", nil, "Set namespaced kernel parameters of container, format is key=value, such as net.core.somaxconn=1024, only kernel.msg*, kernel.sem, kernel.shm*, fs.mqueue.* and net.* are allowed")
 	flagSet.BoolVarP(&c.tty, "tty", "t", false, "Allocate a pseudo-TTY")
 
 	// user
-	flagSet.StringVarP(&c.user, "user", "u", "", "UID")
+	flagSet.StringVarP(&c.user, "user", "u", "", "Username or UID (format: <name|uid>[:<group|gid>]), the name is resolved in the /etc/passwd and /etc/group of image")
 
-	flagSet.StringSliceVar(&c.groupAdd, "group-add", nil, "Add additional groups to join")
+	flagSet.StringSliceVar(&c.groupAdd, "group-add", nil, "Add additional groups to join, the group is a name resolved in the /etc/group of image or a gid")
 
-	flagSet.StringVar(&c.utsMode, "uts", "", "UTS namespace to use")
+	flagSet.StringVar(&c.utsMode, "uts", "", "UTS namespace to use, valid choice is host")
 
 	flagSet.VarP(config.NewVolumes(&c.volume), "volume", "v", "Bind mount volumes to container, format is: [source:]<destination>[:mode], [source] can be volume or host's path, <destination> is container's path, [mode] can be \"ro/rw/dr/rr/z/Z/nocopy/private/rprivate/slave/rslave/shared/rshared\"")
 	flagSet.StringSliceVar(&c.volumesFrom, "volumes-from", nil, "set volumes from other containers, format is <container>[:mode]")
+	flagSet.StringArrayVar(&c.mounts, "mount", nil, "Attach a filesystem mount to container, format is: type=<bind|volume|tmpfs>,source=<src>,target=<dst>[,readonly], options can be \"bind-propagation\", \"volume-nocopy\", \"tmpfs-size\" and \"tmpfs-mode\"")
+	flagSet.StringArrayVar(&c.tmpfs, "tmpfs", nil, "Mount a tmpfs directory to container, format is: /path[:options], such as /run:rw,noexec,size=64m")
+	flagSet.BoolVar(&c.readOnly, "read-only", false, "Mount the container's root filesystem as read only, /dev, /proc, volumes and tmpfs mounts are still writable")
 
-	flagSet.StringVarP(&c.workdir, "workdir", "w", "", "Set the working directory in a container")
-	flagSet.Var(&c.ulimit, "ulimit", "Set container ulimit")
+	flagSet.StringVarP(&c.workdir, "workdir", "w", "", "Set the working directory in a container, which is created if it does not exist")
+	flagSet.Var(&c.ulimit, "ulimit", "Set container ulimit, format is name=soft[:hard], hard is equal to soft if not set, such as nofile=1024:65535")
 	flagSet.Int64Var(&c.pidsLimit, "pids-limit", 0, "Set container pids limit")
 
 	flagSet.BoolVar(&c.rich, "rich", false, "Start container in rich container mode. (default false)")
 	flagSet.StringVar(&c.richMode, "rich-mode", "", "Choose one rich container mode. dumb-init(default), systemd, sbin-init")
 	flagSet.StringVar(&c.initScript, "initscript", "", "Initial script executed in container")
+	flagSet.BoolVar(&c.init, "init", false, "Run an init as the pid 1 of container, which reaps zombies and forwards signals to the command even if the command is an init, the default of daemon is used if not set")
 	flagSet.StringVar(&c.shmSize, "shm-size", "", "Size of /dev/shm, default value is 64MB")
 	flagSet.Int64Var(&c.netPriority, "net-priority", 0, "net priority")
 
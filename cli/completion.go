@@ -0,0 +1,384 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// completionDescription is used to describe completion command in detail and auto generate command doc.
+var completionDescription = "Output shell completion code for bash or zsh, which is generated from the commands and flags of pouch. " +
+	"The names of containers, images, volumes and networks are completed by querying pouchd, " +
+	"and nothing is completed if pouchd is unreachable. " +
+	"The bash completion depends on the bash-completion package."
+
+// completionShells are the shells supported by completion command.
+var completionShells = map[string]func(out io.Writer, root *cobra.Command) error{
+	"bash": runCompletionBash,
+	"zsh":  runCompletionZsh,
+}
+
+// completionFlagFuncs are the functions completing the values of flags,
+// which are enumerations or names got from pouchd.
+var completionFlagFuncs = map[string]string{
+	"restart":    "__pouch_get_restart_policies",
+	"net":        "__pouch_get_network_modes",
+	"pid":        "__pouch_get_namespace_modes",
+	"ipc":        "__pouch_get_namespace_modes",
+	"uts":        "__pouch_get_uts_modes",
+	"log-driver": "__pouch_get_log_drivers",
+}
+
+// CompletionCommand is used to implement 'completion' command.
+type CompletionCommand struct {
+	baseCommand
+}
+
+// Init initializes completion command.
+func (cc *CompletionCommand) Init(c *Cli) {
+	cc.cli = c
+	cc.cmd = &cobra.Command{
+		Use:       "completion SHELL",
+		Short:     "Output shell completion code for bash or zsh",
+		Long:      completionDescription,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.runCompletion(args)
+		},
+		Example: completionExample(),
+	}
+}
+
+// runCompletion is the entry of completion command.
+func (cc *CompletionCommand) runCompletion(args []string) error {
+	run, ok := completionShells[args[0]]
+	if !ok {
+		return fmt.Errorf("unsupported shell type %q, valid choices are bash or zsh", args[0])
+	}
+
+	root := cc.cmd.Root()
+	root.BashCompletionFunction = bashCompletionFunc
+	annotateCompletionFlags(root)
+
+	return run(os.Stdout, root)
+}
+
+// annotateCompletionFlags sets the completion functions of flags of the
+// command and its sub commands.
+func annotateCompletionFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if f, ok := completionFlagFuncs[flag.Name]; ok {
+			cobra.MarkFlagCustom(cmd.Flags(), flag.Name, f)
+		}
+	})
+	for _, sub := range cmd.Commands() {
+		annotateCompletionFlags(sub)
+	}
+}
+
+// runCompletionBash outputs the bash completion code.
+func runCompletionBash(out io.Writer, root *cobra.Command) error {
+	return root.GenBashCompletion(out)
+}
+
+// runCompletionZsh outputs the zsh completion code, which runs the bash
+// completion code with bashcompinit of zsh, since the zsh completion of
+// cobra doesn't complete flags and names.
+func runCompletionZsh(out io.Writer, root *cobra.Command) error {
+	if _, err := io.WriteString(out, zshCompletionHead); err != nil {
+		return err
+	}
+	if err := root.GenBashCompletion(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, zshCompletionTail)
+	return err
+}
+
+// bashCompletionFunc completes the names of containers, images, volumes and
+// networks in the arguments of commands, the errors of pouch are dropped so
+// that nothing is completed if pouchd is unreachable.
+const bashCompletionFunc = `
+__pouch_override_flags()
+{
+    local f v
+    for f in --host -H --tlscacert --tlscert --tlskey; do
+        v="${flaghash[${f}=]:-${flaghash[${f}]}}"
+        if [ -n "${v}" ]; then
+            echo "${f}=${v}"
+        fi
+    done
+    for f in --tls --tlsverify; do
+        if [ -n "${flaghash[${f}]}" ]; then
+            echo "${f}"
+        fi
+    done
+}
+
+__pouch_exec()
+{
+    pouch $(__pouch_override_flags) "$@" 2>/dev/null
+}
+
+__pouch_complete_words()
+{
+    if [ -n "$1" ]; then
+        COMPREPLY=( $( compgen -W "$1" -- "$cur" ) )
+    fi
+}
+
+__pouch_get_containers()
+{
+    local pouch_out
+    if pouch_out=$(__pouch_exec ps --format '{{.Names}}' "$@"); then
+        __pouch_complete_words "${pouch_out}"
+    fi
+}
+
+__pouch_get_images()
+{
+    local pouch_out
+    if pouch_out=$(__pouch_exec images --format '{{.Name}}:{{.Tag}}'); then
+        __pouch_complete_words "$(echo "${pouch_out}" | grep -v '<none>')"
+    fi
+}
+
+__pouch_get_volumes()
+{
+    local pouch_out
+    if pouch_out=$(__pouch_exec volume ls -q); then
+        __pouch_complete_words "${pouch_out}"
+    fi
+}
+
+__pouch_get_networks()
+{
+    local pouch_out
+    if pouch_out=$(__pouch_exec network ls); then
+        __pouch_complete_words "$(echo "${pouch_out}" | awk 'NR > 1 {print $2}')"
+    fi
+}
+
+__pouch_get_restart_policies()
+{
+    COMPREPLY=( $( compgen -W "no always on-failure on-failure: unless-stopped" -- "$cur" ) )
+}
+
+__pouch_get_network_modes()
+{
+    __pouch_get_networks
+    COMPREPLY+=( $( compgen -W "bridge host none container:" -- "$cur" ) )
+}
+
+__pouch_get_namespace_modes()
+{
+    COMPREPLY=( $( compgen -W "host container:" -- "$cur" ) )
+}
+
+__pouch_get_uts_modes()
+{
+    COMPREPLY=( $( compgen -W "host" -- "$cur" ) )
+}
+
+__pouch_get_log_drivers()
+{
+    COMPREPLY=( $( compgen -W "json-file syslog journald fluentd none" -- "$cur" ) )
+}
+
+__custom_func()
+{
+    case ${last_command} in
+        pouch_stop | pouch_kill | pouch_pause | pouch_top | pouch_stats | pouch_attach)
+            __pouch_get_containers --filter status=running
+            ;;
+        pouch_exec | pouch_logs | pouch_port | pouch_checkpoint_create)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __pouch_get_containers --filter status=running
+            fi
+            ;;
+        pouch_rm | pouch_start | pouch_restart | pouch_unpause | pouch_inspect | pouch_wait | pouch_diff | pouch_export | pouch_update)
+            __pouch_get_containers --all
+            ;;
+        pouch_rename | pouch_commit | pouch_upgrade)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __pouch_get_containers --all
+            fi
+            ;;
+        pouch_run | pouch_create | pouch_history | pouch_tag)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __pouch_get_images
+            fi
+            ;;
+        pouch_rmi | pouch_save | pouch_push | pouch_image_inspect)
+            __pouch_get_images
+            ;;
+        pouch_volume_inspect | pouch_volume_remove)
+            __pouch_get_volumes
+            ;;
+        pouch_network_inspect | pouch_network_remove)
+            __pouch_get_networks
+            ;;
+        pouch_network_connect | pouch_network_disconnect)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __pouch_get_networks
+            elif [[ ${#nouns[@]} -eq 1 ]]; then
+                __pouch_get_containers --all
+            fi
+            ;;
+        *)
+            ;;
+    esac
+}
+`
+
+// zshCompletionHead defines the functions of bash-completion missing in
+// zsh, and converts the bash completion code to be sourced by zsh.
+const zshCompletionHead = `#compdef pouch
+
+__pouch_bash_source()
+{
+    alias shopt=':'
+    emulate -L sh
+    setopt kshglob noshglob braceexpand
+
+    source "$@"
+}
+
+__pouch_type()
+{
+    # -t is not supported by zsh
+    if [ "$1" = "-t" ]; then
+        shift
+
+        # fake Bash 4 to disable "complete -o nospace", the trailing
+        # spaces are left on all the time in zsh.
+        if [ "$1" = "__pouch_compopt" ]; then
+            echo builtin
+            return 0
+        fi
+    fi
+    type "$@"
+}
+
+__pouch_compgen()
+{
+    local completions w
+    completions=( $(compgen "$@") ) || return $?
+
+    # filter by given word as prefix
+    while [[ "$1" = -* && "$1" != -- ]]; do
+        shift
+        shift
+    done
+    if [[ "$1" == -- ]]; then
+        shift
+    fi
+    for w in "${completions[@]}"; do
+        if [[ "${w}" = "$1"* ]]; then
+            echo "${w}"
+        fi
+    done
+}
+
+__pouch_compopt()
+{
+    true # not supported by bashcompinit of zsh
+}
+
+__pouch_ltrim_colon_completions()
+{
+    if [[ "$1" == *:* && "$COMP_WORDBREAKS" == *:* ]]; then
+        # remove colon-word prefix from COMPREPLY items
+        local colon_word=${1%${1##*:}}
+        local i=${#COMPREPLY[*]}
+        while [[ $((--i)) -ge 0 ]]; do
+            COMPREPLY[$i]=${COMPREPLY[$i]#"$colon_word"}
+        done
+    fi
+}
+
+__pouch_get_comp_words_by_ref()
+{
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[${COMP_CWORD}-1]}"
+    words=("${COMP_WORDS[@]}")
+    cword=("${COMP_CWORD[@]}")
+}
+
+__pouch_filedir()
+{
+    local RET OLD_IFS w
+    OLD_IFS="$IFS"
+    IFS=$'\n'
+    if [ "$1" = "-d" ]; then
+        shift
+        RET=( $(compgen -d) )
+    else
+        RET=( $(compgen -f) )
+    fi
+    IFS="$OLD_IFS"
+    for w in ${RET[@]}; do
+        if [[ ! "${w}" = "${cur}"* ]]; then
+            continue
+        fi
+        if eval "[[ \"\${w}\" = *.$1 || -d \"\${w}\" ]]"; then
+            if [ -d "${w}" ]; then
+                COMPREPLY+=("$(printf %q "${w}")/")
+            else
+                COMPREPLY+=("$(printf %q "${w}")")
+            fi
+        fi
+    done
+}
+
+autoload -U +X bashcompinit && bashcompinit
+
+# use word boundary patterns for BSD or GNU sed
+LWORD='[[:<:]]'
+RWORD='[[:>:]]'
+if sed --help 2>&1 | grep -q GNU; then
+    LWORD='\<'
+    RWORD='\>'
+fi
+
+__pouch_convert_bash_to_zsh()
+{
+    sed \
+    -e 's/declare -F/whence -w/' \
+    -e 's/_get_comp_words_by_ref "\$@"/_get_comp_words_by_ref "\$*"/' \
+    -e 's/local \([a-zA-Z0-9_]*\)=/local \1; \1=/' \
+    -e 's/flags+=("\(--.*\)=")/flags+=("\1"); two_word_flags+=("\1")/' \
+    -e 's/must_have_one_flag+=("\(--.*\)=")/must_have_one_flag+=("\1")/' \
+    -e "s/${LWORD}_filedir${RWORD}/__pouch_filedir/g" \
+    -e "s/${LWORD}_get_comp_words_by_ref${RWORD}/__pouch_get_comp_words_by_ref/g" \
+    -e "s/${LWORD}__ltrim_colon_completions${RWORD}/__pouch_ltrim_colon_completions/g" \
+    -e "s/${LWORD}compgen${RWORD}/__pouch_compgen/g" \
+    -e "s/${LWORD}compopt${RWORD}/__pouch_compopt/g" \
+    -e "s/${LWORD}declare${RWORD}/builtin declare/g" \
+    -e "s/\\\$(type${RWORD}/\$(__pouch_type/g" \
+    <<'BASH_COMPLETION_EOF'
+`
+
+// zshCompletionTail ends the bash completion code in zsh completion code.
+const zshCompletionTail = `
+BASH_COMPLETION_EOF
+}
+
+__pouch_bash_source <(__pouch_convert_bash_to_zsh)
+`
+
+// completionExample shows examples in completion command, and is used in auto-generated cli docs.
+func completionExample() string {
+	return `# Load the completion code for bash into the current shell
+$ source <(pouch completion bash)
+
+# Load the completion code for bash at login
+$ pouch completion bash > /etc/bash_completion.d/pouch
+
+# Load the completion code for zsh into the current shell
+$ source <(pouch completion zsh)`
+}
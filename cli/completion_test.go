@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCompletionTestRoot() *cobra.Command {
+	root := &cobra.Command{Use: "pouch"}
+	run := &cobra.Command{Use: "run", Run: func(cmd *cobra.Command, args []string) {}}
+	run.Flags().String("restart", "", "")
+	run.Flags().StringSlice("net", nil, "")
+	run.Flags().String("name", "", "")
+	root.AddCommand(run)
+
+	root.BashCompletionFunction = bashCompletionFunc
+	annotateCompletionFlags(root)
+	return root
+}
+
+func TestRunCompletionBash(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runCompletionBash(out, newCompletionTestRoot()))
+
+	script := out.String()
+	assert.Contains(t, script, "__custom_func()")
+	assert.Contains(t, script, `flags_completion+=("__pouch_get_restart_policies")`)
+	assert.Contains(t, script, `flags_completion+=("__pouch_get_network_modes")`)
+	assert.Equal(t, 2, strings.Count(script, "flags_completion+=("))
+}
+
+func TestRunCompletionZsh(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runCompletionZsh(out, newCompletionTestRoot()))
+
+	script := out.String()
+	assert.True(t, strings.HasPrefix(script, "#compdef pouch\n"))
+	assert.Contains(t, script, "__start_pouch()")
+	assert.True(t, strings.HasSuffix(script, "__pouch_bash_source <(__pouch_convert_bash_to_zsh)\n"))
+}
@@ -2,22 +2,28 @@ package main
 
 import (
 	"strings"
+	"time"
 
 	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/opts/config"
 	"github.com/alibaba/pouch/apis/types"
 
 	"github.com/go-openapi/strfmt"
+	"github.com/spf13/pflag"
 )
 
 type container struct {
+	flagSet             *pflag.FlagSet
 	labels              []string
+	labelFiles          []string
 	name                string
 	tty                 bool
 	volume              config.Volumes
 	volumesFrom         []string
+	mounts              []string
 	runtime             string
 	env                 []string
+	envFile             []string
 	entrypoint          string
 	workdir             string
 	user                string
@@ -35,15 +41,17 @@ type container struct {
 	blkioDeviceWriteIOps config.ThrottleIOpsDevice
 
 	cpushare   int64
+	cpus       string
 	cpusetcpus string
 	cpusetmems string
 	cpuperiod  int64
 	cpuquota   int64
 
-	memory           string
-	memorySwap       string
-	memorySwappiness int64
-	kernelMemory     string
+	memory            string
+	memorySwap        string
+	memoryReservation string
+	memorySwappiness  int64
+	kernelMemory      string
 
 	memoryWmarkRatio    int64
 	memoryExtra         int64
@@ -51,10 +59,18 @@ type container struct {
 	scheLatSwitch       int64
 	oomKillDisable      bool
 
+	extraHosts []string
 	dns        []string
 	dnsOptions []string
 	dnsSearch  []string
 
+	deviceCgroupRules []string
+
+	tmpfs    []string
+	readOnly bool
+
+	init bool
+
 	devices        []string
 	enableLxcfs    bool
 	privileged     bool
@@ -64,6 +80,9 @@ type container struct {
 	utsMode        string
 	sysctls        []string
 	networks       []string
+	networkAliases []string
+	ipAddress      string
+	ipv6Address    string
 	ports          []string
 	expose         []string
 	publishAll     bool
@@ -86,6 +105,14 @@ type container struct {
 	logDriver string
 	logOpts   []string
 
+	// healthcheck
+	healthCmd         string
+	healthInterval    time.Duration
+	healthTimeout     time.Duration
+	healthStartPeriod time.Duration
+	healthRetries     int
+	noHealthcheck     bool
+
 	//add for rich container mode
 	rich       bool
 	richMode   string
@@ -97,7 +124,12 @@ type container struct {
 }
 
 func (c *container) config() (*types.ContainerCreateConfig, error) {
-	labels, err := opts.ParseLabels(c.labels)
+	allLabels, err := readLabels(c.labelFiles, c.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := opts.ParseLabels(allLabels)
 	if err != nil {
 		return nil, err
 	}
@@ -112,11 +144,21 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 		return nil, err
 	}
 
+	memoryReservation, err := opts.ParseMemory(c.memoryReservation)
+	if err != nil {
+		return nil, err
+	}
+
 	kmemory, err := opts.ParseMemory(c.kernelMemory)
 	if err != nil {
 		return nil, err
 	}
 
+	nanoCPUs, err := opts.ParseCPUs(c.cpus)
+	if err != nil {
+		return nil, err
+	}
+
 	intelRdtL3Cbm, err := opts.ParseIntelRdt(c.IntelRdtL3Cbm)
 	if err != nil {
 		return nil, err
@@ -127,6 +169,12 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 		return nil, err
 	}
 
+	for _, rule := range c.deviceCgroupRules {
+		if _, err := opts.ParseDeviceCgroupRule(rule); err != nil {
+			return nil, err
+		}
+	}
+
 	restartPolicy, err := opts.ParseRestartPolicy(c.restartPolicy)
 	if err != nil {
 		return nil, err
@@ -141,6 +189,10 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 		return nil, err
 	}
 
+	if err := opts.ValidateSysctls(sysctls); err != nil {
+		return nil, err
+	}
+
 	diskQuota, err := opts.ParseDiskQuota(c.diskQuota)
 	if err != nil {
 		return nil, err
@@ -165,6 +217,14 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 		return nil, err
 	}
 
+	if err := opts.ParseNetworkAliases(networkingConfig, networkMode, c.networkAliases); err != nil {
+		return nil, err
+	}
+
+	if err := opts.ParseNetworkIPs(networkingConfig, networkMode, c.ipAddress, c.ipv6Address); err != nil {
+		return nil, err
+	}
+
 	if err := opts.ValidateNetworks(networkingConfig); err != nil {
 		return nil, err
 	}
@@ -188,16 +248,48 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	envs, err := parseEnvs(c.envFile, c.env)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := opts.ValidateExtraHosts(c.extraHosts); err != nil {
+		return nil, err
+	}
+
+	if err := opts.ValidateDNS(c.dns); err != nil {
+		return nil, err
+	}
+
+	mounts, err := opts.ParseMounts(c.mounts)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpfs, err := opts.ParseTmpfs(c.tmpfs)
+	if err != nil {
+		return nil, err
+	}
+
 	shmSize, err := opts.ParseShmSize(c.shmSize)
 	if err != nil {
 		return nil, err
 	}
 
+	healthcheck, err := opts.ParseHealthcheck(c.healthCmd, c.healthInterval, c.healthTimeout, c.healthStartPeriod, c.healthRetries, c.noHealthcheck)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := opts.ValidateHealthcheck(healthcheck); err != nil {
+		return nil, err
+	}
+
 	config := &types.ContainerCreateConfig{
 		ContainerConfig: types.ContainerConfig{
 			Tty:                 c.tty,
-			Env:                 c.env,
-			Entrypoint:          strings.Fields(c.entrypoint),
+			Env:                 envs,
+			Entrypoint:          c.parseEntrypoint(),
 			WorkingDir:          c.workdir,
 			User:                c.user,
 			Hostname:            strfmt.Hostname(c.hostname),
@@ -213,31 +305,36 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 			NetPriority:         c.netPriority,
 			SpecificID:          c.specificID,
 			MacAddress:          c.macAddress,
+			Healthcheck:         healthcheck,
 		},
 
 		HostConfig: &types.HostConfig{
+			AutoRemove:  c.rm,
 			Binds:       c.volume.Value(),
+			Mounts:      mounts,
 			VolumesFrom: c.volumesFrom,
 			Runtime:     c.runtime,
 			Resources: types.Resources{
 				// cpu
 				CPUShares:  c.cpushare,
+				NanoCpus:   nanoCPUs,
 				CpusetCpus: c.cpusetcpus,
 				CpusetMems: c.cpusetmems,
 				CPUPeriod:  c.cpuperiod,
 				CPUQuota:   c.cpuquota,
 
 				// memory
-				Memory:           memory,
-				MemorySwap:       memorySwap,
-				MemorySwappiness: &c.memorySwappiness,
-				KernelMemory:     kmemory,
+				Memory:            memory,
+				MemorySwap:        memorySwap,
+				MemoryReservation: memoryReservation,
+				MemorySwappiness:  c.parseMemorySwappiness(),
+				KernelMemory:      kmemory,
 				// FIXME: validate in client side
 				MemoryWmarkRatio:    &c.memoryWmarkRatio,
 				MemoryExtra:         &c.memoryExtra,
 				MemoryForceEmptyCtl: c.memoryForceEmptyCtl,
 				ScheLatSwitch:       c.scheLatSwitch,
-				OomKillDisable:      &c.oomKillDisable,
+				OomKillDisable:      c.parseOomKillDisable(),
 
 				// blkio
 				BlkioWeight:          c.blkioWeight,
@@ -252,10 +349,13 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 				CgroupParent:  c.cgroupParent,
 				Ulimits:       c.ulimit.Value(),
 				PidsLimit:     c.pidsLimit,
+
+				DeviceCgroupRules: c.deviceCgroupRules,
 			},
 			DNS:             c.dns,
 			DNSOptions:      c.dnsOptions,
 			DNSSearch:       c.dnsSearch,
+			ExtraHosts:      c.extraHosts,
 			EnableLxcfs:     c.enableLxcfs,
 			Privileged:      c.privileged,
 			RestartPolicy:   restartPolicy,
@@ -276,6 +376,11 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 				LogOpts:   logOpts,
 			},
 			ShmSize: &shmSize,
+
+			Tmpfs:          tmpfs,
+			ReadonlyRootfs: c.readOnly,
+
+			Init: c.parseInit(),
 		},
 
 		NetworkingConfig: networkingConfig,
@@ -290,3 +395,40 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 
 	return config, nil
 }
+
+// parseInit returns nil if --init is not set, so that the default of daemon
+// is used.
+func (c *container) parseInit() *bool {
+	if c.flagSet == nil || !c.flagSet.Changed("init") {
+		return nil
+	}
+	return &c.init
+}
+
+// parseMemorySwappiness returns nil if --memory-swappiness is not set, so
+// that no warning is given if the kernel doesn't support it.
+func (c *container) parseMemorySwappiness() *int64 {
+	if c.flagSet == nil || !c.flagSet.Changed("memory-swappiness") {
+		return nil
+	}
+	return &c.memorySwappiness
+}
+
+// parseOomKillDisable returns nil if --oom-kill-disable is not set, so that
+// no warning is given if the kernel doesn't support it.
+func (c *container) parseOomKillDisable() *bool {
+	if c.flagSet == nil || !c.flagSet.Changed("oom-kill-disable") {
+		return nil
+	}
+	return &c.oomKillDisable
+}
+
+// parseEntrypoint splits the entrypoint into fields, the empty entrypoint
+// set explicitly is sent as [""] to reset the entrypoint of image.
+func (c *container) parseEntrypoint() []string {
+	entrypoint := strings.Fields(c.entrypoint)
+	if len(entrypoint) == 0 && c.flagSet != nil && c.flagSet.Changed("entrypoint") {
+		return []string{""}
+	}
+	return entrypoint
+}
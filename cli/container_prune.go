@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// containerPruneDescription is used to describe container prune command in detail and auto generate command doc.
+var containerPruneDescription = "Remove all stopped containers. The running and paused containers are never " +
+	"removed, and the containers are removed in the same way as \"pouch rm\"."
+
+// ContainerPruneCommand use to implement 'container prune' command.
+type ContainerPruneCommand struct {
+	baseCommand
+
+	// flags for container prune command
+	flagForce  bool
+	flagFilter []string
+}
+
+// Init initialize "container prune" command.
+func (p *ContainerPruneCommand) Init(c *Cli) {
+	p.cli = c
+	p.cmd = &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove all stopped containers",
+		Long:  containerPruneDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.runPrune()
+		},
+		Example: containerPruneExample(),
+	}
+	p.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (p *ContainerPruneCommand) addFlags() {
+	flagSet := p.cmd.Flags()
+	flagSet.BoolVarP(&p.flagForce, "force", "f", false, "Do not prompt for confirmation")
+	flagSet.StringSliceVar(&p.flagFilter, "filter", []string{}, "Provide filter values, filter support until=<duration or timestamp>, label=<key>[=<value>] and label!=<key>[=<value>]")
+}
+
+// runPrune is the entry of container prune command.
+func (p *ContainerPruneCommand) runPrune() error {
+	filter, err := filters.FromFilterOpts(p.flagFilter)
+	if err != nil {
+		return err
+	}
+
+	if !p.flagForce && !confirmPrompt(os.Stdin, os.Stdout, "WARNING! This will remove all stopped containers.") {
+		return nil
+	}
+
+	ctx := p.cli.Context()
+	apiClient := p.cli.Client()
+
+	resp, err := apiClient.ContainersPrune(ctx, filter)
+	if err != nil {
+		return errors.Wrap(err, "failed to prune containers")
+	}
+
+	displayContainerPruneResult(os.Stdout, resp)
+	return nil
+}
+
+// displayContainerPruneResult prints the deleted containers and the reclaimed space.
+func displayContainerPruneResult(out io.Writer, resp *types.ContainerPruneResp) {
+	if len(resp.ContainersDeleted) > 0 {
+		fmt.Fprintln(out, "Deleted Containers:")
+		for _, id := range resp.ContainersDeleted {
+			fmt.Fprintln(out, id)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "Total reclaimed space: %s\n", utils.FormatSize(resp.SpaceReclaimed))
+}
+
+// containerPruneExample shows examples in container prune command, and is used in auto-generated cli docs.
+func containerPruneExample() string {
+	return `$ pouch container prune --filter until=24h --filter label=env=test --filter label!=keep=true
+WARNING! This will remove all stopped containers.
+Are you sure you want to continue? [y/N] y
+Deleted Containers:
+4f3a0ba1c4dc8b1e7d3e3b7bfe5854e1c4d7f4a6b9a0e1d5c2f3e4a5b6c7d8e9
+a6c1e2b3d4f5061728394a5b6c7d8e9f0a1b2c3d4e5f60718293a4b5c6d7e8f9
+
+Total reclaimed space: 12.00 KB`
+}
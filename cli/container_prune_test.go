@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayContainerPruneResult(t *testing.T) {
+	out := new(bytes.Buffer)
+	displayContainerPruneResult(out, &types.ContainerPruneResp{
+		ContainersDeleted: []string{"a", "b"},
+		SpaceReclaimed:    1024,
+	})
+	assert.Equal(t, `Deleted Containers:
+a
+b
+
+Total reclaimed space: 1.00 KB
+`, out.String())
+
+	// nothing is deleted
+	out.Reset()
+	displayContainerPruneResult(out, &types.ContainerPruneResp{})
+	assert.Equal(t, "Total reclaimed space: 0.00 B\n", out.String())
+}
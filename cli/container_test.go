@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigUnsetResources(t *testing.T) {
+	c := addCommonFlags(pflag.NewFlagSet("create", pflag.ContinueOnError))
+	assert.NoError(t, c.flagSet.Parse(nil))
+
+	config, err := c.config()
+	assert.NoError(t, err)
+	assert.Nil(t, config.HostConfig.MemorySwappiness)
+	assert.Nil(t, config.HostConfig.OomKillDisable)
+
+	c = addCommonFlags(pflag.NewFlagSet("create", pflag.ContinueOnError))
+	assert.NoError(t, c.flagSet.Parse([]string{"--memory-swappiness", "0", "--oom-kill-disable=false"}))
+
+	config, err = c.config()
+	assert.NoError(t, err)
+	if assert.NotNil(t, config.HostConfig.MemorySwappiness) {
+		assert.Equal(t, int64(0), *config.HostConfig.MemorySwappiness)
+	}
+	if assert.NotNil(t, config.HostConfig.OomKillDisable) {
+		assert.False(t, *config.HostConfig.OomKillDisable)
+	}
+}
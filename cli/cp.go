@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alibaba/pouch/client"
+	"github.com/alibaba/pouch/pkg/archive"
+
+	"github.com/spf13/cobra"
+)
+
+// cpDescription is used to describe cp command in detail and auto generate command doc.
+var cpDescription = "Copy files or folders between a container and the local filesystem, the container can be " +
+	"running or stopped. The path in container is relative to its root directory. A directory is copied " +
+	"with its contents, or only the contents are copied if the source path ends with \"/.\". " +
+	"Use \"-\" as the local path to read a tar archive from STDIN which is extracted to the directory in " +
+	"container, or to write the tar archive of the container path to STDOUT. The symlinks are copied as they " +
+	"are, and the symlinks in the container path are resolved in the scope of the container's filesystem. " +
+	"The running container is paused during the copy."
+
+// CpCommand use to implement 'cp' command, it copies files between container and local filesystem.
+type CpCommand struct {
+	baseCommand
+}
+
+// Init initialize cp command.
+func (cp *CpCommand) Init(c *Cli) {
+	cp.cli = c
+	cp.cmd = &cobra.Command{
+		Use: "cp [OPTIONS] CONTAINER:SRC_PATH DEST_PATH|-\n" +
+			"  pouch cp [OPTIONS] SRC_PATH|- CONTAINER:DEST_PATH",
+		Short: "Copy files or folders between a container and the local filesystem",
+		Long:  cpDescription,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cp.runCp(args)
+		},
+		Example: cpExample(),
+	}
+}
+
+// runCp is the entry of cp command.
+func (cp *CpCommand) runCp(args []string) error {
+	ctx := cp.cli.Context()
+	apiClient := cp.cli.Client()
+
+	srcContainer, srcPath := splitCpArg(args[0])
+	dstContainer, dstPath := splitCpArg(args[1])
+
+	switch {
+	case srcContainer != "" && dstContainer != "":
+		return fmt.Errorf("copying between containers is not supported")
+	case srcContainer != "":
+		return copyFromContainer(ctx, apiClient, srcContainer, containerPath(srcPath), dstPath)
+	case dstContainer != "":
+		return copyToContainer(ctx, apiClient, srcPath, dstContainer, containerPath(dstPath))
+	default:
+		return fmt.Errorf("must specify at least one container source")
+	}
+}
+
+// splitCpArg splits the argument into container and path, the path which is
+// absolute or starts with "." is always a local path even if it has colon.
+func splitCpArg(arg string) (string, string) {
+	if filepath.IsAbs(arg) || strings.HasPrefix(arg, ".") {
+		return "", arg
+	}
+
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) == 1 {
+		return "", arg
+	}
+	return parts[0], parts[1]
+}
+
+// containerPath makes the path in container absolute, and keeps the
+// trailing separator or "/." of it.
+func containerPath(path string) string {
+	if !filepath.IsAbs(path) {
+		return string(filepath.Separator) + path
+	}
+	return path
+}
+
+// localPath makes the local path absolute, and keeps the trailing separator
+// or "/." of it.
+func localPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case archive.IsContentsPath(path):
+		return abs + string(filepath.Separator) + ".", nil
+	case archive.HasTrailingSeparator(path):
+		return abs + string(filepath.Separator), nil
+	}
+	return abs, nil
+}
+
+// copyFromContainer copies the path in container to the local path.
+func copyFromContainer(ctx context.Context, apiClient client.CommonAPIClient, container, srcPath, dstPath string) error {
+	content, stat, err := apiClient.CopyFromContainer(ctx, container, srcPath)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	if dstPath == "-" {
+		_, err = io.Copy(os.Stdout, content)
+		return err
+	}
+
+	if dstPath, err = localPath(dstPath); err != nil {
+		return err
+	}
+
+	srcIsDir := os.FileMode(stat.Mode).IsDir()
+	srcContents := archive.IsContentsPath(srcPath) || filepath.Clean(srcPath) == "/"
+	opts := archive.ExtractOptions{NoOverwriteDirNonDir: true}
+
+	fi, err := os.Stat(dstPath)
+	switch {
+	case err == nil && fi.IsDir():
+		// the source is copied into the directory.
+		return extractTar(content, dstPath, opts)
+	case err == nil:
+		if srcIsDir {
+			return fmt.Errorf("cannot copy a directory to a file %s", dstPath)
+		}
+		opts.Rebase = map[string]string{stat.Name: filepath.Base(dstPath)}
+		return extractTar(content, filepath.Dir(dstPath), opts)
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	// the destination is created as the copy of source.
+	if archive.HasTrailingSeparator(dstPath) && !srcIsDir {
+		return fmt.Errorf("destination directory %s does not exist", dstPath)
+	}
+	dstPath = filepath.Clean(dstPath)
+	if _, err := os.Stat(filepath.Dir(dstPath)); err != nil {
+		return fmt.Errorf("failed to stat the parent directory of destination %s: %v", dstPath, err)
+	}
+
+	if srcContents {
+		if err := os.Mkdir(dstPath, os.FileMode(stat.Mode).Perm()); err != nil {
+			return err
+		}
+		return extractTar(content, dstPath, opts)
+	}
+
+	opts.Rebase = map[string]string{stat.Name: filepath.Base(dstPath)}
+	return extractTar(content, filepath.Dir(dstPath), opts)
+}
+
+// extractTar extracts the archive into the local directory, which is also
+// the root of extraction, so that nothing in the archive can escape it.
+func extractTar(content io.Reader, dir string, opts archive.ExtractOptions) error {
+	opts.Root = dir
+	return archive.ExtractTar(content, string(filepath.Separator), opts)
+}
+
+// copyToContainer copies the local path to the path in container.
+func copyToContainer(ctx context.Context, apiClient client.CommonAPIClient, srcPath, container, dstPath string) error {
+	// the tar archive from stdin is extracted into the directory.
+	if srcPath == "-" {
+		return apiClient.CopyToContainer(ctx, container, dstPath, os.Stdin, true)
+	}
+
+	srcPath, err := localPath(srcPath)
+	if err != nil {
+		return err
+	}
+
+	// the symlink is copied as it is, unless the path means a directory.
+	stat := os.Lstat
+	if archive.HasTrailingSeparator(srcPath) || archive.IsContentsPath(srcPath) {
+		stat = os.Stat
+	}
+	fi, err := stat(srcPath)
+	if err != nil {
+		return err
+	}
+	if archive.HasTrailingSeparator(srcPath) && !fi.IsDir() {
+		return fmt.Errorf("source %s is not a directory", srcPath)
+	}
+	srcIsDir := fi.IsDir()
+	srcContents := archive.IsContentsPath(srcPath)
+
+	dstStat, err := apiClient.ContainerStatPath(ctx, container, dstPath)
+	// the destination symlink is followed.
+	if err == nil && dstStat.LinkTarget != "" {
+		dstPath = dstStat.LinkTarget
+		dstStat, err = apiClient.ContainerStatPath(ctx, container, dstPath)
+	}
+	dstExists := err == nil
+	if err != nil {
+		if !client.IsErrNotFound(err) {
+			return err
+		}
+	}
+
+	// name is the top level name in the archive.
+	var extractDir, name string
+	switch {
+	case dstExists && os.FileMode(dstStat.Mode).IsDir():
+		extractDir = dstPath
+		if !srcContents {
+			name = filepath.Base(srcPath)
+		}
+	case dstExists:
+		if srcIsDir {
+			return fmt.Errorf("cannot copy a directory to a file %s", dstPath)
+		}
+		extractDir, name = filepath.Dir(dstPath), filepath.Base(dstPath)
+	default:
+		if archive.HasTrailingSeparator(dstPath) && !srcIsDir {
+			return fmt.Errorf("destination directory %s does not exist in container %s", dstPath, container)
+		}
+		dstPath = filepath.Clean(dstPath)
+		extractDir, name = filepath.Dir(dstPath), filepath.Base(dstPath)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(archive.TarPath(pw, filepath.Clean(srcPath), name))
+	}()
+	defer pr.Close()
+
+	return apiClient.CopyToContainer(ctx, container, extractDir, pr, true)
+}
+
+// cpExample shows examples in cp command, and is used in auto-generated cli docs.
+func cpExample() string {
+	return `$ pouch cp ./nginx.conf foo:/etc/nginx/
+$ pouch cp foo:/var/log/nginx ./logs
+$ ls ./logs
+access.log  error.log
+$ pouch cp foo:/var/log/nginx/. ./
+$ ls
+access.log  error.log
+$ tar -cf - app.conf | pouch cp - foo:/etc
+$ pouch cp foo:/etc/app.conf - | tar -tf -
+app.conf`
+}
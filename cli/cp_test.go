@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCpArg(t *testing.T) {
+	for _, tc := range []struct {
+		arg       string
+		container string
+		path      string
+	}{
+		{arg: "foo:/etc/hosts", container: "foo", path: "/etc/hosts"},
+		{arg: "foo:etc", container: "foo", path: "etc"},
+		{arg: "./foo:bar", path: "./foo:bar"},
+		{arg: "/tmp/foo:bar", path: "/tmp/foo:bar"},
+		{arg: "file", path: "file"},
+		{arg: "-", path: "-"},
+	} {
+		container, path := splitCpArg(tc.arg)
+		assert.Equal(t, tc.container, container, tc.arg)
+		assert.Equal(t, tc.path, path, tc.arg)
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		path     string
+		expected string
+	}{
+		{path: "foo", expected: filepath.Join(wd, "foo")},
+		{path: "foo/", expected: filepath.Join(wd, "foo") + "/"},
+		{path: "foo/.", expected: filepath.Join(wd, "foo") + "/."},
+		{path: ".", expected: wd + "/."},
+		{path: "/tmp/", expected: "/tmp/"},
+	} {
+		got, err := localPath(tc.path)
+		assert.NoError(t, err, tc.path)
+		assert.Equal(t, tc.expected, got, tc.path)
+	}
+
+	assert.Equal(t, "/etc", containerPath("etc"))
+	assert.Equal(t, "/etc/.", containerPath("/etc/."))
+}
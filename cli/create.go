@@ -1,10 +1,10 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -26,7 +26,7 @@ type CreateCommand struct {
 func (cc *CreateCommand) Init(c *Cli) {
 	cc.cli = c
 	cc.cmd = &cobra.Command{
-		Use:   "create [OPTIONS] IMAGE [ARG...]",
+		Use:   "create [OPTIONS] IMAGE [COMMAND] [ARG...]",
 		Short: "Create a new container with specified image",
 		Long:  createDescription,
 		Args:  cobra.MinimumNArgs(1),
@@ -53,7 +53,7 @@ func (cc *CreateCommand) addFlags() {
 func (cc *CreateCommand) runCreate(args []string) error {
 	config, err := cc.config()
 	if err != nil {
-		return fmt.Errorf("failed to create container: %v", err)
+		return errors.Wrap(err, "failed to create container")
 	}
 	config.ContainerConfig.OpenStdin = cc.openstdin
 
@@ -63,15 +63,17 @@ func (cc *CreateCommand) runCreate(args []string) error {
 	}
 	containerName := cc.name
 
-	ctx := context.Background()
+	ctx := cc.cli.Context()
 	apiClient := cc.cli.Client()
-	if err := pullMissingImage(ctx, apiClient, config.Image, false); err != nil {
+	image, err := cc.cli.pullMissingImage(ctx, config.Image, false)
+	if err != nil {
 		return err
 	}
+	config.Image = image
 
 	result, err := apiClient.ContainerCreate(ctx, config.ContainerConfig, config.HostConfig, config.NetworkingConfig, containerName)
 	if err != nil {
-		return fmt.Errorf("failed to create container: %v", err)
+		return errors.Wrap(err, "failed to create container")
 	}
 
 	if len(result.Warnings) != 0 {
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// diffDescription is used to describe diff command in detail and auto generate command doc.
+var diffDescription = "Inspect changes to files or directories on a container's filesystem relative to its image. " +
+	"Each changed path is printed in a line which is prefixed with A for added, C for changed and D for deleted, " +
+	"and the lines are sorted by path."
+
+// DiffCommand use to implement 'diff' command, it shows the changes of container's filesystem.
+type DiffCommand struct {
+	baseCommand
+}
+
+// Init initialize diff command.
+func (d *DiffCommand) Init(c *Cli) {
+	d.cli = c
+	d.cmd = &cobra.Command{
+		Use:   "diff CONTAINER",
+		Short: "Inspect changes to files or directories on a container's filesystem",
+		Long:  diffDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return d.runDiff(args)
+		},
+		Example: diffExample(),
+	}
+}
+
+// runDiff is the entry of diff command.
+func (d *DiffCommand) runDiff(args []string) error {
+	ctx := d.cli.Context()
+	apiClient := d.cli.Client()
+
+	changes, err := apiClient.ContainerDiff(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		fmt.Fprintf(os.Stdout, "%s %s\n", changeKindString(change.Kind), change.Path)
+	}
+	return nil
+}
+
+// changeKindString returns the prefix of change kind in output.
+func changeKindString(kind uint8) string {
+	switch kind {
+	case 0:
+		return "C"
+	case 1:
+		return "A"
+	case 2:
+		return "D"
+	}
+	return "?"
+}
+
+// diffExample shows examples in diff command, and is used in auto-generated cli docs.
+func diffExample() string {
+	return `$ pouch run -d --name foo busybox sh -c 'touch /tmp/new && rm -r /home && top'
+$ pouch diff foo
+D /home
+C /tmp
+A /tmp/new`
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// parseEnvs returns the envs of container from the env files and the env
+// flags. The envs in files come first, and the later value of the same key
+// overrides the earlier one.
+func parseEnvs(envFiles []string, envs []string) ([]string, error) {
+	var all []string
+	for _, file := range envFiles {
+		fileEnvs, err := readEnvFile(file)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, fileEnvs...)
+	}
+
+	for _, env := range envs {
+		if env == "" || strings.HasPrefix(env, "=") {
+			return nil, fmt.Errorf("invalid env %q: key of env cannot be empty", env)
+		}
+		all = append(all, expandEnv(env))
+	}
+
+	return dedupEnvs(all), nil
+}
+
+// readEnvFile reads the envs from file which has one KEY=VALUE per line, the
+// blank lines and the lines starting with # are ignored. The value is kept
+// as it is, including the quotes and whitespaces.
+func readEnvFile(path string) ([]string, error) {
+	return parseKeyValueFile(path, expandEnv)
+}
+
+// expandEnv fills the value of env without '=' from the environment of
+// client. If the client doesn't have it, the env is kept as KEY, which
+// means removing it from the envs inherited from image.
+func expandEnv(env string) string {
+	if strings.Contains(env, "=") {
+		return env
+	}
+
+	if value, ok := os.LookupEnv(env); ok {
+		return env + "=" + value
+	}
+	return env
+}
+
+// dedupEnvs keeps the last value of each key in the order of their first
+// appearance.
+func dedupEnvs(envs []string) []string {
+	var (
+		keys   []string
+		values = make(map[string]string, len(envs))
+	)
+	for _, env := range envs {
+		key := strings.SplitN(env, "=", 2)[0]
+		if _, exist := values[key]; !exist {
+			keys = append(keys, key)
+		}
+		values[key] = env
+	}
+
+	results := make([]string, 0, len(keys))
+	for _, key := range keys {
+		results = append(results, values[key])
+	}
+	return results
+}
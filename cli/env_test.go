@@ -0,0 +1,95 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeEnvFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadEnvFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "env-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("POUCH_ENV_FILE_TEST", "from-client")
+	defer os.Unsetenv("POUCH_ENV_FILE_TEST")
+
+	for _, tc := range []struct {
+		content  string
+		expected []string
+		err      string
+	}{
+		{content: "", expected: nil},
+		{content: "# comment\n\n   \nA=1\n  # indented comment\n", expected: []string{"A=1"}},
+		{content: "A=\nB=b c\n  C=3", expected: []string{"A=", "B=b c", "C=3"}},
+		{content: "A=\"quoted value\"\nB='single'\nC=a=b", expected: []string{"A=\"quoted value\"", "B='single'", "C=a=b"}},
+		{content: "POUCH_ENV_FILE_TEST\nPOUCH_ENV_FILE_UNSET", expected: []string{"POUCH_ENV_FILE_TEST=from-client", "POUCH_ENV_FILE_UNSET"}},
+		{content: "A=1\n=2", err: "line 2: key cannot be empty"},
+		{content: "A=1\n\n# comment\nB C=3", err: "line 4: key \"B C\" contains whitespaces"},
+	} {
+		path := writeEnvFile(t, dir, "env", tc.content)
+
+		envs, err := readEnvFile(path)
+		if tc.err != "" {
+			assert.Error(t, err, tc.content)
+			assert.Contains(t, err.Error(), tc.err, tc.content)
+			continue
+		}
+		assert.NoError(t, err, tc.content)
+		assert.Equal(t, tc.expected, envs, tc.content)
+	}
+
+	_, err = readEnvFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestParseEnvs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "env-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("POUCH_ENV_FLAG_TEST", "from-client")
+	defer os.Unsetenv("POUCH_ENV_FLAG_TEST")
+
+	file1 := writeEnvFile(t, dir, "env1", "A=file1\nB=file1\n")
+	file2 := writeEnvFile(t, dir, "env2", "B=file2\nC=file2\n")
+
+	for _, tc := range []struct {
+		files    []string
+		envs     []string
+		expected []string
+		err      bool
+	}{
+		{expected: []string{}},
+		{envs: []string{"A=1", "B=", "POUCH_ENV_FLAG_TEST", "POUCH_ENV_FLAG_UNSET"}, expected: []string{"A=1", "B=", "POUCH_ENV_FLAG_TEST=from-client", "POUCH_ENV_FLAG_UNSET"}},
+		{envs: []string{"A=1", "A=2"}, expected: []string{"A=2"}},
+		{files: []string{file1, file2}, expected: []string{"A=file1", "B=file2", "C=file2"}},
+		{files: []string{file1, file2}, envs: []string{"C=flag", "D=flag"}, expected: []string{"A=file1", "B=file2", "C=flag", "D=flag"}},
+		{envs: []string{"=1"}, err: true},
+		{envs: []string{""}, err: true},
+		{files: []string{filepath.Join(dir, "missing")}, err: true},
+	} {
+		envs, err := parseEnvs(tc.files, tc.envs)
+		if tc.err {
+			assert.Error(t, err, "%+v", tc)
+			continue
+		}
+		assert.NoError(t, err, "%+v", tc)
+		assert.Equal(t, tc.expected, envs, "%+v", tc)
+	}
+}
@@ -1,17 +1,18 @@
 package main
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/cli/formatter"
 	"github.com/alibaba/pouch/pkg/utils"
 
 	"github.com/spf13/cobra"
@@ -27,6 +28,7 @@ type EventsCommand struct {
 	since  string
 	until  string
 	filter []string
+	format string
 }
 
 // Init initialize events command.
@@ -51,12 +53,13 @@ func (e *EventsCommand) addFlags() {
 
 	flagSet.StringVarP(&e.since, "since", "s", "", "Show all events created since timestamp")
 	flagSet.StringVarP(&e.until, "until", "u", "", "Stream events until this timestamp")
-	flagSet.StringSliceVarP(&e.filter, "filter", "f", []string{}, "Filter output based on conditions provided")
+	flagSet.StringSliceVarP(&e.filter, "filter", "f", []string{}, "Filter output based on conditions provided, such as type=container, container=name, image=ref or event=die")
+	flagSet.StringVar(&e.format, "format", "", "Format the output using the given Go template, or json to print one JSON object per event")
 }
 
 // runEvents is the entry of events command.
 func (e *EventsCommand) runEvents() error {
-	ctx := context.Background()
+	ctx := e.cli.Context()
 	apiClient := e.cli.Client()
 
 	eventFilterArgs, err := filters.FromFilterOpts(e.filter)
@@ -64,21 +67,51 @@ func (e *EventsCommand) runEvents() error {
 		return err
 	}
 
+	tmpl, err := eventsTemplate(e.format)
+	if err != nil {
+		return err
+	}
+
 	responseBody, err := apiClient.Events(ctx, e.since, e.until, eventFilterArgs)
 	if err != nil {
 		return err
 	}
+	defer responseBody.Close()
 
-	return streamEvents(responseBody, os.Stdout)
+	return streamEvents(responseBody, os.Stdout, tmpl)
+}
+
+// eventsTemplate parses the format into template, the json format prints
+// each event as one line of JSON. The nil template means the default output.
+func eventsTemplate(format string) (*template.Template, error) {
+	switch format {
+	case "":
+		return nil, nil
+	case "json":
+		format = "{{json .}}"
+	}
+
+	return formatter.Parse(format + "\n")
 }
 
 // streamEvents decodes prints the incoming events in the provided output.
-func streamEvents(input io.Reader, output io.Writer) error {
+func streamEvents(input io.Reader, output io.Writer, tmpl *template.Template) error {
 	return DecodeEvents(input, func(event types.EventsMessage, err error) error {
 		if err != nil {
 			return err
 		}
-		printOutput(event, output)
+		if tmpl == nil {
+			printOutput(event, output)
+			return nil
+		}
+
+		// skip empty event message
+		if event == (types.EventsMessage{}) {
+			return nil
+		}
+		if err := tmpl.Execute(output, event); err != nil {
+			return fmt.Errorf("failed to execute format template: %v", err)
+		}
 		return nil
 	})
 }
@@ -143,5 +176,8 @@ func eventsExample() string {
 	return `$ pouch events -s "2018-08-10T10:52:05"
 	2018-08-10T10:53:15.071664386-04:00 volume create 9fff54f207615ccc5a29477f5ae2234c6b804ed8aad2f0dfc0dccb0cc69d4d12 (driver=local)
 2018-08-10T10:53:15.091131306-04:00 container create f2b58eb6bc616d7a22bdb89de50b3f04e2c23134accdec1a9b9a7490d609d34c (image=registry.hub.docker.com/library/centos:latest, name=test)
-2018-08-10T10:53:15.537704818-04:00 container start f2b58eb6bc616d7a22bdb89de50b3f04e2c23134accdec1a9b9a7490d609d34c (image=registry.hub.docker.com/library/centos:latest, name=test)`
+2018-08-10T10:53:15.537704818-04:00 container start f2b58eb6bc616d7a22bdb89de50b3f04e2c23134accdec1a9b9a7490d609d34c (image=registry.hub.docker.com/library/centos:latest, name=test)
+
+$ pouch events -f container=test -f event=die --format '{{.Time}} {{.Actor.Attributes.exitCode}}'
+1533913095 0`
 }
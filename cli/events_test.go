@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamEvents(t *testing.T) {
+	input := `{"type":"container","action":"die","actor":{"ID":"asdf","Attributes":{"exitCode":"0","name":"test"}},"time":1533913095}
+{}
+{"type":"daemon","action":"events dropped","actor":{"Attributes":{"count":"3"}},"time":1533913096}
+`
+
+	for format, expected := range map[string]string{
+		"": "container die asdf (exitCode=0, name=test)\n" +
+			"daemon events dropped  (count=3)\n",
+		"json": `{"action":"die","actor":{"Attributes":{"exitCode":"0","name":"test"},"ID":"asdf"},"time":1533913095,"type":"container"}` + "\n" +
+			`{"action":"events dropped","actor":{"Attributes":{"count":"3"}},"time":1533913096,"type":"daemon"}` + "\n",
+		`{{.Action}}\t{{.Actor.Attributes.name}}`: "die\ttest\nevents dropped\t<no value>\n",
+	} {
+		tmpl, err := eventsTemplate(format)
+		assert.NoError(t, err)
+
+		output := new(bytes.Buffer)
+		assert.NoError(t, streamEvents(strings.NewReader(input), output, tmpl))
+
+		// the default output starts with the time of event.
+		got := output.String()
+		if format == "" {
+			var lines []string
+			for _, line := range strings.Split(got, "\n") {
+				if i := strings.Index(line, " "); i >= 0 {
+					line = line[i+1:]
+				}
+				lines = append(lines, line)
+			}
+			got = strings.Join(lines, "\n")
+		}
+		assert.Equal(t, expected, got, format)
+	}
+
+	_, err := eventsTemplate("{{.Action")
+	assert.Error(t, err)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/alibaba/pouch/pkg/ioutils"
 
 	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -27,6 +28,7 @@ type ExecCommand struct {
 	Detach      bool
 	User        string
 	Envs        []string
+	Workdir     string
 	Privileged  bool
 }
 
@@ -55,19 +57,19 @@ func (e *ExecCommand) addFlags() {
 	flagSet.BoolVarP(&e.Interactive, "interactive", "i", false, "Open container's STDIN")
 	flagSet.StringVarP(&e.User, "user", "u", "", "Username or UID (format: <name|uid>[:<group|gid>])")
 	flagSet.StringArrayVarP(&e.Envs, "env", "e", []string{}, "Set environment variables")
+	flagSet.StringVarP(&e.Workdir, "workdir", "w", "", "Working directory inside the container")
 	flagSet.BoolVar(&e.Privileged, "privileged", false, "Give extended privileges to the exec process")
 }
 
 // runExec is the entry of ExecCommand command.
 func (e *ExecCommand) runExec(args []string) error {
-	ctx := context.Background()
+	ctx := e.cli.Context()
 	apiClient := e.cli.Client()
 
 	// create exec process.
 	id := args[0]
 	command := args[1:]
 
-	// TODO(huamin.thm): exec detach not implement now, detach mode not hijack connect
 	createExecConfig := &types.ExecCreateConfig{
 		Cmd:          command,
 		Tty:          e.Terminal,
@@ -78,6 +80,7 @@ func (e *ExecCommand) runExec(args []string) error {
 		Privileged:   e.Privileged,
 		User:         e.User,
 		Env:          e.Envs,
+		WorkingDir:   e.Workdir,
 	}
 
 	if err := checkTty(createExecConfig.AttachStdin, createExecConfig.Tty, os.Stdin.Fd()); err != nil {
@@ -86,7 +89,7 @@ func (e *ExecCommand) runExec(args []string) error {
 
 	createResp, err := apiClient.ContainerCreateExec(ctx, id, createExecConfig)
 	if err != nil {
-		return fmt.Errorf("failed to create exec: %v", err)
+		return errors.Wrap(err, "failed to create exec")
 	}
 
 	// start exec process.
@@ -97,7 +100,20 @@ func (e *ExecCommand) runExec(args []string) error {
 
 	conn, reader, err := apiClient.ContainerStartExec(ctx, createResp.ID, startExecConfig)
 	if err != nil {
-		return fmt.Errorf("failed to start exec: %v", err)
+		return errors.Wrap(err, "failed to start exec")
+	}
+
+	// detach mode doesn't wait for the exec process.
+	if e.Detach {
+		conn.Close()
+		return nil
+	}
+
+	if e.Terminal {
+		stopResize := monitorTtySize(func(height, width string) error {
+			return apiClient.ContainerExecResize(ctx, createResp.ID, height, width)
+		})
+		defer stopResize()
 	}
 
 	// handle stdio.
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/alibaba/pouch/client"
+
+	"github.com/pkg/errors"
+)
+
+// The exit codes of pouch cli, which allow scripts to tell the failures apart,
+// such as the container is not found, the daemon is down or the container
+// exits with 137. The commands running a container in the foreground, such as
+// run, start -a and exec, exit with the exit code of container.
+const (
+	// exitCodeFailure is the exit code when the command fails for the other
+	// reasons, such as the arguments are invalid.
+	exitCodeFailure = 1
+
+	// exitCodeNotFound is the exit code when the object to be operated is
+	// not found.
+	exitCodeNotFound = 2
+
+	// exitCodeConflict is the exit code when the operation conflicts with the
+	// state of object, such as the name is in use.
+	exitCodeConflict = 3
+
+	// exitCodeTimeout is the exit code when the operation does not finish in
+	// the given timeout, which follows the convention of timeout(1).
+	exitCodeTimeout = 124
+
+	// exitCodeInternal is the exit code when pouch itself fails, such as the
+	// daemon can't be reached.
+	exitCodeInternal = 125
+
+	// exitCodeNotExecutable is the exit code when the command of container
+	// can't be invoked, which follows the convention of shell.
+	exitCodeNotExecutable = 126
+
+	// exitCodeCommandNotFound is the exit code when the command of container
+	// is not found, which follows the convention of shell.
+	exitCodeCommandNotFound = 127
+
+	// exitCodeCancelled is the exit code when the operation is cancelled by
+	// SIGINT or SIGTERM, which follows the convention of shell (128 + SIGINT).
+	exitCodeCancelled = 130
+)
+
+// ExitError defines exit error produce by cli commands.
+type ExitError struct {
+	Code   int
+	Status string
+}
+
+// Error implements error interface.
+func (e ExitError) Error() string {
+	return fmt.Sprintf("Exit Code: %d, Status: %s", e.Code, e.Status)
+}
+
+// startError is the error of starting the process of container, which exits
+// with 127 if the command is not found and 126 if it can't be invoked.
+type startError struct {
+	error
+}
+
+// Cause returns the error returned by daemon.
+func (e startError) Cause() error {
+	return e.error
+}
+
+// execErrorRegexp matches the error of runtime when it fails to exec the
+// command of container, such as `exec: "foo": executable file not found in
+// $PATH`, so that the other failures, such as the missing source of bind
+// mount, aren't taken as the command not found.
+var execErrorRegexp = regexp.MustCompile(`exec: "[^"]*": (.*)`)
+
+// exitCode returns the exit code by the message of runtime, and 0 if the
+// process fails for the other reasons.
+func (e startError) exitCode() int {
+	matches := execErrorRegexp.FindStringSubmatch(e.Error())
+	if matches == nil {
+		return 0
+	}
+
+	msg := matches[1]
+	switch {
+	case strings.Contains(msg, "executable file not found"),
+		strings.Contains(msg, "no such file or directory"):
+		return exitCodeCommandNotFound
+	case strings.Contains(msg, "permission denied"):
+		return exitCodeNotExecutable
+	}
+	return 0
+}
+
+// errorList is the errors of a command operating on multiple objects, such
+// as removing containers.
+type errorList []error
+
+// Error implements error interface.
+func (l errorList) Error() string {
+	msgs := make([]string, 0, len(l))
+	for _, err := range l {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// toExitError converts the error returned by command to ExitError, whose
+// code tells the kind of failure.
+func toExitError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if exitErr, ok := err.(ExitError); ok {
+		if exitErr.Code == 0 {
+			// when get error with ExitError, code should not be 0.
+			exitErr.Code = exitCodeFailure
+		}
+		return exitErr
+	}
+
+	code := exitCode(err)
+	if ctx.Err() == context.Canceled {
+		code = exitCodeCancelled
+	}
+	return ExitError{Code: code, Status: fmt.Sprintf("Error: %v", err)}
+}
+
+// exitCode returns the exit code of error. The errors of multiple objects
+// exit with the code only if all of them have the same one, so that removing
+// the missing containers still exits with exitCodeNotFound.
+func exitCode(err error) int {
+	if l, ok := errors.Cause(err).(errorList); ok {
+		code := exitCodeFailure
+		for i, e := range l {
+			if c := exitCode(e); i == 0 {
+				code = c
+			} else if c != code {
+				return exitCodeFailure
+			}
+		}
+		return code
+	}
+
+	if e, ok := err.(startError); ok {
+		if code := e.exitCode(); code != 0 {
+			return code
+		}
+	}
+
+	switch {
+	case client.IsErrNotFound(err):
+		return exitCodeNotFound
+	case client.IsErrConflict(err):
+		return exitCodeConflict
+	case client.IsErrConnectionFailed(err):
+		return exitCodeInternal
+	}
+	return exitCodeFailure
+}
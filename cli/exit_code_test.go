@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/client"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		code, msg := http.StatusInternalServerError, "internal error"
+		switch {
+		case strings.Contains(req.URL.Path, "/containers/missing"):
+			code, msg = http.StatusNotFound, "container missing: not found"
+		case strings.Contains(req.URL.Path, "/containers/create"):
+			code, msg = http.StatusConflict, "container name foo: already existed"
+		case strings.Contains(req.URL.Path, "/containers/nocmd/start"):
+			msg = `exec: "nocmd": executable file not found in $PATH`
+		case strings.Contains(req.URL.Path, "/containers/noexec/start"):
+			msg = `exec: "/etc": permission denied`
+		case strings.Contains(req.URL.Path, "/containers/nopath/start"):
+			msg = `exec: "/bin/foo": stat /bin/foo: no such file or directory`
+		case strings.Contains(req.URL.Path, "/containers/nobind/start"):
+			msg = `mount /missing to rootfs: stat /missing: no such file or directory`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(types.Error{Message: msg})
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), client.TLSConfig{})
+	assert.NoError(t, err)
+	unreachable, err := client.NewAPIClient("unix:///var/run/nonexistent-pouchd.sock", client.TLSConfig{})
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	_, notFound := apiClient.ContainerGet(ctx, "missing")
+	_, conflict := apiClient.ContainerCreate(ctx, types.ContainerConfig{}, nil, nil, "foo")
+	internal := apiClient.ContainerStart(ctx, "foo", types.ContainerStartOptions{})
+	noCmd := apiClient.ContainerStart(ctx, "nocmd", types.ContainerStartOptions{})
+	noExec := apiClient.ContainerStart(ctx, "noexec", types.ContainerStartOptions{})
+	noPath := apiClient.ContainerStart(ctx, "nopath", types.ContainerStartOptions{})
+	noBind := apiClient.ContainerStart(ctx, "nobind", types.ContainerStartOptions{})
+	_, connFailed := unreachable.SystemInfo(ctx)
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "not found", err: notFound, code: exitCodeNotFound},
+		{name: "wrapped not found", err: errors.Wrap(notFound, "failed to start exec"), code: exitCodeNotFound},
+		{name: "formatted not found", err: fmt.Errorf("failed: %v", notFound), code: exitCodeFailure},
+		{name: "conflict", err: conflict, code: exitCodeConflict},
+		{name: "internal error of daemon", err: internal, code: exitCodeFailure},
+		{name: "daemon unreachable", err: connFailed, code: exitCodeInternal},
+		{name: "command not found", err: startError{noCmd}, code: exitCodeCommandNotFound},
+		{name: "command not executable", err: startError{noExec}, code: exitCodeNotExecutable},
+		{name: "command path not found", err: startError{noPath}, code: exitCodeCommandNotFound},
+		{name: "bind source not found", err: startError{noBind}, code: exitCodeFailure},
+		{name: "container not found to start", err: startError{notFound}, code: exitCodeNotFound},
+		{name: "all not found", err: errorList{notFound, notFound}, code: exitCodeNotFound},
+		{name: "wrapped list", err: errors.Wrap(errorList{notFound}, "failed to start containers"), code: exitCodeNotFound},
+		{name: "mixed list", err: errorList{notFound, conflict}, code: exitCodeFailure},
+		{name: "other error", err: fmt.Errorf("invalid argument"), code: exitCodeFailure},
+	} {
+		err := toExitError(ctx, tc.err)
+		exitErr, ok := err.(ExitError)
+		assert.True(t, ok, tc.name)
+		assert.Equal(t, tc.code, exitErr.Code, tc.name)
+		assert.Equal(t, "Error: "+tc.err.Error(), exitErr.Status, tc.name)
+	}
+}
+
+func TestToExitError(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, toExitError(ctx, nil))
+
+	// the exit code of container is kept as it is.
+	assert.Equal(t, ExitError{Code: 137}, toExitError(ctx, ExitError{Code: 137}))
+	assert.Equal(t, ExitError{Code: exitCodeFailure, Status: "failed"}, toExitError(ctx, ExitError{Status: "failed"}))
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Equal(t, ExitError{Code: exitCodeCancelled, Status: "Error: context canceled"}, toExitError(ctx, context.Canceled))
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alibaba/pouch/pkg/term"
+
+	"github.com/spf13/cobra"
+)
+
+// exportDescription is used to describe export command in detail and auto generate command doc.
+var exportDescription = "Export the filesystem of a container as a tar archive, the container can be running or stopped. " +
+	"The device and socket files and the contents of volumes are not exported. The archive can be imported as an image " +
+	"by pouch import. The running container is paused during the export."
+
+// ExportCommand use to implement 'export' command.
+type ExportCommand struct {
+	baseCommand
+	output string
+}
+
+// Init initialize export command.
+func (e *ExportCommand) Init(c *Cli) {
+	e.cli = c
+	e.cmd = &cobra.Command{
+		Use:   "export [OPTIONS] CONTAINER",
+		Short: "Export a container's filesystem as a tar archive",
+		Long:  exportDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return e.runExport(args)
+		},
+		Example: exportExample(),
+	}
+	e.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (e *ExportCommand) addFlags() {
+	flagSet := e.cmd.Flags()
+	flagSet.StringVarP(&e.output, "output", "o", "", "Write to a file, instead of STDOUT")
+}
+
+// runExport is the entry of export command.
+func (e *ExportCommand) runExport(args []string) error {
+	ctx := e.cli.Context()
+	apiClient := e.cli.Client()
+
+	out := os.Stdout
+	if e.output == "" && term.IsTerminal(out.Fd()) {
+		return fmt.Errorf("refusing to write the tar archive to a terminal, use -o flag or redirect STDOUT")
+	}
+
+	r, err := apiClient.ContainerExport(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if e.output != "" {
+		out, err = os.Create(e.output)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+
+	if _, err := io.Copy(out, r); err != nil {
+		return err
+	}
+	return nil
+}
+
+// exportExample shows examples in export command, and is used in auto-generated cli docs.
+func exportExample() string {
+	return `$ pouch export -o rootfs.tar foo
+$ pouch export foo > rootfs.tar
+$ pouch import rootfs.tar foo:rootfs
+sha256:bd3a9ab1d8d1e1b6f6b4e5d6f4f3a3c1d7a8b2f5e9c0d4a6b8e2f1c3d5a7b9e0`
+}
@@ -0,0 +1,56 @@
+package formatter
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/alibaba/pouch/pkg/term"
+)
+
+// EnvNoColor disables the colorization if it is set to a non-empty value,
+// see https://no-color.org.
+const EnvNoColor = "NO_COLOR"
+
+// Color is the ANSI escape code of the color of text.
+type Color string
+
+const (
+	// Red is the color of the failures, such as the container exited with
+	// non-zero code.
+	Red Color = "\x1b[31m"
+
+	// Green is the color of the healthy objects, such as the running container.
+	Green Color = "\x1b[32m"
+
+	// Yellow is the color of the objects in transition, such as the
+	// restarting container.
+	Yellow Color = "\x1b[33m"
+
+	// colorReset resets the color of text to default.
+	colorReset = "\x1b[0m"
+)
+
+// colorPattern matches the ANSI escape codes of color.
+var colorPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// ColorEnabled returns true if the output is a terminal and colorization is
+// not disabled by --no-color or NO_COLOR, so that the color codes never leak
+// into the piped output.
+func ColorEnabled(out io.Writer, noColor bool) bool {
+	if noColor {
+		return false
+	}
+	if os.Getenv(EnvNoColor) != "" {
+		return false
+	}
+	f, ok := out.(*os.File)
+	return ok && term.IsTerminal(f.Fd())
+}
+
+// displayWidth returns the number of characters displayed of text, which
+// excludes the color codes.
+func displayWidth(text string) int {
+	return utf8.RuneCountInString(colorPattern.ReplaceAllString(text, ""))
+}
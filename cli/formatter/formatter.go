@@ -0,0 +1,149 @@
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/alibaba/pouch/pkg/utils/templates"
+)
+
+// TableFormatKey is the prefix of format which renders the rows in a table
+// with the headers, such as "table {{.ID}}\t{{.Names}}".
+const TableFormatKey = "table"
+
+// DefaultPadding is the default number of spaces between the columns.
+const DefaultPadding = 3
+
+// Formatter renders the rows of list commands, such as ps and images, with
+// the go template of row. The columns separated by tab are aligned if the
+// format is prefixed with "table".
+type Formatter struct {
+	// Output is where the rows are written.
+	Output io.Writer
+
+	// Format is the go template of row.
+	Format string
+
+	// Color enables the colorization of cells, which should be set only if
+	// Output is a terminal, see ColorEnabled.
+	Color bool
+
+	// Padding is the number of spaces between the columns of table.
+	Padding int
+}
+
+// IsTable returns true if the rows are rendered in a table.
+func (f *Formatter) IsTable() bool {
+	return strings.HasPrefix(f.Format, TableFormatKey)
+}
+
+// Colorize returns the text in color, or the text as it is if colorization
+// isn't enabled.
+func (f *Formatter) Colorize(text string, color Color) string {
+	if !f.Color || color == "" || text == "" {
+		return text
+	}
+	return string(color) + text + colorReset
+}
+
+// Parse parses the go template of format, and like docker, the escaped tab
+// and newline are also accepted.
+func Parse(format string) (*template.Template, error) {
+	format = strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(format)
+
+	tmpl, err := templates.Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse format template: %v", err)
+	}
+	return tmpl, nil
+}
+
+// Write renders the rows with template, and the header is rendered as the
+// first row in table, which is a row whose fields are the column names.
+// Nothing will be written if template fails to execute on any row.
+func (f *Formatter) Write(header interface{}, rows []interface{}) error {
+	format := f.Format
+	if f.IsTable() {
+		format = strings.TrimLeft(strings.TrimPrefix(format, TableFormatKey), " ")
+	}
+
+	tmpl, err := Parse(format)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if f.IsTable() {
+		if err := execute(buf, tmpl, header); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := execute(buf, tmpl, row); err != nil {
+			return err
+		}
+	}
+
+	if !f.IsTable() {
+		_, err := io.Copy(f.Output, buf)
+		return err
+	}
+	return writeTable(f.Output, buf.String(), f.padding())
+}
+
+// padding returns the number of spaces between the columns.
+func (f *Formatter) padding() int {
+	if f.Padding <= 0 {
+		return DefaultPadding
+	}
+	return f.Padding
+}
+
+// execute renders a row with template.
+func execute(buf *bytes.Buffer, tmpl *template.Template, row interface{}) error {
+	if err := tmpl.Execute(buf, row); err != nil {
+		return fmt.Errorf("failed to execute format template: %v", err)
+	}
+	buf.WriteByte('\n')
+	return nil
+}
+
+// writeTable aligns the columns separated by tab like tabwriter, but the
+// width of cell excludes the color codes, so that the colored cells are
+// aligned as well.
+func writeTable(out io.Writer, text string, padding int) error {
+	var (
+		lines  [][]string
+		widths []int
+	)
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		cells := strings.Split(line, "\t")
+		// the last cell of line isn't padded.
+		for i, cell := range cells[:len(cells)-1] {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := displayWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+		lines = append(lines, cells)
+	}
+
+	buf := new(bytes.Buffer)
+	for _, cells := range lines {
+		for i, cell := range cells {
+			buf.WriteString(cell)
+			if i < len(cells)-1 {
+				buf.WriteString(strings.Repeat(" ", widths[i]-displayWidth(cell)+padding))
+			}
+		}
+		buf.WriteByte('\n')
+	}
+
+	_, err := io.Copy(out, buf)
+	return err
+}
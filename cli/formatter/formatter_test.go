@@ -0,0 +1,122 @@
+package formatter
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type row struct {
+	ID     string
+	Name   string
+	Status string
+}
+
+var header = row{ID: "ID", Name: "NAME", Status: "STATUS"}
+
+func TestWriteTemplate(t *testing.T) {
+	out := new(bytes.Buffer)
+	f := &Formatter{Output: out, Format: `{{.Name}}\t{{.Status}}`}
+	assert.False(t, f.IsTable())
+
+	assert.NoError(t, f.Write(header, []interface{}{row{Name: "foo", Status: "Up"}, row{Name: "bar", Status: "Exited"}}))
+	assert.Equal(t, "foo\tUp\nbar\tExited\n", out.String())
+
+	// nothing is written if template fails on any row.
+	out.Reset()
+	f.Format = "{{.Unknown}}"
+	assert.Error(t, f.Write(header, []interface{}{row{}}))
+	assert.Equal(t, "", out.String())
+
+	f.Format = "{{.Name"
+	assert.EqualError(t, f.Write(header, nil), `failed to parse format template: template: :1: unclosed action`)
+}
+
+func TestParse(t *testing.T) {
+	tmpl, err := Parse(`{{.Name}}\t{{upper .Status}}\n`)
+	assert.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, tmpl.Execute(out, row{Name: "foo", Status: "Up"}))
+	assert.Equal(t, "foo\tUP\n", out.String())
+
+	_, err = Parse("{{.Name")
+	assert.EqualError(t, err, `failed to parse format template: template: :1: unclosed action`)
+}
+
+func TestWriteTable(t *testing.T) {
+	rows := []interface{}{
+		row{ID: "e42c68", Name: "foo", Status: "Up 5 minutes"},
+		row{ID: "a8c2ea", Name: "a-long-name", Status: ""},
+		row{ID: "faf132", Name: "", Status: "Exited (1) 1 minute ago"},
+	}
+
+	out := new(bytes.Buffer)
+	f := &Formatter{Output: out, Format: "table {{.ID}}\t{{.Name}}\t{{.Status}}", Padding: 3}
+	assert.True(t, f.IsTable())
+	assert.NoError(t, f.Write(header, rows))
+
+	// the table is aligned in the same way as tabwriter.
+	expected := new(bytes.Buffer)
+	w := tabwriter.NewWriter(expected, 0, 0, 3, ' ', 0)
+	for _, r := range append([]interface{}{header}, rows...) {
+		r := r.(row)
+		w.Write([]byte(strings.Join([]string{r.ID, r.Name, r.Status}, "\t") + "\n"))
+	}
+	w.Flush()
+	assert.Equal(t, expected.String(), out.String())
+
+	// only the header is printed if there is no row.
+	out.Reset()
+	assert.NoError(t, f.Write(header, nil))
+	assert.Equal(t, "ID   NAME   STATUS\n", out.String())
+}
+
+func TestWriteColoredTable(t *testing.T) {
+	out := new(bytes.Buffer)
+	f := &Formatter{Output: out, Format: "table {{.Status}}\t{{.Name}}", Color: true}
+
+	rows := []interface{}{
+		row{Name: "foo", Status: f.Colorize("Up 5 minutes", Green)},
+		row{Name: "bar", Status: f.Colorize("Exited (1)", Red)},
+	}
+	assert.NoError(t, f.Write(header, rows))
+	assert.Equal(t, "STATUS         NAME\n"+
+		"\x1b[32mUp 5 minutes\x1b[0m   foo\n"+
+		"\x1b[31mExited (1)\x1b[0m     bar\n", out.String())
+}
+
+func TestColorize(t *testing.T) {
+	f := &Formatter{}
+	assert.Equal(t, "Up", f.Colorize("Up", Green))
+
+	f.Color = true
+	assert.Equal(t, "\x1b[33mRestarting\x1b[0m", f.Colorize("Restarting", Yellow))
+	assert.Equal(t, "Created", f.Colorize("Created", ""))
+	assert.Equal(t, "", f.Colorize("", Red))
+}
+
+func TestColorEnabled(t *testing.T) {
+	// the output which isn't a terminal is never colorized.
+	assert.False(t, ColorEnabled(new(bytes.Buffer), false))
+
+	devNull, err := os.Open(os.DevNull)
+	assert.NoError(t, err)
+	defer devNull.Close()
+	assert.False(t, ColorEnabled(devNull, false))
+
+	os.Setenv(EnvNoColor, "1")
+	defer os.Unsetenv(EnvNoColor)
+	assert.False(t, ColorEnabled(os.Stdout, false))
+	assert.False(t, ColorEnabled(os.Stdout, true))
+}
+
+func TestDisplayWidth(t *testing.T) {
+	assert.Equal(t, 2, displayWidth("Up"))
+	assert.Equal(t, 2, displayWidth("\x1b[32mUp\x1b[0m"))
+	assert.Equal(t, 3, displayWidth("ab…"))
+}
@@ -1,11 +1,17 @@
 package main
 
 import (
-	"context"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/cli/formatter"
 	"github.com/alibaba/pouch/pkg/utils"
 
 	"github.com/docker/docker/pkg/stringid"
@@ -23,6 +29,17 @@ type HistoryCommand struct {
 	flagHuman   bool
 	flagQuiet   bool
 	flagNoTrunc bool
+	flagFormat  string
+}
+
+// historyFormatContext contains the fields which can be used in the template of history --format.
+type historyFormatContext struct {
+	ID           string
+	CreatedSince string
+	CreatedAt    string
+	CreatedBy    string
+	Size         string
+	Comment      string
 }
 
 // Init initialize "image history" command.
@@ -47,13 +64,24 @@ func (h *HistoryCommand) addFlags() {
 	flagSet.BoolVar(&h.flagHuman, "human", true, "Print information in human readable format")
 	flagSet.BoolVarP(&h.flagQuiet, "quiet", "q", false, "Only show image numeric ID")
 	flagSet.BoolVar(&h.flagNoTrunc, "no-trunc", false, "Do not truncate output")
+	flagSet.StringVar(&h.flagFormat, "format", "", "Pretty-print history using a Go template, fields ID, CreatedSince, CreatedAt, CreatedBy, Size and Comment are supported")
 }
 
 // runHistory is used to get history of an image.
 func (h *HistoryCommand) runHistory(args []string) error {
 	name := args[0]
 
-	ctx := context.Background()
+	var (
+		tmpl *template.Template
+		err  error
+	)
+	if h.flagFormat != "" && !h.flagQuiet {
+		if tmpl, err = formatter.Parse(h.flagFormat); err != nil {
+			return err
+		}
+	}
+
+	ctx := h.cli.Context()
 	apiClient := h.cli.Client()
 
 	history, err := apiClient.ImageHistory(ctx, name)
@@ -74,46 +102,86 @@ func (h *HistoryCommand) runHistory(args []string) error {
 		return nil
 	}
 
-	var (
-		imageID   string
-		createdBy string
-		created   string
-		size      string
-	)
-
-	display.AddRow([]string{"IMAGE", "CREATED", "CREATED BY", "SIZE", "COMMENT"})
+	rows := make([]historyFormatContext, 0, len(history))
 	for _, entry := range history {
-		imageID = entry.ID
-		createdBy = strings.Replace(entry.CreatedBy, "\t", " ", -1)
-		if !h.flagNoTrunc {
-			createdBy = ellipsis(createdBy, 45)
-			imageID = stringid.TruncateID(entry.ID)
+		row, err := historyToFormatContext(entry, h.flagHuman, h.flagNoTrunc)
+		if err != nil {
+			return err
 		}
+		rows = append(rows, row)
+	}
+
+	if tmpl != nil {
+		return formatHistory(os.Stdout, tmpl, rows)
+	}
 
+	display.AddRow([]string{"IMAGE", "CREATED", "CREATED BY", "SIZE", "COMMENT"})
+	for _, row := range rows {
+		created := row.CreatedAt
 		if h.flagHuman {
-			created, err = utils.FormatTimeInterval(entry.Created)
-			if err != nil {
-				return err
-			}
-			created = created + " ago"
-			size = utils.FormatSize(entry.Size)
-		} else {
-			created = time.Unix(0, entry.Created).Format(time.RFC3339)
-			size = strconv.FormatInt(entry.Size, 10)
+			created = row.CreatedSince
 		}
-
-		display.AddRow([]string{imageID, created, createdBy, size, entry.Comment})
+		display.AddRow([]string{row.ID, created, row.CreatedBy, row.Size, row.Comment})
 	}
 	display.Flush()
 	return nil
 }
 
+// historyToFormatContext converts the history entry into the row of history.
+func historyToFormatContext(entry types.HistoryResultItem, human, noTrunc bool) (historyFormatContext, error) {
+	row := historyFormatContext{
+		ID:        entry.ID,
+		CreatedAt: time.Unix(0, entry.Created).Format(time.RFC3339),
+		CreatedBy: strings.Replace(entry.CreatedBy, "\t", " ", -1),
+		Size:      strconv.FormatInt(entry.Size, 10),
+		Comment:   entry.Comment,
+	}
+
+	if !noTrunc {
+		row.ID = stringid.TruncateID(entry.ID)
+		row.CreatedBy = ellipsis(row.CreatedBy, 45)
+	}
+
+	// the created time in the future is only invalid in human readable format
+	since, err := utils.FormatTimeInterval(entry.Created)
+	if err != nil && human {
+		return row, err
+	}
+	if err == nil {
+		row.CreatedSince = since + " ago"
+	}
+
+	if human {
+		row.Size = utils.FormatSize(entry.Size)
+	}
+	return row, nil
+}
+
+// formatHistory renders the history with template. Nothing will be
+// written into out if the template fails to execute.
+func formatHistory(out io.Writer, tmpl *template.Template, rows []historyFormatContext) error {
+	buf := new(bytes.Buffer)
+	for _, row := range rows {
+		if err := tmpl.Execute(buf, row); err != nil {
+			return fmt.Errorf("failed to execute format template: %v", err)
+		}
+		buf.WriteByte('\n')
+	}
+
+	_, err := io.Copy(out, buf)
+	return err
+}
+
 // example shows examples in history command, and is used in auto-generated cli docs.
 func (h *HistoryCommand) example() string {
 	return `pouch history busybox:latest
 IMAGE          CREATED      CREATED BY                                      SIZE        COMMENT
 e1ddd7948a1c   1 week ago   /bin/sh -c #(nop)  CMD ["sh"]                   0.00 B
-<missing>      1 week ago   /bin/sh -c #(nop) ADD file:96fda64a6b725d4...   716.06 KB  `
+<missing>      1 week ago   /bin/sh -c #(nop) ADD file:96fda64a6b725d4...   716.06 KB
+
+pouch history --format '{{.ID}}\t{{.Size}}' busybox:latest
+e1ddd7948a1c	0.00 B
+<missing>	716.06 KB`
 }
 
 // ellipsis truncates a string to fit within maxlen, and appends ellipsis (...).
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils/templates"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryToFormatContext(t *testing.T) {
+	created := time.Date(2018, 6, 26, 8, 0, 0, 0, time.UTC)
+	entry := types.HistoryResultItem{
+		ID:        "sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a",
+		Created:   created.UnixNano(),
+		CreatedBy: "/bin/sh -c #(nop) ADD\tfile:96fda64a6b725d4df5249c12e32245e2f02469ff637c38077740f4984cd883dd in / ",
+		Size:      1024,
+		Comment:   "comment",
+	}
+
+	row, err := historyToFormatContext(entry, true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "8c811b4aec35", row.ID)
+	assert.Equal(t, "/bin/sh -c #(nop) ADD file:96fda64a6b725d4...", row.CreatedBy)
+	assert.Equal(t, "1.00 KB", row.Size)
+	assert.Contains(t, row.CreatedSince, " ago")
+	assert.Equal(t, created.Local().Format(time.RFC3339), row.CreatedAt)
+	assert.Equal(t, "comment", row.Comment)
+
+	row, err = historyToFormatContext(entry, false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, entry.ID, row.ID)
+	assert.Equal(t, "/bin/sh -c #(nop) ADD file:96fda64a6b725d4df5249c12e32245e2f02469ff637c38077740f4984cd883dd in / ", row.CreatedBy)
+	assert.Equal(t, "1024", row.Size)
+
+	// the created time in the future is invalid in human readable format
+	entry.Created = time.Now().Add(time.Hour).UnixNano()
+	_, err = historyToFormatContext(entry, true, false)
+	assert.Error(t, err)
+
+	_, err = historyToFormatContext(entry, false, false)
+	assert.NoError(t, err)
+}
+
+func TestFormatHistory(t *testing.T) {
+	rows := []historyFormatContext{
+		{ID: "8c811b4aec35", Size: "0.00 B", CreatedBy: "/bin/sh -c #(nop)  CMD [\"sh\"]"},
+		{ID: "<missing>", Size: "1.00 KB", CreatedBy: "/bin/sh -c #(nop) ADD file:96fda64a6b725d4..."},
+	}
+
+	tmpl, err := templates.Parse("{{.ID}}\t{{.Size}}")
+	assert.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, formatHistory(out, tmpl, rows))
+	assert.Equal(t, "8c811b4aec35\t0.00 B\n<missing>\t1.00 KB\n", out.String())
+
+	// nothing should be written if the template fails
+	tmpl, err = templates.Parse("{{.Unknown}}")
+	assert.NoError(t, err)
+
+	out.Reset()
+	assert.Error(t, formatHistory(out, tmpl, rows))
+	assert.Equal(t, "", out.String())
+}
@@ -1,10 +1,11 @@
 package main
 
 import (
-	"context"
+	"fmt"
 	"os"
 
 	"github.com/alibaba/pouch/cli/inspect"
+	"github.com/alibaba/pouch/client"
 
 	"github.com/spf13/cobra"
 )
@@ -41,25 +42,60 @@ func (i *ImageInspectCommand) addFlags() {
 
 // runInpsect is used to inspect image.
 func (i *ImageInspectCommand) runInspect(args []string) error {
-	ctx := context.Background()
+	ctx := i.cli.Context()
 	apiClient := i.cli.Client()
 
+	notFound := false
 	getRefFunc := func(ref string) (interface{}, error) {
-		return apiClient.ImageInspect(ctx, ref)
+		image, err := apiClient.ImageInspect(ctx, ref)
+		if client.IsErrNotFound(err) {
+			notFound = true
+		}
+		return image, err
 	}
 
-	return inspect.Inspect(os.Stdout, args, i.format, getRefFunc)
+	if err := inspect.Inspect(os.Stdout, args, i.format, getRefFunc); err != nil {
+		if notFound {
+			return ExitError{Code: exitCodeNotFound, Status: fmt.Sprintf("Error: %v", err)}
+		}
+		return err
+	}
+	return nil
 }
 
 // example shows examples in inspect command, and is used in auto-generated cli docs.
 func (i *ImageInspectCommand) example() string {
 	return `$ pouch image inspect docker.io/library/busybox
-{
-  "CreatedAt": "2017-12-21 04:30:57",
-  "Digest": "sha256:bbc3a03235220b170ba48a157dd097dd1379299370e1ed99ce976df0355d24f0",
-  "ID": "bbc3a0323522",
-  "Name": "docker.io/library/busybox:latest",
-  "Size": 720019,
-  "Tag": "latest"
-}`
+[
+    {
+        "Architecture": "amd64",
+        "Config": {
+            "Cmd": [
+                "sh"
+            ],
+            "Env": [
+                "PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+            ],
+            "OnBuild": null
+        },
+        "CreatedAt": "2018-05-22T22:20:29.474236033Z",
+        "Id": "sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a",
+        "Os": "linux",
+        "RepoDigests": [
+            "docker.io/library/busybox@sha256:141c253bc4c3fd0a201d32dc1f493bcf3fff003b6df416dea4f41046e0f37d47"
+        ],
+        "RepoTags": [
+            "docker.io/library/busybox:latest"
+        ],
+        "RootFS": {
+            "Layers": [
+                "sha256:0314be9edf00a925d59f9b88c9d8ccb34447ab677078874d8c14e7a6816e21e1"
+            ],
+            "Type": "layers"
+        },
+        "Size": 720019
+    }
+]
+$ pouch image inspect --format '{{.Architecture}}' docker.io/library/busybox
+amd64`
 }
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/alibaba/pouch/apis/filters"
@@ -10,6 +9,7 @@ import (
 	"github.com/alibaba/pouch/pkg/utils"
 
 	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -25,10 +25,44 @@ func (i imageSize) String() string {
 }
 
 type displayImage struct {
-	id     string
-	name   string
-	size   imageSize
-	digest string
+	id         string
+	name       string
+	repository string
+	tag        string
+	size       imageSize
+	digest     string
+	createdAt  string
+}
+
+// imageFormatContext contains the fields which can be used in the template of images --format.
+type imageFormatContext struct {
+	ID        string
+	Reference string
+	Name      string
+	Tag       string
+	Digest    string
+	Size      string
+	CreatedAt string
+}
+
+const (
+	// defaultImagesFormat is the format of images if neither --format nor
+	// the imagesFormat in config file is set.
+	defaultImagesFormat = "table {{.ID}}\t{{.Reference}}\t{{.Size}}"
+
+	// defaultImagesDigestsFormat is the default format of images --digests.
+	defaultImagesDigestsFormat = "table {{.ID}}\t{{.Reference}}\t{{.Digest}}\t{{.Size}}"
+)
+
+// imagesHeader is the header of images table.
+var imagesHeader = imageFormatContext{
+	ID:        "IMAGE ID",
+	Reference: "IMAGE NAME",
+	Name:      "REPOSITORY",
+	Tag:       "TAG",
+	Digest:    "DIGEST",
+	Size:      "SIZE",
+	CreatedAt: "CREATED AT",
 }
 
 // ImagesCommand use to implement 'images' command.
@@ -40,6 +74,7 @@ type ImagesCommand struct {
 	flagDigest  bool
 	flagNoTrunc bool
 	flagFilter  []string
+	flagFormat  string
 }
 
 // Init initialize images command.
@@ -63,14 +98,17 @@ func (i *ImagesCommand) Init(c *Cli) {
 func (i *ImagesCommand) addFlags() {
 	flagSet := i.cmd.Flags()
 	flagSet.BoolVarP(&i.flagQuiet, "quiet", "q", false, "Only show image numeric ID")
-	flagSet.BoolVar(&i.flagDigest, "digest", false, "Show images with digest")
+	flagSet.BoolVar(&i.flagDigest, "digests", false, "Show images with digests")
+	flagSet.BoolVar(&i.flagDigest, "digest", false, "Show images with digests")
+	flagSet.MarkDeprecated("digest", "please use --digests instead")
 	flagSet.BoolVar(&i.flagNoTrunc, "no-trunc", false, "Do not truncate output")
-	flagSet.StringSliceVarP(&i.flagFilter, "filter", "f", []string{}, "Filter output based on conditions provided, filter support reference, since, before")
+	flagSet.StringSliceVarP(&i.flagFilter, "filter", "f", []string{}, "Filter output based on conditions provided, filter support dangling, reference, since, before")
+	flagSet.StringVar(&i.flagFormat, "format", "", "Pretty-print images using a Go template, fields ID, Reference, Name, Tag, Digest, Size and CreatedAt are supported, the template prefixed with \"table\" prints the columns with headers")
 }
 
 // runImages is the entry of images container command.
 func (i *ImagesCommand) runImages(args []string) error {
-	ctx := context.Background()
+	ctx := i.cli.Context()
 	apiClient := i.cli.Client()
 
 	imageFilterArgs, err := filters.FromFilterOpts(i.flagFilter)
@@ -78,9 +116,21 @@ func (i *ImagesCommand) runImages(args []string) error {
 		return err
 	}
 
+	// the default template in config file is used if --format isn't set.
+	format := i.flagFormat
+	if format == "" {
+		format = i.cli.ConfigFile().ImagesFormat
+	}
+	if format == "" {
+		format = defaultImagesFormat
+		if i.flagDigest {
+			format = defaultImagesDigestsFormat
+		}
+	}
+
 	imageList, err := apiClient.ImageList(ctx, imageFilterArgs)
 	if err != nil {
-		return fmt.Errorf("failed to get image list: %v", err)
+		return errors.Wrap(err, "failed to get image list")
 	}
 
 	if i.flagQuiet {
@@ -94,35 +144,50 @@ func (i *ImagesCommand) runImages(args []string) error {
 		return nil
 	}
 
-	display := i.cli.NewTableDisplay()
-	if i.flagDigest {
-		display.AddRow([]string{"IMAGE ID", "IMAGE NAME", "DIGEST", "SIZE"})
-	} else {
-		display.AddRow([]string{"IMAGE ID", "IMAGE NAME", "SIZE"})
-	}
-
-	dimgs := make([]displayImage, 0, len(imageList))
+	var rows []interface{}
 	for _, img := range imageList {
-		dimgs = append(dimgs, imageInfoToDisplayImages(img, i.flagNoTrunc)...)
-	}
-
-	for _, dimg := range dimgs {
-		if i.flagDigest {
-			display.AddRow([]string{dimg.id, dimg.name, dimg.digest, dimg.size.String()})
-		} else {
-			display.AddRow([]string{dimg.id, dimg.name, dimg.size.String()})
+		for _, dimg := range imageInfoToDisplayImages(img, i.flagNoTrunc, i.flagDigest) {
+			rows = append(rows, dimg.formatContext())
 		}
 	}
 
-	display.Flush()
-	return nil
+	return i.cli.NewFormatter(format).Write(imagesHeader, rows)
 }
 
-func imageInfoToDisplayImages(img types.ImageInfo, noTrunc bool) []displayImage {
+// formatContext converts the image into the row of images.
+func (dimg displayImage) formatContext() imageFormatContext {
+	return imageFormatContext{
+		ID:        dimg.id,
+		Reference: dimg.name,
+		Name:      dimg.repository,
+		Tag:       dimg.tag,
+		Digest:    dimg.digest,
+		Size:      dimg.size.String(),
+		CreatedAt: dimg.createdAt,
+	}
+}
+
+// imageInfoToDisplayImages converts the image into rows of images table.
+// If withDigests is true, the tagged image with multiple digests will be
+// rendered one row per digest.
+func imageInfoToDisplayImages(img types.ImageInfo, noTrunc, withDigests bool) []displayImage {
 	dimgs := make([]displayImage, 0)
 
-	nameTags := make(map[string][]string)
-	digestIndexByName := make(map[string]digest.Digest)
+	var (
+		names       []string
+		nameTags    = make(map[string][]string)
+		nameDigests = make(map[string][]digest.Digest)
+	)
+
+	addName := func(name string) {
+		if _, ok := nameTags[name]; ok {
+			return
+		}
+		if _, ok := nameDigests[name]; ok {
+			return
+		}
+		names = append(names, name)
+	}
 
 	for _, repoTag := range img.RepoTags {
 		namedRef, err := reference.Parse(repoTag)
@@ -133,6 +198,7 @@ func imageInfoToDisplayImages(img types.ImageInfo, noTrunc bool) []displayImage
 
 		if reference.IsNameTagged(namedRef) {
 			taggedRef := namedRef.(reference.Tagged)
+			addName(taggedRef.Name())
 			nameTags[taggedRef.Name()] = append(nameTags[taggedRef.Name()], taggedRef.Tag())
 		}
 	}
@@ -146,7 +212,8 @@ func imageInfoToDisplayImages(img types.ImageInfo, noTrunc bool) []displayImage
 
 		namedRef = reference.TrimTagForDigest(namedRef)
 		if cdRef, ok := namedRef.(reference.CanonicalDigested); ok {
-			digestIndexByName[cdRef.Name()] = cdRef.Digest()
+			addName(cdRef.Name())
+			nameDigests[cdRef.Name()] = append(nameDigests[cdRef.Name()], cdRef.Digest())
 		}
 	}
 
@@ -155,41 +222,63 @@ func imageInfoToDisplayImages(img types.ImageInfo, noTrunc bool) []displayImage
 		imageDisplayID = img.ID
 	}
 
-	for name, tags := range nameTags {
-		for _, tag := range tags {
+	for _, name := range names {
+		digests := nameDigests[name]
+		for _, tag := range nameTags[name] {
 			dimg := displayImage{
-				id:   imageDisplayID,
-				name: name + ":" + tag,
-				size: imageSize(img.Size),
+				id:         imageDisplayID,
+				name:       name + ":" + tag,
+				repository: name,
+				tag:        tag,
+				size:       imageSize(img.Size),
+				digest:     "<none>",
+				createdAt:  img.CreatedAt,
+			}
+
+			if len(digests) == 0 {
+				dimgs = append(dimgs, dimg)
+				continue
 			}
 
-			if dig, ok := digestIndexByName[name]; ok {
+			if !withDigests {
+				dimg.digest = digests[0].String()
+				dimgs = append(dimgs, dimg)
+				continue
+			}
+
+			for _, dig := range digests {
 				dimg.digest = dig.String()
-			} else {
-				dimg.digest = "<none>"
+				dimgs = append(dimgs, dimg)
 			}
-			dimgs = append(dimgs, dimg)
 		}
 	}
 
 	// if there is no repo tags
 	if len(dimgs) == 0 {
-		for name, dig := range digestIndexByName {
-			dimgs = append(dimgs, displayImage{
-				id:     imageDisplayID,
-				name:   name + "@" + dig.String(),
-				digest: dig.String(),
-				size:   imageSize(img.Size),
-			})
+		for _, name := range names {
+			for _, dig := range nameDigests[name] {
+				dimgs = append(dimgs, displayImage{
+					id:         imageDisplayID,
+					name:       name + "@" + dig.String(),
+					repository: name,
+					tag:        "<none>",
+					digest:     dig.String(),
+					size:       imageSize(img.Size),
+					createdAt:  img.CreatedAt,
+				})
+			}
 		}
 
 		// if there is no repo digests
 		if len(dimgs) == 0 {
 			dimgs = append(dimgs, displayImage{
-				id:     imageDisplayID,
-				name:   "<none>",
-				digest: "<none>",
-				size:   imageSize(img.Size),
+				id:         imageDisplayID,
+				name:       "<none>",
+				repository: "<none>",
+				tag:        "<none>",
+				digest:     "<none>",
+				size:       imageSize(img.Size),
+				createdAt:  img.CreatedAt,
 			})
 		}
 	}
@@ -203,7 +292,7 @@ IMAGE ID             IMAGE NAME                                               SI
 bbc3a0323522         docker.io/library/busybox:latest                         703.14 KB
 b81f317384d7         docker.io/library/nginx:latest                           42.39 MB
 
-$ pouch images --digest
+$ pouch images --digests
 IMAGE ID       IMAGE NAME                                           DIGEST                                                                    SIZE
 2cb0d9787c4d   registry.hub.docker.com/library/hello-world:latest   sha256:4b8ff392a12ed9ea17784bd3c9a8b1fa3299cac44aca35a85c90c5e3c7afacdc   6.30 KB
 4ab4c602aa5e   registry.hub.docker.com/library/hello-world:linux    sha256:d5c7d767f5ba807f9b363aa4db87d75ab030404a670880e16aedff16f605484b   5.25 KB
@@ -211,5 +300,13 @@ IMAGE ID       IMAGE NAME                                           DIGEST
 $ pouch images --no-trunc
 IMAGE ID                                                                  IMAGE NAME                                           SIZE
 sha256:2cb0d9787c4dd17ef9eb03e512923bc4db10add190d3f84af63b744e353a9b34   registry.hub.docker.com/library/hello-world:latest   6.30 KB
-sha256:4ab4c602aa5eed5528a6620ff18a1dc4faef0e1ab3a5eddeddb410714478c67f   registry.hub.docker.com/library/hello-world:linux    5.25 KB`
+sha256:4ab4c602aa5eed5528a6620ff18a1dc4faef0e1ab3a5eddeddb410714478c67f   registry.hub.docker.com/library/hello-world:linux    5.25 KB
+
+$ pouch images -q
+2cb0d9787c4d
+4ab4c602aa5e
+
+$ pouch images --format '{{.Name}}:{{.Tag}}\t{{.Size}}'
+registry.hub.docker.com/library/hello-world:latest	6.30 KB
+registry.hub.docker.com/library/hello-world:linux	5.25 KB`
 }
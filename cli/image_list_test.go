@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/cli/formatter"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageInfoToDisplayImages(t *testing.T) {
+	var (
+		id      = "sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a"
+		digest1 = "sha256:141c253bc4c3fd0a201d32dc1f493bcf3fff003b6df416dea4f41046e0f37d47"
+		digest2 = "sha256:74f634b1bc1bd74535d5209589734efbd44a25f4e2dc96d78784576a3eb5b335"
+	)
+
+	img := types.ImageInfo{
+		ID:       id,
+		Size:     1024,
+		RepoTags: []string{"docker.io/library/busybox:latest"},
+		RepoDigests: []string{
+			"docker.io/library/busybox@" + digest1,
+			"docker.io/library/busybox@" + digest2,
+		},
+	}
+
+	// one row per tag without digests
+	dimgs := imageInfoToDisplayImages(img, false, false)
+	assert.Equal(t, 1, len(dimgs))
+	assert.Equal(t, id[7:19], dimgs[0].id)
+	assert.Equal(t, "docker.io/library/busybox:latest", dimgs[0].name)
+
+	// one row per digest with digests
+	dimgs = imageInfoToDisplayImages(img, true, true)
+	assert.Equal(t, 2, len(dimgs))
+	for i, dig := range []string{digest1, digest2} {
+		assert.Equal(t, id, dimgs[i].id)
+		assert.Equal(t, "docker.io/library/busybox:latest", dimgs[i].name)
+		assert.Equal(t, dig, dimgs[i].digest)
+	}
+
+	// image without tags
+	img.RepoTags = nil
+	dimgs = imageInfoToDisplayImages(img, false, true)
+	assert.Equal(t, 2, len(dimgs))
+	assert.Equal(t, "docker.io/library/busybox@"+digest1, dimgs[0].name)
+	assert.Equal(t, "docker.io/library/busybox@"+digest2, dimgs[1].name)
+
+	// image without tags and digests
+	img.RepoDigests = nil
+	dimgs = imageInfoToDisplayImages(img, false, true)
+	assert.Equal(t, []displayImage{{
+		id:         id[7:19],
+		name:       "<none>",
+		repository: "<none>",
+		tag:        "<none>",
+		digest:     "<none>",
+		size:       1024,
+	}}, dimgs)
+}
+
+func TestFormatDisplayImages(t *testing.T) {
+	rows := []interface{}{
+		displayImage{id: "8c811b4aec35", name: "docker.io/library/busybox:latest", repository: "docker.io/library/busybox", tag: "latest", size: 1024}.formatContext(),
+		displayImage{id: "8c811b4aec36", name: "docker.io/library/busybox:1.28", repository: "docker.io/library/busybox", tag: "1.28", size: 2048}.formatContext(),
+	}
+
+	out := new(bytes.Buffer)
+	f := &formatter.Formatter{Output: out, Format: "{{.ID}} {{.Name}}:{{.Tag}} {{.Size}}"}
+	assert.NoError(t, f.Write(imagesHeader, rows))
+	assert.Equal(t, "8c811b4aec35 docker.io/library/busybox:latest 1.00 KB\n8c811b4aec36 docker.io/library/busybox:1.28 2.00 KB\n", out.String())
+
+	// nothing should be printed if template fails
+	out.Reset()
+	f.Format = "{{.Name.Unknown}}"
+	assert.Error(t, f.Write(imagesHeader, rows))
+	assert.Equal(t, "", out.String())
+
+	// the default format prints the table with headers.
+	out.Reset()
+	f.Format = defaultImagesFormat
+	assert.NoError(t, f.Write(imagesHeader, rows))
+	assert.Equal(t, "IMAGE ID       IMAGE NAME                         SIZE\n"+
+		"8c811b4aec35   docker.io/library/busybox:latest   1.00 KB\n"+
+		"8c811b4aec36   docker.io/library/busybox:1.28     2.00 KB\n", out.String())
+}
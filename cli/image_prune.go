@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// imagePruneDescription is used to describe image prune command in detail and auto generate command doc.
+var imagePruneDescription = "Remove unused images. By default, only the dangling images, which have no tag, " +
+	"will be removed. With -a, all the images which are not used by any container will be removed."
+
+// ImagePruneCommand use to implement 'image prune' command.
+type ImagePruneCommand struct {
+	baseCommand
+
+	// flags for image prune command
+	flagAll    bool
+	flagForce  bool
+	flagFilter []string
+}
+
+// Init initialize "image prune" command.
+func (p *ImagePruneCommand) Init(c *Cli) {
+	p.cli = c
+	p.cmd = &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove unused images",
+		Long:  imagePruneDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.runPrune()
+		},
+		Example: imagePruneExample(),
+	}
+	p.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (p *ImagePruneCommand) addFlags() {
+	flagSet := p.cmd.Flags()
+	flagSet.BoolVarP(&p.flagAll, "all", "a", false, "Remove all unused images, not just dangling ones")
+	flagSet.BoolVarP(&p.flagForce, "force", "f", false, "Do not prompt for confirmation")
+	flagSet.StringSliceVar(&p.flagFilter, "filter", []string{}, "Provide filter values, filter support until=<duration or timestamp>")
+}
+
+// runPrune is the entry of image prune command.
+func (p *ImagePruneCommand) runPrune() error {
+	filter, err := filters.FromFilterOpts(p.flagFilter)
+	if err != nil {
+		return err
+	}
+
+	// dangling filter is decided by --all flag
+	if filter.Contains("dangling") {
+		return fmt.Errorf("invalid filter 'dangling', use --all instead")
+	}
+	filter.Add("dangling", strconv.FormatBool(!p.flagAll))
+
+	message := "WARNING! This will remove all dangling images."
+	if p.flagAll {
+		message = "WARNING! This will remove all images without at least one container associated to them."
+	}
+
+	if !p.flagForce && !confirmPrompt(os.Stdin, os.Stdout, message) {
+		return nil
+	}
+
+	ctx := p.cli.Context()
+	apiClient := p.cli.Client()
+
+	resp, err := apiClient.ImagesPrune(ctx, filter)
+	if err != nil {
+		return errors.Wrap(err, "failed to prune images")
+	}
+
+	displayPruneResult(os.Stdout, resp)
+	return nil
+}
+
+// displayPruneResult prints the deleted references and the reclaimed space.
+func displayPruneResult(out io.Writer, resp *types.ImagePruneResp) {
+	if len(resp.ImagesDeleted) > 0 {
+		fmt.Fprintln(out, "Deleted Images:")
+		for _, item := range resp.ImagesDeleted {
+			if item.Untagged != "" {
+				fmt.Fprintf(out, "Untagged: %s\n", item.Untagged)
+			}
+			if item.Deleted != "" {
+				fmt.Fprintf(out, "Deleted: %s\n", item.Deleted)
+			}
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "Total reclaimed space: %s\n", utils.FormatSize(resp.SpaceReclaimed))
+}
+
+// confirmPrompt shows the message and asks user to confirm. Only y or yes
+// is regarded as confirmation.
+func confirmPrompt(in io.Reader, out io.Writer, message string) bool {
+	fmt.Fprintf(out, "%s\nAre you sure you want to continue? [y/N] ", message)
+
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// imagePruneExample shows examples in image prune command, and is used in auto-generated cli docs.
+func imagePruneExample() string {
+	return `$ pouch image prune -a --filter until=24h
+WARNING! This will remove all images without at least one container associated to them.
+Are you sure you want to continue? [y/N] y
+Deleted Images:
+Untagged: registry.hub.docker.com/library/busybox:1.28
+Untagged: registry.hub.docker.com/library/busybox@sha256:141c253bc4c3fd0a201d32dc1f493bcf3fff003b6df416dea4f41046e0f37d47
+Deleted: sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a
+
+Total reclaimed space: 710.80 KB`
+}
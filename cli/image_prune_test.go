@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmPrompt(t *testing.T) {
+	for _, tc := range []struct {
+		answer string
+		expect bool
+	}{
+		{answer: "y\n", expect: true},
+		{answer: "Yes\n", expect: true},
+		{answer: " y ", expect: true},
+		{answer: "n\n", expect: false},
+		{answer: "\n", expect: false},
+		{answer: "", expect: false},
+		{answer: "yep\n", expect: false},
+	} {
+		out := new(bytes.Buffer)
+		assert.Equal(t, tc.expect, confirmPrompt(strings.NewReader(tc.answer), out, "WARNING!"), tc.answer)
+		assert.Equal(t, "WARNING!\nAre you sure you want to continue? [y/N] ", out.String())
+	}
+}
+
+func TestDisplayPruneResult(t *testing.T) {
+	out := new(bytes.Buffer)
+	displayPruneResult(out, &types.ImagePruneResp{
+		ImagesDeleted: []*types.ImageDeleteResponseItem{
+			{Untagged: "docker.io/library/busybox:1.28"},
+			{Deleted: "sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a"},
+		},
+		SpaceReclaimed: 1024,
+	})
+	assert.Equal(t, `Deleted Images:
+Untagged: docker.io/library/busybox:1.28
+Deleted: sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a
+
+Total reclaimed space: 1.00 KB
+`, out.String())
+
+	// nothing is deleted
+	out.Reset()
+	displayPruneResult(out, &types.ImagePruneResp{})
+	assert.Equal(t, "Total reclaimed space: 0.00 B\n", out.String())
+}
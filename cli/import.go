@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alibaba/pouch/pkg/term"
+
+	"github.com/spf13/cobra"
+)
+
+// importDescription is used to describe import command in detail and auto generate command doc.
+var importDescription = "Import the tar archive of rootfs as an image with single layer, such as the one produced by " +
+	"pouch export. Use \"-\" to read the tar archive from STDIN. The Dockerfile instructions ENV, CMD, ENTRYPOINT and " +
+	"EXPOSE can be applied to the config of image by --change."
+
+// ImportCommand use to implement 'import' command.
+type ImportCommand struct {
+	baseCommand
+	message string
+	changes []string
+}
+
+// Init initialize import command.
+func (i *ImportCommand) Init(c *Cli) {
+	i.cli = c
+	i.cmd = &cobra.Command{
+		Use:   "import [OPTIONS] file|- REPOSITORY[:TAG]",
+		Short: "Import the tar archive of rootfs as an image",
+		Long:  importDescription,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return i.runImport(args)
+		},
+		Example: importExample(),
+	}
+	i.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (i *ImportCommand) addFlags() {
+	flagSet := i.cmd.Flags()
+	flagSet.StringVarP(&i.message, "message", "m", "", "Set commit message for imported image")
+	flagSet.StringArrayVarP(&i.changes, "change", "c", nil, "Apply Dockerfile instruction to the created image, only ENV, CMD, ENTRYPOINT and EXPOSE are supported")
+}
+
+// runImport is the entry of import command.
+func (i *ImportCommand) runImport(args []string) error {
+	ctx := i.cli.Context()
+	apiClient := i.cli.Client()
+
+	var in io.Reader = os.Stdin
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+		in = file
+	} else if term.IsTerminal(os.Stdin.Fd()) {
+		return fmt.Errorf("requested import from STDIN, but STDIN is a terminal, redirect STDIN")
+	}
+
+	imageInfo, err := apiClient.ImageImport(ctx, args[1], i.message, i.changes, in)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(imageInfo.ID)
+	return nil
+}
+
+// importExample shows examples in import command, and is used in auto-generated cli docs.
+func importExample() string {
+	return `$ pouch import -c 'CMD ["/bin/sh"]' rootfs.tar foo:rootfs
+sha256:bd3a9ab1d8d1e1b6f6b4e5d6f4f3a3c1d7a8b2f5e9c0d4a6b8e2f1c3d5a7b9e0
+$ cat rootfs.tar | pouch import - foo:stdin
+sha256:bd3a9ab1d8d1e1b6f6b4e5d6f4f3a3c1d7a8b2f5e9c0d4a6b8e2f1c3d5a7b9e0`
+}
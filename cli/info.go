@@ -1,24 +1,32 @@
 package main
 
 import (
-	"context"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"text/template"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/cli/formatter"
 
 	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 // infoDespscription is used to describe info command in detail and auto generate command doc.
 var infoDescription = "Display the information of pouch, " +
-	"including Containers, Images, Storage Driver, Execution Driver, Logging Driver, Kernel Version, " +
-	"Operating System, CPUs, Total Memory, Name, ID."
+	"including Containers by state, Images, Storage Driver, containerd, Logging Driver, Cgroup Driver, Kernel Version, " +
+	"Operating System, CPUs, Total Memory, Registry Mirrors, Insecure Registries, Labels, Name, ID. " +
+	"The warnings of daemon and host, such as no swap limit support, are printed at the end."
 
 // InfoCommand implements info command.
 type InfoCommand struct {
 	baseCommand
+
+	flagFormat string
 }
 
 // Init initializes info command.
@@ -39,129 +47,192 @@ func (v *InfoCommand) Init(c *Cli) {
 
 // addFlags adds flags for specific command.
 func (v *InfoCommand) addFlags() {
-	// TODO: add flags here
+	flagSet := v.cmd.Flags()
+	flagSet.StringVar(&v.flagFormat, "format", "", "Format the output using the given Go template, such as {{.ServerVersion}} or {{json .RegistryConfig}}")
 }
 
 // runInfo is the entry of info command.
 func (v *InfoCommand) runInfo() error {
-	ctx := context.Background()
+	var tmpl *template.Template
+	if v.flagFormat != "" {
+		t, err := formatter.Parse(v.flagFormat + "\n")
+		if err != nil {
+			return err
+		}
+		tmpl = t
+	}
+
+	ctx := v.cli.Context()
 	apiClient := v.cli.Client()
 
 	result, err := apiClient.SystemInfo(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get system info: %v", err)
+		return errors.Wrap(err, "failed to get system info")
+	}
+
+	if tmpl != nil {
+		if err := tmpl.Execute(os.Stdout, result); err != nil {
+			return fmt.Errorf("failed to execute format template: %v", err)
+		}
+		return nil
 	}
 
-	return prettyPrintInfo(v.cli, result)
+	prettyPrintInfo(os.Stdout, v.cli.Host(), result)
+
+	// like docker, the warnings are printed at the end.
+	for _, warning := range result.Warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+	return nil
 }
 
-func prettyPrintInfo(cli *Cli, info *types.SystemInfo) error {
-	fmt.Fprintf(os.Stdout, "Containers: %d\n", info.Containers)
-	fmt.Fprintf(os.Stdout, " Running: %d\n", info.ContainersRunning)
-	fmt.Fprintf(os.Stdout, " Paused: %d\n", info.ContainersPaused)
-	fmt.Fprintf(os.Stdout, " Stopped: %d\n", info.ContainersStopped)
-	fmt.Fprintf(os.Stdout, "Images: %d\n", info.Images)
-	fmt.Fprintf(os.Stdout, "ID: %s\n", info.ID)
-	fmt.Fprintf(os.Stdout, "Name: %s\n", info.Name)
-	fmt.Fprintf(os.Stdout, "Server Version: %s\n", info.ServerVersion)
-	fmt.Fprintf(os.Stdout, "Storage Driver: %s\n", info.Driver)
-	fmt.Fprintf(os.Stdout, "Driver Status: %v\n", info.DriverStatus)
-	fmt.Fprintf(os.Stdout, "Logging Driver: %s\n", info.LoggingDriver)
-	fmt.Fprintf(os.Stdout, "Volume Drivers: %v\n", info.VolumeDrivers)
-	fmt.Fprintf(os.Stdout, "Cgroup Driver: %s\n", info.CgroupDriver)
-	fmt.Fprintf(os.Stdout, "Default Runtime: %s\n", info.DefaultRuntime)
+// prettyPrintInfo prints the system info as an indented block, the lists
+// are printed in the lines indented under their names.
+func prettyPrintInfo(out io.Writer, endpoint string, info *types.SystemInfo) {
+	fmt.Fprintf(out, "Containers: %d\n", info.Containers)
+	fmt.Fprintf(out, " Running: %d\n", info.ContainersRunning)
+	fmt.Fprintf(out, " Paused: %d\n", info.ContainersPaused)
+	fmt.Fprintf(out, " Stopped: %d\n", info.ContainersStopped)
+	fmt.Fprintf(out, "Images: %d\n", info.Images)
+	fmt.Fprintf(out, "ID: %s\n", info.ID)
+	fmt.Fprintf(out, "Name: %s\n", info.Name)
+	fmt.Fprintf(out, "Endpoint: %s\n", endpoint)
+	fmt.Fprintf(out, "Server Version: %s\n", info.ServerVersion)
+	fmt.Fprintf(out, "Storage Driver: %s\n", info.Driver)
+	for _, status := range info.DriverStatus {
+		if len(status) == 2 {
+			fmt.Fprintf(out, " %s: %s\n", status[0], status[1])
+		}
+	}
+	fmt.Fprintf(out, "Logging Driver: %s\n", info.LoggingDriver)
+	fmt.Fprintf(out, "Volume Drivers: %s\n", strings.Join(info.VolumeDrivers, " "))
+	fmt.Fprintf(out, "Cgroup Driver: %s\n", info.CgroupDriver)
+	fmt.Fprintf(out, "Default Runtime: %s\n", info.DefaultRuntime)
 	if len(info.Runtimes) > 0 {
-		fmt.Fprint(os.Stdout, "Runtimes:")
+		names := make([]string, 0, len(info.Runtimes))
 		for name := range info.Runtimes {
-			fmt.Fprintf(os.Stdout, " %s", name)
+			names = append(names, name)
 		}
-		fmt.Fprint(os.Stdout, "\n")
+		sort.Strings(names)
+		fmt.Fprintf(out, "Runtimes: %s\n", strings.Join(names, " "))
 	}
-	fmt.Fprintf(os.Stdout, "runc: %v\n", info.RuncCommit)
-	fmt.Fprintf(os.Stdout, "containerd: %v\n", info.ContainerdCommit)
+	fmt.Fprintln(out, "containerd:")
+	fmt.Fprintf(out, " Address: %s\n", info.ContainerdAddress)
+	fmt.Fprintf(out, " Status: %s\n", info.ContainerdStatus)
+	fmt.Fprintf(out, " Version: %s\n", info.ContainerdVersion)
+	fmt.Fprintf(out, " Commit: %s\n", commitID(info.ContainerdCommit))
+	fmt.Fprintln(out, "runc:")
+	fmt.Fprintf(out, " Commit: %s\n", commitID(info.RuncCommit))
+	printInfoList(out, "Security Options", info.SecurityOptions)
 
 	// Kernel info
-	fmt.Fprintf(os.Stdout, "Security Options: %v\n", info.SecurityOptions)
-	fmt.Fprintf(os.Stdout, "Kernel Version: %s\n", info.KernelVersion)
-	fmt.Fprintf(os.Stdout, "Operating System: %s\n", info.OperatingSystem)
-	fmt.Fprintf(os.Stdout, "OSType: %s\n", info.OSType)
-	fmt.Fprintf(os.Stdout, "Architecture: %s\n", info.Architecture)
-
-	fmt.Fprintf(os.Stdout, "HTTP Proxy: %s\n", info.HTTPProxy)
-	fmt.Fprintf(os.Stdout, "HTTPS Proxy: %s\n", info.HTTPSProxy)
-	fmt.Fprintf(os.Stdout, "Registry: %s\n", info.IndexServerAddress)
-	fmt.Fprintf(os.Stdout, "Experimental: %v\n", info.ExperimentalBuild)
-	fmt.Fprintf(os.Stdout, "Debug: %v\n", info.Debug)
-	if len(info.Labels) != 0 {
-		fmt.Fprintln(os.Stdout, "Labels:")
-		for _, label := range info.Labels {
-			fmt.Fprintf(os.Stdout, "  %s\n", label)
-		}
-	}
-
-	fmt.Fprintf(os.Stdout, "CPUs: %d\n", info.NCPU)
-	fmt.Fprintf(os.Stdout, "Total Memory: %s\n", units.BytesSize(float64(info.MemTotal)))
-	fmt.Fprintf(os.Stdout, "Pouch Root Dir: %s\n", info.PouchRootDir)
-	fmt.Fprintf(os.Stdout, "LiveRestoreEnabled: %v\n", info.LiveRestoreEnabled)
-	fmt.Fprintf(os.Stdout, "LxcfsEnabled: %v\n", info.LxcfsEnabled)
-	fmt.Fprintf(os.Stdout, "CriEnabled: %v\n", info.CriEnabled)
-	if info.RegistryConfig != nil && (len(info.RegistryConfig.InsecureRegistryCIDRs) > 0 || len(info.RegistryConfig.IndexConfigs) > 0) {
-		fmt.Fprintln(os.Stdout, "Insecure Registries:")
+	fmt.Fprintf(out, "Kernel Version: %s\n", info.KernelVersion)
+	fmt.Fprintf(out, "Operating System: %s\n", info.OperatingSystem)
+	fmt.Fprintf(out, "OSType: %s\n", info.OSType)
+	fmt.Fprintf(out, "Architecture: %s\n", info.Architecture)
+	fmt.Fprintf(out, "CPUs: %d\n", info.NCPU)
+	fmt.Fprintf(out, "Total Memory: %s\n", units.BytesSize(float64(info.MemTotal)))
+
+	fmt.Fprintf(out, "HTTP Proxy: %s\n", info.HTTPProxy)
+	fmt.Fprintf(out, "HTTPS Proxy: %s\n", info.HTTPSProxy)
+	fmt.Fprintf(out, "Registry: %s\n", info.IndexServerAddress)
+	fmt.Fprintf(out, "Experimental: %v\n", info.ExperimentalBuild)
+	fmt.Fprintf(out, "Debug: %v\n", info.Debug)
+	printInfoList(out, "Labels", info.Labels)
+
+	fmt.Fprintf(out, "Pouch Root Dir: %s\n", info.PouchRootDir)
+	fmt.Fprintf(out, "LiveRestoreEnabled: %v\n", info.LiveRestoreEnabled)
+	fmt.Fprintf(out, "LxcfsEnabled: %v\n", info.LxcfsEnabled)
+	fmt.Fprintf(out, "CriEnabled: %v\n", info.CriEnabled)
+	fmt.Fprintf(out, "Max Concurrent Downloads: %d\n", info.MaxConcurrentDownloads)
+	if info.RegistryConfig != nil {
+		// sort the registries to keep the output stable.
+		names := make([]string, 0, len(info.RegistryConfig.IndexConfigs))
 		for _, registry := range info.RegistryConfig.IndexConfigs {
 			if !registry.Secure {
-				fmt.Fprintf(os.Stdout, " %s\n", registry.Name)
+				names = append(names, registry.Name)
 			}
 		}
-
-		for _, registry := range info.RegistryConfig.InsecureRegistryCIDRs {
-			fmt.Fprintf(os.Stdout, " %s\n", registry)
-		}
+		sort.Strings(names)
+		printInfoList(out, "Insecure Registries", append(names, info.RegistryConfig.InsecureRegistryCIDRs...))
+		printInfoList(out, "Registry Mirrors", info.RegistryConfig.Mirrors)
 	}
+	printInfoList(out, "Daemon Listen Addresses", info.ListenAddresses)
+}
 
-	if info.RegistryConfig != nil && len(info.RegistryConfig.Mirrors) > 0 {
-		fmt.Fprintln(os.Stdout, "Registry Mirrors:")
-		for _, mirror := range info.RegistryConfig.Mirrors {
-			fmt.Fprintf(os.Stdout, " %s\n", mirror)
-		}
+// printInfoList prints the name and the items indented in following lines,
+// nothing is printed if the list is empty.
+func printInfoList(out io.Writer, name string, items []string) {
+	if len(items) == 0 {
+		return
 	}
+	fmt.Fprintf(out, "%s:\n", name)
+	for _, item := range items {
+		fmt.Fprintf(out, " %s\n", item)
+	}
+}
 
-	fmt.Fprintf(os.Stdout, "Daemon Listen Addresses: %v\n", info.ListenAddresses)
-
-	return nil
+// commitID returns the ID of commit, or <unknown> if it isn't reported.
+func commitID(commit *types.Commit) string {
+	if commit == nil || commit.ID == "" {
+		return "<unknown>"
+	}
+	return commit.ID
 }
 
 // infoExample shows examples in info command, and is used in auto-generated cli docs.
 func infoExample() string {
 	return `$ pouch info
-Containers: 1
+Containers: 3
  Running: 1
  Paused: 0
- Stopped: 0
-Images:  0
+ Stopped: 2
+Images: 2
 ID:
-Name:
-Server Version: 0.3-dev
-Storage Driver:
-Driver Status: []
-Logging Driver:
-Cgroup Driver:
-runc: <nil>
-containerd: <nil>
-Security Options: []
+Name: pouch-host
+Endpoint: unix:///var/run/pouchd.sock
+Server Version: 1.3.0
+Storage Driver: overlayfs
+Logging Driver: json-file
+Volume Drivers: local tmpfs
+Cgroup Driver: cgroupfs
+Default Runtime: runc
+Runtimes: runc
+containerd:
+ Address: /var/run/containerd.sock
+ Status: connected
+ Version: v1.0.3
+ Commit: 773c489c9c1b21a6d78b5c538cd395416ec50f88
+runc:
+ Commit: ccb5efd37fb7c86364786e9137e22948751de7ed-dirty
+Security Options:
+ seccomp
 Kernel Version: 3.10.0-693.17.1.el7.x86_64
-Operating System:
+Operating System: CentOS Linux 7 (Core)
 OSType: linux
-Architecture:
+Architecture: amd64
+CPUs: 4
+Total Memory: 7.638GiB
 HTTP Proxy: http://127.0.0.1:5678
 HTTPS Proxy:
 Registry: https://index.docker.io/v1/
 Experimental: false
-Debug: true
-Labels: []
-CPUs: 0
-Total Memory: 0
+Debug: false
+Labels:
+ zone=hz
 Pouch Root Dir: /var/lib/pouch
-LiveRestoreEnabled: false
-Daemon Listen Addresses: [unix:///var/run/pouchd.sock]
-`
+LiveRestoreEnabled: true
+LxcfsEnabled: false
+CriEnabled: false
+Max Concurrent Downloads: 3
+Insecure Registries:
+ lab-registry:5000
+Registry Mirrors:
+ https://mirror.example.com
+Daemon Listen Addresses:
+ unix:///var/run/pouchd.sock
+WARNING: No swap limit support
+
+$ pouch info --format '{{.ServerVersion}} {{.Driver}}'
+1.3.0 overlayfs`
 }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrettyPrintInfo(t *testing.T) {
+	info := &types.SystemInfo{
+		Containers:        3,
+		ContainersRunning: 1,
+		ContainersStopped: 2,
+		ContainerdAddress: "/var/run/containerd.sock",
+		ContainerdCommit:  &types.Commit{ID: "773c489c"},
+		ContainerdStatus:  "connected",
+		ContainerdVersion: "v1.0.3",
+		DriverStatus:      [][]string{{"Backing Filesystem", "xfs"}},
+		Labels:            []string{"zone=hz"},
+		RegistryConfig: &types.RegistryServiceConfig{
+			IndexConfigs: map[string]types.IndexInfo{
+				"reg.abc.com":       {Name: "reg.abc.com", Secure: true},
+				"lab-registry:5000": {Name: "lab-registry:5000"},
+			},
+			Mirrors: []string{"https://mirror.example.com"},
+		},
+		ListenAddresses: []string{"unix:///var/run/pouchd.sock"},
+	}
+
+	out := new(bytes.Buffer)
+	prettyPrintInfo(out, "unix:///var/run/pouchd.sock", info)
+
+	for _, expected := range []string{
+		"Containers: 3\n Running: 1\n Paused: 0\n Stopped: 2\n",
+		"Storage Driver: \n Backing Filesystem: xfs\n",
+		"containerd:\n Address: /var/run/containerd.sock\n Status: connected\n Version: v1.0.3\n Commit: 773c489c\n",
+		"runc:\n Commit: <unknown>\n",
+		"Labels:\n zone=hz\n",
+		"Insecure Registries:\n lab-registry:5000\nRegistry Mirrors:\n https://mirror.example.com\n",
+		"Daemon Listen Addresses:\n unix:///var/run/pouchd.sock\n",
+	} {
+		assert.Contains(t, out.String(), expected)
+	}
+
+	// the empty lists are not printed.
+	assert.NotContains(t, out.String(), "Security Options:")
+	assert.NotContains(t, out.String(), "reg.abc.com")
+}
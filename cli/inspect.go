@@ -2,28 +2,40 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/cli/inspect"
+	"github.com/alibaba/pouch/client"
 
 	"github.com/spf13/cobra"
 )
 
 // inspectDescription is used to describe inspect command in detail and auto generate command doc.
-var inspectDescription = "Return detailed information on Pouch container"
+var inspectDescription = "Return detailed information on Pouch container. " +
+	"If the object is not a container, the image with the same name or ID will be returned, " +
+	"and the flag type can be used to select the type of object."
+
+const (
+	// inspectTypeContainer is the type to inspect container only.
+	inspectTypeContainer = "container"
+	// inspectTypeImage is the type to inspect image only.
+	inspectTypeImage = "image"
+)
 
 // InspectCommand is used to implement 'inspect' command.
 type InspectCommand struct {
 	baseCommand
-	format string
+	format     string
+	objectType string
 }
 
 // Init initializes InspectCommand command.
 func (p *InspectCommand) Init(c *Cli) {
 	p.cli = c
 	p.cmd = &cobra.Command{
-		Use:   "inspect [OPTIONS] CONTAINER",
+		Use:   "inspect [OPTIONS] CONTAINER|IMAGE [CONTAINER|IMAGE...]",
 		Short: "Get the detailed information of container",
 		Long:  inspectDescription,
 		Args:  cobra.MinimumNArgs(1),
@@ -38,22 +50,60 @@ func (p *InspectCommand) Init(c *Cli) {
 // addFlags adds flags for specific command.
 func (p *InspectCommand) addFlags() {
 	p.cmd.Flags().StringVarP(&p.format, "format", "f", "", "Format the output using the given go template")
+	p.cmd.Flags().StringVar(&p.objectType, "type", "", "Return JSON for specified type, container or image")
 }
 
 // runInspect is the entry of InspectCommand command.
 func (p *InspectCommand) runInspect(args []string) error {
-	ctx := context.Background()
+	ctx := p.cli.Context()
 	apiClient := p.cli.Client()
 
+	switch p.objectType {
+	case "", inspectTypeContainer, inspectTypeImage:
+	default:
+		return fmt.Errorf("invalid type %q: only container and image are supported", p.objectType)
+	}
+
+	notFound := false
 	getRefFunc := func(ref string) (interface{}, error) {
-		res, err := apiClient.ContainerGet(ctx, ref)
-		if err != nil {
-			return nil, err
+		obj, err := p.inspectObject(ctx, apiClient, ref)
+		if client.IsErrNotFound(err) {
+			notFound = true
 		}
-		return convContainerJSONToInspectContainerJSON(res), nil
+		return obj, err
+	}
+
+	if err := inspect.Inspect(os.Stdout, args, p.format, getRefFunc); err != nil {
+		if notFound {
+			return ExitError{Code: exitCodeNotFound, Status: fmt.Sprintf("Error: %v", err)}
+		}
+		return err
+	}
+	return nil
+}
+
+// inspectObject returns the container by reference, and falls back to the
+// image if the container is not found and the type isn't specified.
+func (p *InspectCommand) inspectObject(ctx context.Context, apiClient client.CommonAPIClient, ref string) (interface{}, error) {
+	if p.objectType == inspectTypeImage {
+		return apiClient.ImageInspect(ctx, ref)
+	}
+
+	c, err := apiClient.ContainerGet(ctx, ref)
+	if err == nil {
+		return convContainerJSONToInspectContainerJSON(c), nil
 	}
 
-	return inspect.Inspect(os.Stdout, args, p.format, getRefFunc)
+	if p.objectType == inspectTypeContainer || !client.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	image, imageErr := apiClient.ImageInspect(ctx, ref)
+	if imageErr != nil {
+		// report the error of container, since it is the default type.
+		return nil, err
+	}
+	return image, nil
 }
 
 // inspectExample shows examples in inspect command, and is used in auto-generated cli docs.
@@ -66,7 +66,7 @@ func Inspect(out io.Writer, refs []string, tmplStr string, getRef GetRefFunc) er
 	for _, ref := range refs {
 		element, err := getRef(ref)
 		if err != nil {
-			errs = append(errs, errors.Errorf("Fetch object error: %v", err))
+			errs = append(errs, errors.Wrap(err, "Fetch object error"))
 			continue
 		}
 
@@ -79,8 +79,12 @@ func Inspect(out io.Writer, refs []string, tmplStr string, getRef GetRefFunc) er
 		return err
 	}
 
-	if len(errs) == 0 {
+	switch len(errs) {
+	case 0:
 		return nil
+	case 1:
+		// keep the error returned by getRef, which tells the kind of failure.
+		return errs[0]
 	}
 
 	formatErrMsg := func(idx int, err error) (string, error) {
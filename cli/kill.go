@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// killDescription is used to describe kill command in detail and auto generate command doc.
+var killDescription = "Send a signal to the init process of one or more running containers in Pouchd. " +
+	"The default signal is SIGKILL, and the signal can be specified by name such as SIGHUP or HUP, or by number such as 1. " +
+	"Killing a container which is not running will fail."
+
+// KillCommand use to implement 'kill' command, it sends a signal to containers.
+type KillCommand struct {
+	baseCommand
+	signal string
+}
+
+// Init initialize kill command.
+func (k *KillCommand) Init(c *Cli) {
+	k.cli = c
+	k.cmd = &cobra.Command{
+		Use:   "kill [OPTIONS] CONTAINER [CONTAINER...]",
+		Short: "Kill one or more running containers",
+		Long:  killDescription,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return k.runKill(args)
+		},
+		Example: killExample(),
+	}
+	k.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (k *KillCommand) addFlags() {
+	flagSet := k.cmd.Flags()
+	flagSet.StringVarP(&k.signal, "signal", "s", "KILL", "Signal to send to the container")
+}
+
+// runKill is the entry of kill command.
+func (k *KillCommand) runKill(args []string) error {
+	ctx := k.cli.Context()
+	apiClient := k.cli.Client()
+
+	var errs errorList
+	for _, name := range args {
+		if err := apiClient.ContainerKill(ctx, name, k.signal); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Printf("%s\n", name)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// killExample shows examples in kill command, and is used in auto-generated cli docs.
+func killExample() string {
+	return `$ pouch ps
+Name     ID       Status    Image                              Runtime
+foo      71b9c1   Running   docker.io/library/busybox:latest   runc
+$ pouch kill -s SIGTERM foo
+foo
+$ pouch ps -a
+Name     ID       Status    Image                              Runtime
+foo      71b9c1   Exited    docker.io/library/busybox:latest   runc`
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// parseKeyValueFile reads the KEY=VALUE lines of file, the blank lines and
+// the lines starting with # are ignored. The line without '=' is passed to
+// emptyFn, and it is invalid if emptyFn is nil.
+func parseKeyValueFile(path string, emptyFn func(string) string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		lines   []string
+		lineNum int
+	)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimLeftFunc(scanner.Text(), unicode.IsSpace)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		key := parts[0]
+		if key == "" {
+			return nil, fmt.Errorf("invalid file %s: line %d: key cannot be empty", path, lineNum)
+		}
+		if strings.IndexFunc(key, unicode.IsSpace) >= 0 {
+			return nil, fmt.Errorf("invalid file %s: line %d: key %q contains whitespaces", path, lineNum, key)
+		}
+
+		if len(parts) == 1 {
+			if emptyFn == nil {
+				return nil, fmt.Errorf("invalid file %s: line %d: %q must be in format of key=value", path, lineNum, line)
+			}
+			line = emptyFn(line)
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %v", path, err)
+	}
+	return lines, nil
+}
+
+// readLabels returns the labels from the label files and the label flags,
+// the later value of the same key overrides the earlier one.
+func readLabels(labelFiles []string, labels []string) ([]string, error) {
+	var all []string
+	for _, file := range labelFiles {
+		fileLabels, err := parseKeyValueFile(file, nil)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, fileLabels...)
+	}
+	return append(all, labels...), nil
+}
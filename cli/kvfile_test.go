@@ -0,0 +1,36 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadLabels(t *testing.T) {
+	dir, err := ioutil.TempDir("", "label-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := writeEnvFile(t, dir, "labels", "# comment\n\nteam=payments\nenv=prod\n")
+	labels, err := readLabels([]string{file}, []string{"env=test"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team=payments", "env=prod", "env=test"}, labels)
+
+	labels, err = readLabels(nil, []string{"a=b"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a=b"}, labels)
+
+	// the label without value is invalid in file.
+	file = writeEnvFile(t, dir, "invalid", "team=payments\nenv\n")
+	_, err = readLabels([]string{file}, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+
+	_, err = readLabels([]string{filepath.Join(dir, "missing")}, nil)
+	assert.Error(t, err)
+}
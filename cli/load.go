@@ -1,20 +1,27 @@
 package main
 
 import (
-	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 
+	"github.com/alibaba/pouch/client"
+	"github.com/alibaba/pouch/pkg/jsonstream"
+	"github.com/alibaba/pouch/pkg/term"
+
 	"github.com/spf13/cobra"
 )
 
 // loadDescription is used to describe load command in detail and auto generate command doc.
-var loadDescription = "load a set of images by tar stream"
+var loadDescription = "load a set of images by tar stream, which can be produced by pouch save or docker save. " +
+	"If IMAGE_NAME is specified, the images will be named as IMAGE_NAME:TAG, otherwise the references in the tar stream are kept."
 
 // LoadCommand use to implement 'load' command.
 type LoadCommand struct {
 	baseCommand
 	input string
+	quiet bool
 }
 
 // Init initialize load command.
@@ -37,11 +44,12 @@ func (l *LoadCommand) Init(c *Cli) {
 func (l *LoadCommand) addFlags() {
 	flagSet := l.cmd.Flags()
 	flagSet.StringVarP(&l.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
+	flagSet.BoolVarP(&l.quiet, "quiet", "q", false, "Suppress the load progress and only print the loaded image names")
 }
 
 // runLoad is the entry of load command.
 func (l *LoadCommand) runLoad(args []string) error {
-	ctx := context.Background()
+	ctx := l.cli.Context()
 	apiClient := l.cli.Client()
 
 	var (
@@ -57,15 +65,61 @@ func (l *LoadCommand) runLoad(args []string) error {
 
 		defer file.Close()
 		in = file
+	} else if term.IsTerminal(os.Stdin.Fd()) {
+		return fmt.Errorf("requested load from STDIN, but STDIN is a terminal, use -i flag or redirect STDIN")
 	}
 
 	if len(args) > 0 {
 		imageName = args[0]
 	}
-	return apiClient.ImageLoad(ctx, imageName, in)
+
+	body, err := apiClient.ImageLoad(ctx, imageName, in)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if !l.quiet {
+		return showProgress(body)
+	}
+
+	names, err := loadedImages(body)
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return err
+}
+
+// loadedImages consumes the load progress, and returns the names of the
+// loaded images.
+func loadedImages(body io.Reader) ([]string, error) {
+	var names []string
+
+	dec := json.NewDecoder(body)
+	for {
+		var msg jsonstream.JSONMessage
+
+		if err := dec.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return names, nil
+			}
+			return names, err
+		}
+
+		if msg.Error != nil {
+			return names, client.NewStreamError(msg.Error.Code, msg.Error.Message)
+		}
+
+		if msg.Status == jsonstream.LoadStatusLoaded {
+			names = append(names, msg.ID)
+		}
+	}
 }
 
 // loadExample shows examples in load command, and is used in auto-generated cli docs.
 func loadExample() string {
-	return `$ pouch load -i busybox.tar busybox`
+	return `$ pouch load -i busybox.tar busybox
+$ pouch load -q < images.tar
+registry.hub.docker.com/library/busybox:latest
+registry.hub.docker.com/library/busybox:1.25`
 }
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadedImages(t *testing.T) {
+	body := strings.NewReader(`{"id":"8c811b4aec35","status":"loading","progressDetail":{"current":0,"total":10}}
+{"id":"8c811b4aec35","status":"done","progressDetail":{"current":10,"total":10}}
+{"id":"docker.io/library/busybox:latest","status":"unpacking"}
+{"id":"docker.io/library/busybox:latest","status":"loaded"}
+{"id":"docker.io/library/busybox:1.25","status":"loaded"}
+`)
+
+	names, err := loadedImages(body)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docker.io/library/busybox:latest", "docker.io/library/busybox:1.25"}, names)
+}
+
+func TestLoadedImagesError(t *testing.T) {
+	body := strings.NewReader(`{"id":"docker.io/library/busybox:latest","status":"loaded"}
+{"errorDetail":{"code":500,"message":"blob sha256:8c81 is corrupted"},"error":"blob sha256:8c81 is corrupted"}
+`)
+
+	names, err := loadedImages(body)
+	assert.EqualError(t, err, "blob sha256:8c81 is corrupted")
+	assert.Equal(t, []string{"docker.io/library/busybox:latest"}, names)
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/credential"
@@ -12,14 +16,17 @@ import (
 )
 
 // loginDescription is used to describe login command and auto generate command doc.
-var loginDescription = "\nlogin to a v1/v2 registry with the provided credentials."
+var loginDescription = "\nlogin to a v1/v2 registry with the provided credentials. " +
+	"The credentials are stored in ~/.pouch/config.json or the file set by POUCH_CONFIG, which is only readable by the user, or by the docker credential helper " +
+	"if \"credsStore\" or \"credHelpers\" is set in the file, such as {\"credsStore\": \"osxkeychain\"}."
 
 // LoginCommand use to implement 'login' command.
 type LoginCommand struct {
 	baseCommand
 
-	username string
-	password string
+	username      string
+	password      string
+	passwordStdin bool
 }
 
 // Init initialize login command.
@@ -45,16 +52,32 @@ func (l *LoginCommand) addFlags() {
 
 	flagSet.StringVarP(&l.username, "username", "u", "", "username for registry")
 	flagSet.StringVarP(&l.password, "password", "p", "", "password for registry")
+	flagSet.BoolVar(&l.passwordStdin, "password-stdin", false, "read password for registry from stdin")
 }
 
 // runLogin is the entry of login command.
 func (l *LoginCommand) runLogin(args []string) error {
+	if l.passwordStdin {
+		if l.password != "" {
+			return errors.New("--password and --password-stdin are mutually exclusive")
+		}
+		if l.username == "" {
+			return errors.New("must provide --username with --password-stdin")
+		}
+
+		password, err := readPasswordStdin(os.Stdin)
+		if err != nil {
+			return err
+		}
+		l.password = password
+	}
+
 	auth := configureAuth(l.username, l.password)
 	if len(args) > 0 {
 		auth.ServerAddress = args[0]
 	}
 
-	ctx := context.Background()
+	ctx := l.cli.Context()
 	apiClient := l.cli.Client()
 
 	// error will be ignored here, cause registry address can be null.
@@ -92,6 +115,21 @@ func configureAuth(username, password string) *types.AuthConfig {
 	}
 }
 
+// readPasswordStdin reads the password from stdin, and the trailing newline
+// is trimmed so that `echo $password | pouch login` works.
+func readPasswordStdin(in io.Reader) (string, error) {
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password from stdin: %v", err)
+	}
+
+	password := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	if password == "" {
+		return "", errors.New("password is required from stdin")
+	}
+	return password, nil
+}
+
 // readInput reads from stdin.
 func readInput(prompt string, echo bool) (read string) {
 	fmt.Printf("%s: ", prompt)
@@ -116,5 +154,7 @@ func disableEcho(echo bool, read *string) {
 // loginExample shows examples in login command, and is used in auto-generated cli docs.
 func loginExample() string {
 	return `$ pouch login -u $username -p $password
+Login Succeeded
+$ cat ~/password.txt | pouch login -u $username --password-stdin $registry
 Login Succeeded`
 }
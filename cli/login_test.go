@@ -0,0 +1,24 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPasswordStdin(t *testing.T) {
+	for input, expected := range map[string]string{
+		"secret":     "secret",
+		"secret\n":   "secret",
+		"secret\r\n": "secret",
+		"sec ret\n":  "sec ret",
+	} {
+		password, err := readPasswordStdin(strings.NewReader(input))
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, password, input)
+	}
+
+	_, err := readPasswordStdin(strings.NewReader("\n"))
+	assert.Error(t, err)
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"os"
 
@@ -42,7 +41,7 @@ func (l *LogoutCommand) runLogout(args []string) error {
 	}
 
 	if registry == "" {
-		ctx := context.Background()
+		ctx := l.cli.Context()
 		info, err := l.cli.Client().SystemInfo(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Fail to get default registry: %s\n", err)
@@ -51,7 +50,15 @@ func (l *LogoutCommand) runLogout(args []string) error {
 		registry = info.DefaultRegistry
 	}
 
-	if !credential.Exist(registry) {
+	// get the credential instead of checking existence, so that the error
+	// of credential helper can be reported.
+	authConfig, err := credential.Get(registry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Fail to get login credential: %s\n", err)
+		return err
+	}
+
+	if authConfig.Username == "" && authConfig.IdentityToken == "" {
 		fmt.Fprintf(os.Stdout, "Has not logged in registry: %s\n", registry)
 		return nil
 	}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"io"
 	"os"
 
@@ -56,7 +55,7 @@ func (lc *LogsCommand) addFlags() {
 func (lc *LogsCommand) runLogs(args []string) error {
 	containerName := args[0]
 
-	ctx := context.Background()
+	ctx := lc.cli.Context()
 	apiClient := lc.cli.Client()
 
 	opts := types.ContainerLogsOptions{
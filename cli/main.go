@@ -16,16 +16,23 @@ func main() {
 
 	// Add all subcommands.
 	cli.AddCommand(base, &PullCommand{})
+	cli.AddCommand(base, &PushCommand{})
 	cli.AddCommand(base, &CreateCommand{})
 	cli.AddCommand(base, &StartCommand{})
+	cli.AddCommand(base, &AttachCommand{})
 	cli.AddCommand(base, &StopCommand{})
+	cli.AddCommand(base, &KillCommand{})
 	cli.AddCommand(base, &PsCommand{})
 	cli.AddCommand(base, &RmCommand{})
 	cli.AddCommand(base, &RestartCommand{})
 	cli.AddCommand(base, &ExecCommand{})
+	cli.AddCommand(base, &CpCommand{})
+	cli.AddCommand(base, &ExportCommand{})
 	cli.AddCommand(base, &VersionCommand{})
 	cli.AddCommand(base, &InfoCommand{})
+	cli.AddCommand(base, &ContainerMgmtCommand{})
 	cli.AddCommand(base, &ImageMgmtCommand{})
+	cli.AddCommand(base, &SystemCommand{})
 	cli.AddCommand(base, &ImagesCommand{})
 	cli.AddCommand(base, &RmiCommand{})
 	cli.AddCommand(base, &VolumeCommand{})
@@ -33,7 +40,9 @@ func main() {
 	cli.AddCommand(base, &TagCommand{})
 	cli.AddCommand(base, &LoadCommand{})
 	cli.AddCommand(base, &SaveCommand{})
+	cli.AddCommand(base, &ImportCommand{})
 	cli.AddCommand(base, &HistoryCommand{})
+	cli.AddCommand(base, &SearchCommand{})
 
 	cli.AddCommand(base, &InspectCommand{})
 	cli.AddCommand(base, &RenameCommand{})
@@ -45,6 +54,8 @@ func main() {
 	cli.AddCommand(base, &LogoutCommand{})
 	cli.AddCommand(base, &UpgradeCommand{})
 	cli.AddCommand(base, &TopCommand{})
+	cli.AddCommand(base, &PortCommand{})
+	cli.AddCommand(base, &DiffCommand{})
 	cli.AddCommand(base, &LogsCommand{})
 	cli.AddCommand(base, &RemountLxcfsCommand{})
 	cli.AddCommand(base, &WaitCommand{})
@@ -53,26 +64,21 @@ func main() {
 	cli.AddCommand(base, &EventsCommand{})
 	cli.AddCommand(base, &CommitCommand{})
 	cli.AddCommand(base, &StatsCommand{})
+	cli.AddCommand(base, &CompletionCommand{})
 
 	// add generate doc command
 	cli.AddCommand(base, &GenDocCommand{})
 
+	// the error is converted to ExitError by cli.Run, whose code tells the
+	// kind of failure, see exit_code.go for the details.
 	if err := cli.Run(); err != nil {
-		// deal with ExitError, which should be recognize as error, and should
-		// not be exit with status 0.
-		if exitErr, ok := err.(ExitError); ok {
-			if exitErr.Status != "" {
-				fmt.Fprintln(os.Stderr, exitErr.Status)
-			}
-			if exitErr.Code == 0 {
-				// when get error with ExitError, code should not be 0.
-				exitErr.Code = 1
-			}
-			os.Exit(exitErr.Code)
+		exitErr, ok := err.(ExitError)
+		if !ok {
+			exitErr = ExitError{Code: exitCodeFailure, Status: fmt.Sprintf("Error: %v", err)}
 		}
-
-		// not ExitError, print error to os.Stderr, exit code 1.
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		if exitErr.Status != "" {
+			fmt.Fprintln(os.Stderr, exitErr.Status)
+		}
+		os.Exit(exitErr.Code)
 	}
 }
@@ -1,11 +1,11 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/cli/inspect"
 
@@ -15,7 +15,7 @@ import (
 
 // networkDescription defines the network command description and auto generate command doc.
 var networkDescription = "Manager the networks in pouchd. " +
-	"It contains the functions of create/remove/list/inspect network, 'driver' is used to list drivers that pouch support. " +
+	"It contains the functions of create/remove/list/inspect/prune network, 'driver' is used to list drivers that pouch support. " +
 	"Now bridge network is supported in pouchd defaulted, it will be initialized when pouchd starting."
 
 // NetworkCommand is used to implement 'network' command.
@@ -43,11 +43,14 @@ func (n *NetworkCommand) Init(c *Cli) {
 	c.AddCommand(n, &NetworkListCommand{})
 	c.AddCommand(n, &NetworkConnectCommand{})
 	c.AddCommand(n, &NetworkDisconnectCommand{})
+	c.AddCommand(n, &NetworkPruneCommand{})
 }
 
 // networkCreateDescription is used to describe network create command in detail and auto generate command doc.
 var networkCreateDescription = "Create a network in pouchd. " +
-	"It must specify network's name and driver. You can use 'network driver' to get drivers that pouch support."
+	"It must specify network's name and driver, such as bridge and macvlan. " +
+	"The macvlan network requires subnet and gateway, and the parent interface is set by -o parent=<interface>, " +
+	"the vlan sub interface such as eth0.100 is created if it doesn't exist."
 
 // NetworkCreateCommand is used to implement 'network create' command.
 type NetworkCreateCommand struct {
@@ -114,7 +117,7 @@ func (n *NetworkCreateCommand) runNetworkCreate(args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := n.cli.Context()
 	apiClient := n.cli.Client()
 	resp, err := apiClient.NetworkCreate(ctx, networkRequest)
 	if err != nil {
@@ -198,12 +201,15 @@ func parseSliceToMap(slices []string) (map[string]string, error) {
 // networkCreateExample shows examples in network create command, and is used in auto-generated cli docs.
 func networkCreateExample() string {
 	return `$ pouch network create -n pouchnet -d bridge --gateway 192.168.1.1 --subnet 192.168.1.0/24
-pouchnet: e1d541722d68dc5d133cca9e7bd8fd9338603e1763096c8e853522b60d11f7b9`
+pouchnet: e1d541722d68dc5d133cca9e7bd8fd9338603e1763096c8e853522b60d11f7b9
+$ pouch network create -d macvlan --subnet 10.10.100.0/24 --gateway 10.10.100.1 --ip-range 10.10.100.128/25 -o parent=eth0.100 vlan100
+vlan100: 9b2d1a1f6bb92a74e2c1f94d2cfce23bd8d5e3bc870daa1b3b5b3e98bf01c5d4`
 }
 
 // networkRemoveDescription is used to describe network remove command in detail and auto generate command doc.
 var networkRemoveDescription = "Remove a network in pouchd. " +
-	"It must specify network's name."
+	"It must specify network's name. The pre-defined networks bridge, host and none, " +
+	"and the networks connected by containers can't be removed."
 
 // NetworkRemoveCommand is used to implement 'network remove' command.
 type NetworkRemoveCommand struct {
@@ -238,7 +244,7 @@ func (n *NetworkRemoveCommand) addFlags() {
 func (n *NetworkRemoveCommand) runNetworkRemove(args []string) error {
 	name := args[0]
 
-	ctx := context.Background()
+	ctx := n.cli.Context()
 	apiClient := n.cli.Client()
 	if err := apiClient.NetworkRemove(ctx, name); err != nil {
 		return err
@@ -255,7 +261,7 @@ Removed: pouch-net`
 
 // networkInspectDescription is used to describe network inspect command in detail and auto generate command doc.
 var networkInspectDescription = "Inspect a network in pouchd. " +
-	"It must specify network's name."
+	"It must specify network's name. The connected containers are shown with their addresses."
 
 // NetworkInspectCommand is used to implement 'network inspect' command.
 type NetworkInspectCommand struct {
@@ -289,7 +295,7 @@ func (n *NetworkInspectCommand) addFlags() {
 
 // runNetworkInspect is the entry of NetworkInspectCommand command.
 func (n *NetworkInspectCommand) runNetworkInspect(args []string) error {
-	ctx := context.Background()
+	ctx := n.cli.Context()
 	apiClient := n.cli.Client()
 
 	getRefFunc := func(ref string) (interface{}, error) {
@@ -302,21 +308,64 @@ func (n *NetworkInspectCommand) runNetworkInspect(args []string) error {
 // networkInspectExample shows examples in network inspect command, and is used in auto-generated cli docs.
 func networkInspectExample() string {
 	return `$ pouch network inspect net1
-Name:         net1
-Scope:        
-Driver:       bridge
-EnableIPV6:   false
-ID:           c33c2646dc8ce9162faa65d17e80582475bbe53dc70ba0dc4def4b71e44551d6
-Internal:     false`
+[
+    {
+        "Containers": {
+            "8d4cb5d7d93cf2ec7bfb9b9c4b1cb6b6d317b0f9c6b43bde3e1d1a1ae4d2b6c1": {
+                "EndpointID": "a4f0f2bd5c8e4f3b0ec2d1a5cc1f6eb6c6a1a3b3e6a5f2f9b2b7a4c6d5e8f1a2",
+                "IPv4Address": "192.168.1.2/24",
+                "MacAddress": "02:42:c0:a8:01:02",
+                "Name": "foo"
+            }
+        },
+        "Driver": "bridge",
+        "IPAM": {
+            "Config": [
+                {
+                    "Gateway": "192.168.1.1",
+                    "Subnet": "192.168.1.0/24"
+                }
+            ],
+            "Driver": "default"
+        },
+        "Id": "c33c2646dc8ce9162faa65d17e80582475bbe53dc70ba0dc4def4b71e44551d6",
+        "Name": "net1",
+        "Scope": "local"
+    }
+]`
 }
 
 // networkListDescription is used to describe network list command in detail and auto generate command doc.
 var networkListDescription = "List networks in pouchd. " +
 	"It lists the network's Id, name, driver and scope."
 
+// defaultNetworkListFormat is the format of network list if --format isn't set.
+const defaultNetworkListFormat = "table {{.ID}}\t{{.Name}}\t{{.Driver}}\t{{.Scope}}"
+
+// networkFormatContext contains the fields which can be used in the template of network list --format.
+type networkFormatContext struct {
+	ID     string
+	Name   string
+	Driver string
+	Scope  string
+	Labels map[string]string
+}
+
+// networkListHeader is the header of network list table.
+var networkListHeader = networkFormatContext{
+	ID:     "NETWORK ID",
+	Name:   "NAME",
+	Driver: "DRIVER",
+	Scope:  "SCOPE",
+}
+
 // NetworkListCommand is used to implement 'network list' command.
 type NetworkListCommand struct {
 	baseCommand
+
+	quiet  bool
+	filter []string
+	format string
 }
 
 // Init initializes NetworkListCommand command.
@@ -340,33 +389,53 @@ func (n *NetworkListCommand) Init(c *Cli) {
 
 // addFlags adds flags for specific command.
 func (n *NetworkListCommand) addFlags() {
-	//TODO add flags
+	flagSet := n.cmd.Flags()
+
+	flagSet.BoolVarP(&n.quiet, "quiet", "q", false, "Only display network IDs")
+	flagSet.StringSliceVarP(&n.filter, "filter", "f", nil, "Filter output based on conditions provided, filter support driver, id, label, name and type")
+	flagSet.StringVar(&n.format, "format", "", "Pretty-print networks using a Go template, fields ID, Name, Driver, Scope and Labels are supported, the template prefixed with \"table\" prints the columns with headers")
 }
 
 // runNetworkList is the entry of NetworkListCommand command.
 func (n *NetworkListCommand) runNetworkList(args []string) error {
 	logrus.Debugf("list the networks")
 
-	ctx := context.Background()
+	filter, err := filters.FromFilterOpts(n.filter)
+	if err != nil {
+		return err
+	}
+
+	ctx := n.cli.Context()
 	apiClient := n.cli.Client()
-	respNetworkResource, err := apiClient.NetworkList(ctx)
+	respNetworkResource, err := apiClient.NetworkList(ctx, filter)
 	if err != nil {
 		return err
 	}
 
-	display := n.cli.NewTableDisplay()
-	display.AddRow([]string{"NETWORK ID", "NAME", "DRIVER", "SCOPE"})
+	if n.quiet {
+		for _, network := range respNetworkResource {
+			fmt.Println(network.ID[:10])
+		}
+		return nil
+	}
+
+	format := n.format
+	if format == "" {
+		format = defaultNetworkListFormat
+	}
+
+	rows := make([]interface{}, 0, len(respNetworkResource))
 	for _, network := range respNetworkResource {
-		display.AddRow([]string{
-			network.ID[:10],
-			network.Name,
-			network.Driver,
-			network.Scope,
+		rows = append(rows, networkFormatContext{
+			ID:     network.ID[:10],
+			Name:   network.Name,
+			Driver: network.Driver,
+			Scope:  network.Scope,
+			Labels: network.Labels,
 		})
 	}
 
-	display.Flush()
-	return nil
+	return n.cli.NewFormatter(format).Write(networkListHeader, rows)
 }
 
 // networkListExample shows examples in network list command, and is used in auto-generated cli docs.
@@ -376,12 +445,17 @@ NETWORK ID   NAME     DRIVER   SCOPE
 058fce03b8   none     null     local
 b05a9b8844   bridge   bridge   local
 d8684bf988   host     host     local
+
+$ pouch network list --filter type=custom -q
+e1d541722d
 `
 }
 
 // networkConnectDescription is used to describe network connect command in detail and auto generate command doc.
 var networkConnectDescription = "Connect a container to a network in pouchd. " +
-	"It must specify network's name and container's name."
+	"It must specify network's name and container's name. " +
+	"A running container is connected at once, and a stopped container is connected when it starts. " +
+	"The IP address and aliases are only supported on user defined networks."
 
 // NetworkConnectCommand is used to implement 'network connect' command.
 type NetworkConnectCommand struct {
@@ -447,7 +521,7 @@ func (n *NetworkConnectCommand) runNetworkConnect(args []string) error {
 		},
 	}
 
-	ctx := context.Background()
+	ctx := n.cli.Context()
 	apiClient := n.cli.Client()
 	err := apiClient.NetworkConnect(ctx, network, networkReq)
 	if err != nil {
@@ -461,11 +535,15 @@ func (n *NetworkConnectCommand) runNetworkConnect(args []string) error {
 // networkConnectExample shows examples in network connect command, and is used in auto-generated cli docs.
 func networkConnectExample() string {
 	return `$ pouch network connect net1 container1
-container container1 is connected to network net1`
+container container1 is connected to network net1
+$ pouch network connect --ip 172.20.0.10 --alias db mynet container2
+container container2 is connected to network mynet`
 }
 
 // networkDisconnectDescription is used to describe network disconnect command in detail and auto generate comand doc.
-var networkDisconnectDescription = "Disconnect a container from a network"
+var networkDisconnectDescription = "Disconnect a container from a network. " +
+	"A container disconnected from all of its networks has no network connectivity. " +
+	"Use --force to delete the endpoint of a removed or dead container, which requires the full container ID if the container has been removed."
 
 // NetworkDisconnectCommand use to implement 'network disconnect' command, it disconnects given container from given network.
 type NetworkDisconnectCommand struct {
@@ -507,14 +585,14 @@ func (nd *NetworkDisconnectCommand) runNetworkDisconnect(args []string) error {
 		return fmt.Errorf("container name cannot be empty")
 	}
 
-	ctx := context.Background()
+	ctx := nd.cli.Context()
 	apiClient := nd.cli.Client()
 
 	err := apiClient.NetworkDisconnect(ctx, network, container, nd.force)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("container %s is disconnected from network %s\n successfully", container, network)
+	fmt.Printf("container %s is disconnected from network %s successfully\n", container, network)
 
 	return nil
 }
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// networkPruneDescription is used to describe network prune command in detail and auto generate command doc.
+var networkPruneDescription = "Remove all the user defined networks which are not used by any container, no matter the " +
+	"container is running or stopped. The pre-defined networks bridge, host and none are never removed."
+
+// NetworkPruneCommand use to implement 'network prune' command.
+type NetworkPruneCommand struct {
+	baseCommand
+
+	// flags for network prune command
+	flagForce  bool
+	flagFilter []string
+}
+
+// Init initialize "network prune" command.
+func (p *NetworkPruneCommand) Init(c *Cli) {
+	p.cli = c
+	p.cmd = &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove all unused networks",
+		Long:  networkPruneDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.runPrune()
+		},
+		Example: networkPruneExample(),
+	}
+	p.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (p *NetworkPruneCommand) addFlags() {
+	flagSet := p.cmd.Flags()
+	flagSet.BoolVarP(&p.flagForce, "force", "f", false, "Do not prompt for confirmation")
+	flagSet.StringSliceVar(&p.flagFilter, "filter", []string{}, "Provide filter values, filter support until=<timestamp>, label=<key>[=<value>] and label!=<key>[=<value>]")
+}
+
+// runPrune is the entry of network prune command.
+func (p *NetworkPruneCommand) runPrune() error {
+	filter, err := filters.FromFilterOpts(p.flagFilter)
+	if err != nil {
+		return err
+	}
+
+	if !p.flagForce && !confirmPrompt(os.Stdin, os.Stdout, "WARNING! This will remove all networks not used by at least one container.") {
+		return nil
+	}
+
+	ctx := p.cli.Context()
+	apiClient := p.cli.Client()
+
+	resp, err := apiClient.NetworksPrune(ctx, filter)
+	if err != nil {
+		return errors.Wrap(err, "failed to prune networks")
+	}
+
+	displayNetworkPruneResult(os.Stdout, resp)
+	return nil
+}
+
+// displayNetworkPruneResult prints the deleted networks.
+func displayNetworkPruneResult(out io.Writer, resp *types.NetworkPruneResp) {
+	if len(resp.NetworksDeleted) == 0 {
+		return
+	}
+	fmt.Fprintln(out, "Deleted Networks:")
+	for _, name := range resp.NetworksDeleted {
+		fmt.Fprintln(out, name)
+	}
+}
+
+// networkPruneExample shows examples in network prune command, and is used in auto-generated cli docs.
+func networkPruneExample() string {
+	return `$ pouch network prune --filter until=24h
+WARNING! This will remove all networks not used by at least one container.
+Are you sure you want to continue? [y/N] y
+Deleted Networks:
+net1
+net2`
+}
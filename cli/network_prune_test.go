@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayNetworkPruneResult(t *testing.T) {
+	out := new(bytes.Buffer)
+	displayNetworkPruneResult(out, &types.NetworkPruneResp{
+		NetworksDeleted: []string{"foo", "bar"},
+	})
+	assert.Equal(t, `Deleted Networks:
+foo
+bar
+`, out.String())
+
+	// nothing is deleted
+	out.Reset()
+	displayNetworkPruneResult(out, &types.NetworkPruneResp{})
+	assert.Equal(t, "", out.String())
+}
@@ -1,10 +1,7 @@
 package main
 
 import (
-	"context"
-	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -37,20 +34,20 @@ func (p *PauseCommand) Init(c *Cli) {
 
 // runPause is the entry of pause command.
 func (p *PauseCommand) runPause(args []string) error {
-	ctx := context.Background()
+	ctx := p.cli.Context()
 	apiClient := p.cli.Client()
 
-	var errs []string
+	var errs errorList
 	for _, name := range args {
 		if err := apiClient.ContainerPause(ctx, name); err != nil {
-			errs = append(errs, err.Error())
+			errs = append(errs, err)
 			continue
 		}
 		fmt.Printf("%s\n", name)
 	}
 
 	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
+		return errs
 	}
 
 	return nil
@@ -59,14 +56,14 @@ func (p *PauseCommand) runPause(args []string) error {
 // pauseExample shows examples in pause command, and is used in auto-generated cli docs.
 func pauseExample() string {
 	return `$ pouch ps
-Name   ID       Status          Created          Image                                            Runtime
-foo2   87259c   Up 25 seconds   26 seconds ago   registry.hub.docker.com/library/busybox:latest   runc
-foo1   77188c   Up 46 seconds   47 seconds ago   registry.hub.docker.com/library/busybox:latest   runc
+CONTAINER ID   IMAGE                              COMMAND   CREATED          STATUS          PORTS   NAMES
+87259c         docker.io/library/busybox:latest   "top"     26 seconds ago   Up 25 seconds           foo2
+77188c         docker.io/library/busybox:latest   "top"     47 seconds ago   Up 46 seconds           foo1
 $ pouch pause foo1 foo2
 foo1
 foo2
 $ pouch ps
-Name   ID       Status                Created        Image                                            Runtime
-foo2   87259c   Up 1 minute(paused)   1 minute ago   registry.hub.docker.com/library/busybox:latest   runc
-foo1   77188c   Up 1 minute(paused)   1 minute ago   registry.hub.docker.com/library/busybox:latest   runc`
+CONTAINER ID   IMAGE                              COMMAND   CREATED        STATUS                 PORTS   NAMES
+87259c         docker.io/library/busybox:latest   "top"     1 minute ago   Up 1 minute (Paused)           foo2
+77188c         docker.io/library/busybox:latest   "top"     1 minute ago   Up 1 minute (Paused)           foo1`
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/spf13/cobra"
+)
+
+// portDescription is used to describe port command in detail and auto generate command doc.
+var portDescription = "List the port mappings of a running container, or look up the public addresses " +
+	"which PRIVATE_PORT is mapped to. The protocol of PRIVATE_PORT is tcp if it is not specified."
+
+// PortCommand use to implement 'port' command, it lists the port mappings of container.
+type PortCommand struct {
+	baseCommand
+}
+
+// Init initialize port command.
+func (p *PortCommand) Init(c *Cli) {
+	p.cli = c
+	p.cmd = &cobra.Command{
+		Use:   "port CONTAINER [PRIVATE_PORT[/PROTO]]",
+		Short: "List port mappings or a specific mapping for the container",
+		Long:  portDescription,
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.runPort(args)
+		},
+		Example: portExample(),
+	}
+}
+
+// runPort is the entry of port command.
+func (p *PortCommand) runPort(args []string) error {
+	ctx := p.cli.Context()
+	apiClient := p.cli.Client()
+
+	c, err := apiClient.ContainerGet(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	var ports types.PortMap
+	if c.NetworkSettings != nil {
+		ports = c.NetworkSettings.Ports
+	}
+
+	if len(args) == 1 {
+		for _, line := range formatPortMappings(ports) {
+			fmt.Fprintln(os.Stdout, line)
+		}
+		return nil
+	}
+
+	port := args[1]
+	if !strings.Contains(port, "/") {
+		port += "/tcp"
+	}
+
+	bindings := ports[port]
+	if len(bindings) == 0 {
+		return fmt.Errorf("no public port '%s' published for %s", port, args[0])
+	}
+	for _, b := range bindings {
+		fmt.Fprintln(os.Stdout, hostAddress(b))
+	}
+	return nil
+}
+
+// formatPortMappings formats the port mappings sorted by the private port,
+// such as 80/tcp -> 0.0.0.0:8080.
+func formatPortMappings(ports types.PortMap) []string {
+	var lines []string
+	for port, bindings := range ports {
+		for _, b := range bindings {
+			lines = append(lines, fmt.Sprintf("%s -> %s", port, hostAddress(b)))
+		}
+	}
+
+	sort.Strings(lines)
+	return lines
+}
+
+// hostAddress returns the host address of port binding.
+func hostAddress(b types.PortBinding) string {
+	ip := b.HostIP
+	if ip == "" {
+		ip = "0.0.0.0"
+	}
+	return ip + ":" + b.HostPort
+}
+
+// portExample shows examples in port command, and is used in auto-generated cli docs.
+func portExample() string {
+	return `$ pouch run -d --name foo -p 8080:80 -p 127.0.0.1:8443:443/tcp busybox top
+$ pouch port foo
+443/tcp -> 127.0.0.1:8443
+80/tcp -> 0.0.0.0:8080
+$ pouch port foo 80
+0.0.0.0:8080`
+}
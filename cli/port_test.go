@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPortMappings(t *testing.T) {
+	ports := types.PortMap{
+		"80/tcp":   {{HostIP: "0.0.0.0", HostPort: "8080"}, {HostIP: "127.0.0.1", HostPort: "8081"}},
+		"443/tcp":  {{HostPort: "8443"}},
+		"53/udp":   {{HostIP: "127.0.0.1", HostPort: "53"}},
+		"8000/tcp": nil,
+	}
+
+	assert.Equal(t, []string{
+		"443/tcp -> 0.0.0.0:8443",
+		"53/udp -> 127.0.0.1:53",
+		"80/tcp -> 0.0.0.0:8080",
+		"80/tcp -> 127.0.0.1:8081",
+	}, formatPortMappings(ports))
+	assert.Nil(t, formatPortMappings(nil))
+}
@@ -1,24 +1,68 @@
 package main
 
 import (
-	"context"
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/cli/formatter"
 	"github.com/alibaba/pouch/pkg/utils"
 	"github.com/alibaba/pouch/pkg/utils/filters"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 // psDescription is used to describe ps command in detail and auto generate command doc.
-var psDescription = "\nList Containers with container name, ID, status, creation time, image reference and runtime."
+var psDescription = "\nList Containers with container ID, image reference, command, creation time, status, ports and name."
+
+// commandTruncLength is the max length of command shown in the table.
+const commandTruncLength = 20
+
+// defaultPsFormat is the format of ps if neither --format nor the psFormat in
+// config file is set.
+const defaultPsFormat = "table {{.ID}}\t{{.Image}}\t{{.Command}}\t{{.RunningFor}}\t{{.Status}}\t{{.Ports}}\t{{.Names}}"
+
+// psHeader is the header of ps table.
+var psHeader = psFormatContext{
+	ID:         "CONTAINER ID",
+	Image:      "IMAGE",
+	Command:    "COMMAND",
+	CreatedAt:  "CREATED AT",
+	RunningFor: "CREATED",
+	Status:     "STATUS",
+	Ports:      "PORTS",
+	Names:      "NAMES",
+	Runtime:    "RUNTIME",
+}
 
 // containerList is used to save the container list.
 type containerList []*types.Container
 
+// psFormatContext contains the fields which can be used in the template of ps --format.
+type psFormatContext struct {
+	ID         string
+	Image      string
+	Command    string
+	CreatedAt  string
+	RunningFor string
+	Status     string
+	Ports      string
+	Names      string
+	Labels     map[string]string
+	Runtime    string
+}
+
+// Label returns the value of label, which is used as {{.Label "key"}} in the
+// template of ps --format.
+func (p psFormatContext) Label(name string) string {
+	return p.Labels[name]
+}
+
 // PsCommand is used to implement 'ps' command.
 type PsCommand struct {
 	baseCommand
@@ -28,6 +72,9 @@ type PsCommand struct {
 	flagQuiet   bool
 	flagNoTrunc bool
 	flagFilter  []string
+	flagFormat  string
+	flagLast    int
+	flagLatest  bool
 }
 
 // Init initializes PsCommand command.
@@ -53,11 +100,14 @@ func (p *PsCommand) addFlags() {
 	flagSet.BoolVarP(&p.flagQuiet, "quiet", "q", false, "Only show numeric IDs")
 	flagSet.BoolVar(&p.flagNoTrunc, "no-trunc", false, "Do not truncate output")
 	flagSet.StringSliceVarP(&p.flagFilter, "filter", "f", nil, "Filter output based on given conditions, support filter key [ id label name status ]")
+	flagSet.StringVar(&p.flagFormat, "format", "", "Pretty-print containers using a Go template, fields ID, Image, Command, CreatedAt, RunningFor, Status, Ports, Names, Labels and Runtime are supported, and {{.Label \"key\"}} returns the value of label, the template prefixed with \"table\" prints the columns with headers")
+	flagSet.IntVarP(&p.flagLast, "last", "n", 0, "Show n last created containers (includes all states)")
+	flagSet.BoolVarP(&p.flagLatest, "latest", "l", false, "Show the latest created container (includes all states)")
 }
 
 // runPs is the entry of PsCommand command.
 func (p *PsCommand) runPs(args []string) error {
-	ctx := context.Background()
+	ctx := p.cli.Context()
 	apiClient := p.cli.Client()
 
 	filter, err := filters.Parse(p.flagFilter)
@@ -65,15 +115,37 @@ func (p *PsCommand) runPs(args []string) error {
 		return err
 	}
 
+	if p.flagLast < 0 {
+		return fmt.Errorf("invalid --last %d: must be greater than or equal to 0", p.flagLast)
+	}
+
+	// the default template in config file is used if --format isn't set.
+	format := p.flagFormat
+	if format == "" {
+		format = p.cli.ConfigFile().PsFormat
+	}
+
+	// only the default table is colorized, the output of the template set
+	// by user is kept as it is.
+	colorize := format == ""
+	if format == "" {
+		format = defaultPsFormat
+	}
+
 	var containers containerList
 
 	option := types.ContainerListOptions{
 		All:    p.flagAll,
 		Filter: filter,
+		Limit:  int64(p.flagLast),
+	}
+	if p.flagLatest {
+		option.Limit = 1
 	}
+
 	containers, err = apiClient.ContainerList(ctx, option)
 	if err != nil {
-		return fmt.Errorf("failed to get container list: %v", err)
+		return errors.Wrap(err, "failed to get container list")
 	}
 
 	sort.Sort(containers)
@@ -89,62 +161,135 @@ func (p *PsCommand) runPs(args []string) error {
 		return nil
 	}
 
-	display := p.cli.NewTableDisplay()
-	display.AddRow([]string{"Name", "ID", "Status", "Created", "Image", "Runtime"})
-
+	f := p.cli.NewFormatter(format)
+	f.Color = f.Color && colorize
+	rows := make([]interface{}, 0, len(containers))
 	for _, c := range containers {
-		created, err := utils.FormatTimeInterval(c.Created)
+		row, err := containerToFormatContext(c, p.flagNoTrunc)
 		if err != nil {
 			return err
 		}
+		row.Status = f.Colorize(row.Status, statusColor(c))
+		rows = append(rows, row)
+	}
 
-		id := c.ID[:6]
-		if p.flagNoTrunc {
-			id = c.ID
+	return f.Write(psHeader, rows)
+}
+
+// exitedStatusPattern matches the status of exited container, such as
+// "Exited (137) 5 minutes ago", and the exit code is captured.
+var exitedStatusPattern = regexp.MustCompile(`^(?:Exited|Stopped) \((-?\d+)\)`)
+
+// statusColor returns the color of container status, green for the running
+// container, yellow for the restarting one and red for the one exited with
+// non-zero code.
+func statusColor(c *types.Container) formatter.Color {
+	switch types.Status(c.State) {
+	case types.StatusRunning:
+		return formatter.Green
+	case types.StatusRestarting:
+		return formatter.Yellow
+	case types.StatusExited, types.StatusStopped:
+		if m := exitedStatusPattern.FindStringSubmatch(c.Status); m != nil && m[1] != "0" {
+			return formatter.Red
 		}
+	}
+	return ""
+}
 
-		display.AddRow([]string{c.Names[0], id, c.Status, created + " ago", c.Image, c.HostConfig.Runtime})
+// containerToFormatContext converts the container into the row of ps.
+func containerToFormatContext(c *types.Container, noTrunc bool) (psFormatContext, error) {
+	created, err := utils.FormatTimeInterval(c.Created)
+	if err != nil {
+		return psFormatContext{}, err
+	}
+
+	row := psFormatContext{
+		ID:         c.ID,
+		Image:      c.Image,
+		Command:    c.Command,
+		CreatedAt:  time.Unix(0, c.Created).Format(utils.TimeLayout),
+		RunningFor: created + " ago",
+		Status:     c.Status,
+		Labels:     c.Labels,
+	}
+
+	if len(c.Names) > 0 {
+		row.Names = c.Names[0]
+	}
+
+	if c.HostConfig != nil {
+		row.Ports = formatPorts(c.HostConfig.PortBindings)
+		row.Runtime = c.HostConfig.Runtime
+	}
+
+	// the ports of running container are the ones actually mapped, which
+	// include the random host ports.
+	if c.NetworkSettings != nil && len(c.NetworkSettings.Ports) > 0 {
+		row.Ports = formatPorts(c.NetworkSettings.Ports)
+	}
+
+	if !noTrunc {
+		row.ID = c.ID[:6]
+		if cmd := []rune(row.Command); len(cmd) > commandTruncLength {
+			row.Command = string(cmd[:commandTruncLength-1]) + "…"
+		}
 	}
-	display.Flush()
-	return nil
+	row.Command = strconv.Quote(row.Command)
+
+	return row, nil
+}
+
+// formatPorts formats the port bindings, such as 0.0.0.0:8080->80/tcp.
+func formatPorts(bindings types.PortMap) string {
+	var ports []string
+	for port, binds := range bindings {
+		for _, b := range binds {
+			ports = append(ports, fmt.Sprintf("%s->%s", hostAddress(b), port))
+		}
+	}
+
+	sort.Strings(ports)
+	return strings.Join(ports, ", ")
 }
 
 // psExample shows examples in ps command, and is used in auto-generated cli docs.
 func psExample() string {
 	return `$ pouch ps
-Name   ID       Status          Created          Image                              Runtime
-2      e42c68   Up 15 minutes   16 minutes ago   docker.io/library/busybox:latest   runc
-1      a8c2ea   Up 16 minutes   17 minutes ago   docker.io/library/busybox:latest   runc
+CONTAINER ID   IMAGE                              COMMAND   CREATED          STATUS          PORTS                  NAMES
+e42c68         docker.io/library/busybox:latest   "top"     16 minutes ago   Up 15 minutes                          2
+a8c2ea         docker.io/library/nginx:latest     "nginx"   17 minutes ago   Up 16 minutes   0.0.0.0:8080->80/tcp   1
 
 $ pouch ps -a
-Name   ID       Status          Created          Image                              Runtime
-3      faf132   created         16 seconds ago   docker.io/library/busybox:latest   runc
-2      e42c68   Up 16 minutes   16 minutes ago   docker.io/library/busybox:latest   runc
-1      a8c2ea   Up 17 minutes   18 minutes ago   docker.io/library/busybox:latest   runc
+CONTAINER ID   IMAGE                              COMMAND   CREATED          STATUS                       PORTS                  NAMES
+faf132         docker.io/library/busybox:latest   "ls"      16 seconds ago   Exited (0) 15 seconds ago                           3
+e42c68         docker.io/library/busybox:latest   "top"     16 minutes ago   Up 16 minutes                                       2
+a8c2ea         docker.io/library/nginx:latest     "nginx"   18 minutes ago   Up 17 minutes                0.0.0.0:8080->80/tcp   1
 
 $ pouch ps -q
 e42c68
 a8c2ea
 
-$ pouch ps -a -q
-faf132
-e42c68
-a8c2ea
+$ pouch ps --last 1
+CONTAINER ID   IMAGE                              COMMAND   CREATED          STATUS                       PORTS   NAMES
+faf132         docker.io/library/busybox:latest   "ls"      16 seconds ago   Exited (0) 15 seconds ago           3
+
+$ pouch ps -f status=running -f label=team=infra
+CONTAINER ID   IMAGE                              COMMAND   CREATED          STATUS          PORTS   NAMES
+e42c68         docker.io/library/busybox:latest   "top"     16 minutes ago   Up 16 minutes           2
+
+$ pouch ps --format '{{.Names}}\t{{.Status}}'
+2	Up 16 minutes
+1	Up 17 minutes
 
-$ pouch ps --no-trunc
-Name   ID                                                                 Status        Created        Image                            Runtime
-foo2   692c77587b38f60bbd91d986ec3703848d72aea5030e320d4988eb02aa3f9d48   Up 1 minute   1 minute ago   docker.io/library/redis:alpine   runc
-foo    18592900006405ee64788bd108ef1de3d24dc3add73725891f4787d0f8e036f5   Up 1 minute   1 minute ago   docker.io/library/redis:alpine   runc
+$ pouch ps --format 'table {{.Names}}\t{{.Status}}\t{{.Runtime}}'
+NAMES   STATUS          RUNTIME
+2       Up 16 minutes   runc
+1       Up 17 minutes   runc
 
 $ pouch ps --no-trunc -q
-692c77587b38f60bbd91d986ec3703848d72aea5030e320d4988eb02aa3f9d48
-18592900006405ee64788bd108ef1de3d24dc3add73725891f4787d0f8e036f5
-
-$ pouch ps --no-trunc -a
-Name   ID                                                                 Status         Created         Image                            Runtime
-foo3   63fd6371f3d614bb1ecad2780972d5975ca1ab534ec280c5f7d8f4c7b2e9989d   created        2 minutes ago   docker.io/library/redis:alpine   runc
-foo2   692c77587b38f60bbd91d986ec3703848d72aea5030e320d4988eb02aa3f9d48   Up 2 minutes   2 minutes ago   docker.io/library/redis:alpine   runc
-foo    18592900006405ee64788bd108ef1de3d24dc3add73725891f4787d0f8e036f5   Up 2 minutes   2 minutes ago   docker.io/library/redis:alpine   runc
+e42c68d7a85cb7b1b2741fcfc5cf9f9bb1d42a95fc6c5de1907ea7a4cb1b9b59
+a8c2ea0f631f8a9e447bd5f7868490ab8d1a8708a6d7a59e9423f51125e4078a
 `
 }
 
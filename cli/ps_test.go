@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/cli/formatter"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerToFormatContext(t *testing.T) {
+	id := "e42c68d7a85cb7b1b2741fcfc5cf9f9bb1d42a95fc6c5de1907ea7a4cb1b9b59"
+	c := &types.Container{
+		ID:      id,
+		Names:   []string{"foo"},
+		Image:   "docker.io/library/busybox:latest",
+		Command: "sh -c while true; do sleep 1; done",
+		Created: time.Now().Add(-5 * time.Minute).UnixNano(),
+		Status:  "Up 5 minutes",
+		HostConfig: &types.HostConfig{
+			Runtime: "runc",
+			PortBindings: types.PortMap{
+				"80/tcp":  {{HostPort: "8080"}},
+				"53/udp":  {{HostIP: "127.0.0.1", HostPort: "53"}},
+				"443/tcp": {},
+			},
+		},
+	}
+
+	row, err := containerToFormatContext(c, false)
+	assert.NoError(t, err)
+	assert.Equal(t, id[:6], row.ID)
+	assert.Equal(t, `"sh -c while true; d…"`, row.Command)
+	assert.Equal(t, "5 minutes ago", row.RunningFor)
+	assert.Equal(t, "0.0.0.0:8080->80/tcp, 127.0.0.1:53->53/udp", row.Ports)
+	assert.Equal(t, "foo", row.Names)
+	assert.Equal(t, "runc", row.Runtime)
+
+	row, err = containerToFormatContext(c, true)
+	assert.NoError(t, err)
+	assert.Equal(t, id, row.ID)
+	assert.Equal(t, `"sh -c while true; do sleep 1; done"`, row.Command)
+
+	// the mapped ports of running container are preferred.
+	c.NetworkSettings = &types.ContainerNetworkSettings{
+		Ports: types.PortMap{
+			"80/tcp":  {{HostIP: "0.0.0.0", HostPort: "8080"}},
+			"443/tcp": {{HostIP: "0.0.0.0", HostPort: "32768"}},
+		},
+	}
+	row, err = containerToFormatContext(c, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:32768->443/tcp, 0.0.0.0:8080->80/tcp", row.Ports)
+	c.NetworkSettings = nil
+
+	// the container without host config
+	c.HostConfig = nil
+	row, err = containerToFormatContext(c, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "", row.Ports)
+}
+
+func TestFormatContainers(t *testing.T) {
+	rows := []interface{}{
+		psFormatContext{ID: "e42c68", Names: "foo", Status: "Up 5 minutes", Labels: map[string]string{"team": "infra"}},
+		psFormatContext{ID: "a8c2ea", Names: "bar", Status: "Exited (1) 1 minute ago"},
+	}
+
+	buf := new(bytes.Buffer)
+	f := &formatter.Formatter{Output: buf, Format: "{{.Names}}\t{{.Status}}\t{{index .Labels \"team\"}}\t{{.Label \"team\"}}"}
+	assert.NoError(t, f.Write(psHeader, rows))
+	assert.Equal(t, "foo\tUp 5 minutes\tinfra\tinfra\nbar\tExited (1) 1 minute ago\t\t\n", buf.String())
+
+	// unknown field fails and nothing is written
+	buf.Reset()
+	f.Format = "{{.Unknown}}"
+	assert.Error(t, f.Write(psHeader, rows))
+	assert.Equal(t, "", buf.String())
+
+	// the default format prints the table with headers.
+	buf.Reset()
+	f.Format = defaultPsFormat
+	assert.NoError(t, f.Write(psHeader, rows))
+	assert.Equal(t, "CONTAINER ID   IMAGE   COMMAND   CREATED   STATUS                    PORTS   NAMES\n"+
+		"e42c68                                     Up 5 minutes                      foo\n"+
+		"a8c2ea                                     Exited (1) 1 minute ago           bar\n", buf.String())
+}
+
+func TestStatusColor(t *testing.T) {
+	for _, tc := range []struct {
+		state  types.Status
+		status string
+		color  formatter.Color
+	}{
+		{state: types.StatusRunning, status: "Up 5 minutes", color: formatter.Green},
+		{state: types.StatusRestarting, status: "restarting", color: formatter.Yellow},
+		{state: types.StatusExited, status: "Exited (137) 1 minute ago", color: formatter.Red},
+		{state: types.StatusStopped, status: "Stopped (1) 1 minute ago", color: formatter.Red},
+		{state: types.StatusExited, status: "Exited (0) 1 minute ago", color: ""},
+		{state: types.StatusCreated, status: "created", color: ""},
+	} {
+		assert.Equal(t, tc.color, statusColor(&types.Container{State: string(tc.state), Status: tc.status}), tc.status)
+	}
+}
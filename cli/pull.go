@@ -7,8 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -17,10 +17,11 @@ import (
 	"github.com/alibaba/pouch/credential"
 	"github.com/alibaba/pouch/pkg/jsonstream"
 	"github.com/alibaba/pouch/pkg/reference"
+	"github.com/alibaba/pouch/pkg/term"
 
 	"github.com/containerd/containerd/pkg/progress"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"golang.org/x/crypto/ssh/terminal"
 )
 
 // pullDescription is used to describe pull command in detail and auto generate command doc.
@@ -31,6 +32,11 @@ var pullDescription = "Pull an image or a repository from a registry. " +
 // PullCommand use to implement 'pull' command, it download image.
 type PullCommand struct {
 	baseCommand
+
+	// flags for pull command
+	flagQuiet    bool
+	flagAllTags  bool
+	flagPlatform string
 }
 
 // Init initialize pull command.
@@ -52,26 +58,191 @@ func (p *PullCommand) Init(c *Cli) {
 
 // addFlags adds flags for specific command.
 func (p *PullCommand) addFlags() {
-	// TODO: add flags here
+	flagSet := p.cmd.Flags()
+	flagSet.BoolVarP(&p.flagQuiet, "quiet", "q", false, "Suppress progress output and only print the image reference")
+	flagSet.BoolVarP(&p.flagAllTags, "all-tags", "a", false, "Pull all the tagged images in the repository")
+	flagSet.StringVar(&p.flagPlatform, "platform", "", "Pull the image of the platform in the format os[/arch[/variant]], such as linux/arm64")
 }
 
 // runPull is the entry of pull command.
 func (p *PullCommand) runPull(args []string) error {
-	return pullMissingImage(context.Background(), p.cli.Client(), args[0], true)
+	// the pull is cancelled if user hits Ctrl-C, and the daemon will stop
+	// fetching once the connection is closed.
+	ctx := p.cli.Context()
+
+	err := p.pull(ctx, args[0])
+	if err != nil && ctx.Err() == context.Canceled {
+		return ExitError{Code: exitCodeCancelled, Status: "pull cancelled"}
+	}
+	return err
+}
+
+// pull pulls the image with the flags of pull command.
+func (p *PullCommand) pull(ctx context.Context, image string) error {
+	if p.flagAllTags {
+		return p.pullAllTags(ctx, image)
+	}
+
+	if !p.flagQuiet {
+		_, err := p.cli.pullImage(ctx, image, p.flagPlatform, showProgress)
+		return err
+	}
+
+	ref, err := p.cli.pullImage(ctx, image, p.flagPlatform, discardProgress)
+	if err != nil {
+		return err
+	}
+	fmt.Println(ref)
+	return nil
+}
+
+// pullAllTags pulls all the tagged images in the repository one by one.
+func (p *PullCommand) pullAllTags(ctx context.Context, repo string) error {
+	apiClient := p.cli.Client()
+
+	namedRef, err := reference.Parse(p.cli.expandImageName(repo))
+	if err != nil {
+		return err
+	}
+
+	if !reference.IsNamedOnly(namedRef) {
+		return fmt.Errorf("tag or digest can't be used with --all-tags/-a")
+	}
+
+	encodedAuth, err := p.cli.fetchImageRegistryAuth(ctx, namedRef.Name())
+	if err != nil {
+		return err
+	}
+
+	tags, err := apiClient.ImageListTags(ctx, namedRef.Name(), encodedAuth)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list tags of %s", namedRef.Name())
+	}
+
+	display := showProgress
+	if p.flagQuiet {
+		display = discardProgress
+	}
+
+	var errs []string
+	for _, tag := range tags {
+		image := namedRef.Name() + ":" + tag
+		if !p.flagQuiet {
+			fmt.Printf("Pulling %s\n", image)
+		}
+
+		// the failure of one tag should not abort the remaining tags,
+		// unless the pull is cancelled.
+		if _, err := p.cli.pullImage(ctx, image, p.flagPlatform, display); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			errs = append(errs, fmt.Sprintf("%s: %v", image, err))
+			continue
+		}
+
+		if p.flagQuiet {
+			fmt.Println(image)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New("failed to pull images: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// defaultRegistryOfCLI returns the default registry and namespace in CLI
+// config, and the registry is empty if it isn't set.
+func (c *Cli) defaultRegistryOfCLI() (registry, namespace string) {
+	configFile := c.ConfigFile()
+
+	namespace = configFile.DefaultRegistryNamespace
+	if namespace == "" {
+		namespace = "library"
+	}
+	return configFile.DefaultRegistry, namespace
 }
 
-func fetchRegistryAuth(serverAddress string) string {
+// normalizeImage expands the image with the default registry of CLI config,
+// which takes precedence over the default registry of daemon. If it isn't
+// set, only the default tag is added, and the daemon will expand the image.
+func (c *Cli) normalizeImage(image string) (reference.Named, error) {
+	registry, namespace := c.defaultRegistryOfCLI()
+	if registry == "" {
+		namedRef, err := reference.Parse(image)
+		if err != nil {
+			return nil, err
+		}
+		return reference.WithDefaultTagIfMissing(namedRef), nil
+	}
+	return reference.Normalize(image, registry, namespace)
+}
+
+// expandImageName adds the default registry of CLI config if missing.
+func (c *Cli) expandImageName(name string) string {
+	registry, namespace := c.defaultRegistryOfCLI()
+	if registry == "" {
+		return name
+	}
+	return reference.AddDefaultRegistryIfMissing(name, registry, namespace)
+}
+
+// fetchImageRegistryAuth returns the encoded credential of the registry which
+// the image belongs to. If the image name doesn't contain the registry, the
+// default registry of daemon is used, which is the same as pouch login.
+func (c *Cli) fetchImageRegistryAuth(ctx context.Context, name string) (string, error) {
+	registry := imageRegistry(name)
+	if registry == "" {
+		var err error
+		if registry, err = c.defaultRegistryOfDaemon(ctx); err != nil {
+			return "", err
+		}
+	}
+	return fetchRegistryAuth(registry)
+}
+
+// defaultRegistryOfDaemon returns the default registry of daemon, which is
+// only queried once, so that the pulls of all tags don't ask daemon again.
+func (c *Cli) defaultRegistryOfDaemon(ctx context.Context) (string, error) {
+	if c.daemonRegistry != "" {
+		return c.daemonRegistry, nil
+	}
+
+	info, err := c.Client().SystemInfo(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the default registry of daemon")
+	}
+	c.daemonRegistry = info.DefaultRegistry
+	return c.daemonRegistry, nil
+}
+
+// imageRegistry returns the registry part of the image name, and empty
+// string is returned if the name doesn't contain the registry.
+func imageRegistry(name string) string {
+	domain, _ := reference.SplitDomain(name)
+	return domain
+}
+
+// fetchRegistryAuth returns the encoded credential of the registry, which is
+// empty if there is no credential. The error of credential helper is returned
+// so that user can know why the credential isn't used.
+func fetchRegistryAuth(serverAddress string) (string, error) {
 	authConfig, err := credential.Get(serverAddress)
-	if err != nil || authConfig == (types.AuthConfig{}) {
-		return ""
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get credential of registry %s", serverAddress)
+	}
+
+	if authConfig == (types.AuthConfig{}) {
+		return "", nil
 	}
 
 	data, err := json.Marshal(authConfig)
 	if err != nil {
-		return ""
+		return "", err
 	}
 
-	return base64.URLEncoding.EncodeToString(data)
+	return base64.URLEncoding.EncodeToString(data), nil
 }
 
 // bufwriter defines interface which has Write and Flush behaviors.
@@ -82,15 +253,20 @@ type bufwriter interface {
 
 // showProgress shows pull progress status.
 func showProgress(body io.ReadCloser) error {
+	return renderProgress(body, os.Stdout, term.IsTerminal(os.Stdout.Fd()))
+}
+
+// renderProgress renders the progress stream into out. If the out is not
+// terminal, it only prints a status line when the status of layer changes.
+func renderProgress(body io.Reader, out io.Writer, isTerminal bool) error {
 	var (
-		output bufwriter = bufio.NewWriter(os.Stdout)
+		output bufwriter = bufio.NewWriter(out)
 
-		start      = time.Now()
-		isTerminal = terminal.IsTerminal(int(os.Stdout.Fd()))
+		start = time.Now()
 	)
 
 	if isTerminal {
-		output = progress.NewWriter(os.Stdout)
+		output = progress.NewWriter(out)
 	}
 
 	pos := make(map[string]int)
@@ -131,16 +307,36 @@ func showProgress(body io.ReadCloser) error {
 		}
 
 		if err := displayImageReferenceProgress(output, isTerminal, msgs, start); err != nil {
-			return fmt.Errorf("failed to display progress: %v", err)
+			return errors.Wrap(err, "failed to display progress")
 		}
 
 		if err := output.Flush(); err != nil {
-			return fmt.Errorf("failed to display progress: %v", err)
+			return errors.Wrap(err, "failed to display progress")
 		}
 	}
 	return nil
 }
 
+// discardProgress consumes the pull progress without displaying it, but
+// still returns the error message reported by daemon.
+func discardProgress(body io.ReadCloser) error {
+	dec := json.NewDecoder(body)
+	for {
+		var msg jsonstream.JSONMessage
+
+		if err := dec.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if msg.Error != nil {
+			return client.NewStreamError(msg.Error.Code, msg.Error.Message)
+		}
+	}
+}
+
 // displayImageReferenceProgress uses tabwriter to show current progress status.
 func displayImageReferenceProgress(output io.Writer, isTerminal bool, msgs []jsonstream.JSONMessage, start time.Time) error {
 	var (
@@ -150,7 +346,7 @@ func displayImageReferenceProgress(output io.Writer, isTerminal bool, msgs []jso
 
 	for _, msg := range msgs {
 		if msg.Error != nil {
-			return fmt.Errorf(msg.Error.Message)
+			return client.NewStreamError(msg.Error.Code, msg.Error.Message)
 		}
 
 		if msg.Detail != nil {
@@ -185,31 +381,55 @@ $ pouch pull docker.io/library/redis:alpine
 $ pouch images
 IMAGE ID            IMAGE NAME                           SIZE
 bbc3a0323522        docker.io/library/busybox:latest     703.14 KB
-0153c5db97e5        docker.io/library/redis:alpine       9.63 MB`
+0153c5db97e5        docker.io/library/redis:alpine       9.63 MB
+$ pouch pull -q docker.io/library/redis:alpine
+docker.io/library/redis:alpine
+$ pouch pull -a -q docker.io/library/hello-world
+docker.io/library/hello-world:latest
+docker.io/library/hello-world:linux
+$ pouch pull -q --platform linux/arm64 docker.io/library/busybox:latest
+docker.io/library/busybox:latest`
 }
 
-// pullMissingImage pull the image if it doesn't exist.
+// pullMissingImage pull the image if it doesn't exist, and returns the image
+// which should be used to create container.
 // When `force` is true, always pull the latest image instead of
 // using the local version
-func pullMissingImage(ctx context.Context, apiClient client.CommonAPIClient, image string, force bool) error {
+func (c *Cli) pullMissingImage(ctx context.Context, image string, force bool) (string, error) {
 	if !force {
-		_, inspectError := apiClient.ImageInspect(ctx, image)
-		if inspectError == nil {
-			return nil
+		// the image expanded by the default registry of CLI takes precedence,
+		// and the image may be ID, so check the original one at last.
+		candidates := []string{image}
+		if expanded := c.expandImageName(image); expanded != image {
+			candidates = []string{expanded, image}
 		}
-		if err, ok := inspectError.(client.RespError); !ok {
-			return inspectError
-		} else if err.Code() != http.StatusNotFound {
-			return inspectError
+
+		for _, ref := range candidates {
+			_, inspectError := c.Client().ImageInspect(ctx, ref)
+			if inspectError == nil {
+				return ref, nil
+			}
+			if !client.IsErrNotFound(inspectError) {
+				return "", inspectError
+			}
 		}
 	}
 
-	namedRef, err := reference.Parse(image)
+	return c.pullImage(ctx, image, "", showProgress)
+}
+
+// pullImage pulls the image of the platform and uses the display function to
+// consume the progress stream. It returns the full reference of the pulled
+// image. The platform of daemon is used if the platform is empty.
+func (c *Cli) pullImage(ctx context.Context, image, platform string, display func(io.ReadCloser) error) (string, error) {
+	apiClient := c.Client()
+
+	namedRef, err := c.normalizeImage(image)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	namedRef = reference.TrimTagForDigest(reference.WithDefaultTagIfMissing(namedRef))
+	namedRef = reference.TrimTagForDigest(namedRef)
 
 	var name, tag string
 	if reference.IsNameTagged(namedRef) {
@@ -218,11 +438,34 @@ func pullMissingImage(ctx context.Context, apiClient client.CommonAPIClient, ima
 		name = namedRef.String()
 	}
 
-	responseBody, err := apiClient.ImagePull(ctx, name, tag, fetchRegistryAuth(namedRef.Name()))
+	encodedAuth, err := c.fetchImageRegistryAuth(ctx, namedRef.Name())
+	if err != nil {
+		return "", err
+	}
+
+	responseBody, err := apiClient.ImagePull(ctx, name, tag, platform, encodedAuth)
 	if err != nil {
-		return fmt.Errorf("failed to pull image: %v", err)
+		return "", errors.Wrap(err, "failed to pull image")
 	}
 	defer responseBody.Close()
 
-	return showProgress(responseBody)
+	// close the body to stop reading if the pull is cancelled, in case the
+	// progress stream isn't aware of the context.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			responseBody.Close()
+		case <-stop:
+		}
+	}()
+
+	if err := display(responseBody); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", err
+	}
+	return namedRef.String(), nil
 }
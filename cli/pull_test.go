@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/client"
+	"github.com/alibaba/pouch/credential"
+	"github.com/alibaba/pouch/pkg/jsonstream"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeProgress(t *testing.T, msgs ...jsonstream.JSONMessage) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	for _, msg := range msgs {
+		if err := enc.Encode(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf
+}
+
+func cannedProgress(t *testing.T) *bytes.Buffer {
+	return encodeProgress(t,
+		jsonstream.JSONMessage{ID: "sha256:abcd", Status: jsonstream.PullStatusResolving},
+		jsonstream.JSONMessage{ID: "sha256:abcd", Status: jsonstream.PullStatusDownloading, Detail: &jsonstream.ProgressDetail{Current: 1024, Total: 4096}},
+		jsonstream.JSONMessage{ID: "sha256:abcd", Status: jsonstream.PullStatusDownloading, Detail: &jsonstream.ProgressDetail{Current: 2048, Total: 4096}},
+		jsonstream.JSONMessage{ID: "sha256:abcd", Status: jsonstream.PullStatusDone, Detail: &jsonstream.ProgressDetail{Current: 4096, Total: 4096}},
+	)
+}
+
+func TestRenderProgressNonTerminal(t *testing.T) {
+	out := &bytes.Buffer{}
+	assert.NoError(t, renderProgress(cannedProgress(t), out, false))
+
+	// the line should be printed only when the status changes
+	assert.Equal(t, []string{
+		"sha256:abcd: resolving",
+		"sha256:abcd: downloading",
+		"sha256:abcd: done",
+	}, strings.Split(strings.TrimSpace(out.String()), "\n"))
+
+	// no control characters in the plain-text output
+	assert.False(t, strings.Contains(out.String(), "\x1b"))
+}
+
+func TestRenderProgressTerminal(t *testing.T) {
+	out := &bytes.Buffer{}
+	assert.NoError(t, renderProgress(cannedProgress(t), out, true))
+
+	assert.True(t, strings.Contains(out.String(), "elapsed:"))
+	assert.True(t, strings.Contains(out.String(), "\x1b"))
+}
+
+func TestRenderProgressError(t *testing.T) {
+	for _, isTerminal := range []bool{true, false} {
+		body := encodeProgress(t,
+			jsonstream.JSONMessage{ID: "sha256:abcd", Status: jsonstream.PullStatusResolving},
+			jsonstream.JSONMessage{ID: "sha256:abcd", Error: &jsonstream.JSONError{Message: "not found"}},
+		)
+
+		err := renderProgress(body, &bytes.Buffer{}, isTerminal)
+		assert.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "not found"))
+	}
+}
+
+func TestDiscardProgress(t *testing.T) {
+	assert.NoError(t, discardProgress(ioutil.NopCloser(cannedProgress(t))))
+
+	body := encodeProgress(t, jsonstream.JSONMessage{Error: &jsonstream.JSONError{Code: 401, Message: "unauthorized"}})
+	err := discardProgress(ioutil.NopCloser(body))
+	assert.EqualError(t, err, "unauthorized")
+	assert.True(t, client.IsErrUnauthorized(err))
+}
+
+// blockedPullClient returns the progress stream which never ends.
+type blockedPullClient struct {
+	client.CommonAPIClient
+	pr *io.PipeReader
+
+	// infoCalls is the number of SystemInfo calls, and infoErr is returned
+	// by SystemInfo if it's set.
+	infoCalls int
+	infoErr   error
+}
+
+func (c *blockedPullClient) ImagePull(ctx context.Context, name, tag, platform, encodedAuth string) (io.ReadCloser, error) {
+	return c.pr, nil
+}
+
+func (c *blockedPullClient) SystemInfo(ctx context.Context) (*types.SystemInfo, error) {
+	c.infoCalls++
+	if c.infoErr != nil {
+		return nil, c.infoErr
+	}
+	return &types.SystemInfo{DefaultRegistry: "registry.hub.docker.com"}, nil
+}
+
+func TestPullImageCancelled(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		cli := &Cli{APIClient: &blockedPullClient{pr: pr}}
+		_, err := cli.pullImage(ctx, "busybox:latest", "", discardProgress)
+		errCh <- err
+	}()
+
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pull should stop reading progress after cancelled")
+	}
+}
+
+func TestImageRegistry(t *testing.T) {
+	for name, registry := range map[string]string{
+		"busybox":                         "",
+		"library/busybox":                 "",
+		"registry.hub.docker.com/busybox": "registry.hub.docker.com",
+		"localhost/busybox":               "localhost",
+		"127.0.0.1:5000/library/busybox":  "127.0.0.1:5000",
+	} {
+		assert.Equal(t, registry, imageRegistry(name), name)
+	}
+}
+
+func TestFetchImageRegistryAuth(t *testing.T) {
+	home, err := ioutil.TempDir("", "pouch-login")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", home)
+	defer os.Setenv("HOME", oldHome)
+
+	apiClient := &blockedPullClient{}
+	cli := &Cli{APIClient: apiClient}
+	encoded, err := cli.fetchImageRegistryAuth(context.Background(), "busybox")
+	assert.NoError(t, err)
+	assert.Equal(t, "", encoded)
+
+	assert.NoError(t, credential.Save(&types.AuthConfig{
+		Username:      "user",
+		Password:      "secret",
+		ServerAddress: "registry.hub.docker.com",
+	}))
+
+	// the short name belongs to the default registry of daemon
+	for _, name := range []string{"busybox", "registry.hub.docker.com/library/busybox"} {
+		encoded, err := cli.fetchImageRegistryAuth(context.Background(), name)
+		assert.NoError(t, err, name)
+		assert.NotEqual(t, "", encoded, name)
+
+		data, err := base64.URLEncoding.DecodeString(encoded)
+		assert.NoError(t, err)
+
+		var auth types.AuthConfig
+		assert.NoError(t, json.Unmarshal(data, &auth))
+		assert.Equal(t, "user", auth.Username, name)
+		assert.Equal(t, "secret", auth.Password, name)
+	}
+
+	encoded, err = cli.fetchImageRegistryAuth(context.Background(), "reg.abc.com/base/os")
+	assert.NoError(t, err)
+	assert.Equal(t, "", encoded)
+
+	// the default registry of daemon is only queried once.
+	assert.Equal(t, 1, apiClient.infoCalls)
+
+	// the failure of getting the default registry is returned.
+	cli = &Cli{APIClient: &blockedPullClient{infoErr: errors.New("connection refused")}}
+	_, err = cli.fetchImageRegistryAuth(context.Background(), "busybox")
+	assert.EqualError(t, err, "failed to get the default registry of daemon: connection refused")
+}
+
+// inspectClient records the inspected images, and only the image in images
+// exists.
+type inspectClient struct {
+	client.CommonAPIClient
+	images    map[string]bool
+	inspected []string
+}
+
+func (c *inspectClient) ImageInspect(ctx context.Context, image string) (types.ImageInfo, error) {
+	c.inspected = append(c.inspected, image)
+	if !c.images[image] {
+		return types.ImageInfo{}, errors.New("unexpected inspect")
+	}
+	return types.ImageInfo{}, nil
+}
+
+func TestDefaultRegistryOfCLI(t *testing.T) {
+	home, err := ioutil.TempDir("", "pouch-cli-config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", home)
+	defer os.Setenv("HOME", oldHome)
+
+	// the daemon expands the image if the CLI config isn't set
+	cli := &Cli{}
+	assert.NoError(t, cli.LoadConfigFile())
+	named, err := cli.normalizeImage("busybox")
+	assert.NoError(t, err)
+	assert.Equal(t, "busybox:latest", named.String())
+	assert.Equal(t, "busybox", cli.expandImageName("busybox"))
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(home, ".pouch"), 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(home, ".pouch", "config.json"),
+		[]byte(`{"defaultRegistry": "reg.abc.com"}`), 0600))
+
+	// the config file loaded by CLI is used
+	assert.Equal(t, "busybox", cli.expandImageName("busybox"))
+	assert.NoError(t, cli.LoadConfigFile())
+
+	named, err = cli.normalizeImage("busybox")
+	assert.NoError(t, err)
+	assert.Equal(t, "reg.abc.com/library/busybox:latest", named.String())
+
+	named, err = cli.normalizeImage("localhost:5000/foo:1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:5000/foo:1.0", named.String())
+
+	_, err = cli.normalizeImage("BusyBox")
+	assert.Error(t, err)
+
+	assert.Equal(t, "reg.abc.com/library/busybox", cli.expandImageName("busybox"))
+
+	// the image expanded by CLI is used if it exists
+	apiClient := &inspectClient{images: map[string]bool{"reg.abc.com/library/busybox:latest": true}}
+	cli.APIClient = apiClient
+	image, err := cli.pullMissingImage(context.Background(), "busybox:latest", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "reg.abc.com/library/busybox:latest", image)
+	assert.Equal(t, []string{"reg.abc.com/library/busybox:latest"}, apiClient.inspected)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// pushDescription is used to describe push command in detail and auto generate command doc.
+var pushDescription = "Push an image or a repository to a registry. " +
+	"The image should exist locally, and the registry is decided by the reference of image."
+
+// PushCommand use to implement 'push' command, it uploads image.
+type PushCommand struct {
+	baseCommand
+}
+
+// Init initialize push command.
+func (p *PushCommand) Init(c *Cli) {
+	p.cli = c
+
+	p.cmd = &cobra.Command{
+		Use:   "push IMAGE[:TAG]",
+		Short: "Push an image to registry",
+		Long:  pushDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.runPush(args)
+		},
+		Example: pushExample(),
+	}
+	p.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (p *PushCommand) addFlags() {
+	// TODO: add flags here
+}
+
+// runPush is the entry of push command.
+func (p *PushCommand) runPush(args []string) error {
+	ctx := p.cli.Context()
+	apiClient := p.cli.Client()
+
+	namedRef, err := p.cli.normalizeImage(args[0])
+	if err != nil {
+		return err
+	}
+
+	encodedAuth, err := p.cli.fetchImageRegistryAuth(ctx, namedRef.Name())
+	if err != nil {
+		return err
+	}
+
+	responseBody, err := apiClient.ImagePush(ctx, namedRef.String(), encodedAuth)
+	if err != nil {
+		return errors.Wrap(err, "failed to push image")
+	}
+	defer responseBody.Close()
+
+	return showProgress(responseBody)
+}
+
+// pushExample shows examples in push command, and is used in auto-generated cli docs.
+func pushExample() string {
+	return `$ pouch tag docker.io/library/busybox:latest registry.corp/library/busybox:latest
+$ pouch push registry.corp/library/busybox:latest`
+}
@@ -1,14 +1,13 @@
 package main
 
 import (
-	"context"
-
 	"github.com/spf13/cobra"
 )
 
 // renameDescription is used to describe rename command in detail and auto generate command doc.
 var renameDescription = "Rename a container object in Pouchd. " +
 	"You can change the name of one container identified by its name or ID. " +
+	"The new name must match [a-zA-Z0-9][a-zA-Z0-9_.-]+ and must not be used by other containers. " +
 	"The container you renamed is ready to be used by its new name."
 
 // RenameCommand uses to implement 'rename' command, it renames a container.
@@ -34,7 +33,7 @@ func (rc *RenameCommand) Init(c *Cli) {
 
 // runRename is the entry of rename command.
 func (rc *RenameCommand) runRename(args []string) error {
-	ctx := context.Background()
+	ctx := rc.cli.Context()
 	apiClient := rc.cli.Client()
 	container := args[0]
 	newName := args[1]
@@ -45,11 +44,10 @@ func (rc *RenameCommand) runRename(args []string) error {
 // renameExample shows examples in rename command, and is used in auto-generated cli docs.
 func renameExample() string {
 	return `$ pouch ps
-Name     ID       Status    Image                              Runtime
-foo      71b9c1   Running   docker.io/library/busybox:latest   runc
+CONTAINER ID   IMAGE                              COMMAND   CREATED         STATUS         PORTS   NAMES
+71b9c1         docker.io/library/busybox:latest   "top"     2 minutes ago   Up 2 minutes           foo
 $ pouch rename foo newName
 $ pouch ps
-Name     ID       Status    Image                              Runtime
-newName  71b9c1   Running   docker.io/library/busybox:latest   runc
-`
+CONTAINER ID   IMAGE                              COMMAND   CREATED         STATUS         PORTS   NAMES
+71b9c1         docker.io/library/busybox:latest   "top"     2 minutes ago   Up 2 minutes           newName`
 }
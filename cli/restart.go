@@ -1,11 +1,8 @@
 package main
 
 import (
-	"context"
-	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -44,20 +41,20 @@ func (rc *RestartCommand) addFlags() {
 
 // runRestart is the entry of restart command.
 func (rc *RestartCommand) runRestart(args []string) error {
-	ctx := context.Background()
+	ctx := rc.cli.Context()
 	apiClient := rc.cli.Client()
 
-	var errs []string
+	var errs errorList
 	for _, name := range args {
 		if err := apiClient.ContainerRestart(ctx, name, strconv.Itoa(rc.timeout)); err != nil {
-			errs = append(errs, err.Error())
+			errs = append(errs, err)
 			continue
 		}
 		fmt.Printf("%s\n", name)
 	}
 
 	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
+		return errs
 	}
 
 	return nil
@@ -1,10 +1,7 @@
 package main
 
 import (
-	"context"
-	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
 
@@ -52,7 +49,7 @@ func (r *RmCommand) addFlags() {
 
 // runRm is the entry of RmCommand command.
 func (r *RmCommand) runRm(args []string) error {
-	ctx := context.Background()
+	ctx := r.cli.Context()
 	apiClient := r.cli.Client()
 
 	options := &types.ContainerRemoveOptions{
@@ -60,17 +57,17 @@ func (r *RmCommand) runRm(args []string) error {
 		Volumes: r.removeVolumes,
 	}
 
-	var errs []string
+	var errs errorList
 	for _, name := range args {
 		if err := apiClient.ContainerRemove(ctx, name, options); err != nil {
-			errs = append(errs, err.Error())
+			errs = append(errs, err)
 			continue
 		}
 		fmt.Printf("%s\n", name)
 	}
 
 	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
+		return errs
 	}
 
 	return nil
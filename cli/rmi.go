@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -42,16 +41,25 @@ func (rmi *RmiCommand) addFlags() {
 
 // runRmi is the entry of rmi command
 func (rmi *RmiCommand) runRmi(args []string) error {
-	ctx := context.Background()
+	ctx := rmi.cli.Context()
 	apiClient := rmi.cli.Client()
 
 	var errs []string
 	for _, name := range args {
-		if err := apiClient.ImageRemove(ctx, name, rmi.force); err != nil {
+		items, err := apiClient.ImageRemove(ctx, name, rmi.force)
+		if err != nil {
 			errs = append(errs, err.Error())
 			continue
 		}
-		fmt.Printf("%s\n", name)
+
+		for _, item := range items {
+			if item.Untagged != "" {
+				fmt.Printf("Untagged: %s\n", item.Untagged)
+			}
+			if item.Deleted != "" {
+				fmt.Printf("Deleted: %s\n", item.Deleted)
+			}
+		}
 	}
 
 	if len(errs) > 0 {
@@ -63,12 +71,16 @@ func (rmi *RmiCommand) runRmi(args []string) error {
 
 // rmiExample shows examples in rmi command, and is used in auto-generated cli docs.
 func rmiExample() string {
-	return `$ pouch rmi registry.hub.docker.com/library/busybox:latest registry.hub.docker.com/library/busybox:1.28
-registry.hub.docker.com/library/busybox:latest
-registry.hub.docker.com/library/busybox:1.28
+	return `$ pouch tag registry.hub.docker.com/library/busybox:latest localhost:5000/busybox:latest
+$ pouch rmi localhost:5000/busybox:latest
+Untagged: localhost:5000/busybox:latest
+$ pouch rmi registry.hub.docker.com/library/busybox:1.28
+Untagged: registry.hub.docker.com/library/busybox:1.28
+Untagged: registry.hub.docker.com/library/busybox@sha256:141c253bc4c3fd0a201d32dc1f493bcf3fff003b6df416dea4f41046e0f37d47
+Deleted: sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a
 $ pouch create --name test registry.hub.docker.com/library/busybox:latest
 container ID: e5952417f9ee94621bbeaec532be1803ae2dedeb11a80f578a6d621e04a95afd, name: test
 $ pouch rmi registry.hub.docker.com/library/busybox:latest
-Error: failed to remove image: {"message":"Unable to remove the image \"registry.hub.docker.com/library/busybox:latest\" (must force) - container e5952417f9ee94621bbeaec532be1803ae2dedeb11a80f578a6d621e04a95afd is using this image"}
+Error: failed to remove images: Unable to remove the image "sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a" (must force) - container (e5952417f9ee94621bbeaec532be1803ae2dedeb11a80f578a6d621e04a95afd, test) is using this image
 `
 }
@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/client"
 	"github.com/alibaba/pouch/pkg/ioutils"
 
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -25,13 +31,14 @@ type RunCommand struct {
 	attach     bool
 	stdin      bool
 	detach     bool
+	sigProxy   bool
 }
 
 // Init initialize run command.
 func (rc *RunCommand) Init(c *Cli) {
 	rc.cli = c
 	rc.cmd = &cobra.Command{
-		Use:   "run [OPTIONS] IMAGE [ARG...]",
+		Use:   "run [OPTIONS] IMAGE [COMMAND] [ARG...]",
 		Short: "Create a new container and start it",
 		Long:  runDescription,
 		Args:  cobra.MinimumNArgs(1),
@@ -55,15 +62,17 @@ func (rc *RunCommand) addFlags() {
 	flagSet.BoolVarP(&rc.attach, "attach", "a", false, "Attach container's STDOUT and STDERR")
 	flagSet.BoolVarP(&rc.stdin, "interactive", "i", false, "Attach container's STDIN")
 	flagSet.BoolVarP(&rc.detach, "detach", "d", false, "Run container in background and print container ID")
-	flagSet.BoolVar(&rc.rm, "rm", false, "Automatically remove the container after it exits")
-
+	flagSet.BoolVar(&rc.rm, "rm", false, "Automatically remove the container and its anonymous volumes after it exits, which can't be used with --restart")
+	flagSet.BoolVar(&rc.sigProxy, "sig-proxy", true, "Proxy received signals to the process (non-TTY mode only)")
 }
 
 // runRun is the entry of run command.
 func (rc *RunCommand) runRun(args []string) error {
+	rc.detachKeys = rc.cli.DetachKeys(rc.detachKeys)
+
 	config, err := rc.config()
 	if err != nil {
-		return fmt.Errorf("failed to run container: %v", err)
+		return errors.Wrap(err, "failed to run container")
 	}
 
 	config.Image = args[0]
@@ -73,16 +82,18 @@ func (rc *RunCommand) runRun(args []string) error {
 	containerName := rc.name
 	config.ContainerConfig.OpenStdin = rc.stdin
 
-	ctx := context.Background()
+	ctx := rc.cli.Context()
 	apiClient := rc.cli.Client()
 
-	if err := pullMissingImage(ctx, apiClient, config.Image, false); err != nil {
+	image, err := rc.cli.pullMissingImage(ctx, config.Image, false)
+	if err != nil {
 		return err
 	}
+	config.Image = image
 
 	result, err := apiClient.ContainerCreate(ctx, config.ContainerConfig, config.HostConfig, config.NetworkingConfig, containerName)
 	if err != nil {
-		return fmt.Errorf("failed to run container: %v", err)
+		return errors.Wrap(err, "failed to run container")
 	}
 	if len(result.Warnings) != 0 {
 		fmt.Printf("WARNING: %s \n", strings.Join(result.Warnings, "\n"))
@@ -96,10 +107,6 @@ func (rc *RunCommand) runRun(args []string) error {
 	if (rc.attach || rc.stdin) && rc.detach {
 		return fmt.Errorf("Conflicting options: -a (or -i) and -d")
 	}
-	if rc.rm && rc.detach {
-		return fmt.Errorf("Conflicting options: --rm and -d")
-	}
-
 	// default attach container's stdout and stderr
 	if !rc.detach {
 		rc.attach = true
@@ -111,6 +118,15 @@ func (rc *RunCommand) runRun(args []string) error {
 		return err
 	}
 
+	// the signals are forwarded to the container in foreground mode, and the
+	// signals from terminal are sent to the container by TTY itself.
+	sigProxy := (rc.attach || rc.stdin) && rc.sigProxy && !rc.tty
+	if sigProxy {
+		// Ctrl-C is for the container rather than CLI from now on, so the
+		// following requests shouldn't be cancelled by it.
+		ctx = context.Background()
+	}
+
 	if rc.attach || rc.stdin {
 		if rc.tty {
 			in, out, err := setRawMode(rc.stdin, false)
@@ -124,14 +140,14 @@ func (rc *RunCommand) runRun(args []string) error {
 			}()
 		}
 
-		conn, br, err := apiClient.ContainerAttach(ctx, containerName, rc.stdin)
+		conn, br, err := apiClient.ContainerAttach(ctx, containerName, rc.stdin, rc.detachKeys)
 		if err != nil {
-			return fmt.Errorf("failed to attach container: %v", err)
+			return errors.Wrap(err, "failed to attach container")
 		}
 		defer conn.Close()
 
 		go func() {
-			io.Copy(os.Stdout, br)
+			copyAttachedOutput(ctx, conn, br, rc.tty)
 			wait <- struct{}{}
 		}()
 		go func() {
@@ -143,39 +159,92 @@ func (rc *RunCommand) runRun(args []string) error {
 		}()
 	}
 
+	if sigProxy {
+		stop := forwardAllSignals(ctx, apiClient, containerName)
+		defer stop()
+	}
+
+	// the container with --rm is removed by pouchd once it exits, so the
+	// wait should begin before it starts to get the exit status.
+	var (
+		statusCh  <-chan types.ContainerWaitOKBody
+		waitErrCh <-chan error
+	)
+	if rc.rm && (rc.attach || rc.stdin) {
+		statusCh, waitErrCh = apiClient.ContainerWaitNextExit(ctx, containerName)
+	}
+
 	// start container
 	if err := apiClient.ContainerStart(ctx, containerName, types.ContainerStartOptions{
 		DetachKeys: rc.detachKeys,
 	}); err != nil {
-		return fmt.Errorf("failed to run container %s: %v", containerName, err)
+		return startError{errors.Wrapf(err, "failed to run container %s", containerName)}
 	}
 
-	// wait the io to finish
-	if rc.attach || rc.stdin {
-		<-wait
-	} else {
+	if !(rc.attach || rc.stdin) {
 		fmt.Fprintf(os.Stdout, "%s\n", result.ID)
+		return nil
 	}
 
-	info, err := apiClient.ContainerGet(ctx, containerName)
-	if err != nil {
-		return err
-	}
+	// wait the io to finish
+	<-wait
 
+	var status types.ContainerWaitOKBody
 	if rc.rm {
-		if err := apiClient.ContainerRemove(ctx, containerName, &types.ContainerRemoveOptions{Force: true}); err != nil {
-			return fmt.Errorf("failed to remove container %s: %v", containerName, err)
+		select {
+		case status = <-statusCh:
+		case err := <-waitErrCh:
+			return err
+		}
+	} else {
+		status, err = apiClient.ContainerWait(ctx, containerName)
+		if err != nil {
+			return err
 		}
 	}
 
-	code := info.State.ExitCode
-	if code != 0 {
-		return ExitError{Code: int(code)}
+	if status.StatusCode != 0 {
+		return ExitError{Code: int(status.StatusCode)}
 	}
 
 	return nil
 }
 
+// forwardAllSignals forwards the signals received by CLI to the container,
+// and returns the function to stop forwarding.
+func forwardAllSignals(ctx context.Context, apiClient client.CommonAPIClient, name string) func() {
+	sigc := make(chan os.Signal, 128)
+	signal.Notify(sigc)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case s := <-sigc:
+				sig, ok := s.(syscall.Signal)
+				if !ok {
+					continue
+				}
+
+				if isLocalSignal(sig) {
+					continue
+				}
+
+				if err := apiClient.ContainerKill(ctx, name, strconv.Itoa(int(sig))); err != nil {
+					logrus.Debugf("failed to forward signal %d to container %s: %v", sig, name, err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigc)
+		close(done)
+	}
+}
+
 // runExample shows examples in run command, and is used in auto-generated cli docs.
 func runExample() string {
 	return `$ pouch run --name test registry.hub.docker.com/library/busybox:latest echo "hi"
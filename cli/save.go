@@ -1,15 +1,18 @@
 package main
 
 import (
-	"context"
+	"fmt"
 	"io"
 	"os"
 
+	"github.com/alibaba/pouch/pkg/term"
+
 	"github.com/spf13/cobra"
 )
 
 // saveDescription is used to describe save command in detail and auto generate command doc.
-var saveDescription = "save an image to a tar archive."
+var saveDescription = "Save one or more images to a tar archive, which can be loaded by pouch load or docker load. " +
+	"The archive is written to STDOUT by default, but it is refused if STDOUT is a terminal."
 
 // SaveCommand use to implement 'save' command.
 type SaveCommand struct {
@@ -21,10 +24,10 @@ type SaveCommand struct {
 func (save *SaveCommand) Init(c *Cli) {
 	save.cli = c
 	save.cmd = &cobra.Command{
-		Use:   "save [OPTIONS] IMAGE",
-		Short: "Save an image to a tar archive or STDOUT",
+		Use:   "save [OPTIONS] IMAGE [IMAGE...]",
+		Short: "Save one or more images to a tar archive or STDOUT",
 		Long:  saveDescription,
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MinimumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			return save.runSave(args)
 		},
@@ -41,20 +44,24 @@ func (save *SaveCommand) addFlags() {
 
 // runSave is the entry of save command.
 func (save *SaveCommand) runSave(args []string) error {
-	ctx := context.Background()
+	ctx := save.cli.Context()
 	apiClient := save.cli.Client()
 
-	r, err := apiClient.ImageSave(ctx, args[0])
+	out := os.Stdout
+	if save.output == "" && term.IsTerminal(out.Fd()) {
+		return fmt.Errorf("refusing to write the tar archive to a terminal, use -o flag or redirect STDOUT")
+	}
+
+	r, err := apiClient.ImageSave(ctx, args)
 	if err != nil {
 		return err
 	}
 	defer r.Close()
 
-	out := os.Stdout
 	if save.output != "" {
 		out, err = os.Create(save.output)
 		if err != nil {
-			return nil
+			return err
 		}
 		defer out.Close()
 	}
@@ -67,7 +74,8 @@ func (save *SaveCommand) runSave(args []string) error {
 
 // saveExample shows examples in save command, and is used in auto-generated cli docs.
 func saveExample() string {
-	return `$ pouch save -o busybox.tar busybox:latest
+	return `$ pouch save -o images.tar busybox:latest busybox@sha256:141c253bc4c3fd0a201d32dc1f493bcf3fff003b6df416dea4f41046e0f37d47
+$ pouch save busybox:latest > busybox.tar
 $ pouch load -i busybox.tar foo
 $ pouch images
 IMAGE ID       IMAGE NAME                                           SIZE
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/spf13/cobra"
+)
+
+// searchDescription is used to describe search command in detail and auto generate command doc.
+var searchDescription = "Search the images from the registry. The default registry of CLI config or pouchd will be " +
+	"used if no registry is specified, and the results are sorted by stars in descending order."
+
+// SearchCommand use to implement 'search' command.
+type SearchCommand struct {
+	baseCommand
+
+	// flags for search command
+	flagRegistry string
+	flagLimit    int
+	flagNoTrunc  bool
+}
+
+// Init initialize "search" command.
+func (s *SearchCommand) Init(c *Cli) {
+	s.cli = c
+	s.cmd = &cobra.Command{
+		Use:   "search [OPTIONS] TERM",
+		Short: "Search the images from the registry",
+		Long:  searchDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return s.runSearch(args)
+		},
+		Example: searchExample(),
+	}
+	s.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (s *SearchCommand) addFlags() {
+	flagSet := s.cmd.Flags()
+	flagSet.StringVarP(&s.flagRegistry, "registry", "r", "", "Registry to search, the default registry of CLI config or pouchd is used if it is empty")
+	flagSet.IntVar(&s.flagLimit, "limit", 25, "Max number of search results, which should be in the range of [1, 100]")
+	flagSet.BoolVar(&s.flagNoTrunc, "no-trunc", false, "Do not truncate output")
+}
+
+// runSearch is the entry of search command.
+func (s *SearchCommand) runSearch(args []string) error {
+	if s.flagLimit < 1 || s.flagLimit > 100 {
+		return fmt.Errorf("limit %d is outside the range of [1, 100]", s.flagLimit)
+	}
+
+	ctx := s.cli.Context()
+	apiClient := s.cli.Client()
+
+	// the default registry of CLI config takes precedence over the one of
+	// daemon, which is used by daemon if the registry is empty.
+	registry := s.flagRegistry
+	if registry == "" {
+		registry, _ = s.cli.defaultRegistryOfCLI()
+	}
+
+	// the credential is stored by the registry address, and the search of
+	// the default registry of daemon is anonymous, since search doesn't
+	// always require auth.
+	var encodedAuth string
+	if registry != "" {
+		var err error
+		if encodedAuth, err = fetchRegistryAuth(registry); err != nil {
+			return err
+		}
+	}
+
+	results, err := apiClient.ImageSearch(ctx, args[0], registry, s.flagLimit, encodedAuth)
+	if err != nil {
+		return err
+	}
+
+	display := s.cli.NewTableDisplay()
+	display.AddRow([]string{"NAME", "DESCRIPTION", "STARS", "OFFICIAL", "AUTOMATED"})
+	for _, row := range searchResultsToRows(results, s.flagNoTrunc) {
+		display.AddRow(row)
+	}
+	display.Flush()
+	return nil
+}
+
+// searchResultsToRows sorts the results by stars in descending order, and
+// converts them into the rows of search table.
+func searchResultsToRows(results []types.SearchResultItem, noTrunc bool) [][]string {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].StarCount > results[j].StarCount
+	})
+
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		description := strings.Replace(result.Description, "\n", " ", -1)
+		if !noTrunc {
+			description = ellipsis(description, 45)
+		}
+
+		official, automated := "", ""
+		if result.IsOfficial {
+			official = "[OK]"
+		}
+		if result.IsAutomated {
+			automated = "[OK]"
+		}
+
+		rows = append(rows, []string{result.Name, description, strconv.FormatInt(result.StarCount, 10), official, automated})
+	}
+	return rows
+}
+
+// searchExample shows examples in search command, and is used in auto-generated cli docs.
+func searchExample() string {
+	return `$ pouch search --limit 3 nginx
+NAME                      DESCRIPTION                                     STARS   OFFICIAL   AUTOMATED
+nginx                     Official build of Nginx.                        11170   [OK]
+jwilder/nginx-proxy       Automated Nginx reverse proxy for docker c...   1570               [OK]
+richarvey/nginx-php-fpm   Container running Nginx + PHP-FPM capable ...   700                [OK]`
+}
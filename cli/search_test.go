@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchResultsToRows(t *testing.T) {
+	results := []types.SearchResultItem{
+		{Name: "foo/nginx", StarCount: 10, IsAutomated: true, Description: "Nginx\nwith a long description which should be truncated"},
+		{Name: "nginx", StarCount: 100, IsOfficial: true, Description: "Official build of Nginx."},
+		{Name: "bar/nginx", StarCount: 10},
+	}
+
+	assert.Equal(t, [][]string{
+		{"nginx", "Official build of Nginx.", "100", "[OK]", ""},
+		{"foo/nginx", "Nginx with a long description which should...", "10", "", "[OK]"},
+		{"bar/nginx", "", "10", "", ""},
+	}, searchResultsToRows(results, false))
+
+	rows := searchResultsToRows(results, true)
+	assert.Equal(t, "Nginx with a long description which should be truncated", rows[1][1])
+}
@@ -0,0 +1,36 @@
+// +build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyResize calls resize when receiving SIGWINCH, and the returned
+// function stops it.
+func notifyResize(resize func()) func() {
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGWINCH)
+	go func() {
+		for range sigc {
+			resize()
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigc)
+		close(sigc)
+	}
+}
+
+// isLocalSignal returns true if the signal is for CLI itself, which isn't
+// forwarded to the container.
+func isLocalSignal(sig syscall.Signal) bool {
+	switch sig {
+	case syscall.SIGCHLD, syscall.SIGPIPE, syscall.SIGURG, syscall.SIGWINCH:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+// +build windows
+
+package main
+
+import (
+	"syscall"
+	"time"
+)
+
+// resizePollInterval is the interval of checking the size of console.
+const resizePollInterval = 250 * time.Millisecond
+
+// notifyResize polls the size of console by calling resize periodically,
+// since there is no SIGWINCH on windows. The returned function stops it.
+func notifyResize(resize func()) func() {
+	ticker := time.NewTicker(resizePollInterval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				resize()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
+
+// isLocalSignal returns false, since only the interrupt and termination
+// are notified on windows, which are all forwarded to the container.
+func isLocalSignal(sig syscall.Signal) bool {
+	return false
+}
@@ -1,15 +1,13 @@
 package main
 
 import (
-	"context"
-	"errors"
 	"fmt"
 	"io"
 	"os"
-	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
 )
@@ -58,8 +56,10 @@ func (s *StartCommand) addFlags() {
 
 // runStart is the entry of start command.
 func (s *StartCommand) runStart(args []string) error {
-	ctx := context.Background()
+	ctx := s.cli.Context()
 	apiClient := s.cli.Client()
+	s.detachKeys = s.cli.DetachKeys(s.detachKeys)
+
 	// attach to io.
 	if s.attach || s.stdin {
 		var wait chan struct{}
@@ -90,15 +90,15 @@ func (s *StartCommand) runStart(args []string) error {
 			}()
 		}
 
-		conn, br, err := apiClient.ContainerAttach(ctx, container, s.stdin)
+		conn, br, err := apiClient.ContainerAttach(ctx, container, s.stdin, s.detachKeys)
 		if err != nil {
-			return fmt.Errorf("failed to attach container: %v", err)
+			return errors.Wrap(err, "failed to attach container")
 		}
 		defer conn.Close()
 
 		wait = make(chan struct{})
 		go func() {
-			io.Copy(os.Stdout, br)
+			copyAttachedOutput(ctx, conn, br, c.Config.Tty)
 			close(wait)
 		}()
 		go func() {
@@ -111,7 +111,7 @@ func (s *StartCommand) runStart(args []string) error {
 			CheckpointID:  s.checkpoint,
 			CheckpointDir: s.cpDir,
 		}); err != nil {
-			return fmt.Errorf("failed to start container %s: %v", container, err)
+			return startError{errors.Wrapf(err, "failed to start container %s", container)}
 		}
 
 		// wait the io to finish.
@@ -130,21 +130,21 @@ func (s *StartCommand) runStart(args []string) error {
 		}
 	} else {
 		// We're not going to attach to any container, so we just start as many containers as we want.
-		var errs []string
+		var errs errorList
 		for _, name := range args {
 			if err := apiClient.ContainerStart(ctx, name, types.ContainerStartOptions{
 				DetachKeys:    s.detachKeys,
 				CheckpointID:  s.checkpoint,
 				CheckpointDir: s.cpDir,
 			}); err != nil {
-				errs = append(errs, err.Error())
+				errs = append(errs, err)
 				continue
 			}
 			fmt.Printf("%s\n", name)
 		}
 
 		if len(errs) > 0 {
-			return errors.New("failed to start containers: " + strings.Join(errs, ""))
+			return errors.Wrap(errs, "failed to start containers")
 		}
 	}
 	return nil
@@ -158,12 +158,12 @@ func setRawMode(stdin, stdout bool) (*terminal.State, *terminal.State, error) {
 	)
 
 	if stdin {
-		if in, err = terminal.MakeRaw(0); err != nil {
+		if in, err = terminal.MakeRaw(int(os.Stdin.Fd())); err != nil {
 			return nil, nil, err
 		}
 	}
 	if stdout {
-		if out, err = terminal.MakeRaw(1); err != nil {
+		if out, err = terminal.MakeRaw(int(os.Stdout.Fd())); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -173,12 +173,12 @@ func setRawMode(stdin, stdout bool) (*terminal.State, *terminal.State, error) {
 
 func restoreMode(in, out *terminal.State) error {
 	if in != nil {
-		if err := terminal.Restore(0, in); err != nil {
+		if err := terminal.Restore(int(os.Stdin.Fd()), in); err != nil {
 			return err
 		}
 	}
 	if out != nil {
-		if err := terminal.Restore(1, out); err != nil {
+		if err := terminal.Restore(int(os.Stdout.Fd()), out); err != nil {
 			return err
 		}
 	}
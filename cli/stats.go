@@ -1,14 +1,19 @@
 package main
 
 import (
-	"context"
-	"errors"
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/cli/formatter"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -24,24 +29,26 @@ const (
 )
 
 // statsDescription is used to describe stats command in detail and auto generate command doc.
-var statsDescription = "stats command is to display a live stream of container(s) resource usage statistics"
+var statsDescription = "stats command is to display a live stream of container(s) resource usage statistics, " +
+	"the statistics of all running containers are displayed if no container is specified. " +
+	"The containers which exit during streaming drop out of the display."
 
 // StatsCommand use to implement 'stats' command
 type StatsCommand struct {
 	baseCommand
 
 	noStream bool
-	//TODO: add more flags support
+	format   string
 }
 
 // Init initialize stats command.
 func (stats *StatsCommand) Init(c *Cli) {
 	stats.cli = c
 	stats.cmd = &cobra.Command{
-		Use:   "stats [OPTIONS] CONTAINER [CONTAINER...]",
+		Use:   "stats [OPTIONS] [CONTAINER...]",
 		Short: "Display a live stream of container(s) resource usage statistics",
 		Long:  statsDescription,
-		Args:  cobra.MinimumNArgs(1),
+		Args:  cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return stats.runStats(args)
 		},
@@ -54,13 +61,35 @@ func (stats *StatsCommand) Init(c *Cli) {
 func (stats *StatsCommand) addFlags() {
 	flagSet := stats.cmd.Flags()
 	flagSet.BoolVar(&stats.noStream, "no-stream", false, "Disable streaming stats and only pull the first result")
+	flagSet.StringVar(&stats.format, "format", "", "Pretty-print stats using a Go template, fields Container, ID, Name, CPUPerc, MemUsage, MemPerc, NetIO, BlockIO and PIDs are supported")
 }
 
 // runStats is the entry of stats command.
 func (stats *StatsCommand) runStats(args []string) error {
-	ctx := context.Background()
+	ctx := stats.cli.Context()
 	apiClient := stats.cli.Client()
+
+	var (
+		tmpl *template.Template
+		err  error
+	)
+	if stats.format != "" {
+		if tmpl, err = formatter.Parse(stats.format); err != nil {
+			return err
+		}
+	}
+
 	containers := args
+	// display all running containers if no container is specified.
+	if len(containers) == 0 {
+		list, err := apiClient.ContainerList(ctx, types.ContainerListOptions{})
+		if err != nil {
+			return errors.Wrap(err, "failed to get container list")
+		}
+		for _, c := range list {
+			containers = append(containers, c.ID)
+		}
+	}
 
 	cStats := []*StatsEntryWithLock{}
 	waitFirst := &sync.WaitGroup{}
@@ -74,11 +103,14 @@ func (stats *StatsCommand) runStats(args []string) error {
 	// before print to screen, make sure each container get at least one valid stat data
 	waitFirst.Wait()
 
-	// do a quick scan in case container not found and so on
+	// do a quick scan in case container not found and so on, the errors
+	// after the first stat data mean the container exits during streaming.
 	var errs []string
 	for _, c := range cStats {
-		err := c.GetError()
-		if err != nil {
+		if c.GetStatsEntry().Received() {
+			continue
+		}
+		if err := c.GetError(); err != nil {
 			errs = append(errs, err.Error())
 		}
 	}
@@ -93,39 +125,58 @@ func (stats *StatsCommand) runStats(args []string) error {
 		}
 	}
 
-	display := stats.cli.NewTableDisplay()
 	displayHead := []string{containerHeader, containerNameHeader, cpuPercHeader, memPercHeader,
 		memUseHeader, netIOHeader, blockIOHeader, pidsHeader}
 
-	for range time.Tick(500 * time.Millisecond) {
+	for {
 		cleanScreen()
 		ccstats := []StatsEntry{}
 
-		// get snapshot of each container stats
+		// get snapshot of each container stats, the container whose stream
+		// has ended drops out instead of freezing the display.
 		for _, c := range cStats {
-			err := c.GetError()
-			if err != nil {
-				return err
+			if c.GetError() != nil {
+				continue
 			}
 			ccstats = append(ccstats, c.GetStatsEntry())
 		}
 
-		display.AddRow(displayHead)
-		// display the stats of each container
-		for _, c := range ccstats {
-			displayLine := []string{c.ID(), c.Name(), c.CPUPerc(), c.MemPerc(),
-				c.MemUsage(), c.NetIO(), c.BlockIO(), c.PIDs()}
-			display.AddRow(displayLine)
+		if tmpl != nil {
+			if err := formatStats(os.Stdout, tmpl, ccstats); err != nil {
+				return err
+			}
+		} else {
+			display := stats.cli.NewTableDisplay()
+			display.AddRow(displayHead)
+			// display the stats of each container
+			for _, c := range ccstats {
+				displayLine := []string{c.ID(), c.Name(), c.CPUPerc(), c.MemPerc(),
+					c.MemUsage(), c.NetIO(), c.BlockIO(), c.PIDs()}
+				display.AddRow(displayLine)
+			}
+			display.Flush()
 		}
 
-		display.Flush()
-
 		if stats.noStream {
-			break
+			return nil
 		}
+		time.Sleep(time.Second)
 	}
+}
 
-	return nil
+// formatStats renders the stats with template. Nothing will be written if
+// template fails to execute on any container.
+func formatStats(out io.Writer, tmpl *template.Template, entries []StatsEntry) error {
+	buf := new(bytes.Buffer)
+	for _, entry := range entries {
+		if err := tmpl.Execute(buf, entry); err != nil {
+			return fmt.Errorf("failed to execute format template: %v", err)
+		}
+		buf.WriteByte('\n')
+	}
+
+	_, err := io.Copy(out, buf)
+	return err
 }
 
 // statsExample shows examples in stats command, and is used in auto-generated cli docs.
@@ -134,5 +185,9 @@ func statsExample() string {
 CONTAINER ID        NAME                       CPU %               MEM USAGE / LIMIT     MEM %               NET I/O             BLOCK I/O           PIDS
 b25ae88e5b70        naughty_goldwasser         0.11%               2.559MiB / 15.23GiB   0.02%               7.32kB / 0B         0B / 0B             4
 a00670c2bdff        xenodochial_varahamihira   0.11%               2.887MiB / 15.23GiB   0.02%               13.3kB / 0B         14.7MB / 0B         4
+
+$ pouch stats --no-stream --format "{{.Name}}: {{.CPUPerc}} {{.MemUsage}}"
+naughty_goldwasser: 0.11% 2.559MiB / 15.23GiB
+xenodochial_varahamihira: 0.11% 2.887MiB / 15.23GiB
 `
 }
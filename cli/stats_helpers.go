@@ -40,6 +40,16 @@ type StatsEntryWithLock struct {
 	StatsEntry
 }
 
+// Container return the container given by user
+func (s StatsEntry) Container() string {
+	return s.container
+}
+
+// Received return whether any stat data of container is received
+func (s StatsEntry) Received() bool {
+	return s.id != ""
+}
+
 // Name return the name of container
 func (s StatsEntry) Name() string {
 	return s.name
@@ -122,7 +132,7 @@ func (s *StatsEntryWithLock) GetError() error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	if s.err != nil {
-		return fmt.Errorf("failed to stats container %s, err = %v", s.id, s.err.Error())
+		return fmt.Errorf("failed to stats container %s, err = %v", s.container, s.err.Error())
 	}
 	return nil
 }
@@ -130,11 +140,9 @@ func (s *StatsEntryWithLock) GetError() error {
 func collect(ctx context.Context, s *StatsEntryWithLock, cli client.CommonAPIClient, streamStats bool, waitFirst *sync.WaitGroup) {
 	logrus.Debugf("collecting stats for %s", s.container)
 	var (
-		getFirst       bool
-		previousCPU    uint64
-		previousSystem uint64
-		errCh          = make(chan error, 1)
-		dataCh         = make(chan struct{}, 1)
+		getFirst bool
+		errCh    = make(chan error, 1)
+		dataCh   = make(chan struct{}, 1)
 	)
 
 	defer func() {
@@ -169,12 +177,11 @@ func collect(ctx context.Context, s *StatsEntryWithLock, cli client.CommonAPICli
 				break
 			}
 
-			// first time PrecpuStats will be nil
+			// first time PrecpuStats will be nil, the cpu usage percentage
+			// can only be computed from two consecutive stats.
 			if v.PrecpuStats != nil && v.PrecpuStats.CPUUsage != nil {
-				previousCPU = v.PrecpuStats.CPUUsage.TotalUsage
-				previousSystem = v.PrecpuStats.SyetemCPUUsage
+				cpuPercent = calculateCPUPercentUnix(v.PrecpuStats.CPUUsage.TotalUsage, v.PrecpuStats.SyetemCPUUsage, v.CPUStats)
 			}
-			cpuPercent = calculateCPUPercentUnix(previousCPU, previousSystem, v.CPUStats)
 			blkRead, blkWrite = calculateBlockIO(v.BlkioStats)
 			mem = calculateMemUsageUnixNoCache(v.MemoryStats)
 			memLimit = float64(v.MemoryStats.Limit)
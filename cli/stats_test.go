@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils/templates"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatStats(t *testing.T) {
+	entries := []StatsEntry{
+		{container: "foo", name: "foo", id: "b25ae88e5b70c7f3", cpuPercentage: 12.345, pidsCurrent: 4},
+		{container: "a0067", name: "bar", id: "a00670c2bdff", memory: 1024, memoryLimit: 4096, memoryPercentage: 25},
+	}
+
+	tmpl, err := templates.Parse("{{.Container}}\t{{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemPerc}}\t{{.PIDs}}")
+	assert.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, formatStats(out, tmpl, entries))
+	assert.Equal(t, "foo\tb25ae88e5b70\tfoo\t12.35%\t0.00%\t4\na0067\ta00670c2bdff\tbar\t0.00%\t25.00%\t0\n", out.String())
+
+	// nothing is written if the template fails
+	tmpl, err = templates.Parse("{{.Unknown}}")
+	assert.NoError(t, err)
+
+	out.Reset()
+	assert.Error(t, formatStats(out, tmpl, entries))
+	assert.Equal(t, "", out.String())
+}
+
+func TestCalculateCPUPercentUnix(t *testing.T) {
+	cpuStats := &types.CPUStats{
+		CPUUsage:       &types.CPUUsage{TotalUsage: 3000},
+		SyetemCPUUsage: 20000,
+		OnlineCpus:     2,
+	}
+
+	// the container used 2000 of 10000 system cpu time on 2 cpus
+	assert.Equal(t, 40.0, calculateCPUPercentUnix(1000, 10000, cpuStats))
+
+	// no change between the stats
+	assert.Equal(t, 0.0, calculateCPUPercentUnix(3000, 20000, cpuStats))
+
+	// the online cpus falls back to the number of percpu usage
+	cpuStats.OnlineCpus = 0
+	cpuStats.CPUUsage.PercpuUsage = []uint64{1500, 1500, 0, 0}
+	assert.Equal(t, 80.0, calculateCPUPercentUnix(1000, 10000, cpuStats))
+
+	assert.Equal(t, 0.0, calculateCPUPercentUnix(0, 0, nil))
+}
@@ -1,11 +1,8 @@
 package main
 
 import (
-	"context"
-	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -13,7 +10,8 @@ import (
 // stopDescription is used to describe stop command in detail and auto generate command doc.
 var stopDescription = "Stop one or more running containers in Pouchd. Waiting the given number of seconds before forcefully killing the container. " +
 	"This is useful when you wish to stop a container. And Pouchd will stop this running container and release the resource. " +
-	"The container that you stopped will be terminated. "
+	"The container that you stopped will be terminated. " +
+	"A paused container will be unpaused first, so that it can receive the stop signal."
 
 // StopCommand use to implement 'stop' command, it stops a container.
 type StopCommand struct {
@@ -45,20 +43,20 @@ func (s *StopCommand) addFlags() {
 
 // runStop is the entry of stop command.
 func (s *StopCommand) runStop(args []string) error {
-	ctx := context.Background()
+	ctx := s.cli.Context()
 	apiClient := s.cli.Client()
 
-	var errs []string
+	var errs errorList
 	for _, name := range args {
 		if err := apiClient.ContainerStop(ctx, name, strconv.Itoa(s.timeout)); err != nil {
-			errs = append(errs, err.Error())
+			errs = append(errs, err)
 			continue
 		}
 		fmt.Printf("%s\n", name)
 	}
 
 	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
+		return errs
 	}
 
 	return nil
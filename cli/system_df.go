@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// systemDfDescription is used to describe system df command in detail and auto generate command doc.
+var systemDfDescription = "Show the disk space used by images, containers and local volumes, " +
+	"and how much of it can be reclaimed by removing the unused ones. " +
+	"The verbose mode lists the size of each image, container and volume."
+
+// SystemDfCommand use to implement 'system df' command.
+type SystemDfCommand struct {
+	baseCommand
+
+	// flags for system df command
+	flagVerbose bool
+}
+
+// Init initialize "system df" command.
+func (d *SystemDfCommand) Init(c *Cli) {
+	d.cli = c
+	d.cmd = &cobra.Command{
+		Use:   "df [OPTIONS]",
+		Short: "Show pouch disk usage",
+		Long:  systemDfDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return d.runDf()
+		},
+		Example: systemDfExample(),
+	}
+	d.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (d *SystemDfCommand) addFlags() {
+	flagSet := d.cmd.Flags()
+	flagSet.BoolVarP(&d.flagVerbose, "verbose", "v", false, "Show detailed information on space usage")
+}
+
+// runDf is the entry of system df command.
+func (d *SystemDfCommand) runDf() error {
+	ctx := d.cli.Context()
+	apiClient := d.cli.Client()
+
+	usage, err := apiClient.SystemDataUsage(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get disk usage")
+	}
+
+	display := &Display{tabwriter.NewWriter(os.Stdout, 0, 0, d.cli.padding, ' ', 0)}
+	if d.flagVerbose {
+		displayDataUsageVerbose(display, os.Stdout, usage)
+	} else {
+		displayDataUsage(display, usage)
+	}
+	return display.Flush()
+}
+
+// displayDataUsage prints the summary of disk usage, one row per type.
+func displayDataUsage(display *Display, usage *types.DiskUsage) {
+	display.AddRow([]string{"TYPE", "TOTAL", "ACTIVE", "SIZE", "RECLAIMABLE"})
+
+	// like docker, the layers of the image used by container are not
+	// reclaimable except the ones shared with other images.
+	var activeImages int
+	var usedImageSize int64
+	for _, img := range usage.Images {
+		if img.Containers > 0 {
+			activeImages++
+			usedImageSize += img.Size - img.SharedSize
+		}
+	}
+	display.AddRow([]string{"Images", strconv.Itoa(len(usage.Images)), strconv.Itoa(activeImages),
+		utils.FormatSize(usage.LayersSize), reclaimable(usage.LayersSize-usedImageSize, usage.LayersSize)})
+
+	var activeContainers int
+	var containersSize, containersReclaimable int64
+	for _, c := range usage.Containers {
+		if c.SizeRw > 0 {
+			containersSize += c.SizeRw
+		}
+		if c.State == string(types.StatusRunning) || c.State == string(types.StatusPaused) {
+			activeContainers++
+		} else if c.SizeRw > 0 {
+			containersReclaimable += c.SizeRw
+		}
+	}
+	display.AddRow([]string{"Containers", strconv.Itoa(len(usage.Containers)), strconv.Itoa(activeContainers),
+		utils.FormatSize(containersSize), reclaimable(containersReclaimable, containersSize)})
+
+	var activeVolumes int
+	var volumesSize, volumesReclaimable int64
+	for _, v := range usage.Volumes {
+		if v.Size > 0 {
+			volumesSize += v.Size
+		}
+		if v.RefCount > 0 {
+			activeVolumes++
+		} else if v.Size > 0 {
+			volumesReclaimable += v.Size
+		}
+	}
+	display.AddRow([]string{"Local Volumes", strconv.Itoa(len(usage.Volumes)), strconv.Itoa(activeVolumes),
+		utils.FormatSize(volumesSize), reclaimable(volumesReclaimable, volumesSize)})
+}
+
+// displayDataUsageVerbose prints the size of each image, container and volume.
+func displayDataUsageVerbose(display *Display, out io.Writer, usage *types.DiskUsage) {
+	fmt.Fprintf(out, "Images space usage:\n\n")
+	display.AddRow([]string{"IMAGE ID", "IMAGE NAME", "CREATED", "SIZE", "SHARED SIZE", "UNIQUE SIZE", "CONTAINERS"})
+	for _, img := range usage.Images {
+		name := "<none>"
+		if len(img.RepoTags) > 0 {
+			name = img.RepoTags[0]
+		}
+		display.AddRow([]string{utils.TruncateID(img.ID), name, createdSince(img.CreatedAt),
+			utils.FormatSize(img.Size), utils.FormatSize(img.SharedSize), utils.FormatSize(img.Size - img.SharedSize),
+			strconv.FormatInt(img.Containers, 10)})
+	}
+	display.Flush()
+
+	fmt.Fprintf(out, "\nContainers space usage:\n\n")
+	display.AddRow([]string{"CONTAINER ID", "IMAGE", "STATUS", "CREATED", "SIZE", "NAME"})
+	for _, c := range usage.Containers {
+		display.AddRow([]string{utils.TruncateID(c.ID), c.Image, c.State, createdSince(c.CreatedAt),
+			knownSize(c.SizeRw), c.Name})
+	}
+	display.Flush()
+
+	fmt.Fprintf(out, "\nLocal Volumes space usage:\n\n")
+	display.AddRow([]string{"VOLUME NAME", "DRIVER", "LINKS", "SIZE"})
+	for _, v := range usage.Volumes {
+		display.AddRow([]string{v.Name, v.Driver, strconv.FormatInt(v.RefCount, 10), knownSize(v.Size)})
+	}
+}
+
+// reclaimable formats the reclaimable size with its percentage of total.
+func reclaimable(size, total int64) string {
+	if size <= 0 || total <= 0 {
+		return utils.FormatSize(0) + " (0%)"
+	}
+	return fmt.Sprintf("%s (%d%%)", utils.FormatSize(size), size*100/total)
+}
+
+// knownSize formats the size, N/A is returned if the size is unknown.
+func knownSize(size int64) string {
+	if size < 0 {
+		return "N/A"
+	}
+	return utils.FormatSize(size)
+}
+
+// createdSince formats the created time as the interval from it to now.
+func createdSince(created string) string {
+	t, err := time.Parse(utils.TimeLayout, created)
+	if err != nil {
+		return ""
+	}
+	interval, err := utils.FormatTimeInterval(t.UnixNano())
+	if err != nil {
+		return ""
+	}
+	return interval + " ago"
+}
+
+// systemDfExample shows examples in system df command, and is used in auto-generated cli docs.
+func systemDfExample() string {
+	return `$ pouch system df
+TYPE            TOTAL   ACTIVE   SIZE        RECLAIMABLE
+Images          3       1        175.23 MB   174.08 MB (99%)
+Containers      2       1        12.00 KB    4.00 KB (33%)
+Local Volumes   2       1        1.02 MB     1.00 MB (98%)
+
+$ pouch system df -v
+Images space usage:
+
+IMAGE ID       IMAGE NAME                                       CREATED       SIZE        SHARED SIZE   UNIQUE SIZE   CONTAINERS
+8c811b4aec35   registry.hub.docker.com/library/busybox:latest   2 weeks ago   1.15 MB     0.00 B        1.15 MB       2
+4b3ee0cf0dd5   registry.hub.docker.com/library/nginx:latest     3 weeks ago   122.93 MB   55.29 MB      67.64 MB      0
+84b1ec5c5c4d   registry.hub.docker.com/library/redis:latest     3 weeks ago   106.44 MB   55.29 MB      51.15 MB      0
+
+Containers space usage:
+
+CONTAINER ID   IMAGE                                            STATUS    CREATED          SIZE      NAME
+e42c68b3c7ab   registry.hub.docker.com/library/busybox:latest   running   16 minutes ago   8.00 KB   top
+faf132d1f0c0   registry.hub.docker.com/library/busybox:latest   exited    16 seconds ago   4.00 KB   ls
+
+Local Volumes space usage:
+
+VOLUME NAME   DRIVER   LINKS   SIZE
+data          local    1       16.00 KB
+cache         local    0       1.00 MB`
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayDataUsage(t *testing.T) {
+	usage := &types.DiskUsage{
+		LayersSize: 4096,
+		Images: []*types.ImageDiskUsage{
+			{ID: "sha256:1", Size: 3072, SharedSize: 2048, Containers: 1},
+			{ID: "sha256:2", Size: 3072, SharedSize: 2048},
+		},
+		Containers: []*types.ContainerDiskUsage{
+			{ID: "1", State: string(types.StatusRunning), SizeRw: 1024},
+			{ID: "2", State: string(types.StatusExited), SizeRw: 3072},
+			{ID: "3", State: string(types.StatusExited), SizeRw: -1},
+		},
+		Volumes: []*types.VolumeDiskUsage{
+			{Name: "v1", Driver: "local", RefCount: 1, Size: 1024},
+			{Name: "v2", Driver: "local", Size: 1024},
+			{Name: "v3", Driver: "ceph", Size: -1},
+		},
+	}
+
+	out := new(bytes.Buffer)
+	display := &Display{tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)}
+	displayDataUsage(display, usage)
+	assert.NoError(t, display.Flush())
+
+	assert.Equal(t, "TYPE          TOTAL ACTIVE SIZE    RECLAIMABLE\n"+
+		"Images        2     1      4.00 KB 3.00 KB (75%)\n"+
+		"Containers    3     1      4.00 KB 3.00 KB (75%)\n"+
+		"Local Volumes 3     1      2.00 KB 1.00 KB (50%)\n", out.String())
+}
+
+func TestDisplayDataUsageVerbose(t *testing.T) {
+	usage := &types.DiskUsage{
+		Images: []*types.ImageDiskUsage{
+			{ID: "sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a", Size: 3072, SharedSize: 2048},
+		},
+		Containers: []*types.ContainerDiskUsage{
+			{ID: "e42c68b3c7ab", Name: "top", Image: "busybox", State: "running", SizeRw: -1},
+		},
+		Volumes: []*types.VolumeDiskUsage{
+			{Name: "data", Driver: "local", RefCount: 2, Size: 1024},
+		},
+	}
+
+	out := new(bytes.Buffer)
+	display := &Display{tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)}
+	displayDataUsageVerbose(display, out, usage)
+	assert.NoError(t, display.Flush())
+
+	for _, expected := range []string{
+		"8c811b4aec35 <none>             3.00 KB 2.00 KB     1.00 KB     0\n",
+		"e42c68b3c7ab busybox running         N/A  top\n",
+		"data        local  2     1.00 KB\n",
+	} {
+		assert.Contains(t, out.String(), expected)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// systemGCDescription is used to describe system gc command in detail and auto generate command doc.
+var systemGCDescription = "Trigger the garbage collection of containerd to reclaim the content and snapshots " +
+	"which aren't referenced by any image, container or ongoing operation, such as the layers left by " +
+	"the removed images. It doesn't remove any image, container or volume, use prune commands to remove them."
+
+// SystemGCCommand use to implement 'system gc' command.
+type SystemGCCommand struct {
+	baseCommand
+}
+
+// Init initialize "system gc" command.
+func (g *SystemGCCommand) Init(c *Cli) {
+	g.cli = c
+	g.cmd = &cobra.Command{
+		Use:   "gc",
+		Short: "Reclaim unreferenced content of containerd",
+		Long:  systemGCDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return g.runGC()
+		},
+		Example: systemGCExample(),
+	}
+}
+
+// runGC is the entry of system gc command.
+func (g *SystemGCCommand) runGC() error {
+	ctx := g.cli.Context()
+	apiClient := g.cli.Client()
+
+	resp, err := apiClient.SystemGC(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to run garbage collection")
+	}
+
+	displayGCResult(os.Stdout, resp)
+	return nil
+}
+
+// displayGCResult prints the number of removed blobs and the reclaimed space.
+func displayGCResult(out io.Writer, resp *types.SystemGCResp) {
+	fmt.Fprintf(out, "Deleted blobs: %d\n", resp.BlobsDeleted)
+	fmt.Fprintf(out, "Total reclaimed space: %s\n", utils.FormatSize(resp.SpaceReclaimed))
+}
+
+// systemGCExample shows examples in system gc command, and is used in auto-generated cli docs.
+func systemGCExample() string {
+	return `$ pouch system gc
+Deleted blobs: 4
+Total reclaimed space: 1.15 MB`
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayGCResult(t *testing.T) {
+	out := new(bytes.Buffer)
+	displayGCResult(out, &types.SystemGCResp{
+		BlobsDeleted:   4,
+		SpaceReclaimed: 2048,
+	})
+	assert.Equal(t, "Deleted blobs: 4\nTotal reclaimed space: 2.00 KB\n", out.String())
+
+	// nothing is reclaimed
+	out.Reset()
+	displayGCResult(out, &types.SystemGCResp{})
+	assert.Equal(t, "Deleted blobs: 0\nTotal reclaimed space: 0.00 B\n", out.String())
+}
@@ -1,8 +1,6 @@
 package main
 
 import (
-	"context"
-
 	"github.com/spf13/cobra"
 )
 
@@ -31,7 +29,7 @@ func (tag *TagCommand) Init(c *Cli) {
 
 // runTag is the entry of tag command.
 func (tag *TagCommand) runTag(args []string) error {
-	ctx := context.Background()
+	ctx := tag.cli.Context()
 	apiClient := tag.cli.Client()
 
 	source, target := args[0], args[1]
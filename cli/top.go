@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -12,7 +11,8 @@ import (
 
 // topDescription
 var topDescription = "top command is to display the running processes of a container. " +
-	"You can add options just like using Linux ps command."
+	"You can add options just like using Linux ps command, such as \"-eo pid,comm,etime\", " +
+	"the output must contain the PID column. Default options are \"-ef\"."
 
 // TopCommand use to implement 'top' command, it displays all processes in a container.
 type TopCommand struct {
@@ -43,7 +43,7 @@ func (top *TopCommand) addFlags() {
 
 // runTop is the entry of top command.
 func (top *TopCommand) runTop(args []string) error {
-	ctx := context.Background()
+	ctx := top.cli.Context()
 	apiClient := top.cli.Client()
 
 	container := args[0]
@@ -52,7 +52,7 @@ func (top *TopCommand) runTop(args []string) error {
 
 	procList, err := apiClient.ContainerTop(ctx, container, arguments)
 	if err != nil {
-		return fmt.Errorf("failed to execute top command in container %s: %v", container, err)
+		return err
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 1, 8, 4, ' ', 0)
@@ -70,5 +70,8 @@ func topExamples() string {
 	return `$ pouch top 44f675
 UID     PID      PPID     C    STIME    TTY    TIME        CMD
 root    28725    28714    0    3月14     ?      00:00:00    sh
+$ pouch top 44f675 -eo pid,comm,etime
+PID      COMMAND    ELAPSED
+28725    sh         01:02:03
 `
 }
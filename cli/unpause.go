@@ -1,10 +1,7 @@
 package main
 
 import (
-	"context"
-	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -36,20 +33,20 @@ func (p *UnpauseCommand) Init(c *Cli) {
 
 // runUnpause is the entry of unpause command.
 func (p *UnpauseCommand) runUnpause(args []string) error {
-	ctx := context.Background()
+	ctx := p.cli.Context()
 	apiClient := p.cli.Client()
 
-	var errs []string
+	var errs errorList
 	for _, name := range args {
 		if err := apiClient.ContainerUnpause(ctx, name); err != nil {
-			errs = append(errs, err.Error())
+			errs = append(errs, err)
 			continue
 		}
 		fmt.Printf("%s\n", name)
 	}
 
 	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
+		return errs
 	}
 
 	return nil
@@ -58,14 +55,14 @@ func (p *UnpauseCommand) runUnpause(args []string) error {
 // unpauseExample shows examples in unpause command, and is used in auto-generated cli docs.
 func unpauseExample() string {
 	return `$ pouch ps
-Name   ID       Status                  Created          Image                                            Runtime
-foo2   c95673   Up 13 seconds(paused)   14 seconds ago   registry.hub.docker.com/library/busybox:latest   runc
-foo1   204cc6   Up 17 seconds(paused)   17 seconds ago   registry.hub.docker.com/library/busybox:latest   runc
+CONTAINER ID   IMAGE                              COMMAND   CREATED          STATUS                   PORTS   NAMES
+c95673         docker.io/library/busybox:latest   "top"     14 seconds ago   Up 13 seconds (Paused)           foo2
+204cc6         docker.io/library/busybox:latest   "top"     17 seconds ago   Up 17 seconds (Paused)           foo1
 $ pouch unpause foo1 foo2
 foo1
 foo2
 $ pouch ps
-Name   ID       Status          Created          Image                                            Runtime
-foo2   c95673   Up 48 seconds   49 seconds ago   registry.hub.docker.com/library/busybox:latest   runc
-foo1   204cc6   Up 52 seconds   52 seconds ago   registry.hub.docker.com/library/busybox:latest   runc`
+CONTAINER ID   IMAGE                              COMMAND   CREATED          STATUS          PORTS   NAMES
+c95673         docker.io/library/busybox:latest   "top"     49 seconds ago   Up 48 seconds           foo2
+204cc6         docker.io/library/busybox:latest   "top"     52 seconds ago   Up 52 seconds           foo1`
 }
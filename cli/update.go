@@ -1,7 +1,9 @@
 package main
 
 import (
-	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/types"
@@ -10,8 +12,9 @@ import (
 )
 
 // updateDescription is used to describe update command in detail and auto generate command doc.
-var updateDescription = "Update a container's configurations, including memory, cpu and diskquota etc.  " +
-	"You can update a container when it is running."
+var updateDescription = "Update configurations of one or more containers, including memory, cpu and diskquota etc.  " +
+	"You can update a container when it is running, the new resources are applied to it at once and " +
+	"kept after it restarts."
 
 // UpdateCommand use to implement 'update' command, it modifies the configurations of a container.
 type UpdateCommand struct {
@@ -23,10 +26,10 @@ type UpdateCommand struct {
 func (uc *UpdateCommand) Init(c *Cli) {
 	uc.cli = c
 	uc.cmd = &cobra.Command{
-		Use:   "update [OPTIONS] CONTAINER",
-		Short: "Update the configurations of a container",
+		Use:   "update [OPTIONS] CONTAINER [CONTAINER...]",
+		Short: "Update the configurations of one or more containers",
 		Long:  updateDescription,
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return uc.updateRun(args)
 		},
@@ -47,21 +50,21 @@ func (uc *UpdateCommand) addFlags() {
 	flagSet.Int64Var(&uc.cpuperiod, "cpu-period", 0, "Limit CPU CFS (Completely Fair Scheduler) period, range is in [1000(1ms),1000000(1s)]")
 	flagSet.Int64Var(&uc.cpushare, "cpu-shares", 0, "CPU shares (relative weight)")
 	flagSet.Int64Var(&uc.cpuquota, "cpu-quota", 0, "Limit CPU CFS (Completely Fair Scheduler) quota")
+	flagSet.StringVar(&uc.cpus, "cpus", "", "Number of CPUs, such as 1.5, which can't be updated if --cpu-quota or --cpu-period has been set")
 	flagSet.StringVar(&uc.cpusetcpus, "cpuset-cpus", "", "CPUs in cpuset which to allow execution (0-3, 0, 1)")
 	flagSet.StringVar(&uc.cpusetmems, "cpuset-mems", "", "MEMs in cpuset which to allow execution (0-3, 0, 1)")
 	flagSet.StringVarP(&uc.memory, "memory", "m", "", "Container memory limit")
 	flagSet.StringVar(&uc.memorySwap, "memory-swap", "", "Container swap limit")
+	flagSet.StringVar(&uc.memoryReservation, "memory-reservation", "", "Container memory soft limit")
 	flagSet.StringSliceVarP(&uc.env, "env", "e", nil, "Update environment variables for container('--env A=' means updating env A to be empty and '--env A' means removing env A)")
-	flagSet.StringSliceVarP(&uc.labels, "label", "l", nil, "Update labels for container")
-	flagSet.StringVar(&uc.restartPolicy, "restart", "", "Restart policy to apply when container exits")
+	flagSet.StringVar(&uc.restartPolicy, "restart", "", "Restart policy to apply when container exits, no, always, on-failure[:max-retries] or unless-stopped")
 	flagSet.StringSliceVar(&uc.diskQuota, "disk-quota", nil, "Update disk quota for container(/=10g)")
 	flagSet.StringSliceVar(&uc.specAnnotation, "annotation", nil, "Update annotation for runtime spec")
 }
 
 // updateRun is the entry of update command.
 func (uc *UpdateCommand) updateRun(args []string) error {
-	container := args[0]
-	ctx := context.Background()
+	ctx := uc.cli.Context()
 
 	memory, err := opts.ParseMemory(uc.memory)
 	if err != nil {
@@ -73,6 +76,16 @@ func (uc *UpdateCommand) updateRun(args []string) error {
 		return err
 	}
 
+	memoryReservation, err := opts.ParseMemory(uc.memoryReservation)
+	if err != nil {
+		return err
+	}
+
+	nanoCPUs, err := opts.ParseCPUs(uc.cpus)
+	if err != nil {
+		return err
+	}
+
 	resource := types.Resources{
 		BlkioWeight:          uc.blkioWeight,
 		BlkioDeviceReadBps:   uc.blkioDeviceReadBps.Value(),
@@ -82,15 +95,23 @@ func (uc *UpdateCommand) updateRun(args []string) error {
 		CPUPeriod:            uc.cpuperiod,
 		CPUShares:            uc.cpushare,
 		CPUQuota:             uc.cpuquota,
+		NanoCpus:             nanoCPUs,
 		CpusetCpus:           uc.cpusetcpus,
 		CpusetMems:           uc.cpusetmems,
 		Memory:               memory,
 		MemorySwap:           memorySwap,
+		MemoryReservation:    memoryReservation,
 	}
 
-	restartPolicy, err := opts.ParseRestartPolicy(uc.restartPolicy)
-	if err != nil {
-		return err
+	// the restart policy is kept unless it is specified.
+	var restartPolicy *types.RestartPolicy
+	if uc.restartPolicy != "" {
+		if restartPolicy, err = opts.ParseRestartPolicy(uc.restartPolicy); err != nil {
+			return err
+		}
+		if err := opts.ValidateRestartPolicy(restartPolicy); err != nil {
+			return err
+		}
 	}
 
 	diskQuota, err := opts.ParseDiskQuota(uc.diskQuota)
@@ -105,7 +126,6 @@ func (uc *UpdateCommand) updateRun(args []string) error {
 
 	updateConfig := &types.UpdateConfig{
 		Env:            uc.env,
-		Label:          uc.labels,
 		RestartPolicy:  restartPolicy,
 		Resources:      resource,
 		DiskQuota:      diskQuota,
@@ -113,7 +133,21 @@ func (uc *UpdateCommand) updateRun(args []string) error {
 	}
 
 	apiClient := uc.cli.Client()
-	return apiClient.ContainerUpdate(ctx, container, updateConfig)
+
+	var errs []string
+	for _, name := range args {
+		if err := apiClient.ContainerUpdate(ctx, name, updateConfig); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		fmt.Printf("%s\n", name)
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
 }
 
 // updateExample shows examples in update command, and is used in auto-generated cli docs.
@@ -123,6 +157,7 @@ func updateExample() string {
 $ cat /sys/fs/cgroup/memory/8649804cb63ff9713a2734d99728b9d6d5d1e4d2fbafb2b4dbdf79c6bbaef812/memory.limit_in_bytes
 20971520
 $ pouch update -m 30m test-update
+test-update
 $ cat /sys/fs/cgroup/memory/8649804cb63ff9713a2734d99728b9d6d5d1e4d2fbafb2b4dbdf79c6bbaef812/memory.limit_in_bytes
 31457280
 	`
@@ -2,7 +2,6 @@ package main
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
@@ -17,7 +16,7 @@ import (
 
 // daemonUpdateDescription is used to describe updatedaemon command in detail and auto generate command doc.
 var daemonUpdateDescription = "Update daemon's configurations, if daemon is stoped, it will just update config file. " +
-	"Online update just including: image proxy, label, offline update including: manager white list, debug level, " +
+	"Online update just including: image proxy, label, debug level, offline update including: manager white list, debug level, " +
 	"execute root directory, bridge name, bridge IP, fixed CIDR, defaut gateway, iptables, ipforwark, userland proxy. " +
 	"If pouchd is alive, you can only use --offline=true to update config file"
 
@@ -69,7 +68,7 @@ func (udc *DaemonUpdateCommand) addFlags() {
 	flagSet.StringVar(&udc.configFile, "config-file", "/etc/pouch/config.json", "specified config file for updating daemon")
 	flagSet.BoolVar(&udc.offline, "offline", false, "just update daemon config file")
 
-	flagSet.BoolVar(&udc.debug, "debug", false, "update daemon debug mode")
+	flagSet.BoolVar(&udc.debug, "debug", false, "update daemon debug mode, which switches the log level of alive daemon at once")
 	flagSet.StringVar(&udc.imageProxy, "image-proxy", "", "update daemon image proxy")
 	flagSet.StringVar(&udc.managerWhiteList, "manager-white-list", "", "update daemon manager white list")
 	flagSet.StringSliceVar(&udc.label, "label", nil, "update daemon labels")
@@ -88,17 +87,20 @@ func (udc *DaemonUpdateCommand) addFlags() {
 
 // daemonUpdateRun is the entry of updatedaemon command.
 func (udc *DaemonUpdateCommand) daemonUpdateRun(args []string) error {
-	ctx := context.Background()
+	ctx := udc.cli.Context()
 
 	apiClient := udc.cli.Client()
 
 	msg, err := apiClient.SystemPing(ctx)
 	if !udc.offline && err == nil && msg == "OK" {
-		// TODO: daemon support more configures for update online, such as debug level.
+		// TODO: daemon support more configures for update online.
 		daemonConfig := &types.DaemonUpdateConfig{
 			ImageProxy: udc.imageProxy,
 			Labels:     udc.label,
 		}
+		if udc.cmd.Flags().Changed("debug") {
+			daemonConfig.Debug = &udc.debug
+		}
 
 		err = apiClient.DaemonUpdate(ctx, daemonConfig)
 		if err != nil {
@@ -131,6 +133,10 @@ func (udc *DaemonUpdateCommand) updateDaemonConfigFile() error {
 
 	flagSet := udc.cmd.Flags()
 
+	if flagSet.Changed("debug") {
+		daemonConfig.Debug = udc.debug
+	}
+
 	if flagSet.Changed("image-proxy") {
 		daemonConfig.ImageProxy = udc.imageProxy
 	}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -70,12 +69,14 @@ func (ug *UpgradeCommand) runUpgrade(args []string) error {
 		Entrypoint: strings.Fields(ug.entrypoint),
 	}
 
-	ctx := context.Background()
+	ctx := ug.cli.Context()
 	apiClient := ug.cli.Client()
 
-	if err := pullMissingImage(ctx, apiClient, image, false); err != nil {
+	image, err := ug.cli.pullMissingImage(ctx, image, false)
+	if err != nil {
 		return err
 	}
+	upgradeConfig.Image = image
 
 	if err := apiClient.ContainerUpgrade(ctx, name, upgradeConfig); err != nil {
 		return err
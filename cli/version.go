@@ -1,19 +1,72 @@
 package main
 
 import (
-	"context"
 	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/cli/formatter"
+	"github.com/alibaba/pouch/version"
 
 	"github.com/spf13/cobra"
 )
 
 // versionDescription is used to describe version command in detail and auto generate command doc.
-var versionDescription = "Display the version information of pouch client and daemon， " +
-	"including GoVersion, KernelVersion, Os, Version, APIVersion, Arch, BuildTime and GitCommit."
+var versionDescription = "Display the version information of pouch client and daemon. " +
+	"The client part includes Version, APIVersion, GoVersion, GitCommit, BuildTime and OS/Arch, " +
+	"the API version negotiated with daemon is also shown if it's older than the client's, " +
+	"and the server part also includes KernelVersion, ContainerdVersion, RuncVersion and the Endpoint of daemon contacted. " +
+	"Only the client part is printed with a warning if daemon is unreachable, and the command exits with non-zero code."
+
+// defaultVersionTemplate is the template to display version if --format isn't set.
+const defaultVersionTemplate = `Client:
+ Version:            {{.Client.Version}}
+ APIVersion:         {{.Client.APIVersion}}
+{{- if and .NegotiatedAPIVersion (ne .NegotiatedAPIVersion .Client.APIVersion)}} (negotiated {{.NegotiatedAPIVersion}}){{end}}
+ GoVersion:          {{.Client.GoVersion}}
+ GitCommit:          {{.Client.GitCommit}}
+ BuildTime:          {{.Client.BuildTime}}
+ OS/Arch:            {{.Client.Os}}/{{.Client.Arch}}
+{{- if .Server}}
+
+Server:
+ Version:            {{.Server.Version}}
+ APIVersion:         {{.Server.APIVersion}}
+ GoVersion:          {{.Server.GoVersion}}
+ GitCommit:          {{.Server.GitCommit}}
+ BuildTime:          {{.Server.BuildTime}}
+ OS/Arch:            {{.Server.Os}}/{{.Server.Arch}}
+ KernelVersion:      {{.Server.KernelVersion}}
+ ContainerdVersion:  {{.Server.ContainerdVersion}}
+ RuncVersion:        {{.Server.RuncVersion}}
+ Endpoint:           {{.Server.Endpoint}}
+{{- end}}
+`
+
+// versionResult is the version of client and daemon, which can be used in
+// the template of version --format. Server is nil if daemon is unreachable.
+type versionResult struct {
+	Client version.Info
+	Server *serverVersion
+
+	// NegotiatedAPIVersion is the API version used by the requests to
+	// daemon, which is empty if daemon is unreachable.
+	NegotiatedAPIVersion string
+}
+
+// serverVersion is the version of daemon with the address contacted.
+type serverVersion struct {
+	types.SystemVersion
+	Endpoint string
+}
 
 // VersionCommand use to implement 'version' command.
 type VersionCommand struct {
 	baseCommand
+
+	flagFormat string
 }
 
 // Init initialize version command.
@@ -34,33 +87,79 @@ func (v *VersionCommand) Init(c *Cli) {
 
 // addFlags adds flags for specific command.
 func (v *VersionCommand) addFlags() {
-	// TODO: add flags here
+	flagSet := v.cmd.Flags()
+	flagSet.StringVar(&v.flagFormat, "format", "", "Format the output using the given Go template, such as {{.Client.Version}} {{.Server.Version}}")
 }
 
 // runVersion is the entry of version command.
 func (v *VersionCommand) runVersion() error {
-	ctx := context.Background()
-	apiClient := v.cli.Client()
-
-	result, err := apiClient.SystemVersion(ctx)
+	format := v.flagFormat
+	if format == "" {
+		format = defaultVersionTemplate
+	} else {
+		format += "\n"
+	}
+	tmpl, err := formatter.Parse(format)
 	if err != nil {
-		return fmt.Errorf("failed to get system version: %v", err)
+		return err
 	}
 
-	v.cli.Print(result)
+	result := versionResult{Client: version.Get()}
+
+	sv, serverErr := v.cli.Client().SystemVersion(v.cli.Context())
+	if serverErr == nil {
+		result.Server = &serverVersion{SystemVersion: *sv, Endpoint: v.cli.Host()}
+		result.NegotiatedAPIVersion = v.cli.Client().ClientVersion()
+	}
+
+	// only the client part is printed if daemon is unreachable.
+	if serverErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to get version of daemon at %s: %v\n", v.cli.Host(), serverErr)
+	}
+
+	if err := printVersion(os.Stdout, tmpl, result); err != nil {
+		return err
+	}
+	if serverErr != nil {
+		return ExitError{Code: exitCode(serverErr)}
+	}
+	return nil
+}
+
+// printVersion renders the version with template.
+func printVersion(out io.Writer, tmpl *template.Template, result versionResult) error {
+	if err := tmpl.Execute(out, result); err != nil {
+		return fmt.Errorf("failed to execute format template: %v", err)
+	}
 	return nil
 }
 
 // versionExample shows examples in version command, and is used in auto-generated cli docs.
 func versionExample() string {
 	return `$ pouch version
-GoVersion:       go1.10.4
-KernelVersion:   3.10.0-693.11.6.el7.x86_64
-Os:              linux
-Version:         1.0.0
-APIVersion:      1.24
-Arch:            amd64
-BuildTime:       2018-11-07T07:48:56.348129663Z
-GitCommit:
-`
+Client:
+ Version:            1.3.0
+ APIVersion:         1.25
+ GoVersion:          go1.10.4
+ GitCommit:          v1.3.0
+ BuildTime:          2018-11-07T07:48:56+00:00
+ OS/Arch:            linux/amd64
+
+Server:
+ Version:            1.3.0
+ APIVersion:         1.25
+ GoVersion:          go1.10.4
+ GitCommit:          v1.3.0
+ BuildTime:          2018-11-07T07:48:56+00:00
+ OS/Arch:            linux/amd64
+ KernelVersion:      3.10.0-693.11.6.el7.x86_64
+ ContainerdVersion:  v1.0.3
+ RuncVersion:        1.0.0-rc6
+ Endpoint:           unix:///var/run/pouchd.sock
+
+$ pouch version --format '{{.Client.Version}} {{.Server.Version}}'
+1.3.0 1.3.0
+
+$ pouch version --format '{{.Client.APIVersion}} {{.NegotiatedAPIVersion}}'
+1.25 1.24`
 }
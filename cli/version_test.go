@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils/templates"
+	"github.com/alibaba/pouch/version"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintVersion(t *testing.T) {
+	tmpl, err := templates.Parse(defaultVersionTemplate)
+	assert.NoError(t, err)
+
+	client := version.Info{Version: "1.3.0", APIVersion: "1.24", Os: "linux", Arch: "amd64"}
+	server := &serverVersion{
+		SystemVersion: types.SystemVersion{Version: "1.2.0", ContainerdVersion: "v1.0.3", RuncVersion: "1.0.0-rc6", Os: "linux", Arch: "amd64"},
+		Endpoint:      "unix:///var/run/pouchd.sock",
+	}
+
+	// only the client part is printed if daemon is unreachable.
+	out := new(bytes.Buffer)
+	assert.NoError(t, printVersion(out, tmpl, versionResult{Client: client}))
+	assert.Contains(t, out.String(), " OS/Arch:            linux/amd64\n")
+	assert.NotContains(t, out.String(), "Server:")
+
+	out.Reset()
+	assert.NoError(t, printVersion(out, tmpl, versionResult{Client: client, Server: server}))
+	assert.Contains(t, out.String(), "\nServer:\n Version:            1.2.0\n")
+	assert.Contains(t, out.String(), " ContainerdVersion:  v1.0.3\n RuncVersion:        1.0.0-rc6\n")
+	assert.Contains(t, out.String(), " Endpoint:           unix:///var/run/pouchd.sock\n")
+
+	// the negotiated version is only shown if it's different from the client's.
+	out.Reset()
+	assert.NoError(t, printVersion(out, tmpl, versionResult{Client: client, Server: server, NegotiatedAPIVersion: "1.24"}))
+	assert.Contains(t, out.String(), " APIVersion:         1.24\n GoVersion:")
+	out.Reset()
+	client.APIVersion = "1.25"
+	assert.NoError(t, printVersion(out, tmpl, versionResult{Client: client, Server: server, NegotiatedAPIVersion: "1.24"}))
+	assert.Contains(t, out.String(), " APIVersion:         1.25 (negotiated 1.24)\n")
+
+	tmpl, err = templates.Parse("{{.Client.Version}} {{.Server.Version}}")
+	assert.NoError(t, err)
+	out.Reset()
+	assert.NoError(t, printVersion(out, tmpl, versionResult{Client: client, Server: server}))
+	assert.Equal(t, "1.3.0 1.2.0", out.String())
+}
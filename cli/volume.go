@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -42,6 +41,7 @@ func (v *VolumeCommand) Init(c *Cli) {
 	c.AddCommand(v, &VolumeRemoveCommand{})
 	c.AddCommand(v, &VolumeInspectCommand{})
 	c.AddCommand(v, &VolumeListCommand{})
+	c.AddCommand(v, &VolumePruneCommand{})
 }
 
 // RunE is the entry of VolumeCommand command.
@@ -110,7 +110,7 @@ func (v *VolumeCreateCommand) volumeCreate() error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := v.cli.Context()
 	apiClient := v.cli.Client()
 	volume, err := apiClient.VolumeCreate(ctx, volumeReq)
 	if err != nil {
@@ -131,13 +131,21 @@ func parseVolume(volumeCreateConfig *types.VolumeCreateConfig, v *VolumeCreateCo
 		volumeCreateConfig.Labels[l[0]] = l[1]
 	}
 
-	// analyze options.
+	// analyze options, the value may contain '=', such as o=size=100m,
+	// and the item without '=' belongs to the value of previous option,
+	// such as rw in o=addr=10.0.0.5,rw which is split by comma.
+	var last string
 	for _, option := range v.options {
-		opt := strings.Split(option, "=")
-		if len(opt) != 2 {
-			return fmt.Errorf("unknown option %s: option format must be key=value", option)
+		opt := strings.SplitN(option, "=", 2)
+		if len(opt) != 2 || opt[0] == "" {
+			if last == "" {
+				return fmt.Errorf("unknown option %s: option format must be key=value", option)
+			}
+			volumeCreateConfig.DriverOpts[last] += "," + option
+			continue
 		}
 		volumeCreateConfig.DriverOpts[opt[0]] = opt[1]
+		last = opt[0]
 	}
 
 	// analyze selectors.
@@ -158,6 +166,13 @@ Mountpoint:
 Name:         pouch-volume
 Scope:
 CreatedAt:
+Driver:       local
+
+$ pouch volume create -d local -n nfs-volume -o type=nfs,device=:/exports/x,o=addr=10.0.0.5,rw
+Mountpoint:
+Name:         nfs-volume
+Scope:
+CreatedAt:
 Driver:       local`
 }
 
@@ -196,7 +211,7 @@ func (v *VolumeRemoveCommand) runVolumeRm(args []string) error {
 
 	logrus.Debugf("remove a volume: %s", name)
 
-	ctx := context.Background()
+	ctx := v.cli.Context()
 	apiClient := v.cli.Client()
 
 	err := apiClient.VolumeRemove(ctx, name)
@@ -246,7 +261,7 @@ func (v *VolumeInspectCommand) addFlags() {
 
 // runVolumeInspect is the entry of VolumeInspectCommand command.
 func (v *VolumeInspectCommand) runVolumeInspect(args []string) error {
-	ctx := context.Background()
+	ctx := v.cli.Context()
 	apiClient := v.cli.Client()
 
 	getRefFunc := func(ref string) (interface{}, error) {
@@ -279,6 +294,31 @@ func volumeInspectExample() string {
 var volumeListDescription = "List volumes in pouchd. " +
 	"It lists the volume's name"
 
+const (
+	// defaultVolumeListFormat is the format of volume list if --format isn't set.
+	defaultVolumeListFormat = "table {{.Driver}}\t{{.Name}}\t{{.Mountpoint}}"
+
+	// defaultVolumeListSizeFormat is the default format of volume list --size.
+	defaultVolumeListSizeFormat = "table {{.Driver}}\t{{.Name}}\t{{.Size}}\t{{.Mountpoint}}"
+)
+
+// volumeFormatContext contains the fields which can be used in the template of volume list --format.
+type volumeFormatContext struct {
+	Driver     string
+	Name       string
+	Size       string
+	Mountpoint string
+	Labels     map[string]string
+}
+
+// volumeListHeader is the header of volume list table.
+var volumeListHeader = volumeFormatContext{
+	Driver:     "DRIVER",
+	Name:       "VOLUME NAME",
+	Size:       "SIZE",
+	Mountpoint: "MOUNT POINT",
+}
+
 // VolumeListCommand is used to implement 'volume rm' command.
 type VolumeListCommand struct {
 	baseCommand
@@ -287,6 +327,7 @@ type VolumeListCommand struct {
 	mountPoint bool
 	quiet      bool
 	filter     []string
+	format     string
 }
 
 // Init initializes VolumeListCommand command.
@@ -311,15 +352,17 @@ func (v *VolumeListCommand) addFlags() {
 	flagSet := v.cmd.Flags()
 	flagSet.BoolVar(&v.size, "size", false, "Display volume size")
 	flagSet.BoolVar(&v.mountPoint, "mountpoint", false, "Display volume mountpoint")
+	flagSet.MarkDeprecated("mountpoint", "the mountpoint is always displayed")
 	flagSet.BoolVarP(&v.quiet, "quiet", "q", false, "Only display volume names")
-	flagSet.StringSliceVarP(&v.filter, "filter", "f", []string{}, "Filter output based on conditions provided, filter support driver, name, label")
+	flagSet.StringSliceVarP(&v.filter, "filter", "f", []string{}, "Filter output based on conditions provided, filter support driver, name, label and dangling")
+	flagSet.StringVar(&v.format, "format", "", "Pretty-print volumes using a Go template, fields Driver, Name, Size, Mountpoint and Labels are supported, the template prefixed with \"table\" prints the columns with headers")
 }
 
 // runVolumeList is the entry of VolumeListCommand command.
 func (v *VolumeListCommand) runVolumeList(args []string) error {
 	logrus.Debugf("list the volumes")
 
-	ctx := context.Background()
+	ctx := v.cli.Context()
 	apiClient := v.cli.Client()
 
 	volumeFilterArgs, err := filters.FromFilterOpts(v.filter)
@@ -336,53 +379,47 @@ func (v *VolumeListCommand) runVolumeList(args []string) error {
 		return fmt.Errorf("Conflicting options: --size (or --mountpoint) and -q")
 	}
 
-	display := v.cli.NewTableDisplay()
-	displayHead := []string{"VOLUME NAME"}
+	if v.quiet {
+		for _, volume := range volumeList.Volumes {
+			fmt.Println(volume.Name)
+		}
+		return nil
+	}
 
-	if !v.quiet {
-		displayHead = append([]string{"DRIVER"}, displayHead...)
+	format := v.format
+	if format == "" {
+		format = defaultVolumeListFormat
 		if v.size {
-			displayHead = append(displayHead, "SIZE")
-		}
-		if v.mountPoint {
-			displayHead = append(displayHead, "MOUNT POINT")
+			format = defaultVolumeListSizeFormat
 		}
 	}
 
-	display.AddRow(displayHead)
-
+	rows := make([]interface{}, 0, len(volumeList.Volumes))
 	for _, volume := range volumeList.Volumes {
-		displayLine := []string{volume.Name}
-		if !v.quiet {
-			displayLine = append([]string{volume.Driver}, displayLine...)
-			if v.size {
-				if s, ok := volume.Status["size"]; ok {
-					displayLine = append(displayLine, s.(string))
-				} else {
-					displayLine = append(displayLine, "ulimit")
-				}
-			}
-			if v.mountPoint {
-				displayLine = append(displayLine, volume.Mountpoint)
-			}
+		row := volumeFormatContext{
+			Driver:     volume.Driver,
+			Name:       volume.Name,
+			Size:       "ulimit",
+			Mountpoint: volume.Mountpoint,
+			Labels:     volume.Labels,
+		}
+		if s, ok := volume.Status["size"]; ok {
+			row.Size = s.(string)
 		}
-		display.AddRow(displayLine)
+		rows = append(rows, row)
 	}
 
-	display.Flush()
-
-	return nil
+	return v.cli.NewFormatter(format).Write(volumeListHeader, rows)
 }
 
 // volumeListExample shows examples in volume list command, and is used in auto-generated cli docs.
 func volumeListExample() string {
 	return `$ pouch volume list
-DRIVER   VOLUME NAME
-local    pouch-volume-1
-local    pouch-volume-2
-local    pouch-volume-3
+DRIVER   VOLUME NAME      MOUNT POINT
+local    pouch-volume-1   /mnt/local/pouch-volume-1
+local    pouch-volume-2   /mnt/local/pouch-volume-2
+local    pouch-volume-3   /mnt/local/pouch-volume-3
 $ pouch volume list --quiet
-VOLUME NAME
 pouch-volume-1
 pouch-volume-2
 pouch-volume-3`
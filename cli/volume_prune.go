@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// volumePruneDescription is used to describe volume prune command in detail and auto generate command doc.
+var volumePruneDescription = "Remove all the volumes which are not used by any container, no matter the " +
+	"container is running or stopped."
+
+// VolumePruneCommand use to implement 'volume prune' command.
+type VolumePruneCommand struct {
+	baseCommand
+
+	// flags for volume prune command
+	flagForce  bool
+	flagFilter []string
+}
+
+// Init initialize "volume prune" command.
+func (p *VolumePruneCommand) Init(c *Cli) {
+	p.cli = c
+	p.cmd = &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove all unused volumes",
+		Long:  volumePruneDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.runPrune()
+		},
+		Example: volumePruneExample(),
+	}
+	p.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (p *VolumePruneCommand) addFlags() {
+	flagSet := p.cmd.Flags()
+	flagSet.BoolVarP(&p.flagForce, "force", "f", false, "Do not prompt for confirmation")
+	flagSet.StringSliceVar(&p.flagFilter, "filter", []string{}, "Provide filter values, filter support label=<key>[=<value>] and label!=<key>[=<value>]")
+}
+
+// runPrune is the entry of volume prune command.
+func (p *VolumePruneCommand) runPrune() error {
+	filter, err := filters.FromFilterOpts(p.flagFilter)
+	if err != nil {
+		return err
+	}
+
+	if !p.flagForce && !confirmPrompt(os.Stdin, os.Stdout, "WARNING! This will remove all volumes not used by at least one container.") {
+		return nil
+	}
+
+	ctx := p.cli.Context()
+	apiClient := p.cli.Client()
+
+	resp, err := apiClient.VolumesPrune(ctx, filter)
+	if err != nil {
+		return errors.Wrap(err, "failed to prune volumes")
+	}
+
+	displayVolumePruneResult(os.Stdout, resp)
+	return nil
+}
+
+// displayVolumePruneResult prints the deleted volumes and the reclaimed space.
+func displayVolumePruneResult(out io.Writer, resp *types.VolumePruneResp) {
+	if len(resp.VolumesDeleted) > 0 {
+		fmt.Fprintln(out, "Deleted Volumes:")
+		for _, name := range resp.VolumesDeleted {
+			fmt.Fprintln(out, name)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "Total reclaimed space: %s\n", utils.FormatSize(resp.SpaceReclaimed))
+}
+
+// volumePruneExample shows examples in volume prune command, and is used in auto-generated cli docs.
+func volumePruneExample() string {
+	return `$ pouch volume prune --filter label!=keep
+WARNING! This will remove all volumes not used by at least one container.
+Are you sure you want to continue? [y/N] y
+Deleted Volumes:
+pouch-volume-1
+e8b2a4f693b54b42c4ae7d0e28d9b89b9e3b1c4301ccc7a9a2d1f67e5c3b2a76
+
+Total reclaimed space: 2.00 KB`
+}
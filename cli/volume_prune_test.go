@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayVolumePruneResult(t *testing.T) {
+	out := new(bytes.Buffer)
+	displayVolumePruneResult(out, &types.VolumePruneResp{
+		VolumesDeleted: []string{"foo", "bar"},
+		SpaceReclaimed: 2048,
+	})
+	assert.Equal(t, `Deleted Volumes:
+foo
+bar
+
+Total reclaimed space: 2.00 KB
+`, out.String())
+
+	// nothing is deleted
+	out.Reset()
+	displayVolumePruneResult(out, &types.VolumePruneResp{})
+	assert.Equal(t, "Total reclaimed space: 0.00 B\n", out.String())
+}
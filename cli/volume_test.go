@@ -1,6 +1,7 @@
 package main
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/alibaba/pouch/apis/types"
@@ -81,3 +82,23 @@ func Test_parseVolume(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseVolumeMountOptions(t *testing.T) {
+	config := &types.VolumeCreateConfig{
+		DriverOpts: map[string]string{},
+		Labels:     map[string]string{},
+	}
+	v := &VolumeCreateCommand{
+		// -o type=nfs,device=:/exports/x,o=addr=10.0.0.5,rw
+		options: []string{"type=nfs", "device=:/exports/x", "o=addr=10.0.0.5", "rw"},
+	}
+
+	if err := parseVolume(config, v); err != nil {
+		t.Fatalf("parseVolume() error = %v", err)
+	}
+
+	expected := map[string]string{"type": "nfs", "device": ":/exports/x", "o": "addr=10.0.0.5,rw"}
+	if !reflect.DeepEqual(config.DriverOpts, expected) {
+		t.Errorf("parseVolume() options = %v, want %v", config.DriverOpts, expected)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -12,11 +13,13 @@ import (
 // waitDescription is used to describe wait command in detail and auto generate command doc.
 var waitDescription = "Block until one or more containers stop, then print their exit codes. " +
 	"If container state is already stopped, the command will return exit code immediately. " +
-	"On a successful stop, the exit code of the container is returned. "
+	"On a successful stop, the exit code of the container is returned. " +
+	"If the containers do not stop in the time given by --timeout, the command aborts with exit code 124."
 
 // WaitCommand is used to implement 'wait' command.
 type WaitCommand struct {
 	baseCommand
+	timeout time.Duration
 }
 
 // Init initializes wait command.
@@ -32,17 +35,38 @@ func (wait *WaitCommand) Init(c *Cli) {
 		},
 		Example: waitExamples(),
 	}
+	wait.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (wait *WaitCommand) addFlags() {
+	flagSet := wait.cmd.Flags()
+	flagSet.DurationVar(&wait.timeout, "timeout", 0, "Abort if the containers do not stop in the given time, such as 30s or 5m, 0 means no timeout")
 }
 
 // runWait is the entry of wait command.
 func (wait *WaitCommand) runWait(args []string) error {
-	ctx := context.Background()
+	ctx := wait.cli.Context()
 	apiClient := wait.cli.Client()
 
+	if wait.timeout < 0 {
+		return fmt.Errorf("invalid --timeout %v: must be greater than or equal to 0", wait.timeout)
+	}
+
+	if wait.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, wait.timeout)
+		defer cancel()
+	}
+
 	var errs []string
 	for _, name := range args {
 		response, err := apiClient.ContainerWait(ctx, name)
 		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				errs = append(errs, fmt.Sprintf("timed out waiting for container %s to stop after %v", name, wait.timeout))
+				return ExitError{Code: exitCodeTimeout, Status: strings.Join(errs, "\n")}
+			}
 			errs = append(errs, err.Error())
 			continue
 		}
@@ -58,13 +82,14 @@ func (wait *WaitCommand) runWait(args []string) error {
 
 // waitExamples shows examples in wait command, and is used in auto-generated cli docs.
 func waitExamples() string {
-	return `$ pouch ps
-Name   ID       Status         Created         Image                                            Runtime
-foo    f6717e   Up 2 seconds   3 seconds ago   registry.hub.docker.com/library/busybox:latest   runc
-$ pouch stop foo
-$ pouch ps -a
-Name   ID       Status                 Created         Image                                            Runtime
-foo    f6717e   Stopped (0) 1 minute   2 minutes ago   registry.hub.docker.com/library/busybox:latest   runc
-$ pouch wait foo
-0`
+	return `$ pouch run -d --name foo busybox sh -c "sleep 3; exit 3"
+$ pouch run -d --name bar busybox true
+$ pouch wait foo bar
+3
+0
+$ pouch run -d --name baz busybox top
+$ pouch wait --timeout 2s baz
+timed out waiting for container baz to stop after 2s
+$ echo $?
+124`
 }
@@ -1,24 +1,28 @@
 package client
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/alibaba/pouch/pkg/httputils"
+	"github.com/alibaba/pouch/version"
 )
 
 var (
-	defaultHost    = "unix:///var/run/pouchd.sock"
 	defaultTimeout = time.Second * 10
-	// defaultVersion is the version of the current stable API
-	defaultVersion = "v1.24"
 )
 
+// apiVersionHeader is the header of response which contains the latest api
+// version daemon serves.
+const apiVersionHeader = "API-Version"
+
 // APIClient is a API client that performs all operations
 // against a pouch server
 type APIClient struct {
@@ -28,6 +32,12 @@ type APIClient struct {
 	HTTPCli *http.Client
 	// version of the server talks to
 	version string
+	// negotiated is true if the version has been negotiated with daemon or
+	// is set by user, so that it is pinned.
+	negotiated bool
+	versionMu  sync.Mutex
+	// tlsConfig is used by the hijacked connections, it's nil if TLS isn't used.
+	tlsConfig *tls.Config
 }
 
 // TLSConfig contains information of tls which users can specify
@@ -37,12 +47,21 @@ type TLSConfig struct {
 	Key              string `json:"tlskey,omitempty"`
 	VerifyRemote     bool   `json:"tlsverify,omitempty"`
 	ManagerWhiteList string `json:"manager-whitelist,omitempty"`
+
+	// Enable uses TLS even if no client certificate is set, it's implied
+	// by VerifyRemote or the key pair, and is only used by client.
+	Enable bool `json:"-"`
+}
+
+// enabled returns whether the client uses TLS.
+func (tls TLSConfig) enabled() bool {
+	return tls.Enable || tls.VerifyRemote || (tls.Key != "" && tls.Cert != "")
 }
 
 // NewAPIClient initializes a new API client for the given host
 func NewAPIClient(host string, tls TLSConfig) (CommonAPIClient, error) {
 	if host == "" {
-		host = defaultHost
+		host = DefaultHost
 	}
 
 	newURL, _, addr, err := httputils.ParseHost(host)
@@ -50,48 +69,51 @@ func NewAPIClient(host string, tls TLSConfig) (CommonAPIClient, error) {
 		return nil, fmt.Errorf("failed to parse host %s: %v", host, err)
 	}
 
-	tlsConfig := generateTLSConfig(host, tls)
+	tlsConfig, err := generateTLSConfig(newURL, tls)
+	if err != nil {
+		return nil, err
+	}
 
 	httpCli := httputils.NewHTTPClient(newURL, tlsConfig, defaultTimeout)
 
 	basePath := generateBaseURL(newURL, tls)
 
-	version := os.Getenv("POUCH_API_VERSION")
-	if version == "" {
-		version = defaultVersion
-	}
+	// POUCH_API_VERSION pins the version without negotiation for debugging.
+	apiVersion := os.Getenv("POUCH_API_VERSION")
 
 	return &APIClient{
-		proto:   newURL.Scheme,
-		addr:    addr,
-		baseURL: basePath,
-		HTTPCli: httpCli,
-		version: version,
+		proto:      newURL.Scheme,
+		addr:       addr,
+		baseURL:    basePath,
+		HTTPCli:    httpCli,
+		version:    apiVersion,
+		negotiated: apiVersion != "",
+		tlsConfig:  tlsConfig,
 	}, nil
 }
 
-// generateTLSConfig configures TLS for API Client.
-func generateTLSConfig(host string, tls TLSConfig) *tls.Config {
-	// init tls config
-	if tls.Key != "" && tls.Cert != "" && !strings.HasPrefix(host, "unix://") {
-		tlsCfg, err := httputils.GenTLSConfig(tls.Key, tls.Cert, tls.CA)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "fail to parse tls config %v", err)
-			os.Exit(1)
-		}
-		tlsCfg.InsecureSkipVerify = !tls.VerifyRemote
+// generateTLSConfig configures TLS for API Client, the certificate of daemon
+// is only verified if VerifyRemote is set. TLS isn't used by unix socket or
+// named pipe.
+func generateTLSConfig(u *url.URL, tls TLSConfig) (*tls.Config, error) {
+	if !tls.enabled() || httputils.IsLocalScheme(u.Scheme) {
+		return nil, nil
+	}
 
-		return tlsCfg
+	tlsCfg, err := httputils.GenTLSConfig(tls.Key, tls.Cert, tls.CA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tls config: %v", err)
 	}
-	return nil
+	tlsCfg.InsecureSkipVerify = !tls.VerifyRemote
+	return tlsCfg, nil
 }
 
 func generateBaseURL(u *url.URL, tls TLSConfig) string {
-	if tls.Key != "" && tls.Cert != "" && u.Scheme != "unix" {
+	if tls.enabled() && !httputils.IsLocalScheme(u.Scheme) {
 		return "https://" + u.Host
 	}
 
-	if u.Scheme == "unix" {
+	if httputils.IsLocalScheme(u.Scheme) {
 		return "http://d"
 	}
 	return "http://" + u.Host
@@ -106,8 +128,7 @@ func (client *APIClient) BaseURL() string {
 // It appends the query parameters to the path if they are not empty.
 func (client *APIClient) GetAPIPath(path string, query url.Values) string {
 	var apiPath string
-	if client.version != "" {
-		v := strings.TrimPrefix(client.version, "v")
+	if v := client.ClientVersion(); v != "" {
 		apiPath = fmt.Sprintf("/v%s%s", v, path)
 	} else {
 		apiPath = path
@@ -122,7 +143,67 @@ func (client *APIClient) GetAPIPath(path string, query url.Values) string {
 	return u.String()
 }
 
-// UpdateClientVersion sets client version new value.
+// UpdateClientVersion sets client version new value, and the version won't
+// be negotiated with daemon any more.
 func (client *APIClient) UpdateClientVersion(v string) {
+	client.versionMu.Lock()
+	defer client.versionMu.Unlock()
+
 	client.version = v
+	client.negotiated = true
+}
+
+// ClientVersion returns the api version used by the requests, it's empty if
+// the version hasn't been negotiated with daemon.
+func (client *APIClient) ClientVersion() string {
+	client.versionMu.Lock()
+	defer client.versionMu.Unlock()
+
+	return strings.TrimPrefix(client.version, "v")
+}
+
+// negotiateVersion pings daemon once to get the latest api version it serves,
+// and pins the requests to the older one of it and the client's. The daemon
+// which doesn't tell its version only serves the oldest version.
+//
+// NOTE: the lock isn't held during the ping, so that the concurrent requests
+// aren't blocked by the unreachable daemon, and the version pinned by others
+// in the meantime is kept.
+func (client *APIClient) negotiateVersion(ctx context.Context) {
+	client.versionMu.Lock()
+	negotiated := client.negotiated
+	client.versionMu.Unlock()
+
+	if negotiated {
+		return
+	}
+
+	req, err := http.NewRequest("GET", client.baseURL+"/_ping", nil)
+	if err != nil {
+		return
+	}
+	resp, err := client.HTTPCli.Do(req.WithContext(ctx))
+	if err != nil {
+		// daemon is unreachable, and try again with the next request.
+		return
+	}
+	resp.Body.Close()
+
+	serverVersion := resp.Header.Get(apiVersionHeader)
+	if serverVersion == "" {
+		serverVersion = version.MinAPIVersion
+	}
+
+	v := version.APIVersion
+	if version.APIVersionLessThan(serverVersion, v) {
+		v = serverVersion
+	}
+
+	client.versionMu.Lock()
+	defer client.versionMu.Unlock()
+
+	if !client.negotiated {
+		client.version = v
+		client.negotiated = true
+	}
 }
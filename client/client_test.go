@@ -1,20 +1,33 @@
 package client
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/alibaba/pouch/version"
+
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewAPIClient(t *testing.T) {
 	assert := assert.New(t)
 	kvs := map[string]bool{
-		"":                      false,
-		"foobar":                true,
-		"tcp://localhost:2476":  false,
-		"http://localhost:2476": false,
+		"":                        false,
+		"foobar":                  true,
+		"tcp://localhost:2476":    false,
+		"http://localhost:2476":   false,
+		"npipe:////./pipe/pouchd": false,
+		"npipe://":                true,
 	}
 
 	for host, expectError := range kvs {
@@ -39,7 +52,10 @@ func Test_generateBaseURL(t *testing.T) {
 		args args
 		want string
 	}{
-		// TODO: Add test cases.
+		{name: "unix socket", args: args{u: &url.URL{Scheme: "unix", Path: "/var/run/pouchd.sock"}}, want: "http://d"},
+		{name: "named pipe with TLS", args: args{u: &url.URL{Scheme: "npipe", Path: "//./pipe/pouchd"}, tls: TLSConfig{Enable: true}}, want: "http://d"},
+		{name: "tcp", args: args{u: &url.URL{Scheme: "tcp", Host: "10.0.0.5:2377"}}, want: "http://10.0.0.5:2377"},
+		{name: "tcp with TLS", args: args{u: &url.URL{Scheme: "tcp", Host: "10.0.0.5:2377"}, tls: TLSConfig{Enable: true}}, want: "https://10.0.0.5:2377"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -49,3 +65,127 @@ func Test_generateBaseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestNegotiateVersion(t *testing.T) {
+	for _, tc := range []struct {
+		serverVersion string
+		expected      string
+	}{
+		{serverVersion: "1.24", expected: "1.24"},
+		{serverVersion: "99.0", expected: version.APIVersion},
+		// the daemon without version header only serves the oldest version.
+		{serverVersion: "", expected: version.MinAPIVersion},
+	} {
+		var pings int
+		paths := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if tc.serverVersion != "" {
+				w.Header().Set(apiVersionHeader, tc.serverVersion)
+			}
+			if req.URL.Path == "/_ping" {
+				pings++
+				return
+			}
+			paths <- req.URL.Path
+			w.Write([]byte("{}"))
+		}))
+
+		cli, err := NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), TLSConfig{})
+		assert.NoError(t, err)
+		assert.Equal(t, "", cli.ClientVersion())
+
+		for i := 0; i < 2; i++ {
+			_, err = cli.SystemInfo(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "/v"+tc.expected+"/info", <-paths)
+		}
+		assert.Equal(t, 1, pings)
+		assert.Equal(t, tc.expected, cli.ClientVersion())
+		server.Close()
+	}
+}
+
+func TestNegotiateVersionWithoutLock(t *testing.T) {
+	pinging, unblock := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		close(pinging)
+		<-unblock
+		w.Header().Set(apiVersionHeader, "1.24")
+	}))
+	defer server.Close()
+
+	cli, err := NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), TLSConfig{})
+	assert.NoError(t, err)
+	client := cli.(*APIClient)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.negotiateVersion(context.Background())
+	}()
+
+	// the version can be read and pinned while daemon is being pinged.
+	<-pinging
+	assert.Equal(t, "", client.ClientVersion())
+	client.UpdateClientVersion("1.25")
+	close(unblock)
+	<-done
+
+	// the version pinned during the ping is kept.
+	assert.Equal(t, "1.25", client.ClientVersion())
+}
+
+func TestAPIVersionFromEnv(t *testing.T) {
+	defer os.Unsetenv("POUCH_API_VERSION")
+	os.Setenv("POUCH_API_VERSION", "1.24")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1.24/info", req.URL.Path)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	cli, err := NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), TLSConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.24", cli.ClientVersion())
+
+	_, err = cli.SystemInfo(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestHijackTransports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "client-hijack")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tcpServer := httptest.NewServer(http.HandlerFunc(testTLSHandler))
+	defer tcpServer.Close()
+
+	sock := filepath.Join(dir, "pouchd.sock")
+	l, err := net.Listen("unix", sock)
+	assert.NoError(t, err)
+	unixServer := httptest.NewUnstartedServer(http.HandlerFunc(testTLSHandler))
+	unixServer.Listener = l
+	unixServer.Start()
+	defer unixServer.Close()
+
+	// the hijacked connection goes through the same transport as requests.
+	for _, host := range []string{"tcp://" + strings.TrimPrefix(tcpServer.URL, "http://"), "unix://" + sock} {
+		cli, err := NewAPIClient(host, TLSConfig{})
+		assert.NoError(t, err)
+
+		resp, err := cli.(*APIClient).get(context.Background(), "/_ping", nil, nil)
+		if assert.NoError(t, err, host) {
+			resp.Body.Close()
+		}
+
+		conn, br, err := cli.(*APIClient).hijack(context.Background(), "/containers/foo/attach", nil, nil, nil)
+		if !assert.NoError(t, err, host) {
+			continue
+		}
+		line, err := bufio.NewReader(br).ReadString('\n')
+		assert.NoError(t, err, host)
+		assert.Equal(t, "hello\n", line, host)
+		conn.Close()
+	}
+}
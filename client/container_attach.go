@@ -7,14 +7,18 @@ import (
 	"net/url"
 )
 
-// ContainerAttach attachs a container.
-func (client *APIClient) ContainerAttach(ctx context.Context, name string, stdin bool) (net.Conn, *bufio.Reader, error) {
+// ContainerAttach attachs a container. The default detach keys of daemon
+// are used if detachKeys is empty.
+func (client *APIClient) ContainerAttach(ctx context.Context, name string, stdin bool, detachKeys string) (net.Conn, *bufio.Reader, error) {
 	q := url.Values{}
 	if stdin {
 		q.Set("stdin", "1")
 	} else {
 		q.Set("stdin", "0")
 	}
+	if detachKeys != "" {
+		q.Set("detachKeys", detachKeys)
+	}
 
 	header := map[string][]string{
 		"Content-Type": {"text/plain"},
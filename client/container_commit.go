@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"net/url"
+	"strconv"
 
 	"github.com/alibaba/pouch/apis/types"
 )
@@ -15,6 +16,10 @@ func (client *APIClient) ContainerCommit(ctx context.Context, name string, optio
 	q.Set("tag", options.Tag)
 	q.Set("comment", options.Comment)
 	q.Set("author", options.Author)
+	q.Set("pause", strconv.FormatBool(options.Pause))
+	for _, change := range options.Changes {
+		q.Add("changes", change)
+	}
 
 	response := &types.ContainerCommitResp{}
 	resp, err := client.post(ctx, "/commit", q, nil, nil)
@@ -43,6 +43,12 @@ func TestCommit(t *testing.T) {
 		if options.Tag != "bar" {
 			return nil, fmt.Errorf("expected Tag %s, obtain %s", "bar", options.Tag)
 		}
+		if changes := req.URL.Query()["changes"]; len(changes) != 2 || changes[1] != "EXPOSE 80" {
+			return nil, fmt.Errorf("expected two changes, obtain %v", changes)
+		}
+		if pause := req.FormValue("pause"); pause != "false" {
+			return nil, fmt.Errorf("expected pause false, obtain %s", pause)
+		}
 
 		resp := types.ContainerCommitResp{
 			ID: "newid",
@@ -64,7 +70,9 @@ func TestCommit(t *testing.T) {
 
 	r, err := client.ContainerCommit(context.Background(), "id", types.ContainerCommitOptions{
 		Repository: "foo",
-		Tag:        "bar"})
+		Tag:        "bar",
+		Changes:    []string{"ENV FOO=bar", "EXPOSE 80"},
+		Pause:      false})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// containerPathStatHeader is the header of response which contains the
+// information of path in container.
+const containerPathStatHeader = "X-Docker-Container-Path-Stat"
+
+// ContainerStatPath returns the information of the path in container's filesystem.
+func (client *APIClient) ContainerStatPath(ctx context.Context, name, path string) (types.ContainerPathStat, error) {
+	query := url.Values{}
+	query.Set("path", path)
+
+	resp, err := client.head(ctx, "/containers/"+name+"/archive", query, nil)
+	if err != nil {
+		// the response of HEAD request has no body.
+		if respErr, ok := err.(RespError); ok && respErr.msg == "" {
+			respErr.msg = fmt.Sprintf("failed to stat path %s in container %s: %s", path, name, http.StatusText(respErr.code))
+			return types.ContainerPathStat{}, respErr
+		}
+		return types.ContainerPathStat{}, err
+	}
+	ensureCloseReader(resp)
+
+	return getContainerPathStat(resp.Header)
+}
+
+// CopyFromContainer gets the tar archive of the path in container's
+// filesystem, and the information of the path.
+func (client *APIClient) CopyFromContainer(ctx context.Context, name, path string) (io.ReadCloser, types.ContainerPathStat, error) {
+	query := url.Values{}
+	query.Set("path", path)
+
+	resp, err := client.get(ctx, "/containers/"+name+"/archive", query, nil)
+	if err != nil {
+		return nil, types.ContainerPathStat{}, err
+	}
+
+	stat, err := getContainerPathStat(resp.Header)
+	if err != nil {
+		ensureCloseReader(resp)
+		return nil, types.ContainerPathStat{}, err
+	}
+	return resp.Body, stat, nil
+}
+
+// CopyToContainer extracts the tar archive into the directory of path in
+// container's filesystem.
+func (client *APIClient) CopyToContainer(ctx context.Context, name, path string, content io.Reader, noOverwriteDirNonDir bool) error {
+	query := url.Values{}
+	query.Set("path", path)
+	if noOverwriteDirNonDir {
+		query.Set("noOverwriteDirNonDir", "true")
+	}
+
+	headers := map[string][]string{}
+	headers["Content-Type"] = []string{"application/x-tar"}
+
+	resp, err := client.putRawData(ctx, "/containers/"+name+"/archive", query, content, headers)
+	if err != nil {
+		return err
+	}
+	ensureCloseReader(resp)
+	return nil
+}
+
+// getContainerPathStat decodes the information of path from header.
+func getContainerPathStat(header http.Header) (types.ContainerPathStat, error) {
+	var stat types.ContainerPathStat
+
+	encoded := header.Get(containerPathStatHeader)
+	if encoded == "" {
+		return stat, fmt.Errorf("missing header %s in response", containerPathStatHeader)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return stat, fmt.Errorf("failed to decode header %s: %v", containerPathStatHeader, err)
+	}
+
+	err = json.Unmarshal(data, &stat)
+	return stat, err
+}
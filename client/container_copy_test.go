@@ -0,0 +1,107 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerStatPathNotFound(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		}),
+	}
+
+	_, err := client.ContainerStatPath(context.Background(), "foo", "/nothing")
+	assert.Error(t, err)
+	respErr, ok := err.(RespError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, respErr.Code())
+	assert.Contains(t, err.Error(), "failed to stat path /nothing in container foo")
+}
+
+func TestCopyFromContainer(t *testing.T) {
+	expectedURL := "/containers/foo/archive"
+	stat := `{"name":"hosts","size":12,"mode":420}`
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "GET" {
+			return nil, fmt.Errorf("expected GET method, got %s", req.Method)
+		}
+		if path := req.URL.Query().Get("path"); path != "/etc/hosts" {
+			return nil, fmt.Errorf("expected path /etc/hosts, got %s", path)
+		}
+
+		header := http.Header{}
+		header.Set(containerPathStatHeader, base64.StdEncoding.EncodeToString([]byte(stat)))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("tar"))),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	content, pathStat, err := client.CopyFromContainer(context.Background(), "foo", "/etc/hosts")
+	assert.NoError(t, err)
+	defer content.Close()
+
+	assert.Equal(t, "hosts", pathStat.Name)
+	assert.Equal(t, int64(12), pathStat.Size)
+	assert.Equal(t, uint32(0644), pathStat.Mode)
+
+	data, err := ioutil.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "tar", string(data))
+}
+
+func TestCopyToContainer(t *testing.T) {
+	expectedURL := "/containers/foo/archive"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "PUT" {
+			return nil, fmt.Errorf("expected PUT method, got %s", req.Method)
+		}
+		if v := req.URL.Query().Get("noOverwriteDirNonDir"); v != "true" {
+			return nil, fmt.Errorf("expected noOverwriteDirNonDir true, got %s", v)
+		}
+		data, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if string(data) != "tar" {
+			return nil, fmt.Errorf("expected body tar, got %s", data)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	err := client.CopyToContainer(context.Background(), "foo", "/etc", strings.NewReader("tar"), true)
+	assert.NoError(t, err)
+}
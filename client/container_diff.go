@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ContainerDiff returns the changes on a container's filesystem.
+func (client *APIClient) ContainerDiff(ctx context.Context, name string) ([]*types.ContainerChangeResponseItem, error) {
+	resp, err := client.get(ctx, "/containers/"+name+"/changes", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []*types.ContainerChangeResponseItem{}
+	err = decodeBody(&changes, resp.Body)
+	ensureCloseReader(resp)
+
+	return changes, err
+}
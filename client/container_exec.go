@@ -45,3 +45,15 @@ func (client *APIClient) ContainerExecInspect(ctx context.Context, execid string
 
 	return body, err
 }
+
+// ContainerExecResize resizes the size of exec process's tty.
+func (client *APIClient) ContainerExecResize(ctx context.Context, execid, height, width string) error {
+	query := url.Values{}
+	query.Set("h", height)
+	query.Set("w", width)
+
+	resp, err := client.post(ctx, "/exec/"+execid+"/resize", query, nil, nil)
+	ensureCloseReader(resp)
+
+	return err
+}
@@ -108,3 +108,44 @@ func TestContainerInspectExec(t *testing.T) {
 	assert.Equal(t, res.ID, "exec_id")
 	assert.Equal(t, res.ContainerID, "container_id")
 }
+
+func TestContainerExecResizeError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.ContainerExecResize(context.Background(), "nothing", "", "")
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerExecResize(t *testing.T) {
+	expectedURL := "/exec/exec_id/resize"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "POST" {
+			return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+		}
+		if height := req.URL.Query().Get("h"); height != "24" {
+			return nil, fmt.Errorf("height not set in URL query properly. Expected '24', got %s", height)
+		}
+		if width := req.URL.Query().Get("w"); width != "80" {
+			return nil, fmt.Errorf("width not set in URL query properly. Expected '80', got %s", width)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	if err := client.ContainerExecResize(context.Background(), "exec_id", "24", "80"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,19 @@
+package client
+
+import (
+	"context"
+	"net/url"
+)
+
+// ContainerKill sends a signal to a container.
+func (client *APIClient) ContainerKill(ctx context.Context, name, signal string) error {
+	q := url.Values{}
+	if signal != "" {
+		q.Set("signal", signal)
+	}
+
+	resp, err := client.post(ctx, "/containers/"+name+"/kill", q, nil, nil)
+	ensureCloseReader(resp)
+
+	return err
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"net/url"
+	"strconv"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/pkg/utils/filters"
@@ -16,6 +17,10 @@ func (client *APIClient) ContainerList(ctx context.Context, option types.Contain
 		q.Set("all", "true")
 	}
 
+	if option.Limit > 0 {
+		q.Set("limit", strconv.FormatInt(option.Limit, 10))
+	}
+
 	if len(option.Filter) > 0 {
 		fJSON, err := filters.ToURLParam(option.Filter)
 		if err != nil {
@@ -37,6 +37,10 @@ func TestContainerList(t *testing.T) {
 		if all != "true" {
 			return nil, fmt.Errorf("all not set in URL query properly. Expected 'true', got %s", all)
 		}
+		limit := query.Get("limit")
+		if limit != "2" {
+			return nil, fmt.Errorf("limit not set in URL query properly. Expected '2', got %s", limit)
+		}
 		containersJSON := []types.ContainerJSON{
 			{
 				Name:  "container1",
@@ -60,7 +64,8 @@ func TestContainerList(t *testing.T) {
 		HTTPCli: httpClient,
 	}
 	containers, err := client.ContainerList(context.Background(), types.ContainerListOptions{
-		All: true,
+		All:   true,
+		Limit: 2,
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ContainersPrune requests daemon to delete the containers which are not running or paused.
+func (client *APIClient) ContainersPrune(ctx context.Context, filter filters.Args) (*types.ContainerPruneResp, error) {
+	query := url.Values{}
+
+	if filter.Len() > 0 {
+		filtersJSON, err := filters.ToParam(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Set("filters", filtersJSON)
+	}
+
+	resp, err := client.post(ctx, "/containers/prune", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pruneResp := &types.ContainerPruneResp{}
+
+	err = decodeBody(pruneResp, resp.Body)
+	ensureCloseReader(resp)
+
+	return pruneResp, err
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainersPruneServerError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainersPrune(context.Background(), filters.NewArgs())
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainersPrune(t *testing.T) {
+	expectedURL := "/containers/prune"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "POST" {
+			return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+		}
+
+		filter, err := filters.FromParam(req.URL.Query().Get("filters"))
+		if err != nil {
+			return nil, err
+		}
+		if !filter.ExactMatch("until", "1h") {
+			return nil, fmt.Errorf("expected until=1h filter, got %v", req.URL.Query().Get("filters"))
+		}
+
+		pruneResp, err := json.Marshal(types.ContainerPruneResp{
+			ContainersDeleted: []string{"a", "b"},
+			SpaceReclaimed:    1024,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(pruneResp)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	filter := filters.NewArgs()
+	filter.Add("until", "1h")
+
+	resp, err := client.ContainersPrune(context.Background(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"a", "b"}, resp.ContainersDeleted)
+	assert.Equal(t, int64(1024), resp.SpaceReclaimed)
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/alibaba/pouch/apis/types"
 )
@@ -20,3 +21,33 @@ func (client *APIClient) ContainerWait(ctx context.Context, name string) (types.
 	ensureCloseReader(resp)
 	return response, err
 }
+
+// ContainerWaitNextExit waits for the next exit of container even if it isn't
+// running. It returns after the daemon starts waiting, so that the exit of
+// the container started afterwards is never missed, even if the container is
+// removed automatically. Either the exit status or the error is sent.
+func (client *APIClient) ContainerWaitNextExit(ctx context.Context, name string) (<-chan types.ContainerWaitOKBody, <-chan error) {
+	statusCh := make(chan types.ContainerWaitOKBody, 1)
+	errCh := make(chan error, 1)
+
+	q := url.Values{}
+	q.Set("condition", "next-exit")
+
+	resp, err := client.post(ctx, "/containers/"+name+"/wait", q, nil, nil)
+	if err != nil {
+		errCh <- err
+		return statusCh, errCh
+	}
+
+	go func() {
+		defer ensureCloseReader(resp)
+
+		var response types.ContainerWaitOKBody
+		if err := decodeBody(&response, resp.Body); err != nil {
+			errCh <- err
+			return
+		}
+		statusCh <- response
+	}()
+	return statusCh, errCh
+}
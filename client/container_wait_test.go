@@ -63,3 +63,48 @@ func TestContainerWait(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestContainerWaitNextExit(t *testing.T) {
+	expectedURL := "/containers/container_id/wait"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if condition := req.URL.Query().Get("condition"); condition != "next-exit" {
+			return nil, fmt.Errorf("expected condition next-exit, got %s", condition)
+		}
+		b, err := json.Marshal(types.ContainerWaitOKBody{StatusCode: 2})
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	statusCh, errCh := client.ContainerWaitNextExit(context.Background(), "container_id")
+	select {
+	case status := <-statusCh:
+		if status.StatusCode != 2 {
+			t.Fatalf("expected status code 2, got %d", status.StatusCode)
+		}
+	case err := <-errCh:
+		t.Fatal(err)
+	}
+}
+
+func TestContainerWaitNextExitError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusNotFound, "Not Found")),
+	}
+	_, errCh := client.ContainerWaitNextExit(context.Background(), "no container")
+	if err := <-errCh; err == nil || !strings.Contains(err.Error(), "Not Found") {
+		t.Fatalf("expected a Not Found Error, got %v", err)
+	}
+}
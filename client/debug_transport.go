@@ -0,0 +1,259 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces the credentials printed by DebugTransport.
+const redacted = "<redacted>"
+
+// DefaultDebugBodyLimit is the default max number of bytes of request and
+// response body printed by DebugTransport.
+const DefaultDebugBodyLimit = 1024
+
+// debugHeaders is the headers printed by DebugTransport.
+var debugHeaders = []string{
+	"Content-Type",
+	"Content-Length",
+	"Transfer-Encoding",
+	"Upgrade",
+	apiVersionHeader,
+	"Authorization",
+	"X-Registry-Auth",
+	"X-Registry-Config",
+}
+
+// redactedHeaders is the headers which contain credentials, so that their
+// values are never printed.
+var redactedHeaders = map[string]bool{
+	"Authorization":     true,
+	"X-Registry-Auth":   true,
+	"X-Registry-Config": true,
+}
+
+// redactedKeys is the keys of JSON body which contain credentials, such as
+// the AuthConfig of login and the tokens in the response, compared in lower
+// case.
+var redactedKeys = map[string]bool{
+	"password":      true,
+	"auth":          true,
+	"identitytoken": true,
+	"registrytoken": true,
+}
+
+// DebugTransport is a http.RoundTripper which prints the request line, the
+// selected headers, the status code and the timing of each request. The
+// bodies are truncated at BodyLimit, and the size of the response body is
+// printed once it has been read, which tells the bytes and frames received
+// by the streaming endpoints such as pull and events.
+type DebugTransport struct {
+	// Transport is the underlying RoundTripper which sends the requests.
+	Transport http.RoundTripper
+	// Out is where the requests and responses are printed.
+	Out io.Writer
+	// BodyLimit is the max number of bytes of body printed.
+	BodyLimit int
+
+	mu sync.Mutex
+}
+
+// NewDebugTransport wraps the transport to print the requests and responses.
+func NewDebugTransport(transport http.RoundTripper, out io.Writer, bodyLimit int) *DebugTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &DebugTransport{Transport: transport, Out: out, BodyLimit: bodyLimit}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *DebugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		// only the head of body is read, the rest is sent as it is.
+		head, err := ioutil.ReadAll(io.LimitReader(req.Body, int64(t.BodyLimit)+1))
+		if err != nil {
+			return nil, err
+		}
+		reqBody = head
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(head), req.Body), req.Body}
+	}
+
+	line := req.Method + " " + req.URL.RequestURI()
+	t.printf("--> %s\n%s%s", line, formatHeaders(req.Header), t.formatBody(req.URL.Path, reqBody))
+
+	start := time.Now()
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		t.printf("<-- %s failed in %v: %v\n", line, time.Since(start), err)
+		return nil, err
+	}
+
+	t.printf("<-- %s %s in %v\n%s", resp.Status, line, time.Since(start), formatHeaders(resp.Header))
+	resp.Body = &debugBody{
+		ReadCloser: resp.Body,
+		transport:  t,
+		path:       req.URL.Path,
+		line:       line,
+		start:      start,
+	}
+	return resp, nil
+}
+
+// printf prints the message, and the messages of concurrent requests aren't
+// interleaved.
+func (t *DebugTransport) printf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.Out, format, args...)
+}
+
+// formatBody returns the body truncated at the limit, and the credentials in
+// it are redacted.
+func (t *DebugTransport) formatBody(path string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	body = redactBody(path, body)
+	if len(body) > t.BodyLimit {
+		return fmt.Sprintf("    %s... (truncated)\n", bytes.TrimSpace(body[:t.BodyLimit]))
+	}
+	return fmt.Sprintf("    %s\n", bytes.TrimSpace(body))
+}
+
+// formatHeaders returns the selected headers, and the credentials in them
+// are redacted.
+func formatHeaders(header http.Header) string {
+	var buf bytes.Buffer
+	for _, name := range debugHeaders {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		if redactedHeaders[name] {
+			value = redacted
+		}
+		fmt.Fprintf(&buf, "    %s: %s\n", name, value)
+	}
+	return buf.String()
+}
+
+// redactBody returns the body whose credentials are redacted. The bodies of
+// login are never printed, and the values of redactedKeys in JSON body are
+// replaced. The whole body is redacted if it contains the keys but can't be
+// decoded, such as the truncated one.
+func redactBody(path string, body []byte) []byte {
+	if path == "/auth" || strings.HasSuffix(path, "/auth") {
+		return []byte(redacted)
+	}
+
+	lower := bytes.ToLower(body)
+	found := false
+	for key := range redactedKeys {
+		if bytes.Contains(lower, []byte(`"`+key+`"`)) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return body
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return []byte(redacted)
+	}
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(redactValue(v)); err != nil {
+		return []byte(redacted)
+	}
+	return bytes.TrimSpace(out.Bytes())
+}
+
+// redactValue replaces the values of redactedKeys in the decoded JSON.
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if redactedKeys[strings.ToLower(key)] {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(value)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return v
+}
+
+// debugBody counts the bytes and newline-delimited frames of response body,
+// which are printed with the head of body once it is drained or closed.
+type debugBody struct {
+	io.ReadCloser
+
+	transport *DebugTransport
+	path      string
+	line      string
+	start     time.Time
+
+	// mu protects the counters, since the body may be closed by another
+	// goroutine to stop reading.
+	mu     sync.Mutex
+	head   []byte
+	bytes  int64
+	frames int64
+	once   sync.Once
+}
+
+// Read implements the io.Reader interface.
+func (b *debugBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	b.mu.Lock()
+	b.bytes += int64(n)
+	b.frames += int64(bytes.Count(p[:n], []byte{'\n'}))
+	if rest := b.transport.BodyLimit + 1 - len(b.head); rest > 0 {
+		if rest > n {
+			rest = n
+		}
+		b.head = append(b.head, p[:rest]...)
+	}
+	b.mu.Unlock()
+
+	if err != nil {
+		b.done()
+	}
+	return n, err
+}
+
+// Close implements the io.Closer interface.
+func (b *debugBody) Close() error {
+	b.done()
+	return b.ReadCloser.Close()
+}
+
+// done prints the size of body once.
+func (b *debugBody) done() {
+	b.once.Do(func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.transport.printf("<-- %s received %d bytes, %d frames in %v\n%s",
+			b.line, b.bytes, b.frames, time.Since(b.start), b.transport.formatBody(b.path, b.head))
+	})
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		assert.Equal(t, `{"Image":"busybox:latest"}`, strings.TrimSpace(string(body)))
+
+		w.Header().Set("Content-Type", "application/json")
+		for i := 0; i < 3; i++ {
+			fmt.Fprintf(w, "{\"status\":\"pulling %d\"}\n", i)
+		}
+	}))
+	defer server.Close()
+
+	cli, err := NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), TLSConfig{})
+	assert.NoError(t, err)
+	apiClient := cli.(*APIClient)
+	apiClient.UpdateClientVersion("")
+
+	out := new(bytes.Buffer)
+	apiClient.HTTPCli.Transport = NewDebugTransport(apiClient.HTTPCli.Transport, out, 16)
+
+	resp, err := apiClient.post(context.Background(), "/images/create", nil,
+		map[string]string{"Image": "busybox:latest"},
+		map[string][]string{"X-Registry-Auth": {"c2VjcmV0"}})
+	assert.NoError(t, err)
+	data, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, bytes.Count(data, []byte{'\n'}))
+	resp.Body.Close()
+
+	output := out.String()
+	assert.Contains(t, output, "--> POST /images/create\n")
+	assert.Contains(t, output, "    X-Registry-Auth: <redacted>\n")
+	assert.NotContains(t, output, "c2VjcmV0")
+	assert.Contains(t, output, "    {\"Image\":\"busybo... (truncated)\n")
+	assert.Contains(t, output, "<-- 200 OK POST /images/create in ")
+	assert.Contains(t, output, "    Content-Type: application/json\n")
+	assert.Contains(t, output, fmt.Sprintf("<-- POST /images/create received %d bytes, 3 frames in ", len(data)))
+
+	// the body is printed only once even if it's closed after drained.
+	assert.Equal(t, 1, strings.Count(output, "received"))
+}
+
+func TestDebugTransportError(t *testing.T) {
+	out := new(bytes.Buffer)
+	transport := NewDebugTransport(transportFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("connection refused")
+	}), out, DefaultDebugBodyLimit)
+
+	client := &APIClient{HTTPCli: &http.Client{Transport: transport}}
+	client.UpdateClientVersion("")
+
+	_, err := client.get(context.Background(), "/info", nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, out.String(), "<-- GET /info failed in ")
+	assert.Contains(t, out.String(), "connection refused\n")
+}
+
+func TestDebugTransportRedactBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(req.URL.Path, "/auth") {
+			fmt.Fprint(w, `{"Status":"Login Succeeded","IdentityToken":"token-of-login"}`)
+			return
+		}
+		fmt.Fprint(w, `{"Name":"foo","Config":{"Auth":"c2VjcmV0"}}`)
+	}))
+	defer server.Close()
+
+	cli, err := NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), TLSConfig{})
+	assert.NoError(t, err)
+	apiClient := cli.(*APIClient)
+	apiClient.UpdateClientVersion("1.24")
+
+	out := new(bytes.Buffer)
+	apiClient.HTTPCli.Transport = NewDebugTransport(apiClient.HTTPCli.Transport, out, DefaultDebugBodyLimit)
+
+	// the bodies of login are never printed.
+	resp, err := apiClient.post(context.Background(), "/auth", nil,
+		map[string]string{"Username": "foo", "Password": "password-of-login"}, nil)
+	assert.NoError(t, err)
+	ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	// the credentials in other bodies are redacted.
+	resp, err = apiClient.post(context.Background(), "/containers/create", nil,
+		map[string]interface{}{"Image": "busybox", "Env": []interface{}{map[string]string{"password": "password-of-env"}}}, nil)
+	assert.NoError(t, err)
+	ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	output := out.String()
+	for _, secret := range []string{"password-of-login", "token-of-login", "password-of-env", "c2VjcmV0"} {
+		assert.NotContains(t, output, secret)
+	}
+	assert.Contains(t, output, `"Image":"busybox"`)
+	assert.Contains(t, output, `"Auth":"<redacted>"`)
+}
+
+func TestRedactBody(t *testing.T) {
+	for _, tc := range []struct {
+		path     string
+		body     string
+		expected string
+	}{
+		{path: "/v1.24/auth", body: `{"Username":"foo"}`, expected: redacted},
+		{path: "/info", body: `{"Name":"foo"}`, expected: `{"Name":"foo"}`},
+		{path: "/info", body: `[{"registrytoken":"x","a":1}]`, expected: `[{"a":1,"registrytoken":"<redacted>"}]`},
+		// the truncated body can't be decoded.
+		{path: "/images/create", body: `{"password":"sec`, expected: redacted},
+	} {
+		assert.Equal(t, tc.expected, string(redactBody(tc.path, []byte(tc.body))), tc.body)
+	}
+}
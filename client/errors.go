@@ -0,0 +1,96 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/pkg/errors"
+)
+
+// RespError defines the response error.
+type RespError struct {
+	code int
+	msg  string
+}
+
+// newRespError returns the error of response with the status code, the
+// message is taken from the json error body of daemon, or the raw body if
+// it isn't sent by daemon, such as a proxy in front of it.
+func newRespError(code int, body []byte) RespError {
+	var apiErr types.Error
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		return RespError{code: code, msg: apiErr.Message}
+	}
+	return RespError{code: code, msg: strings.TrimSpace(string(body))}
+}
+
+// NewStreamError returns the error sent by daemon in the json stream, such
+// as the progress of pulling image, since the status code of response has
+// been sent once the stream starts. The code in the stream tells the kind
+// of failure like the status code.
+func NewStreamError(code int, msg string) error {
+	return RespError{code: code, msg: msg}
+}
+
+// Error implements the error interface.
+func (e RespError) Error() string {
+	return e.msg
+}
+
+// Code returns the response  code
+func (e RespError) Code() int {
+	return e.code
+}
+
+// connectionError is the error of failing to reach daemon, such as daemon is
+// down or the TLS settings of client and daemon don't match.
+type connectionError struct {
+	error
+}
+
+// IsErrConnectionFailed returns true if the error is caused by that daemon
+// can't be reached, rather than the failure of request in daemon.
+func IsErrConnectionFailed(err error) bool {
+	_, ok := errors.Cause(err).(connectionError)
+	return ok
+}
+
+// IsErrNotFound returns true if the error is caused by that the object
+// doesn't exist in daemon.
+func IsErrNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsErrConflict returns true if the error is caused by the conflict with the
+// state of object in daemon, such as the name is in use.
+func IsErrConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+// IsErrUnauthorized returns true if the error is caused by the failure of
+// authentication, such as the wrong credential of registry.
+func IsErrUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsErrForbidden returns true if the error is caused by that the client isn't
+// allowed to access daemon.
+func IsErrForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsErrBadRequest returns true if the error is caused by the invalid
+// parameters of request.
+func IsErrBadRequest(err error) bool {
+	return hasStatusCode(err, http.StatusBadRequest)
+}
+
+// hasStatusCode returns true if the cause of error is the response error with
+// the status code.
+func hasStatusCode(err error, code int) bool {
+	respErr, ok := errors.Cause(err).(RespError)
+	return ok && respErr.code == code
+}
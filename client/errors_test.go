@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/jsonstream"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRespErrorClasses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var (
+			code int
+			msg  string
+		)
+		switch {
+		case strings.Contains(req.URL.Path, "/containers/foo"):
+			code, msg = http.StatusNotFound, "container foo: not found"
+		case strings.Contains(req.URL.Path, "/containers/create"):
+			code, msg = http.StatusConflict, "container name foo: already existed"
+		case strings.Contains(req.URL.Path, "/volumes/foo"):
+			code, msg = http.StatusNotFound, "volume foo: not found"
+		case strings.Contains(req.URL.Path, "/networks/foo"):
+			code, msg = http.StatusNotFound, "network foo: not found"
+		case strings.Contains(req.URL.Path, "/auth"):
+			code, msg = http.StatusUnauthorized, "wrong username or password"
+		default:
+			// the body which isn't sent by daemon.
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintln(w, "forbidden by proxy")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(types.Error{Message: msg})
+	}))
+	defer server.Close()
+
+	cli, err := NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), TLSConfig{})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = cli.ContainerGet(ctx, "foo")
+	assert.True(t, IsErrNotFound(err))
+	assert.False(t, IsErrConflict(err))
+	assert.EqualError(t, err, "container foo: not found")
+
+	_, err = cli.ContainerCreate(ctx, types.ContainerConfig{}, nil, nil, "foo")
+	assert.True(t, IsErrConflict(err))
+	assert.EqualError(t, err, "container name foo: already existed")
+
+	_, err = cli.VolumeInspect(ctx, "foo")
+	assert.True(t, IsErrNotFound(err))
+	assert.EqualError(t, err, "volume foo: not found")
+
+	_, err = cli.NetworkInspect(ctx, "foo")
+	assert.True(t, IsErrNotFound(err))
+	assert.EqualError(t, err, "network foo: not found")
+
+	_, err = cli.RegistryLogin(ctx, &types.AuthConfig{})
+	assert.True(t, IsErrUnauthorized(err))
+	assert.False(t, IsErrNotFound(err))
+	assert.EqualError(t, err, "wrong username or password")
+
+	_, err = cli.SystemInfo(ctx)
+	assert.True(t, IsErrForbidden(err))
+	assert.False(t, IsErrConnectionFailed(err))
+	assert.EqualError(t, err, "forbidden by proxy")
+}
+
+func TestStreamError(t *testing.T) {
+	// the daemon responds 200 once the pull starts, and sends the error in
+	// the progress stream.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		enc := json.NewEncoder(w)
+		enc.Encode(jsonstream.JSONMessage{ID: "docker.io/library/foo:latest", Status: jsonstream.PullStatusResolving})
+		enc.Encode(jsonstream.JSONMessage{
+			Error:        &jsonstream.JSONError{Code: http.StatusUnauthorized, Message: "pull access denied for foo"},
+			ErrorMessage: "pull access denied for foo",
+		})
+	}))
+	defer server.Close()
+
+	cli, err := NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), TLSConfig{})
+	assert.NoError(t, err)
+
+	body, err := cli.ImagePull(context.Background(), "foo", "latest", "", "")
+	assert.NoError(t, err)
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	for {
+		var msg jsonstream.JSONMessage
+		if !assert.NoError(t, dec.Decode(&msg)) {
+			return
+		}
+		if msg.Error == nil {
+			continue
+		}
+
+		err = NewStreamError(msg.Error.Code, msg.Error.Message)
+		assert.True(t, IsErrUnauthorized(err))
+		assert.False(t, IsErrNotFound(err))
+		assert.EqualError(t, err, "pull access denied for foo")
+		return
+	}
+}
+
+func TestIsErrConnectionFailed(t *testing.T) {
+	cli, err := NewAPIClient("unix:///var/run/nonexistent-pouchd.sock", TLSConfig{})
+	assert.NoError(t, err)
+
+	_, err = cli.SystemInfo(context.Background())
+	assert.True(t, IsErrConnectionFailed(err))
+	assert.False(t, IsErrNotFound(err))
+	assert.Contains(t, err.Error(), "nonexistent-pouchd.sock")
+}
+
+func TestIsErrWithOtherErrors(t *testing.T) {
+	assert.False(t, IsErrNotFound(nil))
+	assert.False(t, IsErrNotFound(fmt.Errorf("not found")))
+	assert.True(t, IsErrBadRequest(newRespError(http.StatusBadRequest, []byte(`{"message":"invalid"}`))))
+}
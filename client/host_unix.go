@@ -0,0 +1,6 @@
+// +build !windows
+
+package client
+
+// DefaultHost is the address of daemon used if no host is set.
+const DefaultHost = "unix:///var/run/pouchd.sock"
@@ -0,0 +1,7 @@
+// +build windows
+
+package client
+
+// DefaultHost is the address of daemon used if no host is set, which is the
+// named pipe on windows.
+const DefaultHost = "npipe:////./pipe/pouchd"
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ImageImport requests daemon to create an image from the tar archive of
+// rootfs, the changes are applied to the config of image.
+func (client *APIClient) ImageImport(ctx context.Context, ref, message string, changes []string, reader io.Reader) (*types.ImageInfo, error) {
+	q := url.Values{}
+	q.Set("repo", ref)
+	if message != "" {
+		q.Set("message", message)
+	}
+	for _, change := range changes {
+		q.Add("changes", change)
+	}
+
+	headers := map[string][]string{}
+	headers["Content-Type"] = []string{"application/x-tar"}
+
+	resp, err := client.postRawData(ctx, "/images/import", q, reader, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	imageInfo := &types.ImageInfo{}
+	err = decodeBody(imageInfo, resp.Body)
+	ensureCloseReader(resp)
+
+	return imageInfo, err
+}
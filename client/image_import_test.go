@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+func TestImageImportServerError(t *testing.T) {
+	expectedError := "Server error"
+
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, expectedError)),
+	}
+
+	_, err := client.ImageImport(context.Background(), "foo:bar", "", nil, bytes.NewReader(nil))
+	if err == nil || !strings.Contains(err.Error(), expectedError) {
+		t.Fatalf("expected (%v), got (%v)", expectedError, err)
+	}
+}
+
+func TestImageImportOK(t *testing.T) {
+	expectedURL := "/images/import"
+	expectedChanges := []string{"ENV FOO=bar", "CMD top"}
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+
+		if req.Method != "POST" {
+			return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+		}
+
+		query := req.URL.Query()
+		if got := query.Get("repo"); got != "foo:bar" {
+			return nil, fmt.Errorf("expected repo foo:bar, got %s", got)
+		}
+		if got := query.Get("message"); got != "imported" {
+			return nil, fmt.Errorf("expected message imported, got %s", got)
+		}
+		if got := query["changes"]; !reflect.DeepEqual(got, expectedChanges) {
+			return nil, fmt.Errorf("expected (%v), got %v", expectedChanges, got)
+		}
+
+		data, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if string(data) != "rootfs" {
+			return nil, fmt.Errorf("expected body rootfs, got %s", data)
+		}
+
+		b, err := json.Marshal(types.ImageInfo{ID: "sha256:foo"})
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	imageInfo, err := client.ImageImport(context.Background(), "foo:bar", "imported", expectedChanges, strings.NewReader("rootfs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imageInfo.ID != "sha256:foo" {
+		t.Fatalf("expected image ID sha256:foo, got %s", imageInfo.ID)
+	}
+}
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+)
+
+// ImageListTags requests daemon to list all the tags of repository from registry.
+func (client *APIClient) ImageListTags(ctx context.Context, name, encodedAuth string) ([]string, error) {
+	headers := map[string][]string{}
+	if encodedAuth != "" {
+		headers["X-Registry-Auth"] = []string{encodedAuth}
+	}
+
+	resp, err := client.get(ctx, "/images/"+name+"/tags", nil, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	err = decodeBody(&tags, resp.Body)
+	ensureCloseReader(resp)
+
+	return tags, err
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageListTagsServerError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageListTags(context.Background(), "busybox", "")
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestImageListTags(t *testing.T) {
+	expectedURL := "/images/docker.io/library/busybox/tags"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "GET" {
+			return nil, fmt.Errorf("expected GET method, got %s", req.Method)
+		}
+		if auth := req.Header.Get("X-Registry-Auth"); auth != "auth" {
+			return nil, fmt.Errorf("expected X-Registry-Auth header, got %s", auth)
+		}
+
+		b, err := json.Marshal([]string{"1.28", "latest"})
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	tags, err := client.ImageListTags(context.Background(), "docker.io/library/busybox", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"1.28", "latest"}, tags)
+}
@@ -6,8 +6,9 @@ import (
 	"net/url"
 )
 
-// ImageLoad requests daemon to load an image from tarstream.
-func (client *APIClient) ImageLoad(ctx context.Context, imageName string, reader io.Reader) error {
+// ImageLoad requests daemon to load images from tarstream, and returns the
+// progress of loading.
+func (client *APIClient) ImageLoad(ctx context.Context, imageName string, reader io.Reader) (io.ReadCloser, error) {
 	q := url.Values{}
 	if imageName != "" {
 		q.Set("name", imageName)
@@ -18,9 +19,7 @@ func (client *APIClient) ImageLoad(ctx context.Context, imageName string, reader
 
 	resp, err := client.postRawData(ctx, "/images/load", q, reader, headers)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	ensureCloseReader(resp)
-	return nil
+	return resp.Body, nil
 }
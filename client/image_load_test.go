@@ -17,7 +17,7 @@ func TestImageLoadServerError(t *testing.T) {
 		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, expectedError)),
 	}
 
-	err := client.ImageLoad(context.Background(), "test_image_load_500", nil)
+	_, err := client.ImageLoad(context.Background(), "test_image_load_500", nil)
 	if err == nil || !strings.Contains(err.Error(), expectedError) {
 		t.Fatalf("expected (%v), got (%v)", expectedError, err)
 	}
@@ -50,8 +50,10 @@ func TestImageLoadOK(t *testing.T) {
 		HTTPCli: httpClient,
 	}
 
-	if err := client.ImageLoad(context.Background(), expectedImageName, nil); err != nil {
+	body, err := client.ImageLoad(context.Background(), expectedImageName, nil)
+	if err != nil {
 		t.Fatal(err)
 	}
+	body.Close()
 
 }
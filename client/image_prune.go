@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ImagesPrune requests daemon to delete the images which are not used by any container.
+func (client *APIClient) ImagesPrune(ctx context.Context, filter filters.Args) (*types.ImagePruneResp, error) {
+	query := url.Values{}
+
+	if filter.Len() > 0 {
+		filtersJSON, err := filters.ToParam(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Set("filters", filtersJSON)
+	}
+
+	resp, err := client.post(ctx, "/images/prune", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pruneResp := &types.ImagePruneResp{}
+
+	err = decodeBody(pruneResp, resp.Body)
+	ensureCloseReader(resp)
+
+	return pruneResp, err
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImagesPruneServerError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImagesPrune(context.Background(), filters.NewArgs())
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestImagesPrune(t *testing.T) {
+	expectedURL := "/images/prune"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "POST" {
+			return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+		}
+
+		filter, err := filters.FromParam(req.URL.Query().Get("filters"))
+		if err != nil {
+			return nil, err
+		}
+		if !filter.ExactMatch("dangling", "false") {
+			return nil, fmt.Errorf("expected dangling=false filter, got %v", req.URL.Query().Get("filters"))
+		}
+
+		pruneResp, err := json.Marshal(types.ImagePruneResp{
+			ImagesDeleted: []*types.ImageDeleteResponseItem{
+				{Untagged: "docker.io/library/busybox:latest"},
+				{Deleted: "sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a"},
+			},
+			SpaceReclaimed: 1024,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(pruneResp)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	filter := filters.NewArgs()
+	filter.Add("dangling", "false")
+
+	resp, err := client.ImagesPrune(context.Background(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(resp.ImagesDeleted))
+	assert.Equal(t, int64(1024), resp.SpaceReclaimed)
+}
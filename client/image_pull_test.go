@@ -14,7 +14,7 @@ func TestImagePullServerError(t *testing.T) {
 	client := &APIClient{
 		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
 	}
-	_, err := client.ImagePull(context.Background(), "image_name", "image_tag", "auth")
+	_, err := client.ImagePull(context.Background(), "image_name", "image_tag", "", "auth")
 	if err == nil || !strings.Contains(err.Error(), "Server error") {
 		t.Fatalf("expected a Server Error, got %v", err)
 	}
@@ -24,7 +24,7 @@ func TestImagePullWrongError(t *testing.T) {
 	client := &APIClient{
 		HTTPCli: newMockClient(errorMockResponse(http.StatusNotFound, "Image not found")),
 	}
-	_, err := client.ImagePull(context.Background(), "image_name", "image_tag", "auth")
+	_, err := client.ImagePull(context.Background(), "image_name", "image_tag", "", "auth")
 	if err == nil || !strings.Contains(err.Error(), "Image not found") {
 		t.Fatalf("expected an Image Not Found Error, got %v", err)
 	}
@@ -52,9 +52,30 @@ func TestImagePull(t *testing.T) {
 		HTTPCli: httpClient,
 	}
 
-	_, err := client.ImagePull(context.Background(), "image_name", "image_tag", "auth")
+	_, err := client.ImagePull(context.Background(), "image_name", "image_tag", "", "auth")
 	if err != nil {
 		t.Fatal(err)
 	}
 
 }
+
+func TestImagePullWithPlatform(t *testing.T) {
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if platform := req.URL.Query().Get("platform"); platform != "linux/arm64" {
+			return nil, fmt.Errorf("expected platform linux/arm64, got %s", platform)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	if _, err := client.ImagePull(context.Background(), "image_name", "image_tag", "linux/arm64", ""); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,18 +2,34 @@ package client
 
 import (
 	"context"
+	"net/http"
 	"net/url"
+
+	"github.com/alibaba/pouch/apis/types"
 )
 
-// ImageRemove deletes an image.
-func (client *APIClient) ImageRemove(ctx context.Context, name string, force bool) error {
+// ImageRemove deletes an image, and returns the untagged references and the
+// deleted image.
+func (client *APIClient) ImageRemove(ctx context.Context, name string, force bool) ([]types.ImageDeleteResponseItem, error) {
 	q := url.Values{}
 	if force {
 		q.Set("force", "true")
 	}
 
 	resp, err := client.delete(ctx, "/images/"+name, q, nil)
-	ensureCloseReader(resp)
+	defer ensureCloseReader(resp)
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	// the daemon of old version returns nothing.
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	var items []types.ImageDeleteResponseItem
+	if err := decodeBody(&items, resp.Body); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
@@ -3,18 +3,21 @@ package client
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
 )
 
 func TestImageRemoveNotFoundError(t *testing.T) {
 	client := &APIClient{
 		HTTPCli: newMockClient(errorMockResponse(http.StatusNotFound, "Not Found")),
 	}
-	err := client.ImageRemove(context.Background(), "no network", true)
+	_, err := client.ImageRemove(context.Background(), "no network", true)
 	if err == nil || !strings.Contains(err.Error(), "Not Found") {
 		t.Fatalf("expected a Not Found Error, got %v", err)
 	}
@@ -31,9 +34,17 @@ func TestImageRemove(t *testing.T) {
 			return nil, fmt.Errorf("expected DELETE method, got %s", req.Method)
 		}
 
+		b, err := json.Marshal([]types.ImageDeleteResponseItem{
+			{Untagged: "busybox:latest"},
+			{Deleted: "sha256:8c811b4aec35"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
 		return &http.Response{
-			StatusCode: http.StatusNoContent,
-			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
 		}, nil
 	})
 
@@ -41,8 +52,27 @@ func TestImageRemove(t *testing.T) {
 		HTTPCli: httpClient,
 	}
 
-	err := client.ImageRemove(context.Background(), "image_id", false)
+	items, err := client.ImageRemove(context.Background(), "image_id", false)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if len(items) != 2 || items[0].Untagged != "busybox:latest" || items[1].Deleted != "sha256:8c811b4aec35" {
+		t.Fatalf("unexpected response items: %v", items)
+	}
+}
+
+func TestImageRemoveNoContent(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+
+	items, err := client.ImageRemove(context.Background(), "image_id", false)
+	if err != nil || items != nil {
+		t.Fatalf("expected no items and error, got %v, %v", items, err)
+	}
 }
@@ -6,10 +6,12 @@ import (
 	"net/url"
 )
 
-// ImageSave requests daemon to save an image to a tar archive.
-func (client *APIClient) ImageSave(ctx context.Context, imageName string) (io.ReadCloser, error) {
+// ImageSave requests daemon to save images to a tar archive.
+func (client *APIClient) ImageSave(ctx context.Context, imageNames []string) (io.ReadCloser, error) {
 	q := url.Values{}
-	q.Set("name", imageName)
+	for _, name := range imageNames {
+		q.Add("name", name)
+	}
 
 	resp, err := client.get(ctx, "/images/save", q, nil)
 	if err != nil {
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ImageSearch requests daemon to search images from registry.
+func (client *APIClient) ImageSearch(ctx context.Context, term, registry string, limit int, encodedAuth string) ([]types.SearchResultItem, error) {
+	query := url.Values{}
+	query.Set("term", term)
+	if registry != "" {
+		query.Set("registry", registry)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	headers := map[string][]string{}
+	if encodedAuth != "" {
+		headers["X-Registry-Auth"] = []string{encodedAuth}
+	}
+
+	resp, err := client.get(ctx, "/images/search", query, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []types.SearchResultItem{}
+	err = decodeBody(&results, resp.Body)
+	ensureCloseReader(resp)
+
+	return results, err
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageSearchServerError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageSearch(context.Background(), "nginx", "", 0, "")
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestImageSearch(t *testing.T) {
+	expectedURL := "/images/search"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "GET" {
+			return nil, fmt.Errorf("expected GET method, got %s", req.Method)
+		}
+
+		q := req.URL.Query()
+		if q.Get("term") != "nginx" || q.Get("registry") != "localhost:5000" || q.Get("limit") != "10" {
+			return nil, fmt.Errorf("unexpected query %s", req.URL.RawQuery)
+		}
+		if auth := req.Header.Get("X-Registry-Auth"); auth != "auth" {
+			return nil, fmt.Errorf("expected X-Registry-Auth header, got %s", auth)
+		}
+
+		b, err := json.Marshal([]types.SearchResultItem{
+			{Name: "nginx", StarCount: 10, IsOfficial: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	results, err := client.ImageSearch(context.Background(), "nginx", "localhost:5000", 10, "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []types.SearchResultItem{{Name: "nginx", StarCount: 10, IsOfficial: true}}, results)
+}
@@ -17,6 +17,10 @@ type CommonAPIClient interface {
 	VolumeAPIClient
 	SystemAPIClient
 	NetworkAPIClient
+
+	// ClientVersion returns the api version used by the requests, which is
+	// negotiated with daemon or set by POUCH_API_VERSION.
+	ClientVersion() string
 }
 
 // ContainerAPIClient defines methods of Container client.
@@ -26,21 +30,30 @@ type ContainerAPIClient interface {
 	ContainerStop(ctx context.Context, name, timeout string) error
 	ContainerRemove(ctx context.Context, name string, options *types.ContainerRemoveOptions) error
 	ContainerList(ctx context.Context, option types.ContainerListOptions) ([]*types.Container, error)
-	ContainerAttach(ctx context.Context, name string, stdin bool) (net.Conn, *bufio.Reader, error)
+	ContainersPrune(ctx context.Context, filter filters.Args) (*types.ContainerPruneResp, error)
+	ContainerAttach(ctx context.Context, name string, stdin bool, detachKeys string) (net.Conn, *bufio.Reader, error)
 	ContainerCreateExec(ctx context.Context, name string, config *types.ExecCreateConfig) (*types.ExecCreateResp, error)
 	ContainerStartExec(ctx context.Context, execid string, config *types.ExecStartConfig) (net.Conn, *bufio.Reader, error)
 	ContainerExecInspect(ctx context.Context, execid string) (*types.ContainerExecInspect, error)
+	ContainerExecResize(ctx context.Context, execid, height, width string) error
 	ContainerGet(ctx context.Context, name string) (*types.ContainerJSON, error)
 	ContainerRename(ctx context.Context, id string, name string) error
 	ContainerRestart(ctx context.Context, name string, timeout string) error
+	ContainerKill(ctx context.Context, name, signal string) error
 	ContainerPause(ctx context.Context, name string) error
 	ContainerUnpause(ctx context.Context, name string) error
 	ContainerUpdate(ctx context.Context, name string, config *types.UpdateConfig) error
 	ContainerUpgrade(ctx context.Context, name string, config *types.ContainerUpgradeConfig) error
 	ContainerTop(ctx context.Context, name string, arguments []string) (types.ContainerProcessList, error)
+	ContainerDiff(ctx context.Context, name string) ([]*types.ContainerChangeResponseItem, error)
+	ContainerExport(ctx context.Context, name string) (io.ReadCloser, error)
 	ContainerLogs(ctx context.Context, name string, options types.ContainerLogsOptions) (io.ReadCloser, error)
 	ContainerResize(ctx context.Context, name, height, width string) error
 	ContainerWait(ctx context.Context, name string) (types.ContainerWaitOKBody, error)
+	ContainerWaitNextExit(ctx context.Context, name string) (<-chan types.ContainerWaitOKBody, <-chan error)
+	ContainerStatPath(ctx context.Context, name, path string) (types.ContainerPathStat, error)
+	CopyFromContainer(ctx context.Context, name, path string) (io.ReadCloser, types.ContainerPathStat, error)
+	CopyToContainer(ctx context.Context, name, path string, content io.Reader, noOverwriteDirNonDir bool) error
 	ContainerCheckpointCreate(ctx context.Context, name string, options types.CheckpointCreateOptions) error
 	ContainerCheckpointList(ctx context.Context, name string, options types.CheckpointListOptions) ([]string, error)
 	ContainerCheckpointDelete(ctx context.Context, name string, options types.CheckpointDeleteOptions) error
@@ -52,13 +65,17 @@ type ContainerAPIClient interface {
 type ImageAPIClient interface {
 	ImageList(ctx context.Context, filters filters.Args) ([]types.ImageInfo, error)
 	ImageInspect(ctx context.Context, name string) (types.ImageInfo, error)
-	ImagePull(ctx context.Context, name, tag, encodedAuth string) (io.ReadCloser, error)
-	ImageRemove(ctx context.Context, name string, force bool) error
+	ImagePull(ctx context.Context, name, tag, platform, encodedAuth string) (io.ReadCloser, error)
+	ImageRemove(ctx context.Context, name string, force bool) ([]types.ImageDeleteResponseItem, error)
 	ImageTag(ctx context.Context, image string, tag string) error
-	ImageLoad(ctx context.Context, name string, r io.Reader) error
-	ImageSave(ctx context.Context, imageName string) (io.ReadCloser, error)
+	ImageLoad(ctx context.Context, name string, r io.Reader) (io.ReadCloser, error)
+	ImageSave(ctx context.Context, imageNames []string) (io.ReadCloser, error)
+	ImageImport(ctx context.Context, ref, message string, changes []string, reader io.Reader) (*types.ImageInfo, error)
 	ImageHistory(ctx context.Context, name string) ([]types.HistoryResultItem, error)
 	ImagePush(ctx context.Context, ref, encodedAuth string) (io.ReadCloser, error)
+	ImageListTags(ctx context.Context, name, encodedAuth string) ([]string, error)
+	ImagesPrune(ctx context.Context, filter filters.Args) (*types.ImagePruneResp, error)
+	ImageSearch(ctx context.Context, term, registry string, limit int, encodedAuth string) ([]types.SearchResultItem, error)
 }
 
 // VolumeAPIClient defines methods of Volume client.
@@ -67,6 +84,7 @@ type VolumeAPIClient interface {
 	VolumeRemove(ctx context.Context, name string) error
 	VolumeInspect(ctx context.Context, name string) (*types.VolumeInfo, error)
 	VolumeList(ctx context.Context, filter filters.Args) (*types.VolumeListResp, error)
+	VolumesPrune(ctx context.Context, filter filters.Args) (*types.VolumePruneResp, error)
 }
 
 // SystemAPIClient defines methods of System client.
@@ -77,6 +95,8 @@ type SystemAPIClient interface {
 	RegistryLogin(ctx context.Context, auth *types.AuthConfig) (*types.AuthResponse, error)
 	DaemonUpdate(ctx context.Context, daemonConfig *types.DaemonUpdateConfig) error
 	Events(ctx context.Context, since string, until string, filters filters.Args) (io.ReadCloser, error)
+	SystemDataUsage(ctx context.Context) (*types.DiskUsage, error)
+	SystemGC(ctx context.Context) (*types.SystemGCResp, error)
 }
 
 // NetworkAPIClient defines methods of Network client.
@@ -84,7 +104,8 @@ type NetworkAPIClient interface {
 	NetworkCreate(ctx context.Context, req *types.NetworkCreateConfig) (*types.NetworkCreateResp, error)
 	NetworkRemove(ctx context.Context, networkID string) error
 	NetworkInspect(ctx context.Context, networkID string) (*types.NetworkInspectResp, error)
-	NetworkList(ctx context.Context) ([]types.NetworkResource, error)
+	NetworkList(ctx context.Context, filter filters.Args) ([]types.NetworkResource, error)
 	NetworkConnect(ctx context.Context, network string, req *types.NetworkConnect) error
 	NetworkDisconnect(ctx context.Context, networkID, containerID string, force bool) error
+	NetworksPrune(ctx context.Context, filter filters.Args) (*types.NetworkPruneResp, error)
 }
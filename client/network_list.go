@@ -2,13 +2,25 @@ package client
 
 import (
 	"context"
+	"net/url"
 
+	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
 )
 
-// NetworkList lists all the networks.
-func (client *APIClient) NetworkList(ctx context.Context) ([]types.NetworkResource, error) {
-	resp, err := client.get(ctx, "/networks", nil, nil)
+// NetworkList lists the networks which match the filter.
+func (client *APIClient) NetworkList(ctx context.Context, filter filters.Args) ([]types.NetworkResource, error) {
+	query := url.Values{}
+	if filter.Len() > 0 {
+		filtersJSON, err := filters.ToParam(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Set("filters", filtersJSON)
+	}
+
+	resp, err := client.get(ctx, "/networks", query, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
 
 	"github.com/stretchr/testify/assert"
@@ -19,7 +20,7 @@ func TestNetworkListServerError(t *testing.T) {
 	client := &APIClient{
 		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
 	}
-	_, err := client.NetworkList(context.Background())
+	_, err := client.NetworkList(context.Background(), filters.NewArgs())
 	if err == nil || !strings.Contains(err.Error(), "Server error") {
 		t.Fatalf("expected a Server Error, got %v", err)
 	}
@@ -61,9 +62,35 @@ func TestNetworkList(t *testing.T) {
 		HTTPCli: httpClient,
 	}
 
-	network, err := client.NetworkList(context.Background())
+	network, err := client.NetworkList(context.Background(), filters.NewArgs())
 	if err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, len(network), 2)
 }
+
+func TestNetworkListWithFilter(t *testing.T) {
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		expected := `{"driver":{"bridge":true}}`
+		if got := req.URL.Query().Get("filters"); got != expected {
+			return nil, fmt.Errorf("expected filters %s, got %s", expected, got)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("[]"))),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	filter := filters.NewArgs()
+	filter.Add("driver", "bridge")
+	network, err := client.NetworkList(context.Background(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(network), 0)
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// NetworksPrune requests daemon to delete the user defined networks which are not used by any container.
+func (client *APIClient) NetworksPrune(ctx context.Context, filter filters.Args) (*types.NetworkPruneResp, error) {
+	query := url.Values{}
+
+	if filter.Len() > 0 {
+		filtersJSON, err := filters.ToParam(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Set("filters", filtersJSON)
+	}
+
+	resp, err := client.post(ctx, "/networks/prune", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pruneResp := &types.NetworkPruneResp{}
+
+	err = decodeBody(pruneResp, resp.Body)
+	ensureCloseReader(resp)
+
+	return pruneResp, err
+}
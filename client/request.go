@@ -11,29 +11,13 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
-	"time"
 )
 
-// RespError defines the response error.
-type RespError struct {
-	code int
-	msg  string
-}
-
-// Error implements the error interface.
-func (e RespError) Error() string {
-	return e.msg
-}
-
-// Code returns the response  code
-func (e RespError) Code() int {
-	return e.code
-}
-
 // Response wraps the http.Response and other states.
 type Response struct {
 	StatusCode int
 	Status     string
+	Header     http.Header
 	Body       io.ReadCloser
 }
 
@@ -54,6 +38,14 @@ func (client *APIClient) postRawData(ctx context.Context, path string, query url
 	return client.sendRequest(ctx, "POST", path, query, data, headers)
 }
 
+func (client *APIClient) putRawData(ctx context.Context, path string, query url.Values, data io.Reader, headers map[string][]string) (*Response, error) {
+	return client.sendRequest(ctx, "PUT", path, query, data, headers)
+}
+
+func (client *APIClient) head(ctx context.Context, path string, query url.Values, headers map[string][]string) (*Response, error) {
+	return client.sendRequest(ctx, "HEAD", path, query, nil, headers)
+}
+
 func (client *APIClient) delete(ctx context.Context, path string, query url.Values, headers map[string][]string) (*Response, error) {
 	return client.sendRequest(ctx, "DELETE", path, query, nil, headers)
 }
@@ -64,6 +56,7 @@ func (client *APIClient) hijack(ctx context.Context, path string, query url.Valu
 		return nil, nil, err
 	}
 
+	client.negotiateVersion(ctx)
 	req, err := client.newRequest("POST", path, query, body, header)
 	if err != nil {
 		return nil, nil, err
@@ -73,21 +66,32 @@ func (client *APIClient) hijack(ctx context.Context, path string, query url.Valu
 	req.Header.Set("Upgrade", "tcp")
 
 	req.Host = client.addr
-	conn, err := net.DialTimeout(client.proto, client.addr, defaultTimeout)
+	conn, err := client.dial(ctx)
 	if err != nil {
-		return nil, nil, err
-	}
-
-	if tcpConn, ok := conn.(*net.TCPConn); ok {
-		tcpConn.SetKeepAlive(true)
-		tcpConn.SetKeepAlivePeriod(30 * time.Second)
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		return nil, nil, connectionError{client.connError(err)}
 	}
 
 	clientconn := httputil.NewClientConn(conn, nil)
 	defer clientconn.Close()
 
-	if _, err := clientconn.Do(req); err != nil {
-		return nil, nil, err
+	resp, err := doUntilDone(ctx, conn, func() (*http.Response, error) {
+		return clientconn.Do(req)
+	})
+	if err != nil {
+		return nil, nil, connectionError{client.connError(err)}
+	}
+
+	// the connection isn't hijacked by server if the request fails.
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, client.connError(newRespError(resp.StatusCode, data))
 	}
 
 	rwc, br := clientconn.Hijack()
@@ -110,14 +114,20 @@ func (client *APIClient) newRequest(method, path string, query url.Values, body
 }
 
 func (client *APIClient) sendRequest(ctx context.Context, method, path string, query url.Values, body io.Reader, headers map[string][]string) (*Response, error) {
+	client.negotiateVersion(ctx)
 	req, err := client.newRequest(method, path, query, body, headers)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := cancellableDo(ctx, client.HTTPCli, req)
+	// the request is cancelled once the context is done, including reading
+	// the body of the streaming response, such as pull progress and events.
+	resp, err := client.HTTPCli.Do(req.WithContext(ctx))
 	if err != nil {
-		return nil, err
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, connectionError{client.connError(err)}
 	}
 
 	if resp.StatusCode >= 400 {
@@ -127,41 +137,39 @@ func (client *APIClient) sendRequest(ctx context.Context, method, path string, q
 			return nil, err
 		}
 
-		return nil, RespError{code: resp.StatusCode, msg: string(data)}
+		return nil, client.connError(newRespError(resp.StatusCode, data))
 	}
 
 	return &Response{
 		StatusCode: resp.StatusCode,
 		Status:     resp.Status,
+		Header:     resp.Header,
 		Body:       resp.Body,
 	}, nil
 }
 
-func cancellableDo(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
-	type contextResp struct {
-		response *http.Response
-		err      error
-	}
-
-	ctxResp := make(chan contextResp, 1)
+// doUntilDone calls do to send the request on the connection, which is
+// closed to interrupt the request if the context is done before the response.
+func doUntilDone(ctx context.Context, conn net.Conn, do func() (*http.Response, error)) (*http.Response, error) {
+	stop, stopped := make(chan struct{}), make(chan struct{})
 	go func() {
-		resp, err := client.Do(req)
-		ctxResp <- contextResp{
-			response: resp,
-			err:      err,
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
 		}
 	}()
 
-	select {
-	case <-ctx.Done():
-		tr := client.Transport.(*http.Transport)
-		tr.CancelRequest(req)
-		<-ctxResp
-		return nil, ctx.Err()
+	resp, err := do()
+	close(stop)
+	<-stopped
 
-	case resp := <-ctxResp:
-		return resp.response, resp.err
+	if ctx.Err() != nil {
+		conn.Close()
+		return nil, ctx.Err()
 	}
+	return resp, err
 }
 
 func objectToJSONStream(obj interface{}) (io.Reader, error) {
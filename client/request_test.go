@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestDeadline(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	cli, err := NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), TLSConfig{})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = cli.ContainerGet(ctx, "foo")
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestStreamingResponseCancelled(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// write the first progress and never end the stream.
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("{}\n"))
+		w.(http.Flusher).Flush()
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	cli, err := NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), TLSConfig{})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	body, err := cli.ImagePull(ctx, "busybox", "latest", "", "")
+	assert.NoError(t, err)
+	defer body.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := ioutil.ReadAll(body)
+		errCh <- err
+	}()
+
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reading the pull progress should stop after cancelled")
+	}
+}
+
+func TestHijackCancelled(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// never respond the upgrade.
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	cli, err := NewAPIClient("tcp://"+strings.TrimPrefix(server.URL, "http://"), TLSConfig{})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, _, err = cli.ContainerAttach(ctx, "foo", false, "")
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
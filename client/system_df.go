@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// SystemDataUsage requests daemon for the disk space used by images, containers and volumes.
+func (client *APIClient) SystemDataUsage(ctx context.Context) (*types.DiskUsage, error) {
+	resp, err := client.get(ctx, "/system/df", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &types.DiskUsage{}
+	err = decodeBody(usage, resp.Body)
+	ensureCloseReader(resp)
+
+	return usage, err
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemDataUsageError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.SystemDataUsage(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestSystemDataUsage(t *testing.T) {
+	expectedURL := "/system/df"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "GET" {
+			return nil, fmt.Errorf("expected GET method, got %s", req.Method)
+		}
+
+		b, err := json.Marshal(types.DiskUsage{
+			LayersSize: 2048,
+			Images:     []*types.ImageDiskUsage{{ID: "sha256:abcd", Size: 2048, SharedSize: 1024, Containers: 1}},
+			Containers: []*types.ContainerDiskUsage{{ID: "1234", SizeRw: 512}},
+			Volumes:    []*types.VolumeDiskUsage{{Name: "v1", Size: -1}},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	usage, err := client.SystemDataUsage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(2048), usage.LayersSize)
+	assert.Equal(t, int64(1024), usage.Images[0].SharedSize)
+	assert.Equal(t, int64(512), usage.Containers[0].SizeRw)
+	assert.Equal(t, int64(-1), usage.Volumes[0].Size)
+}
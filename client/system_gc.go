@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// SystemGC requests daemon to trigger the garbage collection of containerd.
+func (client *APIClient) SystemGC(ctx context.Context) (*types.SystemGCResp, error) {
+	resp, err := client.post(ctx, "/system/gc", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	gcResp := &types.SystemGCResp{}
+	err = decodeBody(gcResp, resp.Body)
+	ensureCloseReader(resp)
+
+	return gcResp, err
+}
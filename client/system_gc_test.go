@@ -0,0 +1,63 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemGCError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusServiceUnavailable, "containerd unavailable")),
+	}
+	_, err := client.SystemGC(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "containerd unavailable") {
+		t.Fatalf("expected a containerd unavailable error, got %v", err)
+	}
+}
+
+func TestSystemGC(t *testing.T) {
+	expectedURL := "/system/gc"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "POST" {
+			return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+		}
+
+		b, err := json.Marshal(types.SystemGCResp{
+			BlobsDeleted:   3,
+			SpaceReclaimed: 4096,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	resp, err := client.SystemGC(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(3), resp.BlobsDeleted)
+	assert.Equal(t, int64(4096), resp.SpaceReclaimed)
+}
@@ -24,7 +24,8 @@ func TestSystemPing(t *testing.T) {
 	expectedURL := "/_ping"
 
 	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
-		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+		// the path is versioned after the negotiation.
+		if !strings.HasSuffix(req.URL.Path, expectedURL) {
 			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
 		}
 
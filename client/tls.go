@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/httputils"
+)
+
+// dial connects to daemon for the hijacked connection over the same
+// transport as the requests, which is wrapped by TLS if the API client uses
+// TLS.
+func (client *APIClient) dial(ctx context.Context) (net.Conn, error) {
+	addr := strings.TrimSuffix(client.addr, "/")
+
+	conn, err := httputils.Dial(ctx, client.proto, addr, defaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		tcpConn.SetKeepAlive(true)
+		tcpConn.SetKeepAlivePeriod(30 * time.Second)
+	}
+
+	if client.tlsConfig == nil {
+		return conn, nil
+	}
+
+	config := client.tlsConfig.Clone()
+	if config.ServerName == "" {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			config.ServerName = host
+		}
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// connError returns the error of connecting to daemon, the raw error of TLS
+// handshake or the response of TLS server to plain HTTP request is replaced
+// by the hint of the mismatched TLS settings.
+func (client *APIClient) connError(err error) error {
+	if err == nil || httputils.IsLocalScheme(client.proto) {
+		return err
+	}
+
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "malformed HTTP response"),
+		strings.Contains(msg, "malformed HTTP status code"),
+		strings.Contains(msg, "HTTP request to an HTTPS server"):
+		return fmt.Errorf("daemon at %s requires TLS, use --tls or --tlsverify: %v", client.addr, err)
+	case strings.Contains(msg, "server gave HTTP response to HTTPS client"),
+		strings.Contains(msg, "first record does not look like a TLS handshake"):
+		return fmt.Errorf("daemon at %s doesn't use TLS, remove --tls and --tlsverify: %v", client.addr, err)
+	case strings.Contains(msg, "tls: bad certificate"),
+		strings.Contains(msg, "tls: certificate required"),
+		strings.Contains(msg, "tls: unknown certificate authority"):
+		return fmt.Errorf("daemon at %s requires TLS client certs signed by its CA, set --tlscert and --tlskey: %v", client.addr, err)
+	case strings.Contains(msg, "certificate signed by unknown authority"):
+		return fmt.Errorf("failed to verify the certificate of daemon at %s, set --tlscacert to the CA of daemon: %v", client.addr, err)
+	}
+	return err
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/httputils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestCert signs a certificate by the parent, and writes the cert and
+// key in PEM into dir, the certificate is self-signed if parent is nil.
+func writeTestCert(t *testing.T, dir, name string, tmpl *x509.Certificate, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+"-cert.pem"), certPEM, 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+"-key.pem"), keyPEM, 0600))
+	return cert, key
+}
+
+// newTestCerts generates the CA, and the server and client certificates
+// signed by the CA into dir.
+func newTestCerts(t *testing.T, dir string) {
+	notAfter := time.Now().Add(time.Hour)
+	ca, caKey := writeTestCert(t, dir, "ca", &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "pouch-ca"},
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	writeTestCert(t, dir, "server", &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "pouchd"},
+		NotAfter:     notAfter,
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+
+	writeTestCert(t, dir, "client", &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "pouch"},
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+}
+
+// testTLSHandler returns OK, and writes hello into the hijacked connection
+// of attach.
+func testTLSHandler(w http.ResponseWriter, req *http.Request) {
+	if !strings.HasSuffix(req.URL.Path, "/attach") {
+		w.Write([]byte("OK"))
+		return
+	}
+
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.Write([]byte("HTTP/1.1 101 UPGRADED\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\nhello\n"))
+}
+
+func TestTLSConnection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "client-tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	newTestCerts(t, dir)
+
+	file := func(name string) string { return filepath.Join(dir, name+".pem") }
+
+	serverTLS, err := httputils.GenTLSConfig(file("server-key"), file("server-cert"), file("ca-cert"))
+	assert.NoError(t, err)
+	serverTLS.ClientAuth = tls.RequireAndVerifyClientCert
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(testTLSHandler))
+	server.TLS = serverTLS
+	server.StartTLS()
+	defer server.Close()
+	host := "tcp://" + strings.TrimPrefix(server.URL, "https://")
+
+	plainServer := httptest.NewServer(http.HandlerFunc(testTLSHandler))
+	defer plainServer.Close()
+	plainHost := "tcp://" + strings.TrimPrefix(plainServer.URL, "http://")
+
+	mutual := TLSConfig{CA: file("ca-cert"), Cert: file("client-cert"), Key: file("client-key"), VerifyRemote: true}
+
+	// the mutual TLS works for both request and hijacked connection.
+	cli, err := NewAPIClient(host, mutual)
+	assert.NoError(t, err)
+	resp, err := cli.(*APIClient).get(context.Background(), "/_ping", nil, nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	conn, br, err := cli.(*APIClient).hijack(context.Background(), "/containers/foo/attach", nil, nil, nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+	line, err := bufio.NewReader(br).ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "hello\n", line)
+
+	for _, tc := range []struct {
+		host string
+		tls  TLSConfig
+		err  string
+	}{
+		{host: host, tls: TLSConfig{}, err: "requires TLS, use --tls or --tlsverify"},
+		{host: host, tls: TLSConfig{CA: file("ca-cert"), VerifyRemote: true}, err: "requires TLS client certs"},
+		{host: host, tls: TLSConfig{Cert: file("client-cert"), Key: file("client-key"), VerifyRemote: true}, err: "set --tlscacert"},
+		{host: plainHost, tls: mutual, err: "doesn't use TLS"},
+	} {
+		cli, err := NewAPIClient(tc.host, tc.tls)
+		assert.NoError(t, err)
+
+		_, err = cli.(*APIClient).get(context.Background(), "/_ping", nil, nil)
+		assert.Error(t, err, "%+v", tc.tls)
+		if err != nil {
+			assert.Contains(t, err.Error(), tc.err, "%+v", tc.tls)
+		}
+	}
+
+	// the invalid key pair is reported when the client is created.
+	_, err = NewAPIClient(host, TLSConfig{Cert: file("client-cert"), Key: file("nonexistent")})
+	assert.Error(t, err)
+}
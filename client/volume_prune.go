@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// VolumesPrune requests daemon to delete the volumes which are not used by any container.
+func (client *APIClient) VolumesPrune(ctx context.Context, filter filters.Args) (*types.VolumePruneResp, error) {
+	query := url.Values{}
+
+	if filter.Len() > 0 {
+		filtersJSON, err := filters.ToParam(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Set("filters", filtersJSON)
+	}
+
+	resp, err := client.post(ctx, "/volumes/prune", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pruneResp := &types.VolumePruneResp{}
+
+	err = decodeBody(pruneResp, resp.Body)
+	ensureCloseReader(resp)
+
+	return pruneResp, err
+}
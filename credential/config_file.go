@@ -1,13 +1,114 @@
 package credential
 
-import "github.com/alibaba/pouch/apis/types"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/alibaba/pouch/apis/types"
+)
 
 var (
 	defaultRegistry = "docker.io"
 	configFileName  = ".pouch/config.json"
 )
 
+// EnvConfig is the environment variable of the path of config file, which
+// overrides ~/.pouch/config.json.
+const EnvConfig = "POUCH_CONFIG"
+
 // ConfigFile defines configs that file needs keep.
 type ConfigFile struct {
 	AuthConfigs map[string]types.AuthConfig `json:"auths"`
+
+	// CredentialsStore is the default credential helper for all registries,
+	// such as osxkeychain which runs docker-credential-osxkeychain.
+	CredentialsStore string `json:"credsStore,omitempty"`
+
+	// CredentialHelpers is the credential helper for the specific registry,
+	// which takes precedence over CredentialsStore.
+	CredentialHelpers map[string]string `json:"credHelpers,omitempty"`
+
+	// DefaultRegistry is the default registry of CLI, which is used to
+	// expand the image name without registry. It takes precedence over
+	// the default registry of daemon.
+	DefaultRegistry string `json:"defaultRegistry,omitempty"`
+
+	// DefaultRegistryNamespace is the default namespace used in the
+	// DefaultRegistry, default library.
+	DefaultRegistryNamespace string `json:"defaultRegistryNamespace,omitempty"`
+
+	// Host is the default address of daemon, which is used if neither
+	// --host nor POUCH_HOST is set.
+	Host string `json:"host,omitempty"`
+
+	// PsFormat is the default template of ps, which is used if --format
+	// isn't set.
+	PsFormat string `json:"psFormat,omitempty"`
+
+	// ImagesFormat is the default template of images, which is used if
+	// --format isn't set.
+	ImagesFormat string `json:"imagesFormat,omitempty"`
+
+	// DetachKeys is the default key sequence for detaching a container,
+	// which is used if --detach-keys isn't set.
+	DetachKeys string `json:"detachKeys,omitempty"`
+}
+
+// credentialHelper returns the name of credential helper for the registry,
+// and empty string is returned if the credential is stored in file.
+func (cf *ConfigFile) credentialHelper(serverAddress string) string {
+	if cf == nil {
+		return ""
+	}
+
+	// the key of credential helpers may be the url of registry.
+	serverAddress = normalizeServerAddress(serverAddress)
+	for addr, helper := range cf.CredentialHelpers {
+		if normalizeServerAddress(addr) == serverAddress && helper != "" {
+			return helper
+		}
+	}
+	return cf.CredentialsStore
+}
+
+// configFilePath returns the path of config file, which is set by
+// POUCH_CONFIG or in home directory.
+func configFilePath() string {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path
+	}
+	return filepath.Join(homedir(), configFileName)
+}
+
+// Load loads the config file, the empty config is returned if the file
+// doesn't exist. The invalid file is reported with the byte offset of the
+// error.
+func Load() (*ConfigFile, error) {
+	fileName := configFilePath()
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ConfigFile{}, nil
+		}
+		return nil, fmt.Errorf("failed to read config file %s: %v", fileName, err)
+	}
+
+	var configFile ConfigFile
+	if len(bytes.TrimSpace(data)) == 0 {
+		return &configFile, nil
+	}
+	if err := json.Unmarshal(data, &configFile); err != nil {
+		switch e := err.(type) {
+		case *json.SyntaxError:
+			return nil, fmt.Errorf("invalid config file %s at byte offset %d: %v", fileName, e.Offset, err)
+		case *json.UnmarshalTypeError:
+			return nil, fmt.Errorf("invalid config file %s at byte offset %d: %v", fileName, e.Offset, err)
+		}
+		return nil, fmt.Errorf("invalid config file %s: %v", fileName, err)
+	}
+	return &configFile, nil
 }
@@ -0,0 +1,57 @@
+package credential
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credential-config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "config.json")
+	os.Setenv(EnvConfig, fileName)
+	defer os.Unsetenv(EnvConfig)
+
+	// the empty config is used if the file doesn't exist
+	configFile, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, &ConfigFile{}, configFile)
+
+	data := []byte(`{"host": "tcp://10.0.0.5:2377", "psFormat": "{{.Names}}", "detachKeys": "ctrl-x,x"}`)
+	assert.NoError(t, ioutil.WriteFile(fileName, data, 0644))
+	configFile, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "tcp://10.0.0.5:2377", configFile.Host)
+	assert.Equal(t, "{{.Names}}", configFile.PsFormat)
+	assert.Equal(t, "ctrl-x,x", configFile.DetachKeys)
+
+	// the defaults are kept and the file is only readable by user after login
+	assert.NoError(t, Save(&types.AuthConfig{Username: "user", Password: "secret", ServerAddress: "reg.abc.com"}))
+	configFile, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "tcp://10.0.0.5:2377", configFile.Host)
+	assert.Contains(t, configFile.AuthConfigs, "reg.abc.com")
+	info, err := os.Stat(fileName)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	for content, msg := range map[string]string{
+		`{"host": "tcp://10.0.0.5:2377",}`: "at byte offset 32",
+		`{"psFormat": 1}`:                  "at byte offset 14",
+	} {
+		assert.NoError(t, ioutil.WriteFile(fileName, []byte(content), 0600))
+		_, err = Load()
+		if assert.Error(t, err, content) {
+			assert.Contains(t, err.Error(), fileName)
+			assert.Contains(t, err.Error(), msg)
+		}
+	}
+}
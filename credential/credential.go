@@ -4,28 +4,50 @@ import "github.com/alibaba/pouch/apis/types"
 
 // Save saves a registry credential into a credential store.
 func Save(authConfig *types.AuthConfig) error {
-	s := loadCredentialStore()
+	s, err := loadCredentialStore(authConfig.ServerAddress)
+	if err != nil {
+		return err
+	}
 	return s.Save(authConfig)
 }
 
 // Get gets a registry credential from a credential store.
 func Get(serverAddress string) (types.AuthConfig, error) {
-	s := loadCredentialStore()
+	s, err := loadCredentialStore(serverAddress)
+	if err != nil {
+		return types.AuthConfig{}, err
+	}
 	return s.Get(serverAddress)
 }
 
 // Delete deletes a registry credential from a credential store.
 func Delete(serverAddress string) error {
-	s := loadCredentialStore()
+	s, err := loadCredentialStore(serverAddress)
+	if err != nil {
+		return err
+	}
 	return s.Delete(serverAddress)
 }
 
 // Exist determines whether a specified credential is exist in a credential store.
 func Exist(serverAddress string) bool {
-	s := loadCredentialStore()
+	s, err := loadCredentialStore(serverAddress)
+	if err != nil {
+		return false
+	}
 	return s.Exist(serverAddress)
 }
 
-func loadCredentialStore() Store {
-	return newFileStore()
+// loadCredentialStore returns the store of the registry. The credential
+// helper is used if it is configured by credsStore or credHelpers.
+func loadCredentialStore(serverAddress string) (Store, error) {
+	configFile, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if helper := configFile.credentialHelper(serverAddress); helper != "" {
+		return newNativeStore(newShellProgram(helper)), nil
+	}
+	return newFileStore(configFilePath(), configFile), nil
 }
@@ -13,24 +13,11 @@ type fileStore struct {
 	fileName   string
 }
 
-func newFileStore() Store {
-	fs := &fileStore{
-		fileName: filepath.Join(homedir(), configFileName),
+func newFileStore(fileName string, configFile *ConfigFile) Store {
+	return &fileStore{
+		configFile: configFile,
+		fileName:   fileName,
 	}
-
-	fd, err := os.Open(fs.fileName)
-	if err != nil {
-		return fs
-	}
-	defer fd.Close()
-
-	var configFile ConfigFile
-	err = json.NewDecoder(fd).Decode(&configFile)
-	if err == nil {
-		fs.configFile = &configFile
-	}
-
-	return fs
 }
 
 // Save implements Store interface.
@@ -41,6 +28,11 @@ func (fs *fileStore) Save(authConfig *types.AuthConfig) error {
 		}
 	}
 
+	// the config file may only contain credential helpers.
+	if fs.configFile.AuthConfigs == nil {
+		fs.configFile.AuthConfigs = make(map[string]types.AuthConfig)
+	}
+
 	encodedAuth := encodeAuth(authConfig.Username, authConfig.Password)
 	if encodedAuth == "" {
 		return nil
@@ -131,6 +123,11 @@ func (fs *fileStore) update() error {
 	}
 	defer fd.Close()
 
+	// the file created by the old version may be readable by others.
+	if err := fd.Chmod(0600); err != nil {
+		return err
+	}
+
 	data, err := json.MarshalIndent(fs.configFile, "", "    ")
 	if err != nil {
 		return err
@@ -65,3 +65,12 @@ func convertHost(addr string) string {
 	splits := strings.SplitN(addr, "/", 2)
 	return splits[0]
 }
+
+// normalizeServerAddress returns the host of registry which is used as the
+// key of credential, and the default registry is used if it is empty.
+func normalizeServerAddress(addr string) string {
+	if addr == "" {
+		return defaultRegistry
+	}
+	return convertHost(addr)
+}
@@ -0,0 +1,149 @@
+package credential
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+const (
+	// helperPrefix is the prefix of credential helper executable, which is
+	// compatible with docker credential helpers.
+	helperPrefix = "docker-credential-"
+
+	// errCredentialsNotFoundMessage is returned by the helper if there is
+	// no credential for the registry.
+	errCredentialsNotFoundMessage = "credentials not found in native keychain"
+
+	// tokenUsername is returned by the helper as username if the secret is
+	// identity token.
+	tokenUsername = "<token>"
+)
+
+// helperCredential is the credential exchanged with credential helper.
+type helperCredential struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// helperProgram runs the action of credential helper, such as get, store
+// and erase. The input is passed by stdin and the output is read from stdout.
+type helperProgram interface {
+	Run(action string, input []byte) ([]byte, error)
+}
+
+// shellProgram runs the helper executable in $PATH.
+type shellProgram struct {
+	name string
+}
+
+// newShellProgram returns the program of credential helper, such as
+// osxkeychain which runs docker-credential-osxkeychain.
+func newShellProgram(helper string) helperProgram {
+	return &shellProgram{name: helperPrefix + helper}
+}
+
+// Run implements helperProgram interface.
+func (p *shellProgram) Run(action string, input []byte) ([]byte, error) {
+	cmd := exec.Command(p.name, action)
+	cmd.Stdin = bytes.NewReader(input)
+
+	out, err := cmd.Output()
+	if err == nil {
+		return out, nil
+	}
+
+	if execErr, ok := err.(*exec.Error); ok && execErr.Err == exec.ErrNotFound {
+		return nil, fmt.Errorf("credential helper %s is not found in $PATH", p.name)
+	}
+
+	// the helper writes the error message into stdout.
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return nil, errors.New(msg)
+	}
+	return nil, fmt.Errorf("failed to run credential helper %s %s: %v", p.name, action, err)
+}
+
+// nativeStore stores the credential by the credential helper.
+type nativeStore struct {
+	program helperProgram
+}
+
+func newNativeStore(program helperProgram) Store {
+	return &nativeStore{program: program}
+}
+
+// Save implements Store interface.
+func (ns *nativeStore) Save(authConfig *types.AuthConfig) error {
+	if authConfig.Username == "" || authConfig.Password == "" {
+		return nil
+	}
+
+	input, err := json.Marshal(helperCredential{
+		ServerURL: normalizeServerAddress(authConfig.ServerAddress),
+		Username:  authConfig.Username,
+		Secret:    authConfig.Password,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = ns.program.Run("store", input)
+	return err
+}
+
+// Get implements Store interface.
+func (ns *nativeStore) Get(serverAddress string) (types.AuthConfig, error) {
+	serverAddress = normalizeServerAddress(serverAddress)
+
+	out, err := ns.program.Run("get", []byte(serverAddress))
+	if err != nil {
+		if isCredentialsNotFound(err) {
+			return types.AuthConfig{}, nil
+		}
+		return types.AuthConfig{}, err
+	}
+
+	var cred helperCredential
+	if err := json.Unmarshal(out, &cred); err != nil {
+		return types.AuthConfig{}, fmt.Errorf("failed to decode credential from helper: %v", err)
+	}
+
+	authConfig := types.AuthConfig{ServerAddress: serverAddress}
+	if cred.Username == tokenUsername {
+		authConfig.IdentityToken = cred.Secret
+	} else {
+		authConfig.Username = cred.Username
+		authConfig.Password = cred.Secret
+	}
+	return authConfig, nil
+}
+
+// Delete implements Store interface.
+func (ns *nativeStore) Delete(serverAddress string) error {
+	_, err := ns.program.Run("erase", []byte(normalizeServerAddress(serverAddress)))
+	if err != nil && isCredentialsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Exist implements Store interface.
+func (ns *nativeStore) Exist(serverAddress string) bool {
+	authConfig, err := ns.Get(serverAddress)
+	if err != nil {
+		return false
+	}
+	return authConfig.Username != "" || authConfig.IdentityToken != ""
+}
+
+// isCredentialsNotFound returns true if the helper doesn't have the credential.
+func isCredentialsNotFound(err error) bool {
+	return strings.Contains(err.Error(), errCredentialsNotFoundMessage)
+}
@@ -0,0 +1,169 @@
+package credential
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProgram implements the protocol of credential helper in memory.
+type fakeProgram struct {
+	creds map[string]helperCredential
+}
+
+func (p *fakeProgram) Run(action string, input []byte) ([]byte, error) {
+	switch action {
+	case "store":
+		var cred helperCredential
+		if err := json.Unmarshal(input, &cred); err != nil {
+			return nil, err
+		}
+		p.creds[cred.ServerURL] = cred
+		return nil, nil
+	case "get":
+		cred, ok := p.creds[string(input)]
+		if !ok {
+			return nil, errors.New(errCredentialsNotFoundMessage)
+		}
+		return json.Marshal(cred)
+	case "erase":
+		if _, ok := p.creds[string(input)]; !ok {
+			return nil, errors.New(errCredentialsNotFoundMessage)
+		}
+		delete(p.creds, string(input))
+		return nil, nil
+	}
+	return nil, errors.New("unknown action " + action)
+}
+
+func TestNativeStore(t *testing.T) {
+	program := &fakeProgram{creds: make(map[string]helperCredential)}
+	s := newNativeStore(program)
+
+	authConfig, err := s.Get("reg.abc.com")
+	assert.NoError(t, err)
+	assert.Equal(t, types.AuthConfig{}, authConfig)
+	assert.False(t, s.Exist("reg.abc.com"))
+
+	assert.NoError(t, s.Save(&types.AuthConfig{
+		Username:      "user",
+		Password:      "secret",
+		ServerAddress: "https://reg.abc.com/v2/",
+	}))
+	assert.Equal(t, "user", program.creds["reg.abc.com"].Username)
+
+	authConfig, err = s.Get("reg.abc.com")
+	assert.NoError(t, err)
+	assert.Equal(t, types.AuthConfig{Username: "user", Password: "secret", ServerAddress: "reg.abc.com"}, authConfig)
+	assert.True(t, s.Exist("reg.abc.com"))
+
+	// the identity token is returned with the special username
+	program.creds["token.abc.com"] = helperCredential{ServerURL: "token.abc.com", Username: tokenUsername, Secret: "token"}
+	authConfig, err = s.Get("token.abc.com")
+	assert.NoError(t, err)
+	assert.Equal(t, types.AuthConfig{IdentityToken: "token", ServerAddress: "token.abc.com"}, authConfig)
+
+	assert.NoError(t, s.Delete("reg.abc.com"))
+	assert.False(t, s.Exist("reg.abc.com"))
+	assert.NoError(t, s.Delete("reg.abc.com"))
+}
+
+func TestShellProgram(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credential-helper")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	oldPath := os.Getenv("PATH")
+	os.Setenv("PATH", dir)
+	defer os.Setenv("PATH", oldPath)
+
+	// the missing helper should be reported with the executable name
+	_, err = newShellProgram("missing").Run("get", []byte("reg.abc.com"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "docker-credential-missing is not found")
+
+	script := `#!/bin/sh
+read server
+if [ "$1" = "get" ] && [ "$server" = "reg.abc.com" ]; then
+	echo '{"ServerURL":"reg.abc.com","Username":"user","Secret":"secret"}'
+	exit 0
+fi
+echo "credentials not found in native keychain"
+exit 1
+`
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "docker-credential-fake"), []byte(script), 0755))
+
+	s := newNativeStore(newShellProgram("fake"))
+	authConfig, err := s.Get("reg.abc.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "user", authConfig.Username)
+	assert.Equal(t, "secret", authConfig.Password)
+
+	authConfig, err = s.Get("other.abc.com")
+	assert.NoError(t, err)
+	assert.Equal(t, types.AuthConfig{}, authConfig)
+}
+
+func TestLoadCredentialStore(t *testing.T) {
+	home, err := ioutil.TempDir("", "credential-home")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", home)
+	defer os.Setenv("HOME", oldHome)
+
+	// the file store is used without config file
+	s, err := loadCredentialStore("reg.abc.com")
+	assert.NoError(t, err)
+	_, ok := s.(*fileStore)
+	assert.True(t, ok)
+
+	data := []byte(`{"credsStore": "osxkeychain", "credHelpers": {"https://ecr.abc.com": "ecr-login"}}`)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(configFilePath()), 0700))
+	assert.NoError(t, ioutil.WriteFile(configFilePath(), data, 0600))
+
+	for addr, helper := range map[string]string{
+		"reg.abc.com": "docker-credential-osxkeychain",
+		"":            "docker-credential-osxkeychain",
+		"ecr.abc.com": "docker-credential-ecr-login",
+	} {
+		s, err := loadCredentialStore(addr)
+		assert.NoError(t, err, addr)
+		if s, ok := s.(*nativeStore); assert.True(t, ok, addr) {
+			assert.Equal(t, helper, s.program.(*shellProgram).name, addr)
+		}
+	}
+}
+
+func TestFileStoreKeepsHelpers(t *testing.T) {
+	home, err := ioutil.TempDir("", "credential-home")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", home)
+	defer os.Setenv("HOME", oldHome)
+
+	data := []byte(`{"credHelpers": {"ecr.abc.com": "ecr-login"}}`)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(configFilePath()), 0700))
+	assert.NoError(t, ioutil.WriteFile(configFilePath(), data, 0600))
+
+	// the registry without helper is still stored in file
+	assert.NoError(t, Save(&types.AuthConfig{Username: "user", Password: "secret", ServerAddress: "reg.abc.com"}))
+
+	authConfig, err := Get("reg.abc.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "user", authConfig.Username)
+
+	configFile, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ecr.abc.com": "ecr-login"}, configFile.CredentialHelpers)
+}
@@ -1331,7 +1331,7 @@ func (c *CriManager) PullImage(ctx context.Context, r *runtime.PullImageRequest)
 		authConfig.RegistryToken = r.Auth.RegistryToken
 	}
 
-	if err := c.ImageMgr.PullImage(ctx, imageRef, authConfig, bytes.NewBuffer([]byte{})); err != nil {
+	if err := c.ImageMgr.PullImage(ctx, imageRef, "", authConfig, bytes.NewBuffer([]byte{})); err != nil {
 		return nil, err
 	}
 
@@ -1355,7 +1355,7 @@ func (c *CriManager) RemoveImage(ctx context.Context, r *runtime.RemoveImageRequ
 
 	imageRef := r.GetImage().GetImage()
 
-	if err := c.ImageMgr.RemoveImage(ctx, imageRef, false); err != nil {
+	if _, err := c.ImageMgr.RemoveImage(ctx, imageRef, false); err != nil {
 		if errtypes.IsNotfound(err) {
 			// Now we just return empty if the ErrorNotFound occurred.
 			return &runtime.RemoveImageResponse{}, nil
@@ -992,7 +992,7 @@ func (c *CriManager) ensureSandboxImageExists(ctx context.Context, imageRef stri
 		return nil
 	}
 	if errtypes.IsNotfound(err) {
-		err = c.ImageMgr.PullImage(ctx, imageRef, nil, bytes.NewBuffer([]byte{}))
+		err = c.ImageMgr.PullImage(ctx, imageRef, "", nil, bytes.NewBuffer([]byte{}))
 		if err != nil {
 			return fmt.Errorf("failed to pull sandbox image %q: %v", imageRef, err)
 		}
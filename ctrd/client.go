@@ -3,7 +3,9 @@ package ctrd
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -47,6 +49,15 @@ type Client struct {
 	// insecureRegistries stores the insecure registries
 	insecureRegistries []string
 
+	// registryMirrors stores the mirrors of docker hub
+	registryMirrors []*url.URL
+
+	// pullRetry describes how to retry the failed fetch during pull
+	pullRetry retryPolicy
+
+	// downloadLimiter bounds the concurrent layer downloads of all pulls
+	downloadLimiter downloadLimiter
+
 	// containerd grpc pool
 	pool      []scheduler.Factory
 	scheduler scheduler.Scheduler
@@ -55,6 +66,9 @@ type Client struct {
 
 	// eventsHooks specified methods that handle containerd events
 	eventsHooks []func(context.Context, string, string, map[string]string) error
+
+	// health records whether containerd is serving
+	health *health
 }
 
 // Plugin is the containerd plugin type
@@ -72,6 +86,9 @@ func NewClient(opts ...ClientOpt) (APIClient, error) {
 		grpcClientPoolCapacity: defaultGrpcClientPoolCapacity,
 		maxStreamsClient:       defaultMaxStreamsClient,
 		insecureRegistries:     []string{},
+		maxPullAttempts:        defaultMaxPullAttempts,
+		pullRetryBackoff:       defaultPullRetryBackoff,
+		maxConcurrentDownloads: defaultMaxConcurrentDownloads,
 	}
 
 	for _, opt := range opts {
@@ -88,6 +105,13 @@ func NewClient(opts ...ClientOpt) (APIClient, error) {
 			containers: make(map[string]*containerPack),
 		},
 		insecureRegistries: copts.insecureRegistries,
+		registryMirrors:    copts.registryMirrors,
+		pullRetry: retryPolicy{
+			attempts: copts.maxPullAttempts,
+			backoff:  copts.pullRetryBackoff,
+		},
+		downloadLimiter: newDownloadLimiter(copts.maxConcurrentDownloads),
+		health:          newHealth(),
 	}
 
 	lease, err := client.preparePouchdLease(copts.rpcAddr, copts.defaultns)
@@ -111,15 +135,28 @@ func NewClient(opts ...ClientOpt) (APIClient, error) {
 	}
 	client.scheduler = scheduler
 
+	// release the leases held by the operations before pouchd restarts.
+	if err := client.releaseStaleLeases(context.TODO()); err != nil {
+		logrus.Warnf("failed to release stale leases: %v", err)
+	}
+
 	// start collect containerd events
 	go client.collectContainerdEvents()
 
+	// start check whether containerd is serving
+	go client.monitorHealth()
+
 	return client, nil
 }
 
 // Get will reture an available containerd grpc client,
-// Or occurred an error
+// Or occurred an error. It fails fast with errtypes.ErrContainerdUnavailable
+// if containerd isn't serving.
 func (c *Client) Get(ctx context.Context) (*WrapperClient, error) {
+	if err := c.Available(); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 
 	c.mu.RLock()
@@ -160,8 +197,48 @@ func (c *Client) SetEventsHooks(hooks ...func(context.Context, string, string, m
 	c.eventsHooks = hooks
 }
 
+// UpdateRegistryOptions applies the insecure registries, registry mirrors
+// and max concurrent downloads in opts to the later pulls, and the ongoing
+// pulls are not affected. Nothing is changed if any of opts is invalid.
+func (c *Client) UpdateRegistryOptions(opts ...ClientOpt) error {
+	c.mu.RLock()
+	copts := clientOpts{
+		insecureRegistries:     c.insecureRegistries,
+		registryMirrors:        c.registryMirrors,
+		maxConcurrentDownloads: cap(c.downloadLimiter),
+	}
+	c.mu.RUnlock()
+
+	for _, opt := range opts {
+		if err := opt(&copts); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.insecureRegistries = copts.insecureRegistries
+	c.registryMirrors = copts.registryMirrors
+	if copts.maxConcurrentDownloads != cap(c.downloadLimiter) {
+		c.downloadLimiter = newDownloadLimiter(copts.maxConcurrentDownloads)
+	}
+	return nil
+}
+
+// registryOptions returns the insecure registries, registry mirrors and
+// the download limiter used by pulls.
+func (c *Client) registryOptions() ([]string, []*url.URL, downloadLimiter) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.insecureRegistries, c.registryMirrors, c.downloadLimiter
+}
+
 // Close closes the client.
 func (c *Client) Close() error {
+	c.stopHealth()
+
 	c.mu.Lock()
 	factories := c.pool
 	c.pool = nil
@@ -247,15 +324,38 @@ func (c *Client) Plugins(ctx context.Context, filters []string) ([]Plugin, error
 	return plugins, nil
 }
 
-// collectContainerdEvents collects events generated by containerd.
+// collectContainerdEvents collects events generated by containerd, and
+// subscribes the events again when containerd is available after the
+// subscription is broken, until the client is closed.
 func (c *Client) collectContainerdEvents() {
-	ctx := context.Background()
+	for {
+		err := c.subscribeContainerdEvents(context.Background())
+		logrus.Errorf("failed to receive event: %v", err)
+
+		// wait for the health check to find out the broken connection.
+		select {
+		case <-time.After(healthCheckInterval):
+		case <-c.health.closeCh:
+			return
+		}
+
+		if !c.waitAvailable() {
+			return
+		}
+		logrus.Infof("subscribe containerd events again")
+	}
+}
+
+// subscribeContainerdEvents subscribes the events and handles them until
+// the subscription is broken.
+func (c *Client) subscribeContainerdEvents(ctx context.Context) error {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	// get client
-	wrapperCli, err := c.Get(ctx)
+	wrapperCli, err := c.Get(subCtx)
 	if err != nil {
-		logrus.Errorf("failed to get a containerd grpc client: %v", err)
-		return
+		return fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
 	eventsClient := wrapperCli.client.EventService()
 
@@ -264,18 +364,14 @@ func (c *Client) collectContainerdEvents() {
 	ef := []string{"topic~=task.*", "topic~=container.*"}
 	topicsToHandle := []string{TaskOOMEventTopic, TaskExitEventTopic}
 
-	eventCh, errCh := eventsClient.Subscribe(ctx, ef...)
+	eventCh, errCh := eventsClient.Subscribe(subCtx, ef...)
 
 	for {
-		// TODO(ziren):need reconnect the event service
 		var e *events.Envelope
 		select {
 		case e = <-eventCh:
 		case err := <-errCh:
-			if err != nil {
-				logrus.Errorf("failed to receive event: %v", err)
-			}
-			return
+			return err
 		}
 
 		if !utils.StringInSlice(topicsToHandle, e.Topic) || e.Event == nil {
@@ -336,6 +432,7 @@ func (c *Client) collectContainerdEvents() {
 func (c *Client) CheckSnapshotterValid(snapshotter string, allowMultiSnapshotter bool) error {
 	var (
 		driverFound = false
+		available   []string
 	)
 
 	plugins, err := c.Plugins(context.Background(), []string{fmt.Sprintf("type==%s", plugin.SnapshotPlugin)})
@@ -348,6 +445,7 @@ func (c *Client) CheckSnapshotterValid(snapshotter string, allowMultiSnapshotter
 		if p.Status != PluginStatusOk {
 			continue
 		}
+		available = append(available, p.ID)
 
 		if p.ID == snapshotter {
 			driverFound = true
@@ -369,7 +467,7 @@ func (c *Client) CheckSnapshotterValid(snapshotter string, allowMultiSnapshotter
 	}
 
 	if !driverFound {
-		return fmt.Errorf("containerd not support snapshotter driver %s", snapshotter)
+		return fmt.Errorf("containerd not support snapshotter driver %s, available snapshotters: %s", snapshotter, strings.Join(available, ", "))
 	}
 
 	return nil
@@ -3,8 +3,10 @@ package ctrd
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type clientOpts struct {
@@ -13,6 +15,10 @@ type clientOpts struct {
 	maxStreamsClient       int
 	defaultns              string
 	insecureRegistries     []string
+	registryMirrors        []*url.URL
+	maxPullAttempts        int
+	pullRetryBackoff       time.Duration
+	maxConcurrentDownloads int
 }
 
 // ClientOpt allows caller to set options for containerd client.
@@ -87,6 +93,68 @@ func WithInsecureRegistries(endpoints []string) ClientOpt {
 	}
 }
 
+// WithRegistryMirrors sets the mirrors of docker hub, which are tried in
+// order before docker hub. The mirror should be in the format of
+// scheme://host[:port], such as https://mirror.example.com.
+func WithRegistryMirrors(mirrors []string) ClientOpt {
+	return func(c *clientOpts) error {
+		urls := make([]*url.URL, 0, len(mirrors))
+
+		for _, m := range mirrors {
+			u, err := url.Parse(strings.TrimSuffix(m, "/"))
+			if err != nil {
+				return fmt.Errorf("invalid registry mirror %s: %v", m, err)
+			}
+
+			if u.Scheme != "http" && u.Scheme != "https" {
+				return fmt.Errorf("invalid registry mirror %s: scheme should be http or https", m)
+			}
+
+			if u.Host == "" || u.Path != "" || u.RawQuery != "" || u.User != nil {
+				return fmt.Errorf("invalid registry mirror %s: should be in the format of scheme://host[:port]", m)
+			}
+
+			if err := validateHostPort(u.Host); err != nil {
+				return err
+			}
+			urls = append(urls, u)
+		}
+		c.registryMirrors = urls
+		return nil
+	}
+}
+
+// WithPullRetry sets the max attempts to fetch the content during pull, and
+// the backoff before the first retry, which is doubled for each retry.
+func WithPullRetry(maxAttempts int, backoff time.Duration) ClientOpt {
+	return func(c *clientOpts) error {
+		if maxAttempts <= 0 {
+			return fmt.Errorf("max pull attempts should be positive number")
+		}
+
+		if backoff < 0 {
+			return fmt.Errorf("pull retry backoff should not be negative")
+		}
+
+		c.maxPullAttempts = maxAttempts
+		c.pullRetryBackoff = backoff
+		return nil
+	}
+}
+
+// WithMaxConcurrentDownloads sets the max number of layers which can be
+// downloaded at the same time by all the pulls.
+func WithMaxConcurrentDownloads(n int) ClientOpt {
+	return func(c *clientOpts) error {
+		if n <= 0 {
+			return fmt.Errorf("max concurrent downloads should be positive number")
+		}
+
+		c.maxConcurrentDownloads = n
+		return nil
+	}
+}
+
 func validateHostPort(s string) error {
 	_, port, err := net.SplitHostPort(s)
 	if err != nil {
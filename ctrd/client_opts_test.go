@@ -48,3 +48,38 @@ func TestWithInsecureRegistries(t *testing.T) {
 		}
 	}
 }
+
+func TestWithRegistryMirrors(t *testing.T) {
+	testCases := []struct {
+		mirrors  []string
+		hasError bool
+	}{
+		{
+			mirrors:  []string{"https://mirror.example.com", "http://127.0.0.1:5000/"},
+			hasError: false,
+		},
+		{
+			mirrors:  []string{"mirror.example.com"},
+			hasError: true,
+		},
+		{
+			mirrors:  []string{"ftp://mirror.example.com"},
+			hasError: true,
+		},
+		{
+			mirrors:  []string{"https://mirror.example.com/v2"},
+			hasError: true,
+		},
+		{
+			mirrors:  []string{"https://mirror.example.com:65536"},
+			hasError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := WithRegistryMirrors(tc.mirrors)(&clientOpts{})
+		if (err != nil) != tc.hasError {
+			t.Fatalf("expected hasError = %v for %v, but got error = %v", tc.hasError, tc.mirrors, err)
+		}
+	}
+}
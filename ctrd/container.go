@@ -192,6 +192,24 @@ func (c *Client) ResizeExec(ctx context.Context, id string, execid string, opts
 	return execProcess.Resize(ctx, uint32(opts.Width), uint32(opts.Height))
 }
 
+// KillExec sends the signal to the exec process running in the container.
+func (c *Client) KillExec(ctx context.Context, id string, execid string, signal syscall.Signal) error {
+	pack, err := c.watch.get(id)
+	if err != nil {
+		return err
+	}
+
+	execProcess, err := pack.task.LoadProcess(ctx, execid, nil)
+	if err != nil {
+		return convertCtrdErr(err)
+	}
+
+	if err := execProcess.Kill(ctx, signal); err != nil {
+		return errors.Wrapf(convertCtrdErr(err), "failed to send signal %d to exec process %s", signal, execid)
+	}
+	return nil
+}
+
 // ContainerPID returns the container's init process id.
 func (c *Client) ContainerPID(ctx context.Context, id string) (int, error) {
 	pid, err := c.containerPID(ctx, id)
@@ -326,6 +344,12 @@ func (c *Client) recoverContainer(ctx context.Context, id string, io *containeri
 		return errors.Wrap(errtypes.ErrNotfound, "task")
 	}
 
+	// the task which exited when pouchd is down is reported by Wait at
+	// once, so that its exit code is recorded by the exit hooks.
+	if status, err := task.Status(ctx); err == nil && status.Status == containerd.Stopped {
+		logrus.Warnf("the task of container %s exited with code %d when pouchd is down", id, status.ExitStatus)
+	}
+
 	statusCh, err := task.Wait(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to wait task")
@@ -344,9 +368,10 @@ func (c *Client) recoverContainer(ctx context.Context, id string, io *containeri
 	return nil
 }
 
-// DestroyContainer kill container and delete it.
-func (c *Client) DestroyContainer(ctx context.Context, id string, timeout int64) (*Message, error) {
-	msg, err := c.destroyContainer(ctx, id, timeout)
+// DestroyContainer kill container with the signal and delete it, the
+// container is killed by SIGKILL if it doesn't exit in timeout.
+func (c *Client) DestroyContainer(ctx context.Context, id string, signal syscall.Signal, timeout int64) (*Message, error) {
+	msg, err := c.destroyContainer(ctx, id, signal, timeout)
 	if err != nil {
 		return msg, convertCtrdErr(err)
 	}
@@ -354,7 +379,7 @@ func (c *Client) DestroyContainer(ctx context.Context, id string, timeout int64)
 }
 
 // DestroyContainer kill container and delete it.
-func (c *Client) destroyContainer(ctx context.Context, id string, timeout int64) (*Message, error) {
+func (c *Client) destroyContainer(ctx context.Context, id string, signal syscall.Signal, timeout int64) (*Message, error) {
 	// TODO(ziren): if we just want to stop a container,
 	// we may need lease to lock the snapshot of container,
 	// in case, it be deleted by gc.
@@ -393,7 +418,7 @@ func (c *Client) destroyContainer(ctx context.Context, id string, timeout int64)
 	var msg *Message
 
 	// TODO: set task request timeout by context timeout
-	if err := pack.task.Kill(ctx, syscall.SIGTERM, containerd.WithKillAll); err != nil {
+	if err := pack.task.Kill(ctx, signal, containerd.WithKillAll); err != nil {
 		if !errdefs.IsNotFound(err) {
 			return nil, errors.Wrap(err, "failed to kill task")
 		}
@@ -470,6 +495,30 @@ func (c *Client) pauseContainer(ctx context.Context, id string) error {
 	return nil
 }
 
+// KillContainer sends the signal to the init process of container.
+func (c *Client) KillContainer(ctx context.Context, id string, signal syscall.Signal) error {
+	if err := c.killContainer(ctx, id, signal); err != nil {
+		return convertCtrdErr(err)
+	}
+	return nil
+}
+
+// killContainer sends the signal to the init process of container.
+func (c *Client) killContainer(ctx context.Context, id string, signal syscall.Signal) error {
+	pack, err := c.watch.get(id)
+	if err != nil {
+		return err
+	}
+
+	if err := pack.task.Kill(ctx, signal); err != nil {
+		return errors.Wrapf(err, "failed to send signal %d to task", signal)
+	}
+
+	logrus.Infof("success to send signal %d to container: %s", signal, id)
+
+	return nil
+}
+
 // UnpauseContainer unpauses container.
 func (c *Client) UnpauseContainer(ctx context.Context, id string) error {
 	if err := c.unpauseContainer(ctx, id); err != nil {
@@ -619,6 +668,11 @@ func (c *Client) createTask(ctx context.Context, id, checkpointDir string, conta
 		closeStdinCh            = make(chan struct{})
 	)
 
+	taskOpts := []containerd.NewTaskOpts{withCheckpointOpt(checkpoint)}
+	if checkpoint != nil && cc.TCPEstablished {
+		taskOpts = append(taskOpts, withShimV1RestoreTaskOpts(true))
+	}
+
 	// create task
 	task, err := container.NewTask(ctx, func(_ string) (cio.IO, error) {
 		logrus.WithField("container", cntrID).Debugf("creating cio (withStdin=%v, withTerminal=%v)", withStdin, withTerminal)
@@ -628,7 +682,7 @@ func (c *Client) createTask(ctx context.Context, id, checkpointDir string, conta
 			return nil, err
 		}
 		return c.createIO(fifoset, cntrID, execID, closeStdinCh, cc.IO.InitContainerIO)
-	}, withCheckpointOpt(checkpoint))
+	}, taskOpts...)
 	close(closeStdinCh)
 
 	if err != nil {
@@ -760,7 +814,7 @@ func (c *Client) waitContainer(ctx context.Context, id string) (types.ContainerW
 }
 
 // CreateCheckpoint create a checkpoint from a running container
-func (c *Client) CreateCheckpoint(ctx context.Context, id string, checkpointDir string, exit bool) error {
+func (c *Client) CreateCheckpoint(ctx context.Context, id string, checkpointDir string, exit, tcpEstablished bool) error {
 	pack, err := c.watch.get(id)
 	if err != nil {
 		return err
@@ -773,8 +827,8 @@ func (c *Client) CreateCheckpoint(ctx context.Context, id string, checkpointDir
 	client := wrapperCli.client
 
 	var opts []containerd.CheckpointTaskOpts
-	if exit {
-		opts = append(opts, withExitShimV1CheckpointTaskOpts())
+	if exit || tcpEstablished {
+		opts = append(opts, withShimV1CheckpointTaskOpts(exit, tcpEstablished))
 	}
 	checkpoint, err := pack.task.Checkpoint(ctx, opts...)
 	if err != nil {
@@ -27,6 +27,10 @@ type Container struct {
 
 	// UseSystemd tells whether container use systemd cgroup driver
 	UseSystemd bool
+
+	// TCPEstablished tells whether to restore the established TCP
+	// connections when the container is restored from checkpoint
+	TCPEstablished bool
 }
 
 // Process wraps exec process's info.
@@ -0,0 +1,139 @@
+package ctrd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// healthCheckInterval is the interval to check whether containerd is serving.
+	healthCheckInterval = time.Second
+
+	// healthCheckTimeout is the timeout of each health check.
+	healthCheckTimeout = 3 * time.Second
+)
+
+// health records whether containerd is serving, which is updated by the
+// health check in background.
+type health struct {
+	mu sync.RWMutex
+
+	// err is the error of the last health check, nil means serving.
+	err error
+
+	// availableCh is closed when containerd is serving.
+	availableCh chan struct{}
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+}
+
+func newHealth() *health {
+	availableCh := make(chan struct{})
+	close(availableCh)
+
+	return &health{
+		availableCh: availableCh,
+		closeCh:     make(chan struct{}),
+	}
+}
+
+// Available returns errtypes.ErrContainerdUnavailable if containerd isn't
+// serving, so that the calls fail fast instead of waiting for containerd.
+func (c *Client) Available() error {
+	c.health.mu.RLock()
+	defer c.health.mu.RUnlock()
+
+	if c.health.err != nil {
+		return errtypes.ErrContainerdUnavailable
+	}
+	return nil
+}
+
+// monitorHealth checks whether containerd is serving periodically until the
+// client is closed. The dropped connections are re-established by grpc with
+// backoff, see dialOptions, and the health check only records the result.
+func (c *Client) monitorHealth() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.health.closeCh:
+			return
+		case <-ticker.C:
+		}
+
+		c.setHealth(c.checkHealth())
+	}
+}
+
+// checkHealth checks every connection in pool is serving.
+func (c *Client) checkHealth() error {
+	c.mu.RLock()
+	pool := c.pool
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	for _, factory := range pool {
+		wrapperCli, ok := factory.(*WrapperClient)
+		if !ok {
+			return fmt.Errorf("failed to convert Factory interface to *WrapperClient")
+		}
+
+		serving, err := wrapperCli.client.IsServing(ctx)
+		if err != nil {
+			return err
+		}
+		if !serving {
+			return fmt.Errorf("containerd is not serving")
+		}
+	}
+	return nil
+}
+
+// setHealth records the result of health check.
+func (c *Client) setHealth(err error) {
+	c.health.mu.Lock()
+	defer c.health.mu.Unlock()
+
+	switch {
+	case err != nil && c.health.err == nil:
+		logrus.Warnf("containerd is unavailable: %v", err)
+		c.health.availableCh = make(chan struct{})
+	case err == nil && c.health.err != nil:
+		logrus.Infof("containerd is available again")
+		close(c.health.availableCh)
+	}
+	c.health.err = err
+}
+
+// waitAvailable blocks until containerd is serving. It returns false if
+// the client has been closed.
+func (c *Client) waitAvailable() bool {
+	c.health.mu.RLock()
+	availableCh := c.health.availableCh
+	c.health.mu.RUnlock()
+
+	select {
+	case <-availableCh:
+		return true
+	case <-c.health.closeCh:
+		return false
+	}
+}
+
+// stopHealth stops the health check.
+func (c *Client) stopHealth() {
+	c.health.closeOnce.Do(func() {
+		close(c.health.closeCh)
+	})
+}
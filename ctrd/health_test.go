@@ -0,0 +1,91 @@
+package ctrd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHealth(t *testing.T) {
+	c := &Client{health: newHealth()}
+
+	if err := c.Available(); err != nil {
+		t.Fatalf("expect containerd to be available, but got %v", err)
+	}
+	if !c.waitAvailable() {
+		t.Fatalf("expect waitAvailable to return true when containerd is available")
+	}
+
+	c.setHealth(fmt.Errorf("connection refused"))
+	if err := c.Available(); !errtypes.IsUnavailable(err) {
+		t.Fatalf("expect unavailable error, but got %v", err)
+	}
+
+	waitCh := make(chan bool, 1)
+	go func() {
+		waitCh <- c.waitAvailable()
+	}()
+
+	select {
+	case <-waitCh:
+		t.Fatalf("expect waitAvailable to block when containerd is unavailable")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	c.setHealth(nil)
+	select {
+	case ok := <-waitCh:
+		if !ok {
+			t.Fatalf("expect waitAvailable to return true when containerd is available again")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expect waitAvailable to return when containerd is available again")
+	}
+
+	// waitAvailable returns false after the client is closed.
+	c.setHealth(fmt.Errorf("connection refused"))
+	c.stopHealth()
+	if c.waitAvailable() {
+		t.Fatalf("expect waitAvailable to return false after the client is closed")
+	}
+}
+
+func TestConvertUnavailable(t *testing.T) {
+	// the connection never becomes ready.
+	cc, err := grpc.Dial("/path/to/not/exist/containerd.sock", grpc.WithInsecure(), grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+		return nil, fmt.Errorf("connection refused")
+	}))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer cc.Close()
+
+	method := "/containerd.services.images.v1.Images/Get"
+
+	notFound := status.Error(codes.NotFound, "image not found")
+	if err := convertUnavailable(cc, method, notFound); err != notFound {
+		t.Fatalf("expect the error not to be converted, but got %v", err)
+	}
+
+	if err := convertUnavailable(cc, method, nil); err != nil {
+		t.Fatalf("expect nil, but got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = cc.Invoke(ctx, method, nil, nil)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expect unavailable rpc error, but got %v", err)
+	}
+	if err := convertUnavailable(cc, method, err); !errtypes.IsUnavailable(err) {
+		t.Fatalf("expect containerd unavailable error, but got %v", err)
+	}
+}
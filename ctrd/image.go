@@ -8,12 +8,14 @@ import (
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/httputils"
 	"github.com/alibaba/pouch/pkg/jsonstream"
 	"github.com/alibaba/pouch/pkg/reference"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/errdefs"
 	ctrdmetaimages "github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/opencontainers/go-digest"
@@ -57,7 +59,11 @@ func (c *Client) getImage(ctx context.Context, ref string) (containerd.Image, er
 		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
 
-	return wrapperCli.client.GetImage(ctx, ref)
+	img, err := wrapperCli.client.ImageService().Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return containerd.NewImageWithPlatform(wrapperCli.client, img, ImagePlatformMatcher(img.Labels)), nil
 }
 
 // ListImages lists all images.
@@ -76,7 +82,18 @@ func (c *Client) listImages(ctx context.Context, filter ...string) ([]containerd
 		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
 
-	return wrapperCli.client.ListImages(ctx, filter...)
+	imgs, err := wrapperCli.client.ImageService().List(ctx, filter...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the image pulled with platform should use the manifest of that
+	// platform instead of the default one.
+	res := make([]containerd.Image, 0, len(imgs))
+	for _, img := range imgs {
+		res = append(res, containerd.NewImageWithPlatform(wrapperCli.client, img, ImagePlatformMatcher(img.Labels)))
+	}
+	return res, nil
 }
 
 // RemoveImage deletes an image.
@@ -94,57 +111,80 @@ func (c *Client) removeImage(ctx context.Context, ref string) error {
 		return fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
 
-	if err := wrapperCli.client.ImageService().Delete(ctx, ref); err != nil {
+	// use synchronous delete to make sure that the content of image has
+	// been reclaimed by containerd gc before return.
+	if err := wrapperCli.client.ImageService().Delete(ctx, ref, ctrdmetaimages.SynchronousDelete()); err != nil {
 		return errors.Wrap(err, "failed to remove image")
 	}
 	return nil
 }
 
-// SaveImage saves image to tarstream
-func (c *Client) SaveImage(ctx context.Context, exporter ctrdmetaimages.Exporter, ref string) (io.ReadCloser, error) {
-	r, err := c.saveImage(ctx, exporter, ref)
+// SaveImage saves images to tarstream.
+func (c *Client) SaveImage(ctx context.Context, refs []string) (io.ReadCloser, error) {
+	r, err := c.saveImage(ctx, refs)
 	if err != nil {
 		return r, convertCtrdErr(err)
 	}
 	return r, nil
 }
 
-// saveImage saves image to tarstream
-func (c *Client) saveImage(ctx context.Context, exporter ctrdmetaimages.Exporter, ref string) (io.ReadCloser, error) {
+// saveImage saves images to tarstream.
+func (c *Client) saveImage(ctx context.Context, refs []string) (io.ReadCloser, error) {
 	wrapperCli, err := c.Get(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
 
-	image, err := c.GetImage(ctx, ref)
-	if err != nil {
-		return nil, err
-	}
+	imgs := make([]exportedImage, 0, len(refs))
+	for _, ref := range refs {
+		image, err := c.GetImage(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
 
-	desc := image.Target()
-	// add annotations in image description
-	if desc.Annotations == nil {
-		desc.Annotations = make(map[string]string)
-	}
-	if s, exist := desc.Annotations[ocispec.AnnotationRefName]; !exist || s == "" {
 		namedRef, err := reference.Parse(ref)
 		if err != nil {
 			return nil, err
 		}
 
+		// add annotations in image description, copy the annotations
+		// so that the image in containerd will not be changed.
+		desc := image.Target()
+		annotations := make(map[string]string, len(desc.Annotations)+1)
+		for k, v := range desc.Annotations {
+			annotations[k] = v
+		}
+		desc.Annotations = annotations
+
+		// NOTE: the full reference is recorded in the annotation, and the
+		// image name can be changed by the load.
+		img := exportedImage{desc: desc}
 		if reference.IsNameTagged(namedRef) {
-			desc.Annotations[ocispec.AnnotationRefName] = namedRef.(reference.Tagged).Tag()
+			if s, exist := desc.Annotations[ocispec.AnnotationRefName]; !exist || s == "" {
+				desc.Annotations[ocispec.AnnotationRefName] = namedRef.String()
+			}
+			img.repoTag = namedRef.String()
 		}
+		imgs = append(imgs, img)
 	}
 
-	return wrapperCli.client.Export(ctx, exporter, desc)
+	exporter := &archiveExporter{
+		store:    wrapperCli.client.ContentStore(),
+		platform: platforms.Default(),
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(exporter.Export(ctx, imgs, pw))
+	}()
+	return pr, nil
 }
 
 // ImportImage creates a set of images by tarstream.
 //
 // NOTE: One tar may have several manifests.
-func (c *Client) ImportImage(ctx context.Context, reader io.Reader, opts ...containerd.ImportOpt) ([]containerd.Image, error) {
-	imgs, err := c.importImage(ctx, reader, opts...)
+func (c *Client) ImportImage(ctx context.Context, reader io.Reader, stream *jsonstream.JSONStream, opts ...containerd.ImportOpt) ([]containerd.Image, error) {
+	imgs, err := c.importImage(ctx, reader, stream, opts...)
 	if err != nil {
 		return imgs, convertCtrdErr(err)
 	}
@@ -154,15 +194,30 @@ func (c *Client) ImportImage(ctx context.Context, reader io.Reader, opts ...cont
 // importImage creates a set of images by tarstream.
 //
 // NOTE: One tar may have several manifests.
-func (c *Client) importImage(ctx context.Context, reader io.Reader, opts ...containerd.ImportOpt) ([]containerd.Image, error) {
+func (c *Client) importImage(ctx context.Context, reader io.Reader, stream *jsonstream.JSONStream, opts ...containerd.ImportOpt) ([]containerd.Image, error) {
 	wrapperCli, err := c.Get(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
 
+	// NOTE: make sure that gc scheduler doesn't remove content/snapshot
+	// during import and unpack
+	ctx, done, err := withTemporaryLease(ctx, wrapperCli, "import")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create lease for import")
+	}
+	defer done()
+
 	// NOTE: The import will store the data into boltdb. But the unpack may
 	// fail. It is not transaction.
+	//
+	// The containerd doesn't verify the blobs in the archive, so verify
+	// them during import to report which blob is broken.
+	reader, finishVerify := startVerifyArchive(reader, stream)
 	imgs, err := wrapperCli.client.Import(ctx, reader, opts...)
+	if verr := finishVerify(); verr != nil {
+		return nil, verr
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -175,9 +230,14 @@ func (c *Client) importImage(ctx context.Context, reader io.Reader, opts ...cont
 	for _, img := range imgs {
 		image := containerd.NewImage(wrapperCli.client, img)
 
+		stream.WriteObject(jsonstream.JSONMessage{
+			ID:     img.Name,
+			Status: jsonstream.LoadStatusUnpacking,
+		})
+
 		err = image.Unpack(ctx, snaphotter)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrapf(err, "failed to unpack image %s", img.Name)
 		}
 
 		res = append(res, image)
@@ -233,6 +293,10 @@ func (c *Client) PushImage(ctx context.Context, ref string, authConfig *types.Au
 		stream.Wait()
 	}()
 	if err != nil {
+		// the progress has been sent to client, so send the error
+		// information through stream too.
+		err = convertRegistryErr(err)
+		writeStreamError(stream, err)
 		return err
 	}
 
@@ -241,8 +305,21 @@ func (c *Client) PushImage(ctx context.Context, ref string, authConfig *types.Au
 	return nil
 }
 
-// FetchImage fetches image content from the remote repository.
-func (c *Client) FetchImage(ctx context.Context, ref string, authConfig *types.AuthConfig, stream *jsonstream.JSONStream) (containerd.Image, error) {
+// writeStreamError sends the error through stream, and the code tells the
+// kind of error like the status code of response.
+func writeStreamError(stream *jsonstream.JSONStream, err error) {
+	stream.WriteObject(jsonstream.JSONMessage{
+		Error: &jsonstream.JSONError{
+			Code:    httputils.StatusCode(err),
+			Message: err.Error(),
+		},
+		ErrorMessage: err.Error(),
+	})
+}
+
+// FetchImage fetches image content from the remote repository. The manifest
+// of host platform is fetched if the platform is empty.
+func (c *Client) FetchImage(ctx context.Context, ref, platform string, authConfig *types.AuthConfig, stream *jsonstream.JSONStream) (containerd.Image, error) {
 	wrapperCli, err := c.Get(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
@@ -255,6 +332,21 @@ func (c *Client) FetchImage(ctx context.Context, ref string, authConfig *types.A
 
 	ongoing := newJobs(ref)
 
+	// retry the fetch of content on transient errors, and show the
+	// retrying status in progress.
+	resolver = &retryResolver{Resolver: resolver, policy: c.pullRetry, notify: ongoing.retry}
+
+	// the limit of pull can't exceed the limit of daemon, which is shared
+	// by all the pulls.
+	_, _, daemonLimiter := c.registryOptions()
+	limiters := []downloadLimiter{}
+	if n := GetPullMaxConcurrentDownloads(ctx); n > 0 && n < cap(daemonLimiter) {
+		limiters = append(limiters, newDownloadLimiter(n))
+	}
+	limiters = append(limiters, daemonLimiter)
+	resolver = &limitedResolver{Resolver: resolver, limiters: limiters}
+	resolver = &meteredResolver{Resolver: resolver}
+
 	options := []containerd.RemoteOpt{
 		containerd.WithSchema1Conversion,
 		containerd.WithResolver(resolver),
@@ -268,6 +360,24 @@ func (c *Client) FetchImage(ctx context.Context, ref string, authConfig *types.A
 	}
 	options = append(options, containerd.WithImageHandler(ctrdmetaimages.HandlerFunc(handle)))
 
+	// record the platform in the labels of image, so that the manifest of
+	// the platform can be found after pull.
+	if platform != "" {
+		p, err := ParsePlatform(platform)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid platform %s", platform)
+		}
+
+		if err := checkRemotePlatform(ctx, resolver, ref, p); err != nil {
+			return nil, convertRegistryErr(err)
+		}
+
+		options = append(options,
+			containerd.WithPlatformMatcher(platforms.Only(p)),
+			containerd.WithPullLabel(ImagePlatformLabel, platforms.Format(p)),
+		)
+	}
+
 	// fetch progress status, then send to client via out channel.
 	pctx, cancelProgress := context.WithCancel(ctx)
 	wait := make(chan struct{})
@@ -291,7 +401,7 @@ func (c *Client) FetchImage(ctx context.Context, ref string, authConfig *types.A
 	<-wait
 
 	if err != nil {
-		return nil, err
+		return nil, convertRegistryErr(err)
 	}
 
 	logrus.Infof("success to fetch image: %s", img.Name())
@@ -299,6 +409,12 @@ func (c *Client) FetchImage(ctx context.Context, ref string, authConfig *types.A
 }
 
 func (c *Client) fetchImage(ctx context.Context, wrapperCli *WrapperClient, ref string, options []containerd.RemoteOpt) (containerd.Image, error) {
+	ctx, done, err := withTemporaryLease(ctx, wrapperCli, "pull")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create lease for pulling image")
+	}
+	defer done()
+
 	img, err := wrapperCli.client.Pull(ctx, ref, options...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to pull image")
@@ -342,9 +458,14 @@ outer:
 				}
 				// update status of active entries!
 				for _, active := range actives {
+					status := jsonstream.PullStatusDownloading
+					if ongoing.isRetrying(active.Expected) {
+						status = jsonstream.PullStatusRetrying
+					}
+
 					progresses[active.Ref] = jsonstream.JSONMessage{
 						ID:     active.Ref,
-						Status: jsonstream.PullStatusDownloading,
+						Status: status,
 						Detail: &jsonstream.ProgressDetail{
 							Current: active.Offset,
 							Total:   active.Total,
@@ -365,16 +486,21 @@ outer:
 				}
 
 				status, ok := progresses[key]
-				if !done && (!ok || status.Status == jsonstream.PullStatusDownloading) {
+				if !done && (!ok || status.Status == jsonstream.PullStatusDownloading || status.Status == jsonstream.PullStatusRetrying) {
 					info, err := cs.Info(context.TODO(), j.Digest)
 					if err != nil {
 						if !errdefs.IsNotFound(err) {
 							logrus.Errorf("failed to get content info: %v", err)
 							continue outer
 						} else {
+							status := jsonstream.PullStatusWaiting
+							if ongoing.isRetrying(j.Digest) {
+								status = jsonstream.PullStatusRetrying
+							}
+
 							progresses[key] = jsonstream.JSONMessage{
 								ID:     key,
-								Status: jsonstream.PullStatusWaiting,
+								Status: status,
 							}
 						}
 					} else if info.CreatedAt.After(start) {
@@ -427,17 +553,39 @@ type jobs struct {
 	name     string
 	added    map[digest.Digest]struct{}
 	descs    []ocispec.Descriptor
+	retrying map[digest.Digest]struct{}
 	mu       sync.Mutex
 	resolved bool
 }
 
 func newJobs(name string) *jobs {
 	return &jobs{
-		name:  name,
-		added: map[digest.Digest]struct{}{},
+		name:     name,
+		added:    map[digest.Digest]struct{}{},
+		retrying: map[digest.Digest]struct{}{},
 	}
 }
 
+// retry marks the descriptor as retrying until the fetch succeeds.
+func (j *jobs) retry(desc ocispec.Descriptor, attempt int, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err == nil {
+		delete(j.retrying, desc.Digest)
+		return
+	}
+	j.retrying[desc.Digest] = struct{}{}
+}
+
+func (j *jobs) isRetrying(dgst digest.Digest) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, ok := j.retrying[dgst]
+	return ok
+}
+
 func (j *jobs) add(desc ocispec.Descriptor) {
 	j.mu.Lock()
 	defer j.mu.Unlock()
@@ -17,7 +17,6 @@ import (
 	"github.com/containerd/containerd/diff"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
-	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/containerd/rootfs"
 	"github.com/containerd/containerd/snapshots"
 	digest "github.com/opencontainers/go-digest"
@@ -77,11 +76,11 @@ func (c *Client) Commit(ctx context.Context, config *CommitConfig) (_ digest.Dig
 	client := wrapperCli.client
 
 	// NOTE: make sure that gc scheduler doesn't remove content/snapshot during commmit
-	ctx, done, err := client.WithLease(ctx)
+	ctx, done, err := withTemporaryLease(ctx, wrapperCli, "commit")
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to create lease for commit")
 	}
-	defer done(ctx)
+	defer done()
 
 	var (
 		sn     = client.SnapshotService(CurrentSnapshotterName(ctx))
@@ -115,7 +114,21 @@ func (c *Client) Commit(ctx context.Context, config *CommitConfig) (_ digest.Dig
 		}
 	}()
 
-	imgJSON, err := json.Marshal(childImg)
+	// get parent image layer descriptor
+	pmfst, err := images.Manifest(ctx, cs, config.CImage.Target(), ImagePlatformMatcher(config.CImage.Labels()))
+	if err != nil {
+		return "", err
+	}
+
+	return writeImage(ctx, client, config.Reference, childImg, append(pmfst.Layers, layer), rootfsID)
+}
+
+// writeImage writes the config and manifest of image into content store, and
+// registers the image with reference. The image ID is returned.
+func writeImage(ctx context.Context, client *containerd.Client, reference string, img ocispec.Image, layers []ocispec.Descriptor, rootfsID string) (digest.Digest, error) {
+	cs := client.ContentStore()
+
+	imgJSON, err := json.Marshal(img)
 	if err != nil {
 		return "", err
 	}
@@ -127,14 +140,7 @@ func (c *Client) Commit(ctx context.Context, config *CommitConfig) (_ digest.Dig
 		Size:      int64(len(imgJSON)),
 	}
 
-	// get parent image layer descriptor
-	pmfst, err := images.Manifest(ctx, cs, config.CImage.Target(), platforms.Default())
-	if err != nil {
-		return "", err
-	}
-
 	// new layer descriptor
-	layers := append(pmfst.Layers, layer)
 	labels := map[string]string{
 		"containerd.io/gc.ref.content.0": configDesc.Digest.String(),
 	}
@@ -175,19 +181,19 @@ func (c *Client) Commit(ctx context.Context, config *CommitConfig) (_ digest.Dig
 	}
 
 	// image create
-	img := images.Image{
-		Name:      config.Reference,
+	cImg := images.Image{
+		Name:      reference,
 		Target:    desc,
 		CreatedAt: time.Now(),
 	}
 
 	// register containerd image metadata.
-	if _, err := client.ImageService().Update(ctx, img); err != nil {
+	if _, err := client.ImageService().Update(ctx, cImg); err != nil {
 		if !errdefs.IsNotFound(err) {
 			return "", fmt.Errorf("failed to cover exist image %s", err)
 		}
 
-		if _, err := client.ImageService().Create(ctx, img); err != nil {
+		if _, err := client.ImageService().Create(ctx, cImg); err != nil {
 			return "", fmt.Errorf("failed to create new image %s", err)
 		}
 	}
@@ -308,13 +314,19 @@ func newImageConfig(c *types.ContainerConfig) ocispec.ImageConfig {
 			volumes[i] = struct{}(nv)
 		}
 	}
+	exposedPorts := make(map[string]struct{})
+	for port := range c.ExposedPorts {
+		exposedPorts[port] = struct{}{}
+	}
 	return ocispec.ImageConfig{
-		User:       c.User,
-		Env:        c.Env,
-		Entrypoint: c.Entrypoint,
-		Cmd:        c.Cmd,
-		Volumes:    volumes,
-		WorkingDir: c.WorkingDir,
-		Labels:     c.Labels,
+		User:         c.User,
+		ExposedPorts: exposedPorts,
+		Env:          c.Env,
+		Entrypoint:   c.Entrypoint,
+		Cmd:          c.Cmd,
+		Volumes:      volumes,
+		WorkingDir:   c.WorkingDir,
+		Labels:       c.Labels,
+		StopSignal:   c.StopSignal,
 	}
 }
@@ -0,0 +1,143 @@
+package ctrd
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	ctrdmetaimages "github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// defaultMaxConcurrentDownloads is the default max number of layers that
+// can be downloaded at the same time, which is the same as docker.
+const defaultMaxConcurrentDownloads = 3
+
+type maxConcurrentDownloadsKey struct{}
+
+// WithPullMaxConcurrentDownloads sets the max concurrent downloads of one
+// pull in context, which can't exceed the limit of daemon.
+func WithPullMaxConcurrentDownloads(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, maxConcurrentDownloadsKey{}, n)
+}
+
+// GetPullMaxConcurrentDownloads gets the max concurrent downloads of one pull
+// from context, and zero will be returned if it isn't set.
+func GetPullMaxConcurrentDownloads(ctx context.Context) int {
+	n, _ := ctx.Value(maxConcurrentDownloadsKey{}).(int)
+	return n
+}
+
+// downloadLimiter bounds the number of concurrent downloads.
+type downloadLimiter chan struct{}
+
+// newDownloadLimiter returns the limiter which allows n downloads at the
+// same time.
+func newDownloadLimiter(n int) downloadLimiter {
+	return make(downloadLimiter, n)
+}
+
+// acquire waits for an available slot until the context is done.
+func (l downloadLimiter) acquire(ctx context.Context) error {
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release returns the slot.
+func (l downloadLimiter) release() {
+	<-l
+}
+
+// limitedResolver wraps the resolver so that the layer downloads are bounded
+// by the limiters.
+//
+// NOTE: the limiter of daemon is shared by all the pulls, and the limiter of
+// the pull only limits the downloads of itself.
+type limitedResolver struct {
+	remotes.Resolver
+
+	limiters []downloadLimiter
+}
+
+// Fetcher returns the fetcher with limit.
+func (r *limitedResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	fetcher, err := r.Resolver.Fetcher(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &limitedFetcher{fetcher: fetcher, limiters: r.limiters}, nil
+}
+
+// limitedFetcher holds the slots of limiters until the layer is downloaded.
+type limitedFetcher struct {
+	fetcher  remotes.Fetcher
+	limiters []downloadLimiter
+}
+
+// Fetch waits for the slots before fetching the layer, and the slots are
+// released after the reader is closed.
+func (f *limitedFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	// the manifest and config are small, no need to wait for them.
+	if !isLayerMediaType(desc.MediaType) {
+		return f.fetcher.Fetch(ctx, desc)
+	}
+
+	acquired := make([]downloadLimiter, 0, len(f.limiters))
+	release := func() {
+		for _, l := range acquired {
+			l.release()
+		}
+	}
+
+	for _, l := range f.limiters {
+		if err := l.acquire(ctx); err != nil {
+			release()
+			return nil, err
+		}
+		acquired = append(acquired, l)
+	}
+
+	rc, err := f.fetcher.Fetch(ctx, desc)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return &limitedReadCloser{ReadCloser: rc, release: release}, nil
+}
+
+// limitedReadCloser releases the slots when it is closed.
+type limitedReadCloser struct {
+	io.ReadCloser
+
+	once    sync.Once
+	release func()
+}
+
+// Close implements io.Closer.
+func (rc *limitedReadCloser) Close() error {
+	err := rc.ReadCloser.Close()
+	rc.once.Do(rc.release)
+	return err
+}
+
+// isLayerMediaType returns true if the media type is layer.
+func isLayerMediaType(mediaType string) bool {
+	switch mediaType {
+	case ctrdmetaimages.MediaTypeDockerSchema2Layer,
+		ctrdmetaimages.MediaTypeDockerSchema2LayerGzip,
+		ctrdmetaimages.MediaTypeDockerSchema2LayerForeign,
+		ctrdmetaimages.MediaTypeDockerSchema2LayerForeignGzip,
+		ocispec.MediaTypeImageLayer,
+		ocispec.MediaTypeImageLayerGzip,
+		ocispec.MediaTypeImageLayerNonDistributable,
+		ocispec.MediaTypeImageLayerNonDistributableGzip:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,130 @@
+package ctrd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/remotes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingFetcher records the max number of readers opened at the same time.
+type countingFetcher struct {
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (f *countingFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.current++
+	if f.current > f.max {
+		f.max = f.current
+	}
+	return &countingReadCloser{Reader: bytes.NewReader([]byte("layer")), f: f}, nil
+}
+
+type countingReadCloser struct {
+	io.Reader
+	f *countingFetcher
+}
+
+func (rc *countingReadCloser) Close() error {
+	rc.f.mu.Lock()
+	defer rc.f.mu.Unlock()
+
+	rc.f.current--
+	return nil
+}
+
+// fetchLayers fetches n layers concurrently through the limited fetcher, and
+// every reader is held for a while before closed.
+func fetchLayers(t *testing.T, fetcher remotes.Fetcher, mediaType string, n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			rc, err := fetcher.Fetch(context.Background(), ocispec.Descriptor{MediaType: mediaType})
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer rc.Close()
+
+			time.Sleep(20 * time.Millisecond)
+			_, err = ioutil.ReadAll(rc)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLimitedFetcher(t *testing.T) {
+	daemonLimiter := newDownloadLimiter(3)
+
+	// the limit of daemon applies across pulls
+	{
+		backend := &countingFetcher{}
+		pull1 := &limitedFetcher{fetcher: backend, limiters: []downloadLimiter{daemonLimiter}}
+		pull2 := &limitedFetcher{fetcher: backend, limiters: []downloadLimiter{daemonLimiter}}
+
+		var wg sync.WaitGroup
+		for _, f := range []remotes.Fetcher{pull1, pull2} {
+			wg.Add(1)
+			go func(f remotes.Fetcher) {
+				defer wg.Done()
+				fetchLayers(t, f, ocispec.MediaTypeImageLayerGzip, 5)
+			}(f)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 3, backend.max)
+		assert.Equal(t, 0, len(daemonLimiter))
+	}
+
+	// the limit of pull lowers the limit of daemon
+	{
+		backend := &countingFetcher{}
+		pull := &limitedFetcher{fetcher: backend, limiters: []downloadLimiter{newDownloadLimiter(1), daemonLimiter}}
+		fetchLayers(t, pull, ocispec.MediaTypeImageLayerGzip, 4)
+		assert.Equal(t, 1, backend.max)
+	}
+
+	// manifest and config are not limited
+	{
+		backend := &countingFetcher{}
+		pull := &limitedFetcher{fetcher: backend, limiters: []downloadLimiter{daemonLimiter}}
+		fetchLayers(t, pull, ocispec.MediaTypeImageManifest, 5)
+		assert.Equal(t, 5, backend.max)
+	}
+}
+
+func TestLimitedFetcherCancelled(t *testing.T) {
+	limiter := newDownloadLimiter(1)
+	assert.NoError(t, limiter.acquire(context.Background()))
+	defer limiter.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	f := &limitedFetcher{fetcher: &countingFetcher{}, limiters: []downloadLimiter{newDownloadLimiter(1), limiter}}
+	_, err := f.Fetch(ctx, ocispec.Descriptor{MediaType: ocispec.MediaTypeImageLayer})
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// the slot of the first limiter should be released
+	assert.Equal(t, 0, len(f.limiters[0]))
+}
+
+func TestPullMaxConcurrentDownloads(t *testing.T) {
+	assert.Equal(t, 0, GetPullMaxConcurrentDownloads(context.Background()))
+	assert.Equal(t, 2, GetPullMaxConcurrentDownloads(WithPullMaxConcurrentDownloads(context.Background(), 2)))
+}
@@ -0,0 +1,231 @@
+package ctrd
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/containerd/containerd/content"
+	ctrdmetaimages "github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// dockerManifestFile is the manifest file in the tar archive produced by docker save.
+const dockerManifestFile = "manifest.json"
+
+// exportedImage represents the image to be exported into tar archive.
+type exportedImage struct {
+	// desc is the target of image. The org.opencontainers.image.ref.name
+	// annotation of desc will be recorded in the index.json.
+	desc ocispec.Descriptor
+
+	// repoTag will be recorded in the manifest.json for docker.
+	repoTag string
+}
+
+// dockerManifest is the item of manifest.json produced by docker save.
+type dockerManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// archiveExporter exports images into one tar archive which contains both
+// the OCI image layout and the manifest.json of docker save format, so that
+// the archive can be loaded by pouch, containerd and docker.
+type archiveExporter struct {
+	store    content.Provider
+	platform platforms.MatchComparer
+}
+
+// tarRecord is the entry in the tar archive.
+type tarRecord struct {
+	header *tar.Header
+	copyTo func(context.Context, io.Writer) (int64, error)
+}
+
+// Export writes the images into writer as tar archive.
+func (e *archiveExporter) Export(ctx context.Context, imgs []exportedImage, writer io.Writer) error {
+	var (
+		records   []tarRecord
+		indexes   []ocispec.Descriptor
+		manifests []*dockerManifest
+
+		blobs          = make(map[digest.Digest]struct{})
+		algorithms     = make(map[string]struct{})
+		manifestByDesc = make(map[digest.Digest]*dockerManifest)
+	)
+
+	exportHandler := func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		if _, ok := blobs[desc.Digest]; ok {
+			return nil, nil
+		}
+		blobs[desc.Digest] = struct{}{}
+		algorithms[desc.Digest.Algorithm().String()] = struct{}{}
+
+		records = append(records, blobRecord(e.store, desc))
+		return nil, nil
+	}
+
+	handlers := ctrdmetaimages.Handlers(
+		ctrdmetaimages.FilterPlatforms(ctrdmetaimages.ChildrenHandler(e.store), e.platform),
+		ctrdmetaimages.HandlerFunc(exportHandler),
+	)
+
+	for _, img := range imgs {
+		indexes = append(indexes, img.desc)
+
+		// Walk sequentially because the handler isn't thread-safe.
+		if err := ctrdmetaimages.Walk(ctx, handlers, img.desc); err != nil {
+			return errors.Wrapf(err, "failed to walk image %s", img.desc.Digest)
+		}
+
+		dm, ok := manifestByDesc[img.desc.Digest]
+		if !ok {
+			manifest, err := ctrdmetaimages.Manifest(ctx, e.store, img.desc, e.platform)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get manifest of image %s", img.desc.Digest)
+			}
+
+			dm = &dockerManifest{
+				Config:   blobPath(manifest.Config.Digest),
+				RepoTags: []string{},
+				Layers:   make([]string, 0, len(manifest.Layers)),
+			}
+			for _, layer := range manifest.Layers {
+				dm.Layers = append(dm.Layers, blobPath(layer.Digest))
+			}
+
+			manifestByDesc[img.desc.Digest] = dm
+			manifests = append(manifests, dm)
+		}
+
+		if img.repoTag != "" {
+			dm.RepoTags = append(dm.RepoTags, img.repoTag)
+		}
+	}
+
+	index := ocispec.Index{
+		Versioned: ocispecs.Versioned{
+			SchemaVersion: 2,
+		},
+		Manifests: indexes,
+	}
+
+	for name, v := range map[string]interface{}{
+		ocispec.ImageLayoutFile: ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion},
+		"index.json":            index,
+		dockerManifestFile:      manifests,
+	} {
+		record, err := jsonRecord(name, v)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+
+	records = append(records, directoryRecord("blobs/"))
+	for alg := range algorithms {
+		records = append(records, directoryRecord("blobs/"+alg+"/"))
+	}
+	return writeTar(ctx, tar.NewWriter(writer), records)
+}
+
+// blobPath returns the path of blob in the tar archive.
+func blobPath(dgst digest.Digest) string {
+	return "blobs/" + dgst.Algorithm().String() + "/" + dgst.Hex()
+}
+
+// blobRecord returns the record which copies the blob from content store.
+func blobRecord(store content.Provider, desc ocispec.Descriptor) tarRecord {
+	return tarRecord{
+		header: &tar.Header{
+			Name:     blobPath(desc.Digest),
+			Mode:     0444,
+			Size:     desc.Size,
+			Typeflag: tar.TypeReg,
+		},
+		copyTo: func(ctx context.Context, w io.Writer) (int64, error) {
+			r, err := store.ReaderAt(ctx, desc)
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed to get reader of blob %s", desc.Digest)
+			}
+			defer r.Close()
+
+			// make sure that the blob isn't broken
+			verifier := desc.Digest.Verifier()
+			n, err := io.Copy(io.MultiWriter(w, verifier), content.NewReader(r))
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed to copy blob %s", desc.Digest)
+			}
+			if !verifier.Verified() {
+				return 0, errors.Errorf("blob %s is broken", desc.Digest)
+			}
+			return n, nil
+		},
+	}
+}
+
+// jsonRecord returns the record of the json file.
+func jsonRecord(name string, v interface{}) (tarRecord, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return tarRecord{}, errors.Wrapf(err, "failed to marshal %s", name)
+	}
+
+	return tarRecord{
+		header: &tar.Header{
+			Name:     name,
+			Mode:     0444,
+			Size:     int64(len(data)),
+			Typeflag: tar.TypeReg,
+		},
+		copyTo: func(ctx context.Context, w io.Writer) (int64, error) {
+			n, err := w.Write(data)
+			return int64(n), err
+		},
+	}, nil
+}
+
+// directoryRecord returns the record of directory.
+func directoryRecord(name string) tarRecord {
+	return tarRecord{
+		header: &tar.Header{
+			Name:     name,
+			Mode:     0755,
+			Typeflag: tar.TypeDir,
+		},
+	}
+}
+
+// writeTar writes the records into tar archive in order of name.
+func writeTar(ctx context.Context, tw *tar.Writer, records []tarRecord) error {
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].header.Name < records[j].header.Name
+	})
+
+	for _, record := range records {
+		if err := tw.WriteHeader(record.header); err != nil {
+			return err
+		}
+
+		if record.copyTo == nil {
+			continue
+		}
+
+		n, err := record.copyTo(ctx, tw)
+		if err != nil {
+			return err
+		}
+		if n != record.header.Size {
+			return errors.Errorf("unexpected copy size for %s", record.header.Name)
+		}
+	}
+	return tw.Close()
+}
@@ -0,0 +1,147 @@
+package ctrd
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/platforms"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore is the content provider in memory.
+type memoryStore map[digest.Digest][]byte
+
+type memoryReaderAt struct {
+	*bytes.Reader
+}
+
+func (r memoryReaderAt) Close() error {
+	return nil
+}
+
+func (s memoryStore) ReaderAt(ctx context.Context, desc ocispec.Descriptor) (content.ReaderAt, error) {
+	data, ok := s[desc.Digest]
+	if !ok {
+		return nil, errdefs.ErrNotFound
+	}
+	return memoryReaderAt{bytes.NewReader(data)}, nil
+}
+
+func (s memoryStore) add(t *testing.T, mediaType string, v interface{}) ocispec.Descriptor {
+	data, ok := v.([]byte)
+	if !ok {
+		var err error
+		data, err = json.Marshal(v)
+		assert.NoError(t, err)
+	}
+
+	dgst := digest.FromBytes(data)
+	s[dgst] = data
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      int64(len(data)),
+	}
+}
+
+func TestArchiveExporter(t *testing.T) {
+	store := memoryStore{}
+	platform := platforms.DefaultSpec()
+
+	layer := store.add(t, ocispec.MediaTypeImageLayerGzip, []byte("layer"))
+	config := store.add(t, ocispec.MediaTypeImageConfig, ocispec.Image{
+		Architecture: platform.Architecture,
+		OS:           platform.OS,
+	})
+	manifest := store.add(t, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned: ocispecs.Versioned{SchemaVersion: 2},
+		Config:    config,
+		Layers:    []ocispec.Descriptor{layer},
+	})
+
+	exporter := &archiveExporter{
+		store:    store,
+		platform: platforms.Default(),
+	}
+
+	imgs := []exportedImage{
+		{desc: manifest, repoTag: "docker.io/library/busybox:latest"},
+		{desc: manifest, repoTag: "docker.io/library/busybox:1.28"},
+		{desc: manifest},
+	}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, exporter.Export(context.Background(), imgs, buf))
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadAll(tr)
+		assert.NoError(t, err)
+		files[hdr.Name] = data
+	}
+
+	for _, name := range []string{
+		"oci-layout",
+		"index.json",
+		"manifest.json",
+		"blobs/",
+		"blobs/sha256/",
+		blobPath(layer.Digest),
+		blobPath(config.Digest),
+		blobPath(manifest.Digest),
+	} {
+		_, ok := files[name]
+		assert.True(t, ok, "missing %s in archive", name)
+	}
+	assert.Equal(t, 8, len(files))
+	assert.Equal(t, []byte("layer"), files[blobPath(layer.Digest)])
+
+	// the same image should be merged into one item in manifest.json
+	var dms []dockerManifest
+	assert.NoError(t, json.Unmarshal(files["manifest.json"], &dms))
+	assert.Equal(t, []dockerManifest{{
+		Config:   blobPath(config.Digest),
+		RepoTags: []string{"docker.io/library/busybox:latest", "docker.io/library/busybox:1.28"},
+		Layers:   []string{blobPath(layer.Digest)},
+	}}, dms)
+
+	var index ocispec.Index
+	assert.NoError(t, json.Unmarshal(files["index.json"], &index))
+	assert.Equal(t, 3, len(index.Manifests))
+}
+
+func TestArchiveExporterMissingBlob(t *testing.T) {
+	store := memoryStore{}
+	layer := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayerGzip,
+		Digest:    digest.FromString("missing"),
+		Size:      7,
+	}
+	config := store.add(t, ocispec.MediaTypeImageConfig, ocispec.Image{})
+	manifest := store.add(t, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned: ocispecs.Versioned{SchemaVersion: 2},
+		Config:    config,
+		Layers:    []ocispec.Descriptor{layer},
+	})
+
+	exporter := &archiveExporter{store: store}
+	err := exporter.Export(context.Background(), []exportedImage{{desc: manifest}}, ioutil.Discard)
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package ctrd
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/alibaba/pouch/pkg/jsonstream"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// errImportFinished is used to stop verifying the archive when the import
+// has been finished.
+var errImportFinished = errors.New("import has been finished")
+
+// archiveTeeReader copies the archive read by importer to the verifier.
+type archiveTeeReader struct {
+	r  io.Reader
+	pw *io.PipeWriter
+}
+
+// Read implements io.Reader interface.
+func (t *archiveTeeReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if n > 0 {
+		if _, werr := t.pw.Write(p[:n]); werr != nil {
+			return n, werr
+		}
+	}
+
+	// let verifier know that the archive is end, so that it can tell
+	// whether the archive is truncated or not.
+	if err == io.EOF {
+		t.pw.Close()
+	}
+	return n, err
+}
+
+// verifyArchive reads the tar archive, reports the loading progress of
+// blobs and makes sure that the content of blob matches its digest.
+//
+// NOTE: only the blobs in OCI image layout can be verified, because the
+// name of the blob is its digest.
+func verifyArchive(r io.Reader, stream *jsonstream.JSONStream) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read the tar archive")
+		}
+
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		dgst, ok := blobDigest(hdr.Name)
+		if !ok {
+			continue
+		}
+
+		id := strings.TrimPrefix(dgst.String(), dgst.Algorithm().String()+":")
+		if len(id) > 12 {
+			id = id[:12]
+		}
+
+		stream.WriteObject(jsonstream.JSONMessage{
+			ID:     id,
+			Status: jsonstream.LoadStatusLoading,
+			Detail: &jsonstream.ProgressDetail{Total: hdr.Size},
+		})
+
+		verifier := dgst.Verifier()
+		n, err := io.Copy(verifier, tr)
+		if err != nil {
+			return errors.Wrapf(err, "blob %s is truncated, only %d of %d bytes are read", dgst, n, hdr.Size)
+		}
+		if !verifier.Verified() {
+			return errors.Errorf("blob %s is corrupted, the content doesn't match the digest", dgst)
+		}
+
+		stream.WriteObject(jsonstream.JSONMessage{
+			ID:     id,
+			Status: jsonstream.PullStatusDone,
+			Detail: &jsonstream.ProgressDetail{Current: hdr.Size, Total: hdr.Size},
+		})
+	}
+}
+
+// blobDigest returns the digest of blob by the name in OCI image layout,
+// like blobs/sha256/<hex>.
+func blobDigest(name string) (digest.Digest, bool) {
+	parts := strings.Split(path.Clean(name), "/")
+	if len(parts) != 3 || parts[0] != "blobs" {
+		return "", false
+	}
+
+	dgst := digest.NewDigestFromHex(parts[1], parts[2])
+	if err := dgst.Validate(); err != nil {
+		return "", false
+	}
+	return dgst, true
+}
+
+// startVerifyArchive starts to verify the archive read by importer in the
+// background. The returned function must be called when the import is
+// finished, and returns the error found in the archive.
+func startVerifyArchive(reader io.Reader, stream *jsonstream.JSONStream) (io.Reader, func() error) {
+	pr, pw := io.Pipe()
+	verified := make(chan error, 1)
+
+	go func() {
+		err := verifyArchive(pr, stream)
+		if err != nil {
+			// stop the importer as soon as possible
+			pr.CloseWithError(err)
+		} else {
+			// drain the padding of archive, the importer will not be
+			// blocked.
+			io.Copy(ioutil.Discard, pr)
+		}
+		verified <- err
+	}()
+
+	return &archiveTeeReader{r: reader, pw: pw}, func() error {
+		pw.CloseWithError(errImportFinished)
+
+		err := <-verified
+		if errors.Cause(err) == errImportFinished {
+			return nil
+		}
+		return err
+	}
+}
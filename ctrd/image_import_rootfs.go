@@ -0,0 +1,142 @@
+package ctrd
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/randomid"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ImportRootfsConfig defines options for importing an image from the tar
+// archive of rootfs.
+type ImportRootfsConfig struct {
+	// reference
+	Reference string
+
+	// comment
+	Comment string
+
+	// config of the image
+	ContainerConfig *types.ContainerConfig
+}
+
+// ImportRootfs creates an image with single layer from the tar archive of
+// rootfs, such as the one exported from a container.
+func (c *Client) ImportRootfs(ctx context.Context, config *ImportRootfsConfig, reader io.Reader) (_ digest.Digest, err0 error) {
+	wrapperCli, err := c.Get(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get a containerd grpc client: %v", err)
+	}
+	client := wrapperCli.client
+
+	// NOTE: make sure that gc scheduler doesn't remove content/snapshot during import
+	ctx, done, err := withTemporaryLease(ctx, wrapperCli, "import")
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create lease for import")
+	}
+	defer done()
+
+	var (
+		sn     = client.SnapshotService(CurrentSnapshotterName(ctx))
+		cs     = client.ContentStore()
+		differ = client.DiffService()
+	)
+
+	layer, diffID, err := writeRootfsLayer(ctx, cs, reader)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to write layer")
+	}
+
+	createdTime := time.Now()
+	img := ocispec.Image{
+		Architecture: runtime.GOARCH,
+		OS:           runtime.GOOS,
+		Created:      &createdTime,
+		Config:       newImageConfig(config.ContainerConfig),
+		RootFS: ocispec.RootFS{
+			Type:    "layers",
+			DiffIDs: []digest.Digest{diffID},
+		},
+		History: []ocispec.History{{
+			Created:   &createdTime,
+			CreatedBy: "pouch import",
+			Comment:   config.Comment,
+		}},
+	}
+
+	// create new snapshot for the layer, which has no parent.
+	rootfsID := identity.ChainID(img.RootFS.DiffIDs).String()
+	if err = newSnapshot(ctx, rootfsID, ocispec.Image{}, sn, differ, layer); err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if err0 != nil {
+			logrus.Warnf("remove snapshot %s cause import image failed", rootfsID)
+			sn.Remove(ctx, rootfsID)
+		}
+	}()
+
+	return writeImage(ctx, client, config.Reference, img, []ocispec.Descriptor{layer}, rootfsID)
+}
+
+// writeRootfsLayer compresses the tar archive into the content store as a
+// layer, and returns the descriptor and the diffID of it.
+func writeRootfsLayer(ctx context.Context, cs content.Store, reader io.Reader) (ocispec.Descriptor, digest.Digest, error) {
+	w, err := content.OpenWriter(ctx, cs, content.WithRef("import-"+randomid.Generate()))
+	if err != nil {
+		return ocispec.Descriptor{}, "", err
+	}
+	defer w.Close()
+
+	var (
+		uncompressed = digest.Canonical.Digester()
+		compressed   = digest.Canonical.Digester()
+		counter      = &countWriter{}
+	)
+
+	gw := gzip.NewWriter(io.MultiWriter(w, compressed.Hash(), counter))
+	if _, err := io.Copy(io.MultiWriter(gw, uncompressed.Hash()), reader); err != nil {
+		return ocispec.Descriptor{}, "", err
+	}
+	if err := gw.Close(); err != nil {
+		return ocispec.Descriptor{}, "", err
+	}
+
+	labels := map[string]string{
+		containerdUncompressed: uncompressed.Digest().String(),
+	}
+	if err := w.Commit(ctx, counter.size, compressed.Digest(), content.WithLabels(labels)); err != nil && !errdefs.IsAlreadyExists(err) {
+		return ocispec.Descriptor{}, "", err
+	}
+
+	return ocispec.Descriptor{
+		MediaType: layerType,
+		Digest:    compressed.Digest(),
+		Size:      counter.size,
+	}, uncompressed.Digest(), nil
+}
+
+// countWriter counts the size of written data.
+type countWriter struct {
+	size int64
+}
+
+// Write implements io.Writer.
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.size += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,117 @@
+package ctrd
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/pkg/jsonstream"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestArchive returns the tar archive with the blobs.
+func newTestArchive(t *testing.T, blobs map[digest.Digest][]byte) []byte {
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+
+	hdr := &tar.Header{Name: "oci-layout", Mode: 0444, Size: 2, Typeflag: tar.TypeReg}
+	assert.NoError(t, tw.WriteHeader(hdr))
+	_, err := tw.Write([]byte("{}"))
+	assert.NoError(t, err)
+
+	for dgst, data := range blobs {
+		hdr := &tar.Header{Name: blobPath(dgst), Mode: 0444, Size: int64(len(data)), Typeflag: tar.TypeReg}
+		assert.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write(data)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func TestVerifyArchive(t *testing.T) {
+	layer := []byte(strings.Repeat("layer", 1024))
+	layerDigest := digest.FromBytes(layer)
+	brokenDigest := digest.FromString("broken")
+
+	for _, tc := range []struct {
+		name    string
+		archive []byte
+		errMsg  string
+	}{
+		{
+			name:    "valid archive",
+			archive: newTestArchive(t, map[digest.Digest][]byte{layerDigest: layer}),
+		},
+		{
+			name:    "corrupted blob",
+			archive: newTestArchive(t, map[digest.Digest][]byte{brokenDigest: layer}),
+			errMsg:  "blob " + brokenDigest.String() + " is corrupted",
+		},
+		{
+			name:    "truncated blob",
+			archive: newTestArchive(t, map[digest.Digest][]byte{layerDigest: layer})[:2048],
+			errMsg:  "blob " + layerDigest.String() + " is truncated",
+		},
+	} {
+		out := new(bytes.Buffer)
+		stream := jsonstream.New(out, nil)
+
+		// the importer reads the whole archive
+		reader, finish := startVerifyArchive(bytes.NewReader(tc.archive), stream)
+		_, err := ioutil.ReadAll(reader)
+
+		verr := finish()
+		stream.Close()
+		stream.Wait()
+
+		if tc.errMsg == "" {
+			assert.NoError(t, err, tc.name)
+			assert.NoError(t, verr, tc.name)
+			assert.Contains(t, out.String(), `"status":"done"`, tc.name)
+			continue
+		}
+		assert.Error(t, verr, tc.name)
+		assert.Contains(t, verr.Error(), tc.errMsg, tc.name)
+	}
+}
+
+func TestVerifyArchiveImportStopped(t *testing.T) {
+	layer := []byte(strings.Repeat("layer", 1024))
+	archive := newTestArchive(t, map[digest.Digest][]byte{digest.FromBytes(layer): layer})
+
+	out := new(bytes.Buffer)
+	stream := jsonstream.New(out, nil)
+	defer stream.Wait()
+	defer stream.Close()
+
+	// the error should be reported by importer if it fails before reading
+	// the whole archive.
+	reader, finish := startVerifyArchive(bytes.NewReader(archive), stream)
+	_, err := reader.Read(make([]byte, 1024))
+	assert.NoError(t, err)
+	assert.NoError(t, finish())
+}
+
+func TestBlobDigest(t *testing.T) {
+	hex := "141c253bc4c3fd0a201d32dc1f493bcf3fff003b6df416dea4f41046e0f37d47"
+
+	dgst, ok := blobDigest("blobs/sha256/" + hex)
+	assert.True(t, ok)
+	assert.Equal(t, digest.Digest("sha256:"+hex), dgst)
+
+	for _, name := range []string{
+		"index.json",
+		"manifest.json",
+		"blobs/sha256/",
+		"blobs/sha256/invalid",
+		hex + "/layer.tar",
+	} {
+		_, ok := blobDigest(name)
+		assert.False(t, ok, name)
+	}
+}
@@ -0,0 +1,117 @@
+package ctrd
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/containerd/containerd/remotes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// dockerHubHosts are the hosts of docker hub, and the registry mirrors only
+// apply to the images of docker hub.
+var dockerHubHosts = map[string]struct{}{
+	"docker.io":               {},
+	"index.docker.io":         {},
+	"registry-1.docker.io":    {},
+	"registry.hub.docker.com": {},
+}
+
+// isDockerHub returns true if the host is docker hub.
+func isDockerHub(host string) bool {
+	_, ok := dockerHubHosts[host]
+	return ok
+}
+
+// mirrorResolver tries the registry mirrors in order before the upstream
+// registry, and falls back to the next one if the mirror fails.
+//
+// NOTE: the image is pushed to the upstream registry, since the mirror is
+// read-only.
+type mirrorResolver struct {
+	// names are used to log the failed mirror.
+	names []string
+	// resolvers are the resolvers of mirrors, and the last one is upstream.
+	resolvers []remotes.Resolver
+
+	mu sync.Mutex
+	// resolved is the index of resolver which resolves the reference, and
+	// the fetch starts from it.
+	resolved int
+}
+
+// Resolve resolves the reference from the mirrors and upstream in order.
+func (r *mirrorResolver) Resolve(ctx context.Context, ref string) (string, ocispec.Descriptor, error) {
+	var lastErr error
+	for i, resolver := range r.resolvers {
+		name, desc, err := resolver.Resolve(ctx, ref)
+		if err == nil {
+			r.mu.Lock()
+			r.resolved = i
+			r.mu.Unlock()
+			return name, desc, nil
+		}
+
+		if ctx.Err() != nil {
+			return "", ocispec.Descriptor{}, err
+		}
+
+		if i < len(r.resolvers)-1 {
+			logrus.Warnf("failed to resolve %s from %s, fall back to %s: %v", ref, r.names[i], r.names[i+1], err)
+		}
+		lastErr = err
+	}
+	return "", ocispec.Descriptor{}, lastErr
+}
+
+// Fetcher returns the fetcher which fetches from the resolved one, and then
+// the remaining ones in order.
+func (r *mirrorResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	r.mu.Lock()
+	start := r.resolved
+	r.mu.Unlock()
+
+	f := &mirrorFetcher{names: r.names[start:]}
+	for _, resolver := range r.resolvers[start:] {
+		fetcher, err := resolver.Fetcher(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		f.fetchers = append(f.fetchers, fetcher)
+	}
+	return f, nil
+}
+
+// Pusher returns the pusher of upstream registry.
+func (r *mirrorResolver) Pusher(ctx context.Context, ref string) (remotes.Pusher, error) {
+	return r.resolvers[len(r.resolvers)-1].Pusher(ctx, ref)
+}
+
+// mirrorFetcher fetches the content from the fetchers in order.
+type mirrorFetcher struct {
+	names    []string
+	fetchers []remotes.Fetcher
+}
+
+// Fetch returns the content from the first fetcher which succeeds.
+func (f *mirrorFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	var lastErr error
+	for i, fetcher := range f.fetchers {
+		rc, err := fetcher.Fetch(ctx, desc)
+		if err == nil {
+			return rc, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		if i < len(f.fetchers)-1 {
+			logrus.Warnf("failed to fetch %s from %s, fall back to %s: %v", desc.Digest, f.names[i], f.names[i+1], err)
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,178 @@
+package ctrd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubResolver resolves and fetches the blobs it has, and records the calls.
+type stubResolver struct {
+	name  string
+	root  *ocispec.Descriptor
+	blobs map[digest.Digest][]byte
+
+	calls *[]string
+}
+
+func (r *stubResolver) Resolve(ctx context.Context, ref string) (string, ocispec.Descriptor, error) {
+	*r.calls = append(*r.calls, "resolve "+r.name)
+	if r.root == nil {
+		return "", ocispec.Descriptor{}, fmt.Errorf("%s is unavailable", r.name)
+	}
+	return ref, *r.root, nil
+}
+
+func (r *stubResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	return remotes.FetcherFunc(func(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+		*r.calls = append(*r.calls, "fetch "+r.name)
+		data, ok := r.blobs[desc.Digest]
+		if !ok {
+			return nil, fmt.Errorf("blob %s not found in %s", desc.Digest, r.name)
+		}
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	}), nil
+}
+
+func (r *stubResolver) Pusher(ctx context.Context, ref string) (remotes.Pusher, error) {
+	*r.calls = append(*r.calls, "push "+r.name)
+	return nil, nil
+}
+
+func TestMirrorResolver(t *testing.T) {
+	var (
+		calls  []string
+		layer  = []byte("layer")
+		root   = ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("manifest")}
+		desc   = ocispec.Descriptor{Digest: digest.FromBytes(layer)}
+		broken = &stubResolver{name: "broken", calls: &calls}
+		mirror = &stubResolver{name: "mirror", root: &root, blobs: map[digest.Digest][]byte{}, calls: &calls}
+		hub    = &stubResolver{name: "hub", root: &root, blobs: map[digest.Digest][]byte{desc.Digest: layer}, calls: &calls}
+	)
+
+	r := &mirrorResolver{
+		names:     []string{"broken", "mirror", "hub"},
+		resolvers: []remotes.Resolver{broken, mirror, hub},
+	}
+
+	// the broken mirror falls back to the next mirror
+	_, got, err := r.Resolve(context.Background(), "docker.io/library/busybox:latest")
+	assert.NoError(t, err)
+	assert.Equal(t, root, got)
+
+	// the fetch starts from the resolved mirror, and falls back to hub if
+	// the mirror doesn't have the blob
+	fetcher, err := r.Fetcher(context.Background(), "docker.io/library/busybox:latest")
+	assert.NoError(t, err)
+
+	rc, err := fetcher.Fetch(context.Background(), desc)
+	assert.NoError(t, err)
+	data, err := ioutil.ReadAll(rc)
+	assert.NoError(t, err)
+	assert.Equal(t, layer, data)
+
+	_, err = r.Pusher(context.Background(), "docker.io/library/busybox:latest")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"resolve broken", "resolve mirror", "fetch mirror", "fetch hub", "push hub"}, calls)
+
+	// the error of upstream is returned if all fail
+	hub.root = nil
+	mirror.root = nil
+	_, _, err = r.Resolve(context.Background(), "docker.io/library/busybox:latest")
+	assert.EqualError(t, err, "hub is unavailable")
+}
+
+func TestGetResolverWithMirrors(t *testing.T) {
+	mirror, _ := url.Parse("https://mirror.example.com")
+	c := &Client{registryMirrors: []*url.URL{mirror}}
+
+	for ref, mirrored := range map[string]bool{
+		"docker.io/library/busybox:latest":               true,
+		"registry.hub.docker.com/library/busybox:latest": true,
+		"reg.abc.com/base/os:7.2":                        false,
+	} {
+		r, err := c.getResolver(nil, ref, docker.ResolverOptions{})
+		assert.NoError(t, err)
+
+		_, ok := r.(*mirrorResolver)
+		assert.Equal(t, mirrored, ok, ref)
+	}
+}
+
+func TestHTTPFallbackTransport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	c := &Client{insecureRegistries: []string{u.Host}}
+	httpCli := c.newRegistryHTTPClient(true)
+
+	// the plain http registry is requested by https at first
+	for i := 0; i < 2; i++ {
+		resp, err := httpCli.Get("https://" + u.Host + "/v2/")
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, 2, requests)
+
+	// the http is remembered for the registry
+	tr := httpCli.Transport.(*httpFallbackTransport)
+	_, ok := tr.plainHosts[u.Host]
+	assert.True(t, ok)
+
+	// the secure registry never falls back to http
+	_, err := (&Client{}).newRegistryHTTPClient(false).Get("https://" + u.Host + "/v2/")
+	assert.Error(t, err)
+}
+
+func TestUpdateRegistryOptions(t *testing.T) {
+	c := &Client{downloadLimiter: newDownloadLimiter(3)}
+	limiter := c.downloadLimiter
+
+	assert.NoError(t, c.UpdateRegistryOptions(
+		WithRegistryMirrors([]string{"https://mirror.example.com"}),
+		WithInsecureRegistries([]string{"reg.abc.com:5000"}),
+		WithMaxConcurrentDownloads(3),
+	))
+	r, err := c.getResolver(nil, "docker.io/library/busybox:latest", docker.ResolverOptions{})
+	assert.NoError(t, err)
+	_, ok := r.(*mirrorResolver)
+	assert.True(t, ok)
+	assert.True(t, c.isInsecureHost("reg.abc.com:5000"))
+	// the limiter is kept if the limit isn't changed.
+	assert.True(t, limiter == c.downloadLimiter)
+
+	// nothing is changed if any option is invalid.
+	err = c.UpdateRegistryOptions(
+		WithRegistryMirrors(nil),
+		WithInsecureRegistries([]string{"http://reg.abc.com:5000"}),
+		WithMaxConcurrentDownloads(5),
+	)
+	assert.Error(t, err)
+	assert.Len(t, c.registryMirrors, 1)
+	assert.True(t, c.isInsecureHost("reg.abc.com:5000"))
+	assert.Equal(t, 3, cap(c.downloadLimiter))
+
+	assert.NoError(t, c.UpdateRegistryOptions(WithRegistryMirrors(nil), WithInsecureRegistries(nil), WithMaxConcurrentDownloads(5)))
+	assert.Len(t, c.registryMirrors, 0)
+	assert.False(t, c.isInsecureHost("reg.abc.com:5000"))
+	assert.Equal(t, 5, cap(c.downloadLimiter))
+}
@@ -0,0 +1,134 @@
+package ctrd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	ctrdmetaimages "github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// ImagePlatformLabel is the label of image in containerd meta data, which
+// records the platform specified by user during pull.
+const ImagePlatformLabel = "pouch.io/image.platform"
+
+// ParsePlatform parses and normalizes the platform specifier, such as
+// linux/arm64 and linux/amd64/v2.
+func ParsePlatform(specifier string) (ocispec.Platform, error) {
+	p, err := platforms.Parse(specifier)
+	if err != nil {
+		return ocispec.Platform{}, err
+	}
+	return platforms.Normalize(p), nil
+}
+
+// ImagePlatform returns the platform recorded in the labels of image. The
+// default platform is returned if the image is pulled without platform.
+func ImagePlatform(labels map[string]string) ocispec.Platform {
+	if specifier, ok := labels[ImagePlatformLabel]; ok {
+		if p, err := ParsePlatform(specifier); err == nil {
+			return p
+		}
+	}
+	return platforms.DefaultSpec()
+}
+
+// ImagePlatformMatcher returns the matcher used to select the manifest from
+// the image index by the labels of image.
+func ImagePlatformMatcher(labels map[string]string) platforms.MatchComparer {
+	if _, ok := labels[ImagePlatformLabel]; !ok {
+		return platforms.Default()
+	}
+	return platforms.Only(ImagePlatform(labels))
+}
+
+// checkRemotePlatform makes sure that the remote image provides the
+// platform before pulling, so that nothing will be stored if the platform
+// is missing.
+func checkRemotePlatform(ctx context.Context, resolver remotes.Resolver, ref string, platform ocispec.Platform) error {
+	name, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return errors.Wrapf(err, "failed to resolve reference %q", ref)
+	}
+
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get fetcher for %q", name)
+	}
+
+	matcher := platforms.Only(platform)
+	available, err := remotePlatforms(ctx, fetcher, desc)
+	if err != nil {
+		return err
+	}
+
+	// the platform of schema1 manifest is unknown, let it go.
+	if available == nil {
+		return nil
+	}
+
+	for _, p := range available {
+		if matcher.Match(p) {
+			return nil
+		}
+	}
+
+	names := make([]string, 0, len(available))
+	for _, p := range available {
+		names = append(names, platforms.Format(p))
+	}
+	return fmt.Errorf("no matching manifest for %s in %s, available platforms: %s",
+		platforms.Format(platform), ref, strings.Join(names, ", "))
+}
+
+// remotePlatforms returns the platforms provided by the index or manifest.
+func remotePlatforms(ctx context.Context, fetcher remotes.Fetcher, desc ocispec.Descriptor) ([]ocispec.Platform, error) {
+	switch desc.MediaType {
+	case ctrdmetaimages.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+		var idx ocispec.Index
+		if err := readRemoteJSON(ctx, fetcher, desc, &idx); err != nil {
+			return nil, err
+		}
+
+		res := make([]ocispec.Platform, 0, len(idx.Manifests))
+		for _, m := range idx.Manifests {
+			if m.Platform != nil {
+				res = append(res, platforms.Normalize(*m.Platform))
+			}
+		}
+		return res, nil
+	case ctrdmetaimages.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+		var manifest ocispec.Manifest
+		if err := readRemoteJSON(ctx, fetcher, desc, &manifest); err != nil {
+			return nil, err
+		}
+
+		var img ocispec.Image
+		if err := readRemoteJSON(ctx, fetcher, manifest.Config, &img); err != nil {
+			return nil, err
+		}
+		return []ocispec.Platform{platforms.Normalize(ocispec.Platform{OS: img.OS, Architecture: img.Architecture})}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// readRemoteJSON reads the blob from the remote and decodes it into v.
+func readRemoteJSON(ctx context.Context, fetcher remotes.Fetcher, desc ocispec.Descriptor, v interface{}) error {
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch %s", desc.Digest)
+	}
+	defer rc.Close()
+
+	if err := json.NewDecoder(io.LimitReader(rc, desc.Size)).Decode(v); err != nil {
+		return errors.Wrapf(err, "failed to decode %s", desc.Digest)
+	}
+	return nil
+}
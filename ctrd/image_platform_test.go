@@ -0,0 +1,105 @@
+package ctrd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	ctrdmetaimages "github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeResolver resolves the reference into the root descriptor, and serves
+// the blobs from memory.
+type fakeResolver struct {
+	root  ocispec.Descriptor
+	blobs map[digest.Digest][]byte
+}
+
+func (r *fakeResolver) Resolve(ctx context.Context, ref string) (string, ocispec.Descriptor, error) {
+	return ref, r.root, nil
+}
+
+func (r *fakeResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	return remotes.FetcherFunc(func(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+		data, ok := r.blobs[desc.Digest]
+		if !ok {
+			return nil, fmt.Errorf("blob %s not found", desc.Digest)
+		}
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	}), nil
+}
+
+func (r *fakeResolver) Pusher(ctx context.Context, ref string) (remotes.Pusher, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// add stores the object as blob and returns the descriptor of it.
+func (r *fakeResolver) add(t *testing.T, mediaType string, v interface{}) ocispec.Descriptor {
+	data, err := json.Marshal(v)
+	assert.NoError(t, err)
+
+	desc := ocispec.Descriptor{MediaType: mediaType, Digest: digest.FromBytes(data), Size: int64(len(data))}
+	r.blobs[desc.Digest] = data
+	return desc
+}
+
+func TestCheckRemotePlatform(t *testing.T) {
+	r := &fakeResolver{blobs: make(map[digest.Digest][]byte)}
+
+	config := r.add(t, ocispec.MediaTypeImageConfig, ocispec.Image{OS: "linux", Architecture: "amd64"})
+	manifest := r.add(t, ocispec.MediaTypeImageManifest, ocispec.Manifest{Config: config})
+	index := r.add(t, ocispec.MediaTypeImageIndex, ocispec.Index{
+		Manifests: []ocispec.Descriptor{
+			{MediaType: ocispec.MediaTypeImageManifest, Digest: manifest.Digest, Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}},
+			{MediaType: ocispec.MediaTypeImageManifest, Digest: manifest.Digest, Platform: &ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}},
+		},
+	})
+
+	for _, tc := range []struct {
+		root     ocispec.Descriptor
+		platform string
+		errMsg   string
+	}{
+		{root: index, platform: "linux/arm64"},
+		{root: index, platform: "linux/amd64"},
+		{root: index, platform: "linux/s390x", errMsg: "available platforms: linux/amd64, linux/arm64"},
+		{root: manifest, platform: "linux/amd64"},
+		{root: manifest, platform: "linux/arm64", errMsg: "available platforms: linux/amd64"},
+		{root: ocispec.Descriptor{MediaType: ctrdmetaimages.MediaTypeDockerSchema1Manifest}, platform: "linux/arm64"},
+	} {
+		r.root = tc.root
+
+		p, err := ParsePlatform(tc.platform)
+		assert.NoError(t, err)
+
+		err = checkRemotePlatform(context.Background(), r, "busybox:latest", p)
+		if tc.errMsg == "" {
+			assert.NoError(t, err, tc.platform)
+			continue
+		}
+		assert.Error(t, err, tc.platform)
+		assert.Contains(t, err.Error(), tc.errMsg, tc.platform)
+	}
+}
+
+func TestImagePlatformMatcher(t *testing.T) {
+	arm64 := ocispec.Platform{OS: "linux", Architecture: "arm64"}
+
+	matcher := ImagePlatformMatcher(map[string]string{ImagePlatformLabel: "linux/arm64"})
+	assert.True(t, matcher.Match(arm64))
+	assert.False(t, matcher.Match(ocispec.Platform{OS: "linux", Architecture: "s390x"}))
+	assert.Equal(t, "arm64", ImagePlatform(map[string]string{ImagePlatformLabel: "linux/arm64"}).Architecture)
+
+	// the default platform is used for image pulled without platform
+	assert.True(t, ImagePlatformMatcher(nil).Match(platforms.DefaultSpec()))
+	assert.Equal(t, platforms.DefaultSpec(), ImagePlatform(nil))
+}
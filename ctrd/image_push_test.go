@@ -0,0 +1,31 @@
+package ctrd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/alibaba/pouch/pkg/jsonstream"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteStreamErrorUnauthorized(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	stream := jsonstream.New(buf, nil)
+
+	err := convertRegistryErr(errors.Wrap(docker.ErrInvalidAuthorization, "failed to push"))
+	writeStreamError(stream, err)
+	stream.Close()
+	stream.Wait()
+
+	var msg jsonstream.JSONMessage
+	assert.NoError(t, json.NewDecoder(buf).Decode(&msg))
+	if assert.NotNil(t, msg.Error) {
+		assert.Equal(t, http.StatusUnauthorized, msg.Error.Code)
+		assert.Equal(t, err.Error(), msg.Error.Message)
+	}
+}
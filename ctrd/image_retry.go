@@ -0,0 +1,227 @@
+package ctrd
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultMaxPullAttempts is the default max attempts to fetch content.
+	defaultMaxPullAttempts = 3
+	// defaultPullRetryBackoff is the default backoff before the first retry.
+	defaultPullRetryBackoff = time.Second
+	// maxPullRetryBackoff is the upper bound of backoff between retries.
+	maxPullRetryBackoff = 30 * time.Second
+)
+
+// unexpectedStatusRegexp matches the error returned by docker fetcher if the
+// registry responses the unexpected http status.
+var unexpectedStatusRegexp = regexp.MustCompile(`unexpected status code .*: (\d{3})`)
+
+// retryPolicy describes how to retry the failed fetch.
+type retryPolicy struct {
+	attempts int
+	backoff  time.Duration
+}
+
+// wait returns the backoff after the nth failed attempt, which is doubled
+// for each attempt.
+func (p retryPolicy) wait(n int) time.Duration {
+	d := p.backoff
+	for i := 1; i < n && d < maxPullRetryBackoff; i++ {
+		d *= 2
+	}
+
+	if d > maxPullRetryBackoff {
+		d = maxPullRetryBackoff
+	}
+	return d
+}
+
+// retryNotifier is called before retrying to fetch desc. The err is nil if
+// the fetch succeeds after retry.
+type retryNotifier func(desc ocispec.Descriptor, attempt int, err error)
+
+// retryResolver wraps the resolver so that the content fetch will be
+// retried on network errors and 5xx responses.
+//
+// NOTE: the fetch of content is http GET which is idempotent, and the
+// content will be verified by digest after download, so it is safe to
+// retry from the offset which has been read.
+type retryResolver struct {
+	remotes.Resolver
+
+	policy retryPolicy
+	notify retryNotifier
+}
+
+// Fetcher returns the fetcher with retry.
+func (r *retryResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	fetcher, err := r.Resolver.Fetcher(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &retryFetcher{fetcher: fetcher, policy: r.policy, notify: r.notify}, nil
+}
+
+// retryFetcher retries the fetch on retryable errors.
+type retryFetcher struct {
+	fetcher remotes.Fetcher
+	policy  retryPolicy
+	notify  retryNotifier
+}
+
+// Fetch opens the content with retry, and returns the reader which will
+// reopen the content if the connection is broken during reading.
+func (f *retryFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	r := &retryReader{ctx: ctx, desc: desc, fetcher: f}
+	if err := r.open(nil); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// backoff waits before the next attempt, and returns false if the attempts
+// has been used up or the context is done.
+func (f *retryFetcher) backoff(ctx context.Context, desc ocispec.Descriptor, attempt int, err error) bool {
+	if attempt >= f.policy.attempts || ctx.Err() != nil || !isRetryableFetchError(err) {
+		return false
+	}
+
+	wait := f.policy.wait(attempt)
+	logrus.Warnf("failed to fetch %s (attempt %d/%d), retry in %v: %v", desc.Digest, attempt, f.policy.attempts, wait, err)
+	if f.notify != nil {
+		f.notify(desc, attempt+1, err)
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// retryReader reads the content and reopens it from the current offset if
+// the read fails with retryable error.
+type retryReader struct {
+	ctx     context.Context
+	desc    ocispec.Descriptor
+	fetcher *retryFetcher
+
+	rc       io.ReadCloser
+	offset   int64
+	attempts int
+	retried  bool
+}
+
+// open fetches the content until it succeeds or the attempts are used up.
+// The cause is the error which makes the reader reopen.
+func (r *retryReader) open(cause error) error {
+	if cause != nil {
+		if !r.fetcher.backoff(r.ctx, r.desc, r.attempts, cause) {
+			return cause
+		}
+		r.retried = true
+	}
+
+	for {
+		r.attempts++
+
+		rc, err := r.fetcher.fetcher.Fetch(r.ctx, r.desc)
+		if err == nil {
+			// skip the content which has been read.
+			if r.offset > 0 {
+				if _, err = io.CopyN(ioutil.Discard, rc, r.offset); err != nil {
+					rc.Close()
+				}
+			}
+		}
+
+		if err == nil {
+			r.rc = rc
+			if r.retried && r.fetcher.notify != nil {
+				r.fetcher.notify(r.desc, r.attempts, nil)
+			}
+			return nil
+		}
+
+		if !r.fetcher.backoff(r.ctx, r.desc, r.attempts, err) {
+			return err
+		}
+		r.retried = true
+	}
+}
+
+// Read implements io.Reader.
+func (r *retryReader) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	r.offset += int64(n)
+
+	if err == nil || err == io.EOF {
+		return n, err
+	}
+
+	r.rc.Close()
+	if oerr := r.open(err); oerr != nil {
+		return n, oerr
+	}
+	return n, nil
+}
+
+// Close implements io.Closer.
+func (r *retryReader) Close() error {
+	return r.rc.Close()
+}
+
+// isRetryableFetchError returns true if the error is caused by network or
+// the 5xx response. The 401 and 404 will never be retried.
+func isRetryableFetchError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	cause := errors.Cause(err)
+	switch cause {
+	case context.Canceled, context.DeadlineExceeded:
+		return false
+	case io.ErrUnexpectedEOF, syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.EPIPE:
+		return true
+	}
+
+	if errdefs.IsNotFound(err) {
+		return false
+	}
+
+	if match := unexpectedStatusRegexp.FindStringSubmatch(err.Error()); match != nil {
+		code, _ := strconv.Atoi(match[1])
+		return code >= 500 && code <= 599
+	}
+
+	// the url error also implements net.Error, so check the underlying
+	// error, such as x509 error which should not be retried.
+	if uerr, ok := cause.(*url.Error); ok {
+		return isRetryableFetchError(uerr.Err)
+	}
+
+	if _, ok := cause.(net.Error); ok {
+		return true
+	}
+	return false
+}
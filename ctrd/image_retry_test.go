@@ -0,0 +1,196 @@
+package ctrd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyResolver fails the first N fetches with the error. If brokenAt is
+// positive, the fetch succeeds but the reader fails after reading brokenAt
+// bytes.
+type flakyResolver struct {
+	remotes.Resolver
+
+	data     []byte
+	failures int
+	err      error
+	brokenAt int
+
+	fetches int
+}
+
+func (r *flakyResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	return remotes.FetcherFunc(func(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+		r.fetches++
+		if r.fetches > r.failures {
+			return ioutil.NopCloser(bytes.NewReader(r.data)), nil
+		}
+
+		if r.brokenAt > 0 {
+			return ioutil.NopCloser(io.MultiReader(
+				bytes.NewReader(r.data[:r.brokenAt]),
+				&errReader{err: r.err},
+			)), nil
+		}
+		return nil, r.err
+	}), nil
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+// fetchWithRetry fetches the content through the retry resolver, and
+// returns the content and the attempts reported by notifier.
+func fetchWithRetry(t *testing.T, r *flakyResolver, attempts int) ([]byte, []int, error) {
+	var notified []int
+	resolver := &retryResolver{
+		Resolver: r,
+		policy:   retryPolicy{attempts: attempts, backoff: time.Millisecond},
+		notify: func(desc ocispec.Descriptor, attempt int, err error) {
+			if err != nil {
+				notified = append(notified, attempt)
+			}
+		},
+	}
+
+	fetcher, err := resolver.Fetcher(context.Background(), "busybox:latest")
+	assert.NoError(t, err)
+
+	desc := ocispec.Descriptor{Digest: digest.FromBytes(r.data), Size: int64(len(r.data))}
+	rc, err := fetcher.Fetch(context.Background(), desc)
+	if err != nil {
+		return nil, notified, err
+	}
+	defer rc.Close()
+
+	data, err := ioutil.ReadAll(rc)
+	return data, notified, err
+}
+
+func TestRetryFetch(t *testing.T) {
+	data := []byte(strings.Repeat("layer", 1024))
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+	for _, tc := range []struct {
+		name     string
+		resolver *flakyResolver
+		fetches  int
+		notified []int
+		errMsg   string
+	}{
+		{
+			name:     "connection reset",
+			resolver: &flakyResolver{data: data, failures: 2, err: &url.Error{Op: "Get", URL: "https://registry", Err: reset}},
+			fetches:  3,
+			notified: []int{2, 3},
+		},
+		{
+			name:     "5xx response",
+			resolver: &flakyResolver{data: data, failures: 1, err: errors.Errorf("unexpected status code https://registry/v2/blobs: 503 Service Unavailable")},
+			fetches:  2,
+			notified: []int{2},
+		},
+		{
+			name:     "broken during read",
+			resolver: &flakyResolver{data: data, failures: 1, err: io.ErrUnexpectedEOF, brokenAt: 1024},
+			fetches:  2,
+			notified: []int{2},
+		},
+		{
+			name:     "attempts used up",
+			resolver: &flakyResolver{data: data, failures: 3, err: reset},
+			fetches:  3,
+			notified: []int{2, 3},
+			errMsg:   "connection reset",
+		},
+		{
+			name:     "unauthorized",
+			resolver: &flakyResolver{data: data, failures: 1, err: errors.Errorf("unexpected status code https://registry/v2/blobs: 401 Unauthorized")},
+			fetches:  1,
+			errMsg:   "401 Unauthorized",
+		},
+		{
+			name:     "not found",
+			resolver: &flakyResolver{data: data, failures: 1, err: errors.Wrapf(errdefs.ErrNotFound, "content at https://registry not found")},
+			fetches:  1,
+			errMsg:   "not found",
+		},
+	} {
+		got, notified, err := fetchWithRetry(t, tc.resolver, 3)
+		assert.Equal(t, tc.fetches, tc.resolver.fetches, tc.name)
+		assert.Equal(t, tc.notified, notified, tc.name)
+
+		if tc.errMsg != "" {
+			assert.Error(t, err, tc.name)
+			assert.Contains(t, err.Error(), tc.errMsg, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, data, got, tc.name)
+	}
+}
+
+func TestRetryFetchCancelled(t *testing.T) {
+	r := &flakyResolver{data: []byte("layer"), failures: 1, err: io.ErrUnexpectedEOF}
+	resolver := &retryResolver{
+		Resolver: r,
+		policy:   retryPolicy{attempts: 3, backoff: time.Hour},
+	}
+
+	fetcher, err := resolver.Fetcher(context.Background(), "busybox:latest")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, err = fetcher.Fetch(ctx, ocispec.Descriptor{})
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	assert.Equal(t, 1, r.fetches)
+}
+
+func TestRetryPolicyWait(t *testing.T) {
+	p := retryPolicy{attempts: 10, backoff: time.Second}
+	assert.Equal(t, time.Second, p.wait(1))
+	assert.Equal(t, 2*time.Second, p.wait(2))
+	assert.Equal(t, 4*time.Second, p.wait(3))
+	assert.Equal(t, maxPullRetryBackoff, p.wait(10))
+}
+
+func TestIsRetryableFetchError(t *testing.T) {
+	for _, tc := range []struct {
+		err       error
+		retryable bool
+	}{
+		{err: nil, retryable: false},
+		{err: context.Canceled, retryable: false},
+		{err: errors.Wrap(io.ErrUnexpectedEOF, "failed to copy"), retryable: true},
+		{err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, retryable: true},
+		{err: &url.Error{Op: "Get", URL: "https://registry", Err: fmt.Errorf("x509: certificate signed by unknown authority")}, retryable: false},
+		{err: errors.Errorf("unexpected status code https://registry: 500 Internal Server Error"), retryable: true},
+		{err: errors.Errorf("unexpected status code https://registry: 404 Not Found"), retryable: false},
+		{err: errors.Wrap(errdefs.ErrNotFound, "content not found"), retryable: false},
+	} {
+		assert.Equal(t, tc.retryable, isRetryableFetchError(tc.err), fmt.Sprintf("%v", tc.err))
+	}
+}
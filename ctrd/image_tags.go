@@ -0,0 +1,151 @@
+package ctrd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	ctrdreference "github.com/containerd/containerd/reference"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// tagList is the response of registry v2 tags list API.
+type tagList struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// ListRemoteTags lists all the tags of repository from the remote registry.
+func (c *Client) ListRemoteTags(ctx context.Context, ref string, authConfig *types.AuthConfig) ([]string, error) {
+	refspec, err := ctrdreference.Parse(ref)
+	if err != nil {
+		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "failed to parse reference %s: %v", ref, err)
+	}
+
+	var (
+		username = ""
+		secret   = ""
+		insecure = c.isInsecureDomain(ref)
+		httpCli  = c.newRegistryHTTPClient(insecure)
+	)
+
+	if authConfig != nil {
+		username = authConfig.Username
+		secret = authConfig.Password
+	}
+
+	authorizer := docker.NewAuthorizer(httpCli, func(host string) (string, string, error) {
+		return username, secret, nil
+	})
+
+	host := refspec.Hostname()
+	base := url.URL{Scheme: "https"}
+	if base.Host, err = docker.DefaultHost(host); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(base.Host, "localhost:") {
+		base.Scheme = "http"
+	}
+	base.Path = path.Join("/v2", strings.TrimPrefix(refspec.Locator, host+"/"), "tags/list")
+
+	var (
+		tags []string
+		next = base.String()
+	)
+
+	// the registry may split the tags into several pages, and the next
+	// page is described by the Link header.
+	for next != "" {
+		list, link, err := fetchTagList(ctx, httpCli, authorizer, next)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list tags of %s", refspec.Locator)
+		}
+		tags = append(tags, list.Tags...)
+
+		next = ""
+		if link != "" {
+			u, err := base.Parse(link)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid link %s of tags list", link)
+			}
+			next = u.String()
+		}
+	}
+	return tags, nil
+}
+
+// fetchTagList requests one page of tags list and returns the link of next page.
+func fetchTagList(ctx context.Context, httpCli *http.Client, authorizer docker.Authorizer, u string) (*tagList, string, error) {
+	var responses []*http.Response
+
+	// retry once if the registry asks for authorization.
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		if err := authorizer.Authorize(ctx, req); err != nil {
+			return nil, "", err
+		}
+
+		resp, err := ctxhttp.Do(ctx, httpCli, req)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && i == 0 {
+			resp.Body.Close()
+			responses = append(responses, resp)
+			if err := authorizer.AddResponses(ctx, responses); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+		defer resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound:
+			return nil, "", errtypes.ErrNotfound
+		default:
+			return nil, "", fmt.Errorf("unexpected status code %v", resp.Status)
+		}
+
+		list := &tagList{}
+		if err := json.NewDecoder(resp.Body).Decode(list); err != nil {
+			return nil, "", err
+		}
+		return list, parseNextLink(resp.Header.Get("Link")), nil
+	}
+	return nil, "", fmt.Errorf("unauthorized")
+}
+
+// parseNextLink parses the url of next page from the Link header, like
+//
+//	</v2/library/busybox/tags/list?last=1.29&n=100>; rel="next"
+func parseNextLink(link string) string {
+	for _, l := range strings.Split(link, ",") {
+		parts := strings.Split(l, ";")
+		if len(parts) < 2 {
+			continue
+		}
+
+		for _, p := range parts[1:] {
+			if strings.TrimSpace(p) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
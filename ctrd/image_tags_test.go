@@ -0,0 +1,30 @@
+package ctrd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNextLink(t *testing.T) {
+	for _, tc := range []struct {
+		link     string
+		expected string
+	}{
+		{
+			link:     "",
+			expected: "",
+		}, {
+			link:     `</v2/library/busybox/tags/list?last=1.29&n=100>; rel="next"`,
+			expected: "/v2/library/busybox/tags/list?last=1.29&n=100",
+		}, {
+			link:     `</v2/library/busybox/tags/list?last=1.29&n=100>; rel="prev"`,
+			expected: "",
+		}, {
+			link:     `</v2/a?n=1>; rel="prev", </v2/b?n=1>; rel="next"`,
+			expected: "/v2/b?n=1",
+		},
+	} {
+		assert.Equal(t, tc.expected, parseNextLink(tc.link), tc.link)
+	}
+}
@@ -3,6 +3,8 @@ package ctrd
 import (
 	"context"
 	"io"
+	"net/http"
+	"syscall"
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
@@ -14,6 +16,7 @@ import (
 	ctrdmetaimages "github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/mount"
 	"github.com/containerd/containerd/snapshots"
+	"github.com/containerd/continuity/fs"
 	digest "github.com/opencontainers/go-digest"
 )
 
@@ -24,7 +27,11 @@ type APIClient interface {
 	SnapshotAPIClient
 
 	Version(ctx context.Context) (containerd.Version, error)
+	// Available returns errtypes.ErrContainerdUnavailable if containerd isn't serving.
+	Available() error
 	Cleanup() error
+	// GarbageCollect triggers the gc of containerd, and returns the number and the size of the removed blobs.
+	GarbageCollect(ctx context.Context) (int64, int64, error)
 	Plugins(ctx context.Context, filters []string) ([]Plugin, error)
 	CheckSnapshotterValid(snapshotter string, allowMultiSnapshotter bool) error
 }
@@ -33,8 +40,8 @@ type APIClient interface {
 type ContainerAPIClient interface {
 	// CreateContainer creates a containerd container and start process.
 	CreateContainer(ctx context.Context, container *Container, checkpointDir string) error
-	// DestroyContainer kill container and delete it.
-	DestroyContainer(ctx context.Context, id string, timeout int64) (*Message, error)
+	// DestroyContainer kill container with the signal, and kill it by SIGKILL if it doesn't exit in timeout.
+	DestroyContainer(ctx context.Context, id string, signal syscall.Signal, timeout int64) (*Message, error)
 	// ProbeContainer probe the container's status, if timeout <= 0, will block to receive message.
 	ProbeContainer(ctx context.Context, id string, timeout time.Duration) *Message
 	// ContainerPIDs returns the all processes's ids inside the container.
@@ -48,10 +55,14 @@ type ContainerAPIClient interface {
 	// ResizeContainer changes the size of the TTY of the exec process running
 	// in the container to the given height and width.
 	ResizeExec(ctx context.Context, id string, execid string, opts types.ResizeOptions) error
+	// KillExec sends the signal to the exec process running in the container.
+	KillExec(ctx context.Context, id string, execid string, signal syscall.Signal) error
 	// RecoverContainer reload the container from metadata and watch it, if program be restarted.
 	RecoverContainer(ctx context.Context, id string, io *containerio.IO) error
 	// PauseContainer pause container.
 	PauseContainer(ctx context.Context, id string) error
+	// KillContainer sends the signal to the init process of container.
+	KillContainer(ctx context.Context, id string, signal syscall.Signal) error
 	// UnpauseContainer unpauses a container.
 	UnpauseContainer(ctx context.Context, id string) error
 	// ResizeContainer changes the size of the TTY of the init process running
@@ -77,18 +88,26 @@ type ImageAPIClient interface {
 	GetImage(ctx context.Context, ref string) (containerd.Image, error)
 	// ListImages returns the list of containerd.Image filtered by the given conditions.
 	ListImages(ctx context.Context, filter ...string) ([]containerd.Image, error)
-	// FetchImage fetchs image content by the given reference.
-	FetchImage(ctx context.Context, ref string, authConfig *types.AuthConfig, stream *jsonstream.JSONStream) (containerd.Image, error)
+	// FetchImage fetchs image content by the given reference and platform.
+	FetchImage(ctx context.Context, ref, platform string, authConfig *types.AuthConfig, stream *jsonstream.JSONStream) (containerd.Image, error)
 	// RemoveImage removes the image by the given reference.
 	RemoveImage(ctx context.Context, ref string) error
 	// ImportImage creates a set of images by tarstream.
-	ImportImage(ctx context.Context, reader io.Reader, opts ...containerd.ImportOpt) ([]containerd.Image, error)
-	// SaveImage saves image to tarstream
-	SaveImage(ctx context.Context, exporter ctrdmetaimages.Exporter, ref string) (io.ReadCloser, error)
+	ImportImage(ctx context.Context, reader io.Reader, stream *jsonstream.JSONStream, opts ...containerd.ImportOpt) ([]containerd.Image, error)
+	// SaveImage saves images to tarstream.
+	SaveImage(ctx context.Context, refs []string) (io.ReadCloser, error)
 	// Commit commits an image from a container.
 	Commit(ctx context.Context, config *CommitConfig) (digest.Digest, error)
+	// ImportRootfs creates an image with single layer from the tar archive of rootfs.
+	ImportRootfs(ctx context.Context, config *ImportRootfsConfig, reader io.Reader) (digest.Digest, error)
 	// PushImage pushes a image to registry
 	PushImage(ctx context.Context, ref string, authConfig *types.AuthConfig, out io.Writer) error
+	// ListRemoteTags lists all the tags of repository from registry.
+	ListRemoteTags(ctx context.Context, ref string, authConfig *types.AuthConfig) ([]string, error)
+	// UpdateRegistryOptions applies the registry options to the later pulls.
+	UpdateRegistryOptions(opts ...ClientOpt) error
+	// RegistryHTTPClient returns the http client to talk with registry.
+	RegistryHTTPClient(host string) *http.Client
 }
 
 // SnapshotAPIClient provides access to containerd snapshot features
@@ -105,9 +124,12 @@ type SnapshotAPIClient interface {
 	// GetSnapshotUsage returns the resource usage of an active or committed snapshot
 	// excluding the usage of parent snapshots.
 	GetSnapshotUsage(ctx context.Context, id string) (snapshots.Usage, error)
+	// GetSnapshotChanges returns the changes of the active snapshot identified
+	// by id relative to its parent.
+	GetSnapshotChanges(ctx context.Context, id string) ([]fs.Change, error)
 	// WalkSnapshot walk all snapshots in specific snapshotter. If not set specific snapshotter,
 	// it will be set to current snapshotter. For each snapshot, the function will be called.
 	WalkSnapshot(ctx context.Context, snapshotter string, fn func(context.Context, snapshots.Info) error) error
 	// CreateCheckpoint creates a checkpoint from a running container
-	CreateCheckpoint(ctx context.Context, id string, checkpointDir string, exit bool) error
+	CreateCheckpoint(ctx context.Context, id string, checkpointDir string, exit, tcpEstablished bool) error
 }
@@ -0,0 +1,161 @@
+package ctrd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/leases"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// leaseOperationLabel marks the temporary lease created by pouchd, and
+	// the value is the operation holding the lease, such as pull.
+	leaseOperationLabel = "pouch.io/lease.operation"
+
+	// temporaryLeaseExpiration is the expiration of temporary lease, which
+	// makes sure that the lease is released even if it's leaked.
+	temporaryLeaseExpiration = 24 * time.Hour
+)
+
+// createTemporaryLease creates a lease labeled with the operation, which is
+// released by releaseStaleLeases if pouchd crashes before the operation is
+// done.
+func createTemporaryLease(ctx context.Context, ls leases.Manager, operation string) (leases.Lease, error) {
+	return ls.Create(ctx,
+		leases.WithRandomID(),
+		leases.WithLabels(map[string]string{leaseOperationLabel: operation}),
+		leases.WithExpiration(temporaryLeaseExpiration),
+	)
+}
+
+// withTemporaryLease attaches a temporary lease on the context to protect
+// the content and snapshots from gc during the operation. The lease in the
+// context is reused if there is one.
+//
+// NOTE: containerd releases the lease with the context of operation, which
+// fails if the operation has been cancelled by client, and the lease will be
+// held until it expires. So the lease is released with the context which
+// can't be cancelled, and the resources which aren't referenced by others
+// will be reclaimed by gc.
+func withTemporaryLease(ctx context.Context, wrapperCli *WrapperClient, operation string) (context.Context, func(), error) {
+	if _, ok := leases.FromContext(ctx); ok {
+		return ctx, func() {}, nil
+	}
+
+	ls := wrapperCli.client.LeasesService()
+	l, err := createTemporaryLease(ctx, ls, operation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return leases.WithLease(ctx, l.ID), func() {
+		releaseCtx := context.Background()
+		if ns, ok := namespaces.Namespace(ctx); ok {
+			releaseCtx = namespaces.WithNamespace(releaseCtx, ns)
+		}
+
+		if err := ls.Delete(releaseCtx, l); err != nil {
+			logrus.Warnf("failed to release lease %s of %s: %v", l.ID, operation, err)
+		}
+	}, nil
+}
+
+// releaseStaleLeases releases the temporary leases held by the operations
+// which were interrupted by the crash of pouchd. It must be called before
+// any operation starts. The leases in all namespaces are released, since
+// the operations of cri are in the namespace of k8s.
+func (c *Client) releaseStaleLeases(ctx context.Context) error {
+	wrapperCli, err := c.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get a containerd grpc client: %v", err)
+	}
+
+	nsList, err := wrapperCli.client.NamespaceService().List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list namespaces")
+	}
+
+	ls := wrapperCli.client.LeasesService()
+	for _, ns := range nsList {
+		nsCtx := namespaces.WithNamespace(ctx, ns)
+
+		leaseList, err := ls.List(nsCtx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list leases in namespace %s", ns)
+		}
+
+		for _, l := range leaseList {
+			operation, ok := l.Labels[leaseOperationLabel]
+			if !ok {
+				continue
+			}
+
+			if err := ls.Delete(nsCtx, l); err != nil {
+				return errors.Wrapf(err, "failed to release lease %s of %s in namespace %s", l.ID, operation, ns)
+			}
+			logrus.Infof("release lease %s of interrupted %s in namespace %s", l.ID, operation, ns)
+		}
+	}
+	return nil
+}
+
+// GarbageCollect triggers the gc of containerd to remove the content and
+// snapshots which aren't referenced by images, containers or leases, and
+// returns the number and the size of the removed blobs in content store.
+func (c *Client) GarbageCollect(ctx context.Context) (int64, int64, error) {
+	wrapperCli, err := c.Get(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get a containerd grpc client: %v", err)
+	}
+
+	cs := wrapperCli.client.ContentStore()
+	before, err := contentBlobs(ctx, cs)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// containerd doesn't provide the api to run gc directly, but it runs gc
+	// before returning if the lease is deleted synchronously.
+	ls := wrapperCli.client.LeasesService()
+	l, err := createTemporaryLease(ctx, ls, "gc")
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to create lease for gc")
+	}
+	if err := ls.Delete(ctx, l, leases.SynchronousDelete); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to run gc")
+	}
+
+	after, err := contentBlobs(ctx, cs)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// only count the blobs removed by gc, the ones added by the concurrent
+	// operations are ignored.
+	var deleted, reclaimed int64
+	for dgst, size := range before {
+		if _, ok := after[dgst]; !ok {
+			deleted++
+			reclaimed += size
+		}
+	}
+	return deleted, reclaimed, nil
+}
+
+// contentBlobs returns the size of each blob in content store.
+func contentBlobs(ctx context.Context, cs content.Store) (map[digest.Digest]int64, error) {
+	blobs := make(map[digest.Digest]int64)
+	if err := cs.Walk(ctx, func(info content.Info) error {
+		blobs[info.Digest] = info.Size
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to walk content store")
+	}
+	return blobs, nil
+}
@@ -0,0 +1,121 @@
+package ctrd
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/alibaba/pouch/apis/metrics"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/containerd/containerd/defaults"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/pkg/dialer"
+	"github.com/containerd/containerd/remotes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// dialOptions returns the options to connect containerd, which are the same
+// as the default ones of containerd client except that the failed rpc calls
+// are recorded in metrics, and the calls failed by the broken connection are
+// converted into errtypes.ErrContainerdUnavailable. The broken connection is
+// re-established by grpc with backoff up to 3 seconds.
+//
+// NOTE: the connection only accepts one interceptor, so the default namespace
+// is set by the interceptor here instead of containerd.WithDefaultNamespace.
+func dialOptions(defaultns string) []grpc.DialOption {
+	unary := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(withDefaultNamespace(ctx, defaultns), method, req, reply, cc, opts...)
+		recordRPCError(method, err)
+		return convertUnavailable(cc, method, err)
+	}
+
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		s, err := streamer(withDefaultNamespace(ctx, defaultns), desc, cc, method, opts...)
+		recordRPCError(method, err)
+		return s, convertUnavailable(cc, method, err)
+	}
+
+	return []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithInsecure(),
+		grpc.FailOnNonTempDialError(true),
+		grpc.WithBackoffMaxDelay(3 * time.Second),
+		grpc.WithDialer(dialer.Dialer),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(defaults.DefaultMaxRecvMsgSize)),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(defaults.DefaultMaxSendMsgSize)),
+		grpc.WithUnaryInterceptor(unary),
+		grpc.WithStreamInterceptor(stream),
+	}
+}
+
+// withDefaultNamespace sets the namespace in context if it isn't set.
+func withDefaultNamespace(ctx context.Context, ns string) context.Context {
+	if _, ok := namespaces.Namespace(ctx); !ok && ns != "" {
+		return namespaces.WithNamespace(ctx, ns)
+	}
+	return ctx
+}
+
+// recordRPCError counts the failed rpc call by method and grpc code.
+func recordRPCError(method string, err error) {
+	if err == nil {
+		return
+	}
+	metrics.ContainerdRPCErrorsCounter.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+// convertUnavailable converts the error of unavailable connection into
+// errtypes.ErrContainerdUnavailable, so that the caller can retry later.
+//
+// NOTE: containerd also returns Unavailable if the ingest of content is
+// locked, which is retried by content.OpenWriter until it's unlocked. So the
+// error is only converted if the connection isn't ready, otherwise the pull
+// would keep retrying when containerd is down.
+func convertUnavailable(cc *grpc.ClientConn, method string, err error) error {
+	if status.Code(err) != codes.Unavailable || cc.GetState() == connectivity.Ready {
+		return err
+	}
+
+	logrus.Debugf("failed to call %s: %v", method, err)
+	return errors.Wrapf(errtypes.ErrContainerdUnavailable, "failed to call %s", method)
+}
+
+// meteredResolver wraps the resolver so that the bytes downloaded from
+// registry are recorded in metrics.
+type meteredResolver struct {
+	remotes.Resolver
+}
+
+// Fetcher returns the fetcher which counts the bytes read.
+func (r *meteredResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	fetcher, err := r.Resolver.Fetcher(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return remotes.FetcherFunc(func(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+		rc, err := fetcher.Fetch(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		return &meteredReadCloser{ReadCloser: rc}, nil
+	}), nil
+}
+
+// meteredReadCloser counts the bytes read.
+type meteredReadCloser struct {
+	io.ReadCloser
+}
+
+// Read implements io.Reader.
+func (rc *meteredReadCloser) Read(p []byte) (int, error) {
+	n, err := rc.ReadCloser.Read(p)
+	metrics.ImagePullBytes.Add(float64(n))
+	return n, err
+}
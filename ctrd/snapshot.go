@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/leases"
 	"github.com/containerd/containerd/mount"
-	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/containerd/snapshots"
 	"github.com/opencontainers/image-spec/identity"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -38,23 +41,63 @@ func (c *Client) CreateSnapshot(ctx context.Context, id, ref string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
-	ctx = leases.WithLease(ctx, wrapperCli.lease.ID)
 
 	image, err := wrapperCli.client.ImageService().Get(ctx, ref)
 	if err != nil {
 		return err
 	}
 
-	diffIDs, err := image.RootFS(ctx, wrapperCli.client.ContentStore(), platforms.Default())
+	snapshotter := CurrentSnapshotterName(ctx)
+	if err := ensureImageUnpacked(ctx, wrapperCli, image, snapshotter); err != nil {
+		return err
+	}
+
+	diffIDs, err := image.RootFS(ctx, wrapperCli.client.ContentStore(), ImagePlatformMatcher(image.Labels))
 	if err != nil {
 		return err
 	}
 
+	ctx = leases.WithLease(ctx, wrapperCli.lease.ID)
+
 	parent := identity.ChainID(diffIDs).String()
-	_, err = wrapperCli.client.SnapshotService(CurrentSnapshotterName(ctx)).Prepare(ctx, id, parent)
+	_, err = wrapperCli.client.SnapshotService(snapshotter).Prepare(ctx, id, parent)
 	return err
 }
 
+// ensureImageUnpacked unpacks the image for the snapshotter if it hasn't
+// been unpacked, such as the image pulled before the snapshotter of pouchd
+// is changed, or the snapshotter is chosen by plugin for the container.
+//
+// NOTE: containerd records the snapshotters which the image is unpacked
+// for by the gc label of image config, so the snapshots are kept until the
+// image is removed. The unpack is protected by the temporary lease instead
+// of pouchd lease, otherwise the snapshots can't be reclaimed after the
+// image is removed.
+func ensureImageUnpacked(ctx context.Context, wrapperCli *WrapperClient, image images.Image, snapshotter string) error {
+	img := containerd.NewImageWithPlatform(wrapperCli.client, image, ImagePlatformMatcher(image.Labels))
+
+	unpacked, err := img.IsUnpacked(ctx, snapshotter)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check whether image %s is unpacked for snapshotter %s", image.Name, snapshotter)
+	}
+	if unpacked {
+		return nil
+	}
+
+	logrus.Infof("image %s isn't unpacked for snapshotter %s, start to unpack it", image.Name, snapshotter)
+
+	ctx, done, err := withTemporaryLease(ctx, wrapperCli, "unpack")
+	if err != nil {
+		return errors.Wrap(err, "failed to create lease for unpack")
+	}
+	defer done()
+
+	if err := img.Unpack(WithImageUnpack(ctx), snapshotter); err != nil {
+		return errors.Wrapf(err, "failed to unpack image %s for snapshotter %s", image.Name, snapshotter)
+	}
+	return nil
+}
+
 // GetSnapshot returns the snapshot's info by id.
 func (c *Client) GetSnapshot(ctx context.Context, id string) (snapshots.Info, error) {
 	wrapperCli, err := c.Get(ctx)
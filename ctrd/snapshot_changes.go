@@ -0,0 +1,252 @@
+package ctrd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/alibaba/pouch/pkg/randomid"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/continuity/fs"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// whiteoutPrefix is the prefix of aufs style whiteout file, which means
+	// the file without prefix is removed.
+	whiteoutPrefix = ".wh."
+
+	// whiteoutOpaqueDir is the aufs style whiteout file, which means all the
+	// entries of lower directory are removed.
+	whiteoutOpaqueDir = whiteoutPrefix + whiteoutPrefix + ".opq"
+
+	// overlayOpaqueXattr is the xattr of overlay opaque directory.
+	overlayOpaqueXattr = "trusted.overlay.opaque"
+)
+
+// GetSnapshotChanges returns the changes of the active snapshot identified by
+// id relative to its parent, which are sorted by path. The upper directory is
+// walked for the overlay snapshot, and the snapshot is compared with its
+// parent for others.
+func (c *Client) GetSnapshotChanges(ctx context.Context, id string) ([]fs.Change, error) {
+	wrapperCli, err := c.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
+	}
+	client := wrapperCli.client
+
+	// NOTE: make sure that gc scheduler doesn't remove the view of parent.
+	ctx, done, err := withTemporaryLease(ctx, wrapperCli, "changes")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create lease for changes")
+	}
+	defer done()
+
+	service := client.SnapshotService(CurrentSnapshotterName(ctx))
+	defer service.Close()
+
+	mounts, err := service.Mounts(ctx, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get mounts of snapshot %s", id)
+	}
+
+	if upper, lowers, ok := overlayDirs(mounts); ok {
+		return overlayChanges(upper, lowers)
+	}
+
+	info, err := service.Stat(ctx, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to stat snapshot %s", id)
+	}
+
+	var changes []fs.Change
+	changeFn := func(kind fs.ChangeKind, path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		changes = append(changes, fs.Change{Kind: kind, Path: path})
+		return nil
+	}
+
+	// the snapshot without parent only contains the added files.
+	if info.Parent == "" {
+		err = mount.WithTempMount(ctx, mounts, func(upper string) error {
+			return fs.Changes(ctx, "", upper, changeFn)
+		})
+		return changes, err
+	}
+
+	key := randomid.Generate()
+	lowerMounts, err := service.View(ctx, key, info.Parent)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create view of snapshot %s", info.Parent)
+	}
+	defer service.Remove(ctx, key)
+
+	err = mount.WithTempMount(ctx, lowerMounts, func(lower string) error {
+		return mount.WithTempMount(ctx, mounts, func(upper string) error {
+			return fs.Changes(ctx, lower, upper, changeFn)
+		})
+	})
+	return changes, err
+}
+
+// overlayDirs returns the upper directory and lower directories of overlay
+// mounts, the lower directories are in order from the top most.
+func overlayDirs(mounts []mount.Mount) (string, []string, bool) {
+	if len(mounts) != 1 || mounts[0].Type != "overlay" {
+		return "", nil, false
+	}
+
+	var (
+		upper  string
+		lowers []string
+	)
+	for _, opt := range mounts[0].Options {
+		switch {
+		case strings.HasPrefix(opt, "upperdir="):
+			upper = strings.TrimPrefix(opt, "upperdir=")
+		case strings.HasPrefix(opt, "lowerdir="):
+			lowers = strings.Split(strings.TrimPrefix(opt, "lowerdir="), ":")
+		}
+	}
+	return upper, lowers, upper != ""
+}
+
+// overlayChanges computes the changes by walking the upper directory. The
+// whiteout files are translated into the deletions, and the entries which
+// also exist in the lower directories are modifications.
+func overlayChanges(upper string, lowers []string) ([]fs.Change, error) {
+	var changes []fs.Change
+
+	err := filepath.Walk(upper, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(upper, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.Join(string(filepath.Separator), rel)
+		if rel == string(filepath.Separator) {
+			return nil
+		}
+
+		base := filepath.Base(rel)
+		switch {
+		case isOverlayWhiteout(fi):
+			changes = append(changes, fs.Change{Kind: fs.ChangeKindDelete, Path: rel})
+			return nil
+		case base == whiteoutOpaqueDir:
+			// the opaque directory is handled with its parent.
+			return nil
+		case strings.HasPrefix(base, whiteoutPrefix):
+			changes = append(changes, fs.Change{
+				Kind: fs.ChangeKindDelete,
+				Path: filepath.Join(filepath.Dir(rel), strings.TrimPrefix(base, whiteoutPrefix)),
+			})
+			return nil
+		}
+
+		if !existsInLowers(lowers, rel) {
+			changes = append(changes, fs.Change{Kind: fs.ChangeKindAdd, Path: rel})
+			return nil
+		}
+		changes = append(changes, fs.Change{Kind: fs.ChangeKindModify, Path: rel})
+
+		if fi.IsDir() && isOpaqueDir(path) {
+			deleted, err := opaqueDeletes(path, lowers, rel)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, deleted...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// isOverlayWhiteout returns whether the file is the overlay whiteout, which
+// is a character device with 0/0 device number.
+func isOverlayWhiteout(fi os.FileInfo) bool {
+	if fi.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	stat, ok := fi.Sys().(*syscall.Stat_t)
+	return ok && stat.Rdev == 0
+}
+
+// isOpaqueDir returns whether the entries of lower directory are hidden by
+// the directory in upper.
+func isOpaqueDir(path string) bool {
+	buf := make([]byte, 1)
+	if n, err := unix.Lgetxattr(path, overlayOpaqueXattr, buf); err == nil && n == 1 && buf[0] == 'y' {
+		return true
+	}
+	_, err := os.Lstat(filepath.Join(path, whiteoutOpaqueDir))
+	return err == nil
+}
+
+// existsInLowers returns whether the path exists in the lower directories,
+// the lookup stops at the first whiteout of it.
+func existsInLowers(lowers []string, rel string) bool {
+	for _, lower := range lowers {
+		fi, err := os.Lstat(filepath.Join(lower, rel))
+		if err != nil {
+			continue
+		}
+		return !isOverlayWhiteout(fi)
+	}
+	return false
+}
+
+// opaqueDeletes returns the deletions of entries in lower directories which
+// are hidden by the opaque directory and do not exist in upper.
+func opaqueDeletes(upperDir string, lowers []string, rel string) ([]fs.Change, error) {
+	names := map[string]struct{}{}
+	for _, lower := range lowers {
+		dir := filepath.Join(lower, rel)
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if !isOverlayWhiteout(entry) && !strings.HasPrefix(entry.Name(), whiteoutPrefix) {
+				names[entry.Name()] = struct{}{}
+			}
+		}
+
+		// the directories below the opaque one are hidden.
+		if isOpaqueDir(dir) {
+			break
+		}
+	}
+
+	var changes []fs.Change
+	for name := range names {
+		if _, err := os.Lstat(filepath.Join(upperDir, name)); err == nil {
+			continue
+		}
+		changes = append(changes, fs.Change{Kind: fs.ChangeKindDelete, Path: filepath.Join(rel, name)})
+	}
+	return changes, nil
+}
@@ -0,0 +1,86 @@
+package ctrd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/continuity/fs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestOverlayChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overlay-changes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	lower, upper := filepath.Join(dir, "lower"), filepath.Join(dir, "upper")
+	for _, d := range []string{
+		filepath.Join(lower, "etc"),
+		filepath.Join(lower, "opaque"),
+		filepath.Join(upper, "etc"),
+		filepath.Join(upper, "opaque"),
+		filepath.Join(upper, "new"),
+	} {
+		assert.NoError(t, os.MkdirAll(d, 0755))
+	}
+
+	for _, f := range []string{
+		filepath.Join(lower, "etc", "hosts"),
+		filepath.Join(lower, "etc", "passwd"),
+		filepath.Join(lower, "etc", "removed"),
+		filepath.Join(lower, "opaque", "hidden"),
+		filepath.Join(lower, "opaque", "kept"),
+		filepath.Join(upper, "etc", "hosts"),
+		filepath.Join(upper, "etc", whiteoutPrefix+"passwd"),
+		filepath.Join(upper, "opaque", whiteoutOpaqueDir),
+		filepath.Join(upper, "opaque", "kept"),
+		filepath.Join(upper, "new", "file"),
+	} {
+		assert.NoError(t, ioutil.WriteFile(f, nil, 0644))
+	}
+
+	expected := []fs.Change{
+		{Kind: fs.ChangeKindModify, Path: "/etc"},
+		{Kind: fs.ChangeKindModify, Path: "/etc/hosts"},
+		{Kind: fs.ChangeKindDelete, Path: "/etc/passwd"},
+		{Kind: fs.ChangeKindAdd, Path: "/new"},
+		{Kind: fs.ChangeKindAdd, Path: "/new/file"},
+		{Kind: fs.ChangeKindModify, Path: "/opaque"},
+		{Kind: fs.ChangeKindDelete, Path: "/opaque/hidden"},
+		{Kind: fs.ChangeKindModify, Path: "/opaque/kept"},
+	}
+
+	// the overlay whiteout requires the privilege to create.
+	if err := unix.Mknod(filepath.Join(upper, "etc", "removed"), unix.S_IFCHR, 0); err == nil {
+		expected = append(expected, fs.Change{Kind: fs.ChangeKindDelete, Path: "/etc/removed"})
+		sort.Slice(expected, func(i, j int) bool {
+			return expected[i].Path < expected[j].Path
+		})
+	}
+
+	changes, err := overlayChanges(upper, []string{lower})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, changes)
+}
+
+func TestOverlayDirs(t *testing.T) {
+	mounts := []mount.Mount{{
+		Type:    "overlay",
+		Source:  "overlay",
+		Options: []string{"workdir=/work", "upperdir=/upper", "lowerdir=/lower1:/lower2"},
+	}}
+	upper, lowers, ok := overlayDirs(mounts)
+	assert.True(t, ok)
+	assert.Equal(t, "/upper", upper)
+	assert.Equal(t, []string{"/lower1", "/lower2"}, lowers)
+
+	_, _, ok = overlayDirs([]mount.Mount{{Type: "bind", Source: "/snapshot", Options: []string{"rbind"}}})
+	assert.False(t, ok)
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -33,6 +34,14 @@ const (
 	// stopTimeout used to send SIGTERM to containerd in limit time to shutdown containerd
 	// if the containerd is still alive, Stop action will send SIGKILL
 	stopTimeout = 15 * time.Second
+
+	// monitorInterval used to check whether containerd is still alive
+	monitorInterval = time.Second
+
+	// restartBackoff is the wait before restarting containerd, which is
+	// doubled after each failed restart up to maxRestartBackoff
+	restartBackoff    = time.Second
+	maxRestartBackoff = 30 * time.Second
 )
 
 // Opt is used to modify the daemon setting.
@@ -42,6 +51,10 @@ type Opt func(*Daemon) error
 type Daemon struct {
 	cfg Config
 
+	// mu protects pid from being changed by the restart during Stop
+	mu     sync.Mutex
+	stopCh chan struct{}
+
 	pid        int
 	binaryName string
 	rootDir    string
@@ -68,6 +81,7 @@ func Start(ctx context.Context, rootDir, stateDir string, opts ...Opt) (*Daemon,
 				Address: defaults.DefaultDebugAddress,
 			},
 		},
+		stopCh:     make(chan struct{}),
 		pid:        -1,
 		binaryName: binaryName,
 		rootDir:    rootDir,
@@ -95,11 +109,23 @@ func Start(ctx context.Context, rootDir, stateDir string, opts ...Opt) (*Daemon,
 	if err := d.healthPostCheck(); err != nil {
 		return nil, err
 	}
+
+	go d.monitor()
 	return d, nil
 }
 
 // Stop stops the containerd in 15 seconds.
 func (d *Daemon) Stop() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	select {
+	case <-d.stopCh:
+		return nil
+	default:
+		close(d.stopCh)
+	}
+
 	if d.pid != -1 {
 		syscall.Kill(d.pid, syscall.SIGTERM)
 
@@ -121,6 +147,68 @@ func (d *Daemon) Stop() error {
 	return nil
 }
 
+// monitor restarts containerd with backoff if it exits unexpectedly, until
+// the daemon is stopped.
+func (d *Daemon) monitor() {
+	ticker := time.NewTicker(monitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		if utils.IsProcessAlive(d.getPid()) {
+			continue
+		}
+
+		d.logger.WithField("containerd-pid", d.getPid()).Warnf("containerd exited unexpectedly, restart it")
+		for backoff := restartBackoff; ; {
+			err := d.restart()
+			if err == nil {
+				break
+			}
+
+			d.logger.Errorf("failed to restart containerd, retry in %v: %v", backoff, err)
+			select {
+			case <-d.stopCh:
+				return
+			case <-time.After(backoff):
+			}
+
+			if backoff *= 2; backoff > maxRestartBackoff {
+				backoff = maxRestartBackoff
+			}
+		}
+	}
+}
+
+// restart starts containerd again unless the daemon has been stopped.
+func (d *Daemon) restart() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	select {
+	case <-d.stopCh:
+		return nil
+	default:
+	}
+
+	if err := d.runContainerd(); err != nil {
+		return err
+	}
+	return d.healthPostCheck()
+}
+
+func (d *Daemon) getPid() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.pid
+}
+
 func (d *Daemon) healthPostCheck() error {
 	var (
 		failureCount  = 0
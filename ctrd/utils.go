@@ -1,10 +1,13 @@
 package ctrd
 
 import (
+	"context"
 	"crypto/tls"
 	"net"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -19,12 +22,23 @@ import (
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 )
 
-func withExitShimV1CheckpointTaskOpts() containerd.CheckpointTaskOpts {
+func withShimV1CheckpointTaskOpts(exit, tcpEstablished bool) containerd.CheckpointTaskOpts {
 	return func(r *containerd.CheckpointTaskInfo) error {
 		r.Options = &runctypes.CheckpointOptions{
-			Exit: true,
+			Exit:    exit,
+			OpenTcp: tcpEstablished,
+		}
+		return nil
+	}
+}
+
+func withShimV1RestoreTaskOpts(tcpEstablished bool) containerd.NewTaskOpts {
+	return func(_ context.Context, _ *containerd.Client, t *containerd.TaskInfo) error {
+		t.Options = &runctypes.CreateOptions{
+			OpenTcp: tcpEstablished,
 		}
 		return nil
 	}
@@ -34,25 +48,38 @@ func withExitShimV1CheckpointTaskOpts() containerd.CheckpointTaskOpts {
 // insecure registry. The insecure registry will accept HTTP or HTTPS with
 // certificates from unknown CAs.
 func (c *Client) isInsecureDomain(ref string) bool {
-	u, err := url.Parse("dummy://" + ref)
-	if err != nil {
-		logrus.Warning("failed to parse reference(%s) into url: %v", ref, err)
-		return false
-	}
+	return c.isInsecureHost(refHost(ref))
+}
 
-	for _, r := range c.insecureRegistries {
-		if r == u.Host {
+// isInsecureHost returns true if the host is in the insecure registries.
+func (c *Client) isInsecureHost(host string) bool {
+	insecureRegistries, _, _ := c.registryOptions()
+	for _, r := range insecureRegistries {
+		if r == host {
 			return true
 		}
 	}
 	return false
 }
 
+// refHost returns the host of the reference.
+func refHost(ref string) string {
+	u, err := url.Parse("dummy://" + ref)
+	if err != nil {
+		logrus.Warningf("failed to parse reference(%s) into url: %v", ref, err)
+		return ""
+	}
+	return u.Host
+}
+
+// getResolver returns the resolver of the reference. If the reference
+// belongs to docker hub and the registry mirrors are set, the mirrors will
+// be tried in order before docker hub.
 func (c *Client) getResolver(authConfig *types.AuthConfig, ref string, resolverOpt docker.ResolverOptions) (remotes.Resolver, error) {
 	var (
 		username = ""
 		secret   = ""
-		insecure = c.isInsecureDomain(ref)
+		host     = refHost(ref)
 	)
 
 	if authConfig != nil {
@@ -60,6 +87,50 @@ func (c *Client) getResolver(authConfig *types.AuthConfig, ref string, resolverO
 		secret = authConfig.Password
 	}
 
+	options := docker.ResolverOptions{
+		Tracker: resolverOpt.Tracker,
+		Credentials: func(host string) (string, string, error) {
+			// Only one host
+			return username, secret, nil
+		},
+		Client: c.newRegistryHTTPClient(c.isInsecureHost(host)),
+	}
+	upstream := docker.NewResolver(options)
+
+	_, registryMirrors, _ := c.registryOptions()
+	if len(registryMirrors) == 0 || !isDockerHub(host) {
+		return upstream, nil
+	}
+
+	r := &mirrorResolver{}
+	for _, m := range registryMirrors {
+		mirror := m
+
+		mirrorOptions := options
+		mirrorOptions.Host = func(string) (string, error) {
+			return mirror.Host, nil
+		}
+		mirrorOptions.PlainHTTP = mirror.Scheme == "http"
+		mirrorOptions.Client = c.newRegistryHTTPClient(c.isInsecureHost(mirror.Host))
+
+		r.names = append(r.names, mirror.String())
+		r.resolvers = append(r.resolvers, docker.NewResolver(mirrorOptions))
+	}
+	r.names = append(r.names, host)
+	r.resolvers = append(r.resolvers, upstream)
+	return r, nil
+}
+
+// RegistryHTTPClient returns the http client to talk with registry, which
+// follows the insecure registries of daemon like pull.
+func (c *Client) RegistryHTTPClient(host string) *http.Client {
+	return c.newRegistryHTTPClient(c.isInsecureHost(host))
+}
+
+// newRegistryHTTPClient returns the http client used to talk with registry.
+// If the registry is insecure, the certificate will not be verified, and the
+// request will fall back to HTTP if HTTPS fails.
+func (c *Client) newRegistryHTTPClient(insecure bool) *http.Client {
 	tr := &http.Transport{
 		Proxy: proxyFromEnvironment,
 		DialContext: (&net.Dialer{
@@ -76,18 +147,98 @@ func (c *Client) getResolver(authConfig *types.AuthConfig, ref string, resolverO
 		ExpectContinueTimeout: 5 * time.Second,
 	}
 
-	options := docker.ResolverOptions{
-		Tracker:   resolverOpt.Tracker,
-		PlainHTTP: insecure,
-		Credentials: func(host string) (string, string, error) {
-			// Only one host
-			return username, secret, nil
-		},
-		Client: &http.Client{
+	if !insecure {
+		return &http.Client{
 			Transport: tr,
+		}
+	}
+
+	return &http.Client{
+		Transport: &httpFallbackTransport{
+			base:       tr,
+			isInsecure: c.isInsecureHost,
+			plainHosts: make(map[string]struct{}),
 		},
 	}
-	return docker.NewResolver(options), nil
+}
+
+// httpFallbackTransport retries the HTTPS request of insecure registry with
+// HTTP if it fails, and the registry using HTTP is remembered so that the
+// following requests are sent by HTTP directly.
+type httpFallbackTransport struct {
+	base       http.RoundTripper
+	isInsecure func(host string) bool
+
+	mu         sync.Mutex
+	plainHosts map[string]struct{}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *httpFallbackTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if req.URL.Scheme != "https" || !t.isInsecure(host) {
+		return t.base.RoundTrip(req)
+	}
+
+	t.mu.Lock()
+	_, plain := t.plainHosts[host]
+	t.mu.Unlock()
+
+	if !plain {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil {
+			return resp, nil
+		}
+
+		// the request can't be sent again if the body can't be replayed.
+		if req.Body != nil && req.GetBody == nil {
+			return nil, err
+		}
+		logrus.Warnf("failed to request insecure registry %s by https, fall back to http: %v", host, err)
+	}
+
+	plainReq, err := withHTTPScheme(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(plainReq)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	t.plainHosts[host] = struct{}{}
+	t.mu.Unlock()
+	return resp, nil
+}
+
+// withHTTPScheme returns the copy of the request with http scheme.
+func withHTTPScheme(req *http.Request) (*http.Request, error) {
+	u := *req.URL
+	u.Scheme = "http"
+
+	r := req.WithContext(req.Context())
+	r.URL = &u
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+// GetCPUQuotaAndPeriod returns the cfs quota and period of resources, the
+// NanoCpus is converted into the quota of the default period 100ms.
+func GetCPUQuotaAndPeriod(r types.Resources) (int64, uint64) {
+	if r.NanoCpus > 0 {
+		period := uint64(100 * time.Millisecond / time.Microsecond)
+		return r.NanoCpus * int64(period) / 1e9, period
+	}
+	return r.CPUQuota, uint64(r.CPUPeriod)
 }
 
 // GetWeightDevice Convert weight device from []*types.WeightDevice to []specs.LinuxWeightDevice
@@ -97,14 +248,14 @@ func GetWeightDevice(devs []*types.WeightDevice) ([]specs.LinuxWeightDevice, err
 
 	for _, dev := range devs {
 		if err := syscall.Stat(dev.Path, &stat); err != nil {
-			return nil, err
+			return nil, errors.Wrapf(err, "failed to get device %s", dev.Path)
 		}
 
 		d := specs.LinuxWeightDevice{
 			Weight: &dev.Weight,
 		}
-		d.Major = int64(stat.Rdev >> 8)
-		d.Minor = int64(stat.Rdev & 255)
+		d.Major = int64(unix.Major(uint64(stat.Rdev)))
+		d.Minor = int64(unix.Minor(uint64(stat.Rdev)))
 		weightDevice = append(weightDevice, d)
 	}
 
@@ -118,14 +269,14 @@ func GetThrottleDevice(devs []*types.ThrottleDevice) ([]specs.LinuxThrottleDevic
 
 	for _, dev := range devs {
 		if err := syscall.Stat(dev.Path, &stat); err != nil {
-			return nil, err
+			return nil, errors.Wrapf(err, "failed to get device %s", dev.Path)
 		}
 
 		d := specs.LinuxThrottleDevice{
 			Rate: dev.Rate,
 		}
-		d.Major = int64(stat.Rdev >> 8)
-		d.Minor = int64(stat.Rdev & 255)
+		d.Major = int64(unix.Major(uint64(stat.Rdev)))
+		d.Minor = int64(unix.Minor(uint64(stat.Rdev)))
 		ThrottleDevice = append(ThrottleDevice, d)
 	}
 
@@ -163,13 +314,13 @@ func toLinuxResources(resources types.Resources) (*specs.LinuxResources, error)
 
 	// toLinuxCPU
 	shares := uint64(resources.CPUShares)
-	period := uint64(resources.CPUPeriod)
+	quota, period := GetCPUQuotaAndPeriod(resources)
 	r.CPU = &specs.LinuxCPU{
 		Cpus:   resources.CpusetCpus,
 		Mems:   resources.CpusetMems,
 		Shares: &shares,
 		Period: &period,
-		Quota:  &resources.CPUQuota,
+		Quota:  &quota,
 	}
 
 	// toLinuxMemory
@@ -213,3 +364,24 @@ func convertCtrdErr(err error) error {
 
 	return err
 }
+
+// convertRegistryErr converts the error of resolving image from registry to
+// the typed error, so that the client can tell the image is missing from
+// the credential is wrong.
+func convertRegistryErr(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	cause := errors.Cause(err)
+	switch {
+	case cause == docker.ErrInvalidAuthorization,
+		strings.Contains(cause.Error(), "401 Unauthorized"):
+		return errors.Wrap(errtypes.ErrUnauthorized, err.Error())
+	case errdefs.IsNotFound(err), strings.HasSuffix(cause.Error(), " not found"):
+		// the resolver of containerd reports the missing manifest by
+		// the plain error "<ref> not found".
+		return errors.Wrap(errtypes.ErrNotfound, err.Error())
+	}
+	return err
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/pkg/errtypes"
 
 	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes/docker"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_convertCtrdErr(t *testing.T) {
@@ -81,3 +84,56 @@ func Test_convertCtrdErr(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertRegistryErr(t *testing.T) {
+	assert.NoError(t, convertRegistryErr(nil))
+
+	for _, tc := range []struct {
+		name         string
+		err          error
+		unauthorized bool
+		notFound     bool
+	}{
+		{
+			name:         "wrong credential",
+			err:          errors.Wrap(errors.Wrap(docker.ErrInvalidAuthorization, "pull access denied"), "failed to resolve reference"),
+			unauthorized: true,
+		},
+		{
+			name:         "missing credential",
+			err:          errors.Wrap(errors.Errorf("unexpected status code https://registry/v2/foo/manifests/latest: 401 Unauthorized"), "failed to resolve reference"),
+			unauthorized: true,
+		},
+		{
+			name:     "missing manifest",
+			err:      errors.Wrap(errors.Errorf("docker.io/library/foo:latest not found"), "failed to resolve reference"),
+			notFound: true,
+		},
+		{
+			name: "other error",
+			err:  errors.Wrap(errors.Errorf("connection refused"), "failed to resolve reference"),
+		},
+	} {
+		err := convertRegistryErr(tc.err)
+		assert.Equal(t, tc.unauthorized, errtypes.IsUnauthorized(err), tc.name)
+		assert.Equal(t, tc.notFound, errtypes.IsNotfound(err), tc.name)
+		assert.Contains(t, err.Error(), tc.err.Error(), tc.name)
+	}
+}
+
+func TestGetCPUQuotaAndPeriod(t *testing.T) {
+	for _, tc := range []struct {
+		resources types.Resources
+		quota     int64
+		period    uint64
+	}{
+		{resources: types.Resources{}, quota: 0, period: 0},
+		{resources: types.Resources{CPUQuota: 20000, CPUPeriod: 50000}, quota: 20000, period: 50000},
+		{resources: types.Resources{NanoCpus: 1500000000}, quota: 150000, period: 100000},
+		{resources: types.Resources{NanoCpus: 10000000}, quota: 1000, period: 100000},
+	} {
+		quota, period := GetCPUQuotaAndPeriod(tc.resources)
+		assert.Equal(t, tc.quota, quota, "%+v", tc.resources)
+		assert.Equal(t, tc.period, period, "%+v", tc.resources)
+	}
+}
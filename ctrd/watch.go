@@ -18,6 +18,16 @@ type Message struct {
 	err      error
 }
 
+// NewMessage returns the Message of task exit, which is used if the exit
+// isn't watched, such as the task is lost when pouchd is down.
+func NewMessage(exitCode uint32, exitTime time.Time, err error) *Message {
+	return &Message{
+		exitCode: exitCode,
+		exitTime: exitTime,
+		err:      err,
+	}
+}
+
 // RawError returns the error contained in Message.
 func (m *Message) RawError() error {
 	return m.err
@@ -27,7 +27,7 @@ type WrapperClient struct {
 
 func newWrapperClient(rpcAddr string, defaultns string, maxStreamsClient int, lease *leases.Lease) (*WrapperClient, error) {
 	options := []containerd.ClientOpt{
-		containerd.WithDefaultNamespace(defaultns),
+		containerd.WithDialOpts(dialOptions(defaultns)),
 	}
 
 	cli, err := containerd.New(rpcAddr, options...)
@@ -7,9 +7,12 @@ import (
 	"io/ioutil"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/client"
 	criconfig "github.com/alibaba/pouch/cri/config"
@@ -28,6 +31,16 @@ const (
 	CgroupSystemdDriver = "systemd"
 	// DefaultCgroupDriver is default cgroups driver
 	DefaultCgroupDriver = CgroupfsDriver
+	// DefaultMaxPullAttempts is the default max attempts to fetch content during pull
+	DefaultMaxPullAttempts = 3
+	// DefaultPullRetryBackoff is the default backoff before the first retry of pull
+	DefaultPullRetryBackoff = "1s"
+	// DefaultMaxConcurrentDownloads is the default max concurrent layer downloads of all pulls
+	DefaultMaxConcurrentDownloads = 3
+	// DefaultInitPath is the default init binary run in containers, which is looked up in PATH
+	DefaultInitPath = "dumb-init"
+	// DefaultShutdownTimeout is the default timeout (in seconds) to stop containers when pouchd is stopped
+	DefaultShutdownTimeout = 15
 )
 
 // Config refers to daemon's whole configurations.
@@ -68,6 +81,11 @@ type Config struct {
 	// /usr/local/bin is the default.
 	ContainerdPath string `json:"containerd-path,omitempty"`
 
+	// ManageContainerd starts containerd as the child process of pouchd and
+	// restarts it if it exits unexpectedly, default true. Otherwise pouchd
+	// connects to the containerd running at ContainerdAddr.
+	ManageContainerd bool `json:"manage-containerd,omitempty"`
+
 	// TLS configuration
 	TLS client.TLSConfig `json:"TLS,omitempty"`
 
@@ -110,6 +128,18 @@ type Config struct {
 	// RegistryService
 	RegistryService types.RegistryServiceConfig `json:"registry-service,omitempty" `
 
+	// DefaultUlimits is the default ulimits of all containers, the ulimits
+	// of container win if both of them set the same kind of limit.
+	DefaultUlimits []*types.Ulimit `json:"default-ulimits,omitempty"`
+
+	// DefaultInit runs an init as the pid 1 of containers by default, which
+	// reaps the zombies and forwards the signals to the command of container.
+	DefaultInit bool `json:"default-init,omitempty"`
+
+	// InitPath is the path of the static init binary, which is bind mounted
+	// into the containers run with init.
+	InitPath string `json:"init-path,omitempty"`
+
 	// oom_score_adj for the daemon
 	OOMScoreAdjust int `json:"oom-score-adjust,omitempty"`
 
@@ -131,6 +161,112 @@ type Config struct {
 	// InsecureRegistries sets insecure registries to allow to pull
 	// insecure registries.
 	InsecureRegistries []string `json:"insecure-registries,omitempty"`
+
+	// RegistryMirrors sets the mirrors of docker hub, which are tried in
+	// order before docker hub.
+	RegistryMirrors []string `json:"registry-mirrors,omitempty"`
+
+	// MaxPullAttempts is the max attempts to fetch the content of image
+	// during pull, default 3.
+	MaxPullAttempts int `json:"max-pull-attempts,omitempty"`
+
+	// PullRetryBackoff is the backoff before the first retry of failed
+	// fetch, which is doubled for each retry, default 1s.
+	PullRetryBackoff string `json:"pull-retry-backoff,omitempty"`
+
+	// MaxConcurrentDownloads is the max number of layers downloaded at
+	// the same time by all the pulls, default 3.
+	MaxConcurrentDownloads int `json:"max-concurrent-downloads,omitempty"`
+
+	// LiveRestore keeps the containers running when pouchd is stopped, and
+	// pouchd reconnects to them when it starts again, default true.
+	LiveRestore bool `json:"live-restore,omitempty"`
+
+	// ShutdownTimeout is the timeout (in seconds) to stop the containers
+	// when pouchd is stopped without live restore, the containers which
+	// don't exit in time are killed, default 15.
+	ShutdownTimeout int `json:"shutdown-timeout,omitempty"`
+
+	// MetricsAddress is the tcp address to serve the metrics only, such as
+	// 127.0.0.1:9323, the metrics are still served on the listening
+	// addresses of pouchd if it is empty.
+	MetricsAddress string `json:"metrics-addr,omitempty"`
+}
+
+// ReloadableKeys are the keys of configurations which are applied to the
+// running daemon on reload, the others require restarting daemon.
+var ReloadableKeys = []string{"debug", "label", "registry-mirrors", "insecure-registries", "max-concurrent-downloads"}
+
+// Reload applies the reloadable configurations of newCfg, which should have
+// been validated. It returns the keys changed, and the keys ignored since
+// they can't be changed without restarting daemon.
+func (cfg *Config) Reload(newCfg *Config) (changed []string, ignored []string) {
+	cfg.Lock()
+	defer cfg.Unlock()
+
+	reloadable := make(map[string]bool, len(ReloadableKeys))
+	for _, key := range ReloadableKeys {
+		reloadable[key] = true
+	}
+
+	current, updated := reflect.ValueOf(cfg).Elem(), reflect.ValueOf(newCfg).Elem()
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Type().Field(i)
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+
+		if sameConfigValue(current.Field(i).Interface(), updated.Field(i).Interface()) {
+			continue
+		}
+
+		if !reloadable[key] {
+			ignored = append(ignored, key)
+			continue
+		}
+		current.Field(i).Set(updated.Field(i))
+		changed = append(changed, key)
+	}
+	return changed, ignored
+}
+
+// GetLabels returns a copy of the labels of daemon, which may be changed by
+// reload or update of daemon.
+func (cfg *Config) GetLabels() []string {
+	cfg.Lock()
+	defer cfg.Unlock()
+
+	return append([]string{}, cfg.Labels...)
+}
+
+// sameConfigValue returns true if the values are encoded into the same json,
+// and the nil and empty slice or map are considered the same.
+func sameConfigValue(a, b interface{}) bool {
+	isEmpty := func(data []byte) bool {
+		s := string(data)
+		return s == "null" || s == "[]" || s == "{}"
+	}
+
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return bytes.Equal(ja, jb) || (isEmpty(ja) && isEmpty(jb))
+}
+
+// GetPullRetryBackoff returns the backoff before the first retry of pull.
+func (cfg *Config) GetPullRetryBackoff() (time.Duration, error) {
+	return time.ParseDuration(cfg.PullRetryBackoff)
+}
+
+// GetShutdownTimeout returns the timeout to stop containers when pouchd is stopped.
+func (cfg *Config) GetShutdownTimeout() time.Duration {
+	if cfg.ShutdownTimeout <= 0 {
+		return DefaultShutdownTimeout * time.Second
+	}
+	return time.Duration(cfg.ShutdownTimeout) * time.Second
 }
 
 // GetCgroupDriver gets cgroup driver used in runc.
@@ -163,6 +299,14 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if err := opts.ValidateUlimits(cfg.DefaultUlimits); err != nil {
+		return fmt.Errorf("invalid default ulimits: %v", err)
+	}
+
+	if err := validateTLS(cfg.TLS); err != nil {
+		return err
+	}
+
 	// TODO: add config validation
 
 	// validates runtimes config
@@ -174,6 +318,42 @@ func (cfg *Config) Validate() error {
 		cfg.Runtimes[cfg.DefaultRuntime] = types.Runtime{Path: cfg.DefaultRuntime}
 	}
 
+	// if pull retry is empty, use default pull retry
+	if cfg.MaxPullAttempts == 0 {
+		cfg.MaxPullAttempts = DefaultMaxPullAttempts
+	}
+	if cfg.MaxPullAttempts < 0 {
+		return fmt.Errorf("max pull attempts %d should be positive number", cfg.MaxPullAttempts)
+	}
+
+	if cfg.PullRetryBackoff == "" {
+		cfg.PullRetryBackoff = DefaultPullRetryBackoff
+	}
+	if backoff, err := cfg.GetPullRetryBackoff(); err != nil {
+		return fmt.Errorf("invalid pull retry backoff %s: %v", cfg.PullRetryBackoff, err)
+	} else if backoff < 0 {
+		return fmt.Errorf("pull retry backoff %s should not be negative", cfg.PullRetryBackoff)
+	}
+
+	if cfg.InitPath == "" {
+		cfg.InitPath = DefaultInitPath
+	}
+
+	// if max concurrent downloads is empty, use default max concurrent downloads
+	if cfg.MaxConcurrentDownloads == 0 {
+		cfg.MaxConcurrentDownloads = DefaultMaxConcurrentDownloads
+	}
+	if cfg.MaxConcurrentDownloads < 0 {
+		return fmt.Errorf("max concurrent downloads %d should be positive number", cfg.MaxConcurrentDownloads)
+	}
+
+	if cfg.ShutdownTimeout == 0 {
+		cfg.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	if cfg.ShutdownTimeout < 0 {
+		return fmt.Errorf("shutdown timeout %d should be positive number", cfg.ShutdownTimeout)
+	}
+
 	// if cgroup driver is empty, use default cgroup driver
 	if cfg.CgroupDriver == "" {
 		cfg.CgroupDriver = DefaultCgroupDriver
@@ -182,6 +362,19 @@ func (cfg *Config) Validate() error {
 	return validateCgroupDriver(cfg.CgroupDriver)
 }
 
+// validateTLS validates the TLS config of tcp listeners, the key and cert
+// of daemon should be set together, and verifying the client certs requires
+// the CA signing them.
+func validateTLS(tls client.TLSConfig) error {
+	if (tls.Key == "") != (tls.Cert == "") {
+		return fmt.Errorf("tlscert and tlskey should be set together")
+	}
+	if tls.VerifyRemote && (tls.Key == "" || tls.CA == "") {
+		return fmt.Errorf("tlsverify requires tlscacert, tlscert and tlskey to verify client certs")
+	}
+	return nil
+}
+
 //MergeConfigurations merges flagSet flags and config file flags into Config.
 func (cfg *Config) MergeConfigurations(flagSet *pflag.FlagSet) error {
 	contents, err := ioutil.ReadFile(cfg.ConfigFile)
@@ -201,6 +394,10 @@ func (cfg *Config) MergeConfigurations(flagSet *pflag.FlagSet) error {
 		return nil
 	}
 
+	if err = validateConfigKeys(origin); err != nil {
+		return err
+	}
+
 	fileFlags := make(map[string]interface{})
 	flattenConfig(origin, fileFlags)
 
@@ -214,8 +411,54 @@ func (cfg *Config) MergeConfigurations(flagSet *pflag.FlagSet) error {
 		return fmt.Errorf("failed to decode json: %s", err)
 	}
 
+	// the bool values missing in config file are decoded as false, which
+	// override the flags in merging, so the flags are restored after merge,
+	// for example, live-restore defaults to true.
+	boolFlags := boolFlagValues(flagSet)
+
 	// merge configurations from command line flags and config file
-	return mergeConfigurations(fileConfig, cfg.delValue(flagSet, fileFlags))
+	if err = mergeConfigurations(fileConfig, cfg.delValue(flagSet, fileFlags)); err != nil {
+		return err
+	}
+
+	fileKeys := make(map[string]bool)
+	collectConfigKeys(origin, fileKeys)
+	return restoreBoolFlags(flagSet, boolFlags, fileKeys)
+}
+
+// boolFlagValues returns the values of bool flags.
+func boolFlagValues(flagSet *pflag.FlagSet) map[string]string {
+	values := make(map[string]string)
+	flagSet.VisitAll(func(f *pflag.Flag) {
+		if f.Value.Type() == "bool" {
+			values[f.Name] = f.Value.String()
+		}
+	})
+	return values
+}
+
+// restoreBoolFlags restores the values of bool flags which are missing in
+// config file.
+func restoreBoolFlags(flagSet *pflag.FlagSet, values map[string]string, fileKeys map[string]bool) error {
+	for name, value := range values {
+		if fileKeys[name] {
+			continue
+		}
+		if err := flagSet.Lookup(name).Value.Set(value); err != nil {
+			return fmt.Errorf("failed to restore flag %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// collectConfigKeys collects the keys in config file, including the nested ones.
+func collectConfigKeys(origin map[string]interface{}, keys map[string]bool) {
+	for k, v := range origin {
+		keys[k] = true
+		if c, ok := v.(map[string]interface{}); ok {
+			collectConfigKeys(c, keys)
+		}
+	}
 }
 
 // delValue deleles value in config, since we do not do conflict check for slice
@@ -278,7 +521,7 @@ func getConflictConfigurations(flagSet *pflag.FlagSet, fileFlags map[string]inte
 			return
 		}
 		if v, exist := fileFlags[f.Name]; exist {
-			conflictFlags = append(conflictFlags, fmt.Sprintf("from flag: %s and from config file: %s", f.Value.String(), v))
+			conflictFlags = append(conflictFlags, fmt.Sprintf("%s (from flag: %s, from config file: %v)", f.Name, f.Value.String(), v))
 		}
 	})
 
@@ -289,6 +532,59 @@ func getConflictConfigurations(flagSet *pflag.FlagSet, fileFlags map[string]inte
 	return nil
 }
 
+// validateConfigKeys returns the error of the unknown keys in config file,
+// which are probably typos and would be ignored silently otherwise.
+func validateConfigKeys(origin map[string]interface{}) error {
+	unknown := unknownKeys(origin, reflect.TypeOf(Config{}), "")
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("found unknown keys in config file: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// unknownKeys returns the keys which don't match any field of struct t, and
+// the nested keys are prefixed by the parent, such as network-config.foo.
+// The keys of map, such as the runtimes of add-runtime, are not checked.
+func unknownKeys(values map[string]interface{}, t reflect.Type, prefix string) []string {
+	var unknown []string
+	for key, value := range values {
+		field, ok := jsonField(t, key)
+		if !ok {
+			unknown = append(unknown, prefix+key)
+			continue
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if nested, ok := value.(map[string]interface{}); ok && ft.Kind() == reflect.Struct {
+			unknown = append(unknown, unknownKeys(nested, ft, prefix+key+".")...)
+		}
+	}
+	return unknown
+}
+
+// jsonField returns the field of struct t which the key is decoded into,
+// the key is matched case-insensitively as encoding/json does.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
 // merge flagSet and config file into cfg
 func mergeConfigurations(src *Config, dest *Config) error {
 	return utils.Merge(src, dest)
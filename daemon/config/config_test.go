@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/client"
@@ -248,7 +250,7 @@ func TestGetConflictConfigurations(t *testing.T) {
 	// Test Conflict
 	flags.String("a", "a1", "a")
 	flags.Parse([]string{"--a=a1"})
-	assert.Equal("found conflict flags in command line and config file: from flag: a1 and from config file: a1",
+	assert.Equal("found conflict flags in command line and config file: a (from flag: a1, from config file: a1)",
 		getConflictConfigurations(flags, fileflags).Error())
 }
 
@@ -303,6 +305,101 @@ func TestMergeConfigurations(t *testing.T) {
 	defer os.Remove(configFile)
 }
 
+func TestMergeConfigurationsUnknownKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "test-merge-config")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config.json")
+
+	for _, tc := range []struct {
+		content string
+		err     string
+	}{
+		{
+			content: `{"debug": true, "TLS": {"tlscacert": "/etc/pouch/ca.pem"}, "add-runtime": {"runv": {"path": "/usr/bin/runv"}}}`,
+		},
+		{
+			// the keys are matched case-insensitively like encoding/json.
+			content: `{"Debug": true, "tls": {"tlsverify": true}}`,
+		},
+		{
+			content: `{"debgu": true, "network-config": {"bridge-config": {"bip": "192.168.1.1/24", "mtuu": 1500}}}`,
+			err:     "found unknown keys in config file: debgu, network-config.bridge-config.mtuu",
+		},
+		{
+			content: `{"debug": "yes"}`,
+			err:     "failed to decode json",
+		},
+	} {
+		assert.NoError(ioutil.WriteFile(configFile, []byte(tc.content), 0644))
+
+		cfg := &Config{ConfigFile: configFile}
+		err := cfg.MergeConfigurations(pflag.NewFlagSet("cmflags", pflag.ContinueOnError))
+		if tc.err == "" {
+			assert.NoError(err, tc.content)
+		} else if assert.Error(err, tc.content) {
+			assert.Contains(err.Error(), tc.err)
+		}
+	}
+}
+
+func TestMergeConfigurationsConflict(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "test-merge-config")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config.json")
+	assert.NoError(ioutil.WriteFile(configFile, []byte(`{"debug": false, "cgroup-driver": "systemd", "label": ["a=b"]}`), 0644))
+
+	cfg := &Config{ConfigFile: configFile}
+	flags := pflag.NewFlagSet("cmflags", pflag.ContinueOnError)
+	flags.BoolVar(&cfg.Debug, "debug", false, "")
+	flags.StringVar(&cfg.CgroupDriver, "cgroup-driver", CgroupfsDriver, "")
+	flags.StringSliceVar(&cfg.Labels, "label", nil, "")
+
+	// the labels are merged from both of them.
+	assert.NoError(flags.Parse([]string{"--label=c=d"}))
+	assert.NoError(cfg.MergeConfigurations(flags))
+	assert.Equal(CgroupSystemdDriver, cfg.CgroupDriver)
+	assert.Equal([]string{"c=d", "a=b"}, cfg.Labels)
+
+	assert.NoError(flags.Parse([]string{"--debug"}))
+	assert.EqualError(cfg.MergeConfigurations(flags),
+		"found conflict flags in command line and config file: debug (from flag: true, from config file: false)")
+}
+
+func TestMergeConfigurationsBoolValue(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "test-merge-config")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config.json")
+	assert.NoError(ioutil.WriteFile(configFile, []byte(`{"live-restore": false, "debug": false}`), 0644))
+
+	cfg := &Config{ConfigFile: configFile}
+	flags := pflag.NewFlagSet("cmflags", pflag.ContinueOnError)
+	flags.BoolVar(&cfg.LiveRestore, "live-restore", true, "")
+	flags.BoolVar(&cfg.Debug, "debug", false, "")
+	assert.NoError(flags.Parse(nil))
+
+	// the false value in config file overrides the default value of flag.
+	assert.NoError(cfg.MergeConfigurations(flags))
+	assert.False(cfg.LiveRestore)
+	assert.False(cfg.Debug)
+
+	// the flags missing in config file are kept.
+	assert.NoError(ioutil.WriteFile(configFile, []byte(`{"label": ["a=b"]}`), 0644))
+	cfg.LiveRestore = true
+	assert.NoError(flags.Parse([]string{"--debug"}))
+	assert.NoError(cfg.MergeConfigurations(flags))
+	assert.True(cfg.LiveRestore)
+	assert.True(cfg.Debug)
+}
+
 func TestValidateCgroupDriver(t *testing.T) {
 	for _, tc := range []struct {
 		driver    string
@@ -327,3 +424,137 @@ func TestValidateCgroupDriver(t *testing.T) {
 		}
 	}
 }
+
+func TestValidatePullRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	// use the default pull retry if it is empty
+	cfg := &Config{}
+	assert.NoError(cfg.Validate())
+	assert.Equal(DefaultMaxPullAttempts, cfg.MaxPullAttempts)
+	backoff, err := cfg.GetPullRetryBackoff()
+	assert.NoError(err)
+	assert.Equal(time.Second, backoff)
+
+	for _, tc := range []struct {
+		attempts  int
+		backoff   string
+		expectErr bool
+	}{
+		{attempts: 5, backoff: "500ms", expectErr: false},
+		{attempts: -1, backoff: "1s", expectErr: true},
+		{attempts: 3, backoff: "foo", expectErr: true},
+		{attempts: 3, backoff: "-1s", expectErr: true},
+	} {
+		cfg := &Config{MaxPullAttempts: tc.attempts, PullRetryBackoff: tc.backoff}
+		err := cfg.Validate()
+		if tc.expectErr != (err != nil) {
+			t.Fatalf("expectd error: %v, but get %s", tc.expectErr, err)
+		}
+	}
+}
+
+func TestValidateMaxConcurrentDownloads(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &Config{}
+	assert.NoError(cfg.Validate())
+	assert.Equal(DefaultMaxConcurrentDownloads, cfg.MaxConcurrentDownloads)
+
+	cfg = &Config{MaxConcurrentDownloads: 10}
+	assert.NoError(cfg.Validate())
+	assert.Equal(10, cfg.MaxConcurrentDownloads)
+
+	cfg = &Config{MaxConcurrentDownloads: -1}
+	assert.Error(cfg.Validate())
+}
+
+func TestValidateShutdownTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &Config{}
+	assert.Equal(DefaultShutdownTimeout*time.Second, cfg.GetShutdownTimeout())
+	assert.NoError(cfg.Validate())
+	assert.Equal(DefaultShutdownTimeout, cfg.ShutdownTimeout)
+
+	cfg = &Config{ShutdownTimeout: 3}
+	assert.NoError(cfg.Validate())
+	assert.Equal(3*time.Second, cfg.GetShutdownTimeout())
+
+	cfg = &Config{ShutdownTimeout: -1}
+	assert.Error(cfg.Validate())
+}
+
+func TestValidateDefaultUlimits(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &Config{DefaultUlimits: []*types.Ulimit{{Name: "nofile", Soft: 1024, Hard: 65535}}}
+	assert.NoError(cfg.Validate())
+
+	cfg = &Config{DefaultUlimits: []*types.Ulimit{{Name: "nofile", Soft: 65535, Hard: 1024}}}
+	assert.Error(cfg.Validate())
+
+	cfg = &Config{DefaultUlimits: []*types.Ulimit{{Name: "foo", Soft: 1, Hard: 1}}}
+	assert.Error(cfg.Validate())
+}
+
+func TestValidateTLS(t *testing.T) {
+	for _, tc := range []struct {
+		tls       client.TLSConfig
+		expectErr bool
+	}{
+		{tls: client.TLSConfig{}, expectErr: false},
+		{tls: client.TLSConfig{Key: "key.pem", Cert: "cert.pem"}, expectErr: false},
+		{tls: client.TLSConfig{Key: "key.pem", Cert: "cert.pem", CA: "ca.pem", VerifyRemote: true}, expectErr: false},
+		{tls: client.TLSConfig{Key: "key.pem"}, expectErr: true},
+		{tls: client.TLSConfig{Cert: "cert.pem", CA: "ca.pem"}, expectErr: true},
+		{tls: client.TLSConfig{Key: "key.pem", Cert: "cert.pem", VerifyRemote: true}, expectErr: true},
+		{tls: client.TLSConfig{CA: "ca.pem", VerifyRemote: true}, expectErr: true},
+	} {
+		err := (&Config{TLS: tc.tls}).Validate()
+		if tc.expectErr != (err != nil) {
+			t.Fatalf("expectd error: %v, but get %s", tc.expectErr, err)
+		}
+	}
+}
+
+func TestConfigReload(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &Config{
+		Debug:                  false,
+		Labels:                 []string{},
+		RegistryMirrors:        []string{"https://mirror.example.com"},
+		MaxConcurrentDownloads: 3,
+		CgroupDriver:           CgroupfsDriver,
+		HomeDir:                "/var/lib/pouch",
+	}
+	newCfg := &Config{
+		Debug:                  true,
+		Labels:                 nil,
+		InsecureRegistries:     []string{"reg.abc.com:5000"},
+		MaxConcurrentDownloads: 3,
+		CgroupDriver:           CgroupSystemdDriver,
+		HomeDir:                "/var/lib/pouch",
+	}
+
+	changed, ignored := cfg.Reload(newCfg)
+	assert.Equal([]string{"debug", "insecure-registries", "registry-mirrors"}, changed)
+	assert.Equal([]string{"cgroup-driver"}, ignored)
+
+	assert.True(cfg.Debug)
+	assert.Equal([]string{"reg.abc.com:5000"}, cfg.InsecureRegistries)
+	assert.Len(cfg.RegistryMirrors, 0)
+	// the configurations require restarting daemon are kept.
+	assert.Equal(CgroupfsDriver, cfg.CgroupDriver)
+
+	changed, ignored = cfg.Reload(newCfg)
+	assert.Len(changed, 0)
+	assert.Equal([]string{"cgroup-driver"}, ignored)
+
+	// the labels returned are a copy.
+	cfg.Labels = []string{"a=b"}
+	labels := cfg.GetLabels()
+	labels[0] = "c=d"
+	assert.Equal([]string{"a=b"}, cfg.Labels)
+}
@@ -47,6 +47,7 @@ type IO struct {
 
 	logdriver logger.LogDriver
 	logcopier *logger.LogCopier
+	logpipes  []io.ReadCloser
 	criLog    *crilog.Log
 
 	nonBlock      bool
@@ -83,6 +84,7 @@ func (ctrio *IO) Reset() {
 	}
 	ctrio.logdriver = nil
 	ctrio.logcopier = nil
+	ctrio.logpipes = nil
 	ctrio.criLog = nil
 }
 
@@ -153,18 +155,7 @@ func (ctrio *IO) Close() error {
 	}
 
 	if ctrio.logdriver != nil {
-		if ctrio.logcopier != nil {
-			waitCh := make(chan struct{})
-			go func() {
-				defer close(waitCh)
-				ctrio.logcopier.Wait()
-			}()
-			select {
-			case <-waitCh:
-			case <-time.After(logcopierCloseTimeout):
-				logrus.Warnf("logcopier doesn't exit in time")
-			}
-		}
+		ctrio.waitLogCopier()
 
 		if err := ctrio.logdriver.Close(); err != nil {
 			multiErrs.Append(err)
@@ -183,6 +174,49 @@ func (ctrio *IO) Close() error {
 	return nil
 }
 
+// FlushLogs writes the buffered logs with the log driver and closes it,
+// which is used when pouchd is stopped. Unlike Close, the streams aren't
+// closed since the container may be kept running.
+//
+// NOTE: the log copier must be stopped before closing the log driver,
+// otherwise it keeps writing into the closed one if the container is kept
+// running. Closing the pipes only evicts them from the stream, so the data
+// is still copied to other writers, such as attached clients.
+func (ctrio *IO) FlushLogs() error {
+	if ctrio.logdriver == nil {
+		return nil
+	}
+
+	for _, p := range ctrio.logpipes {
+		p.Close()
+	}
+	ctrio.waitLogCopier()
+
+	err := ctrio.logdriver.Close()
+	ctrio.logdriver = nil
+	ctrio.logcopier = nil
+	ctrio.logpipes = nil
+	return err
+}
+
+// waitLogCopier waits for the log copier to exit with timeout.
+func (ctrio *IO) waitLogCopier() {
+	if ctrio.logcopier == nil {
+		return
+	}
+
+	waitCh := make(chan struct{})
+	go func() {
+		defer close(waitCh)
+		ctrio.logcopier.Wait()
+	}()
+	select {
+	case <-waitCh:
+	case <-time.After(logcopierCloseTimeout):
+		logrus.Warnf("logcopier doesn't exit in time")
+	}
+}
+
 // InitContainerIO will start logger and coping data from fifo.
 func (ctrio *IO) InitContainerIO(dio *cio.DirectIO) (cio.IO, error) {
 	if err := ctrio.startLogging(); err != nil {
@@ -210,9 +244,11 @@ func (ctrio *IO) startLogging() error {
 		ctrio.logdriver = logDriver
 	}
 
+	stdout, stderr := ctrio.stream.NewStdoutPipe(), ctrio.stream.NewStderrPipe()
+	ctrio.logpipes = []io.ReadCloser{stdout, stderr}
 	ctrio.logcopier = logger.NewLogCopier(ctrio.logdriver, map[string]io.Reader{
-		"stdout": ctrio.stream.NewStdoutPipe(),
-		"stderr": ctrio.stream.NewStderrPipe(),
+		"stdout": stdout,
+		"stderr": stderr,
 	})
 	ctrio.logcopier.StartCopy()
 	return nil
@@ -0,0 +1,66 @@
+package containerio
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/alibaba/pouch/daemon/logger"
+)
+
+type fakeLogDriver struct {
+	sync.Mutex
+	closed          bool
+	writeAfterClose bool
+}
+
+func (ld *fakeLogDriver) Name() string {
+	return "fake"
+}
+
+func (ld *fakeLogDriver) WriteLogMessage(msg *logger.LogMessage) error {
+	ld.Lock()
+	defer ld.Unlock()
+	if ld.closed {
+		ld.writeAfterClose = true
+	}
+	return nil
+}
+
+func (ld *fakeLogDriver) Close() error {
+	ld.Lock()
+	defer ld.Unlock()
+	ld.closed = true
+	return nil
+}
+
+func TestFlushLogsStopsLogCopier(t *testing.T) {
+	ld := &fakeLogDriver{}
+	ctrio := NewIO("test", false)
+	ctrio.SetLogDriver(ld)
+	if err := ctrio.startLogging(); err != nil {
+		t.Fatalf("failed to start logging: %v", err)
+	}
+
+	stdout := ctrio.Stream().Stdout()
+	if _, err := stdout.Write([]byte("before flush\n")); err != nil {
+		t.Fatalf("failed to write data: %v", err)
+	}
+
+	if err := ctrio.FlushLogs(); err != nil {
+		t.Fatalf("failed to flush logs: %v", err)
+	}
+
+	// the container is kept running and writes after flush.
+	if _, err := stdout.Write([]byte("after flush\n")); err != nil {
+		t.Fatalf("failed to write data: %v", err)
+	}
+
+	ld.Lock()
+	defer ld.Unlock()
+	if !ld.closed {
+		t.Fatalf("expected the log driver to be closed")
+	}
+	if ld.writeAfterClose {
+		t.Fatalf("expected no log written into the closed log driver")
+	}
+}
@@ -69,35 +69,46 @@ func NewDaemon(cfg *config.Config) *Daemon {
 	}
 
 	// start containerd
-	ctrdDaemonOpts := []supervisord.Opt{
-		supervisord.WithOOMScore(cfg.OOMScoreAdjust),
-		supervisord.WithGRPCAddress(cfg.ContainerdAddr),
-	}
+	var ctrdDaemon *supervisord.Daemon
+	if cfg.ManageContainerd {
+		ctrdDaemonOpts := []supervisord.Opt{
+			supervisord.WithOOMScore(cfg.OOMScoreAdjust),
+			supervisord.WithGRPCAddress(cfg.ContainerdAddr),
+		}
 
-	if cfg.ContainerdPath != "" {
-		ctrdDaemonOpts = append(ctrdDaemonOpts, supervisord.WithContainerdBinary(cfg.ContainerdPath))
-	}
+		if cfg.ContainerdPath != "" {
+			ctrdDaemonOpts = append(ctrdDaemonOpts, supervisord.WithContainerdBinary(cfg.ContainerdPath))
+		}
 
-	if cfg.Debug {
-		ctrdDaemonOpts = append(ctrdDaemonOpts, supervisord.WithLogLevel("debug"))
-		ctrdDaemonOpts = append(ctrdDaemonOpts, supervisord.WithV1RuntimeShimDebug())
-	}
+		if cfg.Debug {
+			ctrdDaemonOpts = append(ctrdDaemonOpts, supervisord.WithLogLevel("debug"))
+			ctrdDaemonOpts = append(ctrdDaemonOpts, supervisord.WithV1RuntimeShimDebug())
+		}
 
-	ctrdDaemon, err := supervisord.Start(context.TODO(),
-		filepath.Join(cfg.HomeDir, "containerd/root"),
-		filepath.Join(cfg.HomeDir, "containerd/state"),
-		ctrdDaemonOpts...,
-	)
-	if err != nil {
-		logrus.Errorf("failed to start containerd: %v", err)
-		return nil
+		ctrdDaemon, err = supervisord.Start(context.TODO(),
+			filepath.Join(cfg.HomeDir, "containerd/root"),
+			filepath.Join(cfg.HomeDir, "containerd/state"),
+			ctrdDaemonOpts...,
+		)
+		if err != nil {
+			logrus.Errorf("failed to start containerd: %v", err)
+			return nil
+		}
+	} else {
+		logrus.Infof("connect to the running containerd at %s", cfg.ContainerdAddr)
 	}
 
+	// the backoff has been validated in config
+	pullRetryBackoff, _ := cfg.GetPullRetryBackoff()
+
 	// create containerd client
 	ctrdClient, err := ctrd.NewClient(
 		ctrd.WithRPCAddr(cfg.ContainerdAddr),
 		ctrd.WithDefaultNamespace(cfg.DefaultNamespace),
 		ctrd.WithInsecureRegistries(cfg.InsecureRegistries),
+		ctrd.WithRegistryMirrors(cfg.RegistryMirrors),
+		ctrd.WithPullRetry(cfg.MaxPullAttempts, pullRetryBackoff),
+		ctrd.WithMaxConcurrentDownloads(cfg.MaxConcurrentDownloads),
 	)
 	if err != nil {
 		logrus.Errorf("failed to new containerd's client: %v", err)
@@ -205,6 +216,7 @@ func (d *Daemon) Run() error {
 		return err
 	}
 	d.containerMgr = containerMgr
+	volumeMgr.(*mgr.VolumeManager).ContainerMgr = containerMgr
 
 	// just register containers information here to let
 	// networkMgr to use.
@@ -235,6 +247,10 @@ func (d *Daemon) Run() error {
 		return err
 	}
 
+	if err := d.registerMetrics(); err != nil {
+		logrus.Warnf("failed to register metrics of daemon: %v", err)
+	}
+
 	// set image proxy
 	ctrd.SetImageProxy(d.config.ImageProxy)
 
@@ -290,21 +306,36 @@ func (d *Daemon) Run() error {
 	return nil
 }
 
-// Shutdown stops daemon.
+// Shutdown stops daemon. It stops accepting API requests first, then stops
+// the containers if live restore is disabled, flushes the logs of containers
+// and the events to subscribers, and closes the containerd client last.
 func (d *Daemon) Shutdown() error {
 	var errMsg string
 
 	if err := d.server.Stop(); err != nil {
-		errMsg = fmt.Sprintf("%s\n", err.Error())
+		errMsg += fmt.Sprintf("%s\n", err.Error())
+	}
+
+	if !d.config.LiveRestore {
+		logrus.Infof("live restore is disabled, stop all the running containers in %v", d.config.GetShutdownTimeout())
+	}
+	if err := d.containerMgr.Shutdown(context.Background()); err != nil {
+		errMsg += fmt.Sprintf("%s\n", err.Error())
+	}
+
+	if err := d.eventsService.Close(); err != nil {
+		errMsg += fmt.Sprintf("%s\n", err.Error())
 	}
 
 	logrus.Debugf("Start cleanup containerd...")
 	if err := d.ctrdClient.Cleanup(); err != nil {
-		errMsg = fmt.Sprintf("%s\n", err.Error())
+		errMsg += fmt.Sprintf("%s\n", err.Error())
 	}
 
-	if err := d.ctrdDaemon.Stop(); err != nil {
-		errMsg = fmt.Sprintf("%s\n", err.Error())
+	if d.ctrdDaemon != nil {
+		if err := d.ctrdDaemon.Stop(); err != nil {
+			errMsg += fmt.Sprintf("%s\n", err.Error())
+		}
 	}
 
 	if errMsg != "" {
@@ -388,15 +419,20 @@ func (d *Daemon) addSystemLabels() error {
 	if d.config.Labels == nil {
 		d.config.Labels = make([]string, 0)
 	}
+	d.config.Labels = append(d.config.Labels, systemLabels()...)
+
+	return nil
+}
+
+// systemLabels returns the labels of node ip and serial number.
+func systemLabels() []string {
 	// get node IP
 	nodeIP := system.GetNodeIP()
-	d.config.Labels = append(d.config.Labels, fmt.Sprintf("node_ip=%s", nodeIP))
 
 	// get serial number
 	serialNo := system.GetSerialNumber()
-	d.config.Labels = append(d.config.Labels, fmt.Sprintf("SN=%s", serialNo))
 
-	return nil
+	return []string{fmt.Sprintf("node_ip=%s", nodeIP), fmt.Sprintf("SN=%s", serialNo)}
 }
 
 func notifySystemd() {
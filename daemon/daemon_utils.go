@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/sirupsen/logrus"
 )
 
 var (
@@ -35,14 +38,20 @@ func initialRuntime(baseDir string, runtimes map[string]types.Runtime) error {
 
 	// create script for runtime who has args
 	for name, r := range runtimes {
+		if r.Path == "" {
+			r.Path = name
+		}
+
+		// the containers of the runtime fail to start, but the others work.
+		if _, err := exec.LookPath(r.Path); err != nil {
+			logrus.Warnf("failed to find binary %s of runtime %s: %v", r.Path, name, err)
+		}
+
 		if len(r.RuntimeArgs) == 0 {
 			continue
 		}
 
 		script := filepath.Join(dir, name)
-		if r.Path == "" {
-			r.Path = name
-		}
 		data := fmt.Sprintf("#!/bin/sh\n%s %s $@\n", r.Path, strings.Join(r.RuntimeArgs, " "))
 
 		if err := ioutil.WriteFile(script, []byte(data), runtimeScriptPerm); err != nil {
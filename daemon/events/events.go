@@ -2,13 +2,13 @@ package events
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
 
 	goevents "github.com/docker/go-events"
-	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -16,6 +16,9 @@ const (
 	eventsLimit = 64
 )
 
+// closeTimeout is the timeout to wait for the subscriptions to end on Close.
+var closeTimeout = 5 * time.Second
+
 // Events is pubsub channel for events generated by the engine.
 type Events struct {
 	mux sync.Mutex
@@ -23,6 +26,16 @@ type Events struct {
 	// support buffered events message
 	events      []types.EventsMessage
 	broadcaster *goevents.Broadcaster
+
+	// closed is closed to end all the subscriptions.
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	// subscribers is the number of subscriptions which haven't ended.
+	subscribers sync.WaitGroup
+
+	// queues are the queues of subscriptions, protected by mux.
+	queues map[*boundedQueue]struct{}
 }
 
 // NewEvents return a new Events instance
@@ -30,6 +43,8 @@ func NewEvents() *Events {
 	return &Events{
 		events:      make([]types.EventsMessage, 0, eventsLimit),
 		broadcaster: goevents.NewBroadcaster(),
+		closed:      make(chan struct{}),
+		queues:      make(map[*boundedQueue]struct{}),
 	}
 }
 
@@ -92,24 +107,31 @@ func (e *Events) Publish(ctx context.Context, action string, eventType types.Eve
 //
 // Zero or more filters may be provided as Args. Only events that match
 // *any* of the provided filters will be sent on the channel.
+//
+// Each subscriber has a bounded queue, so that the slow subscriber doesn't
+// block the publishers. If the queue overflows, the events are dropped and
+// a daemon event with action "events dropped" is sent to the subscriber.
 func (e *Events) Subscribe(ctx context.Context, since, until time.Time, ef *Filter) ([]types.EventsMessage, <-chan *types.EventsMessage, <-chan error) {
 	var (
-		evch                  = make(chan *types.EventsMessage)
-		errq                  = make(chan error, 1)
-		channel               = goevents.NewChannel(0)
-		queue                 = goevents.NewQueue(channel)
-		dst     goevents.Sink = queue
+		evch                = make(chan *types.EventsMessage)
+		errq                = make(chan error, 1)
+		queue               = newBoundedQueue(subscriberQueueSize)
+		dst   goevents.Sink = queue
 	)
 
 	closeAll := func() {
 		close(errq)
 		e.broadcaster.Remove(dst)
 		queue.Close()
-		channel.Close()
+
+		e.mux.Lock()
+		delete(e.queues, queue)
+		e.mux.Unlock()
 	}
 
 	e.mux.Lock()
 	buffered := e.filterBufferedEvents(since, until, ef)
+	e.queues[queue] = struct{}{}
 	e.mux.Unlock()
 
 	// add filters for event messages
@@ -123,42 +145,93 @@ func (e *Events) Subscribe(ctx context.Context, since, until time.Time, ef *Filt
 
 	e.broadcaster.Add(dst)
 
+	e.subscribers.Add(1)
 	go func() {
+		defer e.subscribers.Done()
 		defer closeAll()
 
-		var err error
+		send := func(ev *types.EventsMessage) bool {
+			select {
+			case evch <- ev:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
 	loop:
 		for {
 			select {
-			case ev := <-channel.C:
-				env, ok := ev.(*types.EventsMessage)
-				if !ok {
-					err = errors.Errorf("invalid message encountered %#v; please file a bug", ev)
-					break
+			case ev := <-queue.ch:
+				if !send(ev) {
+					break loop
 				}
-
-				select {
-				case evch <- env:
-				case <-ctx.Done():
+				if marker := queue.takeDropped(); marker != nil && !send(marker) {
 					break loop
 				}
 			case <-ctx.Done():
 				break loop
+			case <-e.closed:
+				// the pending events are sent before the subscription ends.
+				for {
+					select {
+					case ev := <-queue.ch:
+						if !send(ev) {
+							break loop
+						}
+					default:
+						break loop
+					}
+				}
 			}
 		}
 
-		if err == nil {
-			if cerr := ctx.Err(); cerr != context.Canceled {
-				err = cerr
-			}
+		var err error
+		if cerr := ctx.Err(); cerr != context.Canceled {
+			err = cerr
 		}
-
 		errq <- err
 	}()
 
 	return buffered, evch, errq
 }
 
+// Close ends all the subscriptions after the pending events are sent to the
+// subscribers, which is used when pouchd is stopped. It waits for the
+// subscriptions to end until timeout, so that the slow subscriber doesn't
+// block the shutdown.
+func (e *Events) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.closed)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.subscribers.Wait()
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(closeTimeout):
+		return fmt.Errorf("failed to wait for the subscriptions of events to end in %v", closeTimeout)
+	}
+}
+
+// QueueDepth returns the number of events waiting to be sent to the
+// subscribers.
+func (e *Events) QueueDepth() int {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	depth := 0
+	for q := range e.queues {
+		depth += len(q.ch)
+	}
+	return depth
+}
+
 // filterBufferedEvents iterates over the cached events in the buffer
 // and returns those that were emitted between two specific dates.
 func (e *Events) filterBufferedEvents(since, until time.Time, ef *Filter) []types.EventsMessage {
@@ -115,3 +115,138 @@ func TestBasicSubscribe(t *testing.T) {
 		}
 	}
 }
+
+func TestSubscribeDroppedEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventsService := NewEvents()
+	_, eventq, _ := eventsService.Subscribe(ctx, time.Time{}, time.Time{}, nil)
+
+	// the subscriber doesn't read events, so the queue overflows.
+	total := subscriberQueueSize + 10
+	for i := 0; i < total; i++ {
+		if err := eventsService.Publish(ctx, "start", types.EventTypeContainer, &types.EventsActor{ID: fmt.Sprint(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var (
+		received int
+		marker   *types.EventsMessage
+	)
+	timeout := time.After(10 * time.Second)
+	for marker == nil {
+		select {
+		case ev := <-eventq:
+			if ev.Action == droppedAction {
+				marker = ev
+				break
+			}
+			received++
+		case <-timeout:
+			t.Fatalf("expected the dropped marker after %d events", received)
+		}
+	}
+
+	if marker.Type != types.EventTypeDaemon {
+		t.Fatalf("expected the marker is daemon event, but got %s", marker.Type)
+	}
+	if dropped := marker.Actor.Attributes["count"]; dropped != fmt.Sprint(total-received) {
+		t.Fatalf("expected %d dropped events, but got %s", total-received, dropped)
+	}
+}
+
+func TestClose(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventsService := NewEvents()
+	_, eventq, errq := eventsService.Subscribe(ctx, time.Time{}, time.Time{}, nil)
+
+	for _, action := range []string{"die", "stop"} {
+		if err := eventsService.Publish(ctx, action, types.EventTypeContainer, &types.EventsActor{ID: "foo"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// wait for the events to be queued for the subscriber.
+	time.Sleep(100 * time.Millisecond)
+
+	closeErr := make(chan error, 1)
+	go func() {
+		closeErr <- eventsService.Close()
+	}()
+
+	// the pending events are sent before the subscription ends.
+	for _, action := range []string{"die", "stop"} {
+		select {
+		case ev := <-eventq:
+			if ev.Action != action {
+				t.Fatalf("expected event %s, but got %s", action, ev.Action)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected event %s before the subscription ends", action)
+		}
+	}
+
+	if err := <-errq; err != nil {
+		t.Fatalf("expected the subscription ends without error, but got %v", err)
+	}
+	if err := <-closeErr; err != nil {
+		t.Fatal(err)
+	}
+
+	// the subscription after close ends at once.
+	_, _, errq = eventsService.Subscribe(ctx, time.Time{}, time.Time{}, nil)
+	if err := <-errq; err != nil {
+		t.Fatalf("expected the subscription ends without error, but got %v", err)
+	}
+}
+
+func TestCloseTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { closeTimeout = timeout }(closeTimeout)
+	closeTimeout = 100 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventsService := NewEvents()
+	eventsService.Subscribe(ctx, time.Time{}, time.Time{}, nil)
+	if err := eventsService.Publish(ctx, "die", types.EventTypeContainer, &types.EventsActor{ID: "foo"}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// the subscriber which doesn't read events doesn't block close.
+	if err := eventsService.Close(); err == nil {
+		t.Fatal("expected error of timeout, but got nil")
+	}
+}
+
+func TestQueueDepth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	eventsService := NewEvents()
+	_, _, errq := eventsService.Subscribe(ctx, time.Time{}, time.Time{}, nil)
+
+	for _, action := range []string{"create", "start", "die"} {
+		if err := eventsService.Publish(ctx, action, types.EventTypeContainer, &types.EventsActor{ID: "foo"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// the first event has been taken from the queue, and it is waiting for
+	// the subscriber to read.
+	if depth := eventsService.QueueDepth(); depth != 2 {
+		t.Fatalf("expected queue depth 2, but got %d", depth)
+	}
+
+	// the queue isn't counted after the subscription ends.
+	cancel()
+	for range errq {
+	}
+	if depth := eventsService.QueueDepth(); depth != 0 {
+		t.Fatalf("expected queue depth 0, but got %d", depth)
+	}
+}
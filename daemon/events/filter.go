@@ -1,6 +1,8 @@
 package events
 
 import (
+	"strings"
+
 	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
 )
@@ -17,7 +19,74 @@ func NewFilter(filter filters.Args) *Filter {
 
 // Match returns true when the event ev is included by the filters
 func (ef *Filter) Match(ev types.EventsMessage) bool {
-	// TODO(ziren): add more filters
 	return ef.filter.ExactMatch("event", ev.Action) &&
-		ef.filter.ExactMatch("type", string(ev.Type))
+		ef.filter.ExactMatch("type", string(ev.Type)) &&
+		ef.matchContainer(ev) &&
+		ef.matchImage(ev)
+}
+
+// matchContainer matches the container filter with the ID or name of the
+// container, the events of other types never match the container filter.
+func (ef *Filter) matchContainer(ev types.EventsMessage) bool {
+	if !ef.filter.Contains("container") {
+		return true
+	}
+	if ev.Type != types.EventTypeContainer || ev.Actor == nil {
+		return false
+	}
+
+	for _, value := range ef.filter.Get("container") {
+		if value == ev.Actor.Attributes["name"] || (value != "" && strings.HasPrefix(ev.Actor.ID, value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchImage matches the image filter with the image of container events,
+// or the ID and reference of image events.
+func (ef *Filter) matchImage(ev types.EventsMessage) bool {
+	if !ef.filter.Contains("image") {
+		return true
+	}
+	if ev.Actor == nil {
+		return false
+	}
+
+	var candidates []string
+	switch ev.Type {
+	case types.EventTypeContainer:
+		candidates = []string{ev.Actor.Attributes["image"]}
+	case types.EventTypeImage:
+		candidates = []string{ev.Actor.ID, ev.Actor.Attributes["Name"]}
+	default:
+		return false
+	}
+
+	for _, value := range ef.filter.Get("image") {
+		for _, candidate := range candidates {
+			if matchImageReference(value, candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchImageReference returns true if the ref is the same image as value,
+// the default registry, namespace and tag can be omitted in the value, such
+// as busybox for registry.hub.docker.com/library/busybox:latest.
+func matchImageReference(value, ref string) bool {
+	if value == "" || ref == "" {
+		return false
+	}
+	if value == ref {
+		return true
+	}
+
+	// add the default tag if the value has neither tag nor digest.
+	if !strings.Contains(value, "@") && !strings.Contains(value[strings.LastIndex(value, "/")+1:], ":") {
+		value += ":latest"
+	}
+	return value == ref || strings.HasSuffix(ref, "/"+value)
 }
@@ -52,6 +52,90 @@ func TestFilter_Match(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "container filter matches the name",
+			fields: fields{
+				filter: filters.NewArgs(filters.Arg("container", "web"), filters.Arg("event", "die")),
+			},
+			args: args{
+				ev: types.EventsMessage{
+					Action: "die",
+					Type:   types.EventTypeContainer,
+					Actor:  &types.EventsActor{ID: "asdf", Attributes: map[string]string{"name": "web"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "container filter matches the ID prefix",
+			fields: fields{
+				filter: filters.NewArgs(filters.Arg("container", "as")),
+			},
+			args: args{
+				ev: types.EventsMessage{
+					Action: "start",
+					Type:   types.EventTypeContainer,
+					Actor:  &types.EventsActor{ID: "asdf", Attributes: map[string]string{"name": "web"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "container filter skips the image events",
+			fields: fields{
+				filter: filters.NewArgs(filters.Arg("container", "web")),
+			},
+			args: args{
+				ev: types.EventsMessage{
+					Action: "pull",
+					Type:   types.EventTypeImage,
+					Actor:  &types.EventsActor{ID: "web"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "image filter matches the image of container",
+			fields: fields{
+				filter: filters.NewArgs(filters.Arg("image", "busybox")),
+			},
+			args: args{
+				ev: types.EventsMessage{
+					Action: "create",
+					Type:   types.EventTypeContainer,
+					Actor:  &types.EventsActor{ID: "asdf", Attributes: map[string]string{"image": "registry.hub.docker.com/library/busybox:latest"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "image filter matches the reference of image",
+			fields: fields{
+				filter: filters.NewArgs(filters.Arg("image", "library/busybox:1.25")),
+			},
+			args: args{
+				ev: types.EventsMessage{
+					Action: "tag",
+					Type:   types.EventTypeImage,
+					Actor:  &types.EventsActor{ID: "sha256:abcd", Attributes: map[string]string{"Name": "registry.hub.docker.com/library/busybox:1.25"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "image filter with different tag",
+			fields: fields{
+				filter: filters.NewArgs(filters.Arg("image", "busybox")),
+			},
+			args: args{
+				ev: types.EventsMessage{
+					Action: "tag",
+					Type:   types.EventTypeImage,
+					Actor:  &types.EventsActor{ID: "sha256:abcd", Attributes: map[string]string{"Name": "registry.hub.docker.com/library/busybox:1.25"}},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
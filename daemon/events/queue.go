@@ -0,0 +1,81 @@
+package events
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	goevents "github.com/docker/go-events"
+	"github.com/pkg/errors"
+)
+
+const (
+	// subscriberQueueSize is the number of events which can be queued for
+	// one subscriber before the events are dropped.
+	subscriberQueueSize = 1024
+
+	// droppedAction is the action of the marker event which tells the
+	// subscriber that some events have been dropped.
+	droppedAction = "events dropped"
+)
+
+// boundedQueue is a goevents.Sink which queues the events for a subscriber.
+// Write never blocks the broadcaster, the events are dropped and counted if
+// the subscriber is too slow to drain the queue.
+type boundedQueue struct {
+	ch      chan *types.EventsMessage
+	dropped int64
+}
+
+func newBoundedQueue(size int) *boundedQueue {
+	return &boundedQueue{
+		ch: make(chan *types.EventsMessage, size),
+	}
+}
+
+// Write puts the event into the queue, or drops it if the queue is full.
+func (q *boundedQueue) Write(ev goevents.Event) error {
+	msg, ok := ev.(*types.EventsMessage)
+	if !ok {
+		return errors.Errorf("invalid message encountered %#v; please file a bug", ev)
+	}
+
+	select {
+	case q.ch <- msg:
+	default:
+		atomic.AddInt64(&q.dropped, 1)
+	}
+	return nil
+}
+
+// Close does nothing, the queue is released after removed from broadcaster.
+func (q *boundedQueue) Close() error {
+	return nil
+}
+
+// takeDropped returns the marker event if there are dropped events and
+// resets the counter. The marker is only returned once the queue has been
+// drained, so that it follows the events queued before the overflow.
+func (q *boundedQueue) takeDropped() *types.EventsMessage {
+	if len(q.ch) != 0 {
+		return nil
+	}
+
+	n := atomic.SwapInt64(&q.dropped, 0)
+	if n == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	return &types.EventsMessage{
+		Action: droppedAction,
+		Type:   types.EventTypeDaemon,
+		Actor: &types.EventsActor{
+			Attributes: map[string]string{"count": strconv.FormatInt(n, 10)},
+		},
+		Time:     now.Unix(),
+		TimeNano: now.UnixNano(),
+	}
+}
@@ -51,7 +51,8 @@ func (lc *LogCopier) copy(source string, reader io.Reader) {
 	for {
 		bs, isPartial, err = br.ReadLine()
 		if err != nil {
-			if err != io.EOF {
+			// the pipe is closed when the copier is stopped.
+			if err != io.EOF && err != io.ErrClosedPipe {
 				logrus.WithError(err).
 					Errorf("failed to copy into %v-%v", lc.dst.Name(), source)
 			}
@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Creator creates the log driver with the information of container.
+type Creator func(info Info) (LogDriver, error)
+
+// Validator validates the log options of container, the options common to
+// all the log drivers, such as mode, aren't passed to it.
+type Validator func(info Info) error
+
+// factory records the registered log drivers.
+type factory struct {
+	sync.RWMutex
+
+	creators   map[string]Creator
+	validators map[string]Validator
+}
+
+var drivers = &factory{
+	creators:   make(map[string]Creator),
+	validators: make(map[string]Validator),
+}
+
+// RegisterLogDriver registers the log driver, which is usually called in the
+// init of the package of log driver, so that new log drivers can be added
+// without changing the daemon.
+func RegisterLogDriver(name string, c Creator) error {
+	drivers.Lock()
+	defer drivers.Unlock()
+
+	if _, ok := drivers.creators[name]; ok {
+		return fmt.Errorf("log driver %s is already registered", name)
+	}
+	drivers.creators[name] = c
+	return nil
+}
+
+// RegisterLogOptValidator registers the validator of log options of the log
+// driver. The options aren't validated if the log driver has no validator.
+func RegisterLogOptValidator(name string, v Validator) error {
+	drivers.Lock()
+	defer drivers.Unlock()
+
+	if _, ok := drivers.validators[name]; ok {
+		return fmt.Errorf("log validator for %s is already registered", name)
+	}
+	drivers.validators[name] = v
+	return nil
+}
+
+// GetLogDriver returns the creator of the log driver.
+func GetLogDriver(name string) (Creator, error) {
+	drivers.RLock()
+	defer drivers.RUnlock()
+
+	c, ok := drivers.creators[name]
+	if !ok {
+		return nil, fmt.Errorf("not support (%v) log driver yet", name)
+	}
+	return c, nil
+}
+
+// ValidateLogOpts validates the log options of the log driver.
+func ValidateLogOpts(name string, info Info) error {
+	if _, err := GetLogDriver(name); err != nil {
+		return err
+	}
+
+	drivers.RLock()
+	v, ok := drivers.validators[name]
+	drivers.RUnlock()
+
+	if !ok {
+		return nil
+	}
+	return v(info)
+}
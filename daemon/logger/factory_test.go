@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRegisterLogDriver(t *testing.T) {
+	name := "test-factory-driver"
+	creator := func(info Info) (LogDriver, error) {
+		return nil, fmt.Errorf("not implemented")
+	}
+
+	if err := RegisterLogDriver(name, creator); err != nil {
+		t.Fatalf("failed to register log driver: %v", err)
+	}
+	if err := RegisterLogDriver(name, creator); err == nil {
+		t.Fatalf("expect error when log driver is registered twice, but got nil")
+	}
+
+	if _, err := GetLogDriver(name); err != nil {
+		t.Fatalf("expect log driver %s, but got error: %v", name, err)
+	}
+	if _, err := GetLogDriver("test-factory-unknown"); err == nil {
+		t.Fatalf("expect error for unknown log driver, but got nil")
+	}
+}
+
+func TestValidateLogOpts(t *testing.T) {
+	name := "test-factory-validator"
+	if err := ValidateLogOpts(name, Info{}); err == nil {
+		t.Fatalf("expect error for unknown log driver, but got nil")
+	}
+
+	if err := RegisterLogDriver(name, func(info Info) (LogDriver, error) {
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("failed to register log driver: %v", err)
+	}
+
+	// the options aren't validated without validator.
+	if err := ValidateLogOpts(name, Info{LogConfig: map[string]string{"foo": "bar"}}); err != nil {
+		t.Fatalf("expect no error without validator, but got %v", err)
+	}
+
+	if err := RegisterLogOptValidator(name, func(info Info) error {
+		if _, ok := info.LogConfig["foo"]; ok {
+			return fmt.Errorf("unknown log opt 'foo'")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to register log opt validator: %v", err)
+	}
+	if err := ValidateLogOpts(name, Info{LogConfig: map[string]string{"foo": "bar"}}); err == nil {
+		t.Fatalf("expect error from validator, but got nil")
+	}
+}
@@ -0,0 +1,219 @@
+package fluentd
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alibaba/pouch/daemon/logger"
+	"github.com/alibaba/pouch/daemon/logger/loggerutils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Name is the name of fluentd log driver.
+const Name = "fluentd"
+
+const (
+	defaultTagTemplate = "{{.ID}}"
+
+	defaultHost        = "127.0.0.1"
+	defaultPort        = "24224"
+	defaultBufferLimit = 8192
+	defaultRetryWait   = time.Second
+
+	addressKey     = "fluentd-address"
+	bufferLimitKey = "fluentd-buffer-limit"
+	retryWaitKey   = "fluentd-retry-wait"
+)
+
+var validLogOpt = map[string]bool{
+	addressKey:     true,
+	bufferLimitKey: true,
+	retryWaitKey:   true,
+	"tag":          true,
+	"labels":       true,
+	"env":          true,
+	"env-regex":    true,
+}
+
+func init() {
+	if err := logger.RegisterLogDriver(Name, Init); err != nil {
+		logrus.Fatal(err)
+	}
+	if err := logger.RegisterLogOptValidator(Name, ValidateLogOpt); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+// Fluentd sends the log data to fluentd with the fields of container.
+type Fluentd struct {
+	containerID   string
+	containerName string
+	extra         map[string]string
+
+	forwarder *forwarder
+}
+
+type options struct {
+	tag         string
+	proto       string
+	address     string
+	bufferLimit int
+	retryWait   time.Duration
+}
+
+// Init returns the Fluentd log driver.
+func Init(info logger.Info) (logger.LogDriver, error) {
+	return NewFluentd(info)
+}
+
+// NewFluentd returns new Fluentd based on the log config. It doesn't fail
+// if fluentd is down, and the logs are buffered until it's connected.
+func NewFluentd(info logger.Info) (*Fluentd, error) {
+	opts, err := parseOptions(info)
+	if err != nil {
+		return nil, err
+	}
+
+	extra, err := info.ExtraAttributes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fluentd{
+		containerID:   info.FullID(),
+		containerName: info.Name(),
+		extra:         extra,
+		forwarder:     newForwarder(opts.tag, opts),
+	}, nil
+}
+
+// Name return the log driver's name.
+func (f *Fluentd) Name() string {
+	return Name
+}
+
+// WriteLogMessage will write the LogMessage. It never blocks, and the
+// message is dropped if the buffer is full.
+func (f *Fluentd) WriteLogMessage(msg *logger.LogMessage) error {
+	record := make(map[string]string, len(f.extra)+4)
+	for k, v := range f.extra {
+		record[k] = v
+	}
+	record["container_id"] = f.containerID
+	record["container_name"] = f.containerName
+	record["source"] = msg.Source
+	record["log"] = strings.TrimSuffix(string(msg.Line), "\n")
+
+	ts := msg.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	f.forwarder.enqueue(&entry{time: ts, record: record})
+	return nil
+}
+
+// Close closes the Fluentd after the buffered logs are sent.
+func (f *Fluentd) Close() error {
+	f.forwarder.close()
+	return nil
+}
+
+// ValidateLogOpt validates the log options of fluentd log driver.
+func ValidateLogOpt(info logger.Info) error {
+	for key := range info.LogConfig {
+		if !validLogOpt[key] {
+			return fmt.Errorf("unknown log opt '%s' for fluentd log driver", key)
+		}
+	}
+
+	if _, err := parseOptions(info); err != nil {
+		return err
+	}
+	_, err := info.ExtraAttributes(nil)
+	return err
+}
+
+func parseOptions(info logger.Info) (*options, error) {
+	var (
+		opts = &options{
+			bufferLimit: defaultBufferLimit,
+			retryWait:   defaultRetryWait,
+		}
+		err error
+	)
+
+	opts.tag, err = loggerutils.GenerateLogTag(info, defaultTagTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.proto, opts.address, err = parseAddress(info.LogConfig[addressKey])
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := info.LogConfig[bufferLimitKey]; ok {
+		opts.bufferLimit, err = strconv.Atoi(v)
+		if err != nil || opts.bufferLimit <= 0 {
+			return nil, fmt.Errorf("%s must be a positive integer, but got %v", bufferLimitKey, v)
+		}
+	}
+
+	if v, ok := info.LogConfig[retryWaitKey]; ok {
+		opts.retryWait, err = time.ParseDuration(v)
+		if err != nil || opts.retryWait <= 0 {
+			return nil, fmt.Errorf("%s must be a positive duration, such as 1s, but got %v", retryWaitKey, v)
+		}
+	}
+	return opts, nil
+}
+
+// parseAddress parses the address of fluentd, which is in the form of
+// host:port, tcp://host:port or unix:///path/to/socket.
+func parseAddress(address string) (string, string, error) {
+	if address == "" {
+		return "tcp", net.JoinHostPort(defaultHost, defaultPort), nil
+	}
+
+	if !strings.Contains(address, "://") {
+		address = "tcp://" + address
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid %s %s: %v", addressKey, address, err)
+	}
+
+	switch u.Scheme {
+	case "tcp":
+		if u.Path != "" {
+			return "", "", fmt.Errorf("invalid %s %s: path is not allowed", addressKey, address)
+		}
+
+		host, port, err := net.SplitHostPort(u.Host)
+		if err != nil {
+			// the port is optional.
+			host, port = u.Host, defaultPort
+		}
+		if host == "" {
+			host = defaultHost
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return "", "", fmt.Errorf("invalid %s %s: invalid port %s", addressKey, address, port)
+		}
+		return "tcp", net.JoinHostPort(host, port), nil
+	case "unix":
+		if u.Path == "" {
+			return "", "", fmt.Errorf("invalid %s %s: path is required", addressKey, address)
+		}
+		return "unix", u.Path, nil
+	default:
+		return "", "", fmt.Errorf("invalid %s %s: unsupported protocol %s", addressKey, address, u.Scheme)
+	}
+}
@@ -0,0 +1,249 @@
+package fluentd
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/metrics"
+	"github.com/alibaba/pouch/daemon/logger"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/ugorji/go/codec"
+)
+
+var _ logger.LogDriver = &Fluentd{}
+
+// droppedMessages returns the number of messages dropped by fluentd driver.
+func droppedMessages(t *testing.T) float64 {
+	m := &dto.Metric{}
+	if err := metrics.LogDriverDroppedMessagesCounter.WithLabelValues(Name).Write(m); err != nil {
+		t.Fatalf("failed to read metric: %v", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+// readMessage decodes the message sent in message mode.
+func readMessage(t *testing.T, dec *codec.Decoder) (string, time.Time, map[string]interface{}) {
+	var msg []interface{}
+	if err := dec.Decode(&msg); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+	if len(msg) != 3 {
+		t.Fatalf("expect message [tag, time, record], but got %v", msg)
+	}
+
+	ext, ok := msg[1].(codec.RawExt)
+	if !ok || ext.Tag != 0 || len(ext.Data) != 8 {
+		t.Fatalf("expect time in EventTime, but got %#v", msg[1])
+	}
+	ts := time.Unix(int64(binary.BigEndian.Uint32(ext.Data[:4])), int64(binary.BigEndian.Uint32(ext.Data[4:])))
+
+	record, ok := msg[2].(map[interface{}]interface{})
+	if !ok {
+		t.Fatalf("expect record in map, but got %#v", msg[2])
+	}
+	fields := make(map[string]interface{})
+	for k, v := range record {
+		fields[k.(string)] = v
+	}
+	return msg[0].(string), ts, fields
+}
+
+func TestParseAddress(t *testing.T) {
+	for _, tc := range []struct {
+		address string
+		proto   string
+		addr    string
+		isErr   bool
+	}{
+		{address: "", proto: "tcp", addr: "127.0.0.1:24224"},
+		{address: "10.0.0.1", proto: "tcp", addr: "10.0.0.1:24224"},
+		{address: "10.0.0.1:24225", proto: "tcp", addr: "10.0.0.1:24225"},
+		{address: "tcp://fluentd.local:24225", proto: "tcp", addr: "fluentd.local:24225"},
+		{address: "unix:///var/run/fluentd.sock", proto: "unix", addr: "/var/run/fluentd.sock"},
+		{address: "tcp://10.0.0.1:24224/path", isErr: true},
+		{address: "10.0.0.1:port", isErr: true},
+		{address: "udp://10.0.0.1:24224", isErr: true},
+		{address: "unix://", isErr: true},
+	} {
+		proto, addr, err := parseAddress(tc.address)
+		if tc.isErr {
+			if err == nil {
+				t.Fatalf("expect error for address %s, but got nil", tc.address)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("failed to parse address %s: %v", tc.address, err)
+		}
+		if proto != tc.proto || addr != tc.addr {
+			t.Fatalf("expect %s://%s for address %s, but got %s://%s", tc.proto, tc.addr, tc.address, proto, addr)
+		}
+	}
+}
+
+func TestValidateLogOpt(t *testing.T) {
+	info := logger.Info{
+		LogConfig: map[string]string{
+			"fluentd-address":      "10.0.0.1:24224",
+			"fluentd-buffer-limit": "1024",
+			"fluentd-retry-wait":   "500ms",
+			"tag":                  "{{.Name}}",
+		},
+		ContainerID:   "container-20181015",
+		ContainerName: "foo",
+	}
+	if err := ValidateLogOpt(info); err != nil {
+		t.Fatalf("expect no error, but got %v", err)
+	}
+
+	for key, value := range map[string]string{
+		"fluentd-buffer-limit": "0",
+		"fluentd-retry-wait":   "1",
+		"fluentd-address":      "udp://10.0.0.1:24224",
+		"tag":                  "{{.Unknown}",
+		"max-size":             "10m",
+	} {
+		old, ok := info.LogConfig[key]
+		info.LogConfig[key] = value
+		if err := ValidateLogOpt(info); err == nil {
+			t.Fatalf("expect error for %s=%s, but got nil", key, value)
+		}
+
+		if ok {
+			info.LogConfig[key] = old
+		} else {
+			delete(info.LogConfig, key)
+		}
+	}
+}
+
+func TestWriteLogMessage(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer l.Close()
+
+	f, err := NewFluentd(logger.Info{
+		LogConfig: map[string]string{
+			"fluentd-address": l.Addr().String(),
+			"labels":          "app",
+		},
+		ContainerID:     "0123456789abcdef0123456789abcdef",
+		ContainerName:   "foo",
+		ContainerLabels: map[string]string{"app": "web"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create fluentd: %v", err)
+	}
+	defer f.Close()
+
+	now := time.Now()
+	if err := f.WriteLogMessage(&logger.LogMessage{
+		Source:    "stdout",
+		Line:      []byte("hello fluentd\n"),
+		Timestamp: now,
+	}); err != nil {
+		t.Fatalf("failed to write log message: %v", err)
+	}
+
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatalf("failed to accept: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	tag, ts, record := readMessage(t, codec.NewDecoder(conn, &codec.MsgpackHandle{RawToString: true}))
+	if expected := "0123456789ab"; tag != expected {
+		t.Fatalf("expect tag %s, but got %s", expected, tag)
+	}
+	if !ts.Equal(now) {
+		t.Fatalf("expect time %v, but got %v", now, ts)
+	}
+	for key, expected := range map[string]string{
+		"container_id":   "0123456789abcdef0123456789abcdef",
+		"container_name": "foo",
+		"source":         "stdout",
+		"log":            "hello fluentd",
+		"app":            "web",
+	} {
+		if got := record[key]; got != expected {
+			t.Fatalf("expect field %s=%s, but got %v", key, expected, got)
+		}
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	address := l.Addr().String()
+
+	// fluentd is down.
+	l.Close()
+
+	f, err := NewFluentd(logger.Info{
+		LogConfig: map[string]string{
+			"fluentd-address":    address,
+			"fluentd-retry-wait": "10ms",
+		},
+		ContainerID: "0123456789abcdef0123456789abcdef",
+	})
+	if err != nil {
+		t.Fatalf("expect fluentd to be created when fluentd is down, but got %v", err)
+	}
+	defer f.Close()
+
+	for _, line := range []string{"hello", "world"} {
+		f.WriteLogMessage(&logger.LogMessage{Source: "stdout", Line: []byte(line)})
+	}
+
+	// wait for the retry with backoff.
+	time.Sleep(50 * time.Millisecond)
+
+	l, err = net.Listen("tcp", address)
+	if err != nil {
+		t.Fatalf("failed to listen on %s again: %v", address, err)
+	}
+	defer l.Close()
+
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatalf("failed to accept: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	dec := codec.NewDecoder(conn, &codec.MsgpackHandle{RawToString: true})
+	for _, expected := range []string{"hello", "world"} {
+		if _, _, record := readMessage(t, dec); record["log"] != expected {
+			t.Fatalf("expect log %s, but got %v", expected, record["log"])
+		}
+	}
+}
+
+func TestDropWhenBufferIsFull(t *testing.T) {
+	// the forwarder isn't running so that the queue isn't consumed.
+	fwd := &forwarder{
+		queue:   make(chan *entry, 2),
+		closeCh: make(chan struct{}),
+	}
+
+	before := droppedMessages(t)
+	for i := 0; i < 5; i++ {
+		fwd.enqueue(&entry{time: time.Now()})
+	}
+
+	if got := len(fwd.queue); got != 2 {
+		t.Fatalf("expect 2 entries in queue, but got %d", got)
+	}
+	if got := droppedMessages(t) - before; got != 3 {
+		t.Fatalf("expect 3 dropped messages, but got %v", got)
+	}
+}
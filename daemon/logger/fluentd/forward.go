@@ -0,0 +1,188 @@
+package fluentd
+
+import (
+	"encoding/binary"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/alibaba/pouch/apis/metrics"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	dialTimeout  = 5 * time.Second
+	writeTimeout = 10 * time.Second
+
+	// maxRetryWait is the max interval between the reconnections, the
+	// interval is doubled after each failure, starting from retry-wait.
+	maxRetryWait = time.Minute
+)
+
+var msgpackHandle = &codec.MsgpackHandle{WriteExt: true}
+
+// entry is the record sent to fluentd.
+type entry struct {
+	time   time.Time
+	record map[string]string
+}
+
+// forwarder sends the entries to fluentd in forward protocol, see
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1.
+//
+// The entries are buffered in a bounded queue and sent in background, so
+// that the container isn't blocked when fluentd is slow or down. The entry
+// is dropped if the queue is full.
+type forwarder struct {
+	tag       string
+	proto     string
+	address   string
+	retryWait time.Duration
+
+	queue     chan *entry
+	closeCh   chan struct{}
+	doneCh    chan struct{}
+	closeOnce sync.Once
+
+	// conn is only used in the background goroutine.
+	conn net.Conn
+}
+
+// newForwarder returns the forwarder which has been running in background.
+func newForwarder(tag string, opts *options) *forwarder {
+	f := &forwarder{
+		tag:       tag,
+		proto:     opts.proto,
+		address:   opts.address,
+		retryWait: opts.retryWait,
+		queue:     make(chan *entry, opts.bufferLimit),
+		closeCh:   make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	go f.run()
+	return f
+}
+
+// enqueue puts the entry into queue without blocking.
+func (f *forwarder) enqueue(e *entry) {
+	select {
+	case <-f.closeCh:
+		f.drop(1)
+		return
+	default:
+	}
+
+	select {
+	case f.queue <- e:
+	default:
+		f.drop(1)
+	}
+}
+
+// close stops the forwarder after the buffered entries are sent.
+func (f *forwarder) close() {
+	f.closeOnce.Do(func() {
+		close(f.closeCh)
+	})
+	<-f.doneCh
+}
+
+func (f *forwarder) run() {
+	defer close(f.doneCh)
+
+	for {
+		select {
+		case e := <-f.queue:
+			f.send(e)
+		case <-f.closeCh:
+			f.flush()
+			if f.conn != nil {
+				f.conn.Close()
+			}
+			return
+		}
+	}
+}
+
+// send sends the entry, and reconnects with backoff until it's sent. The
+// entry is dropped if the forwarder is closed during the reconnection.
+func (f *forwarder) send(e *entry) {
+	wait := f.retryWait
+	for {
+		err := f.write(e)
+		if err == nil {
+			return
+		}
+		logrus.Warnf("failed to send log to fluentd %s://%s, retry in %v: %v", f.proto, f.address, wait, err)
+
+		select {
+		case <-time.After(wait):
+		case <-f.closeCh:
+			f.drop(1)
+			return
+		}
+
+		if wait *= 2; wait > maxRetryWait {
+			wait = maxRetryWait
+		}
+	}
+}
+
+// flush sends the buffered entries when the forwarder is closing. It gives
+// up at the first failure so that closing doesn't hang.
+func (f *forwarder) flush() {
+	for {
+		select {
+		case e := <-f.queue:
+			if err := f.write(e); err != nil {
+				logrus.Warnf("failed to flush log to fluentd %s://%s: %v", f.proto, f.address, err)
+				f.drop(len(f.queue) + 1)
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// write encodes the entry in message mode and writes it into connection.
+// The connection is closed if it fails, which will be reconnected in the
+// next write.
+func (f *forwarder) write(e *entry) error {
+	var buf []byte
+	if err := codec.NewEncoderBytes(&buf, msgpackHandle).Encode([]interface{}{f.tag, eventTime(e.time), e.record}); err != nil {
+		return err
+	}
+
+	if f.conn == nil {
+		conn, err := net.DialTimeout(f.proto, f.address, dialTimeout)
+		if err != nil {
+			return err
+		}
+		f.conn = conn
+	}
+
+	f.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := f.conn.Write(buf); err != nil {
+		f.conn.Close()
+		f.conn = nil
+		return err
+	}
+	return nil
+}
+
+// drop records the number of dropped entries.
+func (f *forwarder) drop(n int) {
+	metrics.LogDriverDroppedMessagesCounter.WithLabelValues(Name).Add(float64(n))
+}
+
+// eventTime encodes the time in EventTime extension of fluentd, which
+// keeps the nanoseconds.
+func eventTime(t time.Time) *codec.RawExt {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint32(data[:4], uint32(t.Unix()))
+	binary.BigEndian.PutUint32(data[4:], uint32(t.Nanosecond()))
+	return &codec.RawExt{Tag: 0, Data: data}
+}
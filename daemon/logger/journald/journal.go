@@ -0,0 +1,105 @@
+package journald
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// journalSocket is the socket of systemd-journald which accepts the entries
+// in native protocol, see https://systemd.io/JOURNAL_NATIVE_PROTOCOL.
+var journalSocket = "/run/systemd/journal/socket"
+
+// journal sends the entries to systemd-journald.
+type journal struct {
+	conn *net.UnixConn
+	addr *net.UnixAddr
+}
+
+// newJournal returns the journal which connects the socket of journald.
+func newJournal() (*journal, error) {
+	if _, err := os.Stat(journalSocket); err != nil {
+		return nil, err
+	}
+
+	// the datagram socket is bound to an auto-generated address.
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: "", Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &journal{
+		conn: conn,
+		addr: &net.UnixAddr{Name: journalSocket, Net: "unixgram"},
+	}, nil
+}
+
+// send sends the entry which is encoded by appendField.
+func (j *journal) send(entry []byte) error {
+	_, _, err := j.conn.WriteMsgUnix(entry, nil, j.addr)
+	if err == nil {
+		return nil
+	}
+	if !isSocketSpaceError(err) {
+		return err
+	}
+
+	// the large entry is sent by the file descriptor of temp file.
+	f, err := ioutil.TempFile("/dev/shm", "pouch-journal.")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := os.Remove(f.Name()); err != nil {
+		return err
+	}
+	if _, err := f.Write(entry); err != nil {
+		return err
+	}
+
+	_, _, err = j.conn.WriteMsgUnix([]byte{}, syscall.UnixRights(int(f.Fd())), j.addr)
+	return err
+}
+
+// close closes the connection.
+func (j *journal) close() error {
+	return j.conn.Close()
+}
+
+// appendField appends the field to entry. The value containing newline is
+// encoded in binary, which is prefixed with its length.
+func appendField(entry *bytes.Buffer, key, value string) {
+	if !strings.ContainsRune(value, '\n') {
+		entry.WriteString(key)
+		entry.WriteByte('=')
+		entry.WriteString(value)
+		entry.WriteByte('\n')
+		return
+	}
+
+	entry.WriteString(key)
+	entry.WriteByte('\n')
+	binary.Write(entry, binary.LittleEndian, uint64(len(value)))
+	entry.WriteString(value)
+	entry.WriteByte('\n')
+}
+
+// isSocketSpaceError returns true if the entry is too large to be sent in
+// a datagram.
+func isSocketSpaceError(err error) bool {
+	opErr, ok := err.(*net.OpError)
+	if !ok {
+		return false
+	}
+
+	sysErr, ok := opErr.Err.(*os.SyscallError)
+	if !ok {
+		return false
+	}
+	return sysErr.Err == syscall.EMSGSIZE || sysErr.Err == syscall.ENOBUFS
+}
@@ -0,0 +1,162 @@
+package journald
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/alibaba/pouch/daemon/logger"
+	"github.com/alibaba/pouch/daemon/logger/loggerutils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Name is the name of journald log driver.
+const Name = "journald"
+
+const (
+	// defaultTagTemplate is the default template of CONTAINER_TAG and
+	// SYSLOG_IDENTIFIER fields.
+	defaultTagTemplate = "{{.ID}}"
+
+	// priorityInfo and priorityErr are the PRIORITY of stdout and stderr,
+	// which are the same as syslog.
+	priorityInfo = "6"
+	priorityErr  = "3"
+)
+
+var validLogOpt = map[string]bool{
+	"tag":       true,
+	"labels":    true,
+	"env":       true,
+	"env-regex": true,
+}
+
+func init() {
+	if err := logger.RegisterLogDriver(Name, Init); err != nil {
+		logrus.Fatal(err)
+	}
+	if err := logger.RegisterLogOptValidator(Name, ValidateLogOpt); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+// Journald writes the log data into systemd-journald with the fields of
+// container, so that the logs can be queried by `journalctl CONTAINER_NAME=foo`.
+type Journald struct {
+	journal *journal
+
+	// fields are the encoded fields of container sent with every line.
+	fields []byte
+}
+
+// Init returns the Journald log driver.
+func Init(info logger.Info) (logger.LogDriver, error) {
+	return NewJournald(info)
+}
+
+// NewJournald returns new Journald based on the log config.
+func NewJournald(info logger.Info) (*Journald, error) {
+	fields, err := containerFields(info)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := newJournal()
+	if err != nil {
+		return nil, fmt.Errorf("journald is not enabled on this host: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	for _, kv := range fields {
+		appendField(buf, kv[0], kv[1])
+	}
+	return &Journald{
+		journal: j,
+		fields:  buf.Bytes(),
+	}, nil
+}
+
+// Name return the log driver's name.
+func (j *Journald) Name() string {
+	return Name
+}
+
+// WriteLogMessage will write the LogMessage.
+func (j *Journald) WriteLogMessage(msg *logger.LogMessage) error {
+	priority := priorityInfo
+	if msg.Source == "stderr" {
+		priority = priorityErr
+	}
+
+	entry := bytes.NewBuffer(make([]byte, 0, len(j.fields)+len(msg.Line)+32))
+	entry.Write(j.fields)
+	appendField(entry, "PRIORITY", priority)
+	// the line is a journal entry, so the trailing newline is useless.
+	appendField(entry, "MESSAGE", strings.TrimSuffix(string(msg.Line), "\n"))
+	return j.journal.send(entry.Bytes())
+}
+
+// Close closes the Journald.
+func (j *Journald) Close() error {
+	return j.journal.close()
+}
+
+// ValidateLogOpt validates the log options of journald log driver.
+func ValidateLogOpt(info logger.Info) error {
+	for key := range info.LogConfig {
+		if !validLogOpt[key] {
+			return fmt.Errorf("unknown log opt '%s' for journald log driver", key)
+		}
+	}
+	_, err := containerFields(info)
+	return err
+}
+
+// containerFields returns the fields of container in journal. The labels
+// and envs selected by log options are mapped to the fields whose names
+// are sanitized by sanitizeKey.
+func containerFields(info logger.Info) ([][2]string, error) {
+	tag, err := loggerutils.GenerateLogTag(info, defaultTagTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	extra, err := info.ExtraAttributes(sanitizeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := [][2]string{
+		{"CONTAINER_ID", info.ID()},
+		{"CONTAINER_ID_FULL", info.FullID()},
+		{"CONTAINER_NAME", info.Name()},
+		{"CONTAINER_TAG", tag},
+		{"SYSLOG_IDENTIFIER", tag},
+	}
+	for k, v := range extra {
+		if k == "" {
+			continue
+		}
+		fields = append(fields, [2]string{k, v})
+	}
+	return fields, nil
+}
+
+// sanitizeKey converts the name of label or env into the name of journal
+// field, which only consists of uppercase letters, digits and underscores,
+// and doesn't start with digit or underscore which is reserved by journald.
+func sanitizeKey(key string) string {
+	key = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return unicode.ToUpper(r)
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	return strings.TrimLeft(key, "_0123456789")
+}
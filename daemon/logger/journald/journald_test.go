@@ -0,0 +1,154 @@
+package journald
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/daemon/logger"
+)
+
+var _ logger.LogDriver = &Journald{}
+
+func TestSanitizeKey(t *testing.T) {
+	for key, expected := range map[string]string{
+		"com.example.app": "COM_EXAMPLE_APP",
+		"PATH":            "PATH",
+		"_private":        "PRIVATE",
+		"1st-label":       "ST_LABEL",
+		"__":              "",
+	} {
+		if got := sanitizeKey(key); got != expected {
+			t.Fatalf("expect sanitized key(%v) of %v, but got %v", expected, key, got)
+		}
+	}
+}
+
+func TestAppendField(t *testing.T) {
+	buf := new(bytes.Buffer)
+	appendField(buf, "MESSAGE", "hello")
+	if expected := "MESSAGE=hello\n"; buf.String() != expected {
+		t.Fatalf("expect field(%q), but got %q", expected, buf.String())
+	}
+
+	// the value with newline is encoded in binary.
+	buf.Reset()
+	appendField(buf, "MESSAGE", "hello\nworld")
+
+	expected := new(bytes.Buffer)
+	expected.WriteString("MESSAGE\n")
+	binary.Write(expected, binary.LittleEndian, uint64(len("hello\nworld")))
+	expected.WriteString("hello\nworld\n")
+	if !bytes.Equal(buf.Bytes(), expected.Bytes()) {
+		t.Fatalf("expect field(%q), but got %q", expected.String(), buf.String())
+	}
+}
+
+func TestValidateLogOpt(t *testing.T) {
+	info := logger.Info{
+		LogConfig: map[string]string{
+			"tag":    "{{.Name}}",
+			"labels": "app",
+		},
+		ContainerID:   "container-20181015",
+		ContainerName: "foo",
+	}
+	if err := ValidateLogOpt(info); err != nil {
+		t.Fatalf("expect no error, but got %v", err)
+	}
+
+	info.LogConfig["syslog-address"] = "udp://localhost:514"
+	if err := ValidateLogOpt(info); err == nil {
+		t.Fatalf("expect error for unknown log opt, but got nil")
+	}
+	delete(info.LogConfig, "syslog-address")
+
+	info.LogConfig["tag"] = "{{.Unknown}"
+	if err := ValidateLogOpt(info); err == nil {
+		t.Fatalf("expect error for invalid tag template, but got nil")
+	}
+}
+
+func TestWriteLogMessage(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "journald")
+	if err != nil {
+		t.Fatalf("failed to create tmp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// fake the socket of journald.
+	oldSocket := journalSocket
+	journalSocket = filepath.Join(tmpDir, "socket")
+	defer func() { journalSocket = oldSocket }()
+
+	server, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: journalSocket, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", journalSocket, err)
+	}
+	defer server.Close()
+
+	j, err := NewJournald(logger.Info{
+		LogConfig: map[string]string{
+			"labels": "com.example.app",
+		},
+		ContainerID:     "0123456789abcdef0123456789abcdef",
+		ContainerName:   "foo",
+		ContainerLabels: map[string]string{"com.example.app": "web"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create journald: %v", err)
+	}
+	defer j.Close()
+
+	if err := j.WriteLogMessage(&logger.LogMessage{
+		Source: "stderr",
+		Line:   []byte("hello journald\n"),
+	}); err != nil {
+		t.Fatalf("failed to write log message: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	n, err := server.Read(buf)
+	if err != nil {
+		t.Fatalf("failed to read entry: %v", err)
+	}
+
+	fields := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSuffix(string(buf[:n]), "\n"), "\n") {
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			t.Fatalf("expect field in KEY=VALUE, but got %q", line)
+		}
+		fields[kv[0]] = kv[1]
+	}
+
+	for key, expected := range map[string]string{
+		"CONTAINER_ID":      "0123456789ab",
+		"CONTAINER_ID_FULL": "0123456789abcdef0123456789abcdef",
+		"CONTAINER_NAME":    "foo",
+		"CONTAINER_TAG":     "0123456789ab",
+		"SYSLOG_IDENTIFIER": "0123456789ab",
+		"COM_EXAMPLE_APP":   "web",
+		"PRIORITY":          priorityErr,
+		"MESSAGE":           "hello journald",
+	} {
+		if got := fields[key]; got != expected {
+			t.Fatalf("expect field %s=%s, but got %s", key, expected, got)
+		}
+	}
+}
+
+func TestNewJournaldWithoutJournal(t *testing.T) {
+	oldSocket := journalSocket
+	journalSocket = "/path/to/not/exist/socket"
+	defer func() { journalSocket = oldSocket }()
+
+	if _, err := NewJournald(logger.Info{ContainerID: "container-20181015"}); err == nil {
+		t.Fatalf("expect error when journald is not enabled, but got nil")
+	}
+}
@@ -10,10 +10,26 @@ import (
 
 	"github.com/alibaba/pouch/daemon/logger"
 	"github.com/alibaba/pouch/pkg/bytefmt"
+
+	"github.com/sirupsen/logrus"
 )
 
+// Name is the name of json-file log driver.
+const Name = "json-file"
+
 var jsonFilePathName = "json.log"
 
+func init() {
+	if err := logger.RegisterLogDriver(Name, Init); err != nil {
+		logrus.Fatal(err)
+	}
+	if err := logger.RegisterLogOptValidator(Name, func(info logger.Info) error {
+		return ValidateLogOpt(info.LogConfig)
+	}); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
 //MarshalFunc is the function of marshal the logMessage
 type MarshalFunc func(message *logger.LogMessage) ([]byte, error)
 
@@ -105,7 +121,7 @@ func NewJSONLogFile(logPath string, perms os.FileMode, logConfig map[string]stri
 
 // Name return the log driver's name.
 func (lf *JSONLogFile) Name() string {
-	return "json-file"
+	return Name
 }
 
 // WriteLogMessage will write the LogMessage into the file.
@@ -128,10 +144,11 @@ func (lf *JSONLogFile) WriteLogMessage(msg *logger.LogMessage) error {
 	return err
 }
 
-// checkRotate rotates logs according to maxSize and maxFile parameters
-// TODO: after rotating logs, a notice should be made to the log reader
+// checkRotate rotates logs according to maxSize and maxFile parameters.
+// The log file is truncated if maxFile is 1, and the followers of log are
+// notified by the change of file, see followFile.
 func (lf *JSONLogFile) checkRotate() error {
-	if lf.maxSize == 0 || lf.maxFile < 2 || lf.currentSize < lf.maxSize {
+	if lf.maxSize == 0 || lf.currentSize < lf.maxSize {
 		// no need to rotate
 		return nil
 	}
@@ -206,5 +223,22 @@ func ValidateLogOpt(cfg map[string]string) error {
 			return fmt.Errorf("unknown log opt '%s' for json-file log driver", key)
 		}
 	}
+
+	if maxSize, ok := cfg["max-size"]; ok {
+		if _, err := bytefmt.ToBytes(maxSize); err != nil {
+			return fmt.Errorf("invalid max-size %s: %v", maxSize, err)
+		}
+	}
+
+	if maxFile, ok := cfg["max-file"]; ok {
+		n, err := strconv.Atoi(maxFile)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid max-file %s: should be positive number", maxFile)
+		}
+		// the logs are never rotated without max-size.
+		if _, ok := cfg["max-size"]; !ok && n > 1 {
+			return fmt.Errorf("max-file %s is only valid with max-size", maxFile)
+		}
+	}
 	return nil
 }
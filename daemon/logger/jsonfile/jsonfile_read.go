@@ -1,7 +1,9 @@
 package jsonfile
 
 import (
+	"io"
 	"os"
+	"strconv"
 
 	"github.com/alibaba/pouch/daemon/logger"
 )
@@ -26,33 +28,101 @@ func (lf *JSONLogFile) ReadLogMessages(cfg *logger.ReadConfig) *logger.LogWatche
 
 func (lf *JSONLogFile) read(cfg *logger.ReadConfig, watcher *logger.LogWatcher) {
 	lf.mu.Lock()
-	f, err := os.Open(lf.f.Name())
+	files, err := openLogFiles(lf.f.Name())
 	lf.mu.Unlock()
 
 	if err != nil {
 		watcher.Err <- err
 		return
 	}
-	defer f.Close()
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
 
-	// find the offset if the config contains the valid tail lines
+	// the rotated files are read before the current one, which is the last.
+	toRead := files
 	if cfg.Tail > 0 {
-		offset, err := seekOffsetByTailLines(f, cfg.Tail)
+		toRead, err = seekFilesByTailLines(files, cfg.Tail)
 		if err != nil {
 			watcher.Err <- err
 			return
 		}
+	}
 
-		if _, err := f.Seek(offset, os.SEEK_SET); err != nil {
-			watcher.Err <- err
-			return
-		}
+	for _, f := range toRead {
+		tailFile(f, cfg, newUnmarshal, watcher)
 	}
-	tailFile(f, cfg, newUnmarshal, watcher)
 
 	if !cfg.Follow {
 		return
 	}
 
-	followFile(f, cfg, newUnmarshal, watcher)
+	followFile(files[len(files)-1], cfg, newUnmarshal, watcher)
+}
+
+// openLogFiles opens the log file and the rotated ones, such as json.log.1,
+// json.log.2. The files are sorted from the oldest to the newest, so the
+// last one is always the current log file.
+func openLogFiles(logPath string) ([]*os.File, error) {
+	names := []string{logPath}
+	for i := 1; ; i++ {
+		name := logPath + "." + strconv.Itoa(i)
+		if _, err := os.Stat(name); err != nil {
+			if os.IsNotExist(err) {
+				break
+			}
+			return nil, err
+		}
+		names = append([]string{name}, names...)
+	}
+
+	files := make([]*os.File, 0, len(names))
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			// the oldest one may be removed by rotation.
+			if os.IsNotExist(err) && name != logPath {
+				continue
+			}
+
+			for _, f := range files {
+				f.Close()
+			}
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// seekFilesByTailLines seeks the files to the offsets of the last n lines
+// in all of them, and returns the files which contain the lines.
+func seekFilesByTailLines(files []*os.File, n int) ([]*os.File, error) {
+	for i := len(files) - 1; i >= 0; i-- {
+		offset, err := seekOffsetByTailLines(files[i], n)
+		if err != nil {
+			return nil, err
+		}
+
+		if offset == 0 {
+			// the whole file is in tail, and the rest of lines are in
+			// the older files.
+			lines, err := countLines(files[i])
+			if err != nil {
+				return nil, err
+			}
+			n -= lines
+		}
+
+		if _, err := files[i].Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		if offset > 0 || n <= 0 {
+			return files[i:], nil
+		}
+	}
+	return files, nil
 }
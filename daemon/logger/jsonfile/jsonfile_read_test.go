@@ -1,8 +1,10 @@
 package jsonfile
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -75,3 +77,150 @@ func TestReadLogMessagesForEmptyFileWithoutFollow(t *testing.T) {
 	default:
 	}
 }
+
+// newRotatedLogFile returns the log file which is rotated every two lines.
+func newRotatedLogFile(t *testing.T, maxFile string) (*JSONLogFile, func()) {
+	dir, err := ioutil.TempDir("", "rotated-file")
+	if err != nil {
+		t.Fatalf("unexpected error during create tempdir: %v", err)
+	}
+
+	logConfig := map[string]string{"max-size": "150B", "max-file": maxFile}
+	if err := ValidateLogOpt(logConfig); err != nil {
+		t.Fatalf("unexpected error during validate log opt: %v", err)
+	}
+
+	jf, err := NewJSONLogFile(filepath.Join(dir, jsonFilePathName), 0644, logConfig, func(msg *logger.LogMessage) ([]byte, error) {
+		return Marshal(msg, nil)
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("unexpected error during create JSONLogFile: %v", err)
+	}
+	return jf, func() {
+		jf.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// writeLines writes the lines #from to #to, the timestamp of line #n is n
+// seconds after start.
+func writeLines(t *testing.T, jf *JSONLogFile, start time.Time, from, to int) {
+	for n := from; n <= to; n++ {
+		msg := &logger.LogMessage{
+			Source:    "stdout",
+			Line:      []byte(fmt.Sprintf("#%d line\n", n)),
+			Timestamp: start.Add(time.Duration(n) * time.Second),
+		}
+		if err := jf.WriteLogMessage(msg); err != nil {
+			t.Fatalf("unexpected error during write log message: %v", err)
+		}
+	}
+}
+
+// readLines reads the lines until the watcher is closed or timeout.
+func readLines(watcher *logger.LogWatcher, count int) []string {
+	var lines []string
+	for len(lines) < count {
+		select {
+		case msg, ok := <-watcher.Msgs:
+			if !ok {
+				return lines
+			}
+			lines = append(lines, string(msg.Line))
+		case <-time.After(time.Second):
+			return lines
+		}
+	}
+	return lines
+}
+
+func expectedLines(from, to int) []string {
+	var lines []string
+	for n := from; n <= to; n++ {
+		lines = append(lines, fmt.Sprintf("#%d line\n", n))
+	}
+	return lines
+}
+
+func assertLines(t *testing.T, expected, got []string) {
+	if len(expected) != len(got) {
+		t.Fatalf("expected lines %q, but got %q", expected, got)
+	}
+	for i := range expected {
+		if expected[i] != got[i] {
+			t.Fatalf("expected lines %q, but got %q", expected, got)
+		}
+	}
+}
+
+func TestReadLogMessagesWithRotatedFiles(t *testing.T) {
+	jf, cleanup := newRotatedLogFile(t, "3")
+	defer cleanup()
+
+	// the oldest lines are removed by rotation, and 5 lines are kept in
+	// json.log.2, json.log.1 and json.log.
+	start := time.Now()
+	writeLines(t, jf, start, 1, 9)
+	for _, suffix := range []string{"", ".1", ".2"} {
+		if _, err := os.Stat(jf.f.Name() + suffix); err != nil {
+			t.Fatalf("expected log file %s, but got error: %v", jf.f.Name()+suffix, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		cfg      *logger.ReadConfig
+		expected []string
+	}{
+		{name: "all", cfg: &logger.ReadConfig{}, expected: expectedLines(5, 9)},
+		{name: "tail in current file", cfg: &logger.ReadConfig{Tail: 1}, expected: expectedLines(9, 9)},
+		{name: "tail across files", cfg: &logger.ReadConfig{Tail: 4}, expected: expectedLines(6, 9)},
+		{name: "tail more than lines", cfg: &logger.ReadConfig{Tail: 100}, expected: expectedLines(5, 9)},
+		{name: "since", cfg: &logger.ReadConfig{Since: start.Add(6 * time.Second)}, expected: expectedLines(6, 9)},
+	} {
+		watcher := jf.ReadLogMessages(tc.cfg)
+		assertLines(t, tc.expected, readLines(watcher, 100))
+		watcher.Close()
+	}
+}
+
+func TestReadLogMessagesFollowRotation(t *testing.T) {
+	for _, maxFile := range []string{"1", "2"} {
+		jf, cleanup := newRotatedLogFile(t, maxFile)
+
+		start := time.Now()
+		writeLines(t, jf, start, 1, 1)
+
+		watcher := jf.ReadLogMessages(&logger.ReadConfig{Follow: true})
+		assertLines(t, expectedLines(1, 1), readLines(watcher, 1))
+
+		// the lines written across rotations are followed in order.
+		for n := 2; n <= 7; n++ {
+			writeLines(t, jf, start, n, n)
+			assertLines(t, expectedLines(n, n), readLines(watcher, 1))
+		}
+
+		watcher.Close()
+		cleanup()
+	}
+}
+
+func TestValidateLogOpt(t *testing.T) {
+	for _, tc := range []struct {
+		opts  map[string]string
+		valid bool
+	}{
+		{opts: map[string]string{"max-size": "10m", "max-file": "3"}, valid: true},
+		{opts: map[string]string{"max-size": "10m"}, valid: true},
+		{opts: map[string]string{"max-file": "1"}, valid: true},
+		{opts: map[string]string{"max-file": "3"}, valid: false},
+		{opts: map[string]string{"max-size": "10x"}, valid: false},
+		{opts: map[string]string{"max-size": "10m", "max-file": "0"}, valid: false},
+		{opts: map[string]string{"unknown": "1"}, valid: false},
+	} {
+		if err := ValidateLogOpt(tc.opts); (err == nil) != tc.valid {
+			t.Fatalf("expected valid %v for %v, but got error %v", tc.valid, tc.opts, err)
+		}
+	}
+}
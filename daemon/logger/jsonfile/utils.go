@@ -19,13 +19,27 @@ type newUnmarshalFunc func(r io.Reader) func() (*logger.LogMessage, error)
 var watchFileTimeout = 200 * time.Millisecond
 
 // followFile will act like `tail -f`.
-func followFile(f *os.File, cfg *logger.ReadConfig, unmarshaler newUnmarshalFunc, watcher *logger.LogWatcher) {
+//
+// The file is followed across rotation, the rest of rotated file is read
+// before the new file.
+func followFile(origin *os.File, cfg *logger.ReadConfig, unmarshaler newUnmarshalFunc, watcher *logger.LogWatcher) {
+	f := origin
+	defer func() {
+		if f != origin {
+			f.Close()
+		}
+	}()
+
 	fileWatcher, err := watchFileChange(f.Name())
 	if err != nil {
 		watcher.Err <- err
 		return
 	}
 
+	// rotated is set if the log file has been renamed by rotation, and
+	// the new file is followed after the rest of old file is read.
+	var rotated bool
+
 	defer func() {
 		fileWatcher.Remove(f.Name())
 		fileWatcher.Close()
@@ -58,6 +72,23 @@ func followFile(f *os.File, cfg *logger.ReadConfig, unmarshaler newUnmarshalFunc
 			return err
 		}
 
+		if rotated {
+			rotated = false
+
+			nf, err := openRotatedFile(ctx, f.Name(), fileWatcher)
+			if err != nil {
+				logrus.Debugf("failed to follow the rotated file %v: %v", f.Name(), err)
+				return errDone
+			}
+			// the file passed by caller is closed by caller.
+			if f != origin {
+				f.Close()
+			}
+			f = nf
+			decodeOneLine = unmarshaler(f)
+			return nil
+		}
+
 		for {
 			watchTimeout.Reset(watchFileTimeout)
 
@@ -68,6 +99,17 @@ func followFile(f *os.File, cfg *logger.ReadConfig, unmarshaler newUnmarshalFunc
 			case e := <-fileWatcher.Events:
 				switch e.Op {
 				case fsnotify.Write:
+					// the file is truncated by rotation if max-file
+					// is 1, read it from the beginning.
+					if isTruncated(f) {
+						if _, err := f.Seek(0, io.SeekStart); err != nil {
+							return err
+						}
+					}
+					decodeOneLine = unmarshaler(f)
+					return nil
+				case fsnotify.Rename:
+					rotated = true
 					decodeOneLine = unmarshaler(f)
 					return nil
 				case fsnotify.Remove:
@@ -143,6 +185,55 @@ func watchFileChange(filePath string) (*fsnotify.Watcher, error) {
 	return fileWatcher, nil
 }
 
+// openRotatedFile opens the new log file created by rotation, and watches it
+// instead of the rotated one. It waits for the writer to create the file.
+func openRotatedFile(ctx context.Context, name string, fileWatcher *fsnotify.Watcher) (*os.File, error) {
+	// the watch of rotated file isn't removed by rename.
+	fileWatcher.Remove(name)
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	timeout := time.NewTimer(time.Second)
+	defer timeout.Stop()
+
+	for {
+		f, err := os.Open(name)
+		if err == nil {
+			if err := fileWatcher.Add(name); err != nil {
+				f.Close()
+				return nil, err
+			}
+			return f, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout.C:
+			return nil, err
+		case <-ticker.C:
+		}
+	}
+}
+
+// isTruncated returns true if the size of file is less than the offset
+// which has been read.
+func isTruncated(f *os.File) bool {
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Size() < offset
+}
+
 // tailFile will read the log message until the io.EOF or limited by config.
 func tailFile(r io.Reader, cfg *logger.ReadConfig, unmarshaler newUnmarshalFunc, watcher *logger.LogWatcher) {
 	decodeOneLine := unmarshaler(r)
@@ -177,6 +268,28 @@ const (
 	endOfLine = '\n'
 )
 
+// countLines returns the number of lines from the beginning of file.
+func countLines(rs io.ReadSeeker) (int, error) {
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	var (
+		cnt int
+		b   = make([]byte, 32*blockSize)
+	)
+	for {
+		n, err := rs.Read(b)
+		cnt += bytes.Count(b[:n], []byte{endOfLine})
+		if err == io.EOF {
+			return cnt, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
 // seekOffsetByTailLines is used to seek the offset in file by the number lines.
 func seekOffsetByTailLines(rs io.ReadSeeker, n int) (int64, error) {
 	if n <= 0 {
@@ -18,7 +18,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-var _ logger.LogDriver = &JSONLogFile{}
+var _ logger.LogReader = &JSONLogFile{}
 
 func generateFileBytes(lines int) []byte {
 	buf := bytes.NewBuffer(nil)
@@ -10,8 +10,21 @@ import (
 	"github.com/alibaba/pouch/daemon/logger/loggerutils"
 
 	"github.com/RackSec/srslog"
+	"github.com/sirupsen/logrus"
 )
 
+// Name is the name of syslog log driver.
+const Name = "syslog"
+
+func init() {
+	if err := logger.RegisterLogDriver(Name, Init); err != nil {
+		logrus.Fatal(err)
+	}
+	if err := logger.RegisterLogOptValidator(Name, ValidateSyslogOption); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
 // Syslog writes the log data into syslog.
 type Syslog struct {
 	mu sync.RWMutex
@@ -58,7 +71,7 @@ func NewSyslog(info logger.Info) (*Syslog, error) {
 
 // Name return the log driver's name.
 func (s *Syslog) Name() string {
-	return "syslog"
+	return Name
 }
 
 // WriteLogMessage will write the LogMessage.
@@ -20,3 +20,12 @@ type LogDriver interface {
 
 	Close() error
 }
+
+// LogReader is the log driver which can read the logs back for `pouch logs`,
+// such as jsonfile. The log drivers which send logs to remote, such as
+// syslog, don't support reading.
+type LogReader interface {
+	LogDriver
+
+	ReadLogMessages(cfg *ReadConfig) *LogWatcher
+}
@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"context"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/metrics"
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+	util_metrics "github.com/alibaba/pouch/pkg/utils/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// containerStates are the states of containers in metrics, which are always
+// reported even if there is no container in the state.
+var containerStates = []types.Status{
+	types.StatusCreated,
+	types.StatusRunning,
+	types.StatusPaused,
+	types.StatusRestarting,
+	types.StatusRemoving,
+	types.StatusStopped,
+	types.StatusExited,
+	types.StatusDead,
+}
+
+// registerMetrics registers the collector of daemon objects, so that they
+// are counted when the metrics are scraped.
+func (d *Daemon) registerMetrics() error {
+	return util_metrics.GetPrometheusRegistry().Register(&collector{d: d})
+}
+
+// collector collects the metrics of the objects of daemon when the metrics
+// are scraped, such as the number of containers by state.
+type collector struct {
+	d *Daemon
+}
+
+// Describe implements prometheus.Collector.
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metrics.ContainersDesc
+	ch <- metrics.ImagesDesc
+	ch <- metrics.EventsQueueDepthDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+
+	if containers, err := c.d.containerMgr.List(ctx, &mgr.ContainerListOption{All: true}); err != nil {
+		logrus.Warnf("failed to list containers for metrics: %v", err)
+	} else {
+		counts := make(map[types.Status]int, len(containerStates))
+		for _, container := range containers {
+			counts[container.State.Status]++
+		}
+		for _, state := range containerStates {
+			ch <- prometheus.MustNewConstMetric(metrics.ContainersDesc, prometheus.GaugeValue, float64(counts[state]), string(state))
+		}
+	}
+
+	if images, err := c.d.imageMgr.ListImages(ctx, filters.NewArgs()); err != nil {
+		logrus.Warnf("failed to list images for metrics: %v", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(metrics.ImagesDesc, prometheus.GaugeValue, float64(len(images)))
+	}
+
+	ch <- prometheus.MustNewConstMetric(metrics.EventsQueueDepthDesc, prometheus.GaugeValue, float64(c.d.eventsService.QueueDepth()))
+}
@@ -5,13 +5,18 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/ctrd"
@@ -58,6 +63,10 @@ type ContainerMgr interface {
 	// Restore recover those alive containers.
 	Restore(ctx context.Context) error
 
+	// Shutdown stops all the running containers if live restore is disabled,
+	// and flushes the logs of containers when pouchd is stopped.
+	Shutdown(ctx context.Context) error
+
 	// Create a new container.
 	Create(ctx context.Context, name string, config *types.ContainerCreateConfig) (*types.ContainerCreateResp, error)
 
@@ -76,6 +85,9 @@ type ContainerMgr interface {
 	// Restart restart a running container.
 	Restart(ctx context.Context, name string, timeout int64) error
 
+	// Kill sends a signal to a running container.
+	Kill(ctx context.Context, name string, signal syscall.Signal) error
+
 	// Pause a container.
 	Pause(ctx context.Context, name string) error
 
@@ -112,9 +124,34 @@ type ContainerMgr interface {
 	// Remove removes a container, it may be running or stopped and so on.
 	Remove(ctx context.Context, name string, option *types.ContainerRemoveOptions) error
 
+	// Prune removes the containers which are not running or paused.
+	Prune(ctx context.Context, filter filters.Args) (*types.ContainerPruneResp, error)
+
+	// DiskUsage returns the disk space used by the writable layer of containers.
+	DiskUsage(ctx context.Context) ([]*types.ContainerDiskUsage, error)
+
 	// Wait stops processing until the given container is stopped.
 	Wait(ctx context.Context, name string) (types.ContainerWaitOKBody, error)
 
+	// WaitNextExit starts waiting for the next exit of container, the exit
+	// status is sent to the returned channel.
+	WaitNextExit(ctx context.Context, name string) (<-chan types.ContainerWaitOKBody, error)
+
+	// StatPath returns the information of the path in container's filesystem.
+	StatPath(ctx context.Context, name, path string) (*types.ContainerPathStat, error)
+
+	// ArchivePath returns the tar archive of the path in container's filesystem.
+	ArchivePath(ctx context.Context, name, path string) (io.ReadCloser, *types.ContainerPathStat, error)
+
+	// ExtractToDir extracts the tar archive into the directory in container's filesystem.
+	ExtractToDir(ctx context.Context, name, path string, noOverwriteDirNonDir bool, content io.Reader) error
+
+	// Changes returns the changes of container's filesystem relative to its image.
+	Changes(ctx context.Context, name string) ([]*types.ContainerChangeResponseItem, error)
+
+	// Export returns the tar archive of container's filesystem.
+	Export(ctx context.Context, name string) (io.ReadCloser, error)
+
 	// 2. The following five functions is related to container exec.
 
 	// CreateExec creates exec process's environment.
@@ -248,6 +285,14 @@ func (mgr *ContainerManager) Load(ctx context.Context) error {
 	return mgr.Store.ForEach(fn)
 }
 
+// lostTaskExitCode is the exit code of container whose task is lost when
+// pouchd is down, for example, the shim is killed, since the real one is
+// unknown.
+const lostTaskExitCode = 255
+
+// errLostTask is the error of container whose task is lost when pouchd is down.
+var errLostTask = errors.New("task is lost when pouchd is down, the exit code is unknown")
+
 // Restore tries to recover those alive containers
 func (mgr *ContainerManager) Restore(ctx context.Context) error {
 	// get all running containers
@@ -261,9 +306,29 @@ func (mgr *ContainerManager) Restore(ctx context.Context) error {
 		return errors.Wrap(err, "failed to get container list")
 	}
 
+	// release the addresses of containers removed or stopped when pouchd
+	// is down, before any container is restarted by restart policy.
+	mgr.releaseStaleEndpoints(containers)
+
 	for _, c := range containers {
 		id := c.Key()
 
+		// resume the removal which is interrupted by pouchd exit.
+		if c.State.Dead {
+			logrus.Warnf("container %s is marked for removal, resume removing it", id)
+			if err := mgr.Remove(ctx, id, &types.ContainerRemoveOptions{Force: true}); err != nil {
+				logrus.Errorf("failed to remove container %s marked for removal: %v", id, err)
+			}
+			continue
+		}
+
+		// the container which exits when pouchd is down is removed
+		// automatically.
+		if c.HostConfig.AutoRemove && !c.IsRunningOrPaused() && !c.IsCreated() {
+			mgr.autoRemove(c)
+			continue
+		}
+
 		// NOTE: when pouch is restarting, we need to initialize
 		// container IO for the existing containers just in case that
 		// user tries to restart the stopped containers.
@@ -278,16 +343,29 @@ func (mgr *ContainerManager) Restore(ctx context.Context) error {
 			return err
 		}
 
-		// recover the running or paused container.
+		// recover the running or paused container, and restart the others
+		// by restart policy.
 		if !c.IsRunningOrPaused() {
+			mgr.restoreRestartPolicy(c)
 			continue
 		}
 
 		logrus.Debugf("Start recover container %s", id)
 
-		// Start recover the container
+		// Start recover the container, the task which exited when pouchd is
+		// down is handled by the exit hooks once it is recovered.
 		err = mgr.Client.RecoverContainer(ctx, id, cntrio)
 		if err == nil {
+			// the references of volume mounts are lost with the exit of
+			// pouchd, so they're taken by the alive containers again, or
+			// the volume will be unmounted when another container stops.
+			mgr.restoreVolumeMounts(ctx, c)
+
+			c.Lock()
+			if c.IsRunningOrPaused() {
+				mgr.initHealthMonitor(c, true)
+			}
+			c.Unlock()
 			continue
 		}
 
@@ -304,9 +382,11 @@ func (mgr *ContainerManager) Restore(ctx context.Context) error {
 
 		// Note(ziren) if containerd post not found error, that is mean
 		// container or task is not found. So we should set the container's
-		// status to exited and release the container's resources.
+		// status to exited and release the container's resources. The exit
+		// code is lost with the task, so it is recorded as unknown and the
+		// container is restarted by restart policy.
 		logrus.Warnf("recover container %s, got a notfound error, start clean the container's resources", id)
-		if err := mgr.exitedAndRelease(id, nil, nil); err != nil {
+		if err := mgr.exitedAndRelease(id, ctrd.NewMessage(lostTaskExitCode, time.Now(), errLostTask), nil); err != nil {
 			logrus.Errorf("failed to execute exited and release for container %s: %v", id, err)
 		}
 	}
@@ -365,6 +445,20 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "NetworkingConfig cannot be empty")
 	}
 
+	for name, ep := range config.NetworkingConfig.EndpointsConfig {
+		if ep != nil && len(ep.Aliases) > 0 && !IsUserDefined(name) {
+			return nil, errors.Wrapf(errtypes.ErrInvalidParam, "network-scoped alias is supported only for containers in user defined networks, but network is %s", name)
+		}
+		if err := mgr.validateStaticAddress(ctx, name, ep); err != nil {
+			return nil, err
+		}
+	}
+	if config.MacAddress != "" {
+		if _, err := net.ParseMAC(config.MacAddress); err != nil {
+			return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid mac address %s: %v", config.MacAddress, err)
+		}
+	}
+
 	// validate disk quota
 	if err := mgr.validateDiskQuota(config); err != nil {
 		return nil, errors.Wrapf(err, "invalid disk quota config")
@@ -385,8 +479,13 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 
 	if name == "" {
 		name = mgr.generateName(id)
-	} else if mgr.NameToID.Get(name).Exist() {
-		return nil, errors.Wrapf(errtypes.ErrAlreadyExisted, "container name %s", name)
+	} else {
+		if err = validateContainerName(name); err != nil {
+			return nil, err
+		}
+		if err = mgr.checkNameConflict(name); err != nil {
+			return nil, err
+		}
 	}
 
 	// set hostname.
@@ -401,7 +500,8 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 	}
 
 	if _, exist := mgr.Config.Runtimes[config.HostConfig.Runtime]; !exist {
-		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "unknown runtime %s", config.HostConfig.Runtime)
+		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "unknown runtime %s, registered runtimes are %v",
+			config.HostConfig.Runtime, mgr.runtimeNames())
 	}
 
 	snapID := id
@@ -428,6 +528,21 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 	// set default log driver and validate for logger driver
 	config.HostConfig.LogConfig = mgr.getDefaultLogConfigIfMissing(config.HostConfig.LogConfig)
 
+	// merge the default ulimits of daemon, the ulimits of container win.
+	config.HostConfig.Ulimits = opts.MergeUlimits(mgr.Config.DefaultUlimits, config.HostConfig.Ulimits)
+
+	// run init in container by the default of daemon if not specified.
+	if config.HostConfig.Init == nil {
+		init := mgr.Config.DefaultInit
+		config.HostConfig.Init = &init
+	}
+
+	// set the default size of /dev/shm if not specified.
+	if config.HostConfig.ShmSize == nil || *config.HostConfig.ShmSize == 0 {
+		shmSize := DefaultShmSize
+		config.HostConfig.ShmSize = &shmSize
+	}
+
 	// set ReadonlyPaths and MaskedPaths to nil if privileged was set.
 	if config.HostConfig.Privileged {
 		config.HostConfig.ReadonlyPaths = nil
@@ -449,6 +564,11 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 		SnapshotID: snapID,
 	}
 
+	// validate the namespace modes, which may join the namespaces of other containers.
+	if err := mgr.validateNamespaceModes(ctx, container); err != nil {
+		return nil, err
+	}
+
 	if _, err := mgr.initContainerIO(container); err != nil {
 		logrus.Errorf("failed to initialise IO: %v", err)
 		return nil, err
@@ -461,6 +581,14 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 		return nil, err
 	}
 
+	// merge image's healthcheck into container, which is not part of the
+	// oci image config.
+	healthcheck, err := mgr.ImageMgr.GetImageHealthcheck(ctx, config.Image)
+	if err != nil {
+		return nil, err
+	}
+	container.Config.Healthcheck = mergeHealthcheck(container.Config.Healthcheck, healthcheck)
+
 	// set container basefs, basefs is not created in pouchd, it will created
 	// after create options passed to containerd.
 	mgr.setBaseFS(ctx, container)
@@ -486,7 +614,7 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 	container.NetworkSettings.Ports = config.HostConfig.PortBindings
 
 	if err := parseSecurityOpts(container, config.HostConfig.SecurityOpt); err != nil {
-		return nil, err
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
 	}
 
 	// Get snapshot UpperDir
@@ -499,6 +627,11 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 	}
 	container.SetSnapshotterMeta(mounts)
 
+	// validate the user is defined in the image if given by name
+	if err := validateUser(container); err != nil {
+		return nil, err
+	}
+
 	// amendContainerSettings modify container config settings to wanted
 	amendContainerSettings(&config.ContainerConfig, config.HostConfig)
 
@@ -543,10 +676,18 @@ func (mgr *ContainerManager) getDefaultLogConfigIfMissing(logConfig *types.LogCo
 		logConfig.LogDriver = mgr.Config.DefaultLogConfig.LogDriver
 	}
 
-	if len(logConfig.LogOpts) == 0 {
-		logConfig.LogOpts = defaultLogOpts
+	// the default log options are only valid for the default log driver.
+	if logConfig.LogDriver != mgr.Config.DefaultLogConfig.LogDriver {
+		return logConfig
 	}
 
+	// the log options of container take precedence over the default ones,
+	// so that the options such as fluentd-address aren't repeated.
+	for k, v := range logConfig.LogOpts {
+		defaultLogOpts[k] = v
+	}
+	logConfig.LogOpts = defaultLogOpts
+
 	return logConfig
 }
 
@@ -593,9 +734,21 @@ func (mgr *ContainerManager) Start(ctx context.Context, id string, options *type
 	// through containerPlugin in Create function
 	ctx = ctrd.WithSnapshotter(ctx, c.Config.Snapshotter)
 
+	// the supervision of restart policy starts over when the container is
+	// started by API.
+	c.Lock()
+	c.cancelRestart()
+	if !c.IsRunningOrPaused() {
+		c.RestartCount = 0
+	}
+	c.Unlock()
+
 	err = mgr.start(ctx, c, options)
 	if err == nil {
 		mgr.LogContainerEvent(ctx, c, "start")
+	} else if c.HostConfig.AutoRemove && !c.IsRunningOrPaused() && !errtypes.IsNotModified(err) {
+		// the container failing to start is also removed automatically.
+		mgr.autoRemove(c)
 	}
 
 	return err
@@ -624,6 +777,7 @@ func (mgr *ContainerManager) start(ctx context.Context, c *Container, options *t
 	}
 
 	attachedVolumes := map[string]struct{}{}
+	mountedVolumes := map[string]struct{}{}
 	defer func() {
 		if err == nil {
 			return
@@ -635,7 +789,12 @@ func (mgr *ContainerManager) start(ctx context.Context, c *Container, options *t
 			logrus.Errorf("failed to release container(%s) resources: %v", c.ID, err)
 		}
 
-		// detach the volumes
+		// unmount and detach the volumes
+		for name := range mountedVolumes {
+			if err := mgr.VolumeMgr.Unmount(ctx, name, c.ID); err != nil {
+				logrus.Errorf("failed to unmount volume(%s) when start container(%s) rollback: %v", name, c.ID, err)
+			}
+		}
 		for name := range attachedVolumes {
 			if _, err = mgr.VolumeMgr.Detach(ctx, name, map[string]string{volumetypes.OptionRef: c.ID}); err != nil {
 				logrus.Errorf("failed to detach volume(%s) when start container(%s) rollback: %v", name, c.ID, err)
@@ -651,6 +810,17 @@ func (mgr *ContainerManager) start(ctx context.Context, c *Container, options *t
 			return errors.Wrapf(err, "failed to attach volume(%s)", mp.Name)
 		}
 		attachedVolumes[mp.Name] = struct{}{}
+
+		// the volume of plugin is mounted by the plugin, and the mount
+		// path may change every time it is mounted.
+		var source string
+		if source, err = mgr.VolumeMgr.Mount(ctx, mp.Name, c.ID); err != nil {
+			return errors.Wrapf(err, "failed to mount volume(%s)", mp.Name)
+		}
+		mountedVolumes[mp.Name] = struct{}{}
+		if source != "" {
+			mp.Source = source
+		}
 	}
 
 	if err = mgr.prepareContainerNetwork(ctx, c); err != nil {
@@ -774,6 +944,7 @@ func (mgr *ContainerManager) createContainerdContainer(ctx context.Context, c *C
 		prioArr:    prioArr,
 		argsArr:    argsArr,
 		useSystemd: mgr.Config.UseSystemd(),
+		initPath:   mgr.Config.InitPath,
 	}
 
 	if err = createSpec(ctx, c, sw); err != nil {
@@ -808,10 +979,22 @@ func (mgr *ContainerManager) createContainerdContainer(ctx context.Context, c *C
 	ctrdContainer.SnapshotID = c.SnapshotKey()
 
 	if checkpointID != "" {
+		if err := checkCRIU(); err != nil {
+			return err
+		}
+
 		checkpointDir, err = mgr.getCheckpointDir(c.ID, checkpointDir, checkpointID, false)
 		if err != nil {
 			return err
 		}
+
+		config, err := readCheckpointConfig(filepath.Join(checkpointDir, checkpointConfigPath))
+		if err != nil {
+			return errors.Wrapf(err, "failed to read checkpoint %s", checkpointID)
+		}
+		if config != nil {
+			ctrdContainer.TCPEstablished = config.TCPEstablished
+		}
 	}
 	if err := mgr.Client.CreateContainer(ctx, ctrdContainer, checkpointDir); err != nil {
 		logrus.Errorf("failed to create new containerd container: %v", err)
@@ -830,6 +1013,7 @@ func (mgr *ContainerManager) createContainerdContainer(ctx context.Context, c *C
 	}
 
 	c.SetStatusRunning(int64(pid))
+	mgr.initHealthMonitor(c, false)
 
 	// set Snapshot MergedDir
 	c.Snapshotter.Data["MergedDir"] = c.BaseFS
@@ -901,6 +1085,11 @@ func (mgr *ContainerManager) Stop(ctx context.Context, name string, timeout int6
 	}
 	mgr.LogContainerEvent(ctx, c, "stop")
 
+	// the container stopped by API is also removed automatically, but the
+	// one stopped by restart or upgrade isn't.
+	if c.HostConfig.AutoRemove && !c.IsRunningOrPaused() && !c.IsCreated() {
+		mgr.monitor.PostEvent(ContainerExitEvent(c).WithHandle(mgr.handleExit))
+	}
 	return nil
 }
 
@@ -908,8 +1097,16 @@ func (mgr *ContainerManager) stop(ctx context.Context, c *Container, timeout int
 	c.Lock()
 	defer c.Unlock()
 
+	// the pending restart of restart policy is canceled.
+	c.cancelRestart()
+
 	if !c.IsRunningOrPaused() {
-		// stopping a non-running container is valid.
+		// stopping a non-running container is valid, and the exited one is
+		// marked as stopped by API, so that it won't be restarted by policy.
+		if c.State.Exited {
+			c.SetStatusStopped(c.State.ExitCode, c.State.Error)
+			return c.Write(mgr.Store)
+		}
 		return nil
 	}
 
@@ -917,15 +1114,108 @@ func (mgr *ContainerManager) stop(ctx context.Context, c *Container, timeout int
 		timeout = c.StopTimeout()
 	}
 
-	id := c.ID
-	msg, err := mgr.Client.DestroyContainer(ctx, id, timeout)
+	msg, err := mgr.destroy(ctx, c, timeout)
 	if err != nil {
-		return errors.Wrapf(err, "failed to destroy container %s", id)
+		return err
 	}
 
 	return mgr.markStoppedAndRelease(c, msg)
 }
 
+// destroy kills the task of running or paused container with its stop
+// signal, and kills it by SIGKILL if it doesn't exit in timeout, the
+// container should be locked by caller.
+func (mgr *ContainerManager) destroy(ctx context.Context, c *Container, timeout int64) (*ctrd.Message, error) {
+	id := c.ID
+
+	// the paused container is unpaused first, otherwise the frozen process
+	// can't handle the stop signal and will always be killed.
+	if c.State.Paused {
+		if err := mgr.Client.UnpauseContainer(ctx, id); err != nil {
+			return nil, errors.Wrapf(err, "failed to unpause container %s before stopping", id)
+		}
+		c.SetStatusUnpaused()
+	}
+
+	msg, err := mgr.Client.DestroyContainer(ctx, id, c.StopSignal(), timeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to destroy container %s", id)
+	}
+	return msg, nil
+}
+
+// Shutdown stops all the running or paused containers if live restore is
+// disabled, and flushes the logs of containers.
+func (mgr *ContainerManager) Shutdown(ctx context.Context) error {
+	containers, err := mgr.List(ctx, &ContainerListOption{All: true})
+	if err != nil {
+		return errors.Wrap(err, "failed to get container list")
+	}
+
+	if !mgr.Config.LiveRestore {
+		mgr.stopAll(ctx, containers)
+	}
+
+	// the logs buffered are written before pouchd exits, the containers
+	// kept running for live restore are logged again after restore.
+	for _, c := range containers {
+		if cntrio := mgr.IOs.Get(c.ID); cntrio != nil {
+			if err := cntrio.FlushLogs(); err != nil {
+				logrus.Warnf("failed to flush logs of container %s: %v", c.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// stopAll stops the running or paused containers in parallel, each of them
+// is killed by SIGKILL if it doesn't exit in its stop timeout or the shutdown
+// timeout of pouchd, whichever is shorter. Unlike the containers stopped by
+// API, they are marked as exited, so that they are restarted by restart
+// policy when pouchd starts again.
+func (mgr *ContainerManager) stopAll(ctx context.Context, containers []*Container) {
+	shutdownTimeout := int64(mgr.Config.GetShutdownTimeout() / time.Second)
+
+	var wg sync.WaitGroup
+	for _, c := range containers {
+		if !c.IsRunningOrPaused() {
+			continue
+		}
+
+		wg.Add(1)
+		go func(c *Container) {
+			defer wg.Done()
+			if err := mgr.shutdownContainer(ctx, c, shutdownTimeout); err != nil {
+				logrus.Errorf("failed to stop container %s on shutdown: %v", c.ID, err)
+			}
+		}(c)
+	}
+	wg.Wait()
+}
+
+// shutdownContainer stops the container when pouchd is stopped.
+func (mgr *ContainerManager) shutdownContainer(ctx context.Context, c *Container, shutdownTimeout int64) error {
+	c.Lock()
+	defer c.Unlock()
+
+	c.cancelRestart()
+
+	if !c.IsRunningOrPaused() {
+		return nil
+	}
+
+	timeout := c.StopTimeout()
+	if timeout <= 0 || timeout > shutdownTimeout {
+		timeout = shutdownTimeout
+	}
+
+	msg, err := mgr.destroy(ctrd.WithSnapshotter(ctx, c.Config.Snapshotter), c, timeout)
+	if err != nil {
+		return err
+	}
+	return mgr.markExitedAndRelease(c, msg)
+}
+
 // Restart restarts a running container.
 func (mgr *ContainerManager) Restart(ctx context.Context, name string, timeout int64) error {
 	c, err := mgr.container(name)
@@ -961,6 +1251,26 @@ func (mgr *ContainerManager) Restart(ctx context.Context, name string, timeout i
 	return c.Write(mgr.Store)
 }
 
+// Kill sends the signal to the init process of a running container.
+func (mgr *ContainerManager) Kill(ctx context.Context, name string, signal syscall.Signal) error {
+	c, err := mgr.container(name)
+	if err != nil {
+		return err
+	}
+
+	if !c.IsRunning() {
+		return errors.Wrapf(errtypes.ErrConflict, "container %s is not running", c.ID)
+	}
+
+	if err := mgr.Client.KillContainer(ctx, c.ID, signal); err != nil {
+		return errors.Wrapf(err, "failed to kill container %s", c.ID)
+	}
+
+	mgr.LogContainerEventWithAttributes(ctx, c, "kill", map[string]string{"signal": strconv.Itoa(int(signal))})
+
+	return nil
+}
+
 // Pause pauses a running container.
 func (mgr *ContainerManager) Pause(ctx context.Context, name string) error {
 	c, err := mgr.container(name)
@@ -1064,8 +1374,8 @@ func (mgr *ContainerManager) AttachCRILog(ctx context.Context, name string, logP
 
 // Rename renames a container.
 func (mgr *ContainerManager) Rename(ctx context.Context, oldName, newName string) error {
-	if mgr.NameToID.Get(newName).Exist() {
-		return errors.Wrapf(errtypes.ErrAlreadyExisted, "container name %s", newName)
+	if err := validateContainerName(newName); err != nil {
+		return err
 	}
 
 	c, err := mgr.container(oldName)
@@ -1080,18 +1390,27 @@ func (mgr *ContainerManager) Rename(ctx context.Context, oldName, newName string
 		return fmt.Errorf("cannot rename a dead container %s", c.ID)
 	}
 
+	name := c.Name
+	if name == newName {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "container %s already has name %s", c.ID, newName)
+	}
+
 	attributes := map[string]string{
-		"oldName": oldName,
+		"oldName": name,
 	}
 
-	name := c.Name
+	// NOTE: the name index is changed in one step, so that the old name
+	// and new name can't be used by others during renaming.
+	if v, ok := mgr.NameToID.Rename(name, newName); !ok {
+		id, _ := v.String()
+		return nameConflictError(newName, id)
+	}
 	c.Name = newName
 
-	mgr.NameToID.Remove(name)
-	mgr.NameToID.Put(newName, c.ID)
-
 	if err := c.Write(mgr.Store); err != nil {
 		logrus.Errorf("failed to update meta of container %s: %v", c.ID, err)
+		c.Name = name
+		mgr.NameToID.Rename(newName, name)
 		return err
 	}
 
@@ -1117,6 +1436,21 @@ func (mgr *ContainerManager) Update(ctx context.Context, name string, config *ty
 		logrus.Warnf("warnings update %s: %v", name, warnings)
 	}
 
+	// labels are the identity of container used by filters, they are
+	// immutable after the container is created.
+	if len(config.Label) != 0 {
+		return errors.Wrap(errtypes.ErrInvalidParam, "labels of container are immutable after creation")
+	}
+
+	if config.RestartPolicy != nil {
+		if err := opts.ValidateRestartPolicy(config.RestartPolicy); err != nil {
+			return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+		}
+		if err := validateAutoRemove(c.HostConfig.AutoRemove, config.RestartPolicy); err != nil {
+			return err
+		}
+	}
+
 	restore := false
 	oldConfig := *c.Config
 	oldHostconfig := *c.HostConfig
@@ -1135,41 +1469,31 @@ func (mgr *ContainerManager) Update(ctx context.Context, name string, config *ty
 		return fmt.Errorf("cannot update a dead container %s", c.ID)
 	}
 
-	// update container disk quota
-	if err := mgr.updateContainerDiskQuota(ctx, c, config.DiskQuota); err != nil {
-		return errors.Wrapf(err, "failed to update diskquota of container %s", c.ID)
-	}
-
-	// init Container Labels
-	if c.Config.Labels == nil {
-		c.Config.Labels = map[string]string{}
+	// update Resources of a container.
+	if err := mgr.updateContainerResources(c, config.Resources); err != nil {
+		restore = true
+		return errors.Wrapf(err, "failed to update resource of container %s", c.ID)
 	}
 
-	// compatibility with alidocker, UpdateConfig.Label is []string
-	// but ContainerConfig.Labels is map[string]string
-	if len(config.Label) != 0 {
-		// support remove some labels
-		newLabels, err := opts.ParseLabels(config.Label)
-		if err != nil {
-			return errors.Wrapf(err, "failed to parse labels")
-		}
-
-		for k, v := range newLabels {
-			if v == "" {
-				delete(c.Config.Labels, k)
-			} else {
-				c.Config.Labels[k] = v
-			}
+	// If container is not running, update container metadata struct is enough,
+	// resources will be updated when the container is started again,
+	// If container is running or paused, we need to update configs to the real
+	// world first, so the error from kernel (e.g. setting memory limit below
+	// current usage) leaves nothing else changed.
+	if c.IsRunningOrPaused() {
+		if err := mgr.Client.UpdateResources(ctx, c.ID, c.HostConfig.Resources); err != nil {
+			restore = true
+			return fmt.Errorf("failed to update resource: %s", err)
 		}
 	}
 
-	// update Resources of a container.
-	if err := mgr.updateContainerResources(c, config.Resources); err != nil {
-		restore = true
-		return errors.Wrapf(err, "failed to update resource of container %s", c.ID)
+	// update container disk quota
+	if err := mgr.updateContainerDiskQuota(ctx, c, config.DiskQuota); err != nil {
+		return errors.Wrapf(err, "failed to update diskquota of container %s", c.ID)
 	}
 
-	// TODO update restartpolicy when container is running.
+	// the restart policy takes effect on the next exit of container, and
+	// also on the pending restart.
 	if config.RestartPolicy != nil && config.RestartPolicy.Name != "" {
 		c.HostConfig.RestartPolicy = config.RestartPolicy
 	}
@@ -1201,16 +1525,6 @@ func (mgr *ContainerManager) Update(ctx context.Context, name string, config *ty
 		}
 	}
 
-	// If container is not running, update container metadata struct is enough,
-	// resources will be updated when the container is started again,
-	// If container is running, we need to update configs to the real world.
-	if c.State.Running {
-		if err := mgr.Client.UpdateResources(ctx, c.ID, c.HostConfig.Resources); err != nil {
-			restore = true
-			return fmt.Errorf("failed to update resource: %s", err)
-		}
-	}
-
 	// store disk.
 	err = c.Write(mgr.Store)
 	if err != nil {
@@ -1235,28 +1549,55 @@ func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *t
 	c.Lock()
 	defer c.Unlock()
 
+	// the container has been removed by others while waiting for the lock.
+	if c.State.Dead && !mgr.cache.Get(c.ID).Exist() {
+		logrus.Warnf("container has been deleted %s", c.ID)
+		return nil
+	}
+
 	if c.IsRunningOrPaused() && !options.Force {
-		return fmt.Errorf("container %s is not stopped, cannot remove it without flag force", c.ID)
+		return errors.Wrapf(errtypes.ErrConflict, "container %s is %s, cannot remove it without flag force", c.ID, c.State.Status)
 	}
 
-	if c.State.Dead {
-		logrus.Warnf("container has been deleted %s", c.ID)
-		return nil
+	if !options.Force {
+		sharers, err := mgr.namespaceSharers(ctx, c)
+		if err != nil {
+			return err
+		}
+		if len(sharers) > 0 {
+			return errors.Wrapf(errtypes.ErrConflict, "container %s is sharing namespaces with containers %v, cannot remove it without flag force", c.ID, sharers)
+		}
 	}
 
+	// the pending restart of restart policy is canceled only if the
+	// container is going to be removed.
+	c.cancelRestart()
+
 	// if the container is running, force to stop it.
 	if c.IsRunningOrPaused() && options.Force {
-		_, err := mgr.Client.DestroyContainer(ctx, c.ID, c.StopTimeout())
+		_, err := mgr.Client.DestroyContainer(ctx, c.ID, c.StopSignal(), c.StopTimeout())
 		if err != nil && !errtypes.IsNotfound(err) {
 			return errors.Wrapf(err, "failed to destroy container %s when removing", c.ID)
 		}
 		// After stopping a running container, we should release container resource
 		c.UnsetMergedDir()
+		mgr.unmountContainerVolumes(c)
 		if err := mgr.releaseContainerResources(c); err != nil {
 			logrus.Errorf("failed to release container %s resources when removing: %v", c.ID, err)
 		}
 	}
 
+	// mark the container dead in meta store before releasing the resources,
+	// so that the removal can be retried if it is interrupted, and the
+	// interrupted removal will be resumed when pouchd restarts.
+	if !c.State.Dead {
+		c.State.Dead = true
+		if err := c.Write(mgr.Store); err != nil {
+			c.State.Dead = false
+			return errors.Wrapf(err, "failed to mark container %s for removal", c.ID)
+		}
+	}
+
 	if err := mgr.detachVolumes(ctx, c, options.Volumes); err != nil {
 		logrus.Errorf("failed to detach volume: %v", err)
 	}
@@ -1292,7 +1633,6 @@ func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *t
 	mgr.cache.Remove(c.ID)
 	// remove the container IO
 	mgr.IOs.Remove(c.ID)
-	c.State.Dead = true
 
 	logRootDir, err := mgr.getLogRootDirFromOpt(c, false)
 	if err == nil && logRootDir != mgr.Store.Path(c.ID) {
@@ -1307,6 +1647,10 @@ func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *t
 		logrus.Errorf("failed to remove container %s from meta store: %v", c.ID, err)
 	}
 
+	// wake up the waiters, especially the ones of the container removed
+	// automatically.
+	c.notifyExit()
+
 	mgr.LogContainerEvent(ctx, c, "destroy")
 	return nil
 }
@@ -1343,6 +1687,19 @@ func (mgr *ContainerManager) updateContainerDiskQuota(ctx context.Context, c *Co
 func (mgr *ContainerManager) updateContainerResources(c *Container, resources types.Resources) error {
 	// update resources of container.
 	cResources := &c.HostConfig.Resources
+
+	// cpus and cpu period/quota are both converted into cfs quota, so only
+	// the one which has been set can be updated.
+	if resources.NanoCpus != 0 && (cResources.CPUQuota > 0 || cResources.CPUPeriod > 0) {
+		return errors.Wrap(errtypes.ErrInvalidParam, "Conflicting options: CPUs cannot be updated as CPU Period/Quota has already been set")
+	}
+	if (resources.CPUQuota > 0 || resources.CPUPeriod > 0) && cResources.NanoCpus != 0 {
+		return errors.Wrap(errtypes.ErrInvalidParam, "Conflicting options: CPU Period/Quota cannot be updated as CPUs has already been set")
+	}
+	if resources.NanoCpus != 0 {
+		cResources.NanoCpus = resources.NanoCpus
+	}
+
 	if resources.BlkioWeight != 0 {
 		cResources.BlkioWeight = resources.BlkioWeight
 	}
@@ -1409,7 +1766,7 @@ func (mgr *ContainerManager) Top(ctx context.Context, name string, psArgs string
 	defer c.Unlock()
 
 	if !c.IsRunningOrPaused() {
-		return nil, fmt.Errorf("container %s is not running or paused, cannot execute top command", c.ID)
+		return nil, errors.Wrapf(errtypes.ErrConflict, "container %s is not running, cannot execute top command", c.ID)
 	}
 
 	pids, err := mgr.Client.ContainerPIDs(ctx, c.ID)
@@ -1417,8 +1774,12 @@ func (mgr *ContainerManager) Top(ctx context.Context, name string, psArgs string
 		return nil, errors.Wrapf(err, "failed to get pids of container %s", c.ID)
 	}
 
-	output, err := exec.Command("ps", strings.Split(psArgs, " ")...).Output()
+	output, err := exec.Command("ps", strings.Fields(psArgs)...).Output()
 	if err != nil {
+		// the error of ps is mostly caused by the invalid options given by user.
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, errors.Wrapf(errtypes.ErrInvalidParam, "failed to run ps command with options %q: %s", psArgs, strings.TrimSpace(string(exitErr.Stderr)))
+		}
 		return nil, errors.Wrapf(err, "failed to run ps command")
 	}
 
@@ -1467,7 +1828,42 @@ func (mgr *ContainerManager) Wait(ctx context.Context, name string) (types.Conta
 	return mgr.Client.WaitContainer(ctx, c.ID)
 }
 
-// Connect is used to connect a container to a network.
+// WaitNextExit starts waiting for the next exit of container even if it isn't
+// running, which is used to get the exit status of the container removed
+// automatically. The channel is closed without status if ctx is done.
+func (mgr *ContainerManager) WaitNextExit(ctx context.Context, name string) (<-chan types.ContainerWaitOKBody, error) {
+	c, err := mgr.container(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	exitCh := c.exitNotify()
+	c.Unlock()
+
+	statusCh := make(chan types.ContainerWaitOKBody, 1)
+	go func() {
+		defer close(statusCh)
+
+		select {
+		case <-exitCh:
+		case <-ctx.Done():
+			return
+		}
+
+		c.Lock()
+		statusCh <- types.ContainerWaitOKBody{
+			Error:      c.State.Error,
+			StatusCode: c.ExitCode(),
+		}
+		c.Unlock()
+	}()
+	return statusCh, nil
+}
+
+// Connect is used to connect a container to a network. The endpoint is
+// created at once if the container is running, otherwise the network is
+// recorded and the endpoint is created when the container starts.
 func (mgr *ContainerManager) Connect(ctx context.Context, name string, networkIDOrName string, epConfig *types.EndpointSettings) error {
 	c, err := mgr.container(name)
 	if err != nil {
@@ -1489,36 +1885,56 @@ func (mgr *ContainerManager) Connect(ctx context.Context, name string, networkID
 	c.Lock()
 	defer c.Unlock()
 
-	if !c.State.Running {
-		if c.State.Dead {
-			return fmt.Errorf("container %s is marked for removal and cannot be connected or disconnected to the network %s", c.ID, n.Name)
+	if c.State.Dead {
+		return fmt.Errorf("container %s is marked for removal and cannot be connected or disconnected to the network %s", c.ID, n.Name)
+	}
+
+	if c.NetworkSettings != nil {
+		if _, ok := c.NetworkSettings.Networks[n.Name]; ok {
+			return errors.Wrapf(errtypes.ErrConflict, "container %s is already connected to network %s", c.Name, n.Name)
 		}
+	}
 
+	// the container has no network namespace set up by pouch if it's
+	// started after disconnected from all the networks, so the network
+	// is only recorded and connected after the container restarts.
+	if !c.State.Running || c.Config.NetworkDisabled {
 		if err := mgr.updateNetworkConfig(c, n.Name, epConfig); err != nil {
 			return err
 		}
-	} else if err := mgr.connectToNetwork(ctx, c, networkIDOrName, epConfig); err != nil {
+	} else if err := mgr.connectToNetwork(ctx, c, n.Name, epConfig); err != nil {
 		return err
 	}
+	c.Config.NetworkDisabled = false
+
+	// container meta changed, refresh the cache
+	mgr.cache.Put(c.ID, c)
 
 	mgr.LogNetworkEventWithAttributes(ctx, n.Network, "connect", map[string]string{"container": c.ID})
 
 	return c.Write(mgr.Store)
 }
 
-// Disconnect disconnects the given container from
-// given network
+// Disconnect disconnects the given container from given network. If force
+// is true, the endpoint is deleted even if the container has been removed
+// or the endpoint can't be left, so that the dead endpoint is cleaned up.
 func (mgr *ContainerManager) Disconnect(ctx context.Context, containerName, networkName string, force bool) error {
-	c, err := mgr.container(containerName)
+	// Get network
+	network, err := mgr.NetworkMgr.Get(ctx, networkName)
 	if err != nil {
-		// TODO(ziren): if force is true, force delete endpoint
-		return err
+		return errors.Wrapf(err, "failed to get network %s when disconnecting container %s", networkName, containerName)
 	}
 
-	// Get network
-	network, err := mgr.NetworkMgr.Get(ctx, networkName)
+	c, err := mgr.container(containerName)
 	if err != nil {
-		return fmt.Errorf("failed to get network %s when disconnecting container %s: %v", networkName, c.Name, err)
+		if !force || !errtypes.IsNotfound(err) {
+			return err
+		}
+		if err := forceDeleteEndpoint(network.Network, containerName); err != nil {
+			return errors.Wrapf(err, "failed to force disconnect container %s from network %s", containerName, network.Name)
+		}
+		mgr.LogNetworkEventWithAttributes(ctx, network.Network, "disconnect", map[string]string{"container": containerName})
+		return nil
 	}
 
 	c.Lock()
@@ -1531,23 +1947,32 @@ func (mgr *ContainerManager) Disconnect(ctx context.Context, containerName, netw
 	}
 
 	if c.NetworkSettings == nil {
-		return nil
+		return errors.Wrapf(errtypes.ErrNotfound, "container %s is not connected to network %s", c.Name, network.Name)
 	}
 
 	epConfig, ok := c.NetworkSettings.Networks[network.Name]
 	if !ok {
 		// container not attached to the given network
-		return fmt.Errorf("failed to disconnect container from network: container %s not attach to %s", c.Name, networkName)
+		return errors.Wrapf(errtypes.ErrNotfound, "container %s is not connected to network %s", c.Name, network.Name)
 	}
 
-	endpoint := mgr.buildContainerEndpoint(c, network.Name)
-	endpoint.EndpointConfig = epConfig
-	if err := mgr.NetworkMgr.EndpointRemove(ctx, endpoint); err != nil {
-		// TODO(ziren): it is a trick, we should wrapper sandbox
-		// not found as an error type
-		if !strings.Contains(err.Error(), "not found") {
-			logrus.Errorf("failed to remove endpoint: %v", err)
-			return err
+	if c.IsRunning() || force {
+		endpoint := mgr.buildContainerEndpoint(c, network.Name)
+		endpoint.EndpointConfig = epConfig
+		if err := mgr.NetworkMgr.EndpointRemove(ctx, endpoint); err != nil {
+			// TODO(ziren): it is a trick, we should wrapper sandbox
+			// not found as an error type
+			if !force && !strings.Contains(err.Error(), "not found") {
+				logrus.Errorf("failed to remove endpoint: %v", err)
+				return err
+			}
+			logrus.Warnf("failed to remove endpoint of container %s on network %s: %v", c.ID, network.Name, err)
+
+			if force {
+				if err := forceDeleteEndpoint(network.Network, c.ID); err != nil && !errtypes.IsNotfound(err) {
+					logrus.Warnf("failed to force delete endpoint of container %s on network %s: %v", c.ID, network.Name, err)
+				}
+			}
 		}
 	}
 
@@ -1574,19 +1999,30 @@ func (mgr *ContainerManager) Disconnect(ctx context.Context, containerName, netw
 	return nil
 }
 
+// updateNetworkConfig validates the endpoint config of network and records
+// it in the network settings of container.
 func (mgr *ContainerManager) updateNetworkConfig(container *Container, networkIDOrName string, endpointConfig *types.EndpointSettings) error {
 	if IsContainer(container.HostConfig.NetworkMode) {
-		return fmt.Errorf("container sharing network namespace with another container or host cannot be connected to any other network")
+		return errors.Wrap(errtypes.ErrInvalidParam, "container sharing network namespace with another container or host cannot be connected to any other network")
+	}
+
+	network, err := mgr.NetworkMgr.Get(context.Background(), networkIDOrName)
+	if err != nil {
+		return err
+	}
+
+	if IsHost(container.HostConfig.NetworkMode) || IsHost(network.Name) {
+		return errors.Wrap(errtypes.ErrInvalidParam, "container cannot be disconnected from host network or connected to host network")
 	}
 
 	// TODO check bridge-mode conflict
 
-	if IsUserDefined(container.HostConfig.NetworkMode) {
+	if !IsUserDefined(network.Name) {
 		if hasUserDefinedIPAddress(endpointConfig) {
-			return fmt.Errorf("user specified IP address is supported on user defined networks only")
+			return errors.Wrap(errtypes.ErrInvalidParam, "user specified IP address is supported on user defined networks only")
 		}
-		if endpointConfig != nil && len(endpointConfig.Aliases) > 0 {
-			return fmt.Errorf("network-scoped alias is supported only for containers in user defined networks")
+		if len(endpointConfig.Aliases) > 0 {
+			return errors.Wrap(errtypes.ErrInvalidParam, "network-scoped alias is supported only for containers in user defined networks")
 		}
 	} else {
 		addShortID := true
@@ -1602,40 +2038,58 @@ func (mgr *ContainerManager) updateNetworkConfig(container *Container, networkID
 		}
 	}
 
-	network, err := mgr.NetworkMgr.Get(context.Background(), networkIDOrName)
-	if err != nil {
-		return err
-	}
-
 	if err := validateNetworkingConfig(network.Network, endpointConfig); err != nil {
-		return err
+		return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
 	}
 
+	if container.NetworkSettings == nil {
+		container.NetworkSettings = &types.NetworkSettings{}
+	}
+	if container.NetworkSettings.Networks == nil {
+		container.NetworkSettings.Networks = make(map[string]*types.EndpointSettings)
+	}
 	container.NetworkSettings.Networks[network.Name] = endpointConfig
 
 	return nil
 }
 
-func (mgr *ContainerManager) connectToNetwork(ctx context.Context, container *Container, networkIDOrName string, epConfig *types.EndpointSettings) (err error) {
-	if IsContainer(container.HostConfig.NetworkMode) {
-		return fmt.Errorf("container sharing network namespace with another container or host cannot be connected to any other network")
+// validateStaticAddress validates the user specified IP address of container
+// on network when it's created, the conflict with other endpoints is checked
+// when the container starts.
+func (mgr *ContainerManager) validateStaticAddress(ctx context.Context, name string, epConfig *types.EndpointSettings) error {
+	if !hasUserDefinedIPAddress(epConfig) {
+		return nil
+	}
+	if !IsUserDefined(name) {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "user specified IP address is supported on user defined networks only, but network is %s", name)
 	}
 
-	// TODO check bridge mode conflict
-
-	network, err := mgr.NetworkMgr.Get(context.Background(), networkIDOrName)
+	network, err := mgr.NetworkMgr.Get(ctx, name)
 	if err != nil {
-		return errors.Wrap(err, "failed to get network")
+		return err
+	}
+	if err := validateNetworkingConfig(network.Network, epConfig); err != nil {
+		return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+	return nil
+}
+
+// connectToNetwork connects the running container to network, the endpoint
+// config is validated before the endpoint is created.
+func (mgr *ContainerManager) connectToNetwork(ctx context.Context, container *Container, networkName string, epConfig *types.EndpointSettings) (err error) {
+	if err := mgr.updateNetworkConfig(container, networkName, epConfig); err != nil {
+		return err
 	}
 
-	endpoint := mgr.buildContainerEndpoint(container, network.Name)
+	endpoint := mgr.buildContainerEndpoint(container, networkName)
 	endpoint.EndpointConfig = epConfig
 	if _, err := mgr.NetworkMgr.EndpointCreate(ctx, endpoint); err != nil {
 		logrus.Errorf("failed to create endpoint: %v", err)
+		delete(container.NetworkSettings.Networks, networkName)
 		return err
 	}
 
-	return mgr.updateNetworkConfig(container, networkIDOrName, endpoint.EndpointConfig)
+	return nil
 }
 
 func (mgr *ContainerManager) initContainerIO(c *Container) (*containerio.IO, error) {
@@ -1716,7 +2170,11 @@ func (mgr *ContainerManager) markStoppedAndRelease(c *Container, m *ctrd.Message
 		}
 	}
 
+	c.stopHealthMonitor()
 	c.SetStatusStopped(code, errMsg)
+	if !c.HostConfig.AutoRemove {
+		c.notifyExit()
+	}
 
 	// Action Container Remove and function markStoppedAndRelease are conflict.
 	// If a container has been removed and the corresponding meta.json will be removed as well.
@@ -1737,6 +2195,7 @@ func (mgr *ContainerManager) markStoppedAndRelease(c *Container, m *ctrd.Message
 	}()
 
 	c.UnsetMergedDir()
+	mgr.unmountContainerVolumes(c)
 
 	return mgr.releaseContainerResources(c)
 }
@@ -1753,7 +2212,11 @@ func (mgr *ContainerManager) markExitedAndRelease(c *Container, m *ctrd.Message)
 		}
 	}
 
+	c.stopHealthMonitor()
 	c.SetStatusExited(exitCode, errMsg)
+	if !c.HostConfig.AutoRemove {
+		c.notifyExit()
+	}
 
 	// Action Container Remove and function markStoppedAndRelease are conflict.
 	// If a container has been removed and the corresponding meta.json will be removed as well.
@@ -1774,6 +2237,7 @@ func (mgr *ContainerManager) markExitedAndRelease(c *Container, m *ctrd.Message)
 	}()
 
 	c.UnsetMergedDir()
+	mgr.unmountContainerVolumes(c)
 
 	return mgr.releaseContainerResources(c)
 }
@@ -1810,24 +2274,35 @@ func (mgr *ContainerManager) exitedAndRelease(id string, m *ctrd.Message, cleanu
 		return nil
 	}
 
-	// send exit event to monitor
-	mgr.monitor.PostEvent(ContainerExitEvent(c).WithHandle(func(c *Container) error {
-		// check status and restart policy
-		if !c.State.Exited {
-			return nil
-		}
+	// send exit event to monitor, which removes the container or restarts
+	// it by policy.
+	mgr.monitor.PostEvent(ContainerExitEvent(c).WithHandle(mgr.handleExit))
 
-		policy := (*ContainerRestartPolicy)(c.HostConfig.RestartPolicy)
-		keys := c.DetachKeys
+	return nil
+}
 
-		if policy == nil || policy.IsNone() {
-			return nil
-		}
+// handleExit handles the exit event of container, the container is removed
+// if AutoRemove is set, otherwise it is restarted by restart policy.
+func (mgr *ContainerManager) handleExit(c *Container) error {
+	if c.HostConfig != nil && c.HostConfig.AutoRemove {
+		// the removal may take a while, don't block the other events.
+		go mgr.autoRemove(c)
+		return nil
+	}
+	return mgr.handleRestartPolicy(c)
+}
 
-		return mgr.Start(context.TODO(), c.ID, &types.ContainerStartOptions{DetachKeys: keys})
-	}))
+// autoRemove removes the container and its anonymous volumes after it exits.
+// The waiters of exit are woken up after the removal, so that the container
+// is gone once the client gets the exit status.
+func (mgr *ContainerManager) autoRemove(c *Container) {
+	if err := mgr.Remove(context.Background(), c.ID, &types.ContainerRemoveOptions{Volumes: true}); err != nil && !errtypes.IsNotfound(err) {
+		logrus.Errorf("failed to remove container %s automatically: %v", c.ID, err)
 
-	return nil
+		c.Lock()
+		c.notifyExit()
+		c.Unlock()
+	}
 }
 
 // execExitedAndRelease be register into ctrd as a callback function, when the exec process in a container
@@ -1862,6 +2337,58 @@ func (mgr *ContainerManager) releaseContainerResources(c *Container) error {
 	return mgr.releaseContainerNetwork(c)
 }
 
+// unmountContainerVolumes unmounts the named volumes of container when it
+// exits or is stopped, the failure is logged and doesn't block the release.
+func (mgr *ContainerManager) unmountContainerVolumes(c *Container) {
+	// VolumeMgr is nil, which means the pouch daemon is initializing.
+	if mgr.VolumeMgr == nil {
+		return
+	}
+
+	for _, mp := range c.Mounts {
+		if mp.Name == "" {
+			continue
+		}
+		if err := mgr.VolumeMgr.Unmount(context.Background(), mp.Name, c.ID); err != nil {
+			logrus.Errorf("failed to unmount volume(%s) of container(%s): %v", mp.Name, c.ID, err)
+		}
+	}
+}
+
+// releaseStaleEndpoints deletes the endpoints whose containers are removed or
+// not running, which are left if pouchd crashes, so that their addresses are
+// released to the pool. The endpoint is owned by the container of its
+// sandbox, or by the container whose short id is its name.
+func (mgr *ContainerManager) releaseStaleEndpoints(containers []*Container) {
+	if mgr.NetworkMgr == nil {
+		return
+	}
+
+	running := make(map[string]bool)
+	for _, c := range containers {
+		if c.IsRunningOrPaused() && len(c.ID) >= 8 {
+			running[c.ID] = true
+			running[c.ID[:8]] = true
+		}
+	}
+
+	for _, n := range mgr.NetworkMgr.Controller().Networks() {
+		for _, ep := range n.Endpoints() {
+			if sb := ep.Info().Sandbox(); sb != nil && running[sb.ContainerID()] {
+				continue
+			}
+			if running[ep.Name()] {
+				continue
+			}
+
+			logrus.Infof("release stale endpoint %s(%s) on network %s", ep.Name(), ep.ID(), n.Name())
+			if err := ep.Delete(true); err != nil {
+				logrus.Warnf("failed to delete stale endpoint %s on network %s: %v", ep.ID(), n.Name(), err)
+			}
+		}
+	}
+}
+
 // releaseContainerNetwork release container network when container exits or is stopped.
 func (mgr *ContainerManager) releaseContainerNetwork(c *Container) error {
 	// NetworkMgr is nil, which means the pouch daemon is initializing.
@@ -1872,6 +2399,9 @@ func (mgr *ContainerManager) releaseContainerNetwork(c *Container) error {
 		return nil
 	}
 
+	// all the endpoints are removed even if one of them fails, so that
+	// the links of endpoints such as macvlan are not left on host.
+	var releaseErr error
 	for name, epConfig := range c.NetworkSettings.Networks {
 		endpoint := mgr.buildContainerEndpoint(c, name)
 		endpoint.EndpointConfig = epConfig
@@ -1880,12 +2410,16 @@ func (mgr *ContainerManager) releaseContainerNetwork(c *Container) error {
 			// not found"" as an error type
 			if !strings.Contains(err.Error(), "not found") {
 				logrus.Errorf("failed to remove endpoint: %v", err)
-				return err
+				if releaseErr == nil {
+					releaseErr = err
+				}
 			}
 		}
 	}
 
-	return nil
+	// the host ports are released with the endpoints.
+	c.NetworkSettings.Ports = types.PortMap{}
+	return releaseErr
 }
 
 // resetContainerIOs resets container IO resources.
@@ -0,0 +1,53 @@
+package mgr
+
+import (
+	"context"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/containerd/continuity/fs"
+	"github.com/pkg/errors"
+)
+
+const (
+	// changeModified means the path is modified in container's filesystem.
+	changeModified uint8 = iota
+	// changeAdded means the path is added in container's filesystem.
+	changeAdded
+	// changeDeleted means the path is deleted in container's filesystem.
+	changeDeleted
+)
+
+// Changes returns the changes of container's filesystem relative to its
+// image, which are sorted by path.
+func (mgr *ContainerManager) Changes(ctx context.Context, name string) ([]*types.ContainerChangeResponseItem, error) {
+	c, err := mgr.container(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	changes, err := mgr.Client.GetSnapshotChanges(ctx, c.SnapshotKey())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get changes of container %s", c.ID)
+	}
+
+	items := make([]*types.ContainerChangeResponseItem, 0, len(changes))
+	for _, change := range changes {
+		item := &types.ContainerChangeResponseItem{Path: change.Path}
+		switch change.Kind {
+		case fs.ChangeKindModify:
+			item.Kind = changeModified
+		case fs.ChangeKindAdd:
+			item.Kind = changeAdded
+		case fs.ChangeKindDelete:
+			item.Kind = changeDeleted
+		default:
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -1,19 +1,33 @@
 package mgr
 
 import (
+	"bufio"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/pkg/errors"
 )
 
 var (
 	checkpointConfigPath             = "config.json"
 	checkpointConfigPerm os.FileMode = 0700
+
+	// tcpStateEstablished is the state of established TCP connection in
+	// /proc/<pid>/net/tcp.
+	tcpStateEstablished = "01"
 )
 
 // getCheckpointDir gets container checkpoint directory.
@@ -67,6 +81,20 @@ func (mgr *ContainerManager) CreateCheckpoint(ctx context.Context, name string,
 		return fmt.Errorf("checkpoint not support on containers with tty")
 	}
 
+	if err := checkCRIU(); err != nil {
+		return err
+	}
+
+	if !options.TCPEstablished {
+		conns, err := establishedTCPConnections(c.State.Pid)
+		if err != nil {
+			return err
+		}
+		if len(conns) > 0 {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "container %s has established TCP connections %v, use --tcp-established to checkpoint them", c.ID, conns)
+		}
+	}
+
 	dir, err := mgr.getCheckpointDir(c.ID, options.CheckpointDir, options.CheckpointID, true)
 	if err != nil {
 		return err
@@ -77,11 +105,15 @@ func (mgr *ContainerManager) CreateCheckpoint(ctx context.Context, name string,
 		}
 	}()
 
-	if err := mgr.Client.CreateCheckpoint(ctx, c.ID, dir, options.Exit); err != nil {
+	if err := mgr.Client.CreateCheckpoint(ctx, c.ID, dir, options.Exit, options.TCPEstablished); err != nil {
 		return err
 	}
 
-	return writeCheckpointConfig(filepath.Join(dir, checkpointConfigPath), c.ID, options.CheckpointID)
+	return writeCheckpointConfig(filepath.Join(dir, checkpointConfigPath), &types.Checkpoint{
+		ContainerID:    c.ID,
+		CheckpointName: options.CheckpointID,
+		TCPEstablished: options.TCPEstablished,
+	})
 }
 
 // ListCheckpoint lists checkpoints from a container
@@ -130,12 +162,7 @@ func (mgr *ContainerManager) DeleteCheckpoint(ctx context.Context, name string,
 	return os.RemoveAll(dir)
 }
 
-func writeCheckpointConfig(path, container, checkpoint string) error {
-	config := &types.Checkpoint{
-		ContainerID:    container,
-		CheckpointName: checkpoint,
-	}
-
+func writeCheckpointConfig(path string, config *types.Checkpoint) error {
 	raw, err := json.Marshal(config)
 	if err != nil {
 		return err
@@ -156,3 +183,94 @@ func readCheckpointConfig(path string) (*types.Checkpoint, error) {
 
 	return config, err
 }
+
+// checkCRIU checks criu is installed and the kernel has the features
+// required by checkpoint and restore.
+func checkCRIU() error {
+	path, err := exec.LookPath("criu")
+	if err != nil {
+		return fmt.Errorf("criu is not installed, checkpoint and restore need criu in PATH of daemon: %v", err)
+	}
+
+	if out, err := exec.Command(path, "check").CombinedOutput(); err != nil {
+		return fmt.Errorf("kernel lacks features required by criu, criu check failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// establishedTCPConnections returns the established TCP connections with
+// the peers outside of container which runs as process pid.
+func establishedTCPConnections(pid int64) ([]string, error) {
+	var conns []string
+	for _, file := range []string{"tcp", "tcp6"} {
+		f, err := os.Open(filepath.Join("/proc", strconv.FormatInt(pid, 10), "net", file))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		list, err := parseEstablishedTCP(f)
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse TCP connections of process %d", pid)
+		}
+		conns = append(conns, list...)
+	}
+	return conns, nil
+}
+
+// parseEstablishedTCP parses the content of /proc/<pid>/net/tcp{,6}, and
+// returns the established connections whose peers aren't loopback addresses,
+// in the format of local->remote.
+func parseEstablishedTCP(r io.Reader) ([]string, error) {
+	var conns []string
+
+	scanner := bufio.NewScanner(r)
+	for first := true; scanner.Scan(); first = false {
+		fields := strings.Fields(scanner.Text())
+		// skip the header and the sockets not established.
+		if first || len(fields) < 4 || fields[3] != tcpStateEstablished {
+			continue
+		}
+
+		local, err := parseProcTCPAddr(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		remote, err := parseProcTCPAddr(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		if remote.IP.IsLoopback() {
+			continue
+		}
+		conns = append(conns, local.String()+"->"+remote.String())
+	}
+	return conns, scanner.Err()
+}
+
+// parseProcTCPAddr parses the address in /proc/<pid>/net/tcp{,6}, which is
+// in the format of IP:PORT in hex, and IP is in groups of 4 bytes in host
+// byte order.
+func parseProcTCPAddr(s string) (*net.TCPAddr, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid address %q", s)
+	}
+
+	ip, err := hex.DecodeString(parts[0])
+	if err != nil || (len(ip) != net.IPv4len && len(ip) != net.IPv6len) {
+		return nil, fmt.Errorf("invalid address %q", s)
+	}
+	for i := 0; i < len(ip); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = ip[i+3], ip[i+2], ip[i+1], ip[i]
+	}
+
+	port, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q", s)
+	}
+	return &net.TCPAddr{IP: net.IP(ip), Port: int(port)}, nil
+}
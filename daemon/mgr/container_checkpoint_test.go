@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/alibaba/pouch/apis/types"
@@ -38,12 +39,37 @@ func TestRWCheckpointConfig(t *testing.T) {
 			checkpoint: "bar",
 		},
 	} {
-		assert.NoError(writeCheckpointConfig(t.path, t.name, t.checkpoint))
-		c, err := readCheckpointConfig(t.path)
-		assert.NoError(err)
-		assert.Equal(c, &types.Checkpoint{
+		config := &types.Checkpoint{
 			ContainerID:    t.name,
 			CheckpointName: t.checkpoint,
-		})
+			TCPEstablished: t.name == "foo",
+		}
+		assert.NoError(writeCheckpointConfig(t.path, config))
+		c, err := readCheckpointConfig(t.path)
+		assert.NoError(err)
+		assert.Equal(c, config)
 	}
 }
+
+func TestParseEstablishedTCP(t *testing.T) {
+	tcp := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:0CEA 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0
+   1: 0200A8C0:0016 0100A8C0:D431 01 00000000:00000000 02:000A7214 00000000     0        0 23456 4 0000000000000000 20 4 33 10 -1
+   2: 0100007F:1F90 0100007F:C350 01 00000000:00000000 00:00000000 00000000     0        0 34567 1 0000000000000000 20 4 30 10 -1
+   3: 0200A8C0:0016 0100A8C0:D432 06 00000000:00000000 03:00000BB8 00000000     0        0 0 3 0000000000000000
+`
+	conns, err := parseEstablishedTCP(strings.NewReader(tcp))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.0.2:22->192.168.0.1:54321"}, conns)
+
+	tcp6 := `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000001000000:1F90 00000000000000000000000001000000:C350 01 00000000:00000000 00:00000000 00000000     0        0 45678 1 0000000000000000 20 4 30 10 -1
+   1: 0000000000000000FFFF00000200A8C0:1F90 0000000000000000FFFF00000100A8C0:C350 01 00000000:00000000 00:00000000 00000000     0        0 56789 1 0000000000000000 20 4 30 10 -1
+`
+	conns, err = parseEstablishedTCP(strings.NewReader(tcp6))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.0.2:8080->192.168.0.1:50000"}, conns)
+
+	_, err = parseEstablishedTCP(strings.NewReader("header\n   0: 0100007F 0100007F:C350 01\n"))
+	assert.Error(t, err)
+}
@@ -2,6 +2,10 @@ package mgr
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/ctrd"
@@ -48,13 +52,31 @@ func (mgr *ContainerManager) Commit(ctx context.Context, name string, options *t
 		return nil, errors.Wrapf(err, "failed to merge config from image")
 	}
 
+	// the changes only take effect on the new image, not the container.
+	config, err := applyCommitChanges(c.Config, options.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	// pause the running container to make sure the filesystem is consistent.
+	if options.Pause && c.IsRunning() {
+		if err := mgr.Pause(ctx, c.ID); err != nil {
+			return nil, errors.Wrapf(err, "failed to pause container %s before commit", c.ID)
+		}
+		defer func() {
+			if err := mgr.Unpause(ctx, c.ID); err != nil {
+				logrus.Errorf("failed to unpause container %s after commit: %v", c.ID, err)
+			}
+		}()
+	}
+
 	commitConfig := &ctrd.CommitConfig{
 		Author:          options.Author,
 		Comment:         options.Comment,
 		ContainerID:     c.ID,
 		Reference:       options.Repository + ":" + options.Tag,
 		ParentReference: pRef.String(),
-		ContainerConfig: c.Config,
+		ContainerConfig: config,
 		CImage:          img,
 		Image:           ociImage,
 	}
@@ -85,3 +107,122 @@ func (mgr *ContainerManager) Commit(ctx context.Context, name string, options *t
 	imageID := imageDigest.Hex()
 	return &types.ContainerCommitResp{ID: string(imageID[:12])}, nil
 }
+
+// applyCommitChanges returns a copy of config with the Dockerfile instructions
+// in changes applied. Only ENV, CMD, ENTRYPOINT and EXPOSE are supported.
+func applyCommitChanges(config *types.ContainerConfig, changes []string) (*types.ContainerConfig, error) {
+	newConfig := *config
+	newConfig.Env = append([]string{}, config.Env...)
+	newConfig.ExposedPorts = make(map[string]interface{}, len(config.ExposedPorts))
+	for port, v := range config.ExposedPorts {
+		newConfig.ExposedPorts[port] = v
+	}
+
+	for _, change := range changes {
+		fields := strings.Fields(change)
+		if len(fields) < 2 {
+			return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid change %q: instruction requires arguments", change)
+		}
+		instruction := strings.ToUpper(fields[0])
+		args := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(change), fields[0]))
+
+		switch instruction {
+		case "ENV":
+			envs, err := parseEnvChange(fields[1:], args)
+			if err != nil {
+				return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid change %q: %v", change, err)
+			}
+			for _, env := range envs {
+				newConfig.Env = setEnv(newConfig.Env, env)
+			}
+		case "CMD":
+			cmd, err := parseCommandChange(args)
+			if err != nil {
+				return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid change %q: %v", change, err)
+			}
+			newConfig.Cmd = cmd
+		case "ENTRYPOINT":
+			entrypoint, err := parseCommandChange(args)
+			if err != nil {
+				return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid change %q: %v", change, err)
+			}
+			newConfig.Entrypoint = entrypoint
+		case "EXPOSE":
+			for _, port := range fields[1:] {
+				p, err := parseExposedPort(port)
+				if err != nil {
+					return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid change %q: %v", change, err)
+				}
+				newConfig.ExposedPorts[p] = struct{}{}
+			}
+		default:
+			return nil, errors.Wrapf(errtypes.ErrInvalidParam, "unsupported instruction %s in change %q, only ENV, CMD, ENTRYPOINT and EXPOSE are supported", fields[0], change)
+		}
+	}
+
+	return &newConfig, nil
+}
+
+// parseEnvChange parses the arguments of ENV instruction, which is either
+// "KEY=VALUE ..." or "KEY VALUE".
+func parseEnvChange(fields []string, args string) ([]string, error) {
+	if !strings.Contains(fields[0], "=") {
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("ENV requires a value for %s", fields[0])
+		}
+		return []string{fields[0] + "=" + strings.TrimSpace(strings.TrimPrefix(args, fields[0]))}, nil
+	}
+
+	for _, env := range fields {
+		if strings.HasPrefix(env, "=") || !strings.Contains(env, "=") {
+			return nil, fmt.Errorf("ENV %s should be in format of KEY=VALUE", env)
+		}
+	}
+	return fields, nil
+}
+
+// setEnv sets the KEY=VALUE env in envs, replacing the existing one with
+// the same key.
+func setEnv(envs []string, env string) []string {
+	key := strings.SplitN(env, "=", 2)[0]
+	for i, e := range envs {
+		if strings.SplitN(e, "=", 2)[0] == key {
+			envs[i] = env
+			return envs
+		}
+	}
+	return append(envs, env)
+}
+
+// parseCommandChange parses the arguments of CMD or ENTRYPOINT instruction,
+// the JSON array is exec form and others are run by "/bin/sh -c".
+func parseCommandChange(args string) ([]string, error) {
+	if !strings.HasPrefix(args, "[") {
+		return []string{"/bin/sh", "-c", args}, nil
+	}
+
+	var cmd []string
+	if err := json.Unmarshal([]byte(args), &cmd); err != nil {
+		return nil, fmt.Errorf("exec form should be a JSON array of strings")
+	}
+	return cmd, nil
+}
+
+// parseExposedPort parses the port of EXPOSE instruction into the format of
+// "PORT/PROTOCOL", the protocol defaults to tcp.
+func parseExposedPort(port string) (string, error) {
+	parts := strings.SplitN(port, "/", 2)
+	proto := "tcp"
+	if len(parts) == 2 {
+		proto = strings.ToLower(parts[1])
+	}
+	if proto != "tcp" && proto != "udp" && proto != "sctp" {
+		return "", fmt.Errorf("invalid protocol %s of port %s", proto, port)
+	}
+
+	n, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil || n == 0 {
+		return "", fmt.Errorf("invalid port %s", port)
+	}
+	return fmt.Sprintf("%d/%s", n, proto), nil
+}
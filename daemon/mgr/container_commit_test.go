@@ -0,0 +1,51 @@
+package mgr
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCommitChanges(t *testing.T) {
+	config := &types.ContainerConfig{
+		Env:          []string{"PATH=/bin", "FOO=old"},
+		Cmd:          []string{"sh"},
+		ExposedPorts: map[string]interface{}{"22/tcp": struct{}{}},
+	}
+
+	newConfig, err := applyCommitChanges(config, []string{
+		"ENV FOO=new BAR=bar",
+		"env DEBUG true now",
+		`CMD ["top", "-b"]`,
+		"ENTRYPOINT echo hi",
+		"EXPOSE 80 53/udp",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"PATH=/bin", "FOO=new", "BAR=bar", "DEBUG=true now"}, newConfig.Env)
+	assert.Equal(t, []string{"top", "-b"}, newConfig.Cmd)
+	assert.Equal(t, []string{"/bin/sh", "-c", "echo hi"}, newConfig.Entrypoint)
+	assert.Len(t, newConfig.ExposedPorts, 3)
+	assert.Contains(t, newConfig.ExposedPorts, "80/tcp")
+	assert.Contains(t, newConfig.ExposedPorts, "53/udp")
+
+	// the original config is not changed.
+	assert.Equal(t, []string{"PATH=/bin", "FOO=old"}, config.Env)
+	assert.Len(t, config.ExposedPorts, 1)
+
+	for _, change := range []string{
+		"RUN echo hi",
+		"ENV",
+		"ENV =foo",
+		`CMD ["top"`,
+		"EXPOSE 80/foo",
+		"EXPOSE 70000",
+	} {
+		_, err := applyCommitChanges(config, []string{change})
+		assert.Error(t, err, change)
+		assert.True(t, errtypes.IsInvalidParam(err), change)
+	}
+}
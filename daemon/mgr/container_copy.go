@@ -0,0 +1,252 @@
+package mgr
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/archive"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/continuity/fs"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// StatPath returns the information of the path in container's filesystem.
+func (mgr *ContainerManager) StatPath(ctx context.Context, name, path string) (*types.ContainerPathStat, error) {
+	c, err := mgr.container(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	rootfs, release, err := mgr.mountRootfs(ctx, c, true)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resolved, err := archive.ResolvePath(rootfs, path)
+	if err != nil {
+		return nil, err
+	}
+	return statPath(c, rootfs, path, resolved)
+}
+
+// ArchivePath returns the tar archive of the path in container's filesystem
+// and the information of it. The archive contains the path itself, or only
+// its contents if the path ends with "/.". The container is locked until the
+// rootfs is mounted, and the running container is kept paused until the
+// archive is closed.
+func (mgr *ContainerManager) ArchivePath(ctx context.Context, name, path string) (io.ReadCloser, *types.ContainerPathStat, error) {
+	c, err := mgr.container(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.Lock()
+	rootfs, release, err := mgr.mountRootfs(ctx, c, true)
+	c.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		c.Lock()
+		release()
+		c.Unlock()
+	}
+
+	resolved, err := archive.ResolvePath(rootfs, path)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	stat, err := statPath(c, rootfs, path, resolved)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	base := filepath.Base(filepath.Join("/", path))
+	if archive.IsContentsPath(path) || base == "/" {
+		base = ""
+	}
+
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(archive.TarPath(pw, resolved, base))
+	}()
+
+	return ioutils.NewReadCloserWrapper(pr, func() error {
+		err := pr.Close()
+		// the rootfs can only be released after the archive is written.
+		<-done
+		cleanup()
+		return err
+	}), stat, nil
+}
+
+// ExtractToDir extracts the tar archive into the directory of path in
+// container's filesystem. The symlinks in the archive and the path are
+// resolved in the scope of container's filesystem.
+func (mgr *ContainerManager) ExtractToDir(ctx context.Context, name, path string, noOverwriteDirNonDir bool, content io.Reader) error {
+	c, err := mgr.container(name)
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	rootfs, release, err := mgr.mountRootfs(ctx, c, false)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	resolved, err := fs.RootPath(rootfs, path)
+	if err != nil {
+		return err
+	}
+
+	fi, err := os.Stat(resolved)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.Wrapf(errtypes.ErrNotfound, "could not find the file %s in container %s", path, c.ID)
+		}
+		return err
+	}
+	if !fi.IsDir() {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "extraction point %s is not a directory", path)
+	}
+
+	return archive.ExtractTar(content, path, archive.ExtractOptions{
+		Root:                 rootfs,
+		NoOverwriteDirNonDir: noOverwriteDirNonDir,
+		KeepOwner:            true,
+	})
+}
+
+// mountRootfs returns the root of container's filesystem, and the function
+// to release it, both of which must be called with the lock of container.
+//
+// The running container is paused until the rootfs is released, otherwise
+// the process in container can swap a path component for a symlink after
+// the path is resolved, and make the copy read or write the files on host,
+// see CVE-2018-15664. The snapshot of stopped container is mounted in a
+// temporary directory, so that it isn't affected by the start of container
+// once the lock is released.
+func (mgr *ContainerManager) mountRootfs(ctx context.Context, c *Container, readonly bool) (string, func(), error) {
+	if c.IsRunningOrPaused() {
+		unfreeze, err := mgr.freezeContainer(ctx, c)
+		if err != nil {
+			return "", nil, err
+		}
+		return c.BaseFS, unfreeze, nil
+	}
+
+	return mgr.mountSnapshot(ctx, c, readonly)
+}
+
+// mountSnapshot mounts the snapshot of container in a temporary directory,
+// and returns the directory and the function to unmount it.
+func (mgr *ContainerManager) mountSnapshot(ctx context.Context, c *Container, readonly bool) (string, func(), error) {
+	mounts, err := mgr.Client.GetMounts(ctx, c.SnapshotKey())
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get mounts of container %s", c.ID)
+	}
+	if readonly {
+		mounts = readonlyMounts(mounts)
+	}
+
+	root, err := ioutil.TempDir("", "pouch-rootfs-")
+	if err != nil {
+		return "", nil, err
+	}
+	if err := mount.All(mounts, root); err != nil {
+		os.Remove(root)
+		return "", nil, errors.Wrapf(err, "failed to mount rootfs of container %s", c.ID)
+	}
+
+	return root, func() {
+		if err := mount.UnmountAll(root, 0); err != nil {
+			logrus.Errorf("failed to umount rootfs of container %s: %v", c.ID, err)
+			return
+		}
+		os.Remove(root)
+	}, nil
+}
+
+// freezeContainer pauses the running container, and returns the function to
+// unpause it, which must be called with the lock of container. The paused
+// container is kept as it is.
+func (mgr *ContainerManager) freezeContainer(ctx context.Context, c *Container) (func(), error) {
+	if !c.IsRunning() {
+		return func() {}, nil
+	}
+
+	if err := mgr.Client.PauseContainer(ctx, c.ID); err != nil {
+		return nil, errors.Wrapf(err, "failed to pause container %s", c.ID)
+	}
+
+	return func() {
+		// the container may be paused by user or stopped in the meantime.
+		if !c.IsRunning() {
+			return
+		}
+
+		// the context of request may have been cancelled.
+		if err := mgr.Client.UnpauseContainer(context.Background(), c.ID); err != nil {
+			logrus.Errorf("failed to unpause container %s: %v", c.ID, err)
+		}
+	}, nil
+}
+
+// statPath returns the information of the resolved path in rootfs.
+func statPath(c *Container, rootfs, path, resolved string) (*types.ContainerPathStat, error) {
+	fi, err := os.Lstat(resolved)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(errtypes.ErrNotfound, "could not find the file %s in container %s", path, c.ID)
+		}
+		return nil, err
+	}
+
+	if archive.HasTrailingSeparator(path) && !fi.IsDir() {
+		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "path %s is not a directory", path)
+	}
+
+	var linkTarget string
+	if fi.Mode()&os.ModeSymlink != 0 {
+		// the target is the path in container's filesystem.
+		target, err := fs.RootPath(rootfs, path)
+		if err != nil {
+			return nil, err
+		}
+		if linkTarget, err = filepath.Rel(rootfs, target); err != nil {
+			return nil, err
+		}
+		linkTarget = filepath.Join("/", linkTarget)
+	}
+
+	return &types.ContainerPathStat{
+		Name:       filepath.Base(filepath.Join("/", path)),
+		Size:       fi.Size(),
+		Mode:       uint32(fi.Mode()),
+		Mtime:      fi.ModTime().Format(time.RFC3339Nano),
+		LinkTarget: linkTarget,
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/ctrd"
@@ -24,6 +25,10 @@ func (mgr *ContainerManager) CreateExec(ctx context.Context, name string, config
 		return "", err
 	}
 
+	if c.State.Paused {
+		return "", errors.Wrapf(errtypes.ErrConflict, "container %s is paused, unpause the container before exec", c.ID)
+	}
+
 	if !c.State.Running {
 		return "", fmt.Errorf("container %s is not running", c.ID)
 	}
@@ -81,7 +86,10 @@ func (mgr *ContainerManager) StartExec(ctx context.Context, execid string, cfg *
 		return err
 	}
 
-	cwd := c.Config.WorkingDir
+	cwd := execConfig.WorkingDir
+	if cwd == "" {
+		cwd = c.Config.WorkingDir
+	}
 	if cwd == "" {
 		cwd = "/"
 	}
@@ -148,8 +156,7 @@ func (mgr *ContainerManager) StartExec(ctx context.Context, execid string, cfg *
 		if err0 != nil {
 			// set exec exit status
 			execConfig.Running = false
-			exitCode := 126
-			execConfig.ExitCode = int64(exitCode)
+			execConfig.ExitCode = execFailureExitCode(err0)
 			eio.Close()
 			mgr.IOs.Remove(execid)
 		}
@@ -212,6 +219,16 @@ func (mgr *ContainerManager) CheckExecExist(ctx context.Context, name string) er
 	return err
 }
 
+// execFailureExitCode returns the exit code of exec process which fails to
+// start, 127 if the command is not found and 126 if it can't be invoked.
+func execFailureExitCode(err error) int64 {
+	msg := err.Error()
+	if strings.Contains(msg, "executable file not found") || strings.Contains(msg, "no such file or directory") {
+		return 127
+	}
+	return 126
+}
+
 func (mgr *ContainerManager) getEntrypointAndArgs(cmd []string) (string, []string) {
 	if len(cmd) == 0 {
 		return "", []string{}
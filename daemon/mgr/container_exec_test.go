@@ -0,0 +1,22 @@
+package mgr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecFailureExitCode(t *testing.T) {
+	for _, tc := range []struct {
+		err      string
+		expected int64
+	}{
+		{`exec: "foo": executable file not found in $PATH`, 127},
+		{`exec: "/bin/foo": stat /bin/foo: no such file or directory`, 127},
+		{`exec: "/etc/passwd": permission denied`, 126},
+		{`unknown error`, 126},
+	} {
+		assert.Equal(t, tc.expected, execFailureExitCode(errors.New(tc.err)), tc.err)
+	}
+}
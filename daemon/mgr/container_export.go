@@ -0,0 +1,100 @@
+package mgr
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/alibaba/pouch/pkg/archive"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/docker/docker/pkg/ioutils"
+)
+
+// Export returns the tar archive of container's filesystem, which works for
+// both running and stopped container by mounting the snapshot read-only. The
+// contents of volumes are not in the snapshot, and the device and socket
+// files are excluded. The container is locked until the snapshot is mounted,
+// and the running container is kept paused until the archive is closed.
+func (mgr *ContainerManager) Export(ctx context.Context, name string) (io.ReadCloser, error) {
+	c, err := mgr.container(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	// the changes of upper directory made by the running container are
+	// undefined to the read-only mount, see mountRootfs for the symlinks.
+	unfreeze, err := mgr.freezeContainer(ctx, c)
+	if err != nil {
+		c.Unlock()
+		return nil, err
+	}
+
+	root, unmount, err := mgr.mountSnapshot(ctx, c, true)
+	if err != nil {
+		unfreeze()
+		c.Unlock()
+		return nil, err
+	}
+	c.Unlock()
+
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(archive.TarWithOptions(pw, root, "", archive.TarOptions{ExcludeSpecialFiles: true}))
+	}()
+
+	return ioutils.NewReadCloserWrapper(pr, func() error {
+		err := pr.Close()
+		// the snapshot can only be unmounted after the archive is written.
+		<-done
+		unmount()
+
+		c.Lock()
+		unfreeze()
+		c.Unlock()
+		return err
+	}), nil
+}
+
+// readonlyMounts returns the read-only version of snapshot mounts. The upper
+// directory of overlay becomes the top most lower one, so that the merged
+// filesystem is kept without touching the upper of container.
+func readonlyMounts(mounts []mount.Mount) []mount.Mount {
+	ro := make([]mount.Mount, 0, len(mounts))
+	for _, m := range mounts {
+		var (
+			options []string
+			upper   string
+			lower   string
+		)
+		for _, opt := range m.Options {
+			switch {
+			case m.Type == "overlay" && strings.HasPrefix(opt, "upperdir="):
+				upper = strings.TrimPrefix(opt, "upperdir=")
+			case m.Type == "overlay" && strings.HasPrefix(opt, "workdir="):
+			case m.Type == "overlay" && strings.HasPrefix(opt, "lowerdir="):
+				lower = strings.TrimPrefix(opt, "lowerdir=")
+			case opt == "rw":
+			default:
+				options = append(options, opt)
+			}
+		}
+
+		if m.Type == "overlay" {
+			if upper != "" {
+				lower = upper + ":" + lower
+			}
+			options = append(options, "lowerdir="+lower)
+		}
+
+		ro = append(ro, mount.Mount{
+			Type:    m.Type,
+			Source:  m.Source,
+			Options: append(options, "ro"),
+		})
+	}
+	return ro
+}
@@ -0,0 +1,42 @@
+package mgr
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadonlyMounts(t *testing.T) {
+	for _, tc := range []struct {
+		mounts   []mount.Mount
+		expected []mount.Mount
+	}{
+		{
+			mounts: []mount.Mount{{
+				Type:    "overlay",
+				Source:  "overlay",
+				Options: []string{"workdir=/work", "upperdir=/upper", "lowerdir=/lower1:/lower2"},
+			}},
+			expected: []mount.Mount{{
+				Type:    "overlay",
+				Source:  "overlay",
+				Options: []string{"lowerdir=/upper:/lower1:/lower2", "ro"},
+			}},
+		},
+		{
+			mounts: []mount.Mount{{
+				Type:    "bind",
+				Source:  "/snapshot",
+				Options: []string{"rw", "rbind"},
+			}},
+			expected: []mount.Mount{{
+				Type:    "bind",
+				Source:  "/snapshot",
+				Options: []string{"rbind", "ro"},
+			}},
+		},
+	} {
+		assert.Equal(t, tc.expected, readonlyMounts(tc.mounts))
+	}
+}
@@ -0,0 +1,312 @@
+package mgr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/streams"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// healthStarting means the container is in the start period or no probe
+	// result is available yet.
+	healthStarting = "starting"
+
+	// healthy means the last probe succeeded.
+	healthy = "healthy"
+
+	// unhealthy means the probe has failed for the configured retries.
+	unhealthy = "unhealthy"
+
+	// defaultProbeInterval is the default time between probes.
+	defaultProbeInterval = 30 * time.Second
+
+	// defaultProbeTimeout is the default time to wait for a probe.
+	defaultProbeTimeout = 30 * time.Second
+
+	// defaultProbeRetries is the default number of consecutive failures
+	// before the container is unhealthy.
+	defaultProbeRetries = 3
+
+	// maxHealthLogEntries is the number of probe results kept in the state.
+	maxHealthLogEntries = 5
+
+	// maxHealthOutputLen is the maximum length of the output of a probe.
+	maxHealthOutputLen = 4096
+)
+
+// healthcheck returns the healthcheck of container, nil is returned if the
+// container has no healthcheck or it is disabled.
+func (c *Container) healthcheck() *types.HealthConfig {
+	if c.Config == nil || c.Config.Healthcheck == nil {
+		return nil
+	}
+
+	test := c.Config.Healthcheck.Test
+	if len(test) == 0 || test[0] == "NONE" {
+		return nil
+	}
+	return c.Config.Healthcheck
+}
+
+// mergeHealthcheck fills the unset fields of the healthcheck of container
+// with the ones defined in image.
+func mergeHealthcheck(config, image *types.HealthConfig) *types.HealthConfig {
+	if image == nil {
+		return config
+	}
+	if config == nil {
+		merged := *image
+		return &merged
+	}
+
+	if len(config.Test) == 0 {
+		config.Test = image.Test
+	}
+	if config.Interval == 0 {
+		config.Interval = image.Interval
+	}
+	if config.Timeout == 0 {
+		config.Timeout = image.Timeout
+	}
+	if config.Retries == 0 {
+		config.Retries = image.Retries
+	}
+	if config.StartPeriod == 0 {
+		config.StartPeriod = image.StartPeriod
+	}
+	return config
+}
+
+// initHealthMonitor starts probing the running container by its healthcheck.
+// The health is reset to starting unless the container is restored when
+// pouchd starts. The caller should hold the lock of container.
+func (mgr *ContainerManager) initHealthMonitor(c *Container, restore bool) {
+	c.stopHealthMonitor()
+
+	config := c.healthcheck()
+	if config == nil {
+		c.State.Health = nil
+		return
+	}
+
+	if c.State.Health == nil {
+		c.State.Health = &types.Health{Status: healthStarting}
+	} else if !restore {
+		c.State.Health.Status = healthStarting
+		c.State.Health.FailingStreak = 0
+	}
+
+	stop := make(chan struct{})
+	c.healthStop = stop
+	go mgr.monitorHealth(c, config, stop)
+}
+
+// stopHealthMonitor stops probing the container, the caller should hold the
+// lock of container.
+func (c *Container) stopHealthMonitor() {
+	if c.healthStop != nil {
+		close(c.healthStop)
+		c.healthStop = nil
+	}
+}
+
+// monitorHealth runs the probe in the interval until the monitor is stopped.
+func (mgr *ContainerManager) monitorHealth(c *Container, config *types.HealthConfig, stop chan struct{}) {
+	interval := probeDuration(config.Interval, defaultProbeInterval)
+	timeout := probeDuration(config.Timeout, defaultProbeTimeout)
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-time.After(interval):
+		}
+
+		// the exec can't run in the paused container, which is not a
+		// failure of the probe.
+		if c.IsPaused() {
+			continue
+		}
+
+		result := mgr.runProbe(context.Background(), c, config.Test, timeout)
+		mgr.handleProbeResult(c, config, result, stop)
+	}
+}
+
+// runProbe runs the test of healthcheck by exec in the container, the exec
+// process is killed if it doesn't exit in the timeout.
+func (mgr *ContainerManager) runProbe(ctx context.Context, c *Container, test []string, timeout time.Duration) *types.HealthcheckResult {
+	result := &types.HealthcheckResult{
+		Start: time.Now().UTC().Format(utils.TimeLayout),
+	}
+	defer func() {
+		result.End = time.Now().UTC().Format(utils.TimeLayout)
+	}()
+
+	cmd := test[1:]
+	if test[0] == "CMD-SHELL" {
+		cmd = []string{"/bin/sh", "-c", strings.Join(test[1:], " ")}
+	}
+
+	execid, err := mgr.CreateExec(ctx, c.ID, &types.ExecCreateConfig{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		result.ExitCode = -1
+		result.Output = err.Error()
+		return result
+	}
+
+	output := &probeOutput{}
+	exitCh := make(chan int64, 1)
+	go func() {
+		err := mgr.StartExec(ctx, execid, &streams.AttachConfig{
+			UseStdout: true,
+			UseStderr: true,
+			Stdout:    output,
+			Stderr:    output,
+		})
+		if err != nil {
+			output.Write([]byte(errors.Wrap(err, "failed to run healthcheck").Error()))
+		}
+
+		// the exec config is only used by the probe, so it is removed
+		// after the exec process exits.
+		exitCode := int64(-1)
+		if execConfig, err := mgr.GetExecConfig(ctx, execid); err == nil {
+			exitCode = execConfig.ExitCode
+		}
+		mgr.ExecProcesses.Remove(execid)
+		exitCh <- exitCode
+	}()
+
+	select {
+	case result.ExitCode = <-exitCh:
+		result.Output = output.String()
+	case <-time.After(timeout):
+		if err := mgr.Client.KillExec(ctx, c.ID, execid, syscall.SIGKILL); err != nil {
+			logrus.Warnf("failed to kill the timeout healthcheck of container %s: %v", c.ID, err)
+		}
+		result.ExitCode = -1
+		result.Output = fmt.Sprintf("Health check exceeded timeout (%v)", timeout)
+	}
+	return result
+}
+
+// handleProbeResult updates the health of container by the result of probe,
+// and emits the event if the health status is changed.
+func (mgr *ContainerManager) handleProbeResult(c *Container, config *types.HealthConfig, result *types.HealthcheckResult, stop chan struct{}) {
+	c.Lock()
+	defer c.Unlock()
+
+	// the result is dropped if the monitor is stopped during the probe.
+	if c.healthStop != stop || c.State.Health == nil {
+		return
+	}
+
+	health := c.State.Health
+	oldStatus := health.Status
+	updateHealth(health, config, result, inStartPeriod(c, config))
+
+	if err := c.Write(mgr.Store); err != nil {
+		logrus.Errorf("failed to update health of container %s: %v", c.ID, err)
+	}
+
+	if health.Status != oldStatus {
+		mgr.LogContainerEvent(context.Background(), c, "health_status: "+health.Status)
+	}
+}
+
+// updateHealth records the result of probe and updates the health status.
+// The failures in the start period are not counted until the probe succeeds
+// once.
+func updateHealth(health *types.Health, config *types.HealthConfig, result *types.HealthcheckResult, startPeriod bool) {
+	health.Log = append(health.Log, result)
+	if len(health.Log) > maxHealthLogEntries {
+		health.Log = health.Log[len(health.Log)-maxHealthLogEntries:]
+	}
+
+	if result.ExitCode == 0 {
+		health.FailingStreak = 0
+		health.Status = healthy
+		return
+	}
+
+	if startPeriod && health.Status == healthStarting {
+		return
+	}
+
+	health.FailingStreak++
+	retries := config.Retries
+	if retries == 0 {
+		retries = defaultProbeRetries
+	}
+	if health.FailingStreak >= retries {
+		health.Status = unhealthy
+	}
+}
+
+// inStartPeriod returns whether the container is still in the start period
+// of healthcheck, the caller should hold the lock of container.
+func inStartPeriod(c *Container, config *types.HealthConfig) bool {
+	if config.StartPeriod == 0 {
+		return false
+	}
+
+	started, err := time.Parse(utils.TimeLayout, c.State.StartedAt)
+	if err != nil {
+		return false
+	}
+	return time.Since(started) < time.Duration(config.StartPeriod)
+}
+
+// probeDuration returns d as duration, or the default value if d is 0.
+func probeDuration(d int64, defaultValue time.Duration) time.Duration {
+	if d == 0 {
+		return defaultValue
+	}
+	return time.Duration(d)
+}
+
+// probeOutput keeps the output of probe, which is truncated to
+// maxHealthOutputLen. The stdout and stderr are written concurrently.
+type probeOutput struct {
+	sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write implements io.Writer.
+func (o *probeOutput) Write(p []byte) (int, error) {
+	o.Lock()
+	defer o.Unlock()
+
+	if left := maxHealthOutputLen - o.buf.Len(); left > 0 {
+		if len(p) > left {
+			o.buf.Write(p[:left])
+		} else {
+			o.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+// String returns the output.
+func (o *probeOutput) String() string {
+	o.Lock()
+	defer o.Unlock()
+
+	return o.buf.String()
+}
@@ -0,0 +1,111 @@
+package mgr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeHealthcheck(t *testing.T) {
+	image := &types.HealthConfig{
+		Test:     []string{"CMD-SHELL", "true"},
+		Interval: int64(time.Second),
+		Retries:  5,
+	}
+
+	assert.Nil(t, mergeHealthcheck(nil, nil))
+	assert.Equal(t, image, mergeHealthcheck(nil, image))
+
+	// the fields set at create time override the ones of image.
+	merged := mergeHealthcheck(&types.HealthConfig{Interval: int64(time.Minute)}, image)
+	assert.Equal(t, &types.HealthConfig{
+		Test:     []string{"CMD-SHELL", "true"},
+		Interval: int64(time.Minute),
+		Retries:  5,
+	}, merged)
+
+	// the healthcheck of image is disabled.
+	merged = mergeHealthcheck(&types.HealthConfig{Test: []string{"NONE"}}, image)
+	assert.Nil(t, (&Container{Config: &types.ContainerConfig{Healthcheck: merged}}).healthcheck())
+}
+
+func TestContainerHealthcheck(t *testing.T) {
+	for _, tc := range []struct {
+		config *types.HealthConfig
+		expect bool
+	}{
+		{config: nil, expect: false},
+		{config: &types.HealthConfig{}, expect: false},
+		{config: &types.HealthConfig{Test: []string{"NONE"}}, expect: false},
+		{config: &types.HealthConfig{Test: []string{"CMD", "true"}}, expect: true},
+	} {
+		c := &Container{Config: &types.ContainerConfig{Healthcheck: tc.config}}
+		assert.Equal(t, tc.expect, c.healthcheck() != nil, "%+v", tc.config)
+	}
+}
+
+func TestInStartPeriod(t *testing.T) {
+	c := &Container{
+		State: &types.ContainerState{
+			StartedAt: time.Now().Add(-time.Minute).UTC().Format(utils.TimeLayout),
+		},
+	}
+
+	assert.False(t, inStartPeriod(c, &types.HealthConfig{}))
+	assert.True(t, inStartPeriod(c, &types.HealthConfig{StartPeriod: int64(time.Hour)}))
+	assert.False(t, inStartPeriod(c, &types.HealthConfig{StartPeriod: int64(time.Second)}))
+}
+
+func TestProbeOutput(t *testing.T) {
+	output := &probeOutput{}
+
+	n, err := output.Write([]byte(strings.Repeat("a", maxHealthOutputLen-1)))
+	assert.NoError(t, err)
+	assert.Equal(t, maxHealthOutputLen-1, n)
+
+	// the output is truncated, but the write never fails.
+	n, err = output.Write([]byte("bcd"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, maxHealthOutputLen, len(output.String()))
+	assert.True(t, strings.HasSuffix(output.String(), "ab"))
+}
+
+func TestUpdateHealth(t *testing.T) {
+	config := &types.HealthConfig{Retries: 2}
+	failure := &types.HealthcheckResult{ExitCode: 1}
+	success := &types.HealthcheckResult{ExitCode: 0}
+
+	// the failures in start period are ignored.
+	health := &types.Health{Status: healthStarting}
+	updateHealth(health, config, failure, true)
+	assert.Equal(t, healthStarting, health.Status)
+	assert.Equal(t, int64(0), health.FailingStreak)
+
+	updateHealth(health, config, success, true)
+	assert.Equal(t, healthy, health.Status)
+
+	// the failures are counted once the container is healthy.
+	updateHealth(health, config, failure, true)
+	assert.Equal(t, healthy, health.Status)
+	assert.Equal(t, int64(1), health.FailingStreak)
+
+	updateHealth(health, config, failure, false)
+	assert.Equal(t, unhealthy, health.Status)
+	assert.Equal(t, int64(2), health.FailingStreak)
+
+	updateHealth(health, config, success, false)
+	assert.Equal(t, healthy, health.Status)
+	assert.Equal(t, int64(0), health.FailingStreak)
+
+	// only the last results are kept.
+	for i := 0; i < maxHealthLogEntries+2; i++ {
+		updateHealth(health, config, success, false)
+	}
+	assert.Equal(t, maxHealthLogEntries, len(health.Log))
+}
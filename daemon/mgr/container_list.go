@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/alibaba/pouch/pkg/utils"
 	"github.com/alibaba/pouch/pkg/utils/filters"
 )
 
@@ -35,7 +38,7 @@ func newFilterContext(option *ContainerListOption) (*filterContext, error) {
 	}
 	return &filterContext{
 		condition:  option.Filter,
-		all:        option.All,
+		all:        option.All || option.Limit > 0,
 		filterFunc: option.FilterFunc,
 	}, nil
 }
@@ -210,5 +213,29 @@ func (mgr *ContainerManager) List(ctx context.Context, option *ContainerListOpti
 		}
 	}
 
+	if option != nil && option.Limit > 0 {
+		cons = lastCreatedContainers(cons, option.Limit)
+	}
+
 	return cons, nil
 }
+
+// lastCreatedContainers returns the last n created containers, and the most
+// recently created one is the first.
+func lastCreatedContainers(cons []*Container, n int) []*Container {
+	created := make(map[*Container]time.Time, len(cons))
+	for _, c := range cons {
+		// the container with invalid created time is treated as the oldest.
+		t, _ := time.Parse(utils.TimeLayout, c.Created)
+		created[c] = t
+	}
+
+	sort.SliceStable(cons, func(i, j int) bool {
+		return created[cons[i]].After(created[cons[j]])
+	})
+
+	if len(cons) > n {
+		cons = cons[:n]
+	}
+	return cons
+}
@@ -182,3 +182,35 @@ func TestMatchKVFilter(t *testing.T) {
 		assert.Equal(t.isFilter, fc.matchKVFilter(t.field, t.value), fmt.Sprintf("%+v", t.value))
 	}
 }
+
+func TestLastCreatedContainers(t *testing.T) {
+	newContainer := func(id, created string) *Container {
+		return &Container{ID: id, Created: created}
+	}
+
+	cons := []*Container{
+		newContainer("a", "2018-08-01T10:00:00Z"),
+		newContainer("b", "2018-08-01T10:00:00.5Z"),
+		newContainer("c", "2018-08-01T09:00:00Z"),
+		newContainer("d", "invalid"),
+		newContainer("e", "2018-08-02T10:00:00Z"),
+	}
+
+	ids := func(cons []*Container) []string {
+		var res []string
+		for _, c := range cons {
+			res = append(res, c.ID)
+		}
+		return res
+	}
+
+	assert.Equal(t, []string{"e"}, ids(lastCreatedContainers(cons, 1)))
+	assert.Equal(t, []string{"e", "b", "a"}, ids(lastCreatedContainers(cons, 3)))
+	assert.Equal(t, []string{"e", "b", "a", "c", "d"}, ids(lastCreatedContainers(cons, 10)))
+}
+
+func TestNewFilterContextWithLimit(t *testing.T) {
+	fc, err := newFilterContext(&ContainerListOption{Limit: 1})
+	assert.NoError(t, err)
+	assert.True(t, fc.all)
+}
@@ -7,9 +7,15 @@ import (
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/daemon/logger"
-	"github.com/alibaba/pouch/daemon/logger/jsonfile"
-	"github.com/alibaba/pouch/daemon/logger/syslog"
+	"github.com/alibaba/pouch/pkg/errtypes"
 
+	// register the log drivers.
+	_ "github.com/alibaba/pouch/daemon/logger/fluentd"
+	_ "github.com/alibaba/pouch/daemon/logger/journald"
+	_ "github.com/alibaba/pouch/daemon/logger/jsonfile"
+	_ "github.com/alibaba/pouch/daemon/logger/syslog"
+
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -23,15 +29,43 @@ func logOptionsForContainerio(c *Container, info logger.Info) (logger.LogDriver,
 		return nil, nil
 	}
 
-	switch cfg.LogDriver {
-	case types.LogConfigLogDriverJSONFile:
-		return jsonfile.Init(info)
-	case types.LogConfigLogDriverSyslog:
-		return syslog.Init(info)
-	default:
-		logrus.Warnf("not support (%v) log driver yet", cfg.LogDriver)
+	create, err := logger.GetLogDriver(cfg.LogDriver)
+	if err != nil {
+		logrus.Warn(err)
 		return nil, nil
 	}
+	return create(info)
+}
+
+// newLogReader returns the log driver of container to read the logs, the
+// error is returned if the log driver doesn't support reading.
+func (mgr *ContainerManager) newLogReader(c *Container) (logger.LogReader, error) {
+	name := types.LogConfigLogDriverNone
+	if c.HostConfig.LogConfig != nil {
+		name = c.HostConfig.LogConfig.LogDriver
+	}
+
+	create, err := logger.GetLogDriver(name)
+	if err != nil {
+		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "log driver %s does not support reading", name)
+	}
+
+	info, err := mgr.convContainerToLoggerInfo(c)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := create(info)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, ok := driver.(logger.LogReader)
+	if !ok {
+		driver.Close()
+		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "log driver %s does not support reading", name)
+	}
+	return reader, nil
 }
 
 // convContainerToLoggerInfo uses logger.Info to wrap container information.
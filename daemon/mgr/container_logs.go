@@ -2,13 +2,11 @@ package mgr
 
 import (
 	"context"
-	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/daemon/logger"
-	"github.com/alibaba/pouch/daemon/logger/jsonfile"
 	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/utils"
 
@@ -28,47 +26,34 @@ func (mgr *ContainerManager) Logs(ctx context.Context, name string, logOpt *type
 		return nil, false, pkgerrors.Wrap(errtypes.ErrInvalidParam, "you must choose at least one stream")
 	}
 
-	if c.HostConfig.LogConfig.LogDriver != types.LogConfigLogDriverJSONFile {
-		return nil, false, pkgerrors.Wrapf(
-			errtypes.ErrInvalidParam,
-			"only support for the %v log driver", types.LogConfigLogDriverJSONFile,
-		)
+	cfg, err := convContainerLogsOptionsToReadConfig(logOpt)
+	if err != nil {
+		return nil, false, err
 	}
 
-	cfg, err := convContainerLogsOptionsToReadConfig(logOpt)
+	reader, err := mgr.newLogReader(c)
 	if err != nil {
 		return nil, false, err
 	}
 
 	// NOTE: created container doesn't create IO.
 	if c.IsCreated() {
+		reader.Close()
+
 		msgCh := make(chan *logger.LogMessage, 1)
 		close(msgCh)
 
 		return msgCh, c.Config.Tty, nil
 	}
 
-	rootDir, err := mgr.getLogRootDirFromOpt(c, false)
-	if err != nil {
-		return nil, false, err
-	}
-
-	fileName := filepath.Join(rootDir, "json.log")
-
-	jf, err := jsonfile.NewJSONLogFile(fileName, 0640, nil, nil)
-
-	if err != nil {
-		return nil, false, err
-	}
-
 	// NOTE: unset the follow if the container is not running
 	cfg.Follow = cfg.Follow && c.State.Running
 
 	msgCh := make(chan *logger.LogMessage, 1)
-	watcher := jf.ReadLogMessages(cfg)
+	watcher := reader.ReadLogMessages(cfg)
 
 	go func() {
-		defer jf.Close()
+		defer reader.Close()
 		defer watcher.Close()
 		defer close(msgCh)
 
@@ -80,6 +65,11 @@ func (mgr *ContainerManager) Logs(ctx context.Context, name string, logOpt *type
 		watchTimer := time.NewTimer(time.Second)
 		defer watchTimer.Stop()
 
+		// stopped is set when the container is found stopped, and the
+		// follow returns in the next idle round so that the output
+		// written just before exiting can be sent.
+		stopped := false
+
 		for {
 			watchTimer.Reset(watchTimeout)
 			select {
@@ -109,7 +99,10 @@ func (mgr *ContainerManager) Logs(ctx context.Context, name string, logOpt *type
 				// in daemon/logger/jsonfile package.
 				if c, ok := mgr.cache.Get(c.ID).Result(); ok {
 					if !c.(*Container).State.Running {
-						return
+						if stopped {
+							return
+						}
+						stopped = true
 					}
 				}
 
@@ -0,0 +1,131 @@
+package mgr
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// IpcModePrivate means the ipc namespace of container can't be joined by others.
+	IpcModePrivate = "private"
+
+	// IpcModeShareable means the ipc namespace of container can be joined by others.
+	IpcModeShareable = "shareable"
+)
+
+// validateNamespaceModes validates the pid, ipc, uts and network namespace
+// modes of container, and the container:<id|name> mode is converted into
+// container:<id>, so that it is still valid after the target is renamed.
+func (mgr *ContainerManager) validateNamespaceModes(ctx context.Context, c *Container) error {
+	hostConfig := c.HostConfig
+
+	switch pidMode := hostConfig.PidMode; {
+	case pidMode == "", isHost(pidMode):
+	case isContainer(pidMode):
+		target, err := mgr.namespaceTarget(ctx, "PID", pidMode)
+		if err != nil {
+			return err
+		}
+		hostConfig.PidMode = "container:" + target.ID
+	default:
+		return errors.Wrapf(errtypes.ErrInvalidParam, "invalid pid mode %q, valid choices are host or container:<id|name>", pidMode)
+	}
+
+	switch ipcMode := hostConfig.IpcMode; {
+	case ipcMode == "":
+		hostConfig.IpcMode = IpcModeShareable
+	case ipcMode == IpcModePrivate, ipcMode == IpcModeShareable, isHost(ipcMode):
+	case isContainer(ipcMode):
+		target, err := mgr.namespaceTarget(ctx, "IPC", ipcMode)
+		if err != nil {
+			return err
+		}
+		if target.HostConfig.IpcMode == IpcModePrivate {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "can't join IPC namespace of container %s: its ipc mode is private", target.ID)
+		}
+		hostConfig.IpcMode = "container:" + target.ID
+	default:
+		return errors.Wrapf(errtypes.ErrInvalidParam, "invalid ipc mode %q, valid choices are host, private, shareable or container:<id|name>", ipcMode)
+	}
+
+	if utsMode := hostConfig.UTSMode; utsMode != "" && !isHost(utsMode) {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "invalid uts mode %q, valid choice is host", utsMode)
+	}
+
+	if networkMode := hostConfig.NetworkMode; IsContainer(networkMode) {
+		target, err := mgr.namespaceTarget(ctx, "network", networkMode)
+		if err != nil {
+			return err
+		}
+		hostConfig.NetworkMode = "container:" + target.ID
+	}
+
+	return nil
+}
+
+// namespaceTarget returns the container in mode container:<id|name>,
+// whose namespace of the kind is going to be joined.
+func (mgr *ContainerManager) namespaceTarget(ctx context.Context, kind, mode string) (*Container, error) {
+	id := connectedContainer(mode)
+	if id == "" {
+		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid %s mode %q, container id or name can't be empty", kind, mode)
+	}
+
+	target, err := mgr.container(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't join %s namespace of container %s", kind, id)
+	}
+	return target, nil
+}
+
+// getNamespaceContainer returns the running container whose namespace of
+// the kind is going to be joined by container c when c starts.
+func getNamespaceContainer(ctx context.Context, mgr ContainerMgr, c *Container, kind, id string) (*Container, error) {
+	target, err := mgr.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("can't join %s namespace of container %s: %v", kind, id, err)
+	}
+
+	if target.ID == c.ID {
+		return nil, fmt.Errorf("can't join %s namespace of the container itself", kind)
+	}
+	if !target.IsRunning() {
+		return nil, fmt.Errorf("can't join %s namespace of container %s: container is not running, its state is %s",
+			kind, target.ID, target.State.Status)
+	}
+	return target, nil
+}
+
+// namespaceSharers returns the names of containers which join the
+// namespaces of container c.
+func (mgr *ContainerManager) namespaceSharers(ctx context.Context, c *Container) ([]string, error) {
+	list, err := mgr.List(ctx, &ContainerListOption{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var sharers []string
+	for _, other := range list {
+		if other.ID == c.ID || other.HostConfig == nil || other.State.Dead {
+			continue
+		}
+
+		for _, mode := range []string{other.HostConfig.PidMode, other.HostConfig.IpcMode, other.HostConfig.NetworkMode} {
+			if !isContainer(mode) {
+				continue
+			}
+			if id := connectedContainer(mode); id == c.ID || id == c.Name {
+				sharers = append(sharers, other.Name)
+				break
+			}
+		}
+	}
+
+	sort.Strings(sharers)
+	return sharers, nil
+}
@@ -0,0 +1,133 @@
+package mgr
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/collect"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/meta"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newNamespaceTestManager(t *testing.T, dir string, containers ...*Container) *ContainerManager {
+	store, err := meta.NewStore(meta.Config{
+		Driver:  "local",
+		BaseDir: dir,
+		Buckets: []meta.Bucket{
+			{
+				Name: meta.MetaJSONFile,
+				Type: reflect.TypeOf(Container{}),
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	mgr := &ContainerManager{
+		NameToID: collect.NewSafeMap(),
+		Store:    store,
+		cache:    collect.NewSafeMap(),
+	}
+	for _, c := range containers {
+		assert.NoError(t, c.Write(store))
+		mgr.NameToID.Put(c.Name, c.ID)
+		mgr.cache.Put(c.ID, c)
+	}
+	return mgr
+}
+
+func newNamespaceTestContainer(id, name string, hostConfig *types.HostConfig) *Container {
+	return &Container{
+		ID:         id,
+		Name:       name,
+		Config:     &types.ContainerConfig{},
+		HostConfig: hostConfig,
+		State:      &types.ContainerState{Status: types.StatusRunning},
+	}
+}
+
+func TestValidateNamespaceModes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "namespace-modes")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	targetID := "90719b5f9a455b3314a49e72e3ecb9962f215e0f90153aa8911882acf2ba2c84"
+	privateID := "a8c2ea5f9a455b3314a49e72e3ecb9962f215e0f90153aa8911882acf2ba2c84"
+	mgr := newNamespaceTestManager(t, dir,
+		newNamespaceTestContainer(targetID, "target", &types.HostConfig{IpcMode: IpcModeShareable}),
+		newNamespaceTestContainer(privateID, "private", &types.HostConfig{IpcMode: IpcModePrivate}),
+	)
+
+	for _, tc := range []struct {
+		hostConfig types.HostConfig
+		expected   types.HostConfig
+		err        string
+	}{
+		{expected: types.HostConfig{IpcMode: IpcModeShareable}},
+		{
+			hostConfig: types.HostConfig{PidMode: "host", IpcMode: "host", UTSMode: "host", NetworkMode: "host"},
+			expected:   types.HostConfig{PidMode: "host", IpcMode: "host", UTSMode: "host", NetworkMode: "host"},
+		},
+		{
+			hostConfig: types.HostConfig{PidMode: "container:target", IpcMode: "container:90719b", NetworkMode: "container:target"},
+			expected:   types.HostConfig{PidMode: "container:" + targetID, IpcMode: "container:" + targetID, NetworkMode: "container:" + targetID},
+		},
+		{hostConfig: types.HostConfig{PidMode: "private"}, err: "invalid pid mode"},
+		{hostConfig: types.HostConfig{PidMode: "container:"}, err: "container id or name can't be empty"},
+		{hostConfig: types.HostConfig{PidMode: "container:missing"}, err: "can't join PID namespace of container missing"},
+		{hostConfig: types.HostConfig{IpcMode: "foo"}, err: "invalid ipc mode"},
+		{hostConfig: types.HostConfig{IpcMode: "container:private"}, err: "its ipc mode is private"},
+		{hostConfig: types.HostConfig{UTSMode: "container:target"}, err: "invalid uts mode"},
+		{hostConfig: types.HostConfig{NetworkMode: "container:missing"}, err: "can't join network namespace of container missing"},
+	} {
+		hostConfig := tc.hostConfig
+		c := newNamespaceTestContainer("b1c0883f9a455b3314a49e72e3ecb9962f215e0f90153aa8911882acf2ba2c84", "foo", &hostConfig)
+
+		err := mgr.validateNamespaceModes(context.Background(), c)
+		if tc.err != "" {
+			assert.Error(t, err, "%+v", tc.hostConfig)
+			assert.Contains(t, err.Error(), tc.err, "%+v", tc.hostConfig)
+			continue
+		}
+		assert.NoError(t, err, "%+v", tc.hostConfig)
+		assert.Equal(t, tc.expected, hostConfig, "%+v", tc.hostConfig)
+	}
+
+	c := newNamespaceTestContainer("b1c0883f9a455b3314a49e72e3ecb9962f215e0f90153aa8911882acf2ba2c84", "foo", &types.HostConfig{PidMode: "foo"})
+	assert.True(t, errtypes.IsInvalidParam(mgr.validateNamespaceModes(context.Background(), c)))
+}
+
+func TestNamespaceSharers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "namespace-sharers")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	targetID := "90719b5f9a455b3314a49e72e3ecb9962f215e0f90153aa8911882acf2ba2c84"
+	target := newNamespaceTestContainer(targetID, "target", &types.HostConfig{})
+
+	dead := newNamespaceTestContainer("d0c0883f9a455b3314a49e72e3ecb9962f215e0f90153aa8911882acf2ba2c84", "dead", &types.HostConfig{PidMode: "container:" + targetID})
+	dead.State.Dead = true
+
+	mgr := newNamespaceTestManager(t, dir,
+		target,
+		dead,
+		newNamespaceTestContainer("a1c0883f9a455b3314a49e72e3ecb9962f215e0f90153aa8911882acf2ba2c84", "pid", &types.HostConfig{PidMode: "container:" + targetID}),
+		newNamespaceTestContainer("b1c0883f9a455b3314a49e72e3ecb9962f215e0f90153aa8911882acf2ba2c84", "net", &types.HostConfig{NetworkMode: "container:target", IpcMode: "container:" + targetID}),
+		newNamespaceTestContainer("c1c0883f9a455b3314a49e72e3ecb9962f215e0f90153aa8911882acf2ba2c84", "alone", &types.HostConfig{PidMode: "host"}),
+	)
+
+	sharers, err := mgr.namespaceSharers(context.Background(), target)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"net", "pid"}, sharers)
+
+	alone, err := mgr.container("alone")
+	assert.NoError(t, err)
+	sharers, err = mgr.namespaceSharers(context.Background(), alone)
+	assert.NoError(t, err)
+	assert.Empty(t, sharers)
+}
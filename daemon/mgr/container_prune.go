@@ -0,0 +1,127 @@
+package mgr
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// notLabelFilter is the filter of prune which matches the containers without
+// the label, such as label!=keep=true.
+const notLabelFilter = "label!"
+
+// acceptedPruneContainerFilterTags are the filters supported by container prune.
+var acceptedPruneContainerFilterTags = map[string]bool{
+	labelFilter:    true,
+	notLabelFilter: true,
+	"until":        true,
+}
+
+// Prune removes the containers which are not running, paused or waiting to be
+// restarted by restart policy, and returns the IDs of deleted containers and
+// the reclaimed disk space of their snapshots. The containers are removed in
+// the same way as Remove without force, so the running ones are never touched.
+func (mgr *ContainerManager) Prune(ctx context.Context, filter filters.Args) (*types.ContainerPruneResp, error) {
+	if err := filter.Validate(acceptedPruneContainerFilterTags); err != nil {
+		return nil, pkgerrors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	until, err := parseUntilFilter(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	labels, notLabels := filter.Get(labelFilter), filter.Get(notLabelFilter)
+	containers, err := mgr.List(ctx, &ContainerListOption{
+		All: true,
+		FilterFunc: func(c *Container) bool {
+			return !c.IsRunningOrPaused() && !c.IsRestartPending() && matchPruneContainer(c, until, labels, notLabels)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &types.ContainerPruneResp{ContainersDeleted: []string{}}
+	for _, c := range containers {
+		// the usage is only available before the snapshot is removed.
+		var size int64
+		if !c.RootFSProvided {
+			usage, err := mgr.Client.GetSnapshotUsage(ctrd.WithSnapshotter(ctx, c.Config.Snapshotter), c.SnapshotKey())
+			if err != nil {
+				logrus.Warnf("failed to get snapshot usage of container %s during prune containers: %v", c.ID, err)
+			}
+			size = usage.Size
+		}
+
+		if err := mgr.Remove(ctx, c.ID, &types.ContainerRemoveOptions{}); err != nil {
+			logrus.Warnf("failed to remove container %s during prune containers: %v", c.ID, err)
+			continue
+		}
+
+		resp.ContainersDeleted = append(resp.ContainersDeleted, c.ID)
+		resp.SpaceReclaimed += size
+	}
+	return resp, nil
+}
+
+// matchPruneContainer returns whether the container matches the until and
+// label filters of prune. The container must have all the labels, and none
+// of the notLabels.
+func matchPruneContainer(c *Container, until time.Time, labels, notLabels []string) bool {
+	if !until.IsZero() {
+		created, err := time.Parse(utils.TimeLayout, c.Created)
+		if err != nil || !created.Before(until) {
+			return false
+		}
+	}
+
+	var containerLabels map[string]string
+	if c.Config != nil {
+		containerLabels = c.Config.Labels
+	}
+	return matchPruneLabels(containerLabels, labels, notLabels)
+}
+
+// matchPruneLabels returns whether the object with the labels matches the
+// label filters of prune, it must have all the labels, and none of the
+// notLabels.
+func matchPruneLabels(objectLabels map[string]string, labels, notLabels []string) bool {
+	excluded := append([]string{}, notLabels...)
+	for _, label := range labels {
+		// label=key!=value is the same as label!=key=value.
+		if parts := strings.SplitN(label, "!=", 2); len(parts) == 2 {
+			excluded = append(excluded, parts[0]+"="+parts[1])
+			continue
+		}
+		if !hasLabel(objectLabels, label) {
+			return false
+		}
+	}
+	for _, label := range excluded {
+		if hasLabel(objectLabels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasLabel returns whether the labels contain the label in format of key or
+// key=value.
+func hasLabel(labels map[string]string, label string) bool {
+	parts := strings.SplitN(label, "=", 2)
+	value, exist := labels[parts[0]]
+	if len(parts) == 1 {
+		return exist
+	}
+	return exist && value == parts[1]
+}
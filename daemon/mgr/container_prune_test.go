@@ -0,0 +1,51 @@
+package mgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPruneContainer(t *testing.T) {
+	c := &Container{
+		Created: "2018-08-01T10:00:00Z",
+		Config: &types.ContainerConfig{
+			Labels: map[string]string{"app": "web"},
+		},
+	}
+
+	created := time.Date(2018, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		until     time.Time
+		labels    []string
+		notLabels []string
+		expect    bool
+	}{
+		{expect: true},
+		{until: created.Add(time.Hour), expect: true},
+		{until: created, expect: false},
+		{labels: []string{"app=web"}, expect: true},
+		{labels: []string{"app"}, expect: true},
+		{labels: []string{"app=db"}, expect: false},
+		{labels: []string{"app=web", "env=test"}, expect: false},
+		{labels: []string{"app!=db"}, expect: true},
+		{labels: []string{"app!=web"}, expect: false},
+		{until: created.Add(time.Hour), labels: []string{"app=db"}, expect: false},
+		{notLabels: []string{"app=db"}, expect: true},
+		{notLabels: []string{"keep=true"}, expect: true},
+		{notLabels: []string{"app=web"}, expect: false},
+		{notLabels: []string{"app"}, expect: false},
+		{labels: []string{"app=web"}, notLabels: []string{"keep"}, expect: true},
+	} {
+		assert.Equal(t, tc.expect, matchPruneContainer(c, tc.until, tc.labels, tc.notLabels), "%+v", tc)
+	}
+
+	// the container with invalid created time is not pruned by until.
+	invalid := &Container{Created: "invalid", Config: &types.ContainerConfig{}}
+	assert.False(t, matchPruneContainer(invalid, created, nil, nil))
+	assert.True(t, matchPruneContainer(invalid, time.Time{}, nil, nil))
+}
@@ -0,0 +1,219 @@
+package mgr
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultRestartDelay is the delay before the first restart.
+	defaultRestartDelay = 100 * time.Millisecond
+
+	// maxRestartDelay is the upper bound of the delay between restarts.
+	maxRestartDelay = time.Minute
+
+	// restartDelayResetTime is the running time after which the container is
+	// regarded as healthy, and the delay is reset.
+	restartDelayResetTime = 10 * time.Second
+)
+
+// restartManager schedules the restarts of a container, the delay between
+// restarts is doubled each time until the container keeps running long enough.
+type restartManager struct {
+	sync.Mutex
+
+	delay time.Duration
+
+	// cancel is closed to cancel the pending restart, it is nil if no
+	// restart is pending.
+	cancel chan struct{}
+}
+
+// nextDelay returns the delay before the next restart, runningTime is how
+// long the container has run since it is started last time.
+func (rm *restartManager) nextDelay(runningTime time.Duration) time.Duration {
+	rm.Lock()
+	defer rm.Unlock()
+
+	switch {
+	case rm.delay == 0 || runningTime >= restartDelayResetTime:
+		rm.delay = defaultRestartDelay
+	case rm.delay < maxRestartDelay:
+		rm.delay *= 2
+		if rm.delay > maxRestartDelay {
+			rm.delay = maxRestartDelay
+		}
+	}
+	return rm.delay
+}
+
+// schedule calls fn after the delay unless the restart is canceled. The
+// previous pending restart is replaced.
+func (rm *restartManager) schedule(delay time.Duration, fn func()) {
+	rm.Lock()
+	defer rm.Unlock()
+
+	if rm.cancel != nil {
+		close(rm.cancel)
+	}
+	cancel := make(chan struct{})
+	rm.cancel = cancel
+
+	go func() {
+		select {
+		case <-time.After(delay):
+		case <-cancel:
+			return
+		}
+
+		rm.Lock()
+		if rm.cancel != cancel {
+			rm.Unlock()
+			return
+		}
+		rm.cancel = nil
+		rm.Unlock()
+
+		fn()
+	}()
+}
+
+// Cancel cancels the pending restart, and returns whether there is one.
+func (rm *restartManager) Cancel() bool {
+	rm.Lock()
+	defer rm.Unlock()
+
+	if rm.cancel == nil {
+		return false
+	}
+	close(rm.cancel)
+	rm.cancel = nil
+	return true
+}
+
+// waiting returns whether a restart is pending.
+func (rm *restartManager) waiting() bool {
+	rm.Lock()
+	defer rm.Unlock()
+
+	return rm.cancel != nil
+}
+
+// IsRestartPending returns whether the container is waiting to be restarted
+// by restart policy.
+func (c *Container) IsRestartPending() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.restartMgr != nil && c.restartMgr.waiting()
+}
+
+// restartManager returns the restart manager of container, the caller should
+// hold the lock of container.
+func (c *Container) restartManager() *restartManager {
+	if c.restartMgr == nil {
+		c.restartMgr = &restartManager{}
+	}
+	return c.restartMgr
+}
+
+// cancelRestart cancels the pending restart and resets the delay, which is
+// used when the container is operated by API. The caller should hold the
+// lock of container.
+func (c *Container) cancelRestart() bool {
+	if c.restartMgr == nil {
+		return false
+	}
+	canceled := c.restartMgr.Cancel()
+	c.restartMgr = nil
+	return canceled
+}
+
+// handleRestartPolicy schedules the restart of the exited container
+// according to its restart policy.
+func (mgr *ContainerManager) handleRestartPolicy(c *Container) error {
+	c.Lock()
+	defer c.Unlock()
+
+	// the container stopped by API is never restarted.
+	if !c.State.Exited || c.HostConfig == nil || c.HostConfig.RestartPolicy == nil {
+		return nil
+	}
+
+	policy := (*ContainerRestartPolicy)(c.HostConfig.RestartPolicy)
+	if !policy.ShouldRestart(c.State.ExitCode, c.RestartCount, false) {
+		return nil
+	}
+
+	started, _ := time.Parse(utils.TimeLayout, c.State.StartedAt)
+	finished, _ := time.Parse(utils.TimeLayout, c.State.FinishedAt)
+	delay := c.restartManager().nextDelay(finished.Sub(started))
+
+	logrus.Infof("container %s exited with code %d, restart it in %v by policy %s", c.ID, c.State.ExitCode, delay, policy.Name)
+	c.restartManager().schedule(delay, func() {
+		mgr.restartByPolicy(c, false)
+	})
+	return nil
+}
+
+// restartByPolicy starts the container which is restarted by restart policy,
+// the policy is checked again since it may be updated during the delay. The
+// container stopped by API is only restarted if ignoreStopped is true.
+func (mgr *ContainerManager) restartByPolicy(c *Container, ignoreStopped bool) {
+	c.Lock()
+	policy := (*ContainerRestartPolicy)(c.HostConfig.RestartPolicy)
+	manuallyStopped := !c.State.Exited && !ignoreStopped
+	if c.IsRunningOrPaused() || policy == nil || !policy.ShouldRestart(c.State.ExitCode, c.RestartCount, manuallyStopped) {
+		c.Unlock()
+		return
+	}
+	keys := c.DetachKeys
+	c.Unlock()
+
+	ctx := ctrd.WithSnapshotter(context.TODO(), c.Config.Snapshotter)
+	if err := mgr.start(ctx, c, &types.ContainerStartOptions{DetachKeys: keys}); err != nil {
+		if !errtypes.IsNotModified(err) {
+			logrus.Errorf("failed to restart container %s by policy %s: %v", c.ID, policy.Name, err)
+		}
+		return
+	}
+
+	c.Lock()
+	c.RestartCount++
+	if err := c.Write(mgr.Store); err != nil {
+		logrus.Errorf("failed to update meta of container %s: %v", c.ID, err)
+	}
+	c.Unlock()
+
+	mgr.LogContainerEvent(ctx, c, "start")
+}
+
+// restoreRestartPolicy schedules the restart of the container which is not
+// running when pouchd starts. The container with always policy is restarted
+// even if it is stopped by API, like the behavior of docker.
+func (mgr *ContainerManager) restoreRestartPolicy(c *Container) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.HostConfig == nil || c.HostConfig.RestartPolicy == nil || c.State.StartedAt == "" || c.IsCreated() {
+		return
+	}
+
+	policy := (*ContainerRestartPolicy)(c.HostConfig.RestartPolicy)
+	ignoreStopped := policy.IsAlways()
+	if !policy.ShouldRestart(c.State.ExitCode, c.RestartCount, !c.State.Exited && !ignoreStopped) {
+		return
+	}
+
+	c.restartManager().schedule(c.restartManager().nextDelay(0), func() {
+		mgr.restartByPolicy(c, ignoreStopped)
+	})
+}
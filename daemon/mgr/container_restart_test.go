@@ -0,0 +1,79 @@
+package mgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldRestart(t *testing.T) {
+	for _, tc := range []struct {
+		policy          ContainerRestartPolicy
+		exitCode        int64
+		restartCount    int64
+		manuallyStopped bool
+		expect          bool
+	}{
+		{policy: ContainerRestartPolicy{Name: ""}, exitCode: 1, expect: false},
+		{policy: ContainerRestartPolicy{Name: "no"}, exitCode: 1, expect: false},
+		{policy: ContainerRestartPolicy{Name: "always"}, exitCode: 0, expect: true},
+		{policy: ContainerRestartPolicy{Name: "always"}, exitCode: 0, manuallyStopped: true, expect: false},
+		{policy: ContainerRestartPolicy{Name: "unless-stopped"}, exitCode: 0, expect: true},
+		{policy: ContainerRestartPolicy{Name: "unless-stopped"}, exitCode: 1, manuallyStopped: true, expect: false},
+		{policy: ContainerRestartPolicy{Name: "on-failure"}, exitCode: 0, expect: false},
+		{policy: ContainerRestartPolicy{Name: "on-failure"}, exitCode: 1, restartCount: 100, expect: true},
+		{policy: ContainerRestartPolicy{Name: "on-failure", MaximumRetryCount: 2}, exitCode: 1, restartCount: 1, expect: true},
+		{policy: ContainerRestartPolicy{Name: "on-failure", MaximumRetryCount: 2}, exitCode: 1, restartCount: 2, expect: false},
+		{policy: ContainerRestartPolicy{Name: "on-failure"}, exitCode: 1, manuallyStopped: true, expect: false},
+	} {
+		assert.Equal(t, tc.expect, tc.policy.ShouldRestart(tc.exitCode, tc.restartCount, tc.manuallyStopped), "%+v", tc)
+	}
+}
+
+func TestRestartManagerNextDelay(t *testing.T) {
+	rm := &restartManager{}
+
+	assert.Equal(t, defaultRestartDelay, rm.nextDelay(0))
+	assert.Equal(t, 2*defaultRestartDelay, rm.nextDelay(time.Second))
+	assert.Equal(t, 4*defaultRestartDelay, rm.nextDelay(time.Second))
+
+	// the delay is reset after the container keeps running long enough.
+	assert.Equal(t, defaultRestartDelay, rm.nextDelay(restartDelayResetTime))
+
+	// the delay never exceeds the maximum.
+	for i := 0; i < 20; i++ {
+		rm.nextDelay(0)
+	}
+	assert.Equal(t, maxRestartDelay, rm.nextDelay(0))
+}
+
+func TestRestartManagerSchedule(t *testing.T) {
+	rm := &restartManager{}
+
+	done := make(chan struct{})
+	rm.schedule(time.Millisecond, func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the restart is not triggered")
+	}
+	assert.False(t, rm.Cancel())
+
+	// the canceled restart is never triggered.
+	triggered := make(chan struct{})
+	rm.schedule(100*time.Millisecond, func() { close(triggered) })
+	assert.True(t, rm.Cancel())
+	select {
+	case <-triggered:
+		t.Fatal("the canceled restart is triggered")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	// the pending restart is replaced by the new one.
+	count := make(chan struct{}, 2)
+	rm.schedule(100*time.Millisecond, func() { count <- struct{}{} })
+	rm.schedule(time.Millisecond, func() { count <- struct{}{} })
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, 1, len(count))
+}
@@ -17,6 +17,29 @@ func (c *Container) IsRunningOrPaused() bool {
 	return c.State.Running || c.State.Paused
 }
 
+// IsPaused returns container is paused or not.
+func (c *Container) IsPaused() bool {
+	return c.State.Paused
+}
+
+// exitNotify returns the channel which is closed when the container exits or
+// is stopped next time. The caller should hold the lock of container.
+func (c *Container) exitNotify() <-chan struct{} {
+	if c.exitCh == nil {
+		c.exitCh = make(chan struct{})
+	}
+	return c.exitCh
+}
+
+// notifyExit wakes up the waiters of exit, the caller should hold the lock
+// of container.
+func (c *Container) notifyExit() {
+	if c.exitCh != nil {
+		close(c.exitCh)
+		c.exitCh = nil
+	}
+}
+
 // ExitCode returns container's ExitCode.
 func (c *Container) ExitCode() int64 {
 	return c.State.ExitCode
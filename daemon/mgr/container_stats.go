@@ -55,15 +55,34 @@ func (mgr *ContainerManager) StreamStats(ctx context.Context, name string, confi
 		return stats, nil
 	}
 
-	// just collect stats data once.
-	if !config.Stream {
+	// collectStats returns the stats of container and whether it is still
+	// running or paused, the empty stats are returned for stopped container.
+	collectStats := func() (*types.ContainerStats, bool, error) {
 		metrics, stats, err := mgr.Stats(ctx, name)
 		if err != nil {
-			return err
+			return nil, false, err
 		}
 		containerStat, err := wrapContainerStats(metrics, stats)
 		if err != nil {
-			return errors.Errorf("failed to wrap the containerStat: %v", err)
+			return nil, false, errors.Errorf("failed to wrap the containerStat: %v", err)
+		}
+		return containerStat, metrics != nil, nil
+	}
+
+	// just collect stats data once.
+	if !config.Stream {
+		containerStat, running, err := collectStats()
+		if err != nil {
+			return err
+		}
+
+		// the cpu usage percentage is computed from two consecutive stats,
+		// so collect again to fill the precpu stats.
+		if running {
+			time.Sleep(DefaultStatsInterval)
+			if containerStat, _, err = collectStats(); err != nil {
+				return err
+			}
 		}
 		return json.NewEncoder(outStream).Encode(containerStat)
 	}
@@ -82,19 +101,22 @@ func (mgr *ContainerManager) StreamStats(ctx context.Context, name string, confi
 			return nil
 		default:
 			logrus.Debugf("Start to stream stats of container %s", c.ID)
-			metrics, stats, err := mgr.Stats(ctx, name)
+			containerStat, running, err := collectStats()
 			if err != nil {
 				return err
 			}
 
-			containerStat, err := wrapContainerStats(metrics, stats)
-			if err != nil {
-				return errors.Errorf("failed to wrap the containerStat: %v", err)
-			}
 			if err := enc.Encode(containerStat); err != nil {
 				return err
 			}
 
+			// the stream ends once the container is not running, after the
+			// empty stats are sent.
+			if !running {
+				logrus.Debugf("container %s is not running, stop streaming stats", c.ID)
+				return nil
+			}
+
 			time.Sleep(DefaultStatsInterval)
 		}
 	}
@@ -28,21 +28,11 @@ import (
 )
 
 func (mgr *ContainerManager) attachVolume(ctx context.Context, name string, c *Container) (string, string, error) {
-	driver := volumetypes.DefaultBackend
-	v, err := mgr.VolumeMgr.Get(ctx, name)
-	if err != nil || v == nil {
-		opts := map[string]string{
-			"backend": driver,
-		}
-		if _, err := mgr.VolumeMgr.Create(ctx, name, c.HostConfig.VolumeDriver, opts, nil); err != nil {
-			logrus.Errorf("failed to create volume(%s), err(%v)", name, err)
-			return "", "", errors.Wrap(err, "failed to create volume")
-		}
-	} else {
-		driver = v.Driver()
+	opts := map[string]string{
+		"backend": volumetypes.DefaultBackend,
 	}
-
-	if _, err := mgr.VolumeMgr.Attach(ctx, name, map[string]string{volumetypes.OptionRef: c.ID}); err != nil {
+	v, err := mgr.VolumeMgr.CreateAndAttach(ctx, name, c.HostConfig.VolumeDriver, opts, c.ID)
+	if err != nil {
 		logrus.Errorf("failed to attach volume(%s), err(%v)", name, err)
 		return "", "", errors.Wrap(err, "failed to attach volume")
 	}
@@ -53,7 +43,7 @@ func (mgr *ContainerManager) attachVolume(ctx context.Context, name string, c *C
 		return "", "", errors.Wrap(err, "failed to get volume mount path")
 	}
 
-	return mountPath, driver, nil
+	return mountPath, v.Driver(), nil
 }
 
 func (mgr *ContainerManager) generateMountPoints(ctx context.Context, c *Container) error {
@@ -90,13 +80,25 @@ func (mgr *ContainerManager) generateMountPoints(ctx context.Context, c *Contain
 		return errors.Wrap(err, "failed to get mount point from binds")
 	}
 
-	// 3. read MountPoints from image
+	// 3. read MountPoints from mounts
+	err = mgr.getMountPointFromMounts(ctx, c, volumeSet)
+	if err != nil {
+		return errors.Wrap(err, "failed to get mount point from mounts")
+	}
+
+	// 4. read MountPoints from tmpfs
+	err = mgr.getMountPointFromTmpfs(ctx, c)
+	if err != nil {
+		return errors.Wrap(err, "failed to get mount point from tmpfs")
+	}
+
+	// 5. read MountPoints from image
 	err = mgr.getMountPointFromImage(ctx, c, volumeSet)
 	if err != nil {
 		return errors.Wrap(err, "failed to get mount point from image")
 	}
 
-	// 4. read MountPoints from Config.Volumes
+	// 6. read MountPoints from Config.Volumes
 	err = mgr.getMountPointFromVolumes(ctx, c, volumeSet)
 	if err != nil {
 		return errors.Wrap(err, "failed to get mount point from volumes")
@@ -214,6 +216,113 @@ func (mgr *ContainerManager) getMountPointFromBinds(ctx context.Context, c *Cont
 	return nil
 }
 
+// getMountPointFromMounts resolves the mounts of HostConfig. The source of
+// bind must exist on host, and the volume is created if it doesn't exist.
+func (mgr *ContainerManager) getMountPointFromMounts(ctx context.Context, c *Container, volumeSet map[string]struct{}) error {
+	var err error
+
+	for _, m := range c.HostConfig.Mounts {
+		if opts.CheckDuplicateMountPoint(c.Mounts, m.Target) {
+			logrus.Warnf("duplicate mountpoint(%s) from mounts", m.Target)
+			continue
+		}
+
+		mp := &types.MountPoint{
+			Type:        m.Type,
+			Destination: m.Target,
+			RW:          !m.ReadOnly,
+		}
+
+		switch m.Type {
+		case "bind":
+			if _, err = os.Stat(m.Source); err != nil {
+				if os.IsNotExist(err) {
+					return errors.Wrapf(errtypes.ErrInvalidParam, "bind source path does not exist: %s", m.Source)
+				}
+				return errors.Wrapf(err, "failed to stat bind source path %s", m.Source)
+			}
+
+			mp.Source = m.Source
+			if m.BindOptions != nil {
+				mp.Propagation = m.BindOptions.Propagation
+			}
+		case "volume":
+			mp.Name = m.Source
+			mp.Named = m.Source != ""
+			if mp.Name == "" {
+				mp.Name = randomid.Generate()
+			}
+			mp.CopyData = m.VolumeOptions == nil || !m.VolumeOptions.NoCopy
+
+			if _, exist := volumeSet[mp.Name]; !exist {
+				if _, _, err = mgr.attachVolume(ctx, mp.Name, c); err != nil {
+					logrus.Errorf("failed to bind volume(%s), err(%v)", mp.Name, err)
+					return errors.Wrap(err, "failed to bind volume")
+				}
+				volumeSet[mp.Name] = struct{}{}
+			}
+
+			volume, err := mgr.VolumeMgr.Get(ctx, mp.Name)
+			if err != nil || volume == nil {
+				return errors.Wrapf(err, "failed to get volume(%s)", mp.Name)
+			}
+			mp.Driver = volume.Driver()
+
+			if mp.Source, err = mgr.VolumeMgr.Path(ctx, mp.Name); err != nil {
+				return err
+			}
+		case "tmpfs":
+			mp.Source = "tmpfs"
+		default:
+			return errors.Wrapf(errtypes.ErrInvalidParam, "invalid mount type: %s", m.Type)
+		}
+
+		c.Mounts = append(c.Mounts, mp)
+	}
+
+	return nil
+}
+
+// getMountPointFromTmpfs resolves the tmpfs mounts of HostConfig, which are
+// mounted by runtime with the options.
+func (mgr *ContainerManager) getMountPointFromTmpfs(ctx context.Context, c *Container) error {
+	if err := opts.ValidateTmpfs(c.HostConfig.Tmpfs); err != nil {
+		return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	dests := make([]string, 0, len(c.HostConfig.Tmpfs))
+	for dest := range c.HostConfig.Tmpfs {
+		dests = append(dests, dest)
+	}
+	sort.Strings(dests)
+
+	for _, dest := range dests {
+		if opts.CheckDuplicateMountPoint(c.Mounts, dest) {
+			logrus.Warnf("duplicate mountpoint(%s) from tmpfs", dest)
+			continue
+		}
+
+		rw := true
+		for _, o := range strings.Split(c.HostConfig.Tmpfs[dest], ",") {
+			switch o {
+			case "ro":
+				rw = false
+			case "rw":
+				rw = true
+			}
+		}
+
+		c.Mounts = append(c.Mounts, &types.MountPoint{
+			Type:        "tmpfs",
+			Source:      "tmpfs",
+			Destination: dest,
+			RW:          rw,
+		})
+	}
+
+	return nil
+}
+
 func (mgr *ContainerManager) getMountPointFromVolumes(ctx context.Context, c *Container, volumeSet map[string]struct{}) error {
 	var err error
 
@@ -396,6 +505,11 @@ func (mgr *ContainerManager) populateVolumes(ctx context.Context, c *Container)
 	}
 
 	for _, mp := range c.Mounts {
+		// tmpfs is mounted by runtime, it has no source on host.
+		if mp.Type == "tmpfs" {
+			continue
+		}
+
 		if _, err := os.Stat(mp.Source); err != nil {
 			// host directory bind into container.
 			if !os.IsNotExist(err) {
@@ -646,6 +760,20 @@ func (mgr *ContainerManager) setDiskQuota(ctx context.Context, c *Container, mou
 	return nil
 }
 
+// restoreVolumeMounts takes the references of volumes mounted by the alive
+// container again after pouchd restarts, the volumes still mounted aren't
+// mounted twice.
+func (mgr *ContainerManager) restoreVolumeMounts(ctx context.Context, c *Container) {
+	for _, mp := range c.Mounts {
+		if mp.Name == "" {
+			continue
+		}
+		if _, err := mgr.VolumeMgr.Mount(ctx, mp.Name, c.ID); err != nil {
+			logrus.Errorf("failed to restore mount of volume(%s) for container(%s): %v", mp.Name, c.ID, err)
+		}
+	}
+}
+
 func (mgr *ContainerManager) detachVolumes(ctx context.Context, c *Container, remove bool) error {
 	for _, mount := range c.Mounts {
 		name := mount.Name
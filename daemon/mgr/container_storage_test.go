@@ -1,6 +1,7 @@
 package mgr
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -8,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSortMountPoint(t *testing.T) {
@@ -127,3 +131,23 @@ func TestCopyOwnership(t *testing.T) {
 		t.Fatalf("Gid %d is not equal to %d", sysInfo.Gid, uint32(300))
 	}
 }
+
+func TestGetMountPointFromTmpfs(t *testing.T) {
+	mgr := &ContainerManager{}
+	c := &Container{
+		HostConfig: &types.HostConfig{
+			Tmpfs: map[string]string{"/run": "", "/data": "ro,size=64m", "/tmp": ""},
+		},
+		Mounts: []*types.MountPoint{{Type: "bind", Source: "/tmp", Destination: "/tmp", RW: true}},
+	}
+
+	assert.NoError(t, mgr.getMountPointFromTmpfs(context.Background(), c))
+	assert.Equal(t, []*types.MountPoint{
+		{Type: "bind", Source: "/tmp", Destination: "/tmp", RW: true},
+		{Type: "tmpfs", Source: "tmpfs", Destination: "/data", RW: false},
+		{Type: "tmpfs", Source: "tmpfs", Destination: "/run", RW: true},
+	}, c.Mounts)
+
+	c.HostConfig.Tmpfs = map[string]string{"run": ""}
+	assert.True(t, errtypes.IsInvalidParam(mgr.getMountPointFromTmpfs(context.Background(), c)))
+}
@@ -9,15 +9,18 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/cri/stream/remotecommand"
 	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/oci"
 	"github.com/alibaba/pouch/pkg/meta"
 	"github.com/alibaba/pouch/pkg/utils"
 
 	"github.com/containerd/containerd/mount"
+	"github.com/docker/docker/pkg/signal"
 	"github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sirupsen/logrus"
 )
@@ -47,6 +50,12 @@ var (
 	// MemorySwappinessWarn is warning for flag --memory-swappiness
 	MemorySwappinessWarn = "Current Kernel does not support memory swappiness , discard --memory-swappiness"
 
+	// MemoryReservationWarn is warning for flag --memory-reservation
+	MemoryReservationWarn = "Current Kernel does not support memory soft limit, discard --memory-reservation"
+
+	// KernelMemoryWarn is warning for flag --kernel-memory
+	KernelMemoryWarn = "Current Kernel does not support kernel memory limit, discard --kernel-memory"
+
 	//OOMKillWarn is warning for flag --oom-kill-disable
 	OOMKillWarn = "Current Kernel does not support disable oom kill, discard --oom-kill-disable"
 
@@ -62,6 +71,9 @@ var (
 	// CPUQuotaWarn is warning for flag --cpu-quota
 	CPUQuotaWarn = "Current Kernel does not support cpu quota, discard --cpu-quota"
 
+	// CPUsWarn is warning for flag --cpus
+	CPUsWarn = "Current Kernel does not support cpu quota, discard --cpus"
+
 	// CPUPeriodWarn is warning for flag --cpu-period
 	CPUPeriodWarn = "Current Kernel does not support cpu period, discard --cpu-period"
 
@@ -91,6 +103,9 @@ const (
 	// DefaultStopTimeout is the timeout (in seconds) for the syscall signal used to stop a container.
 	DefaultStopTimeout = 10
 
+	// DefaultShmSize is the default size (in bytes) of /dev/shm in container.
+	DefaultShmSize int64 = 64 * 1024 * 1024
+
 	// RuntimeDir is specified name keeps runtime path script.
 	RuntimeDir = "runtimes"
 )
@@ -157,6 +172,9 @@ type ContainerListOption struct {
 	All        bool
 	Filter     map[string][]string
 	FilterFunc ContainerFilter
+	// Limit returns the last created containers including non-running
+	// ones if it is greater than 0.
+	Limit int
 }
 
 // ContainerStatsConfig contains all configs on stats interface.
@@ -269,6 +287,17 @@ type Container struct {
 
 	// SnapshotID specify id of the snapshot that container using.
 	SnapshotID string
+
+	// restartMgr supervises the restarts by restart policy.
+	restartMgr *restartManager
+
+	// healthStop is closed to stop the health monitor, it is nil if the
+	// container isn't monitored.
+	healthStop chan struct{}
+
+	// exitCh is closed when the container exits or is stopped, it is nil
+	// if nobody waits for the exit.
+	exitCh chan struct{}
 }
 
 // Key returns container's id.
@@ -299,6 +328,25 @@ func (c *Container) StopTimeout() int64 {
 	return DefaultStopTimeout
 }
 
+// StopSignal returns the signal used to stop the container, which is
+// SIGTERM if the stop signal isn't set or invalid.
+func (c *Container) StopSignal() syscall.Signal {
+	if c.Config.StopSignal != "" {
+		sig, err := signal.ParseSignal(c.Config.StopSignal)
+		if err == nil {
+			return sig
+		}
+		logrus.Warnf("invalid stop signal %s of container %s, use SIGTERM instead: %v", c.Config.StopSignal, c.ID, err)
+	}
+	return syscall.SIGTERM
+}
+
+// EffectiveCapabilities returns the capabilities of container process, which
+// are the same as the ones set in the runtime spec.
+func (c *Container) EffectiveCapabilities() ([]string, error) {
+	return effectiveCapabilities(c.HostConfig, oci.NewDefaultSpec().Process.Capabilities.Effective)
+}
+
 func (c *Container) merge(getconfig func() (v1.ImageConfig, error)) error {
 	imageConf, err := getconfig()
 	if err != nil {
@@ -306,8 +354,14 @@ func (c *Container) merge(getconfig func() (v1.ImageConfig, error)) error {
 	}
 
 	// If user specify the Entrypoint, no need to merge image's configuration.
-	// Otherwise use the image's configuration to fill it.
-	if len(c.Config.Entrypoint) == 0 {
+	// Otherwise use the image's configuration to fill it. The Entrypoint
+	// [""] resets the image's entrypoint, but the image's Cmd is still used.
+	if len(c.Config.Entrypoint) == 1 && c.Config.Entrypoint[0] == "" {
+		c.Config.Entrypoint = nil
+		if len(c.Config.Cmd) == 0 {
+			c.Config.Cmd = imageConf.Cmd
+		}
+	} else if len(c.Config.Entrypoint) == 0 {
 		if len(c.Config.Cmd) == 0 {
 			c.Config.Cmd = imageConf.Cmd
 		}
@@ -392,7 +446,14 @@ func (c *Container) FormatStatus() (string, error) {
 
 		status = "Up " + startAt
 		if c.State.Status == types.StatusPaused {
-			status += "(paused)"
+			status += " (Paused)"
+		} else if c.State.Health != nil {
+			switch c.State.Health.Status {
+			case healthStarting:
+				status += " (health: starting)"
+			case healthy, unhealthy:
+				status += " (" + c.State.Health.Status + ")"
+			}
 		}
 
 	case types.StatusStopped, types.StatusExited:
@@ -409,10 +470,10 @@ func (c *Container) FormatStatus() (string, error) {
 		//FIXME: if stop status is needed ?
 		exitCode := c.State.ExitCode
 		if c.State.Status == types.StatusStopped {
-			status = fmt.Sprintf("Stopped (%d) %s", exitCode, finishAt)
+			status = fmt.Sprintf("Stopped (%d) %s ago", exitCode, finishAt)
 		}
 		if c.State.Status == types.StatusExited {
-			status = fmt.Sprintf("Exited (%d) %s", exitCode, finishAt)
+			status = fmt.Sprintf("Exited (%d) %s ago", exitCode, finishAt)
 		}
 	}
 
@@ -450,7 +511,7 @@ func (c *Container) SetSnapshotterMeta(mounts []mount.Mount) {
 	}
 
 	c.Snapshotter = &types.SnapshotterData{
-		Name: ctrd.CurrentSnapshotterName(context.TODO()),
+		Name: ctrd.CurrentSnapshotterName(ctrd.WithSnapshotter(context.TODO(), c.Config.Snapshotter)),
 		Data: data,
 	}
 }
@@ -520,3 +581,32 @@ func (p ContainerRestartPolicy) IsNone() bool {
 func (p ContainerRestartPolicy) IsAlways() bool {
 	return p.Name == "always"
 }
+
+// IsUnlessStopped returns the container need to be restarted unless it is
+// stopped by API.
+func (p ContainerRestartPolicy) IsUnlessStopped() bool {
+	return p.Name == "unless-stopped"
+}
+
+// IsOnFailure returns the container need to be restarted only when it exits
+// with non-zero code.
+func (p ContainerRestartPolicy) IsOnFailure() bool {
+	return p.Name == "on-failure"
+}
+
+// ShouldRestart returns whether the container should be restarted by the
+// policy, based on its exit code, the times it has been restarted and whether
+// it is stopped by API.
+func (p ContainerRestartPolicy) ShouldRestart(exitCode, restartCount int64, manuallyStopped bool) bool {
+	if manuallyStopped {
+		return false
+	}
+
+	switch {
+	case p.IsAlways(), p.IsUnlessStopped():
+		return true
+	case p.IsOnFailure():
+		return exitCode != 0 && (p.MaximumRetryCount == 0 || restartCount < p.MaximumRetryCount)
+	}
+	return false
+}
@@ -1,14 +1,19 @@
 package mgr
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
 	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/containerd/containerd/mount"
 	"github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/stretchr/testify/assert"
@@ -43,7 +48,7 @@ func TestContainer_FormatStatus(t *testing.T) {
 					ExitCode:   0,
 				},
 			},
-			expected: "Exited (0) 1 hour",
+			expected: "Exited (0) 1 hour ago",
 			err:      nil,
 		},
 		{
@@ -55,7 +60,7 @@ func TestContainer_FormatStatus(t *testing.T) {
 					ExitCode:   1,
 				},
 			},
-			expected: "Stopped (1) 1 minute",
+			expected: "Stopped (1) 1 minute ago",
 			err:      nil,
 		},
 		{
@@ -77,7 +82,31 @@ func TestContainer_FormatStatus(t *testing.T) {
 					StartedAt: time.Now().Add(0 - utils.Minute*2).UTC().Format(utils.TimeLayout),
 				},
 			},
-			expected: "Up 2 minutes(paused)",
+			expected: "Up 2 minutes (Paused)",
+			err:      nil,
+		},
+		{
+			name: "Healthy",
+			input: &Container{
+				State: &types.ContainerState{
+					Status:    types.StatusRunning,
+					StartedAt: time.Now().Add(0 - utils.Minute).UTC().Format(utils.TimeLayout),
+					Health:    &types.Health{Status: healthy},
+				},
+			},
+			expected: "Up 1 minute (healthy)",
+			err:      nil,
+		},
+		{
+			name: "HealthStarting",
+			input: &Container{
+				State: &types.ContainerState{
+					Status:    types.StatusRunning,
+					StartedAt: time.Now().Add(0 - utils.Minute).UTC().Format(utils.TimeLayout),
+					Health:    &types.Health{Status: healthStarting},
+				},
+			},
+			expected: "Up 1 minute (health: starting)",
 			err:      nil,
 		},
 	} {
@@ -242,6 +271,26 @@ func TestMerge(t *testing.T) {
 				},
 			},
 		},
+		{
+			// test reset image entrypoint, the image cmd is still used
+			c: &Container{
+				Config: &types.ContainerConfig{
+					Entrypoint: []string{""},
+					WorkingDir: "/work",
+				},
+			},
+			image: v1.ImageConfig{
+				Cmd:        []string{"ia"},
+				Entrypoint: []string{"ib"},
+				WorkingDir: "/iwork",
+				User:       "iuser1",
+			},
+			expected: &types.ContainerConfig{
+				Cmd:        []string{"ia"},
+				WorkingDir: "/work",
+				User:       "iuser1",
+			},
+		},
 	} {
 		err := tc.c.merge(func() (v1.ImageConfig, error) {
 			return tc.image, nil
@@ -256,3 +305,42 @@ func TestMerge(t *testing.T) {
 		assert.Equal(true, ret, fmt.Sprintf("test %d fails\n %+v should equal with %+v\n", idx, tc.c.Config, tc.expected))
 	}
 }
+
+func TestContainerStopSignal(t *testing.T) {
+	for _, tc := range []struct {
+		stopSignal string
+		expected   syscall.Signal
+	}{
+		{stopSignal: "", expected: syscall.SIGTERM},
+		{stopSignal: "SIGINT", expected: syscall.SIGINT},
+		{stopSignal: "QUIT", expected: syscall.SIGQUIT},
+		{stopSignal: "9", expected: syscall.SIGKILL},
+		{stopSignal: "NOSUCHSIGNAL", expected: syscall.SIGTERM},
+	} {
+		c := &Container{Config: &types.ContainerConfig{StopSignal: tc.stopSignal}}
+		assert.Equal(t, tc.expected, c.StopSignal(), tc.stopSignal)
+	}
+}
+
+func TestSetSnapshotterMeta(t *testing.T) {
+	mounts := []mount.Mount{{
+		Type:    "overlay",
+		Source:  "overlay",
+		Options: []string{"workdir=/snapshots/2/work", "upperdir=/snapshots/2/fs", "lowerdir=/snapshots/1/fs"},
+	}}
+
+	// the container uses the snapshotter of pouchd.
+	c := &Container{Config: &types.ContainerConfig{}}
+	c.SetSnapshotterMeta(mounts)
+	assert.Equal(t, ctrd.CurrentSnapshotterName(context.TODO()), c.Snapshotter.Name)
+	assert.Equal(t, map[string]string{
+		"UpperDir": "/snapshots/2/fs",
+		"LowerDir": "/snapshots/1/fs",
+		"WorkDir":  "/snapshots/2/work",
+	}, c.Snapshotter.Data)
+
+	// the container uses the snapshotter chosen by plugin.
+	c = &Container{Config: &types.ContainerConfig{Snapshotter: "btrfs"}}
+	c.SetSnapshotterMeta(mounts)
+	assert.Equal(t, "btrfs", c.Snapshotter.Name)
+}
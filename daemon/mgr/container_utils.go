@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -68,6 +71,31 @@ func (mgr *ContainerManager) container(nameOrPrefix string) (*Container, error)
 	return nil, errors.Wrapf(errtypes.ErrNotfound, "container %s", nameOrPrefix)
 }
 
+// validContainerNamePattern is the pattern of container name.
+var validContainerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// validateContainerName checks the name only contains the allowed characters.
+func validateContainerName(name string) error {
+	if !validContainerNamePattern.MatchString(name) {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "invalid container name %s, must match %s", name, validContainerNamePattern.String())
+	}
+	return nil
+}
+
+// checkNameConflict returns error with the ID of container which already
+// uses the name.
+func (mgr *ContainerManager) checkNameConflict(name string) error {
+	id, ok := mgr.NameToID.Get(name).String()
+	if !ok {
+		return nil
+	}
+	return nameConflictError(name, id)
+}
+
+func nameConflictError(name, id string) error {
+	return errors.Wrapf(errtypes.ErrAlreadyExisted, "container name %s is already in use by container %s", name, id)
+}
+
 // generateID generates an ID for newly created container. We must ensure that
 // this ID has not used yet.
 func (mgr *ContainerManager) generateID() (string, error) {
@@ -105,8 +133,18 @@ func (mgr *ContainerManager) generateName(id string) string {
 	return name
 }
 
-// getRuntime returns runtime real path.
-// TODO(huamin): do we need validate runtime is executable ?
+// runtimeNames returns the sorted names of runtimes registered to daemon.
+func (mgr *ContainerManager) runtimeNames() []string {
+	names := make([]string, 0, len(mgr.Config.Runtimes))
+	for name := range mgr.Config.Runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getRuntime returns runtime real path, the binary of runtime must be
+// executable, so that the container fails fast with a clear error.
 func (mgr *ContainerManager) getRuntime(runtime string) (string, error) {
 	r, exist := mgr.Config.Runtimes[runtime]
 	if !exist {
@@ -121,6 +159,10 @@ func (mgr *ContainerManager) getRuntime(runtime string) (string, error) {
 		rPath = r.Path
 	}
 
+	if _, err := exec.LookPath(rPath); err != nil {
+		return "", fmt.Errorf("failed to find binary %s of runtime %s: %v", rPath, runtime, err)
+	}
+
 	// if Runtime has args, use script path as runtime path.
 	if len(r.RuntimeArgs) > 0 {
 		rPath = filepath.Join(mgr.Config.HomeDir, RuntimeDir, runtime)
@@ -150,6 +192,7 @@ func (mgr *ContainerManager) getContainerSpec(c *Container) (*specs.Spec, error)
 func BuildContainerEndpoint(c *Container) *networktypes.Endpoint {
 	return &networktypes.Endpoint{
 		Owner:           c.ID,
+		ContainerName:   c.Name,
 		Hostname:        c.Config.Hostname,
 		Domainname:      c.Config.Domainname,
 		HostsPath:       c.HostsPath,
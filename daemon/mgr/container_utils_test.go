@@ -1,12 +1,17 @@
 package mgr
 
 import (
+	"io/ioutil"
+	"os"
 	"path"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/config"
 	"github.com/alibaba/pouch/pkg/collect"
+	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/meta"
 	"github.com/alibaba/pouch/pkg/utils"
 
@@ -79,6 +84,32 @@ func TestContainerManager_generateName(t *testing.T) {
 	assert.Equal(t, generatedName, "abcdef")
 }
 
+func TestContainerManager_checkNameConflict(t *testing.T) {
+	containerMgr := &ContainerManager{
+		NameToID: collect.NewSafeMap(),
+	}
+	assert.NoError(t, containerMgr.checkNameConflict("foo"))
+
+	id := "90719b5f9a455b3314a49e72e3ecb9962f215e0f90153aa8911882acf2ba2c84"
+	containerMgr.NameToID.Put("foo", id)
+
+	err := containerMgr.checkNameConflict("foo")
+	assert.True(t, errtypes.IsAlreadyExisted(err))
+	assert.Contains(t, err.Error(), id)
+	assert.NoError(t, containerMgr.checkNameConflict("bar"))
+}
+
+func Test_validateContainerName(t *testing.T) {
+	for _, name := range []string{"foo", "foo-bar", "foo_bar.1", "1foo", "Foo"} {
+		assert.NoError(t, validateContainerName(name), name)
+	}
+
+	for _, name := range []string{"", "f", "-foo", ".foo", "foo bar", "foo/bar", "foo:bar"} {
+		err := validateContainerName(name)
+		assert.True(t, errtypes.IsInvalidParam(err), name)
+	}
+}
+
 func Test_parseSecurityOpt(t *testing.T) {
 	type args struct {
 		meta        *Container
@@ -286,3 +317,79 @@ func Test_mergeEnvSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerManager_getRuntime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "get-runtime")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	binary := filepath.Join(dir, "kata-runtime")
+	assert.NoError(t, ioutil.WriteFile(binary, []byte("#!/bin/sh\n"), 0755))
+
+	containerMgr := &ContainerManager{
+		Config: &config.Config{
+			HomeDir: dir,
+			Runtimes: map[string]types.Runtime{
+				"kata":    {Path: binary},
+				"kata-db": {Path: binary, RuntimeArgs: []string{"--debug"}},
+				"missing": {Path: filepath.Join(dir, "missing")},
+			},
+		},
+	}
+	assert.Equal(t, []string{"kata", "kata-db", "missing"}, containerMgr.runtimeNames())
+
+	path, err := containerMgr.getRuntime("kata")
+	assert.NoError(t, err)
+	assert.Equal(t, binary, path)
+
+	// the script with args is used
+	path, err = containerMgr.getRuntime("kata-db")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, RuntimeDir, "kata-db"), path)
+
+	_, err = containerMgr.getRuntime("missing")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to find binary")
+
+	_, err = containerMgr.getRuntime("unknown")
+	assert.Error(t, err)
+}
+
+func TestGetDefaultLogConfigIfMissing(t *testing.T) {
+	mgr := &ContainerManager{
+		Config: &config.Config{
+			DefaultLogConfig: types.LogConfig{
+				LogDriver: "fluentd",
+				LogOpts: map[string]string{
+					"fluentd-address": "10.0.0.1:24224",
+					"tag":             "{{.ID}}",
+				},
+			},
+		},
+	}
+
+	// the default log config is used if missing.
+	logConfig := mgr.getDefaultLogConfigIfMissing(nil)
+	assert.Equal(t, "fluentd", logConfig.LogDriver)
+	assert.Equal(t, mgr.Config.DefaultLogConfig.LogOpts, logConfig.LogOpts)
+
+	// the log options of container are merged with the default ones.
+	logConfig = mgr.getDefaultLogConfigIfMissing(&types.LogConfig{
+		LogOpts: map[string]string{"tag": "{{.Name}}"},
+	})
+	assert.Equal(t, "fluentd", logConfig.LogDriver)
+	assert.Equal(t, map[string]string{
+		"fluentd-address": "10.0.0.1:24224",
+		"tag":             "{{.Name}}",
+	}, logConfig.LogOpts)
+
+	// the default log options aren't used by the other log driver.
+	logConfig = mgr.getDefaultLogConfigIfMissing(&types.LogConfig{
+		LogDriver: "json-file",
+		LogOpts:   map[string]string{"max-size": "10m"},
+	})
+	assert.Equal(t, map[string]string{"max-size": "10m"}, logConfig.LogOpts)
+
+	// the default log options must not be changed.
+	assert.Equal(t, "{{.ID}}", mgr.Config.DefaultLogConfig.LogOpts["tag"])
+}
@@ -4,14 +4,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
 	"github.com/alibaba/pouch/daemon/logger"
 	"github.com/alibaba/pouch/daemon/logger/jsonfile"
-	"github.com/alibaba/pouch/daemon/logger/syslog"
+	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/system"
+	"github.com/alibaba/pouch/pkg/user"
 	"github.com/alibaba/pouch/pkg/utils"
 	"github.com/alibaba/pouch/storage/quota"
 
@@ -56,6 +61,10 @@ func (mgr *ContainerManager) validateConfig(c *Container, update bool) ([]string
 	if err != nil {
 		return nil, err
 	}
+
+	if err := validateDevices(&hostConfig.Resources); err != nil {
+		return nil, err
+	}
 	// validates nvidia config
 	if err := validateNvidiaConfig(&hostConfig.Resources); err != nil {
 		return warnings, err
@@ -70,6 +79,53 @@ func (mgr *ContainerManager) validateConfig(c *Container, update bool) ([]string
 		return warnings, fmt.Errorf("shm-size %d should greater than 0", *hostConfig.ShmSize)
 	}
 
+	if hostConfig.RestartPolicy != nil {
+		if err := opts.ValidateRestartPolicy(hostConfig.RestartPolicy); err != nil {
+			return warnings, err
+		}
+	}
+
+	if err := validateAutoRemove(hostConfig.AutoRemove, hostConfig.RestartPolicy); err != nil {
+		return warnings, err
+	}
+
+	if err := opts.ValidateHealthcheck(c.Config.Healthcheck); err != nil {
+		return warnings, err
+	}
+
+	if err := opts.ValidateMounts(hostConfig.Mounts); err != nil {
+		return warnings, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	if hostConfig.CapAdd, err = normalizeCapabilities(hostConfig.CapAdd); err != nil {
+		return warnings, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	if hostConfig.CapDrop, err = normalizeCapabilities(hostConfig.CapDrop); err != nil {
+		return warnings, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	if err := opts.ValidateExtraHosts(hostConfig.ExtraHosts); err != nil {
+		return warnings, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	if err := opts.ValidateDNS(hostConfig.DNS); err != nil {
+		return warnings, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	if err := opts.ValidateUlimits(hostConfig.Ulimits); err != nil {
+		return warnings, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	if err := validateSysctls(hostConfig); err != nil {
+		return warnings, err
+	}
+
+	// a read only rootfs without writable /tmp breaks most of applications.
+	if hostConfig.ReadonlyRootfs && !opts.CheckDuplicateMountPoint(c.Mounts, "/tmp") {
+		warnings = append(warnings, "The root filesystem is read only and /tmp is not writable, use --tmpfs /tmp or a volume of /tmp if the application writes temporary files")
+	}
+
 	// validate log config
 	if err := mgr.validateLogConfig(c); err != nil {
 		return warnings, err
@@ -78,7 +134,7 @@ func (mgr *ContainerManager) validateConfig(c *Container, update bool) ([]string
 	// validate seccomp, apparmor security parameters
 	sysInfo := system.NewInfo()
 	if !sysInfo.Seccomp {
-		if c.SeccompProfile != "" || c.SeccompProfile != ProfileUnconfined {
+		if c.SeccompProfile != "" && c.SeccompProfile != ProfileUnconfined {
 			warnings = append(warnings, fmt.Sprintf("Current Kernel does not support seccomp, discard --security-opt seccomp=%s", c.SeccompProfile))
 		}
 		// always set SeccompProfile to unconfined if kernel not support seccomp
@@ -90,11 +146,82 @@ func (mgr *ContainerManager) validateConfig(c *Container, update bool) ([]string
 			warnings = append(warnings, fmt.Sprintf("Current Kernel does not support apparmor, discard --security-opt apparmor=%s", c.AppArmorProfile))
 		}
 		c.AppArmorProfile = ""
+	} else if c.HostConfig.Privileged {
+		c.AppArmorProfile = ProfileUnconfined
+	}
+
+	if err := validateSeccompProfile(c); err != nil {
+		return warnings, err
 	}
 
 	return warnings, nil
 }
 
+// validateSysctls validates the sysctls of container are namespaced, and the
+// namespaces isolating them are not shared with the host.
+func validateSysctls(hostConfig *types.HostConfig) error {
+	if err := opts.ValidateSysctls(hostConfig.Sysctls); err != nil {
+		return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	keys := make([]string, 0, len(hostConfig.Sysctls))
+	for k := range hostConfig.Sysctls {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if strings.HasPrefix(k, "net.") {
+			if IsHost(hostConfig.NetworkMode) {
+				return errors.Wrapf(errtypes.ErrInvalidParam, "invalid sysctl %s: it can't be set in the network namespace of host", k)
+			}
+			continue
+		}
+		if isHost(hostConfig.IpcMode) {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "invalid sysctl %s: it can't be set in the ipc namespace of host", k)
+		}
+	}
+	return nil
+}
+
+// validateSeccompProfile verifies the seccomp profile can be applied, and
+// sets the profile to the one applied to the container: unconfined if
+// privileged, the default one of pouch if not set, or the custom profile.
+func validateSeccompProfile(c *Container) error {
+	if c.HostConfig.Privileged {
+		c.SeccompProfile = ProfileUnconfined
+		return nil
+	}
+
+	switch c.SeccompProfile {
+	case ProfileUnconfined:
+		return nil
+	case "":
+		if !IsSeccompEnable() {
+			c.SeccompProfile = ProfileUnconfined
+			return nil
+		}
+		c.SeccompProfile = ProfilePouchDefault
+		return nil
+	}
+
+	if !IsSeccompEnable() {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "seccomp is not supported by pouch, can not set seccomp profile %s", c.SeccompProfile)
+	}
+	if c.SeccompProfile == ProfilePouchDefault {
+		return nil
+	}
+
+	capabilities, err := c.EffectiveCapabilities()
+	if err != nil {
+		return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+	if _, err := loadSeccompProfile(c.SeccompProfile, capabilities); err != nil {
+		return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+	return nil
+}
+
 // validateDiskQuota is used to validate disk quota config
 func (mgr *ContainerManager) validateDiskQuota(config *types.ContainerCreateConfig) error {
 	if config == nil {
@@ -166,6 +293,17 @@ func validateRichMode(c *Container) error {
 
 // validateResource verifies cgroup resources
 func validateResource(r *types.Resources, update bool) ([]string, error) {
+	if err := validateNanoCPUs(r); err != nil {
+		return nil, err
+	}
+
+	if r.MemoryReservation < 0 || (r.MemoryReservation > 0 && r.MemoryReservation < MinMemory) {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, "Minimal memory reservation should greater than 4M")
+	}
+	if r.Memory > 0 && r.MemoryReservation > r.Memory {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, "Minimum memory limit can not be less than memory reservation limit")
+	}
+
 	cgroupInfo := system.NewCgroupInfo()
 	if cgroupInfo == nil {
 		return nil, nil
@@ -199,6 +337,16 @@ func validateResource(r *types.Resources, update bool) ([]string, error) {
 		if r.Memory > 0 && r.MemorySwap > 0 && r.MemorySwap < 2*r.Memory {
 			warnings = append(warnings, "You should typically size your swap space to approximately 2x main memory for systems with less than 2GB of RAM")
 		}
+		if r.MemoryReservation > 0 && !cgroupInfo.Memory.MemoryReservation {
+			logrus.Warn(MemoryReservationWarn)
+			warnings = append(warnings, MemoryReservationWarn)
+			r.MemoryReservation = 0
+		}
+		if r.KernelMemory > 0 && !cgroupInfo.Memory.KernelMemory {
+			logrus.Warn(KernelMemoryWarn)
+			warnings = append(warnings, KernelMemoryWarn)
+			r.KernelMemory = 0
+		}
 		if r.MemorySwappiness != nil && !cgroupInfo.Memory.MemorySwappiness {
 			logrus.Warn(MemorySwappinessWarn)
 			warnings = append(warnings, MemorySwappinessWarn)
@@ -236,6 +384,11 @@ func validateResource(r *types.Resources, update bool) ([]string, error) {
 			warnings = append(warnings, CPUQuotaWarn)
 			r.CPUQuota = 0
 		}
+		if r.NanoCpus > 0 && !cgroupInfo.CPU.CPUQuota {
+			logrus.Warn(CPUsWarn)
+			warnings = append(warnings, CPUsWarn)
+			r.NanoCpus = 0
+		}
 		// cpu.cfs_quota_us can accept value less than 0, we allow -1 and > 1000
 		if r.CPUQuota > 0 && r.CPUQuota < 1000 {
 			return warnings, fmt.Errorf("CPU cfs quota should be greater than 1ms(1000)")
@@ -296,6 +449,58 @@ func validateResource(r *types.Resources, update bool) ([]string, error) {
 	return warnings, nil
 }
 
+// validateDevices verifies the host devices exist and the device cgroup
+// rules are well formed, so that the container fails at create instead of start.
+func validateDevices(r *types.Resources) error {
+	for _, d := range r.Devices {
+		if !opts.ValidateDeviceMode(d.CgroupPermissions) {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "%s invalid device mode: %s", d.PathOnHost, d.CgroupPermissions)
+		}
+		if _, _, err := devicesFromPath(d.PathOnHost, d.PathInContainer, d.CgroupPermissions); err != nil {
+			return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+		}
+	}
+
+	for _, rule := range r.DeviceCgroupRules {
+		if _, err := opts.ParseDeviceCgroupRule(rule); err != nil {
+			return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+		}
+	}
+
+	if _, err := ctrd.GetWeightDevice(r.BlkioWeightDevice); err != nil {
+		return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+	for _, devs := range [][]*types.ThrottleDevice{
+		r.BlkioDeviceReadBps, r.BlkioDeviceWriteBps,
+		r.BlkioDeviceReadIOps, r.BlkioDeviceWriteIOps,
+	} {
+		if _, err := ctrd.GetThrottleDevice(devs); err != nil {
+			return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+		}
+	}
+	return nil
+}
+
+// validateNanoCPUs validates the NanoCpus is in range of the cpus on host,
+// and it can't be used with CPUQuota or CPUPeriod, since both of them
+// are converted into cfs quota and period.
+func validateNanoCPUs(r *types.Resources) error {
+	if r.NanoCpus == 0 {
+		return nil
+	}
+
+	if r.CPUQuota > 0 || r.CPUPeriod > 0 {
+		return errors.Wrap(errtypes.ErrInvalidParam, "Conflicting options: CPUs and CPU Period/Quota cannot both be set")
+	}
+
+	// the minimal cpus 0.01 is converted into the minimal cfs quota 1000.
+	ncpu := int64(runtime.NumCPU())
+	if r.NanoCpus < 1e7 || r.NanoCpus > ncpu*1e9 {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "Range of CPUs is from 0.01 to %d.00, as there are only %d CPUs available", ncpu, ncpu)
+	}
+	return nil
+}
+
 // validateLogConfig is used to verify the correctness of log configuration.
 // TODO(fuwei): remove mgr from validateLogConfig
 func (mgr *ContainerManager) validateLogConfig(c *Container) error {
@@ -332,18 +537,17 @@ func (mgr *ContainerManager) validateLogConfig(c *Container) error {
 		}
 	}
 
-	switch logCfg.LogDriver {
-	case types.LogConfigLogDriverNone, types.LogConfigLogDriverJSONFile:
+	// none log driver accepts the options of json-file for compatibility.
+	if logCfg.LogDriver == types.LogConfigLogDriverNone {
 		return jsonfile.ValidateLogOpt(restOpts)
-	case types.LogConfigLogDriverSyslog:
-		info, err := mgr.convContainerToLoggerInfo(c)
-		if err != nil {
-			return err
-		}
-		return syslog.ValidateSyslogOption(info)
-	default:
-		return fmt.Errorf("not support (%v) log driver yet", logCfg.LogDriver)
 	}
+
+	info, err := mgr.convContainerToLoggerInfo(c)
+	if err != nil {
+		return err
+	}
+	info.LogConfig = restOpts
+	return logger.ValidateLogOpts(logCfg.LogDriver, info)
 }
 
 // validateNvidiaConfig
@@ -412,3 +616,42 @@ func validateNvidiaDevice(r *types.Resources) error {
 	}
 	return nil
 }
+
+// validateAutoRemove validates the container removed automatically has no
+// restart policy, since it is removed instead of restarted after it exits.
+func validateAutoRemove(autoRemove bool, policy *types.RestartPolicy) error {
+	if autoRemove && policy != nil && policy.Name != "" && policy.Name != "no" {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "can't use AutoRemove with restart policy %s", policy.Name)
+	}
+	return nil
+}
+
+// validateUser validates the user and the additional groups of container can
+// be resolved against the /etc/passwd and /etc/group of the container's rootfs.
+// It is skipped if the passwd file can't be found, the user is resolved again
+// when the container starts.
+func validateUser(c *Container) error {
+	var groups []string
+	if c.HostConfig != nil {
+		groups = c.HostConfig.GroupAdd
+	}
+	if (c.Config.User == "" && len(groups) == 0) || c.Snapshotter == nil {
+		return nil
+	}
+
+	passwdPath := c.GetSpecificBasePath(user.PasswdFile)
+	if passwdPath == "" {
+		return nil
+	}
+	groupPath := c.GetSpecificBasePath(user.GroupFile)
+
+	if _, _, _, err := user.Get(passwdPath, groupPath, c.Config.User, nil); err != nil {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "invalid user %s: %v", c.Config.User, err)
+	}
+	if len(groups) > 0 {
+		if _, _, _, err := user.Get(passwdPath, groupPath, c.Config.User, groups); err != nil {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "invalid group-add %v: %v", groups, err)
+		}
+	}
+	return nil
+}
@@ -1,9 +1,14 @@
 package mgr
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -110,3 +115,213 @@ func TestValidateNvidiaDriver(t *testing.T) {
 		assert.Equal(t, tc.errExpected, err)
 	}
 }
+
+func TestValidateAutoRemove(t *testing.T) {
+	for _, tc := range []struct {
+		autoRemove bool
+		policy     *types.RestartPolicy
+		valid      bool
+	}{
+		{autoRemove: true, policy: nil, valid: true},
+		{autoRemove: true, policy: &types.RestartPolicy{Name: "no"}, valid: true},
+		{autoRemove: true, policy: &types.RestartPolicy{Name: "always"}, valid: false},
+		{autoRemove: true, policy: &types.RestartPolicy{Name: "on-failure", MaximumRetryCount: 1}, valid: false},
+		{autoRemove: false, policy: &types.RestartPolicy{Name: "always"}, valid: true},
+	} {
+		err := validateAutoRemove(tc.autoRemove, tc.policy)
+		assert.Equal(t, tc.valid, err == nil, "%+v", tc)
+		if err != nil {
+			assert.True(t, errtypes.IsInvalidParam(err))
+		}
+	}
+}
+
+func TestValidateUser(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validate-user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "etc/passwd"), []byte("root:x:0:0:root:/root:/bin/sh\nfoo:x:1001:1001::/home/foo:/bin/sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "etc/group"), []byte("root:x:0:\nfoo:x:1001:\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		user  string
+		valid bool
+	}{
+		{user: "", valid: true},
+		{user: "foo", valid: true},
+		{user: "foo:root", valid: true},
+		{user: "2000:2000", valid: true},
+		{user: "nobody", valid: false},
+		{user: "foo:nogroup", valid: false},
+	} {
+		c := &Container{
+			Config:      &types.ContainerConfig{User: tc.user},
+			Snapshotter: &types.SnapshotterData{Data: map[string]string{"LowerDir": dir}},
+		}
+		err := validateUser(c)
+		assert.Equal(t, tc.valid, err == nil, "%+v", tc)
+		if err != nil {
+			assert.True(t, errtypes.IsInvalidParam(err))
+		}
+	}
+
+	for _, tc := range []struct {
+		user   string
+		groups []string
+		valid  bool
+	}{
+		{groups: []string{"foo", "0", "2000"}, valid: true},
+		{user: "foo", groups: []string{"root"}, valid: true},
+		{groups: []string{"nogroup"}, valid: false},
+		{user: "foo", groups: []string{"foo", "nogroup"}, valid: false},
+	} {
+		c := &Container{
+			Config:      &types.ContainerConfig{User: tc.user},
+			HostConfig:  &types.HostConfig{GroupAdd: tc.groups},
+			Snapshotter: &types.SnapshotterData{Data: map[string]string{"LowerDir": dir}},
+		}
+		err := validateUser(c)
+		assert.Equal(t, tc.valid, err == nil, "%+v", tc)
+		if err != nil {
+			assert.True(t, errtypes.IsInvalidParam(err))
+		}
+	}
+
+	// skipped if the passwd file can't be found
+	c := &Container{
+		Config:      &types.ContainerConfig{User: "nobody"},
+		Snapshotter: &types.SnapshotterData{Data: map[string]string{}},
+	}
+	assert.NoError(t, validateUser(c))
+}
+
+func TestValidateSysctls(t *testing.T) {
+	for _, tc := range []struct {
+		hostConfig types.HostConfig
+		err        string
+	}{
+		{hostConfig: types.HostConfig{}},
+		{hostConfig: types.HostConfig{Sysctls: map[string]string{"net.ipv4.ip_forward": "1", "kernel.shmmax": "1"}}},
+		{hostConfig: types.HostConfig{Sysctls: map[string]string{"kernel.panic": "1"}}, err: "not namespaced"},
+		{hostConfig: types.HostConfig{Sysctls: map[string]string{"net.core.somaxconn": "1024"}, NetworkMode: "host"}, err: "network namespace of host"},
+		{hostConfig: types.HostConfig{Sysctls: map[string]string{"net.core.somaxconn": "1024"}, IpcMode: "host"}},
+		{hostConfig: types.HostConfig{Sysctls: map[string]string{"kernel.msgmax": "1"}, IpcMode: "host"}, err: "ipc namespace of host"},
+	} {
+		err := validateSysctls(&tc.hostConfig)
+		if tc.err == "" {
+			assert.NoError(t, err, "%+v", tc.hostConfig)
+			continue
+		}
+		assert.Error(t, err, "%+v", tc.hostConfig)
+		assert.True(t, errtypes.IsInvalidParam(err))
+		assert.Contains(t, err.Error(), tc.err)
+	}
+}
+
+func TestValidateNanoCPUs(t *testing.T) {
+	ncpu := int64(runtime.NumCPU())
+
+	for _, tc := range []struct {
+		r     types.Resources
+		valid bool
+	}{
+		{r: types.Resources{}, valid: true},
+		{r: types.Resources{NanoCpus: 1e7}, valid: true},
+		{r: types.Resources{NanoCpus: ncpu * 1e9}, valid: true},
+		{r: types.Resources{NanoCpus: 1e6}, valid: false},
+		{r: types.Resources{NanoCpus: ncpu*1e9 + 1}, valid: false},
+		{r: types.Resources{NanoCpus: 1e9, CPUQuota: 20000}, valid: false},
+		{r: types.Resources{NanoCpus: 1e9, CPUPeriod: 50000}, valid: false},
+		{r: types.Resources{CPUQuota: 20000, CPUPeriod: 50000}, valid: true},
+	} {
+		err := validateNanoCPUs(&tc.r)
+		assert.Equal(t, tc.valid, err == nil, "%+v", tc.r)
+		if err != nil {
+			assert.True(t, errtypes.IsInvalidParam(err))
+		}
+	}
+}
+
+func TestValidateDevices(t *testing.T) {
+	for _, tc := range []struct {
+		r   types.Resources
+		err string
+	}{
+		{r: types.Resources{}},
+		{r: types.Resources{Devices: []*types.DeviceMapping{{PathOnHost: "/dev/null", PathInContainer: "/dev/null", CgroupPermissions: "rwm"}}}},
+		{r: types.Resources{DeviceCgroupRules: []string{"c 13:* rwm", "b 8:0 r"}}},
+		{r: types.Resources{Devices: []*types.DeviceMapping{{PathOnHost: "/dev/null", PathInContainer: "/dev/null", CgroupPermissions: "rwx"}}}, err: "invalid device mode"},
+		{r: types.Resources{Devices: []*types.DeviceMapping{{PathOnHost: "/dev/nonexistent", PathInContainer: "/dev/foo", CgroupPermissions: "rwm"}}}, err: "/dev/nonexistent"},
+		{r: types.Resources{DeviceCgroupRules: []string{"c 13 rwm"}}, err: "invalid device cgroup rule"},
+		{r: types.Resources{BlkioDeviceReadBps: []*types.ThrottleDevice{{Path: "/dev/nonexistent", Rate: 1024}}}, err: "/dev/nonexistent"},
+	} {
+		err := validateDevices(&tc.r)
+		if tc.err == "" {
+			assert.NoError(t, err, "%+v", tc.r)
+			continue
+		}
+		assert.Error(t, err, "%+v", tc.r)
+		assert.True(t, errtypes.IsInvalidParam(err))
+		assert.Contains(t, err.Error(), tc.err)
+	}
+}
+
+func TestValidateResourceMemoryReservation(t *testing.T) {
+	for _, tc := range []struct {
+		r     types.Resources
+		valid bool
+	}{
+		{r: types.Resources{Memory: 512 << 20, MemoryReservation: 256 << 20}, valid: true},
+		{r: types.Resources{MemoryReservation: 256 << 20}, valid: true},
+		{r: types.Resources{Memory: 256 << 20, MemoryReservation: 512 << 20}, valid: false},
+		{r: types.Resources{MemoryReservation: 1 << 20}, valid: false},
+	} {
+		_, err := validateResource(&tc.r, false)
+		assert.Equal(t, tc.valid, err == nil, "%+v", tc.r)
+	}
+}
+
+func TestUpdateContainerResourcesCPUs(t *testing.T) {
+	mgr := &ContainerManager{}
+
+	c := &Container{HostConfig: &types.HostConfig{Resources: types.Resources{CPUQuota: 20000}}}
+	assert.Error(t, mgr.updateContainerResources(c, types.Resources{NanoCpus: 1e9}))
+
+	c = &Container{HostConfig: &types.HostConfig{Resources: types.Resources{NanoCpus: 1e9}}}
+	assert.Error(t, mgr.updateContainerResources(c, types.Resources{CPUPeriod: 50000}))
+	assert.NoError(t, mgr.updateContainerResources(c, types.Resources{NanoCpus: 2e9}))
+	assert.Equal(t, int64(2e9), c.HostConfig.NanoCpus)
+}
+
+func TestValidateSeccompProfile(t *testing.T) {
+	c := &Container{HostConfig: &types.HostConfig{Privileged: true}, SeccompProfile: "/path/to/profile.json"}
+	assert.NoError(t, validateSeccompProfile(c))
+	assert.Equal(t, ProfileUnconfined, c.SeccompProfile)
+
+	c = &Container{HostConfig: &types.HostConfig{}, SeccompProfile: ProfileUnconfined}
+	assert.NoError(t, validateSeccompProfile(c))
+	assert.Equal(t, ProfileUnconfined, c.SeccompProfile)
+
+	c = &Container{HostConfig: &types.HostConfig{}}
+	assert.NoError(t, validateSeccompProfile(c))
+	if IsSeccompEnable() {
+		assert.Equal(t, ProfilePouchDefault, c.SeccompProfile)
+	} else {
+		assert.Equal(t, ProfileUnconfined, c.SeccompProfile)
+	}
+
+	c = &Container{HostConfig: &types.HostConfig{}, SeccompProfile: "/nonexistent/profile.json"}
+	err := validateSeccompProfile(c)
+	assert.Error(t, err)
+	assert.True(t, errtypes.IsInvalidParam(err))
+}
@@ -0,0 +1,205 @@
+package mgr
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/alibaba/pouch/apis/filters"
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/storage/volume/types"
+
+	"github.com/opencontainers/image-spec/identity"
+	"github.com/sirupsen/logrus"
+)
+
+// diskUsageWorkers is the max number of sizes computed at the same time,
+// computing the size of container layer or volume directory walks the whole
+// directory, so that it shouldn't be done one by one or all at once.
+const diskUsageWorkers = 16
+
+// DiskUsage returns the disk space used by each image, and the total size of
+// the distinct layers. The size of layer is the usage of its unpacked snapshot,
+// the size in content store is used if the image hasn't been unpacked. The
+// containers is the number of containers using the image, keyed by image ID.
+func (mgr *ImageManager) DiskUsage(ctx context.Context, containers map[string]int64) ([]*apitypes.ImageDiskUsage, int64, error) {
+	infos := mgr.localStore.ListCtrdImageInfo()
+
+	// the snapshot of layer is named by chain ID, which is shared by the
+	// images built on the same layers.
+	var (
+		imageLayers = make([][]string, len(infos))
+		layerRefs   = make(map[string]int)
+		layers      []string
+	)
+	for i, info := range infos {
+		diffIDs := info.OCISpec.RootFS.DiffIDs
+		for j := range diffIDs {
+			chainID := identity.ChainID(diffIDs[:j+1]).String()
+			if layerRefs[chainID] == 0 {
+				layers = append(layers, chainID)
+			}
+			layerRefs[chainID]++
+			imageLayers[i] = append(imageLayers[i], chainID)
+		}
+	}
+
+	var (
+		sizes    = make([]int64, len(layers))
+		unpacked = make([]bool, len(layers))
+	)
+	runWithWorkers(len(layers), diskUsageWorkers, func(i int) {
+		usage, err := mgr.client.GetSnapshotUsage(ctx, layers[i])
+		if err != nil {
+			logrus.Debugf("failed to get usage of layer %s: %v", layers[i], err)
+			return
+		}
+		sizes[i], unpacked[i] = usage.Size, true
+	})
+
+	layerSizes := make(map[string]int64, len(layers))
+	for i, chainID := range layers {
+		if unpacked[i] {
+			layerSizes[chainID] = sizes[i]
+		}
+	}
+
+	var layersSize int64
+	for _, size := range layerSizes {
+		layersSize += size
+	}
+
+	usages := make([]*apitypes.ImageDiskUsage, 0, len(infos))
+	for i, info := range infos {
+		imgInfo, err := mgr.containerdImageToImageInfo(ctx, info.ID)
+		if err != nil {
+			logrus.Warnf("failed to convert containerd image(%v) to ImageInfo during disk usage: %v", info.ID, err)
+			continue
+		}
+
+		usage := &apitypes.ImageDiskUsage{
+			ID:         info.ID.String(),
+			RepoTags:   imgInfo.RepoTags,
+			CreatedAt:  imgInfo.CreatedAt,
+			Containers: containers[info.ID.String()],
+		}
+
+		var isUnpacked bool
+		for _, chainID := range imageLayers[i] {
+			size, ok := layerSizes[chainID]
+			if !ok {
+				continue
+			}
+			isUnpacked = true
+
+			usage.Size += size
+			if layerRefs[chainID] > 1 {
+				usage.SharedSize += size
+			}
+		}
+
+		// the image which hasn't been unpacked only takes the content store.
+		if !isUnpacked {
+			usage.Size = info.Size
+			layersSize += info.Size
+		}
+		usages = append(usages, usage)
+	}
+	return usages, layersSize, nil
+}
+
+// DiskUsage returns the disk space used by the writable layer of each
+// container, the size is -1 if it can't be got from the snapshotter.
+func (mgr *ContainerManager) DiskUsage(ctx context.Context) ([]*apitypes.ContainerDiskUsage, error) {
+	containers, err := mgr.List(ctx, &ContainerListOption{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]*apitypes.ContainerDiskUsage, len(containers))
+	runWithWorkers(len(containers), diskUsageWorkers, func(i int) {
+		c := containers[i]
+
+		c.Lock()
+		usage := &apitypes.ContainerDiskUsage{
+			ID:        c.ID,
+			Name:      c.Name,
+			Image:     c.Config.Image,
+			State:     string(c.State.Status),
+			CreatedAt: c.Created,
+			SizeRw:    -1,
+		}
+		snapshotter, key := c.Config.Snapshotter, c.SnapshotKey()
+		c.Unlock()
+
+		if u, err := mgr.Client.GetSnapshotUsage(ctrd.WithSnapshotter(ctx, snapshotter), key); err != nil {
+			logrus.Warnf("failed to get usage of container %s: %v", c.ID, err)
+		} else {
+			usage.SizeRw = u.Size
+		}
+		usages[i] = usage
+	})
+	return usages, nil
+}
+
+// DiskUsage returns the disk space used by each volume, only the size of
+// local volume is computed and others are -1.
+func (vm *VolumeManager) DiskUsage(ctx context.Context) ([]*apitypes.VolumeDiskUsage, error) {
+	volumes, err := vm.core.ListVolumes(filters.NewArgs())
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]*apitypes.VolumeDiskUsage, len(volumes))
+	runWithWorkers(len(volumes), diskUsageWorkers, func(i int) {
+		v := volumes[i]
+
+		usage := &apitypes.VolumeDiskUsage{
+			Name:   v.Name,
+			Driver: v.Driver(),
+			Size:   -1,
+		}
+		if ref := v.Option(types.OptionRef); ref != "" {
+			usage.RefCount = int64(len(strings.Split(ref, ",")))
+		}
+
+		if v.Driver() == types.DefaultBackend {
+			if size, err := dirSize(v.Path()); err != nil {
+				logrus.Warnf("failed to get size of volume %s: %v", v.Name, err)
+			} else {
+				usage.Size = size
+			}
+		}
+		usages[i] = usage
+	})
+	return usages, nil
+}
+
+// runWithWorkers calls fn with 0 to n-1 in at most workers goroutines, and
+// returns after all the calls are done.
+func runWithWorkers(n, workers int, fn func(i int)) {
+	if workers > n {
+		workers = n
+	}
+
+	var (
+		wg      sync.WaitGroup
+		indexes = make(chan int)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,46 @@
+package mgr
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunWithWorkers(t *testing.T) {
+	for _, tc := range []struct {
+		n, workers int
+	}{
+		{n: 0, workers: 4},
+		{n: 3, workers: 4},
+		{n: 100, workers: 4},
+	} {
+		var (
+			running, maxRunning int64
+			mu                  sync.Mutex
+			called              = make(map[int]int)
+		)
+
+		runWithWorkers(tc.n, tc.workers, func(i int) {
+			cur := atomic.AddInt64(&running, 1)
+			defer atomic.AddInt64(&running, -1)
+
+			mu.Lock()
+			called[i]++
+			if cur > maxRunning {
+				maxRunning = cur
+			}
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+		})
+
+		assert.Equal(t, tc.n, len(called))
+		for i := 0; i < tc.n; i++ {
+			assert.Equal(t, 1, called[i])
+		}
+		assert.True(t, maxRunning <= int64(tc.workers), "at most %d workers, got %d", tc.workers, maxRunning)
+	}
+}
@@ -5,26 +5,30 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/metrics"
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/ctrd"
 	"github.com/alibaba/pouch/daemon/config"
 	"github.com/alibaba/pouch/daemon/events"
 	"github.com/alibaba/pouch/hookplugins"
 	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/httputils"
 	"github.com/alibaba/pouch/pkg/jsonstream"
 	"github.com/alibaba/pouch/pkg/reference"
 	"github.com/alibaba/pouch/pkg/utils"
+	"github.com/alibaba/pouch/registry"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/content"
 	ctrdmetaimages "github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/platforms"
 	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	pkgerrors "github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -36,32 +40,51 @@ import (
 // more-layers and huge-size images. So update it from 10 secs to 10 mins.
 var deadlineLoadImagesAtBootup = time.Minute * 10
 
+const (
+	// defaultSearchLimit is the default number of search results.
+	defaultSearchLimit = 25
+
+	// maxSearchLimit is the max number of search results.
+	maxSearchLimit = 100
+)
+
 // the filter tags set allowed when pouch images -f
 var acceptedImageFilterTags = map[string]bool{
 	"before":    true,
 	"since":     true,
 	"reference": true,
+	"dangling":  true,
 }
 
 // ImageMgr as an interface defines all operations against images.
 type ImageMgr interface {
-	// PullImage pulls images from specified registry.
-	PullImage(ctx context.Context, ref string, authConfig *types.AuthConfig, out io.Writer) error
+	// PullImage pulls images of the platform from specified registry.
+	PullImage(ctx context.Context, ref, platform string, authConfig *types.AuthConfig, out io.Writer) error
 
 	// PushImage pushes image to specified registry.
 	PushImage(ctx context.Context, name, tag string, authConfig *types.AuthConfig, out io.Writer) error
 
+	// ListRemoteTags lists all the tags of repository from specified registry.
+	ListRemoteTags(ctx context.Context, name string, authConfig *types.AuthConfig) ([]string, error)
+
 	// GetImage returns imageInfo by reference or id.
 	GetImage(ctx context.Context, idOrRef string) (*types.ImageInfo, error)
 
 	// ListImages lists images stored by containerd.
 	ListImages(ctx context.Context, filter filters.Args) ([]types.ImageInfo, error)
 
-	// Search Images from specified registry.
-	SearchImages(ctx context.Context, name string, registry string) ([]types.SearchResultItem, error)
+	// SearchImages searches images from specified registry.
+	SearchImages(ctx context.Context, term string, registry string, limit int, authConfig *types.AuthConfig) ([]types.SearchResultItem, error)
 
-	// RemoveImage deletes an image by reference.
-	RemoveImage(ctx context.Context, idOrRef string, force bool) error
+	// RemoveImage deletes an image by reference, and returns the untagged
+	// references and the deleted image.
+	RemoveImage(ctx context.Context, idOrRef string, force bool) ([]*types.ImageDeleteResponseItem, error)
+
+	// PruneImages removes the images which are not used by any container.
+	PruneImages(ctx context.Context, filter filters.Args, usedImages map[string]struct{}) (*types.ImagePruneResp, error)
+
+	// DiskUsage returns the disk space used by images and the total size of layers.
+	DiskUsage(ctx context.Context, containers map[string]int64) ([]*types.ImageDiskUsage, int64, error)
 
 	// AddTag creates target ref for source image.
 	AddTag(ctx context.Context, sourceImage string, targetRef string) error
@@ -73,10 +96,13 @@ type ImageMgr interface {
 	ListReferences(ctx context.Context, imageID digest.Digest) ([]reference.Named, error)
 
 	// LoadImage creates a set of images by tarstream.
-	LoadImage(ctx context.Context, imageName string, tarstream io.ReadCloser) error
+	LoadImage(ctx context.Context, imageName string, tarstream io.ReadCloser, out io.Writer) error
+
+	// SaveImage saves images to tarstream.
+	SaveImage(ctx context.Context, idOrRefs []string) (io.ReadCloser, error)
 
-	// SaveImage saves image to tarstream.
-	SaveImage(ctx context.Context, idOrRef string) (io.ReadCloser, error)
+	// ImportImage creates an image with single layer from the tar archive of rootfs.
+	ImportImage(ctx context.Context, ref, message string, changes []string, tarstream io.Reader) (*types.ImageInfo, error)
 
 	// ImageHistory returns image history by reference.
 	ImageHistory(ctx context.Context, idOrRef string) ([]types.HistoryResultItem, error)
@@ -86,6 +112,9 @@ type ImageMgr interface {
 
 	// GetOCIImageConfig returns the image config of OCI
 	GetOCIImageConfig(ctx context.Context, image string) (ocispec.ImageConfig, error)
+
+	// GetImageHealthcheck returns the healthcheck defined in the image config.
+	GetImageHealthcheck(ctx context.Context, image string) (*types.HealthConfig, error)
 }
 
 // ImageManager is an implementation of interface ImageMgr.
@@ -110,6 +139,9 @@ type ImageManager struct {
 
 	// imagePlugin is a plugin called before image operations
 	imagePlugin hookplugins.ImagePlugin
+
+	// registry is used to interact with registry directly, like search.
+	registry *registry.Client
 }
 
 // NewImageManager initializes a brand new image manager.
@@ -127,6 +159,7 @@ func NewImageManager(cfg *config.Config, client ctrd.APIClient, eventsService *e
 		localStore:    store,
 		eventsService: eventsService,
 		imagePlugin:   imagePlugin,
+		registry:      &registry.Client{HTTPClient: client.RegistryHTTPClient},
 	}
 
 	if err := mgr.updateLocalStore(); err != nil {
@@ -135,13 +168,12 @@ func NewImageManager(cfg *config.Config, client ctrd.APIClient, eventsService *e
 	return mgr, nil
 }
 
-// PullImage pulls images from specified registry.
-func (mgr *ImageManager) PullImage(ctx context.Context, ref string, authConfig *types.AuthConfig, out io.Writer) error {
-	newRef := addDefaultRegistryIfMissing(ref, mgr.DefaultRegistry, mgr.DefaultNamespace)
-	namedRef, err := reference.Parse(newRef)
-	if err != nil {
-		return err
-	}
+// PullImage pulls images from specified registry. The image of host platform
+// will be pulled if the platform is empty.
+func (mgr *ImageManager) PullImage(ctx context.Context, ref, platform string, authConfig *types.AuthConfig, out io.Writer) error {
+	defer func(start time.Time) {
+		metrics.ImagePullDuration.Observe(time.Since(start).Seconds())
+	}(time.Now())
 
 	pctx, cancel := context.WithCancel(ctx)
 	stream := jsonstream.New(out, nil)
@@ -157,7 +189,7 @@ func (mgr *ImageManager) PullImage(ctx context.Context, ref string, authConfig *
 		// Send Error information to client through stream
 		message := jsonstream.JSONMessage{
 			Error: &jsonstream.JSONError{
-				Code:    http.StatusInternalServerError,
+				Code:    httputils.StatusCode(err),
 				Message: err.Error(),
 			},
 			ErrorMessage: err.Error(),
@@ -166,8 +198,15 @@ func (mgr *ImageManager) PullImage(ctx context.Context, ref string, authConfig *
 		closeStream()
 	}
 
-	namedRef = reference.TrimTagForDigest(reference.WithDefaultTagIfMissing(namedRef))
-	img, err := mgr.client.FetchImage(pctx, namedRef.String(), authConfig, stream)
+	namedRef, err := reference.Normalize(ref, mgr.DefaultRegistry, mgr.DefaultNamespace)
+	if err != nil {
+		err = pkgerrors.Wrap(errtypes.ErrInvalidParam, err.Error())
+		writeStream(err)
+		return err
+	}
+
+	namedRef = reference.TrimTagForDigest(namedRef)
+	img, err := mgr.client.FetchImage(pctx, namedRef.String(), platform, authConfig, stream)
 	if err != nil {
 		writeStream(err)
 		return err
@@ -217,6 +256,20 @@ func (mgr *ImageManager) PushImage(ctx context.Context, name, tag string, authCo
 	return mgr.client.PushImage(ctx, ref.String(), authConfig, out)
 }
 
+// ListRemoteTags lists all the tags of repository from specified registry.
+func (mgr *ImageManager) ListRemoteTags(ctx context.Context, name string, authConfig *types.AuthConfig) ([]string, error) {
+	newRef := reference.AddDefaultRegistryIfMissing(name, mgr.DefaultRegistry, mgr.DefaultNamespace)
+	namedRef, err := reference.Parse(newRef)
+	if err != nil {
+		return nil, pkgerrors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	if !reference.IsNamedOnly(namedRef) {
+		return nil, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "repository name %s should not contain tag or digest", name)
+	}
+	return mgr.client.ListRemoteTags(ctx, namedRef.Name(), authConfig)
+}
+
 // GetImage returns imageInfo by reference.
 func (mgr *ImageManager) GetImage(ctx context.Context, idOrRef string) (*types.ImageInfo, error) {
 	id, _, _, err := mgr.CheckReference(ctx, idOrRef)
@@ -234,6 +287,11 @@ func (mgr *ImageManager) GetImage(ctx context.Context, idOrRef string) (*types.I
 // ListImages lists images stored by containerd.
 func (mgr *ImageManager) ListImages(ctx context.Context, filter filters.Args) ([]types.ImageInfo, error) {
 	if err := filter.Validate(acceptedImageFilterTags); err != nil {
+		return nil, pkgerrors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	dangling, err := parseDanglingFilter(filter)
+	if err != nil {
 		return nil, err
 	}
 
@@ -256,7 +314,6 @@ func (mgr *ImageManager) ListImages(ctx context.Context, filter filters.Args) ([
 	var (
 		beforeFilter, sinceFilter *types.ImageInfo
 		beforeTime, sinceTime     time.Time
-		err                       error
 	)
 
 	if len(beforeImages) > 0 {
@@ -299,6 +356,11 @@ func (mgr *ImageManager) ListImages(ctx context.Context, filter filters.Args) ([
 			continue
 		}
 
+		// dangling image is the image without any tag
+		if dangling != nil && *dangling != (len(imgInfo.RepoTags) == 0) {
+			continue
+		}
+
 		if len(referenceFilter) == 0 {
 			imgInfos = append(imgInfos, imgInfo)
 			continue
@@ -323,21 +385,74 @@ func (mgr *ImageManager) ListImages(ctx context.Context, filter filters.Args) ([
 	return imgInfos, nil
 }
 
-// SearchImages searches imaged from specified registry.
-func (mgr *ImageManager) SearchImages(ctx context.Context, name string, registry string) ([]types.SearchResultItem, error) {
+// SearchImages searches images from specified registry. The default
+// registry will be used if the registry is empty.
+func (mgr *ImageManager) SearchImages(ctx context.Context, term string, registry string, limit int, authConfig *types.AuthConfig) ([]types.SearchResultItem, error) {
+	if term == "" {
+		return nil, pkgerrors.Wrap(errtypes.ErrInvalidParam, "search term cannot be empty")
+	}
+
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	if limit < 1 || limit > maxSearchLimit {
+		return nil, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "limit %d is outside the range of [1, %d]", limit, maxSearchLimit)
+	}
+
+	if registry == "" {
+		registry = mgr.DefaultRegistry
+	}
+
 	// Directly send API calls towards specified registry
-	return nil, errtypes.ErrNotImplemented
+	return mgr.registry.Search(ctx, registry, term, limit, authConfig)
 }
 
-// RemoveImage deletes a reference.
+// RemoveImage deletes a reference, and returns the untagged references and
+// the image ID if there is no reference to the image.
 //
 // NOTE: if the reference is short ID or ID, should remove all the references.
-func (mgr *ImageManager) RemoveImage(ctx context.Context, idOrRef string, force bool) error {
+func (mgr *ImageManager) RemoveImage(ctx context.Context, idOrRef string, force bool) ([]*types.ImageDeleteResponseItem, error) {
 	id, namedRef, primaryRef, err := mgr.CheckReference(ctx, idOrRef)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	refs := repoReferences(mgr.localStore.GetReferences(id))
+	if err := mgr.removeImage(ctx, idOrRef, id, namedRef, primaryRef, force); err != nil {
+		return nil, err
+	}
+
+	// the references removed with the primary reference are untagged too.
+	remains := repoReferences(mgr.localStore.GetReferences(id))
+	items := make([]*types.ImageDeleteResponseItem, 0, len(refs)+1)
+	for _, ref := range refs {
+		if !utils.StringInSlice(remains, ref) {
+			items = append(items, &types.ImageDeleteResponseItem{Untagged: ref})
+		}
 	}
 
+	if len(mgr.localStore.GetPrimaryReferences(id)) == 0 {
+		items = append(items, &types.ImageDeleteResponseItem{Deleted: id.String()})
+	}
+	return items, nil
+}
+
+// repoReferences returns the sorted tag and digest references, which are
+// shown as RepoTags and RepoDigests of image.
+func repoReferences(refs []reference.Named) []string {
+	res := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		switch ref.(type) {
+		case reference.Tagged, reference.CanonicalDigested:
+			res = append(res, ref.String())
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// removeImage removes the reference of the image.
+func (mgr *ImageManager) removeImage(ctx context.Context, idOrRef string, id digest.Digest, namedRef, primaryRef reference.Named, force bool) error {
 	// since there is no rollback functionality, no guarantee that the
 	// containerd.RemoveImage must success. so if the localStore has been
 	// remove all the primary references, we should clear the CtrdImageInfo
@@ -380,6 +495,7 @@ func (mgr *ImageManager) RemoveImage(ctx context.Context, idOrRef string, force
 				return err
 			}
 		}
+		mgr.LogImageEvent(ctx, id.String(), namedRef.String(), "delete")
 		return nil
 	}
 
@@ -390,7 +506,11 @@ func (mgr *ImageManager) RemoveImage(ctx context.Context, idOrRef string, force
 			return err
 		}
 
-		return mgr.client.RemoveImage(ctx, primaryRef.String())
+		if err := mgr.client.RemoveImage(ctx, primaryRef.String()); err != nil {
+			return err
+		}
+		mgr.LogImageEvent(ctx, id.String(), primaryRef.String(), "delete")
+		return nil
 	}
 
 	// untag event
@@ -410,7 +530,7 @@ func (mgr *ImageManager) RemoveImage(ctx context.Context, idOrRef string, force
 //
 // The B is still there.
 func (mgr *ImageManager) AddTag(ctx context.Context, sourceImage string, targetTag string) error {
-	targetTag = addDefaultRegistryIfMissing(targetTag, mgr.DefaultRegistry, mgr.DefaultNamespace)
+	targetTag = reference.AddDefaultRegistryIfMissing(targetTag, mgr.DefaultRegistry, mgr.DefaultNamespace)
 
 	tagRef, err := parseTagReference(targetTag)
 	if err != nil {
@@ -426,21 +546,40 @@ func (mgr *ImageManager) AddTag(ctx context.Context, sourceImage string, targetT
 		return err
 	}
 
-	// add the reference into memory
 	cfg, err := ctrdImg.Config(ctx)
 	if err != nil {
 		return err
 	}
+
+	// NOTE: like docker, the existing tag will be moved to the source image.
+	if oldID, err := mgr.primaryReferenceID(tagRef); err == nil {
+		if oldID == cfg.Digest {
+			return nil
+		}
+
+		if err := mgr.untagReference(ctx, oldID, tagRef); err != nil {
+			return err
+		}
+	}
+
+	// add the reference into memory
 	if err := mgr.addReferenceIntoStore(cfg.Digest, tagRef, ctrdImg.Target().Digest); err != nil {
 		return err
 	}
 
 	// add the reference into containerd meta db
-	_, err = mgr.client.CreateImageReference(ctx, ctrdmetaimages.Image{
+	// NOTE: the labels should be copied, because the platform of image is
+	// recorded in the labels.
+	if _, err := mgr.client.CreateImageReference(ctx, ctrdmetaimages.Image{
 		Name:   tagRef.String(),
 		Target: ctrdImg.Target(),
-	})
-	return err
+		Labels: ctrdImg.Labels(),
+	}); err != nil {
+		return err
+	}
+
+	mgr.LogImageEvent(ctx, cfg.Digest.String(), tagRef.String(), "tag")
+	return nil
 }
 
 // ImageHistory returns image history by reference.
@@ -461,52 +600,37 @@ func (mgr *ImageManager) ImageHistory(ctx context.Context, idOrRef string) ([]ty
 	}
 
 	cs := img.ContentStore()
-	manifest, err := mgr.getManifest(ctx, cs, img, platforms.Default())
+	manifest, err := mgr.getManifest(ctx, cs, img, ctrd.ImagePlatformMatcher(img.Labels()))
 	if err != nil {
 		return nil, err
 	}
 
-	ociImageHistory := ociImage.History
-	lenOciImageHistory := len(ociImageHistory)
-	history := make([]types.HistoryResultItem, lenOciImageHistory)
-	// Note: ociImage History layers info and manifest layers info are all in order from bottom-most to top-most, but the
-	// user-interactive history is in order from top-most to top-bottom, so we need to reverse ociImage History traverse order.
-	j := len(manifest.Layers) - 1
-	for i := range ociImageHistory {
-		history[i] = types.HistoryResultItem{
-			Created:    ociImageHistory[lenOciImageHistory-i-1].Created.UnixNano(),
-			CreatedBy:  ociImageHistory[lenOciImageHistory-i-1].CreatedBy,
-			Author:     ociImageHistory[lenOciImageHistory-i-1].Author,
-			Comment:    ociImageHistory[lenOciImageHistory-i-1].Comment,
-			EmptyLayer: ociImageHistory[lenOciImageHistory-i-1].EmptyLayer,
-			ID:         "<missing>",
-			Size:       0,
-		}
-
-		// TODO: here we just set imageID of top image layer, we do nothing with the lower image ID, after pouch
-		// enables build/commit functionality, we should get local lower image(parent image) layer ID.
-		if i == 0 {
-			history[i].ID = desc.Digest.String()
-		}
-
-		// Note: number of manifest layers should be less than ociImage History messages due to the existence of empty layers.
-		// The size of these empty layers should be set to 0 by default.
-		if !history[i].EmptyLayer {
-			if j < 0 {
-				return nil, errors.New("number of manifest layers shouldn't be less than number of non-empty layer in history info")
-			}
-			info, err := cs.Info(ctx, manifest.Layers[j].Digest)
-			if err != nil {
-				return nil, err
-			}
-			history[i].Size = info.Size
-			j--
-		}
+	diffIDs := ociImage.RootFS.DiffIDs
+	if len(diffIDs) != len(manifest.Layers) {
+		return nil, fmt.Errorf("number of layers %d in manifest doesn't match number of diff IDs %d in config", len(manifest.Layers), len(diffIDs))
 	}
-	if j != -1 {
-		return nil, errors.New("number of manifest layers shouldn't be greater than number of non-empty layer in history info")
+
+	layerSizes := make([]int64, len(diffIDs))
+	for i, layer := range manifest.Layers {
+		// like docker, use the size of unpacked layer. The snapshot of
+		// layer is named by chain ID.
+		chainID := identity.ChainID(diffIDs[:i+1]).String()
+		if usage, err := mgr.client.GetSnapshotUsage(ctx, chainID); err == nil {
+			layerSizes[i] = usage.Size
+			continue
+		}
+
+		// the layer hasn't been unpacked, use the size of blob instead
+		info, err := cs.Info(ctx, layer.Digest)
+		if err != nil {
+			return nil, err
+		}
+		layerSizes[i] = info.Size
 	}
-	return history, nil
+
+	// TODO: here we just set imageID of top image layer, we do nothing with the lower image ID, after pouch
+	// enables build/commit functionality, we should get local lower image(parent image) layer ID.
+	return buildImageHistory(desc.Digest, ociImage.History, layerSizes)
 }
 
 // CheckReference returns image ID and actual reference.
@@ -520,14 +644,14 @@ func (mgr *ImageManager) CheckReference(ctx context.Context, idOrRef string) (ac
 
 	// NOTE: we cannot add default registry for the idOrRef directly
 	// because the idOrRef maybe short ID or ID. we should run search
-	// without addDefaultRegistryIfMissing at first round.
+	// without AddDefaultRegistryIfMissing at first round.
 	actualID, actualRef, err = mgr.localStore.Search(namedRef)
 	if err != nil {
 		if !errtypes.IsNotfound(err) {
 			return
 		}
 
-		newIDOrRef := addDefaultRegistryIfMissing(idOrRef, mgr.DefaultRegistry, mgr.DefaultNamespace)
+		newIDOrRef := reference.AddDefaultRegistryIfMissing(idOrRef, mgr.DefaultRegistry, mgr.DefaultNamespace)
 		if newIDOrRef == idOrRef {
 			return
 		}
@@ -582,6 +706,16 @@ func (mgr *ImageManager) GetOCIImageConfig(ctx context.Context, image string) (o
 	return ociImage.Config, nil
 }
 
+// GetImageHealthcheck returns the healthcheck defined in the image config,
+// nil is returned if the image doesn't define one.
+func (mgr *ImageManager) GetImageHealthcheck(ctx context.Context, image string) (*types.HealthConfig, error) {
+	img, err := mgr.client.GetImage(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	return containerdImageToHealthcheck(ctx, img)
+}
+
 // updateLocalStore updates the local store.
 func (mgr *ImageManager) updateLocalStore() error {
 	ctx, cancel := context.WithTimeout(context.Background(), deadlineLoadImagesAtBootup)
@@ -626,10 +760,18 @@ func (mgr *ImageManager) StoreImageReference(ctx context.Context, img containerd
 		return err
 	}
 
+	// the variant isn't recorded in the config of image, so use the
+	// platform specified during pull.
+	var variant string
+	if _, ok := img.Labels()[ctrd.ImagePlatformLabel]; ok {
+		variant = ctrd.ImagePlatform(img.Labels()).Variant
+	}
+
 	mgr.localStore.CacheCtrdImageInfo(imgCfg.Digest, CtrdImageInfo{
 		ID:      imgCfg.Digest,
 		Size:    size,
 		OCISpec: ociImage,
+		Variant: variant,
 	})
 	return nil
 }
@@ -691,7 +833,8 @@ func (mgr *ImageManager) containerdImageToImageInfo(ctx context.Context, id dige
 			Type:   ociImage.RootFS.Type,
 			Layers: digestSliceToStringSlice(ociImage.RootFS.DiffIDs),
 		},
-		Size: ctrdImageInfo.Size,
+		Size:    ctrdImageInfo.Size,
+		Variant: ctrdImageInfo.Variant,
 	}, nil
 }
 
@@ -712,15 +855,37 @@ func (mgr *ImageManager) validateTagReference(ref reference.Named) error {
 		)
 	}
 
-	// NOTE: we don't allow to use tag to override the existing primary reference.
+	return nil
+}
+
+// primaryReferenceID returns the image ID if the ref is the primary reference.
+func (mgr *ImageManager) primaryReferenceID(ref reference.Named) (digest.Digest, error) {
 	pRef, err := mgr.localStore.GetPrimaryReference(ref)
 	if err != nil {
-		// @fuweid: we should return nil instead of err.
-		return nil
+		return "", err
 	}
 
-	if pRef.String() == ref.String() {
-		return pkgerrors.Wrapf(errtypes.ErrInvalidParam, "the tag reference (%s) has been used as reference", ref.String())
+	if pRef.String() != ref.String() {
+		return "", pkgerrors.Wrapf(errtypes.ErrNotfound, "primary reference %s", ref.String())
+	}
+
+	id, _, err := mgr.localStore.Search(pRef)
+	return id, err
+}
+
+// untagReference removes the primary reference from both containerd and
+// local store.
+func (mgr *ImageManager) untagReference(ctx context.Context, id digest.Digest, ref reference.Named) error {
+	if err := mgr.client.RemoveImage(ctx, ref.String()); err != nil {
+		return err
+	}
+
+	if err := mgr.localStore.RemoveReference(id, ref); err != nil {
+		return err
+	}
+
+	if len(mgr.localStore.GetPrimaryReferences(id)) == 0 {
+		mgr.localStore.ClearCtrdImageInfo(id)
 	}
 	return nil
 }
@@ -763,11 +928,20 @@ func filterReference(filter, ref []string) ([]string, error) {
 	var err error
 	filteredRefs := make([]string, 0)
 	for _, ref := range ref {
+		// the pattern can also match the repository name without tag or digest
+		var name string
+		if namedRef, err := reference.Parse(ref); err == nil {
+			name = namedRef.Name()
+		}
+
 		var found bool
 		for _, pattern := range filter {
 			found, err = filters.FamiliarMatch(pattern, ref)
 			if err != nil {
-				return []string{}, err
+				return []string{}, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid reference filter %s: %v", pattern, err)
+			}
+			if !found && name != "" {
+				found, _ = filters.FamiliarMatch(pattern, name)
 			}
 			if found {
 				filteredRefs = append(filteredRefs, ref)
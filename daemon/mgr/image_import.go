@@ -0,0 +1,61 @@
+package mgr
+
+import (
+	"context"
+	"io"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/reference"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ImportImage creates an image with single layer from the tar archive of
+// rootfs, such as the one exported from a container. The Dockerfile
+// instructions in changes are applied to the config of image.
+func (mgr *ImageManager) ImportImage(ctx context.Context, ref, message string, changes []string, tarstream io.Reader) (*types.ImageInfo, error) {
+	if ref == "" {
+		return nil, pkgerrors.Wrap(errtypes.ErrInvalidParam, "not allow empty image reference")
+	}
+
+	tagRef, err := parseTagReference(reference.AddDefaultRegistryIfMissing(ref, mgr.DefaultRegistry, mgr.DefaultNamespace))
+	if err != nil {
+		return nil, err
+	}
+	if err := mgr.validateTagReference(tagRef); err != nil {
+		return nil, err
+	}
+
+	config, err := applyCommitChanges(&types.ContainerConfig{}, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	// before image import, call WithImageUnpack
+	ctx = ctrd.WithImageUnpack(ctx)
+
+	if _, err := mgr.client.ImportRootfs(ctx, &ctrd.ImportRootfsConfig{
+		Reference:       tagRef.String(),
+		Comment:         message,
+		ContainerConfig: config,
+	}, tarstream); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to import image %s", ref)
+	}
+
+	img, err := mgr.client.GetImage(ctx, tagRef.String())
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to get new imported image %s from containerd", tagRef.String())
+	}
+
+	// update image reference in pouch
+	if err := mgr.StoreImageReference(ctx, img); err != nil {
+		// same as commit, the image is imported even if failed to update
+		// the store, restart pouch can see the new image reference.
+		logrus.Warnf("failed to update image store: %s", err)
+	}
+
+	return mgr.GetImage(ctx, tagRef.String())
+}
@@ -4,35 +4,67 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/httputils"
+	"github.com/alibaba/pouch/pkg/jsonstream"
 	"github.com/alibaba/pouch/pkg/multierror"
 	"github.com/alibaba/pouch/pkg/reference"
 
 	"github.com/containerd/containerd"
-	"github.com/containerd/containerd/images/archive"
 	pkgerrors "github.com/pkg/errors"
 )
 
-// LoadImage loads images by the oci.v1 format tarstream.
-func (mgr *ImageManager) LoadImage(ctx context.Context, imageName string, tarstream io.ReadCloser) error {
+// defaultLoadImageName is used when the archive only contains tags without
+// image name.
+const defaultLoadImageName = "unknown/unknown"
+
+// LoadImage loads images by the oci.v1 or docker format tarstream, and
+// reports the progress into out.
+func (mgr *ImageManager) LoadImage(ctx context.Context, imageName string, tarstream io.ReadCloser, out io.Writer) error {
 	defer tarstream.Close()
 
-	namedRef, err := reference.Parse(imageName)
-	if err != nil {
-		return pkgerrors.Wrapf(err, "failed to parse image name %s", imageName)
+	if imageName != "" {
+		namedRef, err := reference.Parse(imageName)
+		if err != nil {
+			return pkgerrors.Wrapf(errtypes.ErrInvalidParam, "failed to parse image name %s: %v", imageName, err)
+		}
+
+		// NOTE: in the image ocispec.v1, the org.opencontainers.image.ref.name
+		// annotation represents a "tag" for image. For example, an image may
+		// have a tag for different versions or builds of the software.
+		// And the image name will be joined with the tag, so that we don't
+		// allow imageName to contains any digest or tag information, like
+		// foo/bar:latest:v1.2.
+		if !reference.IsNamedOnly(namedRef) {
+			return pkgerrors.Wrap(errtypes.ErrInvalidParam, "the image name should not contains any digest or tag information")
+		}
 	}
 
-	// NOTE: in the image ocispec.v1, the org.opencontainers.image.ref.name
-	// annotation represents a "tag" for image. For example, an image may
-	// have a tag for different versions or builds of the software.
-	// And containerd.importer will append ":" and annotation to the name
-	// so that we don't allow imageName to contains any digest or tag
-	// information, like foo/bar:latest:v1.2.
-	if !reference.IsNamedOnly(namedRef) {
-		return fmt.Errorf("the image name should not contains any digest or tag information")
+	stream := jsonstream.New(out, nil)
+	defer func() {
+		stream.Close()
+		stream.Wait()
+	}()
+
+	err := mgr.loadImage(ctx, imageName, tarstream, stream)
+	if err != nil {
+		// the progress has been sent to client, so send the error
+		// information through stream too.
+		stream.WriteObject(jsonstream.JSONMessage{
+			Error: &jsonstream.JSONError{
+				Code:    httputils.StatusCode(err),
+				Message: err.Error(),
+			},
+			ErrorMessage: err.Error(),
+		})
 	}
+	return err
+}
 
-	imgs, err := mgr.client.ImportImage(ctx, tarstream, containerd.WithImageRefTranslator(archive.FilterRefPrefix(imageName)))
+func (mgr *ImageManager) loadImage(ctx context.Context, imageName string, tarstream io.Reader, stream *jsonstream.JSONStream) error {
+	imgs, err := mgr.client.ImportImage(ctx, tarstream, stream, containerd.WithImageRefTranslator(loadRefTranslator(imageName)))
 	if err != nil {
 		return pkgerrors.Wrap(err, "failed to import image into containerd by tarstream")
 	}
@@ -43,7 +75,13 @@ func (mgr *ImageManager) LoadImage(ctx context.Context, imageName string, tarstr
 	for _, img := range imgs {
 		if err := mgr.StoreImageReference(ctx, img); err != nil {
 			merrs.Append(fmt.Errorf("fail to store reference: %s: %v", img.Name(), err))
+			continue
 		}
+
+		stream.WriteObject(jsonstream.JSONMessage{
+			ID:     img.Name(),
+			Status: jsonstream.LoadStatusLoaded,
+		})
 	}
 
 	if merrs.Size() != 0 {
@@ -51,3 +89,37 @@ func (mgr *ImageManager) LoadImage(ctx context.Context, imageName string, tarstr
 	}
 	return nil
 }
+
+// loadRefTranslator translates the org.opencontainers.image.ref.name
+// annotation into the image reference.
+//
+// If the image name is specified, the annotation, which can be tag or full
+// reference, will be translated into imageName:tag. Otherwise, the full
+// reference will be kept, and the tag will be joined with the default name.
+func loadRefTranslator(imageName string) func(string) string {
+	return func(ref string) string {
+		// the annotation is tag only
+		if !strings.ContainsAny(ref, "/:@") {
+			if imageName == "" {
+				return defaultLoadImageName + ":" + ref
+			}
+			return imageName + ":" + ref
+		}
+
+		namedRef, err := reference.Parse(ref)
+		if err != nil {
+			return ""
+		}
+
+		if imageName == "" {
+			return namedRef.String()
+		}
+
+		// use the tag of reference with the specified image name, and the
+		// digest reference can't be kept.
+		if !reference.IsNameTagged(namedRef) {
+			return ""
+		}
+		return imageName + ":" + namedRef.(reference.Tagged).Tag()
+	}
+}
@@ -0,0 +1,25 @@
+package mgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadRefTranslator(t *testing.T) {
+	for _, tc := range []struct {
+		imageName string
+		ref       string
+		expected  string
+	}{
+		{imageName: "foo", ref: "latest", expected: "foo:latest"},
+		{imageName: "", ref: "latest", expected: "unknown/unknown:latest"},
+		{imageName: "foo", ref: "docker.io/library/busybox:1.25", expected: "foo:1.25"},
+		{imageName: "", ref: "docker.io/library/busybox:1.25", expected: "docker.io/library/busybox:1.25"},
+		{imageName: "foo", ref: "docker.io/library/busybox@sha256:29f5d56d12684887bdfa50dcd29fc31eea4aaf4ad3bec43daf19026a7ce69912", expected: ""},
+		{imageName: "", ref: "docker.io/library/busybox@sha256:29f5d56d12684887bdfa50dcd29fc31eea4aaf4ad3bec43daf19026a7ce69912", expected: "docker.io/library/busybox@sha256:29f5d56d12684887bdfa50dcd29fc31eea4aaf4ad3bec43daf19026a7ce69912"},
+		{imageName: "", ref: "invalid:reference:", expected: ""},
+	} {
+		assert.Equal(t, tc.expected, loadRefTranslator(tc.imageName)(tc.ref), "%s with %s", tc.ref, tc.imageName)
+	}
+}
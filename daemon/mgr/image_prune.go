@@ -0,0 +1,160 @@
+package mgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	ctrdmetaimages "github.com/containerd/containerd/images"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// acceptedPruneImageFilterTags are the filters supported by image prune.
+var acceptedPruneImageFilterTags = map[string]bool{
+	"dangling": true,
+	"until":    true,
+}
+
+// PruneImages removes the images which are not used by any container, and
+// returns the deleted references and the reclaimed disk space. Only the
+// dangling images will be removed unless the dangling filter is false.
+func (mgr *ImageManager) PruneImages(ctx context.Context, filter filters.Args, usedImages map[string]struct{}) (*types.ImagePruneResp, error) {
+	if err := filter.Validate(acceptedPruneImageFilterTags); err != nil {
+		return nil, pkgerrors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	dangling, err := parseDanglingFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	danglingOnly := dangling == nil || *dangling
+
+	until, err := parseUntilFilter(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		resp  = &types.ImagePruneResp{ImagesDeleted: []*types.ImageDeleteResponseItem{}}
+		blobs = make(map[digest.Digest]int64)
+
+		// store is used to check which blobs have been reclaimed
+		store content.Store
+	)
+
+	for _, info := range mgr.localStore.ListCtrdImageInfo() {
+		if _, ok := usedImages[info.ID.String()]; ok {
+			continue
+		}
+
+		if !until.IsZero() && (info.OCISpec.Created == nil || !info.OCISpec.Created.Before(until)) {
+			continue
+		}
+
+		imgInfo, err := mgr.containerdImageToImageInfo(ctx, info.ID)
+		if err != nil {
+			logrus.Warnf("failed to convert containerd image(%v) to ImageInfo during prune images: %v", info.ID, err)
+			continue
+		}
+
+		// dangling image is the image without any tag
+		if danglingOnly && len(imgInfo.RepoTags) != 0 {
+			continue
+		}
+
+		img, err := mgr.fetchContainerdImage(ctx, info.ID.String())
+		if err != nil {
+			logrus.Warnf("failed to get image %s during prune images: %v", info.ID, err)
+			continue
+		}
+
+		imgBlobs, err := imageBlobs(ctx, img)
+		if err != nil {
+			logrus.Warnf("failed to get blobs of image %s during prune images: %v", info.ID, err)
+			continue
+		}
+
+		// force is required because the image may have several references
+		items, err := mgr.RemoveImage(ctx, info.ID.String(), true)
+		if err != nil {
+			logrus.Warnf("failed to remove image %s during prune images: %v", info.ID, err)
+			continue
+		}
+		resp.ImagesDeleted = append(resp.ImagesDeleted, items...)
+
+		for dgst, size := range imgBlobs {
+			blobs[dgst] = size
+		}
+		store = img.ContentStore()
+	}
+
+	if store == nil {
+		return resp, nil
+	}
+
+	// the content has been reclaimed by containerd gc when the image is
+	// removed, and the blob shared with other images is still there.
+	for dgst, size := range blobs {
+		if _, err := store.Info(ctx, dgst); errdefs.IsNotFound(err) {
+			resp.SpaceReclaimed += size
+		}
+	}
+	return resp, nil
+}
+
+// imageBlobs returns the size of blobs which belong to the image, including
+// the index, manifest, config and layers.
+func imageBlobs(ctx context.Context, img containerd.Image) (map[digest.Digest]int64, error) {
+	cs := img.ContentStore()
+	blobs := make(map[digest.Digest]int64)
+
+	handlers := ctrdmetaimages.Handlers(
+		ctrdmetaimages.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+			blobs[desc.Digest] = desc.Size
+			return nil, nil
+		}),
+		ctrdmetaimages.FilterPlatforms(ctrdmetaimages.ChildrenHandler(cs), ctrd.ImagePlatformMatcher(img.Labels())),
+	)
+
+	if err := ctrdmetaimages.Walk(ctx, handlers, img.Target()); err != nil {
+		return nil, err
+	}
+	return blobs, nil
+}
+
+// parseUntilFilter returns the time of until filter, which can be duration
+// relative to now or timestamp. The zero time is returned if the filter isn't
+// set.
+func parseUntilFilter(filter filters.Args, now time.Time) (time.Time, error) {
+	values := filter.Get("until")
+	if len(values) == 0 {
+		return time.Time{}, nil
+	}
+
+	// refuse undefined behavior
+	if len(values) > 1 {
+		return time.Time{}, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "can't use until filter more than one")
+	}
+
+	ts, err := utils.GetUnixTimestamp(values[0], now)
+	if err != nil {
+		return time.Time{}, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid until filter value %q: %v", values[0], err)
+	}
+
+	sec, nano, err := utils.ParseTimestamp(ts, 0)
+	if err != nil {
+		return time.Time{}, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid until filter value %q: %v", values[0], err)
+	}
+	return time.Unix(sec, nano), nil
+}
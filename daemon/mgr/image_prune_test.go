@@ -0,0 +1,43 @@
+package mgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUntilFilter(t *testing.T) {
+	now := time.Unix(1530000000, 0)
+
+	for _, tc := range []struct {
+		values []string
+		expect time.Time
+		hasErr bool
+	}{
+		{values: nil, expect: time.Time{}},
+		{values: []string{"1h"}, expect: now.Add(-time.Hour)},
+		{values: []string{"1529996400"}, expect: time.Unix(1529996400, 0)},
+		{values: []string{"2018-06-26T08:00:00Z"}, expect: time.Date(2018, 6, 26, 8, 0, 0, 0, time.UTC)},
+		{values: []string{"invalid"}, hasErr: true},
+		{values: []string{"1h", "2h"}, hasErr: true},
+	} {
+		filter := filters.NewArgs()
+		for _, v := range tc.values {
+			filter.Add("until", v)
+		}
+
+		until, err := parseUntilFilter(filter, now)
+		if tc.hasErr {
+			assert.Error(t, err, "%v", tc.values)
+			assert.Equal(t, errtypes.ErrInvalidParam, pkgerrors.Cause(err))
+			continue
+		}
+		assert.NoError(t, err, "%v", tc.values)
+		assert.True(t, tc.expect.Equal(until), "%v: expect %v, got %v", tc.values, tc.expect, until)
+	}
+}
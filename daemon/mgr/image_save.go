@@ -4,20 +4,34 @@ import (
 	"context"
 	"io"
 
-	ociimage "github.com/containerd/containerd/images/oci"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	pkgerrors "github.com/pkg/errors"
 )
 
-// SaveImage saves image to the oci.v1 format tarstream.
-func (mgr *ImageManager) SaveImage(ctx context.Context, idOrRef string) (io.ReadCloser, error) {
-	_, _, ref, err := mgr.CheckReference(ctx, idOrRef)
-	if err != nil {
-		return nil, err
+// SaveImage saves images to the tarstream, which contains both the oci.v1
+// image layout and the manifest.json of docker format.
+func (mgr *ImageManager) SaveImage(ctx context.Context, idOrRefs []string) (io.ReadCloser, error) {
+	if len(idOrRefs) == 0 {
+		return nil, pkgerrors.Wrap(errtypes.ErrInvalidParam, "no image specified to save")
 	}
 
-	exportedStream, err := mgr.client.SaveImage(ctx, &ociimage.V1Exporter{}, ref.String())
-	if err != nil {
-		return nil, err
+	var (
+		refs    = make([]string, 0, len(idOrRefs))
+		visited = make(map[string]bool, len(idOrRefs))
+	)
+
+	for _, idOrRef := range idOrRefs {
+		_, _, ref, err := mgr.CheckReference(ctx, idOrRef)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "failed to get image %s", idOrRef)
+		}
+
+		if !visited[ref.String()] {
+			visited[ref.String()] = true
+			refs = append(refs, ref.String())
+		}
 	}
 
-	return exportedStream, nil
+	return mgr.client.SaveImage(ctx, refs)
 }
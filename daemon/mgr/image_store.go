@@ -72,11 +72,13 @@ type imageStore struct {
 	imageInfoCache map[digest.Digest]CtrdImageInfo
 }
 
-// CtrdImageInfo is used to cache the id, size and oci image information.
+// CtrdImageInfo is used to cache the id, size, oci image information and
+// the variant of platform.
 type CtrdImageInfo struct {
 	ID      digest.Digest
 	Size    int64
 	OCISpec ocispec.Image
+	Variant string
 }
 
 // referenceMap represents reference string to corresponding reference.Named
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
+	"strconv"
 
+	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/reference"
 
 	"github.com/containerd/containerd"
@@ -14,6 +16,7 @@ import (
 	"github.com/containerd/containerd/images"
 	digest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	pkgerrors "github.com/pkg/errors"
 )
 
 var legacyDockerConfigMediaType = "application/octet-stream"
@@ -22,11 +25,44 @@ var legacyDockerConfigMediaType = "application/octet-stream"
 func containerdImageToOciImage(ctx context.Context, img containerd.Image) (ocispec.Image, error) {
 	var ociImage ocispec.Image
 
-	cfg, err := img.Config(ctx)
+	data, err := readImageConfigBlob(ctx, img)
 	if err != nil {
 		return ocispec.Image{}, err
 	}
 
+	if err := json.Unmarshal(data, &ociImage); err != nil {
+		return ocispec.Image{}, err
+	}
+	return ociImage, nil
+}
+
+// containerdImageToHealthcheck returns the healthcheck defined in the image
+// config, which is the extension of docker and not part of the oci image spec.
+func containerdImageToHealthcheck(ctx context.Context, img containerd.Image) (*types.HealthConfig, error) {
+	var config struct {
+		Config struct {
+			Healthcheck *types.HealthConfig `json:"Healthcheck,omitempty"`
+		} `json:"config,omitempty"`
+	}
+
+	data, err := readImageConfigBlob(ctx, img)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return config.Config.Healthcheck, nil
+}
+
+// readImageConfigBlob returns the raw content of image config.
+func readImageConfigBlob(ctx context.Context, img containerd.Image) ([]byte, error) {
+	cfg, err := img.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// NOTE(fuweid): There is config content with legacy media type in
 	// content storage. In order to compatible with existing image,
 	// we should support it.
@@ -36,19 +72,10 @@ func containerdImageToOciImage(ctx context.Context, img containerd.Image) (ocisp
 	case ocispec.MediaTypeImageConfig, images.MediaTypeDockerSchema2Config,
 		legacyDockerConfigMediaType:
 
-		data, err := content.ReadBlob(ctx, img.ContentStore(), cfg)
-		if err != nil {
-			return ocispec.Image{}, err
-		}
-
-		if err := json.Unmarshal(data, &ociImage); err != nil {
-			return ocispec.Image{}, err
-		}
+		return content.ReadBlob(ctx, img.ContentStore(), cfg)
 	default:
-		return ocispec.Image{}, fmt.Errorf("unknown image config media type %s", cfg.MediaType)
+		return nil, fmt.Errorf("unknown image config media type %s", cfg.MediaType)
 	}
-
-	return ociImage, nil
 }
 
 // getImageInfoConfigFromOciImage returns config of ImageConfig from oci image.
@@ -78,26 +105,6 @@ func digestSliceToStringSlice(from []digest.Digest) []string {
 	return to
 }
 
-// addDefaultRegistryIfMissing will add default registry and namespace if missing.
-func addDefaultRegistryIfMissing(ref string, defaultRegistry, defaultNamespace string) string {
-	var (
-		registry  string
-		remainder string
-	)
-
-	idx := strings.IndexRune(ref, '/')
-	if idx == -1 || !strings.ContainsAny(ref[:idx], ".:") {
-		registry, remainder = defaultRegistry, ref
-	} else {
-		registry, remainder = ref[:idx], ref[idx+1:]
-	}
-
-	if registry == defaultRegistry && !strings.ContainsAny(remainder, "/") {
-		remainder = defaultNamespace + "/" + remainder
-	}
-	return registry + "/" + remainder
-}
-
 // uniqueLocatorReference checks the references have the same locator.
 //
 // For example,
@@ -121,3 +128,64 @@ func uniqueLocatorReference(refs []reference.Named) bool {
 	}
 	return true
 }
+
+// parseDanglingFilter returns the value of dangling filter, or nil if the
+// filter isn't set.
+func parseDanglingFilter(filter filters.Args) (*bool, error) {
+	values := filter.Get("dangling")
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	// refuse undefined behavior
+	if len(values) > 1 {
+		return nil, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "can't use dangling filter more than one")
+	}
+
+	dangling, err := strconv.ParseBool(values[0])
+	if err != nil {
+		return nil, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid dangling filter value %q, only true or false is supported", values[0])
+	}
+	return &dangling, nil
+}
+
+// buildImageHistory returns the history of image in order from top-most to
+// bottom-most. The layerSizes are in order of rootfs diff IDs, and each
+// non-empty entry of history is matched with one layer. The top-most entry
+// is identified by the image ID, and the others are <missing>.
+func buildImageHistory(id digest.Digest, history []ocispec.History, layerSizes []int64) ([]types.HistoryResultItem, error) {
+	var (
+		items = make([]types.HistoryResultItem, len(history))
+		layer = 0
+	)
+
+	// NOTE: both of history and layers are in order from bottom-most to
+	// top-most, but the history is shown from top-most to bottom-most.
+	for i, h := range history {
+		item := types.HistoryResultItem{
+			CreatedBy:  h.CreatedBy,
+			Author:     h.Author,
+			Comment:    h.Comment,
+			EmptyLayer: h.EmptyLayer,
+			ID:         "<missing>",
+		}
+		if h.Created != nil {
+			item.Created = h.Created.UnixNano()
+		}
+
+		// the empty layer, like ENV or CMD, has no blob and the size is 0.
+		if !h.EmptyLayer {
+			if layer >= len(layerSizes) {
+				return nil, fmt.Errorf("number of non-empty entries in history is greater than number of layers %d", len(layerSizes))
+			}
+			item.Size = layerSizes[layer]
+			layer++
+		}
+		items[len(history)-i-1] = item
+	}
+
+	if len(items) > 0 {
+		items[0].ID = id.String()
+	}
+	return items, nil
+}
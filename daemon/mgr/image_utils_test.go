@@ -2,52 +2,17 @@ package mgr
 
 import (
 	"testing"
+	"time"
 
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/reference"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestAddDefaultRegistry(t *testing.T) {
-	defaultRegistry, defaultNamespace := "pouch.io", "library"
-
-	for _, tc := range []struct {
-		repo   string
-		expect string
-	}{
-		{
-			repo:   "docker.io/library/busybox",
-			expect: "docker.io/library/busybox",
-		}, {
-			repo:   "library/busybox",
-			expect: defaultRegistry + "/library/busybox",
-		}, {
-			repo:   "127.0.0.1:5000/bar",
-			expect: "127.0.0.1:5000/bar",
-		},
-		{
-			repo:   "0.0.0.0/bar",
-			expect: "0.0.0.0/bar",
-		}, {
-			repo:   "registry.com/bar",
-			expect: "registry.com/bar",
-		}, {
-			repo:   "bar",
-			expect: defaultRegistry + "/" + defaultNamespace + "/" + "bar",
-		}, {
-			repo:   "busybox@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
-			expect: defaultRegistry + "/" + defaultNamespace + "/" + "busybox@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
-		}, {
-			repo:   "foo/bar",
-			expect: defaultRegistry + "/foo/bar",
-		}, {
-			repo:   "foo/bar@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
-			expect: defaultRegistry + "/foo/bar@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
-		},
-	} {
-		assert.Equal(t, addDefaultRegistryIfMissing(tc.repo, defaultRegistry, defaultNamespace), tc.expect)
-	}
-}
-
 func TestUniqueLocatorReference(t *testing.T) {
 	for _, tc := range []struct {
 		refs   []string
@@ -85,3 +50,92 @@ func TestUniqueLocatorReference(t *testing.T) {
 		assert.Equal(t, uniqueLocatorReference(refs), tc.expect)
 	}
 }
+
+func TestParseDanglingFilter(t *testing.T) {
+	trueValue, falseValue := true, false
+
+	for _, tc := range []struct {
+		name     string
+		values   []string
+		expected *bool
+		hasError bool
+	}{
+		{name: "not set"},
+		{name: "true", values: []string{"true"}, expected: &trueValue},
+		{name: "false", values: []string{"false"}, expected: &falseValue},
+		{name: "invalid value", values: []string{"yes"}, hasError: true},
+		{name: "more than one", values: []string{"true", "false"}, hasError: true},
+	} {
+		args := filters.NewArgs()
+		for _, v := range tc.values {
+			args.Add("dangling", v)
+		}
+
+		got, err := parseDanglingFilter(args)
+		if tc.hasError {
+			assert.Equal(t, true, errtypes.IsInvalidParam(err), tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expected, got, tc.name)
+	}
+}
+
+func TestFilterReference(t *testing.T) {
+	refs := []string{
+		"registry.corp/app:1.0",
+		"registry.corp/team/app:1.0",
+		"docker.io/library/busybox:latest",
+		"docker.io/library/busybox@sha256:141c253bc4c3fd0a201d32dc1f493bcf3fff003b6df416dea4f41046e0f37d47",
+	}
+
+	got, err := filterReference([]string{"registry.corp/*"}, refs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"registry.corp/app:1.0"}, got)
+
+	got, err = filterReference([]string{"docker.io/library/busybox"}, refs)
+	assert.NoError(t, err)
+	assert.Equal(t, refs[2:], got)
+
+	_, err = filterReference([]string{"[a-"}, refs)
+	assert.Equal(t, true, errtypes.IsInvalidParam(err))
+}
+
+func TestBuildImageHistory(t *testing.T) {
+	id := digest.FromString("config")
+	created := time.Unix(1530000000, 0)
+
+	history := []ocispec.History{
+		{Created: &created, CreatedBy: "/bin/sh -c #(nop) ADD file:96fda64a6b725d4 in / "},
+		{Created: &created, CreatedBy: "/bin/sh -c #(nop)  ENV FOO=bar", EmptyLayer: true},
+		{CreatedBy: "/bin/sh -c touch /foo", Comment: "no created time"},
+		{Created: &created, CreatedBy: "/bin/sh -c #(nop)  CMD [\"sh\"]", EmptyLayer: true},
+	}
+
+	items, err := buildImageHistory(id, history, []int64{1024, 2048})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(items))
+
+	// newest first, and only the top-most entry has the image ID
+	assert.Equal(t, id.String(), items[0].ID)
+	assert.Equal(t, int64(0), items[0].Size)
+	assert.True(t, items[0].EmptyLayer)
+
+	assert.Equal(t, "<missing>", items[1].ID)
+	assert.Equal(t, int64(2048), items[1].Size)
+	assert.Equal(t, int64(0), items[1].Created)
+	assert.Equal(t, "no created time", items[1].Comment)
+
+	assert.Equal(t, int64(0), items[2].Size)
+	assert.Equal(t, int64(1024), items[3].Size)
+	assert.Equal(t, created.UnixNano(), items[3].Created)
+
+	// the non-empty entries are more than layers
+	_, err = buildImageHistory(id, history, []int64{1024})
+	assert.Error(t, err)
+
+	// the image without history
+	items, err = buildImageHistory(id, nil, []int64{1024})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(items))
+}
@@ -1,6 +1,7 @@
 package mgr
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
@@ -8,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/alibaba/pouch/apis/filters"
 	apitypes "github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/daemon/config"
 	"github.com/alibaba/pouch/daemon/events"
@@ -36,8 +38,8 @@ type NetworkMgr interface {
 	// Get returns the information of network that specified name/id.
 	Get(ctx context.Context, name string) (*types.Network, error)
 
-	// List returns all networks on this host.
-	List(ctx context.Context, labels map[string]string) ([]*types.Network, error)
+	// List returns the networks on this host which match the filter.
+	List(ctx context.Context, filter filters.Args) ([]*types.Network, error)
 
 	// NetworkRemove is used to delete an existing network.
 	Remove(ctx context.Context, name string) error
@@ -59,6 +61,9 @@ type NetworkMgr interface {
 
 	// GetNetworkStats returns the network stats of specific sandbox
 	GetNetworkStats(sandboxID string) (map[string]apitypes.NetworkStats, error)
+
+	// Prune removes the user defined networks which are not used by any container.
+	Prune(ctx context.Context, filter filters.Args) (*apitypes.NetworkPruneResp, error)
 }
 
 // NetworkManager is the default implement of interface NetworkMgr.
@@ -67,6 +72,10 @@ type NetworkManager struct {
 	controller    libnetwork.NetworkController
 	config        network.Config
 	eventsService *events.Events
+
+	// ctrMgr finds the networks used by containers, including the
+	// stopped ones which have no endpoints.
+	ctrMgr ContainerMgr
 }
 
 // NewNetworkManager creates a brand new network manager.
@@ -116,6 +125,7 @@ func NewNetworkManager(cfg *config.Config, store *meta.Store, ctrMgr ContainerMg
 		controller:    controller,
 		config:        cfg.NetworkConfig,
 		eventsService: eventsService,
+		ctrMgr:        ctrMgr,
 	}, nil
 }
 
@@ -134,6 +144,14 @@ func (nm *NetworkManager) Create(ctx context.Context, create apitypes.NetworkCre
 		return nil, errors.Wrapf(errtypes.ErrAlreadyExisted, "network %s", name)
 	}
 
+	if err := nm.validateDriverOptions(create); err != nil {
+		return nil, err
+	}
+
+	if err := nm.validateSubnets(create); err != nil {
+		return nil, err
+	}
+
 	net, err := nm.controller.NewNetwork(driver, name, id, nwOptions...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create network")
@@ -171,11 +189,23 @@ func (nm *NetworkManager) Get(ctx context.Context, idName string) (*types.Networ
 	return n, err
 }
 
-// List returns all networks on this host.
-func (nm *NetworkManager) List(ctx context.Context, labels map[string]string) ([]*types.Network, error) {
+// List returns the networks on this host which match the filter.
+func (nm *NetworkManager) List(ctx context.Context, filter filters.Args) ([]*types.Network, error) {
+	if err := filter.Validate(acceptedNetworkFilterTags); err != nil {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+	for _, t := range filter.Get("type") {
+		if t != networkTypeBuiltin && t != networkTypeCustom {
+			return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid filter type=%s, valid choices are %s or %s", t, networkTypeBuiltin, networkTypeCustom)
+		}
+	}
+
 	nw := nm.controller.Networks()
 	var net []*types.Network
 	for _, n := range nw {
+		if !matchNetworkFilter(filter, n) {
+			continue
+		}
 		nm := &types.Network{
 			Name:    n.Name(),
 			ID:      n.ID(),
@@ -187,7 +217,8 @@ func (nm *NetworkManager) List(ctx context.Context, labels map[string]string) ([
 	return net, nil
 }
 
-// Remove is used to delete an existing network.
+// Remove is used to delete an existing network, the pre-defined networks
+// and the networks connected by containers can't be removed.
 func (nm *NetworkManager) Remove(ctx context.Context, name string) error {
 	nw, err := nm.controller.NetworkByName(name)
 	if err != nil {
@@ -200,6 +231,14 @@ func (nm *NetworkManager) Remove(ctx context.Context, name string) error {
 		return nil
 	}
 
+	if isPredefinedNetwork(nw.Name()) {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "%s is a pre-defined network and cannot be removed", nw.Name())
+	}
+
+	if containers := networkContainers(nw); len(containers) > 0 {
+		return errors.Wrapf(errtypes.ErrInUse, "network %s has connected containers %v, disconnect them first", nw.Name(), containers)
+	}
+
 	if err := nw.Delete(); err != nil {
 		return err
 	}
@@ -275,7 +314,7 @@ func isNoSuchNetworkError(err error) bool {
 }
 
 // EndpointCreate is used to create network endpoint.
-func (nm *NetworkManager) EndpointCreate(ctx context.Context, endpoint *types.Endpoint) (string, error) {
+func (nm *NetworkManager) EndpointCreate(ctx context.Context, endpoint *types.Endpoint) (_ string, err0 error) {
 	containerID := endpoint.Owner
 	network := endpoint.Name
 	networkConfig := endpoint.NetworkConfig
@@ -307,13 +346,19 @@ func (nm *NetworkManager) EndpointCreate(ctx context.Context, endpoint *types.En
 		ep.Delete(true)
 	}
 
+	if err := nm.checkAddressConflict(ctx, n, endpoint); err != nil {
+		return "", err
+	}
+
 	ep, err := n.CreateEndpoint(endpointName, epOptions...)
 	if err != nil {
 		return "", err
 	}
 
+	// the endpoint is deleted on any failure below, so that its address
+	// is released to the pool.
 	defer func() {
-		if err != nil {
+		if err0 != nil {
 			if err := ep.Delete(true); err != nil {
 				logrus.Errorf("failed to delete endpoint %s after failing to create endpoint(%v)", ep.Name(), err)
 			}
@@ -397,15 +442,18 @@ func (nm *NetworkManager) EndpointRemove(ctx context.Context, endpoint *types.En
 	logrus.Debugf("remove endpoint(%s) on network(%s)", epConfig.EndpointID, endpoint.Name)
 
 	if sid == "" {
+		nm.releaseStaleEndpoint(endpoint)
 		return nil
 	}
 
 	// find endpoint in network and delete it.
 	sb, err := nm.controller.SandboxByID(sid)
 	if err != nil {
+		nm.releaseStaleEndpoint(endpoint)
 		return errors.Wrapf(err, "failed to get sandbox by id(%s)", sid)
 	}
 	if sb == nil {
+		nm.releaseStaleEndpoint(endpoint)
 		return errors.Errorf("failed to get sandbox by id(%s)", sid)
 	}
 
@@ -422,6 +470,7 @@ func (nm *NetworkManager) EndpointRemove(ctx context.Context, endpoint *types.En
 	}
 
 	if ep == nil {
+		nm.releaseStaleEndpoint(endpoint)
 		return errors.Errorf("not connected to the network(%s)", endpoint.Name)
 	}
 
@@ -613,6 +662,13 @@ func endpointOptions(n libnetwork.Network, endpoint *types.Endpoint) ([]libnetwo
 		}
 	}
 
+	// the name of endpoint is the short id of container, so the name of
+	// container is registered as an alias to be resolved by embedded DNS.
+	if !endpoint.DisableResolver && endpoint.ContainerName != "" &&
+		(epConfig == nil || !utils.StringInSlice(epConfig.Aliases, endpoint.ContainerName)) {
+		createOptions = append(createOptions, libnetwork.CreateOptionMyAlias(endpoint.ContainerName))
+	}
+
 	// generate genric endpoint options
 	genericOption := options.Generic{}
 	if len(endpoint.GenericParams) > 0 {
@@ -693,8 +749,17 @@ func buildSandboxOptions(config network.Config, endpoint *types.Endpoint) ([]lib
 		sandboxOptions = append(sandboxOptions, libnetwork.OptionDNSOptions(ds))
 	}
 
+	// parse extra hosts in format of host:ip
+	for _, h := range endpoint.ExtraHosts {
+		parts := strings.SplitN(h, ":", 2)
+		if len(parts) != 2 {
+			logrus.Warnf("invalid extra host %s, skip it", h)
+			continue
+		}
+		sandboxOptions = append(sandboxOptions, libnetwork.OptionExtraHost(parts[0], parts[1]))
+	}
+
 	// TODO: secondary ip address
-	// TODO: parse extra hosts
 	var bindings = make(nat.PortMap)
 	if endpoint.PortBindings != nil {
 		for p, b := range endpoint.PortBindings {
@@ -839,3 +904,85 @@ func getEndpointPortMapInfo(ep libnetwork.Endpoint) (apitypes.PortMap, error) {
 
 	return pm, nil
 }
+
+// releaseStaleEndpoint deletes the endpoint of container which isn't in any
+// sandbox by force, so that its address is released to the pool.
+func (nm *NetworkManager) releaseStaleEndpoint(endpoint *types.Endpoint) {
+	n, err := nm.controller.NetworkByName(endpoint.Name)
+	if err != nil {
+		return
+	}
+
+	var ep libnetwork.Endpoint
+	if endpoint.EndpointConfig != nil && endpoint.EndpointConfig.EndpointID != "" {
+		ep, _ = n.EndpointByID(endpoint.EndpointConfig.EndpointID)
+	}
+	if ep == nil && len(endpoint.Owner) >= 8 {
+		ep, _ = n.EndpointByName(endpoint.Owner[:8])
+	}
+	if ep == nil {
+		return
+	}
+
+	logrus.Infof("release stale endpoint %s of container %s on network %s", ep.ID(), endpoint.Owner, n.Name())
+	if err := ep.Delete(true); err != nil {
+		logrus.Warnf("failed to delete stale endpoint %s on network %s: %v", ep.ID(), n.Name(), err)
+	}
+}
+
+// checkAddressConflict returns ErrConflict if the user specified IP or MAC
+// address of endpoint is held by another endpoint on the network, and the
+// container owning the address is named in the error.
+func (nm *NetworkManager) checkAddressConflict(ctx context.Context, n libnetwork.Network, endpoint *types.Endpoint) error {
+	var (
+		ipv4, ipv6 net.IP
+		mac        net.HardwareAddr
+	)
+	if ipam := endpoint.EndpointConfig.IPAMConfig; ipam != nil {
+		ipv4, ipv6 = net.ParseIP(ipam.IPV4Address), net.ParseIP(ipam.IPV6Address)
+	}
+	if n.Name() == endpoint.NetworkMode && endpoint.MacAddress != "" {
+		mac, _ = net.ParseMAC(endpoint.MacAddress)
+	}
+	if ipv4 == nil && ipv6 == nil && mac == nil {
+		return nil
+	}
+
+	for _, ep := range n.Endpoints() {
+		iface := ep.Info().Iface()
+		if iface == nil {
+			continue
+		}
+
+		var held string
+		switch {
+		case ipv4 != nil && iface.Address() != nil && iface.Address().IP.Equal(ipv4):
+			held = ipv4.String()
+		case ipv6 != nil && iface.AddressIPv6() != nil && iface.AddressIPv6().IP.Equal(ipv6):
+			held = ipv6.String()
+		case mac != nil && bytes.Equal(iface.MacAddress(), mac):
+			held = mac.String()
+		default:
+			continue
+		}
+		return errors.Wrapf(errtypes.ErrConflict, "address %s is already in use by container %s on network %s",
+			held, nm.endpointOwner(ctx, ep), n.Name())
+	}
+	return nil
+}
+
+// endpointOwner returns the name of container owning the endpoint, or the
+// name of endpoint which is the short id of container if it's not found.
+func (nm *NetworkManager) endpointOwner(ctx context.Context, ep libnetwork.Endpoint) string {
+	id := ep.Name()
+	if sb := ep.Info().Sandbox(); sb != nil {
+		id = sb.ContainerID()
+	}
+
+	if nm.ctrMgr != nil {
+		if c, err := nm.ctrMgr.Get(ctx, id); err == nil {
+			return c.Name
+		}
+	}
+	return id
+}
@@ -0,0 +1,92 @@
+package mgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/docker/libnetwork"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// acceptedPruneNetworkFilterTags are the filters supported by network prune.
+var acceptedPruneNetworkFilterTags = map[string]bool{
+	labelFilter:    true,
+	notLabelFilter: true,
+	"until":        true,
+}
+
+// Prune removes the user defined networks which are not used by any
+// container, and returns the names of deleted networks. A network is used
+// if it has endpoints, or is recorded in the network settings of containers
+// even if they are stopped. The pre-defined networks are never removed.
+func (nm *NetworkManager) Prune(ctx context.Context, filter filters.Args) (*apitypes.NetworkPruneResp, error) {
+	if err := filter.Validate(acceptedPruneNetworkFilterTags); err != nil {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	until, err := parseUntilFilter(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	labels, notLabels := filter.Get(labelFilter), filter.Get(notLabelFilter)
+
+	used, err := nm.usedNetworks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apitypes.NetworkPruneResp{NetworksDeleted: []string{}}
+	for _, n := range nm.controller.Networks() {
+		if isPredefinedNetwork(n.Name()) || used[n.Name()] || !matchPruneNetwork(n, until, labels, notLabels) {
+			continue
+		}
+
+		if err := nm.Remove(ctx, n.Name()); err != nil {
+			logrus.Warnf("failed to remove network %s during prune networks: %v", n.Name(), err)
+			continue
+		}
+		resp.NetworksDeleted = append(resp.NetworksDeleted, n.Name())
+	}
+	return resp, nil
+}
+
+// usedNetworks returns the names of networks which are recorded in the
+// network settings of containers.
+func (nm *NetworkManager) usedNetworks(ctx context.Context) (map[string]bool, error) {
+	used := make(map[string]bool)
+	if nm.ctrMgr == nil {
+		return used, nil
+	}
+
+	containers, err := nm.ctrMgr.List(ctx, &ContainerListOption{All: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get container list")
+	}
+	for _, c := range containers {
+		if c.NetworkSettings == nil {
+			continue
+		}
+		for name := range c.NetworkSettings.Networks {
+			used[name] = true
+		}
+	}
+	return used, nil
+}
+
+// matchPruneNetwork returns whether the network matches the until and label
+// filters of prune, and has no endpoints.
+func matchPruneNetwork(n libnetwork.Network, until time.Time, labels, notLabels []string) bool {
+	if len(n.Endpoints()) > 0 {
+		return false
+	}
+
+	if !until.IsZero() && !n.Info().Created().Before(until) {
+		return false
+	}
+	return matchPruneLabels(n.Info().Labels(), labels, notLabels)
+}
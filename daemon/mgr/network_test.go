@@ -2,6 +2,7 @@ package mgr
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	apitypes "github.com/alibaba/pouch/apis/types"
@@ -101,3 +102,36 @@ func Test_getIpamConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateMacvlanOptions(t *testing.T) {
+	ipam := &apitypes.IPAM{
+		Config: []apitypes.IPAMConfig{{Subnet: "10.10.100.0/24", Gateway: "10.10.100.1"}},
+	}
+
+	for _, tc := range []struct {
+		create apitypes.NetworkCreate
+		err    string
+	}{
+		{create: apitypes.NetworkCreate{IPAM: ipam}},
+		{create: apitypes.NetworkCreate{}, err: "subnet and gateway are required"},
+		{
+			create: apitypes.NetworkCreate{IPAM: &apitypes.IPAM{Config: []apitypes.IPAMConfig{{Subnet: "10.10.100.0/24"}}}},
+			err:    "subnet and gateway are required",
+		},
+		{create: apitypes.NetworkCreate{IPAM: ipam, Options: map[string]string{"parent": "lo"}}, err: "loopback interface"},
+		{create: apitypes.NetworkCreate{IPAM: ipam, Options: map[string]string{"parent": "nonexist0"}}, err: "parent interface nonexist0 of macvlan network doesn't exist"},
+		{create: apitypes.NetworkCreate{IPAM: ipam, Options: map[string]string{"parent": "nonexist0.100"}}, err: "interface nonexist0 of parent nonexist0.100 doesn't exist"},
+		{create: apitypes.NetworkCreate{IPAM: ipam, Options: map[string]string{"parent": "nonexist0.5000"}}, err: "invalid vlan id 5000"},
+	} {
+		err := validateMacvlanOptions(tc.create)
+		if tc.err == "" {
+			if err != nil {
+				t.Errorf("validateMacvlanOptions(%+v) got unexpected error: %v", tc.create, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("validateMacvlanOptions(%+v) got error %v, want %q", tc.create, err, tc.err)
+		}
+	}
+}
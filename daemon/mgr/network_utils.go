@@ -2,13 +2,36 @@ package mgr
 
 import (
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
 
 	"github.com/docker/libnetwork"
+	"github.com/pkg/errors"
 )
 
+const (
+	// networkTypeBuiltin is the type of pre-defined networks.
+	networkTypeBuiltin = "builtin"
+
+	// networkTypeCustom is the type of networks created by user.
+	networkTypeCustom = "custom"
+)
+
+// acceptedNetworkFilterTags are the filters supported by network list.
+var acceptedNetworkFilterTags = map[string]bool{
+	"driver": true,
+	"id":     true,
+	"label":  true,
+	"name":   true,
+	"type":   true,
+}
+
 // IsContainer is used to check if network mode is container mode.
 func IsContainer(mode string) bool {
 	parts := strings.SplitN(mode, ":", 2)
@@ -50,7 +73,8 @@ func hasUserDefinedIPAddress(epConfig *types.EndpointSettings) bool {
 	return epConfig != nil && epConfig.IPAMConfig != nil && (len(epConfig.IPAMConfig.IPV4Address) > 0 || len(epConfig.IPAMConfig.IPV6Address) > 0)
 }
 
-// User specified ip address is acceptable only for networks with user specified subnets.
+// User specified ip address is acceptable only for networks with user specified subnets,
+// and the address must be in one of the subnets and not be the gateway.
 func validateNetworkingConfig(network libnetwork.Network, epConfig *types.EndpointSettings) error {
 	if network == nil || epConfig == nil {
 		return nil
@@ -61,28 +85,206 @@ func validateNetworkingConfig(network libnetwork.Network, epConfig *types.Endpoi
 
 	_, _, nwIPv4Configs, nwIPv6Configs := network.Info().IpamConfig()
 	for _, s := range []struct {
-		ipConfigured  bool
+		ip            string
 		subnetConfigs []*libnetwork.IpamConf
 	}{
 		{
-			ipConfigured:  len(epConfig.IPAMConfig.IPV4Address) > 0,
+			ip:            epConfig.IPAMConfig.IPV4Address,
 			subnetConfigs: nwIPv4Configs,
 		},
 		{
-			ipConfigured:  len(epConfig.IPAMConfig.IPV6Address) > 0,
+			ip:            epConfig.IPAMConfig.IPV6Address,
 			subnetConfigs: nwIPv6Configs,
 		},
 	} {
-		if s.ipConfigured {
-			foundSubnet := false
-			for _, cfg := range s.subnetConfigs {
-				if len(cfg.PreferredPool) > 0 {
-					foundSubnet = true
-					break
+		if s.ip == "" {
+			continue
+		}
+		ip := net.ParseIP(s.ip)
+		if ip == nil {
+			return fmt.Errorf("invalid IP address: %s", s.ip)
+		}
+
+		foundSubnet, inSubnet := false, false
+		for _, cfg := range s.subnetConfigs {
+			if len(cfg.PreferredPool) == 0 {
+				continue
+			}
+			foundSubnet = true
+
+			if _, subnet, err := net.ParseCIDR(cfg.PreferredPool); err == nil && subnet.Contains(ip) {
+				if gw := net.ParseIP(cfg.Gateway); gw != nil && gw.Equal(ip) {
+					return fmt.Errorf("user specified IP address %s is the gateway of network %s", s.ip, network.Name())
 				}
+				inSubnet = true
 			}
-			if !foundSubnet {
-				return fmt.Errorf("user specified IP address is supported only when connecting to networks with user configured subnets")
+		}
+		if !foundSubnet {
+			return fmt.Errorf("user specified IP address is supported only when connecting to networks with user configured subnets")
+		}
+		if !inSubnet {
+			return fmt.Errorf("user specified IP address %s is not in the subnets of network %s", s.ip, network.Name())
+		}
+	}
+
+	return nil
+}
+
+// isPredefinedNetwork returns true if the network is created by pouchd.
+func isPredefinedNetwork(name string) bool {
+	return IsBridge(name) || IsHost(name) || IsNone(name)
+}
+
+// matchNetworkFilter returns true if the network matches the filter, the
+// name matches part of the network name and the id matches the prefix.
+func matchNetworkFilter(filter filters.Args, n libnetwork.Network) bool {
+	if filter.Contains("name") && !matchAny(filter.Get("name"), func(v string) bool { return strings.Contains(n.Name(), v) }) {
+		return false
+	}
+	if filter.Contains("id") && !matchAny(filter.Get("id"), func(v string) bool { return strings.HasPrefix(n.ID(), v) }) {
+		return false
+	}
+	if !filter.ExactMatch("driver", n.Type()) {
+		return false
+	}
+
+	typ := networkTypeCustom
+	if isPredefinedNetwork(n.Name()) {
+		typ = networkTypeBuiltin
+	}
+	if !filter.ExactMatch("type", typ) {
+		return false
+	}
+
+	return filter.MatchKVList("label", n.Info().Labels())
+}
+
+// matchAny returns true if any of the values matches.
+func matchAny(values []string, match func(string) bool) bool {
+	for _, v := range values {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// networkContainers returns the IDs of containers connected to the network.
+func networkContainers(n libnetwork.Network) []string {
+	var containers []string
+	for _, ep := range n.Endpoints() {
+		if sb := ep.Info().Sandbox(); sb != nil {
+			containers = append(containers, sb.ContainerID())
+		}
+	}
+	sort.Strings(containers)
+	return containers
+}
+
+// fullContainerIDLength is the length of container ID generated by randomid.
+const fullContainerIDLength = 64
+
+// forceDeleteEndpoint deletes the endpoint of container on network by force.
+// The container may have been removed, so the endpoint is found by the full
+// container id of its sandbox, or by its name which is the short id if it
+// has no sandbox. The prefix of id isn't accepted, since it may match the
+// endpoint of another container.
+func forceDeleteEndpoint(n libnetwork.Network, containerID string) error {
+	if len(containerID) == fullContainerIDLength {
+		for _, ep := range n.Endpoints() {
+			if sb := ep.Info().Sandbox(); sb != nil {
+				if sb.ContainerID() == containerID {
+					return ep.Delete(true)
+				}
+				continue
+			}
+
+			if ep.Name() == containerID[:8] {
+				return ep.Delete(true)
+			}
+		}
+	}
+	return errors.Wrapf(errtypes.ErrNotfound, "no endpoint of container %s on network %s", containerID, n.Name())
+}
+
+// validateDriverOptions validates the options of network driver, so that an
+// invalid network fails at create rather than when the first container starts.
+func (nm *NetworkManager) validateDriverOptions(create types.NetworkCreateConfig) error {
+	switch create.NetworkCreate.Driver {
+	case "host":
+		for _, n := range nm.controller.Networks() {
+			if n.Type() == "host" {
+				return errors.Wrapf(errtypes.ErrAlreadyExisted, "only one network of host driver is allowed, network %s exists", n.Name())
+			}
+		}
+	case "macvlan":
+		return validateMacvlanOptions(create.NetworkCreate)
+	}
+	return nil
+}
+
+// validateMacvlanOptions validates the subnet, gateway and parent interface
+// of macvlan network. The parent in format of <interface>.<vlan id> is
+// created by the driver on the interface if it doesn't exist.
+func validateMacvlanOptions(create types.NetworkCreate) error {
+	if create.IPAM == nil || len(create.IPAM.Config) == 0 {
+		return errors.Wrap(errtypes.ErrInvalidParam, "subnet and gateway are required by macvlan network")
+	}
+	for _, cfg := range create.IPAM.Config {
+		if cfg.Subnet == "" || cfg.Gateway == "" {
+			return errors.Wrap(errtypes.ErrInvalidParam, "subnet and gateway are required by macvlan network")
+		}
+	}
+
+	parent := create.Options["parent"]
+	if parent == "" {
+		return nil
+	}
+	if parent == "lo" {
+		return errors.Wrap(errtypes.ErrInvalidParam, "loopback interface lo can't be the parent of macvlan network")
+	}
+	if _, err := net.InterfaceByName(parent); err == nil {
+		return nil
+	}
+
+	if i := strings.LastIndex(parent, "."); i > 0 {
+		if vlan, err := strconv.Atoi(parent[i+1:]); err == nil {
+			if vlan < 1 || vlan > 4094 {
+				return errors.Wrapf(errtypes.ErrInvalidParam, "invalid vlan id %d of parent interface %s, should be in range [1, 4094]", vlan, parent)
+			}
+			if _, err := net.InterfaceByName(parent[:i]); err != nil {
+				return errors.Wrapf(errtypes.ErrInvalidParam, "interface %s of parent %s doesn't exist", parent[:i], parent)
+			}
+			return nil
+		}
+	}
+	return errors.Wrapf(errtypes.ErrInvalidParam, "parent interface %s of macvlan network doesn't exist", parent)
+}
+
+// validateSubnets validates the subnets of network to create, which can't
+// overlap with the subnets of existing networks.
+func (nm *NetworkManager) validateSubnets(create types.NetworkCreateConfig) error {
+	if create.NetworkCreate.IPAM == nil {
+		return nil
+	}
+
+	for _, cfg := range create.NetworkCreate.IPAM.Config {
+		if cfg.Subnet == "" {
+			continue
+		}
+		_, subnet, err := net.ParseCIDR(cfg.Subnet)
+		if err != nil {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "invalid subnet %s: %v", cfg.Subnet, err)
+		}
+
+		for _, n := range nm.controller.Networks() {
+			ipv4Info, ipv6Info := n.Info().IpamInfo()
+			for _, info := range append(ipv4Info, ipv6Info...) {
+				pool := info.IPAMData.Pool
+				if pool == nil || !(pool.Contains(subnet.IP) || subnet.Contains(pool.IP)) {
+					continue
+				}
+				return errors.Wrapf(errtypes.ErrConflict, "subnet %s overlaps with subnet %s of network %s", cfg.Subnet, pool, n.Name())
 			}
 		}
 	}
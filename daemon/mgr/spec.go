@@ -18,6 +18,7 @@ type SpecWrapper struct {
 	prioArr    []int
 	argsArr    [][]string
 	useSystemd bool
+	initPath   string
 }
 
 // All the functions related to the spec is lock-free for container instance,
@@ -46,6 +47,11 @@ func createSpec(ctx context.Context, c *Container, specWrapper *SpecWrapper) err
 		return err
 	}
 
+	// run init as the pid 1 of container
+	if err := setupInit(ctx, c, specWrapper); err != nil {
+		return err
+	}
+
 	// create Spec.Annotations
 	if err := setupAnnotations(ctx, c, s); err != nil {
 		return err
@@ -12,10 +12,10 @@ import (
 func setupAppArmor(ctx context.Context, c *Container, s *specs.Spec) error {
 	if apparmor.IsEnabled() {
 		appArmorProfile := ""
-		if c.AppArmorProfile != "" {
+		if c.HostConfig.Privileged {
+			appArmorProfile = ProfileUnconfined
+		} else if c.AppArmorProfile != "" {
 			appArmorProfile = c.AppArmorProfile
-		} else if c.HostConfig.Privileged {
-			appArmorProfile = "unconfined"
 		} else {
 			// TODO: generate pouch-default apparmor profile
 			// appArmorProfile = "pouch-default"
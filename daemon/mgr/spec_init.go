@@ -0,0 +1,36 @@
+package mgr
+
+import (
+	"context"
+	"os/exec"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// containerInitPath is the path of init binary in container.
+const containerInitPath = "/dev/init"
+
+// setupInit bind mounts the init binary of daemon into container and runs it
+// as the pid 1, which reaps the zombies and forwards the signals to the
+// command of container. The command is wrapped even if it is an init already.
+func setupInit(ctx context.Context, c *Container, specWrapper *SpecWrapper) error {
+	if c.HostConfig.Init == nil || !*c.HostConfig.Init {
+		return nil
+	}
+
+	path, err := exec.LookPath(specWrapper.initPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find init binary %s", specWrapper.initPath)
+	}
+
+	s := specWrapper.s
+	s.Process.Args = append([]string{containerInitPath, "--"}, s.Process.Args...)
+	s.Mounts = append(s.Mounts, specs.Mount{
+		Source:      path,
+		Destination: containerInitPath,
+		Type:        "bind",
+		Options:     []string{"rbind", "ro"},
+	})
+	return nil
+}
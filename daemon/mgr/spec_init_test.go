@@ -0,0 +1,57 @@
+package mgr
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupInit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "setup-init")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	initPath := filepath.Join(dir, "init")
+	assert.NoError(t, ioutil.WriteFile(initPath, []byte("#!/bin/sh\n"), 0755))
+
+	enabled, disabled := true, false
+	for _, tc := range []struct {
+		init     *bool
+		initPath string
+		args     []string
+		mounts   []specs.Mount
+		err      bool
+	}{
+		{init: nil, initPath: initPath, args: []string{"top"}},
+		{init: &disabled, initPath: initPath, args: []string{"top"}},
+		{
+			init:     &enabled,
+			initPath: initPath,
+			args:     []string{"/dev/init", "--", "top"},
+			mounts:   []specs.Mount{{Source: initPath, Destination: "/dev/init", Type: "bind", Options: []string{"rbind", "ro"}}},
+		},
+		{init: &enabled, initPath: filepath.Join(dir, "missing"), err: true},
+	} {
+		c := &Container{HostConfig: &types.HostConfig{Init: tc.init}}
+		sw := &SpecWrapper{
+			s:        &specs.Spec{Process: &specs.Process{Args: []string{"top"}}},
+			initPath: tc.initPath,
+		}
+
+		err := setupInit(context.Background(), c, sw)
+		if tc.err {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.args, sw.s.Process.Args)
+		assert.Equal(t, tc.mounts, sw.s.Mounts)
+	}
+}
@@ -171,14 +171,13 @@ func setupCPU(ctx context.Context, r types.Resources, s *specs.Spec) {
 		cpu.Shares = &v
 	}
 
-	if r.CPUPeriod != 0 {
-		v := uint64(r.CPUPeriod)
-		cpu.Period = &v
+	quota, period := ctrd.GetCPUQuotaAndPeriod(r)
+	if period != 0 {
+		cpu.Period = &period
 	}
 
-	if r.CPUQuota != 0 {
-		v := int64(r.CPUQuota)
-		cpu.Quota = &v
+	if quota != 0 {
+		cpu.Quota = &quota
 	}
 
 	s.Linux.Resources.CPU = cpu
@@ -197,6 +196,11 @@ func setupMemory(ctx context.Context, r types.Resources, s *specs.Spec) {
 		memory.Swap = &v
 	}
 
+	if r.MemoryReservation > 0 {
+		v := r.MemoryReservation
+		memory.Reservation = &v
+	}
+
 	if r.MemorySwappiness != nil && *r.MemorySwappiness != -1 {
 		v := uint64(*r.MemorySwappiness)
 		memory.Swappiness = &v
@@ -245,6 +249,20 @@ func setupDevices(ctx context.Context, c *Container, s *specs.Spec) error {
 			devs = append(devs, d...)
 			devPermissions = append(devPermissions, dPermissions...)
 		}
+
+		for _, r := range c.HostConfig.DeviceCgroupRules {
+			rule, err := opts.ParseDeviceCgroupRule(r)
+			if err != nil {
+				return err
+			}
+			devPermissions = append(devPermissions, specs.LinuxDeviceCgroup{
+				Allow:  true,
+				Type:   rule.Type,
+				Major:  rule.Major,
+				Minor:  rule.Minor,
+				Access: rule.Access,
+			})
+		}
 	}
 
 	s.Linux.Devices = append(s.Linux.Devices, devs...)
@@ -373,32 +391,6 @@ func connectedContainer(mode string) string {
 	return ""
 }
 
-func getIpcContainer(ctx context.Context, mgr ContainerMgr, id string) (*Container, error) {
-	// Check whether the container exists.
-	c, err := mgr.Get(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("can't join IPC namespace of container %q: %v", id, err)
-	}
-
-	// TODO: check whether the container is running and not restarting.
-
-	// TODO: check whether the container's ipc namespace is shareable.
-
-	return c, nil
-}
-
-func getPidContainer(ctx context.Context, mgr ContainerMgr, id string) (*Container, error) {
-	// Check the container exists.
-	c, err := mgr.Get(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("can't join PID namespace of %q: %v", id, err)
-	}
-
-	// TODO: check whether the container is running and not restarting.
-
-	return c, nil
-}
-
 // TODO
 func setupUserNamespace(ctx context.Context, c *Container, specWrapper *SpecWrapper) error {
 	return nil
@@ -414,15 +406,10 @@ func setupNetworkNamespace(ctx context.Context, c *Container, specWrapper *SpecW
 
 	networkMode := c.HostConfig.NetworkMode
 	if IsContainer(networkMode) {
-		origContainer, err := specWrapper.ctrMgr.Get(ctx, strings.SplitN(networkMode, ":", 2)[1])
+		origContainer, err := getNamespaceContainer(ctx, specWrapper.ctrMgr, c, "network", connectedContainer(networkMode))
 		if err != nil {
 			return err
 		}
-		if c.ID == origContainer.ID {
-			return fmt.Errorf("can not join own network")
-		} else if origContainer.State.Status != types.StatusRunning {
-			return fmt.Errorf("can not join network of a non running container: %s", origContainer.ID)
-		}
 
 		ns.Path = fmt.Sprintf("/proc/%d/ns/net", origContainer.State.Pid)
 	} else if IsHost(networkMode) {
@@ -453,11 +440,11 @@ func setupIpcNamespace(ctx context.Context, c *Container, specWrapper *SpecWrapp
 	switch {
 	case isContainer(ipcMode):
 		ns := specs.LinuxNamespace{Type: specs.IPCNamespace}
-		c, err := getIpcContainer(ctx, specWrapper.ctrMgr, connectedContainer(ipcMode))
+		target, err := getNamespaceContainer(ctx, specWrapper.ctrMgr, c, "IPC", connectedContainer(ipcMode))
 		if err != nil {
-			return fmt.Errorf("setup container ipc namespace mode failed: %v", err)
+			return err
 		}
-		ns.Path = fmt.Sprintf("/proc/%d/ns/ipc", c.State.Pid)
+		ns.Path = fmt.Sprintf("/proc/%d/ns/ipc", target.State.Pid)
 		setNamespace(s, ns)
 	case isHost(ipcMode):
 		removeNamespace(s, specs.IPCNamespace)
@@ -474,11 +461,11 @@ func setupPidNamespace(ctx context.Context, c *Container, specWrapper *SpecWrapp
 	switch {
 	case isContainer(pidMode):
 		ns := specs.LinuxNamespace{Type: specs.PIDNamespace}
-		c, err := getPidContainer(ctx, specWrapper.ctrMgr, connectedContainer(pidMode))
+		target, err := getNamespaceContainer(ctx, specWrapper.ctrMgr, c, "PID", connectedContainer(pidMode))
 		if err != nil {
-			return fmt.Errorf("setup container pid namespace mode failed: %v", err)
+			return err
 		}
-		ns.Path = fmt.Sprintf("/proc/%d/ns/pid", c.State.Pid)
+		ns.Path = fmt.Sprintf("/proc/%d/ns/pid", target.State.Pid)
 		setNamespace(s, ns)
 	case isHost(pidMode):
 		removeNamespace(s, specs.PIDNamespace)
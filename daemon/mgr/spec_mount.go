@@ -74,6 +74,11 @@ func mergeContainerMount(mounts []specs.Mount, c *Container, s *specs.Spec) ([]s
 			}
 		}
 
+		if mp.Type == "tmpfs" {
+			mounts = append(mounts, generateTmpfsMount(mp, c))
+			continue
+		}
+
 		pg := mp.Propagation
 		rootfspg := s.Linux.RootfsPropagation
 		// Set rootfs propagation, default setting is private.
@@ -115,6 +120,49 @@ func mergeContainerMount(mounts []specs.Mount, c *Container, s *specs.Spec) ([]s
 	return mounts, nil
 }
 
+// generateTmpfsMount returns the tmpfs mount spec of mount point with the
+// options of the mount or tmpfs in HostConfig.
+func generateTmpfsMount(mp *types.MountPoint, c *Container) specs.Mount {
+	opts := []string{"nosuid", "nodev"}
+	if !mp.RW {
+		opts = append(opts, "ro")
+	}
+
+	dest := filepath.Clean(mp.Destination)
+	for _, m := range c.HostConfig.Mounts {
+		if m.Type != "tmpfs" || filepath.Clean(m.Target) != dest || m.TmpfsOptions == nil {
+			continue
+		}
+		if m.TmpfsOptions.SizeBytes > 0 {
+			opts = append(opts, fmt.Sprintf("size=%d", m.TmpfsOptions.SizeBytes))
+		}
+		if m.TmpfsOptions.Mode != 0 {
+			opts = append(opts, fmt.Sprintf("mode=%o", m.TmpfsOptions.Mode))
+		}
+		break
+	}
+
+	// the options of --tmpfs, the read only mode is decided by mount point.
+	for target, options := range c.HostConfig.Tmpfs {
+		if filepath.Clean(target) != dest || options == "" {
+			continue
+		}
+		for _, o := range strings.Split(options, ",") {
+			if o != "ro" && o != "rw" {
+				opts = append(opts, o)
+			}
+		}
+		break
+	}
+
+	return specs.Mount{
+		Source:      "tmpfs",
+		Destination: mp.Destination,
+		Type:        "tmpfs",
+		Options:     opts,
+	}
+}
+
 // setupMounts create mount spec.
 func setupMounts(ctx context.Context, c *Container, s *specs.Spec) error {
 	var (
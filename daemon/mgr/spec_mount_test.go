@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/alibaba/pouch/apis/types"
+
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -39,3 +41,49 @@ func Test_sortMounts(t *testing.T) {
 		})
 	}
 }
+
+func Test_generateTmpfsMount(t *testing.T) {
+	c := &Container{
+		HostConfig: &types.HostConfig{
+			Mounts: []*types.Mount{
+				{Type: "bind", Source: "/tmp", Target: "/tmp"},
+				{Type: "tmpfs", Target: "/run/", TmpfsOptions: &types.MountTmpfsOptions{SizeBytes: 1024, Mode: 01770}},
+				{Type: "tmpfs", Target: "/cache", ReadOnly: true},
+			},
+		},
+	}
+
+	got := generateTmpfsMount(&types.MountPoint{Type: "tmpfs", Source: "tmpfs", Destination: "/run", RW: true}, c)
+	want := specs.Mount{Source: "tmpfs", Destination: "/run", Type: "tmpfs", Options: []string{"nosuid", "nodev", "size=1024", "mode=1770"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generateTmpfsMount() = %v, want %v", got, want)
+	}
+
+	got = generateTmpfsMount(&types.MountPoint{Type: "tmpfs", Source: "tmpfs", Destination: "/cache"}, c)
+	want = specs.Mount{Source: "tmpfs", Destination: "/cache", Type: "tmpfs", Options: []string{"nosuid", "nodev", "ro"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generateTmpfsMount() = %v, want %v", got, want)
+	}
+
+	// the options of --tmpfs
+	c.HostConfig.Tmpfs = map[string]string{"/data": "ro,noexec,size=64m"}
+	got = generateTmpfsMount(&types.MountPoint{Type: "tmpfs", Source: "tmpfs", Destination: "/data"}, c)
+	want = specs.Mount{Source: "tmpfs", Destination: "/data", Type: "tmpfs", Options: []string{"nosuid", "nodev", "ro", "noexec", "size=64m"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generateTmpfsMount() = %v, want %v", got, want)
+	}
+
+	// the destinations are compared after being cleaned.
+	c.HostConfig.Tmpfs = map[string]string{"/data/": "noexec"}
+	got = generateTmpfsMount(&types.MountPoint{Type: "tmpfs", Source: "tmpfs", Destination: "/data//", RW: true}, c)
+	want = specs.Mount{Source: "tmpfs", Destination: "/data//", Type: "tmpfs", Options: []string{"nosuid", "nodev", "noexec"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generateTmpfsMount() = %v, want %v", got, want)
+	}
+
+	got = generateTmpfsMount(&types.MountPoint{Type: "tmpfs", Source: "tmpfs", Destination: "/run/", RW: true}, c)
+	want = specs.Mount{Source: "tmpfs", Destination: "/run/", Type: "tmpfs", Options: []string{"nosuid", "nodev", "size=1024", "mode=1770"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generateTmpfsMount() = %v, want %v", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package mgr
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
@@ -85,17 +86,13 @@ func setupUser(ctx context.Context, c *Container, s *specs.Spec) (err error) {
 }
 
 func setupCapabilities(ctx context.Context, hostConfig *types.HostConfig, s *specs.Spec) error {
-	var caplist []string
-	var err error
-
 	if s.Process.Capabilities == nil {
 		s.Process.Capabilities = &specs.LinuxCapabilities{}
 	}
 	capabilities := s.Process.Capabilities
 
-	if hostConfig.Privileged {
-		caplist = caps.GetAllCapabilities()
-	} else if caplist, err = caps.TweakCapabilities(capabilities.Effective, hostConfig.CapAdd, hostConfig.CapDrop); err != nil {
+	caplist, err := effectiveCapabilities(hostConfig, capabilities.Effective)
+	if err != nil {
 		return err
 	}
 	capabilities.Effective = caplist
@@ -107,6 +104,44 @@ func setupCapabilities(ctx context.Context, hostConfig *types.HostConfig, s *spe
 	return nil
 }
 
+// effectiveCapabilities returns the capabilities of container process, which
+// are all capabilities if privileged, or the basics tweaked by CapAdd and CapDrop.
+func effectiveCapabilities(hostConfig *types.HostConfig, basics []string) ([]string, error) {
+	if hostConfig.Privileged {
+		return caps.GetAllCapabilities(), nil
+	}
+	return caps.TweakCapabilities(basics, hostConfig.CapAdd, hostConfig.CapDrop)
+}
+
+// normalizeCapabilities converts the capabilities into upper case without
+// the CAP_ prefix, such as net_admin or CAP_NET_ADMIN into NET_ADMIN. The
+// special value ALL is kept, and unknown capabilities are rejected.
+func normalizeCapabilities(capabilities []string) ([]string, error) {
+	if len(capabilities) == 0 {
+		return capabilities, nil
+	}
+
+	valid := make(map[string]bool)
+	for _, c := range caps.GetAllCapabilities() {
+		valid[strings.TrimPrefix(c, "CAP_")] = true
+	}
+
+	normalized := make([]string, 0, len(capabilities))
+	for _, c := range capabilities {
+		name := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(c)), "CAP_")
+		if name != "ALL" && !valid[name] {
+			choices := make([]string, 0, len(valid))
+			for v := range valid {
+				choices = append(choices, v)
+			}
+			sort.Strings(choices)
+			return nil, fmt.Errorf("unknown capability %q, valid choices are ALL, %s", c, strings.Join(choices, ", "))
+		}
+		normalized = append(normalized, name)
+	}
+	return normalized, nil
+}
+
 func setupRlimits(ctx context.Context, hostConfig *types.HostConfig, s *specs.Spec) error {
 	var rlimits []specs.POSIXRlimit
 	for _, ul := range hostConfig.Ulimits {
@@ -0,0 +1,52 @@
+package mgr
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/docker/docker/daemon/caps"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCapabilities(t *testing.T) {
+	for _, tc := range []struct {
+		input    []string
+		expected []string
+		err      bool
+	}{
+		{input: nil, expected: nil},
+		{input: []string{"net_admin", "CAP_SYS_ADMIN", "Cap_Net_Raw", " chown "}, expected: []string{"NET_ADMIN", "SYS_ADMIN", "NET_RAW", "CHOWN"}},
+		{input: []string{"all"}, expected: []string{"ALL"}},
+		{input: []string{"NET_ADMIN", "foo"}, err: true},
+		{input: []string{"CAP_"}, err: true},
+	} {
+		normalized, err := normalizeCapabilities(tc.input)
+		if tc.err {
+			assert.Error(t, err, "%v", tc.input)
+			assert.Contains(t, err.Error(), "valid choices are ALL, ")
+			continue
+		}
+		assert.NoError(t, err, "%v", tc.input)
+		assert.Equal(t, tc.expected, normalized)
+	}
+}
+
+func TestEffectiveCapabilities(t *testing.T) {
+	basics := []string{"CAP_CHOWN", "CAP_KILL", "CAP_NET_RAW"}
+
+	for _, tc := range []struct {
+		hostConfig *types.HostConfig
+		expected   []string
+	}{
+		{hostConfig: &types.HostConfig{}, expected: basics},
+		{hostConfig: &types.HostConfig{CapAdd: []string{"NET_ADMIN"}, CapDrop: []string{"NET_RAW"}}, expected: []string{"CAP_CHOWN", "CAP_KILL", "CAP_NET_ADMIN"}},
+		{hostConfig: &types.HostConfig{CapAdd: []string{"NET_ADMIN"}, CapDrop: []string{"ALL"}}, expected: []string{"CAP_NET_ADMIN"}},
+		{hostConfig: &types.HostConfig{CapAdd: []string{"ALL"}}, expected: caps.GetAllCapabilities()},
+		{hostConfig: &types.HostConfig{Privileged: true, CapDrop: []string{"ALL"}}, expected: caps.GetAllCapabilities()},
+	} {
+		effective, err := effectiveCapabilities(tc.hostConfig, basics)
+		assert.NoError(t, err, "%+v", tc.hostConfig)
+		assert.Equal(t, tc.expected, effective, "%+v", tc.hostConfig)
+	}
+}
@@ -4,9 +4,6 @@ package mgr
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
 
 	"github.com/containerd/containerd/contrib/seccomp"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
@@ -34,13 +31,12 @@ func setupSeccomp(ctx context.Context, c *Container, s *specs.Spec) error {
 	case ProfilePouchDefault, "":
 		s.Linux.Seccomp = seccomp.DefaultProfile(s)
 	default:
-		data, err := ioutil.ReadFile(seccompProfile)
+		capabilities, err := c.EffectiveCapabilities()
 		if err != nil {
-			return fmt.Errorf("failed to load seccomp profile %q: %v", seccompProfile, err)
+			return err
 		}
-		err = json.Unmarshal(data, s.Linux.Seccomp)
-		if err != nil {
-			return fmt.Errorf("failed to decode seccomp profile %q: %v", seccompProfile, err)
+		if s.Linux.Seccomp, err = loadSeccompProfile(seccompProfile, capabilities); err != nil {
+			return err
 		}
 	}
 
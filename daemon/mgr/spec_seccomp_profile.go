@@ -0,0 +1,219 @@
+package mgr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"strings"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// seccompProfile is the seccomp profile in the format of docker, such as
+// https://github.com/moby/moby/blob/master/profiles/seccomp/default.json.
+type seccompProfile struct {
+	DefaultAction specs.LinuxSeccompAction `json:"defaultAction"`
+	Architectures []specs.Arch             `json:"architectures,omitempty"`
+	ArchMap       []seccompArchMap         `json:"archMap,omitempty"`
+	Syscalls      []seccompSyscall         `json:"syscalls"`
+}
+
+// seccompArchMap maps the main architecture to its sub architectures.
+type seccompArchMap struct {
+	Arch      specs.Arch   `json:"architecture"`
+	SubArches []specs.Arch `json:"subArchitectures"`
+}
+
+// seccompSyscall is the rule of syscalls, which is only applied if the
+// container matches Includes and doesn't match Excludes.
+type seccompSyscall struct {
+	Name     string                   `json:"name,omitempty"`
+	Names    []string                 `json:"names,omitempty"`
+	Action   specs.LinuxSeccompAction `json:"action"`
+	Args     []specs.LinuxSeccompArg  `json:"args"`
+	Includes seccompFilter            `json:"includes"`
+	Excludes seccompFilter            `json:"excludes"`
+}
+
+// seccompFilter filters the syscall rules by capabilities and architectures.
+type seccompFilter struct {
+	Caps   []string `json:"caps,omitempty"`
+	Arches []string `json:"arches,omitempty"`
+}
+
+var (
+	seccompActions = map[specs.LinuxSeccompAction]bool{
+		specs.ActKill:  true,
+		specs.ActTrap:  true,
+		specs.ActErrno: true,
+		specs.ActTrace: true,
+		specs.ActAllow: true,
+	}
+
+	seccompArches = map[specs.Arch]bool{
+		specs.ArchX86:         true,
+		specs.ArchX86_64:      true,
+		specs.ArchX32:         true,
+		specs.ArchARM:         true,
+		specs.ArchAARCH64:     true,
+		specs.ArchMIPS:        true,
+		specs.ArchMIPS64:      true,
+		specs.ArchMIPS64N32:   true,
+		specs.ArchMIPSEL:      true,
+		specs.ArchMIPSEL64:    true,
+		specs.ArchMIPSEL64N32: true,
+		specs.ArchPPC:         true,
+		specs.ArchPPC64:       true,
+		specs.ArchPPC64LE:     true,
+		specs.ArchS390:        true,
+		specs.ArchS390X:       true,
+		specs.ArchPARISC:      true,
+		specs.ArchPARISC64:    true,
+	}
+
+	seccompOperators = map[specs.LinuxSeccompOperator]bool{
+		specs.OpNotEqual:     true,
+		specs.OpLessThan:     true,
+		specs.OpLessEqual:    true,
+		specs.OpEqualTo:      true,
+		specs.OpGreaterEqual: true,
+		specs.OpGreaterThan:  true,
+		specs.OpMaskedEqual:  true,
+	}
+)
+
+// loadSeccompProfile loads the seccomp profile from file, and converts it
+// into the runtime spec for the container with the capabilities.
+func loadSeccompProfile(path string, capabilities []string) (*specs.LinuxSeccomp, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load seccomp profile %q: %v", path, err)
+	}
+
+	seccomp, err := parseSeccompProfile(data, capabilities)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seccomp profile %q: %v", path, err)
+	}
+	return seccomp, nil
+}
+
+// parseSeccompProfile validates the seccomp profile, and converts it into
+// the runtime spec. The error points at the offending syscall rule.
+func parseSeccompProfile(data []byte, capabilities []string) (*specs.LinuxSeccomp, error) {
+	var profile seccompProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("failed to decode: %v", err)
+	}
+
+	if !seccompActions[profile.DefaultAction] {
+		return nil, fmt.Errorf("invalid defaultAction %q", profile.DefaultAction)
+	}
+
+	if len(profile.Architectures) > 0 && len(profile.ArchMap) > 0 {
+		return nil, fmt.Errorf("architectures and archMap can't be used together")
+	}
+
+	seccomp := &specs.LinuxSeccomp{
+		DefaultAction: profile.DefaultAction,
+	}
+
+	for _, arch := range profile.Architectures {
+		if !seccompArches[arch] {
+			return nil, fmt.Errorf("invalid architecture %q", arch)
+		}
+		seccomp.Architectures = append(seccomp.Architectures, arch)
+	}
+	for _, m := range profile.ArchMap {
+		for _, arch := range append([]specs.Arch{m.Arch}, m.SubArches...) {
+			if !seccompArches[arch] {
+				return nil, fmt.Errorf("invalid architecture %q in archMap", arch)
+			}
+			seccomp.Architectures = append(seccomp.Architectures, arch)
+		}
+	}
+
+	caps := make(map[string]bool, len(capabilities))
+	for _, c := range capabilities {
+		caps[seccompCapability(c)] = true
+	}
+
+	for i, call := range profile.Syscalls {
+		names := call.Names
+		if call.Name != "" {
+			if len(names) > 0 {
+				return nil, fmt.Errorf("syscalls[%d]: name and names can't be used together", i)
+			}
+			names = []string{call.Name}
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("syscalls[%d]: names can't be empty", i)
+		}
+
+		if !seccompActions[call.Action] {
+			return nil, fmt.Errorf("syscalls[%d] %v: invalid action %q", i, names, call.Action)
+		}
+		for j, arg := range call.Args {
+			if arg.Index >= 6 {
+				return nil, fmt.Errorf("syscalls[%d] %v: args[%d] has invalid index %d, should be in range [0, 5]", i, names, j, arg.Index)
+			}
+			if !seccompOperators[arg.Op] {
+				return nil, fmt.Errorf("syscalls[%d] %v: args[%d] has invalid op %q", i, names, j, arg.Op)
+			}
+		}
+
+		if !call.Includes.included(caps) || call.Excludes.excluded(caps) {
+			continue
+		}
+
+		seccomp.Syscalls = append(seccomp.Syscalls, specs.LinuxSyscall{
+			Names:  names,
+			Action: call.Action,
+			Args:   call.Args,
+		})
+	}
+
+	return seccomp, nil
+}
+
+// included returns true if the container has all the capabilities and
+// runs on one of the architectures in the filter.
+func (f seccompFilter) included(caps map[string]bool) bool {
+	for _, c := range f.Caps {
+		if !caps[seccompCapability(c)] {
+			return false
+		}
+	}
+	return len(f.Arches) == 0 || f.hasArch()
+}
+
+// excluded returns true if the container has any of the capabilities or
+// runs on any of the architectures in the filter.
+func (f seccompFilter) excluded(caps map[string]bool) bool {
+	for _, c := range f.Caps {
+		if caps[seccompCapability(c)] {
+			return true
+		}
+	}
+	return f.hasArch()
+}
+
+// hasArch returns true if the architecture of daemon is in the filter.
+func (f seccompFilter) hasArch() bool {
+	for _, arch := range f.Arches {
+		if arch == runtime.GOARCH {
+			return true
+		}
+	}
+	return false
+}
+
+// seccompCapability converts the capability into the format with CAP_
+// prefix in upper case, such as sys_admin into CAP_SYS_ADMIN.
+func seccompCapability(c string) string {
+	c = strings.ToUpper(c)
+	if !strings.HasPrefix(c, "CAP_") {
+		c = "CAP_" + c
+	}
+	return c
+}
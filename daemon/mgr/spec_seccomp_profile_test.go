@@ -0,0 +1,87 @@
+package mgr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeccompProfile(t *testing.T) {
+	profile := `{
+	"defaultAction": "SCMP_ACT_ERRNO",
+	"archMap": [{"architecture": "SCMP_ARCH_X86_64", "subArchitectures": ["SCMP_ARCH_X86", "SCMP_ARCH_X32"]}],
+	"syscalls": [
+		{"names": ["read", "write"], "action": "SCMP_ACT_ALLOW", "args": []},
+		{"name": "personality", "action": "SCMP_ACT_ALLOW", "args": [{"index": 0, "value": 8, "op": "SCMP_CMP_EQ"}]},
+		{"names": ["mount"], "action": "SCMP_ACT_ALLOW", "includes": {"caps": ["CAP_SYS_ADMIN"]}},
+		{"names": ["chown"], "action": "SCMP_ACT_ALLOW", "excludes": {"caps": ["CAP_CHOWN"]}},
+		{"names": ["arch_prctl"], "action": "SCMP_ACT_ALLOW", "includes": {"arches": ["` + runtime.GOARCH + `"]}},
+		{"names": ["s390_pci_mmio_read"], "action": "SCMP_ACT_ALLOW", "includes": {"arches": ["unknown"]}}
+	]
+}`
+
+	seccomp, err := parseSeccompProfile([]byte(profile), []string{"CAP_CHOWN"})
+	assert.NoError(t, err)
+	assert.Equal(t, specs.ActErrno, seccomp.DefaultAction)
+	assert.Equal(t, []specs.Arch{specs.ArchX86_64, specs.ArchX86, specs.ArchX32}, seccomp.Architectures)
+
+	var names [][]string
+	for _, call := range seccomp.Syscalls {
+		names = append(names, call.Names)
+	}
+	assert.Equal(t, [][]string{{"read", "write"}, {"personality"}, {"arch_prctl"}}, names)
+
+	// the rules are filtered by capabilities
+	seccomp, err = parseSeccompProfile([]byte(profile), []string{"SYS_ADMIN"})
+	assert.NoError(t, err)
+	names = nil
+	for _, call := range seccomp.Syscalls {
+		names = append(names, call.Names)
+	}
+	assert.Equal(t, [][]string{{"read", "write"}, {"personality"}, {"mount"}, {"chown"}, {"arch_prctl"}}, names)
+
+	for _, tc := range []struct {
+		profile string
+		err     string
+	}{
+		{profile: `{`, err: "failed to decode"},
+		{profile: `{"defaultAction": "SCMP_ACT_FOO"}`, err: `invalid defaultAction "SCMP_ACT_FOO"`},
+		{profile: `{"defaultAction": "SCMP_ACT_ERRNO", "architectures": ["SCMP_ARCH_FOO"]}`, err: `invalid architecture "SCMP_ARCH_FOO"`},
+		{profile: `{"defaultAction": "SCMP_ACT_ERRNO", "syscalls": [{"names": ["read"], "action": "SCMP_ACT_ALLOW"}, {"names": ["write"], "action": "ALLOW"}]}`, err: `syscalls[1] [write]: invalid action "ALLOW"`},
+		{profile: `{"defaultAction": "SCMP_ACT_ERRNO", "syscalls": [{"action": "SCMP_ACT_ALLOW"}]}`, err: "syscalls[0]: names can't be empty"},
+		{profile: `{"defaultAction": "SCMP_ACT_ERRNO", "syscalls": [{"names": ["read"], "action": "SCMP_ACT_ALLOW", "args": [{"index": 6, "op": "SCMP_CMP_EQ"}]}]}`, err: "syscalls[0] [read]: args[0] has invalid index 6"},
+		{profile: `{"defaultAction": "SCMP_ACT_ERRNO", "syscalls": [{"names": ["read"], "action": "SCMP_ACT_ALLOW", "args": [{"index": 0, "op": "EQ"}]}]}`, err: `syscalls[0] [read]: args[0] has invalid op "EQ"`},
+	} {
+		_, err := parseSeccompProfile([]byte(tc.profile), nil)
+		assert.Error(t, err, tc.profile)
+		if err != nil {
+			assert.Contains(t, err.Error(), tc.err)
+		}
+	}
+}
+
+func TestLoadSeccompProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seccomp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "profile.json")
+	if err := ioutil.WriteFile(path, []byte(`{"defaultAction": "SCMP_ACT_ALLOW"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	seccomp, err := loadSeccompProfile(path, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, specs.ActAllow, seccomp.DefaultAction)
+
+	_, err = loadSeccompProfile(filepath.Join(dir, "missing.json"), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing.json")
+}
@@ -2,6 +2,7 @@ package mgr
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
 	"runtime"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/alibaba/pouch/daemon/config"
 	"github.com/alibaba/pouch/daemon/events"
 	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/exec"
 	"github.com/alibaba/pouch/pkg/kernel"
 	"github.com/alibaba/pouch/pkg/meta"
 	"github.com/alibaba/pouch/pkg/system"
@@ -31,15 +33,20 @@ const (
 	unknownHostName      = "<unknown>"
 	unknownKernelVersion = "<unknown>"
 	unknownOSName        = "<unknown>"
+	unknownVersion       = "<unknown>"
+
+	containerdConnected    = "connected"
+	containerdDisconnected = "disconnected"
 )
 
-//SystemMgr as an interface defines all operations against host.
+// SystemMgr as an interface defines all operations against host.
 type SystemMgr interface {
 	Info() (types.SystemInfo, error)
-	Version() (types.SystemVersion, error)
+	Version(ctx context.Context) (types.SystemVersion, error)
 	Auth(*types.AuthConfig) (string, error)
 	UpdateDaemon(*types.DaemonUpdateConfig) error
 	SubscribeToEvents(ctx context.Context, since, until time.Time, ef filters.Args) ([]types.EventsMessage, <-chan *types.EventsMessage, <-chan error)
+	GarbageCollect(ctx context.Context) (*types.SystemGCResp, error)
 }
 
 // SystemManager is an instance of system management.
@@ -48,6 +55,7 @@ type SystemManager struct {
 	registry *registry.Client
 	config   *config.Config
 	imageMgr ImageMgr
+	ctrd     ctrd.APIClient
 
 	store *meta.Store
 
@@ -55,12 +63,13 @@ type SystemManager struct {
 }
 
 // NewSystemManager creates a brand new system manager.
-func NewSystemManager(cfg *config.Config, store *meta.Store, imageManager ImageMgr, eventsService *events.Events) (*SystemManager, error) {
+func NewSystemManager(cfg *config.Config, store *meta.Store, cli ctrd.APIClient, imageManager ImageMgr, eventsService *events.Events) (*SystemManager, error) {
 	return &SystemManager{
 		name:          "system_manager",
 		registry:      &registry.Client{},
 		config:        cfg,
 		imageMgr:      imageManager,
+		ctrd:          cli,
 		store:         store,
 		eventsService: eventsService,
 	}, nil
@@ -81,13 +90,14 @@ func (mgr *SystemManager) Info() (types.SystemInfo, error) {
 		if !ok {
 			return nil
 		}
+		// the containers created, exited or dead are counted as stopped.
 		status := c.State.Status
 		switch status {
-		case types.StatusRunning:
+		case types.StatusRunning, types.StatusRestarting:
 			atomic.AddInt64(&cRunning, 1)
 		case types.StatusPaused:
 			atomic.AddInt64(&cPaused, 1)
-		case types.StatusStopped:
+		default:
 			atomic.AddInt64(&cStopped, 1)
 		}
 
@@ -134,49 +144,101 @@ func (mgr *SystemManager) Info() (types.SystemInfo, error) {
 		securityOpts = append(securityOpts, "selinux")
 	}
 
+	containerdCommit := &types.Commit{ID: unknownVersion}
+	containerdStatus, containerdVersion := containerdDisconnected, unknownVersion
+	if mgr.ctrd != nil && mgr.ctrd.Available() == nil {
+		containerdStatus = containerdConnected
+		if v, err := mgr.ctrd.Version(context.TODO()); err != nil {
+			logrus.Warnf("failed to get containerd version: %v", err)
+		} else {
+			containerdCommit.ID = v.Revision
+			containerdVersion = v.Version
+		}
+	}
+	_, runcCommit := mgr.runtimeVersion(mgr.config.DefaultRuntime)
+
+	// the configurations below may be changed by reload or update of
+	// daemon, so copy them with the lock held.
+	mgr.config.Lock()
+	var (
+		debug                  = mgr.config.Debug
+		imageProxy             = mgr.config.ImageProxy
+		labels                 = append([]string{}, mgr.config.Labels...)
+		maxConcurrentDownloads = mgr.config.MaxConcurrentDownloads
+		registryConfig         = mgr.registryConfig()
+	)
+	mgr.config.Unlock()
+
 	info := types.SystemInfo{
-		Architecture: runtime.GOARCH,
-		// CgroupDriver: ,
-		// ContainerdCommit: ,
+		Architecture:      runtime.GOARCH,
+		ContainerdAddress: mgr.config.ContainerdAddr,
+		ContainerdCommit:  containerdCommit,
+		ContainerdStatus:  containerdStatus,
+		ContainerdVersion: containerdVersion,
 		Containers:        cRunning + cPaused + cStopped,
 		ContainersPaused:  cPaused,
 		ContainersRunning: cRunning,
 		ContainersStopped: cStopped,
-		Debug:             mgr.config.Debug,
+		Debug:             debug,
 		DefaultRuntime:    mgr.config.DefaultRuntime,
 		Driver:            ctrd.CurrentSnapshotterName(context.TODO()),
 		// DriverStatus: ,
 		ExperimentalBuild: false,
-		HTTPProxy:         mgr.config.ImageProxy,
+		HTTPProxy:         imageProxy,
 		// HTTPSProxy: ,
 		// ID: ,
-		CgroupDriver:       mgr.config.GetCgroupDriver(),
-		Images:             int64(len(images)),
-		IndexServerAddress: "https://index.docker.io/v1/",
-		DefaultRegistry:    mgr.config.DefaultRegistry,
-		KernelVersion:      kernelVersion,
-		Labels:             mgr.config.Labels,
-		LiveRestoreEnabled: true,
-		LoggingDriver:      mgr.config.DefaultLogConfig.LogDriver,
-		VolumeDrivers:      volumeDrivers,
-		LxcfsEnabled:       mgr.config.IsLxcfsEnabled,
-		CriEnabled:         mgr.config.IsCriEnabled,
-		MemTotal:           totalMem,
-		Name:               hostname,
-		NCPU:               int64(runtime.NumCPU()),
-		OperatingSystem:    OSName,
-		OSType:             runtime.GOOS,
-		PouchRootDir:       mgr.config.HomeDir,
-		RegistryConfig:     &mgr.config.RegistryService,
-		// RuncCommit: ,
-		Runtimes:        mgr.config.Runtimes,
-		SecurityOptions: securityOpts,
-		ServerVersion:   version.Version,
-		ListenAddresses: mgr.config.Listen,
+		CgroupDriver:           mgr.config.GetCgroupDriver(),
+		Images:                 int64(len(images)),
+		IndexServerAddress:     "https://index.docker.io/v1/",
+		DefaultRegistry:        mgr.config.DefaultRegistry,
+		KernelVersion:          kernelVersion,
+		Labels:                 labels,
+		LiveRestoreEnabled:     mgr.config.LiveRestore,
+		LoggingDriver:          mgr.config.DefaultLogConfig.LogDriver,
+		VolumeDrivers:          volumeDrivers,
+		LxcfsEnabled:           mgr.config.IsLxcfsEnabled,
+		MaxConcurrentDownloads: int64(maxConcurrentDownloads),
+		CriEnabled:             mgr.config.IsCriEnabled,
+		MemTotal:               totalMem,
+		Name:                   hostname,
+		NCPU:                   int64(runtime.NumCPU()),
+		OperatingSystem:        OSName,
+		OSType:                 runtime.GOOS,
+		PouchRootDir:           mgr.config.HomeDir,
+		RegistryConfig:         registryConfig,
+		RuncCommit:             &types.Commit{ID: runcCommit},
+		Runtimes:               mgr.config.Runtimes,
+		SecurityOptions:        securityOpts,
+		ServerVersion:          version.Version,
+		ListenAddresses:        mgr.config.Listen,
+		Warnings:               mgr.warnings(sysInfo),
 	}
 	return info, nil
 }
 
+// registryConfig returns the registry config of daemon, which includes the
+// active insecure registries and registry mirrors. The lock of config should
+// be held by caller.
+func (mgr *SystemManager) registryConfig() *types.RegistryServiceConfig {
+	cfg := mgr.config.RegistryService
+
+	cfg.Mirrors = append(append([]string{}, cfg.Mirrors...), mgr.config.RegistryMirrors...)
+
+	indexConfigs := make(map[string]types.IndexInfo, len(cfg.IndexConfigs)+len(mgr.config.InsecureRegistries))
+	for name, index := range cfg.IndexConfigs {
+		indexConfigs[name] = index
+	}
+	for _, registry := range mgr.config.InsecureRegistries {
+		indexConfigs[registry] = types.IndexInfo{
+			Name:    registry,
+			Mirrors: []string{},
+			Secure:  false,
+		}
+	}
+	cfg.IndexConfigs = indexConfigs
+	return &cfg
+}
+
 // SubscribeToEvents returns to events on the exchange. Events are sent through the returned
 // channel ch. If an error is encountered, it will be sent on channel errs and
 // errs will be closed. To end the subscription, cancel the provided context.
@@ -185,8 +247,8 @@ func (mgr *SystemManager) SubscribeToEvents(ctx context.Context, since, until ti
 	return mgr.eventsService.Subscribe(ctx, since, until, ef)
 }
 
-// Version shows version of daemon.
-func (mgr *SystemManager) Version() (types.SystemVersion, error) {
+// Version shows version of daemon, containerd and the default runtime.
+func (mgr *SystemManager) Version(ctx context.Context) (types.SystemVersion, error) {
 	kernelVersion := unknownKernelVersion
 	if kv, err := kernel.GetKernelVersion(); err != nil {
 		logrus.Warnf("Could not get kernel version: %v", err)
@@ -194,18 +256,123 @@ func (mgr *SystemManager) Version() (types.SystemVersion, error) {
 		kernelVersion = kv.String()
 	}
 
+	containerdVersion := unknownVersion
+	if mgr.ctrd != nil {
+		if v, err := mgr.ctrd.Version(ctx); err != nil {
+			logrus.Warnf("Could not get containerd version: %v", err)
+		} else {
+			containerdVersion = v.Version
+		}
+	}
+
+	runcVersion, _ := mgr.runtimeVersion(mgr.config.DefaultRuntime)
+
+	info := version.Get()
 	return types.SystemVersion{
-		APIVersion:    version.APIVersion,
-		Arch:          runtime.GOARCH,
-		BuildTime:     version.BuildTime,
-		GitCommit:     version.GitCommit,
-		GoVersion:     runtime.Version(),
-		KernelVersion: kernelVersion,
-		Os:            runtime.GOOS,
-		Version:       version.Version,
+		APIVersion:        info.APIVersion,
+		Arch:              info.Arch,
+		BuildTime:         info.BuildTime,
+		ContainerdVersion: containerdVersion,
+		GitCommit:         info.GitCommit,
+		GoVersion:         info.GoVersion,
+		KernelVersion:     kernelVersion,
+		MinAPIVersion:     info.MinAPIVersion,
+		Os:                info.Os,
+		RuncVersion:       runcVersion,
+		Version:           info.Version,
 	}, nil
 }
 
+// runtimeVersion returns the version and commit of runtime reported by
+// `runtime --version`, such as 1.0.0-rc6 in "runc version 1.0.0-rc6".
+func (mgr *SystemManager) runtimeVersion(name string) (string, string) {
+	path := name
+	if r, ok := mgr.config.Runtimes[name]; ok && r.Path != "" {
+		path = r.Path
+	}
+
+	exit, stdout, stderr, err := exec.Run(5*time.Second, path, "--version")
+	if err != nil || exit != 0 {
+		logrus.Warnf("Could not get version of runtime %s: %v, %s", name, err, strings.TrimSpace(stderr))
+		return unknownVersion, unknownVersion
+	}
+	return parseRuntimeVersion(stdout)
+}
+
+// parseRuntimeVersion gets the version from the first line of the output
+// of `runtime --version`, and the commit from the line "commit: xxx".
+func parseRuntimeVersion(output string) (string, string) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+
+	version, commit := strings.TrimSpace(lines[0]), unknownVersion
+	if fields := strings.Fields(version); len(fields) >= 3 && fields[1] == "version" {
+		version = fields[2]
+	}
+	if version == "" {
+		version = unknownVersion
+	}
+
+	for _, line := range lines[1:] {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "commit:") {
+			commit = strings.TrimSpace(strings.TrimPrefix(line, "commit:"))
+		}
+	}
+	return version, commit
+}
+
+// warnings returns the warnings of the configuration of daemon and host,
+// which are printed at the end of `pouch info`.
+func (mgr *SystemManager) warnings(sysInfo *system.Info) []string {
+	var warnings []string
+
+	for _, l := range mgr.config.Listen {
+		if strings.HasPrefix(l, "tcp://") && !mgr.config.TLS.VerifyRemote {
+			warnings = append(warnings, "WARNING: API is accessible on "+l+" without verifying client certs")
+		}
+	}
+
+	if sysInfo.CgroupInfo != nil {
+		if m := sysInfo.Memory; m != nil {
+			if !m.MemoryLimit {
+				warnings = append(warnings, "WARNING: No memory limit support")
+			}
+			if !m.MemorySwap {
+				warnings = append(warnings, "WARNING: No swap limit support")
+			}
+			if !m.KernelMemory {
+				warnings = append(warnings, "WARNING: No kernel memory limit support")
+			}
+			if !m.OOMKillDisable {
+				warnings = append(warnings, "WARNING: No oom kill disable support")
+			}
+		}
+		if c := sysInfo.CPU; c != nil {
+			if !c.CPUQuota {
+				warnings = append(warnings, "WARNING: No cpu cfs quota support")
+			}
+			if !c.CPUPeriod {
+				warnings = append(warnings, "WARNING: No cpu cfs period support")
+			}
+			if !c.CPUShares {
+				warnings = append(warnings, "WARNING: No cpu shares support")
+			}
+			if !c.CpusetCpus || !c.CpusetMems {
+				warnings = append(warnings, "WARNING: No cpuset support")
+			}
+		}
+	}
+
+	if v, err := ioutil.ReadFile("/proc/sys/net/ipv4/ip_forward"); err == nil && strings.TrimSpace(string(v)) == "0" {
+		warnings = append(warnings, "WARNING: IPv4 forwarding is disabled")
+	}
+	for _, knob := range []string{"bridge-nf-call-iptables", "bridge-nf-call-ip6tables"} {
+		if v, err := ioutil.ReadFile("/proc/sys/net/bridge/" + knob); err == nil && strings.TrimSpace(string(v)) == "0" {
+			warnings = append(warnings, "WARNING: "+knob+" is disabled")
+		}
+	}
+	return warnings
+}
+
 // Auth to log in to a registry.
 func (mgr *SystemManager) Auth(auth *types.AuthConfig) (string, error) {
 	return mgr.registry.Auth(auth)
@@ -213,7 +380,7 @@ func (mgr *SystemManager) Auth(auth *types.AuthConfig) (string, error) {
 
 // UpdateDaemon updates config of daemon, only label and image proxy are allowed.
 func (mgr *SystemManager) UpdateDaemon(cfg *types.DaemonUpdateConfig) error {
-	if cfg == nil || (len(cfg.Labels) == 0 && cfg.ImageProxy == "") {
+	if cfg == nil || (len(cfg.Labels) == 0 && cfg.ImageProxy == "" && cfg.Debug == nil) {
 		return errors.Wrap(errtypes.ErrInvalidParam, "daemon update config cannot be empty")
 	}
 
@@ -221,7 +388,23 @@ func (mgr *SystemManager) UpdateDaemon(cfg *types.DaemonUpdateConfig) error {
 
 	daemonCfg.Lock()
 
-	daemonCfg.ImageProxy = cfg.ImageProxy
+	// the image proxy is replaced by the one in request, and the empty one
+	// clears it. The request which only switches the log level keeps it.
+	if cfg.ImageProxy != "" || len(cfg.Labels) != 0 {
+		daemonCfg.ImageProxy = cfg.ImageProxy
+	}
+
+	// the log level is switched at once, so that the requests from debug
+	// mode of CLI can be correlated with the daemon logs.
+	if cfg.Debug != nil {
+		daemonCfg.Debug = *cfg.Debug
+		if daemonCfg.Debug {
+			logrus.SetLevel(logrus.DebugLevel)
+		} else {
+			logrus.SetLevel(logrus.InfoLevel)
+		}
+		logrus.Infof("daemon log level is switched to %s", logrus.GetLevel())
+	}
 
 	length := len(daemonCfg.Labels)
 	for _, newLabel := range cfg.Labels {
@@ -245,3 +428,18 @@ func (mgr *SystemManager) UpdateDaemon(cfg *types.DaemonUpdateConfig) error {
 
 	return nil
 }
+
+// GarbageCollect triggers the gc of containerd to reclaim the content and
+// snapshots which aren't referenced by any image, container or lease.
+func (mgr *SystemManager) GarbageCollect(ctx context.Context) (*types.SystemGCResp, error) {
+	deleted, reclaimed, err := mgr.ctrd.GarbageCollect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("gc removed %d blobs and reclaimed %d bytes", deleted, reclaimed)
+	return &types.SystemGCResp{
+		BlobsDeleted:   deleted,
+		SpaceReclaimed: reclaimed,
+	}, nil
+}
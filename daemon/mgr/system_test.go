@@ -0,0 +1,46 @@
+package mgr
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/config"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRuntimeVersion(t *testing.T) {
+	for output, expected := range map[string][2]string{
+		"runc version 1.0.0-rc6\ncommit: ccb5efd37fb7c86364786e9137e22948751de7ed-dirty\nspec: 1.0.1-dev\n": {"1.0.0-rc6", "ccb5efd37fb7c86364786e9137e22948751de7ed-dirty"},
+		"runv version 1.0.0\n": {"1.0.0", unknownVersion},
+		"1.2.3\n":              {"1.2.3", unknownVersion},
+		"":                     {unknownVersion, unknownVersion},
+	} {
+		version, commit := parseRuntimeVersion(output)
+		assert.Equal(t, expected, [2]string{version, commit}, output)
+	}
+}
+
+func TestUpdateDaemonDebug(t *testing.T) {
+	defer logrus.SetLevel(logrus.GetLevel())
+
+	mgr := &SystemManager{config: &config.Config{ImageProxy: "http://proxy:8080"}}
+	assert.Error(t, mgr.UpdateDaemon(&types.DaemonUpdateConfig{}))
+
+	debug := true
+	assert.NoError(t, mgr.UpdateDaemon(&types.DaemonUpdateConfig{Debug: &debug}))
+	assert.True(t, mgr.config.Debug)
+	assert.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+	// the image proxy isn't cleared by the update of log level.
+	assert.Equal(t, "http://proxy:8080", mgr.config.ImageProxy)
+
+	debug = false
+	assert.NoError(t, mgr.UpdateDaemon(&types.DaemonUpdateConfig{Debug: &debug}))
+	assert.False(t, mgr.config.Debug)
+	assert.Equal(t, logrus.InfoLevel, logrus.GetLevel())
+
+	// the update of labels without image proxy clears it.
+	assert.NoError(t, mgr.UpdateDaemon(&types.DaemonUpdateConfig{Labels: []string{"a=b"}}))
+	assert.Equal(t, "", mgr.config.ImageProxy)
+}
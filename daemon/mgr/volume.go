@@ -3,8 +3,10 @@ package mgr
 import (
 	"context"
 	"strings"
+	"sync"
 
 	"github.com/alibaba/pouch/apis/filters"
+	apitypes "github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/daemon/events"
 	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/utils"
@@ -12,13 +14,15 @@ import (
 	"github.com/alibaba/pouch/storage/volume/types"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // the filter tags set allowed when pouch volume ls -f
 var acceptedVolumeFilterTags = map[string]bool{
-	"driver": true,
-	"name":   true,
-	"label":  true,
+	"driver":   true,
+	"name":     true,
+	"label":    true,
+	"dangling": true,
 }
 
 // VolumeMgr defines interface to manage container volume.
@@ -41,14 +45,39 @@ type VolumeMgr interface {
 	// Attach is used to bind a volume to container.
 	Attach(ctx context.Context, name string, options map[string]string) (*types.Volume, error)
 
+	// CreateAndAttach binds the volume to container, and creates the volume
+	// if it doesn't exist.
+	CreateAndAttach(ctx context.Context, name, driver string, options map[string]string, containerID string) (*types.Volume, error)
+
 	// Detach is used to unbind a volume from container.
 	Detach(ctx context.Context, name string, options map[string]string) (*types.Volume, error)
+
+	// Mount mounts the volume for the container when it starts, and
+	// returns the mount path.
+	Mount(ctx context.Context, name, containerID string) (string, error)
+
+	// Unmount unmounts the volume for the container when it stops.
+	Unmount(ctx context.Context, name, containerID string) error
+
+	// Prune removes the volumes which are not used by any container.
+	Prune(ctx context.Context, filter filters.Args) (*apitypes.VolumePruneResp, error)
+
+	// DiskUsage returns the disk space used by volumes.
+	DiskUsage(ctx context.Context) ([]*apitypes.VolumeDiskUsage, error)
 }
 
 // VolumeManager is the default implement of interface VolumeMgr.
 type VolumeManager struct {
 	core          *volume.Core
 	eventsService *events.Events
+
+	// lock makes the references of volumes consistent, it's held when the
+	// volume is created, removed, attached or detached.
+	lock sync.Mutex
+
+	// ContainerMgr is used to release the references of the containers
+	// which are gone, it's set after the container manager is created.
+	ContainerMgr ContainerMgr
 }
 
 // NewVolumeManager creates a brand new volume manager.
@@ -70,6 +99,14 @@ func NewVolumeManager(cfg volume.Config, eventsService *events.Events) (*VolumeM
 
 // Create is used to create volume.
 func (vm *VolumeManager) Create(ctx context.Context, name, driver string, options, labels map[string]string) (*types.Volume, error) {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	return vm.create(ctx, name, driver, options, labels)
+}
+
+// create creates the volume, the caller must hold the lock.
+func (vm *VolumeManager) create(ctx context.Context, name, driver string, options, labels map[string]string) (*types.Volume, error) {
 	if driver == "" {
 		driver = types.DefaultBackend
 	}
@@ -122,23 +159,55 @@ func (vm *VolumeManager) List(ctx context.Context, filter filters.Args) ([]*type
 	if err := filter.Validate(acceptedVolumeFilterTags); err != nil {
 		return nil, err
 	}
-	return vm.core.ListVolumes(filter)
+
+	dangling, err := parseDanglingFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := vm.core.ListVolumes(filter)
+	if err != nil || dangling == nil {
+		return volumes, err
+	}
+
+	// the dangling volume isn't used by any container.
+	filtered := make([]*types.Volume, 0, len(volumes))
+	for _, v := range volumes {
+		if (v.Option(types.OptionRef) == "") == *dangling {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered, nil
 }
 
 // Remove is used to delete an existing volume.
 func (vm *VolumeManager) Remove(ctx context.Context, name string) error {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
 	vol, err := vm.Get(ctx, name)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get volume(%s)", name)
 	}
 
+	vol, err = vm.releaseStaleReferences(ctx, vol)
+	if err != nil {
+		return err
+	}
+
 	ref := vol.Option(types.OptionRef)
 	if ref != "" {
-		return errors.Wrapf(errtypes.ErrVolumeInUse, "failed to remove volume(%s)", name)
+		return errors.Wrapf(errtypes.ErrVolumeInUse, "failed to remove volume(%s), it is used by containers %s", name, ref)
 	}
 
+	return vm.remove(ctx, vol)
+}
+
+// remove deletes the volume without checking its references, the caller
+// must hold the lock.
+func (vm *VolumeManager) remove(ctx context.Context, vol *types.Volume) error {
 	id := types.VolumeContext{
-		Name: name,
+		Name: vol.Name,
 	}
 	if err := vm.core.RemoveVolume(id); err != nil {
 		if strings.Contains(err.Error(), "not found") {
@@ -147,7 +216,7 @@ func (vm *VolumeManager) Remove(ctx context.Context, name string) error {
 		return err
 	}
 
-	vm.LogVolumeEvent(ctx, name, "destroy", map[string]string{"driver": vol.Driver()})
+	vm.LogVolumeEvent(ctx, vol.Name, "destroy", map[string]string{"driver": vol.Driver()})
 
 	return nil
 }
@@ -162,6 +231,33 @@ func (vm *VolumeManager) Path(ctx context.Context, name string) (string, error)
 
 // Attach is used to bind a volume to container.
 func (vm *VolumeManager) Attach(ctx context.Context, name string, options map[string]string) (*types.Volume, error) {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	return vm.attach(ctx, name, options)
+}
+
+// CreateAndAttach binds the volume to container, and creates the volume if
+// it doesn't exist. The lock is held from the creation to the attachment, so
+// the volume can't be removed by prune or rm -v in the middle.
+func (vm *VolumeManager) CreateAndAttach(ctx context.Context, name, driver string, options map[string]string, containerID string) (*types.Volume, error) {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	if _, err := vm.Get(ctx, name); err != nil {
+		if !errtypes.IsVolumeNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to get volume(%s)", name)
+		}
+		if _, err := vm.create(ctx, name, driver, options, nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to create volume(%s)", name)
+		}
+	}
+
+	return vm.attach(ctx, name, map[string]string{types.OptionRef: containerID})
+}
+
+// attach binds the volume to container, the caller must hold the lock.
+func (vm *VolumeManager) attach(ctx context.Context, name string, options map[string]string) (*types.Volume, error) {
 	id := types.VolumeContext{
 		Name: name,
 	}
@@ -190,6 +286,14 @@ func (vm *VolumeManager) Attach(ctx context.Context, name string, options map[st
 
 // Detach is used to unbind a volume from container.
 func (vm *VolumeManager) Detach(ctx context.Context, name string, options map[string]string) (*types.Volume, error) {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	return vm.detach(ctx, name, options)
+}
+
+// detach unbinds the volume from container, the caller must hold the lock.
+func (vm *VolumeManager) detach(ctx context.Context, name string, options map[string]string) (*types.Volume, error) {
 	id := types.VolumeContext{
 		Name: name,
 	}
@@ -223,3 +327,44 @@ func (vm *VolumeManager) Detach(ctx context.Context, name string, options map[st
 
 	return vm.core.DetachVolume(id, options)
 }
+
+// releaseStaleReferences detaches the volume from the containers which are
+// gone, whose references are left when daemon exits before the containers
+// are removed completely. The caller must hold the lock.
+func (vm *VolumeManager) releaseStaleReferences(ctx context.Context, vol *types.Volume) (*types.Volume, error) {
+	ref := vol.Option(types.OptionRef)
+	if ref == "" || vm.ContainerMgr == nil {
+		return vol, nil
+	}
+
+	for _, cid := range strings.Split(ref, ",") {
+		if _, err := vm.ContainerMgr.Get(ctx, cid); err == nil || !errtypes.IsNotfound(err) {
+			continue
+		}
+
+		logrus.Warnf("release reference of volume %s from container %s which is gone", vol.Name, cid)
+		v, err := vm.detach(ctx, vol.Name, map[string]string{types.OptionRef: cid})
+		if err != nil {
+			return nil, err
+		}
+		vol = v
+	}
+	return vol, nil
+}
+
+// Mount mounts the volume for the container when it starts, and returns the
+// mount path.
+func (vm *VolumeManager) Mount(ctx context.Context, name, containerID string) (string, error) {
+	id := types.VolumeContext{
+		Name: name,
+	}
+	return vm.core.MountVolume(id, containerID)
+}
+
+// Unmount unmounts the volume for the container when it stops.
+func (vm *VolumeManager) Unmount(ctx context.Context, name, containerID string) error {
+	id := types.VolumeContext{
+		Name: name,
+	}
+	return vm.core.UnmountVolume(id, containerID)
+}
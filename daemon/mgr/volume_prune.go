@@ -0,0 +1,91 @@
+package mgr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/alibaba/pouch/apis/filters"
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/storage/volume/types"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// acceptedPruneVolumeFilterTags are the filters supported by volume prune.
+var acceptedPruneVolumeFilterTags = map[string]bool{
+	labelFilter:    true,
+	notLabelFilter: true,
+}
+
+// Prune removes the volumes which are not used by any container, and returns
+// the names of deleted volumes and the reclaimed disk space of local volumes.
+// The lock is held during the whole prune, so that no volume is attached by
+// the container created at the same time after it's checked.
+func (vm *VolumeManager) Prune(ctx context.Context, filter filters.Args) (*apitypes.VolumePruneResp, error) {
+	if err := filter.Validate(acceptedPruneVolumeFilterTags); err != nil {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+	labels, notLabels := filter.Get(labelFilter), filter.Get(notLabelFilter)
+
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	volumes, err := vm.core.ListVolumes(filters.NewArgs())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apitypes.VolumePruneResp{VolumesDeleted: []string{}}
+	for _, v := range volumes {
+		if !matchPruneLabels(v.Labels, labels, notLabels) {
+			continue
+		}
+
+		// the volume used by the containers which are gone can be pruned.
+		if v.Option(types.OptionRef) != "" {
+			released, err := vm.releaseStaleReferences(ctx, v)
+			if err != nil {
+				logrus.Warnf("failed to release references of volume %s during prune volumes: %v", v.Name, err)
+				continue
+			}
+			if released.Option(types.OptionRef) != "" {
+				continue
+			}
+		}
+
+		// the size is only available before the volume is removed.
+		var size int64
+		if v.Driver() == types.DefaultBackend {
+			if size, err = dirSize(v.Path()); err != nil {
+				logrus.Warnf("failed to get size of volume %s during prune volumes: %v", v.Name, err)
+			}
+		}
+
+		if err := vm.remove(ctx, v); err != nil {
+			logrus.Warnf("failed to remove volume %s during prune volumes: %v", v.Name, err)
+			continue
+		}
+
+		resp.VolumesDeleted = append(resp.VolumesDeleted, v.Name)
+		resp.SpaceReclaimed += size
+	}
+	return resp, nil
+}
+
+// dirSize returns the total size of the regular files in the directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,87 @@
+package mgr
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/daemon/events"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/storage/volume"
+	"github.com/alibaba/pouch/storage/volume/driver"
+	"github.com/alibaba/pouch/storage/volume/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumePrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-prune")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	driverName := "fake-prune"
+	driver.Register(driver.NewFakeDriver(driverName))
+	defer driver.Unregister(driverName)
+
+	vm, err := NewVolumeManager(volume.Config{VolumeMetaPath: filepath.Join(dir, "volume.db")}, events.NewEvents())
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	for name, labels := range map[string]map[string]string{
+		"unused": nil,
+		"used":   nil,
+		"keep":   {"keep": "true"},
+		"test":   {"env": "test"},
+	} {
+		_, err := vm.Create(ctx, name, driverName, nil, labels)
+		assert.NoError(t, err)
+	}
+	_, err = vm.Attach(ctx, "used", map[string]string{types.OptionRef: "a8c2ea5f9a45"})
+	assert.NoError(t, err)
+
+	_, err = vm.Prune(ctx, filters.NewArgs(filters.Arg("until", "1h")))
+	assert.True(t, errtypes.IsInvalidParam(err))
+
+	resp, err := vm.Prune(ctx, filters.NewArgs(filters.Arg("label", "env=test")))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test"}, resp.VolumesDeleted)
+
+	resp, err = vm.Prune(ctx, filters.NewArgs(filters.Arg("label!", "keep")))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"unused"}, resp.VolumesDeleted)
+
+	resp, err = vm.Prune(ctx, filters.NewArgs())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"keep"}, resp.VolumesDeleted)
+
+	volumes, err := vm.List(ctx, filters.NewArgs())
+	assert.NoError(t, err)
+	var names []string
+	for _, v := range volumes {
+		names = append(names, v.Name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"used"}, names)
+}
+
+func TestDirSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dir-size")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "foo"), make([]byte, 1024), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "sub", "bar"), make([]byte, 512), 0644))
+	assert.NoError(t, os.Symlink("foo", filepath.Join(dir, "link")))
+
+	size, err := dirSize(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1536), size)
+
+	_, err = dirSize(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
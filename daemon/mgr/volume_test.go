@@ -0,0 +1,127 @@
+package mgr
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/daemon/events"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/storage/volume"
+	"github.com/alibaba/pouch/storage/volume/driver"
+	"github.com/alibaba/pouch/storage/volume/types"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAndAttach(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-attach")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	driverName := "fake-attach"
+	driver.Register(driver.NewFakeDriver(driverName))
+	defer driver.Unregister(driverName)
+
+	vm, err := NewVolumeManager(volume.Config{VolumeMetaPath: filepath.Join(dir, "volume.db")}, events.NewEvents())
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	// the existing volume is attached without being created again.
+	_, err = vm.Create(ctx, "existing", driverName, nil, map[string]string{"keep": "true"})
+	assert.NoError(t, err)
+	v, err := vm.CreateAndAttach(ctx, "existing", driverName, nil, "a8c2ea5f9a45")
+	assert.NoError(t, err)
+	assert.Equal(t, "a8c2ea5f9a45", v.Option(types.OptionRef))
+	assert.Equal(t, "true", v.Label("keep"))
+
+	// the volume can't be pruned between its creation and attachment.
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				vm.Prune(ctx, filters.NewArgs())
+			}
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := vm.CreateAndAttach(ctx, fmt.Sprintf("vol-%d", i), driverName, nil, fmt.Sprintf("container-%d", i))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	close(stop)
+
+	for i := 0; i < 20; i++ {
+		v, err := vm.Get(ctx, fmt.Sprintf("vol-%d", i))
+		if assert.NoError(t, err) {
+			assert.Equal(t, fmt.Sprintf("container-%d", i), v.Option(types.OptionRef))
+		}
+	}
+}
+
+type fakeContainerMgr struct {
+	ContainerMgr
+	ids map[string]bool
+}
+
+func (mgr *fakeContainerMgr) Get(ctx context.Context, id string) (*Container, error) {
+	if !mgr.ids[id] {
+		return nil, errors.Wrapf(errtypes.ErrNotfound, "container %s", id)
+	}
+	return &Container{ID: id}, nil
+}
+
+func TestReleaseStaleReferences(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-release")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	driverName := "fake-release"
+	driver.Register(driver.NewFakeDriver(driverName))
+	defer driver.Unregister(driverName)
+
+	vm, err := NewVolumeManager(volume.Config{VolumeMetaPath: filepath.Join(dir, "volume.db")}, events.NewEvents())
+	assert.NoError(t, err)
+	vm.ContainerMgr = &fakeContainerMgr{ids: map[string]bool{"alive": true}}
+
+	ctx := context.Background()
+	for name, ids := range map[string][]string{
+		"gone":  {"gone-1", "gone-2"},
+		"mixed": {"gone-3", "alive"},
+		"stale": {"gone-4"},
+	} {
+		for _, id := range ids {
+			_, err := vm.CreateAndAttach(ctx, name, driverName, nil, id)
+			assert.NoError(t, err)
+		}
+	}
+
+	// the references of the containers which are gone are released.
+	assert.NoError(t, vm.Remove(ctx, "gone"))
+
+	err = vm.Remove(ctx, "mixed")
+	assert.True(t, errtypes.IsVolumeInUse(err))
+	v, err := vm.Get(ctx, "mixed")
+	assert.NoError(t, err)
+	assert.Equal(t, "alive", v.Option(types.OptionRef))
+
+	resp, err := vm.Prune(ctx, filters.NewArgs())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"stale"}, resp.VolumesDeleted)
+}
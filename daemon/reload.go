@@ -0,0 +1,61 @@
+package daemon
+
+import (
+	"strings"
+
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/daemon/config"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Reload applies the reloadable configurations of newCfg to the running
+// daemon, which are debug, label, registry-mirrors, insecure-registries and
+// max-concurrent-downloads, the others changed are ignored and logged since
+// they require restarting daemon. Nothing is changed if newCfg is invalid.
+func (d *Daemon) Reload(newCfg *config.Config) error {
+	// the registry options are validated by containerd client before any
+	// config is changed, so that the reload is atomic.
+	if err := d.ctrdClient.UpdateRegistryOptions(
+		ctrd.WithInsecureRegistries(newCfg.InsecureRegistries),
+		ctrd.WithRegistryMirrors(newCfg.RegistryMirrors),
+		ctrd.WithMaxConcurrentDownloads(newCfg.MaxConcurrentDownloads),
+	); err != nil {
+		return errors.Wrap(err, "invalid registry config")
+	}
+
+	// the system labels are added to the labels from config as starting.
+	newCfg.Labels = append(newCfg.Labels, systemLabels()...)
+
+	// the labels set by updatedaemon are replaced by the ones in config
+	// file, so let user know which of them are discarded.
+	var discarded []string
+	for _, label := range d.config.GetLabels() {
+		if !utils.StringInSlice(newCfg.Labels, label) {
+			discarded = append(discarded, label)
+		}
+	}
+
+	changed, ignored := d.config.Reload(newCfg)
+
+	if newCfg.Debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	} else {
+		logrus.SetLevel(logrus.InfoLevel)
+	}
+
+	if len(changed) == 0 {
+		logrus.Infof("daemon configurations are reloaded, nothing is changed")
+	} else {
+		logrus.Infof("daemon configurations are reloaded, changed: %s", strings.Join(changed, ", "))
+	}
+	if len(discarded) > 0 {
+		logrus.Warnf("daemon labels are discarded on reload since they aren't in config: %s", strings.Join(discarded, ", "))
+	}
+	if len(ignored) > 0 {
+		logrus.Warnf("daemon configurations are ignored on reload since they require restarting daemon: %s", strings.Join(ignored, ", "))
+	}
+	return nil
+}
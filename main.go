@@ -30,12 +30,19 @@ import (
 )
 
 var (
-	sigHandles   []func() error
-	printVersion bool
-	logOpts      []string
-	cfg          = &config.Config{}
+	sigHandles     []func() error
+	printVersion   bool
+	validateConfig bool
+	cfg            = &config.Config{}
+	cfgFlags       = &configFlags{}
 )
 
+// configFlags are the flags which are converted into config after parsed.
+type configFlags struct {
+	logOpts        []string
+	defaultUlimits optscfg.Ulimit
+}
+
 var rootCmd = &cobra.Command{
 	Use:               "pouchd",
 	Short:             "An Efficient Enterprise-class Container Engine",
@@ -70,6 +77,14 @@ func setupFlags(cmd *cobra.Command) {
 	// when this action is called directly.
 	flagSet := cmd.Flags()
 
+	flagSet.BoolVarP(&printVersion, "version", "v", false, "Print daemon version")
+	flagSet.BoolVar(&validateConfig, "validate-config", false, "Validate the configurations from config file and flags, and exit without starting pouchd")
+	setupConfigFlags(flagSet, cfg, cfgFlags)
+}
+
+// setupConfigFlags setups the flags of configurations, which are bound to
+// cfg and cfgFlags.
+func setupConfigFlags(flagSet *pflag.FlagSet, cfg *config.Config, cfgFlags *configFlags) {
 	flagSet.StringVar(&cfg.HomeDir, "home-dir", "/var/lib/pouch", "Specify root dir of pouchd")
 	flagSet.StringArrayVarP(&cfg.Listen, "listen", "l", []string{"unix:///var/run/pouchd.sock"}, "Specify listening addresses of Pouchd")
 	flagSet.BoolVar(&cfg.IsCriEnabled, "enable-cri", false, "Specify whether enable the cri part of pouchd which is used to support Kubernetes")
@@ -85,12 +100,12 @@ func setupFlags(cmd *cobra.Command) {
 	flagSet.BoolVarP(&cfg.Debug, "debug", "D", false, "Switch daemon log level to DEBUG mode")
 	flagSet.StringVarP(&cfg.ContainerdAddr, "containerd", "c", "/var/run/containerd.sock", "Specify listening address of containerd")
 	flagSet.StringVar(&cfg.ContainerdPath, "containerd-path", "", "Specify the path of containerd binary")
-	flagSet.StringVar(&cfg.TLS.Key, "tlskey", "", "Specify key file of TLS")
-	flagSet.StringVar(&cfg.TLS.Cert, "tlscert", "", "Specify cert file of TLS")
-	flagSet.StringVar(&cfg.TLS.CA, "tlscacert", "", "Specify CA file of TLS")
-	flagSet.BoolVar(&cfg.TLS.VerifyRemote, "tlsverify", false, "Use TLS and verify remote")
+	flagSet.BoolVar(&cfg.ManageContainerd, "manage-containerd", true, "Start and supervise containerd by pouchd, otherwise connect to the running containerd specified by --containerd")
+	flagSet.StringVar(&cfg.TLS.Key, "tlskey", "", "Specify key file of TLS for tcp listeners")
+	flagSet.StringVar(&cfg.TLS.Cert, "tlscert", "", "Specify cert file of TLS for tcp listeners")
+	flagSet.StringVar(&cfg.TLS.CA, "tlscacert", "", "Specify CA file of TLS to verify client certs")
+	flagSet.BoolVar(&cfg.TLS.VerifyRemote, "tlsverify", false, "Require client certs signed by --tlscacert")
 	flagSet.StringVar(&cfg.TLS.ManagerWhiteList, "manager-whitelist", "", "Set tls name whitelist, multiple values are separated by commas")
-	flagSet.BoolVarP(&printVersion, "version", "v", false, "Print daemon version")
 	flagSet.StringVar(&cfg.DefaultRuntime, "default-runtime", "runc", "Default OCI Runtime")
 	flagSet.BoolVar(&cfg.IsLxcfsEnabled, "enable-lxcfs", false, "Enable Lxcfs to make container to isolate /proc")
 	flagSet.StringVar(&cfg.LxcfsBinPath, "lxcfs", "/usr/local/bin/lxcfs", "Specify the path of lxcfs binary")
@@ -122,7 +137,7 @@ func setupFlags(cmd *cobra.Command) {
 
 	// log config
 	flagSet.StringVar(&cfg.DefaultLogConfig.LogDriver, "log-driver", types.LogConfigLogDriverJSONFile, "Set default log driver")
-	flagSet.StringArrayVar(&logOpts, "log-opt", nil, "Set default log driver options")
+	flagSet.StringArrayVar(&cfgFlags.logOpts, "log-opt", nil, "Set default log driver options")
 
 	// cgroup-path flag is to set parent cgroup for all containers, default is "default" staying with containerd's configuration.
 	flagSet.StringVar(&cfg.CgroupParent, "cgroup-parent", "", "Set parent cgroup for all containers")
@@ -130,7 +145,13 @@ func setupFlags(cmd *cobra.Command) {
 	flagSet.BoolVar(&cfg.EnableProfiler, "enable-profiler", false, "Set if pouchd setup profiler")
 	flagSet.StringVar(&cfg.Pidfile, "pidfile", "/var/run/pouch.pid", "Save daemon pid")
 	flagSet.IntVar(&cfg.OOMScoreAdjust, "oom-score-adj", -500, "Set the oom_score_adj for the daemon")
-	flagSet.Var(optscfg.NewRuntime(&cfg.Runtimes), "add-runtime", "register a OCI runtime to daemon")
+	flagSet.Var(optscfg.NewRuntime(&cfg.Runtimes), "add-runtime", "Register a OCI runtime to daemon, format is name=path, the runtime args can be set by add-runtime in config file")
+	flagSet.Var(&cfgFlags.defaultUlimits, "default-ulimit", "Set default ulimits for all containers, format is name=soft[:hard], such as nofile=65535")
+	flagSet.BoolVar(&cfg.DefaultInit, "default-init", false, "Run an init in all containers by default, which reaps zombies and forwards signals")
+	flagSet.StringVar(&cfg.InitPath, "init-path", config.DefaultInitPath, "Set the path of the static init binary run in containers, which is looked up in PATH if not absolute")
+	flagSet.BoolVar(&cfg.LiveRestore, "live-restore", true, "Keep containers running when pouchd is stopped, and restore them when pouchd starts again")
+	flagSet.IntVar(&cfg.ShutdownTimeout, "shutdown-timeout", config.DefaultShutdownTimeout, "Set the timeout in seconds to stop containers when pouchd is stopped without live restore, the containers which don't exit in time are killed")
+	flagSet.StringVar(&cfg.MetricsAddress, "metrics-addr", "", "Set the tcp address to serve only the prometheus metrics, such as 127.0.0.1:9323")
 
 	// Notes(ziren): default-namespace is passed to containerd, the default
 	// value is 'default'. So if IsCriEnabled is true for k8s, we should set the DefaultNamespace
@@ -140,29 +161,33 @@ func setupFlags(cmd *cobra.Command) {
 
 	// registry
 	flagSet.StringArrayVar(&cfg.InsecureRegistries, "insecure-registries", []string{}, "enable insecure registry")
+	flagSet.StringArrayVar(&cfg.RegistryMirrors, "registry-mirrors", []string{}, "Registry mirrors of docker hub, which are tried in order before docker hub")
+	flagSet.IntVar(&cfg.MaxPullAttempts, "max-pull-attempts", config.DefaultMaxPullAttempts, "Set the max attempts to fetch the content of image on network errors during pull")
+	flagSet.IntVar(&cfg.MaxConcurrentDownloads, "max-concurrent-downloads", config.DefaultMaxConcurrentDownloads, "Set the max number of layers downloaded at the same time by all the pulls")
+	flagSet.StringVar(&cfg.PullRetryBackoff, "pull-retry-backoff", config.DefaultPullRetryBackoff, "Set the backoff before the first retry of failed fetch during pull, which is doubled for each retry")
 }
 
 // runDaemon prepares configs, setups essential details and runs pouchd daemon.
 func runDaemon(cmd *cobra.Command) error {
-	if err := loadDaemonFile(cfg, cmd.Flags()); err != nil {
-		return fmt.Errorf("failed to load daemon file: %s", err)
-	}
-
-	// parse log driver config
-	logOptMap, err := opts.ParseLogOptions(cfg.DefaultLogConfig.LogDriver, logOpts)
-	if err != nil {
+	if err := loadConfig(cfg, cfgFlags, cmd.Flags()); err != nil {
 		return err
 	}
 
-	if len(logOptMap) > 0 {
-		cfg.DefaultLogConfig.LogOpts = logOptMap
-	}
-
 	//user specifies --version or -v, print version and return.
 	if printVersion {
 		fmt.Printf("pouchd version: %s, build: %s, build at: %s\n", version.Version, version.GitCommit, version.BuildTime)
 		return nil
 	}
+
+	// user specifies --validate-config, validate the configurations and return.
+	if validateConfig {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		fmt.Println("configuration OK")
+		return nil
+	}
+
 	metrics.EngineVersion.WithLabelValues(version.GitCommit).Set(1)
 	// initialize log.
 	initLog()
@@ -218,6 +243,7 @@ func runDaemon(cmd *cobra.Command) error {
 	var (
 		errCh    = make(chan error, 1)
 		signalCh = make(chan os.Signal, 1)
+		reloadCh = make(chan os.Signal, 1)
 	)
 
 	// new daemon instance, this is core.
@@ -226,7 +252,9 @@ func runDaemon(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to new daemon")
 	}
 
-	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGHUP)
+	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	// SIGHUP reloads the configurations instead of stopping daemon.
+	signal.Notify(reloadCh, syscall.SIGHUP)
 	sigHandles = append(sigHandles, d.ShutdownPlugin, d.Shutdown)
 
 	go func() {
@@ -234,24 +262,94 @@ func runDaemon(cmd *cobra.Command) error {
 		errCh <- d.Run()
 	}()
 
-	select {
-	case sig := <-signalCh:
-		logrus.Warnf("received signal: %s", sig)
-
-		for _, handle := range sigHandles {
-			if err := handle(); err != nil {
-				logrus.Errorf("failed to handle signal: %v", err)
+	for {
+		select {
+		case <-reloadCh:
+			logrus.Infof("received signal SIGHUP, reloading daemon configurations")
+			if err := reloadConfig(d); err != nil {
+				logrus.Errorf("failed to reload daemon configurations, the previous ones are kept: %v", err)
+			}
+		case sig := <-signalCh:
+			logrus.Warnf("received signal: %s, shutting down pouchd, send the signal again to force exit", sig)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for _, handle := range sigHandles {
+					if err := handle(); err != nil {
+						logrus.Errorf("failed to handle signal: %v", err)
+					}
+				}
+			}()
+
+			// the second signal forces exit without waiting for shutdown.
+			select {
+			case <-done:
+			case sig := <-signalCh:
+				logrus.Warnf("received signal: %s again, force to exit", sig)
 			}
+
+			os.Exit(1)
+		case err := <-errCh:
+			// FIXME: should we do the cleanup like signal handle?
+			return err
 		}
+	}
+}
 
-		os.Exit(1)
-	case err := <-errCh:
-		// FIXME: should we do the cleanup like signal handle?
+// loadConfig merges the config file with the flags parsed into cfg, and
+// converts cfgFlags into cfg.
+func loadConfig(cfg *config.Config, cfgFlags *configFlags, flagSet *pflag.FlagSet) error {
+	if err := loadDaemonFile(cfg, flagSet); err != nil {
+		return fmt.Errorf("failed to load daemon file: %s", err)
+	}
+
+	// parse log driver config
+	logOptMap, err := opts.ParseLogOptions(cfg.DefaultLogConfig.LogDriver, cfgFlags.logOpts)
+	if err != nil {
 		return err
 	}
+
+	if len(logOptMap) > 0 {
+		cfg.DefaultLogConfig.LogOpts = logOptMap
+	}
+
+	// default ulimits from flags override the ones in config file
+	if ulimits := cfgFlags.defaultUlimits.Value(); len(ulimits) > 0 {
+		cfg.DefaultUlimits = ulimits
+	}
 	return nil
 }
 
+// reloadConfig loads the configurations again from the command line and
+// config file in the same way as starting daemon, and applies the reloadable
+// ones to the running daemon. The running configurations are kept as they
+// are if any of the new ones is invalid.
+func reloadConfig(d *daemon.Daemon) error {
+	newCfg, newCfgFlags := &config.Config{}, &configFlags{}
+
+	flagSet := pflag.NewFlagSet("pouchd", pflag.ContinueOnError)
+	setupConfigFlags(flagSet, newCfg, newCfgFlags)
+	if err := flagSet.Parse(os.Args[1:]); err != nil {
+		return err
+	}
+
+	if err := loadConfig(newCfg, newCfgFlags, flagSet); err != nil {
+		return err
+	}
+	if err := newCfg.Validate(); err != nil {
+		return err
+	}
+
+	dir, err := utils.ResolveHomeDir(newCfg.HomeDir)
+	if err != nil {
+		return err
+	}
+	newCfg.HomeDir = dir
+
+	return d.Reload(newCfg)
+}
+
 // initLog initializes log Level and log format of daemon.
 func initLog() {
 	if cfg.Debug {
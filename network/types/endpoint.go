@@ -12,6 +12,9 @@ type Endpoint struct {
 	ID    string
 	Owner string
 
+	// ContainerName is resolved by the embedded DNS of user defined network.
+	ContainerName string
+
 	Hostname       strfmt.Hostname
 	Domainname     string
 	HostnamePath   string
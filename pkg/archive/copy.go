@@ -0,0 +1,313 @@
+package archive
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/continuity/fs"
+)
+
+// ExtractOptions is the options of extracting a tar archive.
+type ExtractOptions struct {
+	// Root is the directory which nothing can be extracted out of, the
+	// symlinks in the paths are resolved in the scope of it. It defaults
+	// to "/".
+	Root string
+
+	// NoOverwriteDirNonDir refuses to replace an existing directory with a
+	// non-directory, or an existing non-directory with a directory.
+	NoOverwriteDirNonDir bool
+
+	// Rebase renames the top level entries of the archive, the key is the
+	// name in archive and the value is the new name.
+	Rebase map[string]string
+
+	// KeepOwner changes the owner of extracted files to the uid and gid
+	// of the entries, which requires the privilege.
+	KeepOwner bool
+}
+
+// IsContentsPath returns whether the path means the contents of a directory
+// rather than the directory itself, which ends with "/.".
+func IsContentsPath(path string) bool {
+	return path == "." || strings.HasSuffix(path, string(filepath.Separator)+".")
+}
+
+// HasTrailingSeparator returns whether the path ends with separator, which
+// means the path must be a directory.
+func HasTrailingSeparator(path string) bool {
+	return len(path) > 1 && os.IsPathSeparator(path[len(path)-1])
+}
+
+// ResolvePath returns the real path of path in the scope of root, so the
+// symlinks are never followed out of root. The last element is kept as it is
+// if it is a symlink, unless the path ends with separator or "/.".
+func ResolvePath(root, path string) (string, error) {
+	if HasTrailingSeparator(path) || IsContentsPath(path) {
+		return fs.RootPath(root, path)
+	}
+
+	dir, base := filepath.Split(filepath.Join("/", path))
+	resolvedDir, err := fs.RootPath(root, dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolvedDir, base), nil
+}
+
+// TarOptions is the options of creating a tar archive.
+type TarOptions struct {
+	// ExcludeSpecialFiles excludes the device and socket files.
+	ExcludeSpecialFiles bool
+}
+
+// TarPath writes the tar archive of path into w. The entries are placed
+// under the directory name, or named by the relative paths of the contents
+// if name is empty. The symlinks are archived as they are, never followed.
+func TarPath(w io.Writer, path, name string) error {
+	return TarWithOptions(w, path, name, TarOptions{})
+}
+
+// TarWithOptions is same as TarPath, but the entries are filtered by opts.
+func TarWithOptions(w io.Writer, path, name string, opts TarOptions) error {
+	tw := tar.NewWriter(w)
+
+	// the hard linked file is archived once, and the later ones link to it.
+	links := map[inode]string{}
+
+	err := filepath.Walk(path, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(path, file)
+		if err != nil {
+			return err
+		}
+		// the directory itself is not archived if only contents are wanted.
+		if name == "" && rel == "." {
+			return nil
+		}
+		if opts.ExcludeSpecialFiles && fi.Mode()&(os.ModeDevice|os.ModeSocket) != 0 {
+			return nil
+		}
+
+		var link string
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(name, rel))
+		if fi.IsDir() {
+			header.Name += "/"
+		}
+		// the owner is kept by the numeric ids.
+		header.Uname, header.Gname = "", ""
+
+		if key, ok := hardLinkInode(fi); ok && fi.Mode().IsRegular() {
+			if first, ok := links[key]; ok {
+				header.Typeflag = tar.TypeLink
+				header.Linkname = first
+				header.Size = 0
+			} else {
+				links[key] = header.Name
+			}
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !fi.Mode().IsRegular() || header.Typeflag == tar.TypeLink {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// ExtractTar extracts the tar archive from r into the directory dst, which
+// is the path in the scope of opts.Root. The entries trying to escape dst
+// are refused. The device and fifo entries are skipped.
+func ExtractTar(r io.Reader, dst string, opts ExtractOptions) error {
+	root := opts.Root
+	if root == "" {
+		root = "/"
+	}
+
+	// the time of directories are set at last, since extracting the
+	// entries inside changes them.
+	type dirTime struct {
+		path   string
+		header *tar.Header
+	}
+	var dirs []dirTime
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		name, err := entryName(header.Name, opts.Rebase)
+		if err != nil {
+			return err
+		}
+		// the destination itself is not touched.
+		if name == "." {
+			continue
+		}
+
+		target, err := ResolvePath(root, filepath.Join(dst, name))
+		if err != nil {
+			return err
+		}
+		if !withinRoot(root, target) {
+			return fmt.Errorf("invalid tar entry %q: out of the root %s", header.Name, root)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		isDir := header.Typeflag == tar.TypeDir
+		if fi, err := os.Lstat(target); err == nil {
+			if opts.NoOverwriteDirNonDir && fi.IsDir() && !isDir {
+				return fmt.Errorf("cannot overwrite directory %q with non-directory %q", filepath.Join(dst, name), header.Name)
+			}
+			if opts.NoOverwriteDirNonDir && !fi.IsDir() && isDir {
+				return fmt.Errorf("cannot overwrite non-directory %q with directory %q", filepath.Join(dst, name), header.Name)
+			}
+			// the existing directory is merged with the new one.
+			if !(fi.IsDir() && isDir) {
+				if err := os.RemoveAll(target); err != nil {
+					return err
+				}
+			}
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+
+		mode := header.FileInfo().Mode()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.Mkdir(target, mode.Perm()); err != nil && !os.IsExist(err) {
+				return err
+			}
+			dirs = append(dirs, dirTime{path: target, header: header})
+		case tar.TypeReg, tar.TypeRegA:
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			linkName, err := entryName(header.Linkname, opts.Rebase)
+			if err != nil {
+				return err
+			}
+			linkTarget, err := ResolvePath(root, filepath.Join(dst, linkName))
+			if err != nil {
+				return err
+			}
+			// the hard link can't refer to the file out of the root.
+			if !withinRoot(root, linkTarget) {
+				return fmt.Errorf("invalid hard link %q: target %q is out of the root %s", header.Name, header.Linkname, root)
+			}
+			if err := os.Link(linkTarget, target); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+
+		if opts.KeepOwner {
+			if err := os.Lchown(target, header.Uid, header.Gid); err != nil {
+				return err
+			}
+		}
+
+		// the mode and time of symlink itself can not be changed.
+		if header.Typeflag == tar.TypeSymlink {
+			continue
+		}
+
+		if err := os.Chmod(target, mode); err != nil {
+			return err
+		}
+		if !isDir {
+			if err := os.Chtimes(target, header.ModTime, header.ModTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chtimes(dirs[i].path, dirs[i].header.ModTime, dirs[i].header.ModTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// entryName cleans the name of tar entry and renames its top level element
+// by rebase, the name out of the destination is refused.
+func entryName(name string, rebase map[string]string) (string, error) {
+	// like tar(1), the leading separator is removed.
+	cleaned := strings.TrimPrefix(filepath.Clean(filepath.FromSlash(name)), string(filepath.Separator))
+	if cleaned == "" {
+		cleaned = "."
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid tar entry %q: out of the destination", name)
+	}
+
+	top, rest := cleaned, ""
+	if i := strings.IndexRune(cleaned, filepath.Separator); i >= 0 {
+		top, rest = cleaned[:i], cleaned[i+1:]
+	}
+	if newTop, ok := rebase[top]; ok {
+		return filepath.Join(newTop, rest), nil
+	}
+	return cleaned, nil
+}
+
+// withinRoot returns whether the path is the root or inside it.
+func withinRoot(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,197 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvePath(t *testing.T) {
+	root, err := ioutil.TempDir("", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "etc"), 0755))
+	// the absolute and relative symlinks must be bounded in the root.
+	assert.NoError(t, os.Symlink("/etc", filepath.Join(root, "abs")))
+	assert.NoError(t, os.Symlink("../../../etc", filepath.Join(root, "rel")))
+
+	for _, tc := range []struct {
+		path     string
+		expected string
+	}{
+		{path: "/abs/passwd", expected: filepath.Join(root, "etc/passwd")},
+		{path: "/rel/passwd", expected: filepath.Join(root, "etc/passwd")},
+		{path: "/abs", expected: filepath.Join(root, "abs")},
+		{path: "/abs/", expected: filepath.Join(root, "etc")},
+		{path: "/abs/.", expected: filepath.Join(root, "etc")},
+		{path: "../../abs/passwd", expected: filepath.Join(root, "etc/passwd")},
+	} {
+		got, err := ResolvePath(root, tc.path)
+		assert.NoError(t, err, tc.path)
+		assert.Equal(t, tc.expected, got, tc.path)
+	}
+}
+
+func TestTarPathAndExtractTar(t *testing.T) {
+	source, err := ioutil.TempDir("", "source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(source)
+
+	destination, err := ioutil.TempDir("", "destination")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(destination)
+
+	dir := filepath.Join(source, "dir")
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "script.sh"), []byte("echo hi"), 0751))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "sub", "file"), []byte("data"), 0600))
+	assert.NoError(t, os.Symlink("sub/file", filepath.Join(dir, "link")))
+	assert.NoError(t, os.Link(filepath.Join(dir, "sub", "file"), filepath.Join(dir, "hardlink")))
+
+	// the directory itself
+	buf := new(bytes.Buffer)
+	assert.NoError(t, TarPath(buf, dir, "dir"))
+	assert.NoError(t, ExtractTar(buf, destination, ExtractOptions{}))
+
+	fi, err := os.Stat(filepath.Join(destination, "dir", "script.sh"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0751), fi.Mode().Perm())
+
+	fi, err = os.Stat(filepath.Join(destination, "dir", "sub"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), fi.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(destination, "dir", "link"))
+	assert.NoError(t, err)
+	assert.Equal(t, "sub/file", link)
+
+	// the hard links are kept.
+	fi1, err := os.Stat(filepath.Join(destination, "dir", "hardlink"))
+	assert.NoError(t, err)
+	fi2, err := os.Stat(filepath.Join(destination, "dir", "sub", "file"))
+	assert.NoError(t, err)
+	assert.True(t, os.SameFile(fi1, fi2))
+
+	// the contents of directory with rebase
+	buf.Reset()
+	assert.NoError(t, TarPath(buf, dir, ""))
+	assert.NoError(t, ExtractTar(buf, destination, ExtractOptions{Rebase: map[string]string{"sub": "renamed"}}))
+
+	data, err := ioutil.ReadFile(filepath.Join(destination, "renamed", "file"))
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+
+	_, err = os.Stat(filepath.Join(destination, "script.sh"))
+	assert.NoError(t, err)
+}
+
+func TestExtractTarRefusesEscape(t *testing.T) {
+	root, err := ioutil.TempDir("", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	outside, err := ioutil.TempDir("", "outside")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "dst"), 0755))
+
+	writeTar := func(headers ...*tar.Header) *bytes.Buffer {
+		buf := new(bytes.Buffer)
+		tw := tar.NewWriter(buf)
+		for _, h := range headers {
+			assert.NoError(t, tw.WriteHeader(h))
+		}
+		assert.NoError(t, tw.Close())
+		return buf
+	}
+
+	// the entry with parent reference is refused
+	buf := writeTar(&tar.Header{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0644})
+	assert.Error(t, ExtractTar(buf, "/dst", ExtractOptions{Root: root}))
+
+	// the file written through the symlink stays in root
+	buf = writeTar(
+		&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: outside},
+		&tar.Header{Name: "link/evil", Typeflag: tar.TypeReg, Mode: 0644},
+	)
+	assert.NoError(t, ExtractTar(buf, "/dst", ExtractOptions{Root: root}))
+
+	_, err = os.Stat(filepath.Join(outside, "evil"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(root, outside, "evil"))
+	assert.NoError(t, err)
+
+	// the hard link to the file out of root is refused
+	buf = writeTar(&tar.Header{Name: "hardlink", Typeflag: tar.TypeLink, Linkname: "../../etc/passwd"})
+	assert.Error(t, ExtractTar(buf, "/dst", ExtractOptions{Root: root}))
+	_, err = os.Lstat(filepath.Join(root, "dst", "hardlink"))
+	assert.True(t, os.IsNotExist(err))
+
+	// the directory can not be overwritten by a file
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "dst", "dir"), 0755))
+	buf = writeTar(&tar.Header{Name: "dir", Typeflag: tar.TypeReg, Mode: 0644})
+	assert.Error(t, ExtractTar(buf, "/dst", ExtractOptions{Root: root, NoOverwriteDirNonDir: true}))
+}
+
+func TestTarWithOptionsExcludeSpecialFiles(t *testing.T) {
+	source, err := ioutil.TempDir("", "source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(source)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(source, "file"), []byte("data"), 0644))
+	l, err := net.Listen("unix", filepath.Join(source, "sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, TarWithOptions(buf, source, "", TarOptions{ExcludeSpecialFiles: true}))
+
+	var names []string
+	tr := tar.NewReader(buf)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, header.Name)
+	}
+	assert.Equal(t, []string{"file"}, names)
+}
+
+func TestWithinRoot(t *testing.T) {
+	for _, tc := range []struct {
+		path     string
+		expected bool
+	}{
+		{path: "/root", expected: true},
+		{path: "/root/file", expected: true},
+		{path: "/root/..file", expected: true},
+		{path: "/", expected: false},
+		{path: "/rootfile", expected: false},
+		{path: "/root/../etc/passwd", expected: false},
+	} {
+		assert.Equal(t, tc.expected, withinRoot("/root", tc.path), tc.path)
+	}
+}
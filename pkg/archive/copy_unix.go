@@ -0,0 +1,22 @@
+// +build !windows
+
+package archive
+
+import (
+	"os"
+	"syscall"
+)
+
+// inode identifies the file which may be linked by several paths.
+type inode struct {
+	dev, ino uint64
+}
+
+// hardLinkInode returns the inode of file if it has more than one hard link.
+func hardLinkInode(fi os.FileInfo) (inode, bool) {
+	stat, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok || stat.Nlink <= 1 {
+		return inode{}, false
+	}
+	return inode{dev: uint64(stat.Dev), ino: uint64(stat.Ino)}, true
+}
@@ -0,0 +1,18 @@
+// +build windows
+
+package archive
+
+import (
+	"os"
+)
+
+// inode identifies the file which may be linked by several paths.
+type inode struct {
+	dev, ino uint64
+}
+
+// hardLinkInode never finds the hard links on windows, and the linked files
+// are archived as separated files.
+func hardLinkInode(fi os.FileInfo) (inode, bool) {
+	return inode{}, false
+}
@@ -55,6 +55,22 @@ func (m *SafeMap) Put(k string, v interface{}) {
 	m.inner[k] = v
 }
 
+// Rename moves the value from oldKey to newKey safely. If newKey already
+// exists, nothing is changed, and the value of newKey is returned with false.
+func (m *SafeMap) Rename(oldKey, newKey string) (*Value, bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	if v, ok := m.inner[newKey]; ok {
+		return &Value{v, ok}, false
+	}
+
+	v, ok := m.inner[oldKey]
+	delete(m.inner, oldKey)
+	m.inner[newKey] = v
+	return &Value{v, ok}, true
+}
+
 // Remove removes the key-value pair.
 func (m *SafeMap) Remove(k string) {
 	m.Lock()
@@ -138,6 +138,28 @@ func TestSafeMapRemove(t *testing.T) {
 	assert.Equal(t, len(safeMap.inner), 0)
 }
 
+func TestSafeMapRename(t *testing.T) {
+	safeMap := NewSafeMap()
+	safeMap.Put("foo", "1")
+	safeMap.Put("bar", "2")
+
+	// rename to an existing key
+	v, ok := safeMap.Rename("foo", "bar")
+	assert.False(t, ok)
+	s, _ := v.String()
+	assert.Equal(t, "2", s)
+	assert.True(t, safeMap.Get("foo").Exist())
+
+	v, ok = safeMap.Rename("foo", "baz")
+	assert.True(t, ok)
+	s, _ = v.String()
+	assert.Equal(t, "1", s)
+	assert.False(t, safeMap.Get("foo").Exist())
+
+	s, _ = safeMap.Get("baz").String()
+	assert.Equal(t, "1", s)
+}
+
 func TestResult(t *testing.T) {
 	value := &Value{
 		data: "asdf",
@@ -14,7 +14,7 @@ var (
 	// ErrAlreadyExisted represents the object has already existed.
 	ErrAlreadyExisted = errorType{codeAlreadyExisted, "already existed"}
 
-	// ErrConflict represents the parameters are invalid.
+	// ErrConflict represents the object conflicts with others.
 	ErrConflict = errorType{codeConflict, "conflict"}
 
 	// ErrTooMany reprensents the objects are too many.
@@ -37,6 +37,18 @@ var (
 
 	// ErrPreCheckFailed represents that failed to pre check.
 	ErrPreCheckFailed = errorType{codePreCheckFailed, "pre check failed"}
+
+	// ErrUnavailable represents that the service is unavailable temporarily,
+	// and the request can be retried later.
+	ErrUnavailable = errorType{codeUnavailable, "unavailable"}
+
+	// ErrContainerdUnavailable represents that containerd isn't connected,
+	// which is in the class of ErrUnavailable.
+	ErrContainerdUnavailable = errorType{codeUnavailable, "containerd unavailable"}
+
+	// ErrUnauthorized represents that the request is rejected by the remote
+	// service, such as the registry, because of the missing or wrong credential.
+	ErrUnauthorized = errorType{codeUnauthorized, "unauthorized"}
 )
 
 const (
@@ -51,6 +63,8 @@ const (
 	codeInUse
 	codeNotModified
 	codePreCheckFailed
+	codeUnavailable
+	codeUnauthorized
 
 	// volume error code
 	codeVolumeExisted
@@ -82,6 +96,11 @@ func IsInvalidParam(err error) bool {
 	return checkError(err, codeInvalidParam)
 }
 
+// IsConflict checks the error is conflict with others or not.
+func IsConflict(err error) bool {
+	return checkError(err, codeConflict)
+}
+
 // IsTimeout checks the error is time out or not.
 func IsTimeout(err error) bool {
 	return checkError(err, codeTimeout)
@@ -102,6 +121,16 @@ func IsPreCheckFailed(err error) bool {
 	return checkError(err, codePreCheckFailed)
 }
 
+// IsUnavailable checks the error is unavailable temporarily or not.
+func IsUnavailable(err error) bool {
+	return checkError(err, codeUnavailable)
+}
+
+// IsUnauthorized checks the error is unauthorized or not.
+func IsUnauthorized(err error) bool {
+	return checkError(err, codeUnauthorized)
+}
+
 func checkError(err error, code int) bool {
 	err = causeError(err)
 
@@ -23,3 +23,13 @@ func TestCheckError(t *testing.T) {
 		t.Error("check Wrap error")
 	}
 }
+
+func TestIsUnavailable(t *testing.T) {
+	if !IsUnavailable(errors.Wrap(ErrContainerdUnavailable, "failed to pull image")) {
+		t.Error("check containerd unavailable error")
+	}
+
+	if IsUnavailable(ErrTimeout) {
+		t.Error("check time out error")
+	}
+}
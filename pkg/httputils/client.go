@@ -24,12 +24,24 @@ func ParseHost(host string) (*url.URL, string, string, error) {
 	var basePath string
 	switch u.Scheme {
 	case "unix":
+		if u.Path == "" {
+			return nil, "", "", fmt.Errorf("invalid host %s: path of unix socket is required, such as unix:///var/run/pouchd.sock", host)
+		}
+		basePath = "http://d"
+	case "npipe":
+		if u.Path == "" {
+			return nil, "", "", fmt.Errorf("invalid host %s: path of named pipe is required, such as npipe:////./pipe/pouchd", host)
+		}
 		basePath = "http://d"
 	case "tcp":
+		if _, port, err := net.SplitHostPort(u.Host); err != nil || port == "" {
+			return nil, "", "", fmt.Errorf("invalid host %s: address and port are required, such as tcp://10.0.0.5:2377", host)
+		}
 		basePath = "http://" + u.Host
-	case "http":
-		basePath = host
-	case "https":
+	case "http", "https":
+		if u.Host == "" {
+			return nil, "", "", fmt.Errorf("invalid host %s: address is required, such as %s://10.0.0.5:2377", host, u.Scheme)
+		}
 		basePath = host
 	default:
 		return nil, "", "", fmt.Errorf("not support url scheme %v", u.Scheme)
@@ -39,13 +51,21 @@ func ParseHost(host string) (*url.URL, string, string, error) {
 }
 
 // GenTLSConfig returns a tls config object according to inputting parameters.
+// The key pair is optional for the client which doesn't present certificate,
+// and the CA verifies the certificate of server on client side, and the
+// certificates of clients on server side.
 func GenTLSConfig(key, cert, ca string) (*tls.Config, error) {
-	tlsConfig := &tls.Config{}
-	tlsCert, err := tls.LoadX509KeyPair(cert, key)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read X509 key pair (cert: %q, key: %q): %v", cert, key, err)
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if key != "" || cert != "" {
+		if key == "" || cert == "" {
+			return nil, fmt.Errorf("both cert and key of TLS are required (cert: %q, key: %q)", cert, key)
+		}
+		tlsCert, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read X509 key pair (cert: %q, key: %q): %v", cert, key, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{tlsCert}
 	}
-	tlsConfig.Certificates = []tls.Certificate{tlsCert}
 	if ca == "" {
 		return tlsConfig, nil
 	}
@@ -58,6 +78,7 @@ func GenTLSConfig(key, cert, ca string) (*tls.Config, error) {
 	if !cp.AppendCertsFromPEM(pem) {
 		return nil, fmt.Errorf("failed to append certificates from PEM file: %q", ca)
 	}
+	tlsConfig.RootCAs = cp
 	tlsConfig.ClientCAs = cp
 	return tlsConfig, nil
 }
@@ -69,11 +90,11 @@ func NewHTTPClient(u *url.URL, tlsConfig *tls.Config, dialTimeout time.Duration)
 	}
 
 	switch u.Scheme {
-	case "unix":
-		unixDial := func(ctx context.Context, network, addr string) (net.Conn, error) {
-			return net.DialTimeout("unix", u.Path, dialTimeout)
+	case "unix", "npipe":
+		localDial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return Dial(ctx, u.Scheme, u.Path, dialTimeout)
 		}
-		tr.DialContext = unixDial
+		tr.DialContext = localDial
 	default:
 		dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
 			return net.DialTimeout(network, addr, dialTimeout)
@@ -85,3 +106,25 @@ func NewHTTPClient(u *url.URL, tlsConfig *tls.Config, dialTimeout time.Duration)
 		Transport: tr,
 	}
 }
+
+// IsLocalScheme returns true if the scheme of host is a local socket, which
+// is unix socket or named pipe on windows, and TLS is never used by them.
+func IsLocalScheme(scheme string) bool {
+	return scheme == "unix" || scheme == "npipe"
+}
+
+// Dial connects to the address of host by the scheme, which is the path of
+// unix socket, the path of named pipe such as //./pipe/pouchd, or host:port
+// of tcp for the other schemes.
+func Dial(ctx context.Context, scheme, addr string, timeout time.Duration) (net.Conn, error) {
+	switch scheme {
+	case "unix":
+		dialer := &net.Dialer{Timeout: timeout}
+		return dialer.DialContext(ctx, "unix", addr)
+	case "npipe":
+		return dialPipe(ctx, strings.Replace(addr, "/", `\`, -1), timeout)
+	default:
+		dialer := &net.Dialer{Timeout: timeout}
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+}
@@ -1,10 +1,17 @@
 package httputils
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -27,7 +34,14 @@ func TestParseHost(t *testing.T) {
 		{host: "tcp://localhost:1234", expectError: false, expectBasePath: "http://localhost:1234", expectAddr: "localhost:1234"},
 		{host: "http://localhost:5678", expectError: false, expectBasePath: "http://localhost:5678", expectAddr: "localhost:5678"},
 		{host: "foo:bar", expectError: true, expectBasePath: "", expectAddr: ""},
+		{host: "10.0.0.5:2377", expectError: true, expectBasePath: "", expectAddr: ""},
+		{host: "unix://", expectError: true, expectBasePath: "", expectAddr: ""},
+		{host: "tcp://10.0.0.5", expectError: true, expectBasePath: "", expectAddr: ""},
+		{host: "tcp://:2377", expectError: false, expectBasePath: "http://:2377", expectAddr: ":2377"},
+		{host: "http://", expectError: true, expectBasePath: "", expectAddr: ""},
 		{host: "", expectError: true, expectBasePath: "", expectAddr: ""},
+		{host: "npipe:////./pipe/pouchd", expectError: false, expectBasePath: "http://d", expectAddr: "//./pipe/pouchd"},
+		{host: "npipe://", expectError: true, expectBasePath: "", expectAddr: ""},
 	}
 
 	for _, p := range parseds {
@@ -55,7 +69,11 @@ func TestGenTLSConfig(t *testing.T) {
 		want    *tls.Config
 		wantErr bool
 	}{
-		// TODO: Add test cases.
+		{name: "no key pair and ca", want: &tls.Config{MinVersion: tls.VersionTLS12}},
+		{name: "key without cert", args: args{key: "key.pem"}, wantErr: true},
+		{name: "cert without key", args: args{cert: "cert.pem"}, wantErr: true},
+		{name: "missing key pair", args: args{key: "/nonexistent/key.pem", cert: "/nonexistent/cert.pem"}, wantErr: true},
+		{name: "missing ca", args: args{ca: "/nonexistent/ca.pem"}, wantErr: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -70,3 +88,41 @@ func TestGenTLSConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestDial(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httputils-dial")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sock := filepath.Join(dir, "pouchd.sock")
+	unixListener, err := net.Listen("unix", sock)
+	assert.NoError(t, err)
+	defer unixListener.Close()
+
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer tcpListener.Close()
+
+	for scheme, addr := range map[string]string{
+		"unix": sock,
+		"tcp":  tcpListener.Addr().String(),
+		"http": tcpListener.Addr().String(),
+	} {
+		conn, err := Dial(context.Background(), scheme, addr, time.Second)
+		if assert.NoError(t, err, scheme) {
+			conn.Close()
+		}
+	}
+
+	if runtime.GOOS != "windows" {
+		_, err := Dial(context.Background(), "npipe", "//./pipe/pouchd", time.Second)
+		assert.EqualError(t, err, `failed to dial \\.\pipe\pouchd: named pipe is only supported on windows`)
+	}
+}
+
+func TestIsLocalScheme(t *testing.T) {
+	assert.True(t, IsLocalScheme("unix"))
+	assert.True(t, IsLocalScheme("npipe"))
+	assert.False(t, IsLocalScheme("tcp"))
+	assert.False(t, IsLocalScheme("https"))
+}
@@ -0,0 +1,15 @@
+// +build !windows
+
+package httputils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// dialPipe fails since named pipe is only supported on windows.
+func dialPipe(ctx context.Context, name string, timeout time.Duration) (net.Conn, error) {
+	return nil, fmt.Errorf("failed to dial %s: named pipe is only supported on windows", name)
+}
@@ -0,0 +1,22 @@
+// +build windows
+
+package httputils
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// dialPipe connects to the named pipe, such as \\.\pipe\pouchd. The timeout
+// is shortened by the deadline of context.
+func dialPipe(ctx context.Context, name string, timeout time.Duration) (net.Conn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		if left := time.Until(deadline); left < timeout {
+			timeout = left
+		}
+	}
+	return winio.DialPipe(name, &timeout)
+}
@@ -1,5 +1,11 @@
 package httputils
 
+import (
+	"net/http"
+
+	"github.com/alibaba/pouch/pkg/errtypes"
+)
+
 // HTTPError represents an HTTP error which contains potential status code.
 // For API layer, daemon side should return error message and using correct status code
 // to construct response when an error happens in handling requests.
@@ -25,3 +31,27 @@ func (err HTTPError) Error() string {
 func (err HTTPError) Code() int {
 	return err.statusCode
 }
+
+// StatusCode returns the status code of response by the type of error, and
+// 500 if the error isn't typed.
+func StatusCode(err error) int {
+	if httpErr, ok := err.(HTTPError); ok {
+		return httpErr.Code()
+	}
+
+	switch {
+	case errtypes.IsNotfound(err):
+		return http.StatusNotFound
+	case errtypes.IsInvalidParam(err):
+		return http.StatusBadRequest
+	case errtypes.IsAlreadyExisted(err), errtypes.IsInUse(err), errtypes.IsConflict(err):
+		return http.StatusConflict
+	case errtypes.IsNotModified(err):
+		return http.StatusNotModified
+	case errtypes.IsUnavailable(err):
+		return http.StatusServiceUnavailable
+	case errtypes.IsUnauthorized(err):
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
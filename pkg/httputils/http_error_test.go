@@ -0,0 +1,32 @@
+package httputils
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/pkg/errors"
+)
+
+func TestStatusCode(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{err: NewHTTPError(fmt.Errorf("forbidden"), http.StatusForbidden), code: http.StatusForbidden},
+		{err: errors.Wrap(errtypes.ErrNotfound, "image foo"), code: http.StatusNotFound},
+		{err: errors.Wrap(errtypes.ErrInvalidParam, "invalid name"), code: http.StatusBadRequest},
+		{err: errors.Wrap(errtypes.ErrAlreadyExisted, "container foo"), code: http.StatusConflict},
+		{err: errors.Wrap(errtypes.ErrConflict, "container foo is paused"), code: http.StatusConflict},
+		{err: errtypes.ErrNotModified, code: http.StatusNotModified},
+		{err: errors.Wrap(errtypes.ErrContainerdUnavailable, "failed to pull"), code: http.StatusServiceUnavailable},
+		{err: errors.Wrap(errtypes.ErrUnauthorized, "pull access denied"), code: http.StatusUnauthorized},
+		{err: fmt.Errorf("unknown error"), code: http.StatusInternalServerError},
+	} {
+		if code := StatusCode(tc.err); code != tc.code {
+			t.Errorf("expected code %d of error %q, but got %d", tc.code, tc.err, code)
+		}
+	}
+}
@@ -19,9 +19,18 @@ const (
 	PullStatusExists = "exists"
 	// PullStatusDone represents done status.
 	PullStatusDone = "done"
+	// PullStatusRetrying represents the fetch is retrying after failure.
+	PullStatusRetrying = "retrying"
 
 	// PushStatusUploading represents uploading status.
 	PushStatusUploading = "uploading"
+
+	// LoadStatusLoading represents loading status.
+	LoadStatusLoading = "loading"
+	// LoadStatusUnpacking represents unpacking status.
+	LoadStatusUnpacking = "unpacking"
+	// LoadStatusLoaded represents the image has been loaded.
+	LoadStatusLoaded = "loaded"
 )
 
 // ProcessStatus returns the status of download or upload image
@@ -36,7 +45,7 @@ func ProcessStatus(short bool, msg JSONMessage) string {
 	switch msg.Status {
 	case PullStatusResolving, PullStatusWaiting:
 		return fmt.Sprintf("%s:\t%s\t%40r\t\n", msg.ID, msg.Status, progress.Bar(0.0))
-	case PullStatusDownloading, PushStatusUploading:
+	case PullStatusDownloading, PullStatusRetrying, PushStatusUploading, LoadStatusLoading:
 		bar := progress.Bar(0)
 		current, total := progress.Bytes(msg.Detail.Current), progress.Bytes(msg.Detail.Total)
 
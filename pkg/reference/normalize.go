@@ -0,0 +1,64 @@
+package reference
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNotLowercase is used to return error if the repository name contains
+// uppercase letters.
+var ErrNotLowercase = errors.New("invalid reference format: repository name must be lowercase")
+
+// SplitDomain splits the name into domain and remainder. The domain is empty
+// if the first component of name isn't hostname, which contains "." or ":"
+// or is "localhost".
+func SplitDomain(name string) (domain, remainder string) {
+	idx := strings.IndexRune(name, '/')
+	if idx == -1 {
+		return "", name
+	}
+
+	if d := name[:idx]; d == "localhost" || strings.ContainsAny(d, ".:") {
+		return d, name[idx+1:]
+	}
+	return "", name
+}
+
+// AddDefaultRegistryIfMissing will add default registry and namespace if
+// missing. The namespace is only added for the default registry.
+func AddDefaultRegistryIfMissing(ref string, defaultRegistry, defaultNamespace string) string {
+	registry, remainder := SplitDomain(ref)
+	if registry == "" {
+		registry = defaultRegistry
+	}
+
+	if registry == defaultRegistry && !strings.ContainsAny(remainder, "/") {
+		remainder = defaultNamespace + "/" + remainder
+	}
+	return registry + "/" + remainder
+}
+
+// Normalize expands the reference into the fully qualified reference. The
+// default registry and namespace will be added if the reference doesn't
+// contain registry, and the default tag will be added if the reference has
+// neither tag nor digest.
+//
+// For example, if the default registry is docker.io and the default
+// namespace is library,
+//
+//	busybox               => docker.io/library/busybox:latest
+//	foo/bar:1.0           => docker.io/foo/bar:1.0
+//	localhost:5000/foo    => localhost:5000/foo:latest
+//	reg.abc.com/os@sha256 => reg.abc.com/os@sha256
+func Normalize(ref string, defaultRegistry, defaultNamespace string) (Named, error) {
+	named, err := Parse(AddDefaultRegistryIfMissing(ref, defaultRegistry, defaultNamespace))
+	if err != nil {
+		return nil, err
+	}
+
+	// the hostname is case-insensitive, but the repository name isn't.
+	if _, remainder := SplitDomain(named.Name()); remainder != strings.ToLower(remainder) {
+		return nil, ErrNotLowercase
+	}
+	return WithDefaultTagIfMissing(named), nil
+}
@@ -0,0 +1,147 @@
+package reference
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddDefaultRegistryIfMissing(t *testing.T) {
+	defaultRegistry, defaultNamespace := "pouch.io", "library"
+
+	for _, tc := range []struct {
+		repo   string
+		expect string
+	}{
+		{
+			repo:   "docker.io/library/busybox",
+			expect: "docker.io/library/busybox",
+		}, {
+			repo:   "library/busybox",
+			expect: defaultRegistry + "/library/busybox",
+		}, {
+			repo:   "127.0.0.1:5000/bar",
+			expect: "127.0.0.1:5000/bar",
+		},
+		{
+			repo:   "0.0.0.0/bar",
+			expect: "0.0.0.0/bar",
+		}, {
+			repo:   "localhost/bar",
+			expect: "localhost/bar",
+		}, {
+			repo:   "registry.com/bar",
+			expect: "registry.com/bar",
+		}, {
+			repo:   "bar",
+			expect: defaultRegistry + "/" + defaultNamespace + "/" + "bar",
+		}, {
+			repo:   "busybox@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
+			expect: defaultRegistry + "/" + defaultNamespace + "/" + "busybox@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
+		}, {
+			repo:   "foo/bar",
+			expect: defaultRegistry + "/foo/bar",
+		}, {
+			repo:   "foo/bar@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
+			expect: defaultRegistry + "/foo/bar@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
+		},
+	} {
+		assert.Equal(t, tc.expect, AddDefaultRegistryIfMissing(tc.repo, defaultRegistry, defaultNamespace))
+	}
+}
+
+func TestSplitDomain(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		domain    string
+		remainder string
+	}{
+		{name: "busybox", domain: "", remainder: "busybox"},
+		{name: "library/busybox", domain: "", remainder: "library/busybox"},
+		{name: "localhost/busybox", domain: "localhost", remainder: "busybox"},
+		{name: "localhost:5000/foo/bar", domain: "localhost:5000", remainder: "foo/bar"},
+		{name: "reg.abc.com/base/os", domain: "reg.abc.com", remainder: "base/os"},
+	} {
+		domain, remainder := SplitDomain(tc.name)
+		assert.Equal(t, tc.domain, domain, tc.name)
+		assert.Equal(t, tc.remainder, remainder, tc.name)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	defaultRegistry, defaultNamespace := "docker.io", "library"
+
+	for _, tc := range []struct {
+		name     string
+		input    string
+		expected string
+		err      error
+	}{
+		{
+			name:     "Bare name",
+			input:    "busybox",
+			expected: "docker.io/library/busybox:latest",
+		}, {
+			name:     "Bare name with tag",
+			input:    "busybox:1.28",
+			expected: "docker.io/library/busybox:1.28",
+		}, {
+			name:     "Name with namespace",
+			input:    "foo/bar",
+			expected: "docker.io/foo/bar:latest",
+		}, {
+			name:     "Nested path",
+			input:    "foo/bar/baz:1.0",
+			expected: "docker.io/foo/bar/baz:1.0",
+		}, {
+			name:     "Localhost with port",
+			input:    "localhost:5000/foo",
+			expected: "localhost:5000/foo:latest",
+		}, {
+			name:     "Localhost without port",
+			input:    "localhost/foo",
+			expected: "localhost/foo:latest",
+		}, {
+			name:     "Registry with nested path",
+			input:    "reg.abc.com/base/os/centos:7.2",
+			expected: "reg.abc.com/base/os/centos:7.2",
+		}, {
+			name:     "Fully qualified",
+			input:    "docker.io/library/busybox:latest",
+			expected: "docker.io/library/busybox:latest",
+		}, {
+			name:     "Digest",
+			input:    "busybox@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
+			expected: "docker.io/library/busybox@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
+		}, {
+			name:     "Tag and digest",
+			input:    "reg.abc.com/os:7.2@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
+			expected: "reg.abc.com/os:7.2@sha256:58ac43b2cc92c687a32c8be6278e50a063579655fe3090125dcb2af0ff9e1a64",
+		}, {
+			name:     "Uppercase registry",
+			input:    "REG.ABC.COM/os",
+			expected: "REG.ABC.COM/os:latest",
+		}, {
+			name:  "Uppercase name",
+			input: "BusyBox",
+			err:   ErrNotLowercase,
+		}, {
+			name:  "Uppercase nested path",
+			input: "reg.abc.com/Base/os:7.2",
+			err:   ErrNotLowercase,
+		}, {
+			name:  "Invalid",
+			input: "busybox?tag=latest",
+			err:   ErrInvalid,
+		},
+	} {
+		named, err := Normalize(tc.input, defaultRegistry, defaultNamespace)
+		if tc.err != nil {
+			assert.Equal(t, tc.err, err, tc.name)
+			continue
+		}
+
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expected, named.String(), tc.name)
+	}
+}
@@ -2,6 +2,8 @@ package reference
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	digest "github.com/opencontainers/go-digest"
 )
@@ -26,6 +28,10 @@ func Parse(ref string) (Named, error) {
 	if digStr != "" {
 		dig, err := digest.Parse(digStr)
 		if err != nil {
+			if err == digest.ErrDigestUnsupported {
+				algorithm := strings.SplitN(digStr, ":", 2)[0]
+				return nil, fmt.Errorf("unsupported digest algorithm %q in reference %s, only %s is supported", algorithm, ref, digest.Canonical)
+			}
 			return nil, err
 		}
 
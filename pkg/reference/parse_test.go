@@ -118,6 +118,11 @@ func TestParse(t *testing.T) {
 			input:    "busybox@sha256:1669a6aa7350e1cdd28f972ddad5aceba2912f589f19a090ac",
 			expected: nil,
 			err:      errors.New("invalid checksum digest length"),
+		}, {
+			name:     "Unsupported digest algorithm",
+			input:    "busybox@md5:1669a6aa7350e1cdd28f972ddad5aceb",
+			expected: nil,
+			err:      errors.New(`unsupported digest algorithm "md5" in reference busybox@md5:1669a6aa7350e1cdd28f972ddad5aceb, only sha256 is supported`),
 		}, {
 			name:  "Digest ID",
 			input: "sha256:1669a6aa7350e1cdd28f972ddad5aceba2912f589f19a090ac",
@@ -154,3 +159,23 @@ func TestParse(t *testing.T) {
 		assert.Equal(t, tc.expected, ref, tc.name)
 	}
 }
+
+func TestTrimTagForDigest(t *testing.T) {
+	dig := digest.Digest("sha256:1669a6aa7350e1cdd28f972ddad5aceba2912f589f19a090ac75b7083da748db")
+
+	// name:tag@digest should prefer the digest
+	named := TrimTagForDigest(reference{
+		Named:  namedReference{"busybox"},
+		tag:    "1.25",
+		digest: dig,
+	})
+	assert.Equal(t, true, IsCanonicalDigested(named))
+	assert.Equal(t, "busybox@"+dig.String(), named.String())
+
+	// name:tag should be kept
+	named = TrimTagForDigest(taggedReference{
+		Named: namedReference{"busybox"},
+		tag:   "1.25",
+	})
+	assert.Equal(t, "busybox:1.25", named.String())
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"io"
 
+	"github.com/alibaba/pouch/pkg/term"
+
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
@@ -34,6 +36,10 @@ type AttachConfig struct {
 
 	Stdin          io.ReadCloser
 	Stdout, Stderr io.Writer
+
+	// DetachKeys is the key sequence to detach the client's stream from
+	// the process's stream without closing the stdin of process.
+	DetachKeys []byte
 }
 
 // CopyPipes will watchs the data pipe's channel, like sticked to the pipe.
@@ -84,25 +90,6 @@ func (s *Stream) Attach(ctx context.Context, cfg *AttachConfig) <-chan error {
 		stdout, stderr io.ReadCloser
 	)
 
-	if cfg.UseStdin {
-		group.Go(func() error {
-			logrus.Debug("start to attach stdin to stream")
-			defer logrus.Debug("stop attach stdin to stream")
-
-			defer func() {
-				if cfg.CloseStdin {
-					s.StdinPipe().Close()
-				}
-			}()
-
-			_, err := io.Copy(s.StdinPipe(), cfg.Stdin)
-			if err == io.ErrClosedPipe {
-				err = nil
-			}
-			return err
-		})
-	}
-
 	attachFn := func(styp string, w io.Writer, r io.ReadCloser) error {
 		logrus.Debugf("start to attach %s to stream", styp)
 		defer logrus.Debugf("stop attach %s to stream", styp)
@@ -140,6 +127,41 @@ func (s *Stream) Attach(ctx context.Context, cfg *AttachConfig) <-chan error {
 		})
 	}
 
+	if cfg.UseStdin {
+		group.Go(func() error {
+			logrus.Debug("start to attach stdin to stream")
+			defer logrus.Debug("stop attach stdin to stream")
+
+			var r io.Reader = cfg.Stdin
+			if len(cfg.DetachKeys) > 0 {
+				r = term.NewEscapeProxy(cfg.Stdin, cfg.DetachKeys)
+			}
+
+			_, err := io.Copy(s.StdinPipe(), r)
+			if err == term.ErrDetached {
+				// NOTE: the process keeps running after detaching,
+				// so only the client's streams are closed.
+				logrus.Debug("the client's stream is detached")
+				cfg.Stdin.Close()
+				if cfg.UseStdout {
+					stdout.Close()
+				}
+				if cfg.UseStderr {
+					stderr.Close()
+				}
+				return nil
+			}
+
+			if cfg.CloseStdin {
+				s.StdinPipe().Close()
+			}
+			if err == io.ErrClosedPipe {
+				err = nil
+			}
+			return err
+		})
+	}
+
 	var (
 		errCh      = make(chan error, 1)
 		groupErrCh = make(chan error, 1)
@@ -73,3 +73,48 @@ func TestAttachWithCloseStdin(t *testing.T) {
 		t.Fatalf("failed to stop stream: %v", err)
 	}
 }
+
+func TestAttachWithDetachKeys(t *testing.T) {
+	var (
+		aStdin  = &bufferWrapper{bytes.NewBufferString("hello\x10\x11world")}
+		aStdout = bytes.NewBuffer(nil)
+	)
+
+	attachCfg := &AttachConfig{
+		UseStdin:   true,
+		Stdin:      aStdin,
+		UseStdout:  true,
+		Stdout:     aStdout,
+		CloseStdin: true,
+		DetachKeys: []byte{16, 17},
+	}
+
+	stream := NewStream()
+	stream.NewStdinInput()
+
+	received := make(chan string, 1)
+	go func() {
+		b := make([]byte, 5)
+		io.ReadFull(stream.Stdin(), b)
+		received <- string(b)
+	}()
+
+	if err := <-stream.Attach(context.Background(), attachCfg); err != nil {
+		t.Fatalf("failed to attach: %v", err)
+	}
+
+	if got := <-received; got != "hello" {
+		t.Fatalf("expected to get (hello) before detaching, but got (%s)", got)
+	}
+
+	// the stdin of process should not be closed after detaching
+	go stream.StdinPipe().Write([]byte("x"))
+	b := make([]byte, 1)
+	if _, err := io.ReadFull(stream.Stdin(), b); err != nil {
+		t.Fatalf("expected stdin to be open after detaching, but got %v", err)
+	}
+
+	if err := stream.Close(); err != nil {
+		t.Fatalf("failed to stop stream: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package system
 
 import (
 	"bufio"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -10,10 +11,12 @@ import (
 
 // MemoryCgroupInfo defines memory cgroup information on current machine
 type MemoryCgroupInfo struct {
-	MemoryLimit      bool
-	MemorySwap       bool
-	MemorySwappiness bool
-	OOMKillDisable   bool
+	MemoryLimit       bool
+	MemorySwap        bool
+	MemoryReservation bool
+	MemorySwappiness  bool
+	OOMKillDisable    bool
+	KernelMemory      bool
 }
 
 // CPUCgroupInfo defines cpu cgroup information on current machine
@@ -42,6 +45,9 @@ type PidsCgroupInfo struct {
 
 // CgroupInfo defines cgroup information on current machine
 type CgroupInfo struct {
+	// Unified is true if the machine runs in cgroup v2 unified hierarchy.
+	Unified bool
+
 	Memory *MemoryCgroupInfo
 	CPU    *CPUCgroupInfo
 	Blkio  *BlkioCgroupInfo
@@ -52,6 +58,10 @@ type CgroupInfo struct {
 func NewCgroupInfo() *CgroupInfo {
 	cgroupRootPath := getCgroupRootMount("/proc/self/mountinfo")
 	if cgroupRootPath == "" {
+		// no cgroup v1 hierarchy is mounted, try cgroup v2 unified hierarchy.
+		if unifiedPath := getCgroup2Mount("/proc/self/mountinfo"); unifiedPath != "" {
+			return getCgroup2Info(unifiedPath, getCgroup2Group("/proc/self/cgroup"))
+		}
 		return nil
 	}
 
@@ -66,10 +76,12 @@ func NewCgroupInfo() *CgroupInfo {
 func getMemoryCgroupInfo(root string) *MemoryCgroupInfo {
 	path := path.Join(root, "memory")
 	return &MemoryCgroupInfo{
-		MemoryLimit:      isCgroupEnable(path, "memory.limit_in_bytes"),
-		MemorySwap:       isCgroupEnable(path, "memory.memsw.limit_in_bytes"),
-		MemorySwappiness: isCgroupEnable(path, "memory.swappiness"),
-		OOMKillDisable:   isCgroupEnable(path, "memory.oom_control"),
+		MemoryLimit:       isCgroupEnable(path, "memory.limit_in_bytes"),
+		MemorySwap:        isCgroupEnable(path, "memory.memsw.limit_in_bytes"),
+		MemoryReservation: isCgroupEnable(path, "memory.soft_limit_in_bytes"),
+		MemorySwappiness:  isCgroupEnable(path, "memory.swappiness"),
+		OOMKillDisable:    isCgroupEnable(path, "memory.oom_control"),
+		KernelMemory:      isCgroupEnable(path, "memory.kmem.limit_in_bytes"),
 	}
 }
 
@@ -141,3 +153,97 @@ func getCgroupRootMount(mountFile string) string {
 
 	return cgroupRootPath
 }
+
+// getCgroup2Mount returns the mount point of cgroup v2 unified hierarchy.
+func getCgroup2Mount(mountFile string) string {
+	f, err := os.Open(mountFile)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text := scanner.Text()
+		index := strings.Index(text, " - ")
+		if index < 0 {
+			continue
+		}
+		fields := strings.Split(text, " ")
+		postSeparatorFields := strings.Fields(text[index+3:])
+		if len(fields) < 5 || len(postSeparatorFields) < 1 || postSeparatorFields[0] != "cgroup2" {
+			continue
+		}
+
+		if _, err := os.Stat(fields[4]); err == nil {
+			return fields[4]
+		}
+	}
+	return ""
+}
+
+// getCgroup2Group returns the cgroup v2 group of current process, which is
+// in the line of format 0::/path in cgroup file.
+func getCgroup2Group(cgroupFile string) string {
+	data, err := ioutil.ReadFile(cgroupFile)
+	if err != nil {
+		return "/"
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "0::") {
+			return strings.TrimPrefix(line, "0::")
+		}
+	}
+	return "/"
+}
+
+// getCgroup2Info gets the cgroup information from the controllers enabled
+// in cgroup v2 unified hierarchy. The cgroup v1 resources are converted into
+// the unified controllers by the runtime, and the ones which have no
+// equivalent in cgroup v2 are marked as unsupported.
+func getCgroup2Info(root, group string) *CgroupInfo {
+	controllers := make(map[string]bool)
+	if data, err := ioutil.ReadFile(path.Join(root, "cgroup.controllers")); err == nil {
+		for _, c := range strings.Fields(string(data)) {
+			controllers[c] = true
+		}
+	}
+
+	// the interface files do not exist in root cgroup, so check the swap
+	// accounting in the cgroup of current process, or the system.slice.
+	swap := false
+	for _, g := range []string{group, "system.slice"} {
+		if g != "/" && isCgroupEnable(root, g, "memory.swap.max") {
+			swap = true
+			break
+		}
+	}
+
+	return &CgroupInfo{
+		Unified: true,
+		Memory: &MemoryCgroupInfo{
+			MemoryLimit:       controllers["memory"],
+			MemorySwap:        controllers["memory"] && swap,
+			MemoryReservation: controllers["memory"],
+		},
+		CPU: &CPUCgroupInfo{
+			CpusetCpus: controllers["cpuset"],
+			CpusetMems: controllers["cpuset"],
+			CPUShares:  controllers["cpu"],
+			CPUQuota:   controllers["cpu"],
+			CPUPeriod:  controllers["cpu"],
+		},
+		Blkio: &BlkioCgroupInfo{
+			BlkioWeight:          controllers["io"],
+			BlkioWeightDevice:    controllers["io"],
+			BlkioDeviceReadBps:   controllers["io"],
+			BlkioDeviceWriteBps:  controllers["io"],
+			BlkioDeviceReadIOps:  controllers["io"],
+			BlkioDeviceWriteIOps: controllers["io"],
+		},
+		Pids: &PidsCgroupInfo{
+			Pids: controllers["pids"],
+		},
+	}
+}
@@ -84,3 +84,66 @@ func TestGetCgroupRootMount(t *testing.T) {
 		assert.Equal(tc.cgroupMount, getCgroupRootMount(file))
 	}
 }
+
+func TestGetCgroup2Mount(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "test-cgroup2-mount")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	file := filepath.Join(tmpDir, "mountinfo")
+
+	for _, tc := range []struct {
+		data     string
+		expected string
+	}{
+		{data: "", expected: ""},
+		{data: "18 58 0:17 / /sys rw,relatime shared:6 - sysfs sysfs rw", expected: ""},
+		{data: fmt.Sprintf("a a a a %s a a - cgroup a a", tmpDir), expected: ""},
+		{data: fmt.Sprintf("26 18 0:23 / %s rw shared:4 - cgroup2 cgroup2 rw,nsdelegate", tmpDir), expected: tmpDir},
+		{data: "26 18 0:23 / /not/exist rw shared:4 - cgroup2 cgroup2 rw", expected: ""},
+	} {
+		assert.NoError(t, ioutil.WriteFile(file, []byte(tc.data), 0644))
+		assert.Equal(t, tc.expected, getCgroup2Mount(file), tc.data)
+	}
+}
+
+func TestGetCgroup2Group(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "test-cgroup2-group")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	file := filepath.Join(tmpDir, "cgroup")
+
+	assert.Equal(t, "/", getCgroup2Group(file))
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("0::/system.slice/pouch.service\n"), 0644))
+	assert.Equal(t, "/system.slice/pouch.service", getCgroup2Group(file))
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("1:name=systemd:/init.scope\n"), 0644))
+	assert.Equal(t, "/", getCgroup2Group(file))
+}
+
+func TestGetCgroup2Info(t *testing.T) {
+	root, err := ioutil.TempDir("", "test-cgroup2-info")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu memory pids\n"), 0644))
+
+	info := getCgroup2Info(root, "/pouch.service")
+	assert.True(t, info.Unified)
+	assert.True(t, info.Memory.MemoryLimit)
+	assert.True(t, info.Memory.MemoryReservation)
+	assert.False(t, info.Memory.MemorySwap)
+	assert.False(t, info.Memory.MemorySwappiness)
+	assert.False(t, info.Memory.OOMKillDisable)
+	assert.False(t, info.Memory.KernelMemory)
+	assert.True(t, info.CPU.CPUQuota)
+	assert.False(t, info.CPU.CpusetCpus)
+	assert.False(t, info.Blkio.BlkioWeight)
+	assert.True(t, info.Pids.Pids)
+
+	// swap accounting is enabled if memory.swap.max exists
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "pouch.service"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "pouch.service", "memory.swap.max"), []byte("max\n"), 0644))
+	info = getCgroup2Info(root, "/pouch.service")
+	assert.True(t, info.Memory.MemorySwap)
+}
@@ -0,0 +1,121 @@
+package term
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultDetachKeys is the default key sequence for detaching a container.
+const DefaultDetachKeys = "ctrl-p,ctrl-q"
+
+// ErrDetached is returned by the reader of escape proxy when the detach key
+// sequence is read.
+var ErrDetached = errors.New("detached from container")
+
+// ToDetachKeys converts the detach keys string, such as "ctrl-p,ctrl-q",
+// into the sequence of bytes. The key is a single letter or one of
+// ctrl-<value>, where the value is a letter, @, [, \, ], ^ or _.
+func ToDetachKeys(keys string) ([]byte, error) {
+	var codes []byte
+
+	for _, key := range strings.Split(keys, ",") {
+		if len(key) == 1 {
+			codes = append(codes, key[0])
+			continue
+		}
+
+		key = strings.ToLower(key)
+		if !strings.HasPrefix(key, "ctrl-") || len(key) != len("ctrl-")+1 {
+			return nil, fmt.Errorf("invalid detach key %q: must be a letter or ctrl-<value>", key)
+		}
+
+		code := key[len("ctrl-")]
+		switch {
+		case code >= 'a' && code <= 'z':
+			codes = append(codes, code-'a'+1)
+		case code == '@':
+			codes = append(codes, 0)
+		case code >= '[' && code <= '_':
+			codes = append(codes, code-'['+27)
+		default:
+			return nil, fmt.Errorf("invalid detach key %q: unknown ctrl value", key)
+		}
+	}
+	return codes, nil
+}
+
+// escapeProxy is used to watch the escape keys in the stream.
+type escapeProxy struct {
+	r         io.Reader
+	keys      []byte
+	buffered  []byte
+	nextIndex int
+	detached  bool
+}
+
+// NewEscapeProxy returns a reader which returns ErrDetached once the keys are
+// read from r. The bytes of a partial matched sequence are held until the
+// sequence is broken.
+func NewEscapeProxy(r io.Reader, keys []byte) io.Reader {
+	return &escapeProxy{
+		r:    r,
+		keys: keys,
+	}
+}
+
+// Read implements the io.Reader interface.
+func (p *escapeProxy) Read(buf []byte) (int, error) {
+	if len(p.keys) == 0 {
+		return p.r.Read(buf)
+	}
+
+	// flush the bytes read before the keys or held by the broken sequence.
+	if len(p.buffered) > 0 {
+		n := copy(buf, p.buffered)
+		p.buffered = p.buffered[n:]
+		return n, nil
+	}
+
+	if p.detached {
+		return 0, ErrDetached
+	}
+
+	n, err := p.r.Read(buf)
+	if n == 0 {
+		return 0, err
+	}
+
+	out := make([]byte, 0, n+p.nextIndex)
+	for _, b := range buf[:n] {
+		if b == p.keys[p.nextIndex] {
+			p.nextIndex++
+			if p.nextIndex == len(p.keys) {
+				p.detached = true
+				break
+			}
+			continue
+		}
+
+		// the sequence is broken, release the held bytes.
+		out = append(out, p.keys[:p.nextIndex]...)
+		p.nextIndex = 0
+		if b == p.keys[0] {
+			p.nextIndex = 1
+			continue
+		}
+		out = append(out, b)
+	}
+
+	if p.detached && len(out) == 0 {
+		return 0, ErrDetached
+	}
+
+	copied := copy(buf, out)
+	p.buffered = append(p.buffered, out[copied:]...)
+	if p.detached {
+		return copied, nil
+	}
+	return copied, err
+}
@@ -0,0 +1,58 @@
+package term
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToDetachKeys(t *testing.T) {
+	for _, tc := range []struct {
+		keys     string
+		expected []byte
+		hasError bool
+	}{
+		{keys: "ctrl-p,ctrl-q", expected: []byte{16, 17}},
+		{keys: "CTRL-A,x", expected: []byte{1, 'x'}},
+		{keys: "ctrl-@,ctrl-[,ctrl-_", expected: []byte{0, 27, 31}},
+		{keys: "ctrl-", hasError: true},
+		{keys: "ctrl-1", hasError: true},
+		{keys: "shift-a", hasError: true},
+		{keys: "", hasError: true},
+	} {
+		got, err := ToDetachKeys(tc.keys)
+		if tc.hasError {
+			assert.Error(t, err, tc.keys)
+			continue
+		}
+		assert.NoError(t, err, tc.keys)
+		assert.Equal(t, tc.expected, got, tc.keys)
+	}
+}
+
+func TestEscapeProxy(t *testing.T) {
+	keys := []byte{16, 17}
+
+	for _, tc := range []struct {
+		name     string
+		input    []byte
+		expected []byte
+		detached bool
+	}{
+		{name: "no keys", input: []byte("hello"), expected: []byte("hello")},
+		{name: "only keys", input: []byte{16, 17}, expected: []byte{}, detached: true},
+		{name: "data before keys", input: []byte{'a', 'b', 16, 17, 'c'}, expected: []byte("ab"), detached: true},
+		{name: "broken sequence", input: []byte{16, 'a', 16, 16, 17}, expected: []byte{16, 'a', 16}, detached: true},
+		{name: "partial keys at the end", input: []byte{'a', 16}, expected: []byte("a")},
+	} {
+		out, err := ioutil.ReadAll(NewEscapeProxy(bytes.NewReader(tc.input), keys))
+		if tc.detached {
+			assert.Equal(t, ErrDetached, err, tc.name)
+		} else {
+			assert.NoError(t, err, tc.name)
+		}
+		assert.Equal(t, string(tc.expected), string(out), tc.name)
+	}
+}
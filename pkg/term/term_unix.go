@@ -0,0 +1,43 @@
+// +build !windows
+
+package term
+
+import (
+	"syscall"
+
+	"github.com/pkg/term/termios"
+)
+
+// IsTerminal returns true if the given file descriptor is connected to a terminal.
+func IsTerminal(fd uintptr) bool {
+	return tcget(fd, &syscall.Termios{}) == nil
+}
+
+// TerminalRestore restores terminal state connected to the file descriptor with the specific termios.
+func TerminalRestore(fd uintptr, termios *syscall.Termios) error {
+	return tcset(fd, termios)
+}
+
+// TerminalEcho enable or disable echoing terminal put which connected to the given file descriptor.
+func TerminalEcho(fd uintptr, echo bool) error {
+	termios := &syscall.Termios{}
+	if err := tcget(fd, termios); err != nil {
+		return err
+	}
+
+	if echo {
+		termios.Lflag |= syscall.ECHO
+	} else {
+		termios.Lflag &^= syscall.ECHO
+	}
+
+	return tcset(fd, termios)
+}
+
+func tcget(fd uintptr, t *syscall.Termios) error {
+	return termios.Tcgetattr(fd, t)
+}
+
+func tcset(fd uintptr, t *syscall.Termios) error {
+	return termios.Tcsetattr(fd, termios.TCSANOW, t)
+}
@@ -0,0 +1,30 @@
+// +build windows
+
+package term
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// IsTerminal returns true if the given handle is connected to a console.
+func IsTerminal(fd uintptr) bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(fd), &mode) == nil
+}
+
+// TerminalEcho enable or disable echoing the input of console connected to
+// the given handle.
+func TerminalEcho(fd uintptr, echo bool) error {
+	var mode uint32
+	if err := windows.GetConsoleMode(windows.Handle(fd), &mode); err != nil {
+		return err
+	}
+
+	if echo {
+		mode |= windows.ENABLE_ECHO_INPUT
+	} else {
+		mode &^= windows.ENABLE_ECHO_INPUT
+	}
+
+	return windows.SetConsoleMode(windows.Handle(fd), mode)
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"strconv"
@@ -36,24 +37,33 @@ func Get(passwdPath, groupPath, username string, groups []string) (uint32, uint3
 		logrus.Warn("get passwd file or group file is nil")
 	}
 
-	passwdFile, err := os.Open(passwdPath)
-	if err == nil {
+	// keep the readers nil if the files do not exist, so that numeric
+	// uid and gid are still accepted.
+	var passwd, group io.Reader
+	if passwdFile, err := os.Open(passwdPath); err == nil {
+		passwd = passwdFile
 		defer passwdFile.Close()
 	}
 
-	groupFile, err := os.Open(groupPath)
-	if err == nil {
+	if groupFile, err := os.Open(groupPath); err == nil {
+		group = groupFile
 		defer groupFile.Close()
 	}
 
-	execUser, err := user.GetExecUser(username, nil, passwdFile, groupFile)
+	execUser, err := user.GetExecUser(username, nil, passwd, group)
 	if err != nil {
 		return 0, 0, nil, err
 	}
 
 	var addGroups []int
 	if len(groups) > 0 {
-		addGroups, err = user.GetAdditionalGroups(groups, groupFile)
+		// group file has been read by GetExecUser, rewind it.
+		if f, ok := group.(*os.File); ok {
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return 0, 0, nil, err
+			}
+		}
+		addGroups, err = user.GetAdditionalGroups(groups, group)
 		if err != nil {
 			return 0, 0, nil, err
 		}
@@ -2,6 +2,9 @@ package user
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -30,3 +33,51 @@ func TestGetAdditionalGids(t *testing.T) {
 	assert := assert.New(t)
 	assert.True(reflect.DeepEqual(expected, result), true)
 }
+
+func TestGet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "user-get")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	passwd := filepath.Join(dir, "passwd")
+	group := filepath.Join(dir, "group")
+	if err := ioutil.WriteFile(passwd, []byte("root:x:0:0:root:/root:/bin/sh\nfoo:x:1001:1001::/home/foo:/bin/sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(group, []byte("root:x:0:\nfoo:x:1001:\nbar:x:1002:foo\nbaz:x:1003:\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		passwd, group string
+		user          string
+		groups        []string
+		uid, gid      uint32
+		gids          []uint32
+		err           bool
+	}{
+		{passwd: passwd, group: group, user: "", uid: 0, gid: 0},
+		{passwd: passwd, group: group, user: "foo", uid: 1001, gid: 1001, gids: []uint32{1002}},
+		{passwd: passwd, group: group, user: "foo:baz", uid: 1001, gid: 1003},
+		{passwd: passwd, group: group, user: "1001:1002", uid: 1001, gid: 1002},
+		{passwd: passwd, group: group, user: "2000:2000", uid: 2000, gid: 2000},
+		{passwd: passwd, group: group, user: "foo", groups: []string{"baz", "4000"}, uid: 1001, gid: 1001, gids: []uint32{1002, 1003, 4000}},
+		{passwd: passwd, group: group, user: "nobody", err: true},
+		{passwd: passwd, group: group, user: "foo:nogroup", err: true},
+		// the image has no passwd or group file
+		{user: "1001:1002", uid: 1001, gid: 1002},
+		{user: "foo", err: true},
+	} {
+		uid, gid, gids, err := Get(tc.passwd, tc.group, tc.user, tc.groups)
+		if tc.err {
+			assert.Error(t, err, "%+v", tc)
+			continue
+		}
+		assert.NoError(t, err, "%+v", tc)
+		assert.Equal(t, tc.uid, uid, "%+v", tc)
+		assert.Equal(t, tc.gid, gid, "%+v", tc)
+		assert.Equal(t, tc.gids, gids, "%+v", tc)
+	}
+}
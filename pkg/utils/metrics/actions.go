@@ -20,4 +20,5 @@ const (
 	ActionStatsListLabel = "stats_list"
 	ActionPauseLabel     = "pause"
 	ActionUnpauseLabel   = "unpause"
+	ActionPruneLabel     = "prune"
 )
@@ -1,5 +1,28 @@
 package registry
 
+import (
+	"net/http"
+	"time"
+)
+
 // Client refers a client toward a specified registry.
 type Client struct {
+	// HTTPClient returns the http client to talk with the registry of host,
+	// which carries the TLS settings of registry. The default http client is
+	// used if it's nil.
+	HTTPClient func(host string) *http.Client
+}
+
+// httpClient returns the http client to talk with the registry of host, and
+// the request fails if it isn't done in the timeout.
+func (client *Client) httpClient(host string, timeout time.Duration) *http.Client {
+	httpCli := http.DefaultClient
+	if client.HTTPClient != nil {
+		httpCli = client.HTTPClient(host)
+	}
+
+	// copy the client to set the timeout, since it may be shared.
+	c := *httpCli
+	c.Timeout = timeout
+	return &c
 }
@@ -0,0 +1,111 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// searchTimeout is the timeout of searching images from registry.
+var searchTimeout = 30 * time.Second
+
+// defaultIndex is used to search images of docker hub, because the v2
+// registry of docker hub doesn't provide the search API.
+var defaultIndex = "index.docker.io"
+
+// dockerHubAddresses are the addresses of docker hub.
+var dockerHubAddresses = map[string]bool{
+	"docker.io":               true,
+	"index.docker.io":         true,
+	"registry-1.docker.io":    true,
+	"registry.hub.docker.com": true,
+}
+
+// searchResults is the response of v1 search API.
+type searchResults struct {
+	Query      string                   `json:"query"`
+	NumResults int                      `json:"num_results"`
+	Results    []types.SearchResultItem `json:"results"`
+}
+
+// Search searches the images matching the term through the v1 search API
+// of registry. The default index of docker hub is used if the registry is
+// empty.
+func (client *Client) Search(ctx context.Context, registry, term string, limit int, authConfig *types.AuthConfig) ([]types.SearchResultItem, error) {
+	endpoint, err := genSearchEndpoint(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("q", term)
+	if limit > 0 {
+		q.Set("n", strconv.Itoa(limit))
+	}
+	endpoint.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if authConfig != nil {
+		if authConfig.RegistryToken != "" {
+			req.Header.Set("Authorization", "Bearer "+authConfig.RegistryToken)
+		} else if authConfig.Username != "" || authConfig.Password != "" {
+			req.SetBasicAuth(authConfig.Username, authConfig.Password)
+		}
+	}
+
+	resp, err := client.httpClient(endpoint.Host, searchTimeout).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s in registry %s: %v", term, endpoint.Host, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return nil, fmt.Errorf("search not supported by registry %s", endpoint.Host)
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("failed to search %s in registry %s: unauthorized", term, endpoint.Host)
+	default:
+		return nil, fmt.Errorf("failed to search %s in registry %s with http status %s", term, endpoint.Host, http.StatusText(resp.StatusCode))
+	}
+
+	results := searchResults{}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode search results from registry %s: %v", endpoint.Host, err)
+	}
+
+	if limit > 0 && len(results.Results) > limit {
+		results.Results = results.Results[:limit]
+	}
+	return results.Results, nil
+}
+
+// genSearchEndpoint returns the url of v1 search API by the registry address.
+func genSearchEndpoint(addr string) (*url.URL, error) {
+	addr = strings.TrimSuffix(addr, "/")
+	if addr == "" || dockerHubAddresses[addr] {
+		addr = defaultIndex
+	}
+
+	if !strings.HasPrefix(addr, "http://") && !strings.HasPrefix(addr, "https://") {
+		addr = "https://" + addr
+	}
+
+	endpoint, err := url.Parse(addr + "/v1/search")
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry address %s: %v", addr, err)
+	}
+	return endpoint, nil
+}
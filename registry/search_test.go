@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if username, password, ok := r.BasicAuth(); !ok || username != "foo" || password != "bar" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		assert.Equal(t, "nginx", r.URL.Query().Get("q"))
+		assert.Equal(t, "1", r.URL.Query().Get("n"))
+
+		json.NewEncoder(w).Encode(searchResults{
+			Query:      "nginx",
+			NumResults: 2,
+			Results: []types.SearchResultItem{
+				{Name: "nginx", StarCount: 100, IsOfficial: true},
+				{Name: "foo/nginx", StarCount: 10},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{}
+	auth := &types.AuthConfig{Username: "foo", Password: "bar"}
+
+	// the results should be limited even if the registry ignores the limit
+	results, err := client.Search(context.Background(), server.URL, "nginx", 1, auth)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.SearchResultItem{{Name: "nginx", StarCount: 100, IsOfficial: true}}, results)
+
+	_, err = client.Search(context.Background(), server.URL, "nginx", 1, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unauthorized")
+}
+
+func TestSearchNotSupported(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client := &Client{}
+	_, err := client.Search(context.Background(), server.URL, "nginx", 25, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "search not supported by registry "+server.Listener.Addr().String())
+}
+
+func TestSearchHTTPClient(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") == "slow" {
+			<-block
+		}
+		json.NewEncoder(w).Encode(searchResults{Query: "nginx"})
+	}))
+	defer server.Close()
+	defer close(block)
+
+	// the certificate of registry is verified by the default http client.
+	_, err := (&Client{}).Search(context.Background(), server.URL, "nginx", 25, nil)
+	assert.Error(t, err)
+
+	var host string
+	client := &Client{
+		HTTPClient: func(h string) *http.Client {
+			host = h
+			return server.Client()
+		},
+	}
+	_, err = client.Search(context.Background(), server.URL, "nginx", 25, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, server.Listener.Addr().String(), host)
+
+	defer func(timeout time.Duration) { searchTimeout = timeout }(searchTimeout)
+	searchTimeout = 100 * time.Millisecond
+
+	_, err = client.Search(context.Background(), server.URL, "slow", 25, nil)
+	assert.Error(t, err)
+	assert.Equal(t, time.Duration(0), server.Client().Timeout)
+}
+
+func TestGenSearchEndpoint(t *testing.T) {
+	for addr, expect := range map[string]string{
+		"":                         "https://index.docker.io/v1/search",
+		"registry.hub.docker.com":  "https://index.docker.io/v1/search",
+		"docker.io/":               "https://index.docker.io/v1/search",
+		"localhost:5000":           "https://localhost:5000/v1/search",
+		"http://localhost:5000":    "http://localhost:5000/v1/search",
+		"https://reg.example.com/": "https://reg.example.com/v1/search",
+	} {
+		endpoint, err := genSearchEndpoint(addr)
+		assert.NoError(t, err, addr)
+		assert.Equal(t, expect, endpoint.String(), addr)
+	}
+}
@@ -19,6 +19,10 @@ import (
 var (
 	maxRequestTimeout  = 30 * time.Second
 	defaultDialTimeout = 10 * time.Second
+
+	// defaultCallTimeout is the timeout of a single call to the plugin,
+	// it is long enough for the plugin to mount a remote filesystem.
+	defaultCallTimeout = 60 * time.Second
 )
 
 // defaultContentType is the default Content-Type accepted and sent by the plugins.
@@ -62,6 +66,7 @@ func NewPluginClient(addr string, tlsconfig *TLSConfig) (*PluginClient, error) {
 	}
 
 	httpCli := httputils.NewHTTPClient(url, config, defaultDialTimeout)
+	httpCli.Timeout = defaultCallTimeout
 
 	return &PluginClient{
 		address: addr,
@@ -96,7 +101,7 @@ func (cli *PluginClient) CallService(service string, in, out interface{}, retry
 		}
 	}
 
-	resp, err := cli.callService(service, input, retry)
+	resp, err := cli.callService(service, input.Bytes(), retry)
 	if err != nil {
 		return err
 	}
@@ -111,6 +116,18 @@ func (cli *PluginClient) CallService(service string, in, out interface{}, retry
 			}
 		}
 
+		// the plugin returns the error as {"Err": "..."}, which is
+		// passed to the user as it is.
+		var pluginErr struct {
+			Err string
+		}
+		if err := json.Unmarshal(body, &pluginErr); err == nil && pluginErr.Err != "" {
+			return &ErrPluginStatus{
+				StatusCode: resp.StatusCode,
+				Message:    pluginErr.Err,
+			}
+		}
+
 		return &ErrPluginStatus{
 			StatusCode: resp.StatusCode,
 			Message:    string(body),
@@ -124,17 +141,18 @@ func (cli *PluginClient) CallService(service string, in, out interface{}, retry
 	return json.NewDecoder(resp.Body).Decode(out)
 }
 
-func (cli *PluginClient) callService(service string, data io.Reader, retry bool) (*http.Response, error) {
+func (cli *PluginClient) callService(service string, data []byte, retry bool) (*http.Response, error) {
 	var start = time.Now()
 	var times = 0
 
-	// generate the request.
-	req, err := cli.newPluginRequest(service, data)
-	if err != nil {
-		return nil, err
-	}
-
 	for {
+		// generate the request for each call, since the body of the
+		// previous request has been consumed.
+		req, err := cli.newPluginRequest(service, bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+
 		resp, err := cli.client.Do(req)
 		if err != nil {
 			if !retry {
@@ -122,3 +122,80 @@ func TestCallService(t *testing.T) {
 		t.Fatalf("expect %v, but got %v", input, output)
 	}
 }
+
+func TestCallServiceError(t *testing.T) {
+	setupPluginServer()
+	defer teardownPluginServer()
+
+	mux.HandleFunc("/VolumeDriver.Mount", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		io.WriteString(w, `{"Err": "nfs1:/exports/x: permission denied"}`)
+	})
+	mux.HandleFunc("/VolumeDriver.Unmount", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		io.WriteString(w, "internal error")
+	})
+
+	cli, err := NewPluginClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = cli.CallService("/VolumeDriver.Mount", nil, nil, false)
+	status, ok := err.(*ErrPluginStatus)
+	if !ok {
+		t.Fatalf("expect ErrPluginStatus, but got %v", err)
+	}
+	if status.StatusCode != http.StatusInternalServerError || status.Message != "nfs1:/exports/x: permission denied" {
+		t.Fatalf("expect the error of plugin, but got %v", status)
+	}
+
+	err = cli.CallService("/VolumeDriver.Unmount", nil, nil, false)
+	status, ok = err.(*ErrPluginStatus)
+	if !ok {
+		t.Fatalf("expect ErrPluginStatus, but got %v", err)
+	}
+	if status.Message != "internal error" {
+		t.Fatalf("expect the body as message, but got %v", status)
+	}
+}
+
+func TestCallServiceRetry(t *testing.T) {
+	setupPluginServer()
+	defer teardownPluginServer()
+
+	calls := 0
+	mux.HandleFunc("/testService", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// close the connection to fail the first call.
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatal(err)
+			}
+			conn.Close()
+			return
+		}
+		io.Copy(w, r.Body)
+	})
+
+	cli, err := NewPluginClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := HandShakeResp{
+		Implements: []string{"VolumeDriver"},
+	}
+	var output HandShakeResp
+	if err := cli.CallService("/testService", &input, &output, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expect 2 calls, but got %d", calls)
+	}
+	if !reflect.DeepEqual(input, output) {
+		t.Fatalf("expect the same request body after retry %v, but got %v", input, output)
+	}
+}
@@ -413,3 +413,46 @@ func (c *Core) DetachVolume(id types.VolumeContext, extra map[string]string) (*t
 
 	return v, nil
 }
+
+// MountVolume mounts a volume for the container, and returns the mount path.
+// The volume whose driver can't be mounted returns its path directly.
+func (c *Core) MountVolume(id types.VolumeContext, containerID string) (string, error) {
+	c.lock.Lock(id.Name)
+	defer c.lock.Unlock(id.Name)
+
+	v, dv, err := c.getVolumeDriver(id)
+	if err != nil {
+		return "", err
+	}
+
+	d, ok := dv.(driver.MountUnmount)
+	if !ok {
+		return c.volumePath(v, dv)
+	}
+
+	mountPath, err := d.Mount(driver.Contexts(), v, containerID)
+	if err != nil {
+		return "", err
+	}
+	if mountPath == "" {
+		return c.volumePath(v, dv)
+	}
+	return mountPath, nil
+}
+
+// UnmountVolume unmounts a volume for the container.
+func (c *Core) UnmountVolume(id types.VolumeContext, containerID string) error {
+	c.lock.Lock(id.Name)
+	defer c.lock.Unlock(id.Name)
+
+	v, dv, err := c.getVolumeDriver(id)
+	if err != nil {
+		return err
+	}
+
+	d, ok := dv.(driver.MountUnmount)
+	if !ok {
+		return nil
+	}
+	return d.Unmount(driver.Contexts(), v, containerID)
+}
@@ -451,3 +451,43 @@ func TestDetachVolume(t *testing.T) {
 		t.Fatal("expect get driver not found error, but err is nil")
 	}
 }
+
+func TestMountVolume(t *testing.T) {
+	volumeDriverName := "fake_mount"
+
+	dir, err := ioutil.TempDir("", "TestMountVolume")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	core, err := createVolumeCore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	driver.Register(driver.NewFakeDriver(volumeDriverName))
+	defer driver.Unregister(volumeDriverName)
+
+	vID := types.VolumeContext{Name: "test-mount", Driver: volumeDriverName}
+	if _, err := core.MountVolume(vID, "c1"); !errtypes.IsVolumeNotFound(err) {
+		t.Fatalf("expect get volume not found error, but got %v", err)
+	}
+
+	if _, err := core.CreateVolume(vID); err != nil {
+		t.Fatal(err)
+	}
+
+	// the driver without mount returns the volume path
+	mountPath, err := core.MountVolume(vID, "c1")
+	if err != nil {
+		t.Fatalf("mount volume error: %v", err)
+	}
+	if mountPath != "/fake/test-mount" {
+		t.Fatalf("expect mount path is /fake/test-mount, but got %s", mountPath)
+	}
+
+	if err := core.UnmountVolume(vID, "c1"); err != nil {
+		t.Fatalf("unmount volume error: %v", err)
+	}
+}
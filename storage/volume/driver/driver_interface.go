@@ -43,6 +43,16 @@ type AttachDetach interface {
 	Detach(Context, *types.Volume) error
 }
 
+// MountUnmount represents volume mount/unmount interface, the volume is
+// mounted when the container starts and unmounted when it stops.
+type MountUnmount interface {
+	// Mount a volume for the container, returns the mount path.
+	Mount(ctx Context, v *types.Volume, containerID string) (string, error)
+
+	// Unmount a volume for the container.
+	Unmount(ctx Context, v *types.Volume, containerID string) error
+}
+
 // Formator represents volume format interface.
 type Formator interface {
 	// Format a volume.
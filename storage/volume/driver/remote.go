@@ -110,17 +110,16 @@ func (r *remoteDriverWrapper) Options() map[string]types.Option {
 	return map[string]types.Option{}
 }
 
-// Attach a remote volume.
-func (r *remoteDriverWrapper) Attach(ctx Context, v *types.Volume) error {
-	ctx.Log.Debugf("driver wrapper [%s] attach volume: %s", r.Name(ctx), v.Name)
+// Mount a remote volume for the container.
+func (r *remoteDriverWrapper) Mount(ctx Context, v *types.Volume, containerID string) (string, error) {
+	ctx.Log.Debugf("driver wrapper [%s] mount volume: %s for container: %s", r.Name(ctx), v.Name, containerID)
 
-	_, err := r.proxy.Mount(v.Name, v.UID)
-	return err
+	return r.proxy.Mount(v.Name, containerID)
 }
 
-// Detach a remote volume.
-func (r *remoteDriverWrapper) Detach(ctx Context, v *types.Volume) error {
-	ctx.Log.Debugf("driver wrapper [%s] detach volume: %s", r.Name(ctx), v.Name)
+// Unmount a remote volume for the container.
+func (r *remoteDriverWrapper) Unmount(ctx Context, v *types.Volume, containerID string) error {
+	ctx.Log.Debugf("driver wrapper [%s] unmount volume: %s for container: %s", r.Name(ctx), v.Name, containerID)
 
-	return r.proxy.Unmount(v.Name, v.UID)
+	return r.proxy.Unmount(v.Name, containerID)
 }
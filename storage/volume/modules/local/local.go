@@ -12,6 +12,8 @@ import (
 	"github.com/alibaba/pouch/storage/quota"
 	"github.com/alibaba/pouch/storage/volume/driver"
 	"github.com/alibaba/pouch/storage/volume/types"
+
+	"github.com/docker/docker/pkg/mount"
 )
 
 var (
@@ -27,6 +29,10 @@ func init() {
 // Local represents local volume driver.
 type Local struct {
 	DataPath string
+
+	// mounts counts the containers which mount the volumes with type,
+	// device and o opts.
+	mounts activeMounts
 }
 
 // Name returns local volume driver's name.
@@ -58,6 +64,12 @@ func (p *Local) Create(ctx driver.Context, id types.VolumeContext) (*types.Volum
 		mountPath = dir
 	}
 
+	// validate the type, device and o opts, which are mounted when the
+	// volume is mounted by container.
+	if _, err := parseMountOpts(id.Options); err != nil {
+		return nil, err
+	}
+
 	// parse the size.
 	s := ""
 	for _, k := range []string{"size", "opt.size", "Size", "opt.Size"} {
@@ -92,6 +104,13 @@ func (p *Local) Remove(ctx driver.Context, v *types.Volume) error {
 	ctx.Log.Debugf("Local remove volume: %s", v.Name)
 	mountPath := v.Path()
 
+	// make sure the device is unmounted, or its data is removed.
+	if m, err := parseMountOpts(v.Options()); err == nil && m != nil {
+		if err := mount.Unmount(mountPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to unmount %s on %s: %v", m, mountPath, err)
+		}
+	}
+
 	if err := os.RemoveAll(mountPath); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("remove %q directory failed, err: %v", mountPath, err)
 	}
@@ -123,7 +142,10 @@ func (p *Local) Path(ctx driver.Context, v *types.Volume) (string, error) {
 // Options returns local volume's options.
 func (p *Local) Options() map[string]types.Option {
 	return map[string]types.Option{
-		"mount": {Value: "", Desc: "local directory"},
+		"mount":         {Value: "", Desc: "local directory"},
+		optionType:      {Value: "", Desc: "filesystem type to mount into volume, such as tmpfs or nfs"},
+		optionDevice:    {Value: "", Desc: "device to mount into volume, such as tmpfs or :/exports/x"},
+		optionMountOpts: {Value: "", Desc: "comma-separated mount options, such as size=100m or addr=10.0.0.5"},
 	}
 }
 
@@ -164,3 +186,58 @@ func (p *Local) Detach(ctx driver.Context, v *types.Volume) error {
 
 	return nil
 }
+
+// Mount a local volume for the container, the device of volume specified by
+// type, device and o opts is mounted when the first container mounts it.
+func (p *Local) Mount(ctx driver.Context, v *types.Volume, containerID string) (string, error) {
+	ctx.Log.Debugf("Local mount volume: %s for container: %s", v.Name, containerID)
+	mountPath := v.Path()
+
+	m, err := parseMountOpts(v.Options())
+	if err != nil || m == nil {
+		return mountPath, err
+	}
+
+	if p.mounts.add(v.Name, containerID) {
+		// the references are kept in memory, so the volume may have been
+		// mounted by the containers which are alive across the restart
+		// of pouchd.
+		mounted, err := mount.Mounted(mountPath)
+		if err == nil && !mounted {
+			err = m.mount(mountPath)
+		}
+		if err != nil {
+			p.mounts.remove(v.Name, containerID)
+			return "", err
+		}
+	}
+
+	return mountPath, nil
+}
+
+// Unmount a local volume for the container, the device of volume is
+// unmounted when the last container releases it.
+func (p *Local) Unmount(ctx driver.Context, v *types.Volume, containerID string) error {
+	ctx.Log.Debugf("Local unmount volume: %s for container: %s", v.Name, containerID)
+	mountPath := v.Path()
+
+	m, err := parseMountOpts(v.Options())
+	if err != nil || m == nil {
+		return err
+	}
+
+	if p.mounts.remove(v.Name, containerID) {
+		mounted, err := mount.Mounted(mountPath)
+		if err != nil {
+			return fmt.Errorf("failed to check mount %s on %s: %v", m, mountPath, err)
+		}
+		if !mounted {
+			return nil
+		}
+		if err := mount.Unmount(mountPath); err != nil {
+			return fmt.Errorf("failed to unmount %s on %s: %v", m, mountPath, err)
+		}
+	}
+
+	return nil
+}
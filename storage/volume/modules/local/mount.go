@@ -0,0 +1,137 @@
+// +build linux
+
+package local
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/pkg/mount"
+)
+
+const (
+	// optionType is the filesystem type of volume, such as tmpfs or nfs.
+	optionType = "type"
+
+	// optionDevice is the device mounted into the volume, such as
+	// tmpfs or :/exports/x of nfs.
+	optionDevice = "device"
+
+	// optionMountOpts is the comma-separated options of mount, such as
+	// size=100m of tmpfs or addr=10.0.0.5 of nfs.
+	optionMountOpts = "o"
+)
+
+// mountOpts is the mount of volume specified by the type, device and o opts,
+// which is mounted into the volume when the first container mounts it.
+type mountOpts struct {
+	Type   string
+	Device string
+	Opts   string
+}
+
+// parseMountOpts validates the type, device and o opts of volume, and
+// returns nil if none of them is specified.
+func parseMountOpts(options map[string]string) (*mountOpts, error) {
+	m := &mountOpts{
+		Type:   options[optionType],
+		Device: options[optionDevice],
+		Opts:   options[optionMountOpts],
+	}
+	if m.Type == "" && m.Device == "" && m.Opts == "" {
+		return nil, nil
+	}
+
+	if m.Type == "" {
+		return nil, fmt.Errorf("invalid volume options: option type is required with device and o")
+	}
+	if m.Device == "" {
+		return nil, fmt.Errorf("invalid volume options: option device is required with type %s", m.Type)
+	}
+
+	for _, k := range []string{"size", "opt.size", "Size", "opt.Size"} {
+		if _, ok := options[k]; ok {
+			return nil, fmt.Errorf("invalid volume options: option %s can't be used with type, set the size in o instead", k)
+		}
+	}
+
+	if m.Type == "nfs" || m.Type == "nfs4" {
+		if addr := m.opt("addr"); addr == "" {
+			return nil, fmt.Errorf("invalid volume options: addr of nfs server is required in o, such as o=addr=10.0.0.5")
+		}
+	}
+
+	return m, nil
+}
+
+// opt returns the value of option in o.
+func (m *mountOpts) opt(name string) string {
+	for _, o := range strings.Split(m.Opts, ",") {
+		if kv := strings.SplitN(o, "=", 2); len(kv) == 2 && kv[0] == name {
+			return kv[1]
+		}
+	}
+	return ""
+}
+
+// String returns the mount in the format of mount command.
+func (m *mountOpts) String() string {
+	return fmt.Sprintf("type=%s device=%s o=%s", m.Type, m.Device, m.Opts)
+}
+
+// mount mounts the device into the target, the hostname of nfs server in
+// addr is resolved, since the kernel only accepts ip address.
+func (m *mountOpts) mount(target string) error {
+	opts := m.Opts
+	if addr := m.opt("addr"); addr != "" {
+		ip, err := net.ResolveIPAddr("ip", addr)
+		if err != nil {
+			return fmt.Errorf("failed to mount %s on %s: failed to resolve addr %s: %v", m, target, addr, err)
+		}
+		opts = strings.Replace(opts, "addr="+addr, "addr="+ip.String(), 1)
+	}
+
+	if err := mount.Mount(m.Device, target, m.Type, opts); err != nil {
+		return fmt.Errorf("failed to mount %s on %s: %v", m, target, err)
+	}
+	return nil
+}
+
+// activeMounts counts the containers which mount the volumes, the volume is
+// unmounted when it's released by the last container.
+type activeMounts struct {
+	sync.Mutex
+	containers map[string]map[string]struct{}
+}
+
+// add adds the container into the references of volume, and returns true
+// if it's the first one.
+func (a *activeMounts) add(name, containerID string) bool {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.containers == nil {
+		a.containers = make(map[string]map[string]struct{})
+	}
+	if _, ok := a.containers[name]; !ok {
+		a.containers[name] = make(map[string]struct{})
+	}
+	a.containers[name][containerID] = struct{}{}
+	return len(a.containers[name]) == 1
+}
+
+// remove removes the container from the references of volume, and returns
+// true if no container mounts the volume anymore.
+func (a *activeMounts) remove(name, containerID string) bool {
+	a.Lock()
+	defer a.Unlock()
+
+	delete(a.containers[name], containerID)
+	if len(a.containers[name]) > 0 {
+		return false
+	}
+	delete(a.containers, name)
+	return true
+}
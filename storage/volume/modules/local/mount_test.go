@@ -0,0 +1,62 @@
+// +build linux
+
+package local
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMountOpts(t *testing.T) {
+	for _, tc := range []struct {
+		options  map[string]string
+		expected *mountOpts
+		err      string
+	}{
+		{options: map[string]string{"mount": "/tmp/foo"}},
+		{
+			options:  map[string]string{"type": "tmpfs", "device": "tmpfs", "o": "size=100m"},
+			expected: &mountOpts{Type: "tmpfs", Device: "tmpfs", Opts: "size=100m"},
+		},
+		{
+			options:  map[string]string{"type": "nfs", "device": ":/exports/x", "o": "addr=10.0.0.5,rw"},
+			expected: &mountOpts{Type: "nfs", Device: ":/exports/x", Opts: "addr=10.0.0.5,rw"},
+		},
+		{options: map[string]string{"device": "tmpfs"}, err: "option type is required"},
+		{options: map[string]string{"type": "tmpfs"}, err: "option device is required"},
+		{options: map[string]string{"type": "tmpfs", "device": "tmpfs", "size": "10g"}, err: "option size can't be used with type"},
+		{options: map[string]string{"type": "nfs", "device": ":/exports/x", "o": "rw"}, err: "addr of nfs server is required"},
+	} {
+		m, err := parseMountOpts(tc.options)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expect error %q for %v, but got %v", tc.err, tc.options, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error for %v: %v", tc.options, err)
+		}
+		if (m == nil) != (tc.expected == nil) || (m != nil && *m != *tc.expected) {
+			t.Fatalf("expect %v for %v, but got %v", tc.expected, tc.options, m)
+		}
+	}
+}
+
+func TestActiveMounts(t *testing.T) {
+	var mounts activeMounts
+
+	if !mounts.add("vol", "c1") {
+		t.Fatal("expect the first container to mount the volume")
+	}
+	if mounts.add("vol", "c2") || mounts.add("vol", "c1") {
+		t.Fatal("expect the volume to be mounted already")
+	}
+
+	if mounts.remove("vol", "c1") {
+		t.Fatal("expect the volume to be still mounted by c2")
+	}
+	if !mounts.remove("vol", "c2") {
+		t.Fatal("expect the volume to be released by the last container")
+	}
+}
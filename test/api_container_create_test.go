@@ -414,3 +414,41 @@ func (suite *APIContainerCreateSuite) TestInvalidSpecificID(c *check.C) {
 		CheckRespStatus(c, resp, http.StatusConflict)
 	}
 }
+
+// TestCreateWithInvalidUlimits tests creating container with unknown ulimit
+// or soft limit larger than hard limit fails.
+func (suite *APIContainerCreateSuite) TestCreateWithInvalidUlimits(c *check.C) {
+	for _, ulimit := range []map[string]interface{}{
+		{"Name": "foo", "Soft": 1024, "Hard": 1024},
+		{"Name": "nofile", "Soft": 65535, "Hard": 1024},
+	} {
+		obj := map[string]interface{}{
+			"Image": busyboxImage,
+			"HostConfig": map[string]interface{}{
+				"Ulimits": []interface{}{ulimit},
+			},
+		}
+
+		resp, err := request.Post("/containers/create", request.WithJSONBody(obj))
+		c.Assert(err, check.IsNil)
+		CheckRespStatus(c, resp, http.StatusBadRequest)
+	}
+}
+
+// TestCreateWithInvalidSysctls tests creating container with the sysctl not
+// namespaced or in the network namespace of host fails.
+func (suite *APIContainerCreateSuite) TestCreateWithInvalidSysctls(c *check.C) {
+	for _, hostConfig := range []map[string]interface{}{
+		{"Sysctls": map[string]string{"kernel.panic": "1"}},
+		{"Sysctls": map[string]string{"net.ipv4.ip_forward": "1"}, "NetworkMode": "host"},
+	} {
+		obj := map[string]interface{}{
+			"Image":      busyboxImage,
+			"HostConfig": hostConfig,
+		}
+
+		resp, err := request.Post("/containers/create", request.WithJSONBody(obj))
+		c.Assert(err, check.IsNil)
+		CheckRespStatus(c, resp, http.StatusBadRequest)
+	}
+}
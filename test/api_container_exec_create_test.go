@@ -111,7 +111,7 @@ func (suite *APIContainerExecSuite) TestExecCreatedContainer(c *check.C) {
 	CheckRespStatus(c, resp, 500)
 }
 
-// TestExecPausedContainer tests creating exec on paused container return error.
+// TestExecPausedContainer tests creating exec on paused container return 409.
 func (suite *APIContainerExecSuite) TestExecPausedContainer(c *check.C) {
 	cname := "TestExecPausedContainer"
 
@@ -128,7 +128,7 @@ func (suite *APIContainerExecSuite) TestExecPausedContainer(c *check.C) {
 	body := request.WithJSONBody(obj)
 	resp, err := request.Post("/containers/"+cname+"/exec", body)
 	c.Assert(err, check.IsNil)
-	CheckRespStatus(c, resp, 500)
+	CheckRespStatus(c, resp, 409)
 }
 
 // TestExecStoppedContainer tests creating exec on stopped container return error.
@@ -2,8 +2,10 @@ package main
 
 import (
 	"net/http"
+	"net/url"
 	"time"
 
+	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/test/environment"
 	"github.com/alibaba/pouch/test/request"
 
@@ -78,3 +80,41 @@ func (suite *APIContainerWaitSuite) TestWaitNonExistingContainer(c *check.C) {
 	c.Assert(err, check.IsNil)
 	CheckRespStatus(c, resp, 404)
 }
+
+// TestWaitNextExit tests waiting the next exit of a stopped container returns after it is started and stopped again.
+func (suite *APIContainerWaitSuite) TestWaitNextExit(c *check.C) {
+	cname := "TestWaitNextExit"
+
+	CreateBusyboxContainerOk(c, cname)
+	defer DelContainerForceMultyTime(c, cname)
+
+	q := url.Values{}
+	q.Set("condition", "next-exit")
+
+	// the response header is sent once the daemon starts waiting.
+	resp, err := request.Post("/containers/"+cname+"/wait", request.WithQuery(q))
+	c.Assert(err, check.IsNil)
+	CheckRespStatus(c, resp, 200)
+	defer resp.Body.Close()
+
+	StartContainerOk(c, cname)
+	StopContainerOk(c, cname)
+
+	var status types.ContainerWaitOKBody
+	c.Assert(request.DecodeBody(&status, resp.Body), check.IsNil)
+}
+
+// TestWaitInvalidCondition tests waiting with invalid condition returns 400.
+func (suite *APIContainerWaitSuite) TestWaitInvalidCondition(c *check.C) {
+	cname := "TestWaitInvalidCondition"
+
+	CreateBusyboxContainerOk(c, cname)
+	defer DelContainerForceMultyTime(c, cname)
+
+	q := url.Values{}
+	q.Set("condition", "removed")
+
+	resp, err := request.Post("/containers/"+cname+"/wait", request.WithQuery(q))
+	c.Assert(err, check.IsNil)
+	CheckRespStatus(c, resp, 400)
+}
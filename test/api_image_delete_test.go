@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/test/environment"
 	"github.com/alibaba/pouch/test/request"
 
@@ -32,7 +33,12 @@ func (suite *APIImageDeleteSuite) TestDeleteImageOk(c *check.C) {
 	PullImage(c, helloworldImage)
 	resp, err := request.Delete("/images/" + helloworldImage)
 	c.Assert(err, check.IsNil)
-	CheckRespStatus(c, resp, 204)
+	CheckRespStatus(c, resp, 200)
+
+	var items []types.ImageDeleteResponseItem
+	c.Assert(request.DecodeBody(&items, resp.Body), check.IsNil)
+	c.Assert(len(items) > 0, check.Equals, true)
+	c.Assert(items[len(items)-1].Deleted, check.Not(check.Equals), "")
 
 	resp, err = request.Get("/images/" + helloworldImage + "/json")
 	c.Assert(err, check.IsNil)
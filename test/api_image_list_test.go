@@ -101,5 +101,5 @@ func (suite *APIImageListSuite) TestImageListInvalidFilter(c *check.C) {
 	resp, err := request.Get("/images/json", query)
 
 	c.Assert(err, check.IsNil)
-	CheckRespStatus(c, resp, 500)
+	CheckRespStatus(c, resp, 400)
 }
@@ -51,7 +51,7 @@ func (suite *APIImageMetricsSuite) checkAction(c *check.C, label string) {
 	case "delete":
 		resp, err := request.Delete("/images/" + helloworldImage)
 		c.Assert(err, check.IsNil)
-		CheckRespStatus(c, resp, 204)
+		CheckRespStatus(c, resp, 200)
 	}
 
 	count, successCount := GetMetric(c,
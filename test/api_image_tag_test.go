@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
 	"github.com/alibaba/pouch/test/request"
@@ -58,8 +59,8 @@ func (suite *APIImageTagSuite) TestImageTagUsingNoFoundSourceImage(c *check.C) {
 	CheckRespStatus(c, resp, 404)
 }
 
-// TestImageTagFailToOverrideExistingPrimaryReference tests fail.
-func (suite *APIImageTagSuite) TestImageTagFailToOverrideExistingPrimaryReference(c *check.C) {
+// TestImageTagOverrideExistingPrimaryReference tests OK.
+func (suite *APIImageTagSuite) TestImageTagOverrideExistingPrimaryReference(c *check.C) {
 	repo, tag := environment.BusyboxRepo, environment.Busybox125Tag
 	tagRef := fmt.Sprintf("%s:%s", repo, tag)
 	command.PouchRun("pull", tagRef).Assert(c, icmd.Success)
@@ -67,12 +68,14 @@ func (suite *APIImageTagSuite) TestImageTagFailToOverrideExistingPrimaryReferenc
 
 	resp, err := suite.doTag(busyboxImage, repo, tag)
 	c.Assert(err, check.IsNil)
-	CheckRespStatus(c, resp, 400)
+	CheckRespStatus(c, resp, 201)
+
+	suite.checkTagReferenceID(c, tagRef, suite.imageID(c, busyboxImage))
 }
 
-// TestImageTagFailToOverrideExistingTag tests fail.
-func (suite *APIImageTagSuite) TestImageTagFailToOverrideExistingTag(c *check.C) {
-	repo, tag := "localhost:5000/shouldnotoverridetag", "1.0"
+// TestImageTagOverrideExistingTag tests OK.
+func (suite *APIImageTagSuite) TestImageTagOverrideExistingTag(c *check.C) {
+	repo, tag := "localhost:5000/overridetag", "1.0"
 	tagRef := fmt.Sprintf("%s:%s", repo, tag)
 
 	// create valid tag
@@ -92,7 +95,9 @@ func (suite *APIImageTagSuite) TestImageTagFailToOverrideExistingTag(c *check.C)
 
 		resp, err := suite.doTag(busyboxImage125, repo, tag)
 		c.Assert(err, check.IsNil)
-		CheckRespStatus(c, resp, 400)
+		CheckRespStatus(c, resp, 201)
+
+		suite.checkTagReferenceID(c, tagRef, suite.imageID(c, busyboxImage125))
 	}
 }
 
@@ -123,3 +128,17 @@ func (suite *APIImageTagSuite) checkTagReferenceExisting(c *check.C, tagRef stri
 	}
 	CheckRespStatus(c, resp, status)
 }
+
+func (suite *APIImageTagSuite) imageID(c *check.C, ref string) string {
+	resp, err := request.Get(fmt.Sprintf("/images/%s/json", ref))
+	c.Assert(err, check.IsNil)
+	CheckRespStatus(c, resp, http.StatusOK)
+
+	got := types.ImageInfo{}
+	c.Assert(request.DecodeBody(&got, resp.Body), check.IsNil)
+	return got.ID
+}
+
+func (suite *APIImageTagSuite) checkTagReferenceID(c *check.C, tagRef string, id string) {
+	c.Assert(suite.imageID(c, tagRef), check.Equals, id)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/request"
+
+	"github.com/go-check/check"
+	"github.com/prometheus/common/expfmt"
+)
+
+// APIMetricsSuite is the test suite for the pouch_ metrics.
+type APIMetricsSuite struct{}
+
+func init() {
+	check.Suite(&APIMetricsSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each suite.
+func (suite *APIMetricsSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+	PullImage(c, busyboxImage)
+}
+
+// TestMetricsFamilies scrapes the metrics and checks the families of pouch
+// are served.
+func (suite *APIMetricsSuite) TestMetricsFamilies(c *check.C) {
+	cname := "TestMetricsFamilies"
+	CreateBusyboxContainerOk(c, cname)
+	defer DelContainerForceMultyTime(c, cname)
+
+	resp, err := request.Get("/containers/" + cname + "/json")
+	c.Assert(err, check.IsNil)
+	CheckRespStatus(c, resp, 200)
+	resp.Body.Close()
+
+	resp, err = request.Get("/metrics")
+	c.Assert(err, check.IsNil)
+	CheckRespStatus(c, resp, 200)
+	defer resp.Body.Close()
+
+	families, err := new(expfmt.TextParser).TextToMetricFamilies(resp.Body)
+	c.Assert(err, check.IsNil)
+
+	for _, name := range []string{
+		"pouch_containers",
+		"pouch_images",
+		"pouch_events_queue_depth",
+		"pouch_api_requests_total",
+		"pouch_api_request_duration_seconds",
+		"pouch_image_pull_duration_seconds",
+		"pouch_image_pull_bytes_total",
+	} {
+		_, ok := families[name]
+		c.Assert(ok, check.Equals, true, check.Commentf("metric family %s is not found", name))
+	}
+
+	// the request is counted by the route rather than the path.
+	found := false
+	for _, m := range families["pouch_api_requests_total"].GetMetric() {
+		for _, l := range m.GetLabel() {
+			if l.GetName() == "route" && l.GetValue() == "/containers/{name:.*}/json" {
+				found = true
+			}
+		}
+	}
+	c.Assert(found, check.Equals, true)
+}
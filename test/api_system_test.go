@@ -57,6 +57,10 @@ func (suite *APISystemSuite) TestInfo(c *check.C) {
 	// TODO: Temporary comment, because of may be enable cri in config file.
 	//c.Assert(got.CriEnabled, check.Equals, false)
 	c.Assert(got.CgroupDriver, check.Equals, "cgroupfs")
+	c.Assert(got.ContainerdAddress, check.Not(check.Equals), "")
+	c.Assert(got.ContainerdStatus, check.Equals, "connected")
+	c.Assert(got.ContainerdVersion, check.Not(check.Equals), "")
+	c.Assert(got.ContainersRunning+got.ContainersPaused+got.ContainersStopped, check.Equals, got.Containers)
 
 	// TODO: Temporary comment, because of may have different volume driver in config file.
 	// Check the volume drivers
@@ -88,6 +92,8 @@ func (suite *APISystemSuite) TestVersion(c *check.C) {
 
 	c.Assert(got.APIVersion, check.Equals, version.APIVersion)
 	c.Assert(got.Version, check.Equals, version.Version)
+	c.Assert(got.ContainerdVersion, check.Not(check.Equals), "")
+	c.Assert(got.RuncVersion, check.Not(check.Equals), "")
 }
 
 // If the /auth is ready, we can login to the registry.
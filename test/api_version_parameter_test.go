@@ -8,6 +8,7 @@ import (
 
 	"github.com/alibaba/pouch/client"
 	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/version"
 
 	"github.com/go-check/check"
 )
@@ -61,3 +62,36 @@ func (suite *APIVersionSuite) TestNoVersionParamsInURL(c *check.C) {
 	CheckRespStatus(c, resp, 201)
 	DelContainerForceMultyTime(c, cname)
 }
+
+// TestUnsupportedVersion tests the version not served by daemon is rejected.
+func (suite *APIVersionSuite) TestUnsupportedVersion(c *check.C) {
+	commonAPIClient, err := client.NewAPIClient(environment.PouchdAddress, environment.TLSConfig)
+	c.Assert(err, check.IsNil)
+	apiClient := commonAPIClient.(*client.APIClient)
+
+	for _, v := range []string{"1.0", "99.0"} {
+		resp, err := apiClient.HTTPCli.Get(apiClient.BaseURL() + "/v" + v + "/info")
+		c.Assert(err, check.IsNil)
+		CheckRespStatus(c, resp, 400)
+		c.Assert(resp.Header.Get("API-Version"), check.Equals, version.APIVersion)
+		resp.Body.Close()
+	}
+}
+
+// TestOmitNewFields tests the fields added in later version are omitted.
+func (suite *APIVersionSuite) TestOmitNewFields(c *check.C) {
+	commonAPIClient, err := client.NewAPIClient(environment.PouchdAddress, environment.TLSConfig)
+	c.Assert(err, check.IsNil)
+	apiClient := commonAPIClient.(*client.APIClient)
+
+	resp, err := apiClient.HTTPCli.Get(apiClient.BaseURL() + "/v" + version.MinAPIVersion + "/version")
+	c.Assert(err, check.IsNil)
+	CheckRespStatus(c, resp, 200)
+
+	got := map[string]interface{}{}
+	c.Assert(json.NewDecoder(resp.Body).Decode(&got), check.IsNil)
+	resp.Body.Close()
+
+	_, ok := got["MinAPIVersion"]
+	c.Assert(ok, check.Equals, false)
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
 	"github.com/alibaba/pouch/test/request"
 
 	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
 )
 
 // APIVolumeDeleteSuite is the test suite for volume delete API.
@@ -26,3 +28,23 @@ func (suite *APIVolumeDeleteSuite) TestDeleteNonExisting(c *check.C) {
 	c.Assert(err, check.IsNil)
 	CheckRespStatus(c, resp, 404)
 }
+
+// TestDeleteUsingVolume tests deleting a volume used by container returns
+// conflict, and it can be deleted after the container is removed.
+func (suite *APIVolumeDeleteSuite) TestDeleteUsingVolume(c *check.C) {
+	PullImage(c, busyboxImage)
+
+	vol, cname := "TestDeleteUsingVolume", "TestDeleteUsingVolumeContainer"
+	CreateVolumeOK(c, vol, "local", nil)
+	defer request.Delete("/volumes/" + vol)
+
+	command.PouchRun("create", "-v", vol+":/mnt", "--name", cname, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	resp, err := request.Delete("/volumes/" + vol)
+	c.Assert(err, check.IsNil)
+	CheckRespStatus(c, resp, 409)
+
+	DelContainerForceMultyTime(c, cname)
+	RemoveVolumeOK(c, vol)
+}
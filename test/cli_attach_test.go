@@ -0,0 +1,101 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchAttachSuite is the test suite for attach CLI.
+type PouchAttachSuite struct{}
+
+func init() {
+	check.Suite(&PouchAttachSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchAttachSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchAttachSuite) TearDownTest(c *check.C) {
+}
+
+// TestAttachStoppedContainer tests attaching to a container which is not
+// running fails with its state.
+func (suite *PouchAttachSuite) TestAttachStoppedContainer(c *check.C) {
+	name := "TestAttachStoppedContainer"
+
+	command.PouchRun("create", "--name", name, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("attach", name)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "which is created"), check.IsNil)
+}
+
+// TestAttachOutputAndExitCode tests the stdout and stderr are separated
+// without tty, and the exit code of container is returned.
+func (suite *PouchAttachSuite) TestAttachOutputAndExitCode(c *check.C) {
+	name := "TestAttachOutputAndExitCode"
+
+	command.PouchRun("run", "-d", "--name", name, busyboxImage,
+		"sh", "-c", "sleep 2; echo stdout; echo stderr >&2; exit 3").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("attach", "--no-stdin", name)
+	res.Assert(c, icmd.Expected{ExitCode: 3})
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "stdout")
+	c.Assert(strings.TrimSpace(res.Stderr()), check.Equals, "stderr")
+}
+
+// TestAttachDetachKeys tests the detach keys leave the container running.
+func (suite *PouchAttachSuite) TestAttachDetachKeys(c *check.C) {
+	for _, tc := range []struct {
+		name  string
+		flags []string
+		input string
+	}{
+		{name: "TestAttachDefaultDetachKeys", input: "hello\n\x10\x11"},
+		{name: "TestAttachCustomDetachKeys", flags: []string{"--detach-keys", "ctrl-a,x"}, input: "hello\n\x01x"},
+	} {
+		command.PouchRun("run", "-d", "-i", "--name", tc.name, busyboxImage, "cat").Assert(c, icmd.Success)
+		defer DelContainerForceMultyTime(c, tc.name)
+
+		args := append(append([]string{"attach"}, tc.flags...), tc.name)
+		cmd := command.PouchCmd(args...)
+		cmd.Stdin = strings.NewReader(tc.input)
+		cmd.Timeout = 10 * time.Second
+
+		res := icmd.RunCmd(cmd)
+		res.Assert(c, icmd.Success)
+		c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "hello")
+
+		status, err := inspectFilter(tc.name, ".State.Status")
+		c.Assert(err, check.IsNil)
+		c.Assert(status, check.Equals, "running")
+	}
+}
+
+// TestAttachInvalidDetachKeys tests the invalid detach keys are refused.
+func (suite *PouchAttachSuite) TestAttachInvalidDetachKeys(c *check.C) {
+	name := "TestAttachInvalidDetachKeys"
+
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("attach", "--detach-keys", "ctrl-1", name)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid detach key"), check.IsNil)
+}
@@ -87,3 +87,21 @@ func (suite *PouchCheckpointSuite) TestCheckpointDelete(c *check.C) {
 	ret.Assert(c, icmd.Success)
 	c.Assert(ret.Stdout(), check.Equals, "")
 }
+
+// TestCheckpointCreateWithTCPEstablished tests creating checkpoint from
+// container with established TCP connections needs --tcp-established.
+func (suite *PouchCheckpointSuite) TestCheckpointCreateWithTCPEstablished(c *check.C) {
+	cname := "TestCheckpointCreateWithTCPEstablished"
+
+	command.PouchRun("run", "-d", "--name", cname, busyboxImage, "sh", "-c",
+		"nc -l -p 8080 & sleep 1; sleep 1000 | nc $(hostname -i) 8080 & top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	ret := command.PouchRun("checkpoint", "create", "--leave-running", cname, "cp0")
+	c.Assert(ret.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(ret.Stderr(), "established TCP connections"), check.IsNil)
+
+	ret = command.PouchRun("checkpoint", "create", "--leave-running", "--tcp-established", cname, "cp0")
+	ret.Assert(c, icmd.Success)
+	c.Assert(ret.Stdout(), check.Equals, "cp0\n")
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
 
 	"github.com/go-check/check"
 	"github.com/gotestyourself/gotestyourself/icmd"
@@ -116,3 +117,59 @@ func (suite *PouchCommitSuite) TestCommitBindMount(c *check.C) {
 	ret.Assert(c, icmd.Success)
 	DelContainerForceMultyTime(c, nname)
 }
+
+// TestCommitWithChanges tests commit a container with author, message and
+// changes applied to the config of new image.
+func (suite *PouchCommitSuite) TestCommitWithChanges(c *check.C) {
+	cname := "TestCommitWithChanges"
+	image := "foo:changes"
+
+	command.PouchRun("run", "-d", "--name", cname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	ret := command.PouchRun("commit", "-a", "pouch", "-m", "added tooling",
+		"-c", "ENV DEBUG=true",
+		"-c", `CMD ["echo", "changed"]`,
+		"-c", "EXPOSE 8080",
+		cname, image)
+	ret.Assert(c, icmd.Success)
+	defer DelImageForceOk(c, image)
+
+	// the running container is unpaused after commit.
+	status, err := inspectFilter(cname, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "running")
+
+	ret = command.PouchRun("image", "inspect", "-f", "{{.Config.Env}} {{.Config.ExposedPorts}}", image).Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(ret.Stdout(), "DEBUG=true"), check.IsNil)
+	c.Assert(util.PartialEqual(ret.Stdout(), "8080/tcp"), check.IsNil)
+
+	nname := "fromChanges"
+	ret = command.PouchRun("run", "--name", nname, image)
+	ret.Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, nname)
+	c.Assert(ret.Stdout(), check.Equals, "changed\n")
+
+	ret = command.PouchRun("commit", "-c", "RUN echo hi", cname, image)
+	c.Assert(ret.Error, check.NotNil)
+	c.Assert(util.PartialEqual(ret.Stderr(), "unsupported instruction"), check.IsNil)
+}
+
+// TestCommitWithoutPause tests commit a running container without pause.
+func (suite *PouchCommitSuite) TestCommitWithoutPause(c *check.C) {
+	cname := "TestCommitWithoutPause"
+	image := "foo:nopause"
+
+	command.PouchRun("run", "-d", "--name", cname, busyboxImage,
+		"sh", "-c", "echo a > /foo && top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	command.PouchRun("commit", "--pause=false", cname, image).Assert(c, icmd.Success)
+	defer DelImageForceOk(c, image)
+
+	nname := "fromNoPause"
+	ret := command.PouchRun("run", "--name", nname, image, "cat", "/foo")
+	ret.Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, nname)
+	c.Assert(ret.Stdout(), check.Equals, "a\n")
+}
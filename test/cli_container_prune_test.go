@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchContainerPruneSuite is the test suite for container prune CLI.
+type PouchContainerPruneSuite struct{}
+
+func init() {
+	check.Suite(&PouchContainerPruneSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchContainerPruneSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchContainerPruneSuite) TearDownTest(c *check.C) {
+	environment.PruneAllContainers(apiClient)
+}
+
+// TestContainerPrune tests "pouch container prune" only removes the stopped containers.
+func (suite *PouchContainerPruneSuite) TestContainerPrune(c *check.C) {
+	stopped, running, paused := "TestContainerPruneStopped", "TestContainerPruneRunning", "TestContainerPrunePaused"
+
+	command.PouchRun("create", "--name", stopped, busyboxImage, "top").Assert(c, icmd.Success)
+	command.PouchRun("run", "-d", "--name", running, busyboxImage, "top").Assert(c, icmd.Success)
+	command.PouchRun("run", "-d", "--name", paused, busyboxImage, "top").Assert(c, icmd.Success)
+	command.PouchRun("pause", paused).Assert(c, icmd.Success)
+
+	stoppedID := command.PouchRun("inspect", "-f", "{{.ID}}", stopped).Assert(c, icmd.Success).Stdout()
+
+	res := command.PouchRun("container", "prune", "-f").Assert(c, icmd.Success)
+	out := res.Stdout()
+	c.Assert(util.PartialEqual(out, strings.TrimSpace(stoppedID)), check.IsNil)
+	c.Assert(util.PartialEqual(out, "Total reclaimed space:"), check.IsNil)
+
+	command.PouchRun("inspect", "--type", "container", stopped).Assert(c, icmd.Expected{ExitCode: 2})
+	command.PouchRun("inspect", running).Assert(c, icmd.Success)
+	command.PouchRun("inspect", paused).Assert(c, icmd.Success)
+}
+
+// TestContainerPruneFilter tests "pouch container prune" with until and label filters.
+func (suite *PouchContainerPruneSuite) TestContainerPruneFilter(c *check.C) {
+	foo, bar := "TestContainerPruneFilterFoo", "TestContainerPruneFilterBar"
+
+	command.PouchRun("create", "--name", foo, "--label", "app=foo", busyboxImage).Assert(c, icmd.Success)
+	command.PouchRun("create", "--name", bar, "--label", "app=bar", busyboxImage).Assert(c, icmd.Success)
+
+	// the containers are created after until
+	res := command.PouchRun("container", "prune", "-f", "--filter", "until=2000-01-01T00:00:00Z").Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "Deleted Containers:"), check.Equals, false)
+
+	command.PouchRun("container", "prune", "-f", "--filter", "label=app=foo").Assert(c, icmd.Success)
+	command.PouchRun("inspect", "--type", "container", foo).Assert(c, icmd.Expected{ExitCode: 2})
+	command.PouchRun("inspect", bar).Assert(c, icmd.Success)
+}
+
+// TestContainerPruneNotLabelFilter tests "pouch container prune" keeps the
+// containers matching the label! filter.
+func (suite *PouchContainerPruneSuite) TestContainerPruneNotLabelFilter(c *check.C) {
+	keep, drop := "TestContainerPruneNotLabelFilterKeep", "TestContainerPruneNotLabelFilterDrop"
+
+	command.PouchRun("create", "--name", keep, "--label", "keep=true", busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, keep)
+	command.PouchRun("create", "--name", drop, busyboxImage).Assert(c, icmd.Success)
+
+	command.PouchRun("container", "prune", "-f", "--filter", "label!=keep=true").Assert(c, icmd.Success)
+	command.PouchRun("inspect", "--type", "container", drop).Assert(c, icmd.Expected{ExitCode: 2})
+	command.PouchRun("inspect", keep).Assert(c, icmd.Success)
+}
+
+// TestContainerPruneInvalidFilter tests "pouch container prune" with invalid filter.
+func (suite *PouchContainerPruneSuite) TestContainerPruneInvalidFilter(c *check.C) {
+	res := command.PouchRun("container", "prune", "-f", "--filter", "unknown=foo")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid filter"), check.IsNil)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchCpSuite is the test suite for cp CLI.
+type PouchCpSuite struct{}
+
+func init() {
+	check.Suite(&PouchCpSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchCpSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchCpSuite) TearDownTest(c *check.C) {
+}
+
+// TestCpToRunningContainer tests copying a local directory into a running container.
+func (suite *PouchCpSuite) TestCpToRunningContainer(c *check.C) {
+	name := "TestCpToRunningContainer"
+
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	dir, err := ioutil.TempDir("", "cp")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "conf")
+	c.Assert(os.Mkdir(src, 0755), check.IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(src, "app.conf"), []byte("hello"), 0640), check.IsNil)
+
+	command.PouchRun("cp", src, name+":/tmp").Assert(c, icmd.Success)
+
+	res := command.PouchRun("exec", name, "cat", "/tmp/conf/app.conf").Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "hello")
+
+	res = command.PouchRun("exec", name, "stat", "-c", "%a", "/tmp/conf/app.conf").Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "640")
+
+	// the single file is renamed to the destination which does not exist.
+	command.PouchRun("cp", filepath.Join(src, "app.conf"), name+":/tmp/renamed.conf").Assert(c, icmd.Success)
+
+	res = command.PouchRun("exec", name, "cat", "/tmp/renamed.conf").Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "hello")
+}
+
+// TestCpFromStoppedContainer tests copying files out of a stopped container,
+// including the contents of a directory with "/.".
+func (suite *PouchCpSuite) TestCpFromStoppedContainer(c *check.C) {
+	name := "TestCpFromStoppedContainer"
+
+	command.PouchRun("run", "--name", name, busyboxImage,
+		"sh", "-c", "mkdir -p /data/sub && echo -n foo > /data/foo && echo -n bar > /data/sub/bar").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	dir, err := ioutil.TempDir("", "cp")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	command.PouchRun("cp", name+":/data", dir).Assert(c, icmd.Success)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "data", "sub", "bar"))
+	c.Assert(err, check.IsNil)
+	c.Assert(string(data), check.Equals, "bar")
+
+	contents := filepath.Join(dir, "contents")
+	command.PouchRun("cp", name+":/data/.", contents).Assert(c, icmd.Success)
+
+	data, err = ioutil.ReadFile(filepath.Join(contents, "foo"))
+	c.Assert(err, check.IsNil)
+	c.Assert(string(data), check.Equals, "foo")
+
+	command.PouchRun("cp", name+":/nothing", dir).Assert(c, icmd.Expected{ExitCode: 2, Err: "could not find the file"})
+}
+
+// TestCpSymlinkInContainer tests the symlink in container is resolved in the
+// scope of the container's filesystem.
+func (suite *PouchCpSuite) TestCpSymlinkInContainer(c *check.C) {
+	name := "TestCpSymlinkInContainer"
+
+	command.PouchRun("run", "-d", "--name", name, busyboxImage,
+		"sh", "-c", "mkdir /target && ln -s / /escape && top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	dir, err := ioutil.TempDir("", "cp")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "TestCpSymlinkInContainer")
+	c.Assert(ioutil.WriteFile(src, []byte("hello"), 0644), check.IsNil)
+
+	// the file is written into the root of container, not the host.
+	command.PouchRun("cp", src, name+":/escape/tmp/").Assert(c, icmd.Success)
+
+	res := command.PouchRun("exec", name, "cat", "/tmp/TestCpSymlinkInContainer").Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "hello")
+
+	_, err = os.Stat("/tmp/TestCpSymlinkInContainer")
+	c.Assert(os.IsNotExist(err), check.Equals, true)
+}
+
+// TestCpWithStdio tests the tar archive is streamed through STDIN and STDOUT.
+func (suite *PouchCpSuite) TestCpWithStdio(c *check.C) {
+	name := "TestCpWithStdio"
+
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	dir, err := ioutil.TempDir("", "cp")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "app.conf"), []byte("hello"), 0644), check.IsNil)
+
+	archive := filepath.Join(dir, "app.tar")
+	icmd.RunCommand("tar", "-cf", archive, "-C", dir, "app.conf").Assert(c, icmd.Success)
+
+	f, err := os.Open(archive)
+	c.Assert(err, check.IsNil)
+	defer f.Close()
+
+	cmd := command.PouchCmd("cp", "-", name+":/tmp")
+	cmd.Stdin = f
+	icmd.RunCmd(cmd).Assert(c, icmd.Success)
+
+	res := command.PouchRun("exec", name, "cat", "/tmp/app.conf").Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "hello")
+
+	res = command.PouchRun("cp", name+":/tmp/app.conf", "-").Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(res.Stdout(), "app.conf"), check.IsNil)
+	c.Assert(util.PartialEqual(res.Stdout(), "hello"), check.IsNil)
+}
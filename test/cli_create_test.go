@@ -3,6 +3,8 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"strings"
 
@@ -73,6 +75,7 @@ func (suite *PouchCreateSuite) TestCreateDuplicateContainerName(c *check.C) {
 	res := command.PouchRun("create", "--name", name, busyboxImage)
 	defer DelContainerForceMultyTime(c, name)
 	res.Assert(c, icmd.Success)
+	id := strings.TrimSpace(res.Stdout())
 
 	res = command.PouchRun("create", "--name", name, busyboxImage)
 	c.Assert(res.Stderr(), check.NotNil)
@@ -80,6 +83,20 @@ func (suite *PouchCreateSuite) TestCreateDuplicateContainerName(c *check.C) {
 	if out := res.Combined(); !strings.Contains(out, "already exist") {
 		c.Fatalf("unexpected output %s expected already exist\n", out)
 	}
+
+	// the error should contain the ID of conflicting container
+	if out := res.Combined(); !strings.Contains(out, id) {
+		c.Fatalf("unexpected output %s expected container id %s\n", out, id)
+	}
+}
+
+// TestCreateInvalidContainerName is to verify invalid container names.
+func (suite *PouchCreateSuite) TestCreateInvalidContainerName(c *check.C) {
+	for _, invalid := range []string{"-foo", "foo/bar", "foo bar"} {
+		res := command.PouchRun("create", "--name", invalid, busyboxImage)
+		c.Assert(res.Error, check.NotNil)
+		c.Assert(util.PartialEqual(res.Stderr(), "invalid container name"), check.IsNil)
+	}
 }
 
 // TestCreateWithArgs is to verify args.
@@ -240,6 +257,29 @@ func (suite *PouchCreateSuite) TestCreateWithSeccomp(c *check.C) {
 	if !exist {
 		c.Errorf("failed to set seccomp in security-opt")
 	}
+	c.Assert(result[0].SeccompProfile, check.Equals, "unconfined")
+}
+
+// TestCreateWithInvalidSecurityOpt tests creating container with invalid
+// security options fails.
+func (suite *PouchCreateSuite) TestCreateWithInvalidSecurityOpt(c *check.C) {
+	for _, opt := range []string{"seccomp=/nonexistent/profile.json", "unknown=foo", "seccomp"} {
+		res := command.PouchRun("create", "--security-opt", opt, busyboxImage)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0, check.Commentf(opt))
+	}
+}
+
+// TestCreatePrivilegedSecurityProfile tests the privileged container runs
+// without seccomp profile.
+func (suite *PouchCreateSuite) TestCreatePrivilegedSecurityProfile(c *check.C) {
+	name := "TestCreatePrivilegedSecurityProfile"
+
+	command.PouchRun("create", "--name", name, "--privileged", busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	output, err := inspectFilter(name, ".SeccompProfile")
+	c.Assert(err, check.IsNil)
+	c.Assert(output, check.Equals, "unconfined")
 }
 
 // TestCreateWithCapability tries to test create a container with capability.
@@ -270,6 +310,35 @@ func (suite *PouchCreateSuite) TestCreateWithCapability(c *check.C) {
 	}
 }
 
+// TestCreateWithCapabilityNormalized tests the capabilities are normalized and
+// the effective capabilities are shown in inspect.
+func (suite *PouchCreateSuite) TestCreateWithCapabilityNormalized(c *check.C) {
+	name := "create-capability-normalized"
+
+	res := command.PouchRun("create", "--name", name, "--cap-add", "cap_net_admin", "--cap-drop", "all", busyboxImage)
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	capAdd, err := inspectFilter(name, ".HostConfig.CapAdd")
+	c.Assert(err, check.IsNil)
+	c.Assert(capAdd, check.Equals, "[NET_ADMIN]")
+
+	capDrop, err := inspectFilter(name, ".HostConfig.CapDrop")
+	c.Assert(err, check.IsNil)
+	c.Assert(capDrop, check.Equals, "[ALL]")
+
+	effective, err := inspectFilter(name, ".EffectiveCapabilities")
+	c.Assert(err, check.IsNil)
+	c.Assert(effective, check.Equals, "[CAP_NET_ADMIN]")
+}
+
+// TestCreateWithUnknownCapability tests the unknown capability is rejected.
+func (suite *PouchCreateSuite) TestCreateWithUnknownCapability(c *check.C) {
+	res := command.PouchRun("create", "--cap-add", "NET_FOO", busyboxImage)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*unknown capability \"NET_FOO\", valid choices are ALL, .*NET_ADMIN.*")
+}
+
 // TestCreateWithPrivilege tries to test create a container with privilege.
 func (suite *PouchCreateSuite) TestCreateWithPrivilege(c *check.C) {
 	name := "create-privilege"
@@ -281,6 +350,11 @@ func (suite *PouchCreateSuite) TestCreateWithPrivilege(c *check.C) {
 	privileged, err := inspectFilter(name, ".HostConfig.Privileged")
 	c.Assert(err, check.IsNil)
 	c.Assert(privileged, check.Equals, "true")
+
+	// all capabilities are effective for privileged container.
+	effective, err := inspectFilter(name, ".EffectiveCapabilities")
+	c.Assert(err, check.IsNil)
+	c.Assert(strings.Contains(effective, "CAP_SYS_ADMIN"), check.Equals, true, check.Commentf(effective))
 }
 
 // TestCreateEnableLxcfs tries to test create a container with lxcfs.
@@ -360,6 +434,44 @@ func (suite *PouchCreateSuite) TestCreateWithEnv(c *check.C) {
 	}
 }
 
+// TestCreateWithEnvFile tests creating container with env file, the envs
+// set by --env override the ones in file, and env without value is passed
+// from client.
+func (suite *PouchCreateSuite) TestCreateWithEnvFile(c *check.C) {
+	name := "TestCreateWithEnvFile"
+
+	envFile, err := ioutil.TempFile("", "env-file")
+	c.Assert(err, check.IsNil)
+	defer os.Remove(envFile.Name())
+
+	_, err = envFile.WriteString("# comment\n\nTEST1=file\nTEST2=file\n")
+	c.Assert(err, check.IsNil)
+	envFile.Close()
+
+	os.Setenv("POUCH_TEST_PASS_ENV", "client")
+	defer os.Unsetenv("POUCH_TEST_PASS_ENV")
+
+	command.PouchRun("create", "--name", name,
+		"--env-file", envFile.Name(),
+		"-e", "TEST2=flag",
+		"-e", "POUCH_TEST_PASS_ENV",
+		busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	envs, err := inspectFilter(name, ".Config.Env")
+	c.Assert(err, check.IsNil)
+	for _, env := range []string{"TEST1=file", "TEST2=flag", "POUCH_TEST_PASS_ENV=client"} {
+		c.Assert(strings.Contains(envs, env), check.Equals, true, check.Commentf("%s not in %s", env, envs))
+	}
+	c.Assert(strings.Contains(envs, "TEST2=file"), check.Equals, false)
+
+	// malformed line is reported with its line number.
+	c.Assert(ioutil.WriteFile(envFile.Name(), []byte("TEST1=file\n=value\n"), 0644), check.IsNil)
+	res := command.PouchRun("create", "--env-file", envFile.Name(), busyboxImage, "top")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*line 2.*")
+}
+
 // TestCreateWithWorkDir tests creating container with a workdir works.
 func (suite *PouchCreateSuite) TestCreateWithWorkDir(c *check.C) {
 	name := "TestCreateWithWorkDir"
@@ -388,6 +500,32 @@ func (suite *PouchCreateSuite) TestCreateWithUser(c *check.C) {
 	c.Assert(userConfig, check.Equals, user)
 }
 
+// TestCreateWithEntrypoint tests the entrypoint of image is overwritten or
+// reset by --entrypoint, and inspect shows the merged config.
+func (suite *PouchCreateSuite) TestCreateWithEntrypoint(c *check.C) {
+	name := "TestCreateWithEntrypoint"
+
+	command.PouchRun("create", "--name", name, "--entrypoint", "echo hello", busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	entrypoint, err := inspectFilter(name, "json .Config.Entrypoint")
+	c.Assert(err, check.IsNil)
+	c.Assert(entrypoint, check.Equals, `["echo","hello"]`)
+
+	// the empty entrypoint resets the image's, and the image's cmd is used.
+	reset := "TestCreateWithEntrypointReset"
+	command.PouchRun("create", "--name", reset, "--entrypoint", "", busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, reset)
+
+	entrypoint, err = inspectFilter(reset, "json .Config.Entrypoint")
+	c.Assert(err, check.IsNil)
+	c.Assert(entrypoint, check.Equals, "null")
+
+	cmd, err := inspectFilter(reset, "json .Config.Cmd")
+	c.Assert(err, check.IsNil)
+	c.Assert(cmd, check.Equals, `["sh"]`)
+}
+
 // TestCreateWithIntelRdt tests creating container with Intel RDT.
 func (suite *PouchCreateSuite) TestCreateWithIntelRdt(c *check.C) {
 	name := "TestCreateWithIntelRdt"
@@ -517,3 +655,20 @@ func (suite *PouchCreateSuite) TestCreateWithoutNvidiaConfig(c *check.C) {
 	}
 	c.Assert(result[0].HostConfig.Resources.NvidiaConfig, check.IsNil)
 }
+
+// TestCreateWithRuntime tests the runtime of container is recorded, and
+// creating container with unregistered runtime fails.
+func (suite *PouchCreateSuite) TestCreateWithRuntime(c *check.C) {
+	name := "TestCreateWithRuntime"
+
+	command.PouchRun("create", "--name", name, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	output, err := inspectFilter(name, ".HostConfig.Runtime")
+	c.Assert(err, check.IsNil)
+	c.Assert(output, check.Equals, "runc")
+
+	res := command.PouchRun("create", "--runtime", "not-registered", busyboxImage)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "unknown runtime not-registered"), check.IsNil)
+}
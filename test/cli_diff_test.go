@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchDiffSuite is the test suite for diff CLI.
+type PouchDiffSuite struct{}
+
+func init() {
+	check.Suite(&PouchDiffSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchDiffSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchDiffSuite) TearDownTest(c *check.C) {
+}
+
+// TestDiffContainer tests the added, changed and deleted paths are listed
+// in order, and no whiteout file is leaked.
+func (suite *PouchDiffSuite) TestDiffContainer(c *check.C) {
+	name := "TestDiffContainer"
+
+	command.PouchRun("run", "--name", name, busyboxImage,
+		"sh", "-c", "echo a > /tmp/new && rm -r /home && echo b >> /etc/group").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("diff", name).Assert(c, icmd.Success)
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+
+	for _, expected := range []string{"C /etc/group", "D /home", "A /tmp/new"} {
+		found := false
+		for _, line := range lines {
+			if line == expected {
+				found = true
+			}
+		}
+		c.Assert(found, check.Equals, true, check.Commentf("%s not in %v", expected, lines))
+	}
+
+	for i, line := range lines {
+		c.Assert(strings.Contains(line, ".wh."), check.Equals, false)
+		if i > 0 {
+			c.Assert(lines[i-1][2:] < line[2:], check.Equals, true, check.Commentf("%v is not sorted", lines))
+		}
+	}
+
+	command.PouchRun("diff", "unknown").Assert(c, icmd.Expected{ExitCode: 2, Err: "not found"})
+}
@@ -228,12 +228,15 @@ func (suite *PouchExecSuite) TestExecFail(c *check.C) {
 	defer DelContainerForceMultyTime(c, name)
 	c.Assert(res.Stderr(), check.NotNil)
 
-	// test a 'executable file not found' fail should get exit code 126.
+	// test a 'executable file not found' fail should get exit code 127.
 	name = "TestExecFailCode"
 	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
 
-	command.PouchRun("exec", name, "shouldnotexit").Assert(c, icmd.Expected{ExitCode: 126})
+	command.PouchRun("exec", name, "shouldnotexit").Assert(c, icmd.Expected{ExitCode: 127})
 	defer DelContainerForceMultyTime(c, name)
+
+	// test a 'permission denied' fail should get exit code 126.
+	command.PouchRun("exec", name, "/etc/passwd").Assert(c, icmd.Expected{ExitCode: 126})
 	// test a 'ls /nosuchfile' fail should get exit code not equal to 0.
 	res = command.PouchRun("exec", name, "ls", "/nosuchfile")
 	if res.ExitCode == 0 {
@@ -285,6 +288,37 @@ func (suite *PouchExecSuite) TestExecWithWorkingDir(c *check.C) {
 	}
 }
 
+// TestExecWithWorkdirFlag tests the flag workdir overrides the working
+// directory of container.
+func (suite *PouchExecSuite) TestExecWithWorkdirFlag(c *check.C) {
+	cname := "TestExecWithWorkdirFlag"
+
+	res := command.PouchRun("run", "-d", "--name", cname, "-w", "/tmp", busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+
+	res = command.PouchRun("exec", "-w", "/etc", cname, "pwd")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "/etc")
+}
+
+// TestExecDetach tests detached exec returns without waiting for the process.
+func (suite *PouchExecSuite) TestExecDetach(c *check.C) {
+	cname := "TestExecDetach"
+
+	command.PouchRun("run", "-d", "--name", cname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	cmd := command.PouchCmd("exec", "-d", cname, "sh", "-c", "sleep 2; touch /tmp/detached")
+	cmd.Timeout = time.Second
+	res := icmd.RunCmd(cmd)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "")
+
+	time.Sleep(3 * time.Second)
+	command.PouchRun("exec", cname, "ls", "/tmp/detached").Assert(c, icmd.Success)
+}
+
 // TestExecWithTty tests running container with -tty flag and attach stdin in a non-tty client.
 func (suite *PouchExecSuite) TestExecWithTty(c *check.C) {
 	name := "TestExecWithTty"
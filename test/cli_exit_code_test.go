@@ -0,0 +1,86 @@
+package main
+
+import (
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchExitCodeSuite is the test suite for the exit codes of CLI.
+type PouchExitCodeSuite struct{}
+
+func init() {
+	check.Suite(&PouchExitCodeSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchExitCodeSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	PullImage(c, busyboxImage)
+}
+
+// TestExitCodeNotFound tests the commands exit with 2 if the object is not found.
+func (suite *PouchExitCodeSuite) TestExitCodeNotFound(c *check.C) {
+	name := "TestExitCodeNotFound"
+
+	command.PouchRun("inspect", name).Assert(c, icmd.Expected{ExitCode: 2, Err: "not found"})
+	command.PouchRun("start", name).Assert(c, icmd.Expected{ExitCode: 2, Err: "not found"})
+	command.PouchRun("stop", name).Assert(c, icmd.Expected{ExitCode: 2, Err: "not found"})
+	command.PouchRun("rm", name, name+"2").Assert(c, icmd.Expected{ExitCode: 2, Err: "not found"})
+	command.PouchRun("exec", name, "ls").Assert(c, icmd.Expected{ExitCode: 2, Err: "not found"})
+}
+
+// TestExitCodeConflict tests the commands exit with 3 if the operation
+// conflicts with the state of object.
+func (suite *PouchExitCodeSuite) TestExitCodeConflict(c *check.C) {
+	name := "TestExitCodeConflict"
+
+	command.PouchRun("create", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("create", "--name", name, busyboxImage, "top").Assert(c, icmd.Expected{ExitCode: 3})
+}
+
+// TestExitCodeDaemonUnreachable tests the commands exit with 125 if daemon
+// can't be reached.
+func (suite *PouchExitCodeSuite) TestExitCodeDaemonUnreachable(c *check.C) {
+	res := command.PouchRun("--host", "unix:///var/run/nonexistent-pouchd.sock", "ps")
+	res.Assert(c, icmd.Expected{ExitCode: 125})
+
+	res = command.PouchRun("--host", "unix:///var/run/nonexistent-pouchd.sock", "version")
+	res.Assert(c, icmd.Expected{ExitCode: 125})
+}
+
+// TestExitCodeRunCommand tests pouch run exits with 127 if the command isn't
+// found, 126 if it can't be invoked, and the exit code of container if it
+// exits.
+func (suite *PouchExitCodeSuite) TestExitCodeRunCommand(c *check.C) {
+	name := "TestExitCodeRunCommand"
+
+	command.PouchRun("run", "--name", name, busyboxImage, "nosuchcommand").Assert(c, icmd.Expected{ExitCode: 127})
+	DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("run", "--name", name, busyboxImage, "/etc/passwd").Assert(c, icmd.Expected{ExitCode: 126})
+	DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("run", "--name", name, busyboxImage, "sh", "-c", "exit 137").Assert(c, icmd.Expected{ExitCode: 137})
+	DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("run", "--rm", busyboxImage, "sh", "-c", "exit 3").Assert(c, icmd.Expected{ExitCode: 3})
+}
+
+// TestExitCodeExecCommand tests pouch exec exits with 127 if the command isn't
+// found, 126 if it can't be invoked, and the exit code of process if it exits.
+func (suite *PouchExitCodeSuite) TestExitCodeExecCommand(c *check.C) {
+	name := "TestExitCodeExecCommand"
+
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("exec", name, "nosuchcommand").Assert(c, icmd.Expected{ExitCode: 127})
+	command.PouchRun("exec", name, "/etc/passwd").Assert(c, icmd.Expected{ExitCode: 126})
+	command.PouchRun("exec", name, "sh", "-c", "exit 42").Assert(c, icmd.Expected{ExitCode: 42})
+}
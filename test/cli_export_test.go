@@ -0,0 +1,107 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchExportSuite is the test suite for export and import CLI.
+type PouchExportSuite struct{}
+
+func init() {
+	check.Suite(&PouchExportSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchExportSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchExportSuite) TearDownTest(c *check.C) {
+}
+
+// TestExportAndImport tests the exported filesystem of running and stopped
+// containers can be imported as an image.
+func (suite *PouchExportSuite) TestExportAndImport(c *check.C) {
+	name := "TestExportAndImport"
+	image := "foo:exported"
+
+	command.PouchRun("run", "-d", "--name", name, "-v", "/data", busyboxImage,
+		"sh", "-c", "echo a > /foo && echo b > /data/bar && mknod /dev-null c 1 3 && top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	dir, err := ioutil.TempDir("", "export")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	archive := filepath.Join(dir, "rootfs.tar")
+	command.PouchRun("export", "-o", archive, name).Assert(c, icmd.Success)
+
+	res := icmd.RunCommand("tar", "-tf", archive).Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(res.Stdout(), "foo"), check.IsNil)
+	c.Assert(strings.Contains(res.Stdout(), "dev-null"), check.Equals, false)
+	c.Assert(strings.Contains(res.Stdout(), "data/bar"), check.Equals, false)
+
+	// the stopped container can be exported too.
+	command.PouchRun("stop", "-t", "1", name).Assert(c, icmd.Success)
+	command.PouchRun("export", "-o", archive, name).Assert(c, icmd.Success)
+
+	command.PouchRun("import", "-c", `CMD ["cat", "/foo"]`, "-m", "imported", archive, image).Assert(c, icmd.Success)
+	defer DelImageForceOk(c, image)
+
+	nname := "fromExported"
+	res = command.PouchRun("run", "--name", nname, image)
+	res.Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, nname)
+	c.Assert(res.Stdout(), check.Equals, "a\n")
+
+	res = command.PouchRun("history", image).Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(res.Stdout(), "imported"), check.IsNil)
+}
+
+// TestImportFromStdin tests the tar archive is imported from STDIN.
+func (suite *PouchExportSuite) TestImportFromStdin(c *check.C) {
+	name := "TestImportFromStdin"
+	image := "foo:stdin"
+
+	command.PouchRun("create", "--name", name, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	dir, err := ioutil.TempDir("", "export")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	archive := filepath.Join(dir, "rootfs.tar")
+	command.PouchRun("export", "-o", archive, name).Assert(c, icmd.Success)
+
+	f, err := os.Open(archive)
+	c.Assert(err, check.IsNil)
+	defer f.Close()
+
+	cmd := command.PouchCmd("import", "-", image)
+	cmd.Stdin = f
+	icmd.RunCmd(cmd).Assert(c, icmd.Success)
+	defer DelImageForceOk(c, image)
+
+	nname := "fromStdin"
+	res := command.PouchRun("run", "--name", nname, image, "echo", "hi")
+	res.Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, nname)
+	c.Assert(res.Stdout(), check.Equals, "hi\n")
+
+	command.PouchRun("export", "unknown").Assert(c, icmd.Expected{ExitCode: 2, Err: "not found"})
+}
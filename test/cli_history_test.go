@@ -1,9 +1,15 @@
 package main
 
 import (
+	"strings"
+
+	"github.com/alibaba/pouch/pkg/utils"
+	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
 
 	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
 )
 
 // PouchHistorySuite is the test suite for history CLI.
@@ -16,6 +22,8 @@ func init() {
 // SetUpSuite does common setup in the beginning of each test suite.
 func (suite *PouchHistorySuite) SetUpSuite(c *check.C) {
 	SkipIfFalse(c, environment.IsLinux)
+
+	PullImage(c, busyboxImage)
 }
 
 // TestHistoryWorks tests "pouch history" work.
@@ -23,3 +31,26 @@ func (suite *PouchHistorySuite) TestHistoryWorks(c *check.C) {
 	// TODO: We shouldn't compare dockerhub's image history with a fixed string, that's too unreadable.
 	// So this test case will be done when pouch enables build functionality.
 }
+
+// TestHistoryFormat tests the --format and --quiet flags of history command.
+func (suite *PouchHistorySuite) TestHistoryFormat(c *check.C) {
+	image, err := getImageInfo(apiClient, busyboxImage)
+	c.Assert(err, check.IsNil)
+
+	// the top-most entry is the image itself
+	res := command.PouchRun("history", "--format", "{{.ID}}", busyboxImage).Assert(c, icmd.Success)
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(lines[0], check.Equals, utils.TruncateID(image.ID))
+	for _, line := range lines[1:] {
+		c.Assert(line, check.Equals, "<missing>")
+	}
+
+	res = command.PouchRun("history", "-q", "--no-trunc", busyboxImage).Assert(c, icmd.Success)
+	c.Assert(strings.Fields(res.Stdout())[0], check.Equals, image.ID)
+
+	// template error should be reported without output
+	res = command.PouchRun("history", "--format", "{{.Unknown}}", busyboxImage)
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(res.Stdout(), check.Equals, "")
+	c.Assert(util.PartialEqual(res.Stderr(), "failed to execute format template"), check.IsNil)
+}
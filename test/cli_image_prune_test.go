@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchImagePruneSuite is the test suite for image prune CLI.
+type PouchImagePruneSuite struct{}
+
+func init() {
+	check.Suite(&PouchImagePruneSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchImagePruneSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TestImagePruneDangling tests "pouch image prune" only removes the dangling images.
+func (suite *PouchImagePruneSuite) TestImagePruneDangling(c *check.C) {
+	digestRef := environment.BusyboxRepo + "@" + environment.Busybox125Digest
+
+	// the image pulled by digest only has no tag
+	command.PouchRun("rmi", "-f", busyboxImage125)
+	command.PouchRun("pull", digestRef).Assert(c, icmd.Success)
+	image, err := getImageInfo(apiClient, digestRef)
+	c.Assert(err, check.IsNil)
+
+	res := command.PouchRun("image", "prune", "-f").Assert(c, icmd.Success)
+	out := res.Stdout()
+	c.Assert(util.PartialEqual(out, "Deleted: "+image.ID), check.IsNil)
+	c.Assert(util.PartialEqual(out, "Total reclaimed space:"), check.IsNil)
+
+	command.PouchRun("image", "inspect", digestRef).Assert(c, icmd.Expected{ExitCode: 2})
+
+	// the tagged image should be kept
+	command.PouchRun("image", "inspect", busyboxImage).Assert(c, icmd.Success)
+}
+
+// TestImagePruneUntil tests "pouch image prune --filter until" keeps the newer images.
+func (suite *PouchImagePruneSuite) TestImagePruneUntil(c *check.C) {
+	res := command.PouchRun("image", "prune", "-a", "-f", "--filter", "until=2000-01-01T00:00:00Z").Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "Deleted:"), check.Equals, false)
+
+	command.PouchRun("image", "inspect", busyboxImage).Assert(c, icmd.Success)
+}
+
+// TestImagePruneInvalidFilter tests "pouch image prune" with invalid filter.
+func (suite *PouchImagePruneSuite) TestImagePruneInvalidFilter(c *check.C) {
+	res := command.PouchRun("image", "prune", "-f", "--filter", "unknown=foo")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid filter"), check.IsNil)
+
+	res = command.PouchRun("image", "prune", "-f", "--filter", "dangling=false")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(util.PartialEqual(res.Stderr(), "use --all instead"), check.IsNil)
+}
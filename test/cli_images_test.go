@@ -69,10 +69,10 @@ func (suite *PouchImagesSuite) TestImagesWorks(c *check.C) {
 		c.Assert(err, check.IsNil)
 	}
 
-	// with --digest
+	// with --digests
 	{
-		res := command.PouchRun("images", "--digest").Assert(c, icmd.Success)
-		items := imagesListToKV(res.Combined())[busyboxImage]
+		res := command.PouchRun("images", "--digests").Assert(c, icmd.Success)
+		items := imagesListToKV(res.Stdout())[busyboxImage]
 		c.Assert(items[2], check.Equals, strings.TrimPrefix(image.RepoDigests[0], environment.BusyboxRepo+"@"))
 	}
 
@@ -84,6 +84,21 @@ func (suite *PouchImagesSuite) TestImagesWorks(c *check.C) {
 	}
 }
 
+// TestImagesFormat tests the --format flag of images command.
+func (suite *PouchImagesSuite) TestImagesFormat(c *check.C) {
+	image, err := getImageInfo(apiClient, busyboxImage)
+	c.Assert(err, check.IsNil)
+
+	res := command.PouchRun("images", "-f", "reference="+busyboxImage, "--format", "{{.ID}}\\t{{.Name}}:{{.Tag}}").Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, fmt.Sprintf("%s\t%s\n", utils.TruncateID(image.ID), busyboxImage))
+
+	// template error should be reported without output
+	res = command.PouchRun("images", "--format", "{{.Unknown}}")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(res.Stdout(), check.Equals, "")
+	c.Assert(util.PartialEqual(res.Stderr(), "failed to execute format template"), check.IsNil)
+}
+
 //TestImageListFilter test the filter flag works right
 func (suite *PouchImagesSuite) TestImageListFilter(c *check.C) {
 	busyBoxImageInfo, err := getImageInfo(apiClient, busyboxImage)
@@ -110,6 +125,38 @@ func (suite *PouchImagesSuite) TestImageListFilter(c *check.C) {
 	c.Assert(len(items1)+len(items2), check.Equals, 1)
 }
 
+// TestImageListMultipleFilters tests multiple filters are combined with AND.
+func (suite *PouchImagesSuite) TestImageListMultipleFilters(c *check.C) {
+	// both images are tagged, so there is no dangling image
+	res := command.PouchRun("images", "-f", "dangling=true").Assert(c, icmd.Success)
+	c.Assert(len(imagesListToKV(res.Stdout())), check.Equals, 0)
+
+	res = command.PouchRun("images", "-f", "dangling=false", "-f", "reference="+busyboxImage).Assert(c, icmd.Success)
+	items := imagesListToKV(res.Stdout())
+	c.Assert(len(items), check.Equals, 1)
+	c.Assert(items[busyboxImage], check.NotNil)
+
+	res = command.PouchRun("images", "-f", "dangling=true", "-f", "reference="+busyboxImage).Assert(c, icmd.Success)
+	c.Assert(len(imagesListToKV(res.Stdout())), check.Equals, 0)
+
+	// the reference glob pattern works with before and since filter
+	pattern := environment.BusyboxRepo + ":*"
+	beforeRes := command.PouchRun("images", "-f", "reference="+pattern, "-f", "before="+helloworldImage).Assert(c, icmd.Success)
+	sinceRes := command.PouchRun("images", "-f", "reference="+pattern, "-f", "since="+helloworldImage).Assert(c, icmd.Success)
+	c.Assert(len(imagesListToKV(beforeRes.Stdout()))+len(imagesListToKV(sinceRes.Stdout())), check.Equals, 1)
+}
+
+// TestImageListInvalidFilter tests unknown filter key is rejected.
+func (suite *PouchImagesSuite) TestImageListInvalidFilter(c *check.C) {
+	res := command.PouchRun("images", "-f", "after="+busyboxImage)
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid filter after"), check.IsNil)
+
+	res = command.PouchRun("images", "-f", "dangling=yes")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid dangling filter value"), check.IsNil)
+}
+
 // imagesListToKV parse "pouch images" into key-value mapping.
 func imagesListToKV(list string) map[string][]string {
 	// skip header
@@ -156,6 +203,18 @@ func (suite *PouchImagesSuite) TestInspectImage(c *check.C) {
 	c.Assert(output, check.Equals, fmt.Sprintf("[%s]\n", busyboxImage))
 }
 
+// TestInspectImageNotFound is to verify the exit code of inspecting unknown image.
+func (suite *PouchImagesSuite) TestInspectImageNotFound(c *check.C) {
+	res := command.PouchRun("image", "inspect", "unknown-image:notfound")
+	res.Assert(c, icmd.Expected{ExitCode: 2})
+	c.Assert(util.PartialEqual(res.Stderr(), "not found"), check.IsNil)
+
+	// the existing image is still printed out.
+	res = command.PouchRun("image", "inspect", "-f", "{{.RepoTags}}", busyboxImage, "unknown-image:notfound")
+	res.Assert(c, icmd.Expected{ExitCode: 2})
+	c.Assert(res.Stdout(), check.Equals, fmt.Sprintf("[%s]\n", busyboxImage))
+}
+
 // TestLoginAndLogout is to test login and logout command
 func (suite *PouchImagesSuite) TestLoginAndLogout(c *check.C) {
 	SkipIfFalse(c, environment.IsHubConnected)
@@ -167,4 +226,22 @@ func (suite *PouchImagesSuite) TestLoginAndLogout(c *check.C) {
 	// test logout a defined registry
 	output = command.PouchRun("logout", testHubAddress).Stdout()
 	c.Assert(util.PartialEqual(output, "Remove login credential for registry"), check.IsNil)
+
+	// test login with the password from stdin
+	cmd := command.PouchCmd("login", "-u", testHubUser, "--password-stdin", testHubAddress)
+	cmd.Stdin = strings.NewReader(testHubPasswd + "\n")
+	res := icmd.RunCmd(cmd)
+	res.Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(res.Stdout(), "Login Succeeded"), check.IsNil)
+
+	// the stored credential is used to pull image of the registry
+	command.PouchRun("pull", testHubAddress+"/library/busybox:latest").Assert(c, icmd.Success)
+	command.PouchRun("rmi", testHubAddress+"/library/busybox:latest").Assert(c, icmd.Success)
+
+	command.PouchRun("logout", testHubAddress).Assert(c, icmd.Success)
+
+	// the password can't be given by both flag and stdin
+	res = command.PouchRun("login", "-u", testHubUser, "-p", testHubPasswd, "--password-stdin", testHubAddress)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "mutually exclusive"), check.IsNil)
 }
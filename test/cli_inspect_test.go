@@ -142,14 +142,14 @@ func (suite *PouchInspectSuite) TestMultiInspectErrors(c *check.C) {
 		{
 			containers: []string{},
 			args:       []string{"multi-inspect-print-1", "multi-inspect-print-2"},
-			expectedOutput: "\nError: Fetch object error: {\"message\":\"container multi-inspect-print-1: not found\"}\n" +
-				"Error: Fetch object error: {\"message\":\"container multi-inspect-print-2: not found\"}\n",
+			expectedOutput: "\nError: Fetch object error: container multi-inspect-print-1: not found\n" +
+				"Error: Fetch object error: container multi-inspect-print-2: not found\n",
 		},
 		{
 			containers: []string{"multi-inspect-print-1"},
 			args:       []string{"multi-inspect-print-1", "multi-inspect-print-2"},
 			expectedOutput: "multi-inspect-print-1\n" +
-				"Error: Fetch object error: {\"message\":\"container multi-inspect-print-2: not found\"}\n",
+				"Error: Fetch object error: container multi-inspect-print-2: not found\n",
 		},
 	}
 
@@ -275,3 +275,35 @@ func (suite *PouchInspectSuite) TestContainerInspectExecIds(c *check.C) {
 	expected := fmt.Sprintf("[%v]\n", execIDs[0])
 	c.Assert(string(output), check.Equals, expected)
 }
+
+// TestInspectImageFallback tests inspect falls back to image if there is no
+// container with the same name, and the flag type restricts the object type.
+func (suite *PouchInspectSuite) TestInspectImageFallback(c *check.C) {
+	name := "TestInspectImageFallback"
+	command.PouchRun("create", "--name", name, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	imageID := strings.TrimSpace(command.PouchRun("image", "inspect", "-f", "{{.ID}}", busyboxImage).Assert(c, icmd.Success).Stdout())
+
+	// inspect container and image together
+	res := command.PouchRun("inspect", "-f", "{{.ID}}", name, busyboxImage).Assert(c, icmd.Success)
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(len(lines), check.Equals, 2)
+	c.Assert(lines[1], check.Equals, imageID)
+
+	// the image is not a container
+	res = command.PouchRun("inspect", "--type", "container", busyboxImage)
+	res.Assert(c, icmd.Expected{ExitCode: 2})
+	c.Assert(strings.Contains(res.Stderr(), "not found"), check.Equals, true)
+
+	// the container is not an image
+	res = command.PouchRun("inspect", "--type", "image", name)
+	res.Assert(c, icmd.Expected{ExitCode: 2})
+
+	command.PouchRun("inspect", "--type", "image", "-f", "{{.ID}}", busyboxImage).Assert(c, icmd.Success)
+
+	// invalid type
+	res = command.PouchRun("inspect", "--type", "volume", name)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(strings.Contains(res.Stderr(), "invalid type"), check.Equals, true)
+}
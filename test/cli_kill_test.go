@@ -0,0 +1,111 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchKillSuite is the test suite for kill CLI.
+type PouchKillSuite struct{}
+
+func init() {
+	check.Suite(&PouchKillSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchKillSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchKillSuite) TearDownTest(c *check.C) {
+}
+
+// TestKillWorks tests "pouch kill" sends SIGKILL by default.
+func (suite *PouchKillSuite) TestKillWorks(c *check.C) {
+	name := "TestKillWorks"
+
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("kill", name).Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, name)
+
+	command.PouchRun("wait", name).Assert(c, icmd.Success)
+
+	exitCode, err := inspectFilter(name, ".State.ExitCode")
+	c.Assert(err, check.IsNil)
+	c.Assert(exitCode, check.Equals, "137")
+}
+
+// TestKillWithSignal tests "pouch kill -s" accepts the name and number of signal.
+func (suite *PouchKillSuite) TestKillWithSignal(c *check.C) {
+	name := "TestKillWithSignal"
+
+	command.PouchRun("run", "-d", "--name", name, busyboxImage,
+		"sh", "-c", "trap 'echo hup' HUP; while true; do sleep 1; done").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	// the container keeps running after receiving the handled signal
+	command.PouchRun("kill", "-s", "SIGHUP", name).Assert(c, icmd.Success)
+	command.PouchRun("kill", "-s", "1", name).Assert(c, icmd.Success)
+
+	status, err := inspectFilter(name, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "running")
+
+	command.PouchRun("kill", "-s", "TERM", name).Assert(c, icmd.Success)
+	command.PouchRun("wait", name).Assert(c, icmd.Success)
+
+	status, err = inspectFilter(name, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "exited")
+}
+
+// TestKillMultiContainers tests "pouch kill" prints each container on its own line.
+func (suite *PouchKillSuite) TestKillMultiContainers(c *check.C) {
+	name1 := "TestKillMultiContainers-1"
+	name2 := "TestKillMultiContainers-2"
+
+	command.PouchRun("run", "-d", "--name", name1, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name1)
+	command.PouchRun("run", "-d", "--name", name2, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name2)
+
+	res := command.PouchRun("kill", name1, name2).Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, name1+"\n"+name2)
+}
+
+// TestKillInWrongWay tests "pouch kill" fails in wrong way.
+func (suite *PouchKillSuite) TestKillInWrongWay(c *check.C) {
+	name := "TestKillInWrongWay"
+
+	command.PouchRun("create", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	// kill a container which isn't running
+	res := command.PouchRun("kill", name)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "is not running"), check.IsNil)
+
+	// kill with invalid signal
+	command.PouchRun("start", name).Assert(c, icmd.Success)
+	res = command.PouchRun("kill", "-s", "NOSIGNAL", name)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "Invalid signal"), check.IsNil)
+
+	// kill unknown container
+	res = command.PouchRun("kill", "unknown")
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "not found"), check.IsNil)
+}
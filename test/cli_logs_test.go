@@ -151,6 +151,41 @@ func (suite *PouchLogsSuite) TestTailLine(c *check.C) {
 	}
 }
 
+// TestLogsWithRotation tests the rotated logs are read by tail.
+func (suite *PouchLogsSuite) TestLogsWithRotation(c *check.C) {
+	cname := "TestCLILogs_rotation"
+
+	command.PouchRun(
+		"run",
+		"-t",
+		"--name", cname,
+		"--log-opt", "max-size=1k",
+		"--log-opt", "max-file=3",
+		busyboxImage,
+		"sh", "-c", "for i in $(seq 1 100); do echo hello-$i; done;",
+	).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	logPath := strings.TrimSpace(command.PouchRun("inspect", "-f", "{{.LogPath}}", cname).Stdout())
+	for _, suffix := range []string{"", ".1", ".2"} {
+		_, err := os.Stat(logPath + suffix)
+		c.Assert(err, check.IsNil)
+	}
+	_, err := os.Stat(logPath + ".3")
+	c.Assert(os.IsNotExist(err), check.Equals, true)
+
+	// the last lines are read across the rotated files.
+	allLogs := suite.syncLogs(c, cname, "--tail", "30")
+	c.Assert(allLogs, check.HasLen, 30)
+	c.Assert(strings.TrimSpace(allLogs[0]), check.Equals, "hello-71")
+	c.Assert(strings.TrimSpace(allLogs[29]), check.Equals, "hello-100")
+
+	command.PouchRun("run", "--log-opt", "max-file=3", busyboxImage, "true").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "only valid with max-size",
+	})
+}
+
 // TestFollowMode tests follow mode.
 func (suite *PouchLogsSuite) TestFollowMode(c *check.C) {
 	cname := "TestCLILogs_follow_mode"
@@ -178,6 +213,28 @@ func (suite *PouchLogsSuite) TestFollowMode(c *check.C) {
 	}
 }
 
+// TestFollowModeAllOutput tests follow mode sends the output written just
+// before the container exits.
+func (suite *PouchLogsSuite) TestFollowModeAllOutput(c *check.C) {
+	cname := "TestCLILogs_follow_mode_all_output"
+
+	command.PouchRun(
+		"run",
+		"-d",
+		"--name", cname,
+		busyboxImage,
+		"sh", "-c", "sleep 2; for i in $(seq 1 50); do echo hello-$i; done;",
+	).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	res := command.PouchRun("logs", "-f", cname)
+	res.Assert(c, icmd.Success)
+
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(lines, check.HasLen, 50)
+	c.Assert(strings.TrimSpace(lines[49]), check.Equals, "hello-50")
+}
+
 // TestLogsOpt tests if log options could work.
 func (suite *PouchLogsSuite) TestLogsOpt(c *check.C) {
 	cname := "TestCLILogs_LogsOpt"
@@ -237,6 +294,49 @@ func (suite *PouchLogsSuite) TestLogsWithDetails(c *check.C) {
 	c.Assert(logs[0], check.Equals, "hello")
 }
 
+// TestLogsWithDriverNotSupportReading tests the logs can't be read from the
+// log driver which doesn't support reading.
+func (suite *PouchLogsSuite) TestLogsWithDriverNotSupportReading(c *check.C) {
+	cname := "TestLogsWithDriverNotSupportReading"
+
+	command.PouchRun("run", "--name", cname,
+		"--log-driver", "none",
+		busyboxImage, "echo", "hello",
+	).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	res := command.PouchRun("logs", cname)
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Contains(res.Stderr(), "log driver none does not support reading"), check.Equals, true, check.Commentf("stderr: %s", res.Stderr()))
+}
+
+// TestLogsWithFluentdDown tests the container isn't blocked by fluentd log
+// driver when fluentd is down.
+func (suite *PouchLogsSuite) TestLogsWithFluentdDown(c *check.C) {
+	cname := "TestLogsWithFluentdDown"
+
+	command.PouchRun("run", "--name", cname,
+		"--log-driver", "fluentd",
+		"--log-opt", "fluentd-address=127.0.0.1:1",
+		"--log-opt", "fluentd-buffer-limit=10",
+		busyboxImage, "sh", "-c", "for i in $(seq 1 100); do echo hello-$i; done",
+	).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	res := command.PouchRun("logs", cname)
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Contains(res.Stderr(), "log driver fluentd does not support reading"), check.Equals, true, check.Commentf("stderr: %s", res.Stderr()))
+
+	cnameOfInvalid := "TestLogsWithFluentdDown_Invalid"
+	res = command.PouchRun("run", "--name", cnameOfInvalid,
+		"--log-driver", "fluentd",
+		"--log-opt", "fluentd-address=udp://127.0.0.1:24224",
+		busyboxImage, "true",
+	)
+	defer DelContainerForceMultyTime(c, cnameOfInvalid)
+	c.Assert(res.ExitCode, check.Equals, 1)
+}
+
 func (suite *PouchLogsSuite) syncLogs(c *check.C, cname string, flags ...string) []string {
 	args := append([]string{"logs"}, flags...)
 
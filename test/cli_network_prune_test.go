@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchNetworkPruneSuite is the test suite for network prune CLI.
+type PouchNetworkPruneSuite struct{}
+
+func init() {
+	check.Suite(&PouchNetworkPruneSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchNetworkPruneSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	PullImage(c, busyboxImage)
+}
+
+// TestNetworkPrune tests "pouch network prune" only removes the networks not
+// used by any container, and the label filters work.
+func (suite *PouchNetworkPruneSuite) TestNetworkPrune(c *check.C) {
+	label := "prune=TestNetworkPrune"
+	unused, used, keep := "TestNetworkPruneUnused", "TestNetworkPruneUsed", "TestNetworkPruneKeep"
+
+	for i, name := range []string{unused, used, keep} {
+		args := []string{"network", "create", "--name", name, "-d", "bridge",
+			"--subnet", fmt.Sprintf("192.168.%d.0/24", 109+i), "--label", label}
+		if name == keep {
+			args = append(args, "--label", "keep")
+		}
+		command.PouchRun(args...).Assert(c, icmd.Success)
+		defer command.PouchRun("network", "rm", name)
+	}
+
+	// the stopped container also uses the network.
+	cname := "TestNetworkPrune"
+	command.PouchRun("create", "--net", used, "--name", cname, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	res := command.PouchRun("network", "prune", "-f", "--filter", "label="+label, "--filter", "label!=keep").Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "Deleted Networks:\n"+unused+"\n")
+
+	c.Assert(command.PouchRun("network", "inspect", unused).ExitCode, check.Not(check.Equals), 0)
+	command.PouchRun("network", "inspect", used).Assert(c, icmd.Success)
+	command.PouchRun("network", "inspect", keep).Assert(c, icmd.Success)
+
+	// the predefined networks are never pruned.
+	command.PouchRun("network", "prune", "-f", "--filter", "until=1h").Assert(c, icmd.Success)
+	for _, name := range []string{"bridge", "host", "none", used} {
+		command.PouchRun("network", "inspect", name).Assert(c, icmd.Success)
+	}
+}
+
+// TestNetworkPruneInvalidFilter tests "pouch network prune" with invalid filter.
+func (suite *PouchNetworkPruneSuite) TestNetworkPruneInvalidFilter(c *check.C) {
+	res := command.PouchRun("network", "prune", "-f", "--filter", "dangling=true")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid filter"), check.IsNil)
+}
+
+// TestNetworkAddressReuse tests the addresses of removed containers are
+// released to the pool, so a small subnet is never exhausted.
+func (suite *PouchNetworkPruneSuite) TestNetworkAddressReuse(c *check.C) {
+	network := "TestNetworkAddressReuse"
+	command.PouchRun("network", "create", "--name", network, "-d", "bridge",
+		"--subnet", "192.168.112.0/29").Assert(c, icmd.Success)
+	defer command.PouchRun("network", "rm", network)
+
+	// the subnet only has 5 addresses for containers.
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("TestNetworkAddressReuse%d", i)
+		command.PouchRun("run", "-d", "--net", network, "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+		command.PouchRun("rm", "-f", name).Assert(c, icmd.Success)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"net"
 	"strings"
 	"time"
 
@@ -368,7 +369,7 @@ func (suite *PouchNetworkSuite) TestNetworkCreateDup(c *check.C) {
 	funcname := "TestNetworkCreateDup"
 
 	expct := icmd.Expected{
-		ExitCode: 1,
+		ExitCode: 3,
 		Err:      "already exist",
 	}
 
@@ -605,3 +606,306 @@ func (suite *PouchNetworkSuite) TestNetworkConnectWithRestart(c *check.C) {
 
 	c.Assert(found, check.Equals, false)
 }
+
+// TestNetworkListFilter tests "pouch network ls" with -q and filters.
+func (suite *PouchNetworkSuite) TestNetworkListFilter(c *check.C) {
+	funcname := "TestNetworkListFilter"
+
+	command.PouchRun("network", "create", "--name", funcname, "-d", "bridge",
+		"--subnet", "192.168.103.0/24", "--gateway", "192.168.103.1", "-l", "app=test").Assert(c, icmd.Success)
+	defer command.PouchRun("network", "remove", funcname)
+
+	id := command.PouchRun("network", "inspect", "-f", "{{.ID}}", funcname).Assert(c, icmd.Success).Stdout()
+
+	res := command.PouchRun("network", "ls", "-q", "--filter", "label=app=test").Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, strings.TrimSpace(id)[:10])
+
+	res = command.PouchRun("network", "ls", "--filter", "type=builtin").Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "bridge"), check.Equals, true)
+	c.Assert(strings.Contains(res.Stdout(), funcname), check.Equals, false)
+
+	res = command.PouchRun("network", "ls", "--filter", "name="+funcname).Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), funcname), check.Equals, true)
+
+	res = command.PouchRun("network", "ls", "--filter", "unknown=foo")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Contains(res.Stderr(), "invalid filter"), check.Equals, true)
+}
+
+// TestNetworkRemovePredefined tests the pre-defined networks can't be removed.
+func (suite *PouchNetworkSuite) TestNetworkRemovePredefined(c *check.C) {
+	for _, name := range []string{"bridge", "host", "none"} {
+		res := command.PouchRun("network", "remove", name)
+		c.Assert(res.ExitCode, check.Equals, 1)
+		c.Assert(strings.Contains(res.Stderr(), "pre-defined network"), check.Equals, true)
+	}
+}
+
+// TestNetworkRemoveInUse tests the network connected by containers can't be
+// removed, and inspect shows the connected containers.
+func (suite *PouchNetworkSuite) TestNetworkRemoveInUse(c *check.C) {
+	funcname := "TestNetworkRemoveInUse"
+
+	command.PouchRun("network", "create", "--name", funcname, "-d", "bridge",
+		"--subnet", "192.168.104.0/24", "--gateway", "192.168.104.1").Assert(c, icmd.Success)
+	defer command.PouchRun("network", "remove", funcname)
+
+	command.PouchRun("run", "-d", "--name", funcname, "--net", funcname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, funcname)
+
+	output := command.PouchRun("network", "inspect", funcname).Assert(c, icmd.Success).Stdout()
+	result := []types.NetworkInspectResp{}
+	c.Assert(json.Unmarshal([]byte(output), &result), check.IsNil)
+	c.Assert(len(result[0].Containers), check.Equals, 1)
+	for _, ep := range result[0].Containers {
+		c.Assert(ep.Name, check.Equals, funcname)
+		c.Assert(strings.HasPrefix(ep.IPV4Address, "192.168.104."), check.Equals, true)
+	}
+
+	res := command.PouchRun("network", "remove", funcname)
+	c.Assert(res.ExitCode, check.Equals, 3)
+	c.Assert(strings.Contains(res.Stderr(), "has connected containers"), check.Equals, true)
+
+	command.PouchRun("rm", "-f", funcname).Assert(c, icmd.Success)
+	command.PouchRun("network", "remove", funcname).Assert(c, icmd.Success)
+}
+
+// TestNetworkCreateOverlapSubnet tests creating network with the subnet
+// overlapping with other network fails with the conflicting network named.
+func (suite *PouchNetworkSuite) TestNetworkCreateOverlapSubnet(c *check.C) {
+	funcname := "TestNetworkCreateOverlapSubnet"
+
+	command.PouchRun("network", "create", "--name", funcname, "-d", "bridge",
+		"--subnet", "192.168.105.0/24", "--gateway", "192.168.105.1").Assert(c, icmd.Success)
+	defer command.PouchRun("network", "remove", funcname)
+
+	res := command.PouchRun("network", "create", "--name", funcname+"2", "-d", "bridge",
+		"--subnet", "192.168.105.128/25", "--gateway", "192.168.105.129")
+	defer command.PouchRun("network", "remove", funcname+"2")
+	c.Assert(res.ExitCode, check.Equals, 3)
+	c.Assert(strings.Contains(res.Stderr(), "of network "+funcname), check.Equals, true)
+}
+
+// TestNetworkConnectWithIPAndAlias tests connecting the stopped and running
+// containers with ip and alias, which is shown in both inspects.
+func (suite *PouchNetworkSuite) TestNetworkConnectWithIPAndAlias(c *check.C) {
+	funcname := "TestNetworkConnectWithIPAndAlias"
+	stopped, running := funcname+"-stopped", funcname+"-running"
+
+	command.PouchRun("network", "create", "--name", funcname, "-d", "bridge",
+		"--subnet", "192.168.106.0/24", "--gateway", "192.168.106.1").Assert(c, icmd.Success)
+	defer command.PouchRun("network", "remove", funcname)
+
+	command.PouchRun("create", "--name", stopped, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, stopped)
+	command.PouchRun("run", "-d", "--name", running, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, running)
+
+	command.PouchRun("network", "connect", "--ip", "192.168.106.10", "--alias", "db", funcname, stopped).Assert(c, icmd.Success)
+	command.PouchRun("network", "connect", "--ip", "192.168.106.11", "--alias", "web", funcname, running).Assert(c, icmd.Success)
+
+	// connecting again fails
+	res := command.PouchRun("network", "connect", funcname, running)
+	c.Assert(res.ExitCode, check.Equals, 3)
+	c.Assert(strings.Contains(res.Stderr(), "already connected"), check.Equals, true)
+
+	// ip and alias are only supported on user defined network
+	res = command.PouchRun("network", "connect", "--alias", "db", "bridge", stopped)
+	c.Assert(res.ExitCode, check.Equals, 1)
+
+	// the stopped container is connected when it starts
+	command.PouchRun("start", stopped).Assert(c, icmd.Success)
+
+	for name, ip := range map[string]string{stopped: "192.168.106.10", running: "192.168.106.11"} {
+		output := command.PouchRun("inspect", name).Assert(c, icmd.Success).Stdout()
+		result := []types.ContainerJSON{}
+		c.Assert(json.Unmarshal([]byte(output), &result), check.IsNil)
+		ep, ok := result[0].NetworkSettings.Networks[funcname]
+		c.Assert(ok, check.Equals, true)
+		c.Assert(ep.IPAddress, check.Equals, ip)
+	}
+
+	output := command.PouchRun("network", "inspect", funcname).Assert(c, icmd.Success).Stdout()
+	result := []types.NetworkInspectResp{}
+	c.Assert(json.Unmarshal([]byte(output), &result), check.IsNil)
+	c.Assert(len(result[0].Containers), check.Equals, 2)
+
+	command.PouchRun("exec", running, "ping", "-c", "1", "db").Assert(c, icmd.Success)
+}
+
+// TestNetworkDisconnectAll tests the container disconnected from its only
+// network has no network connectivity.
+func (suite *PouchNetworkSuite) TestNetworkDisconnectAll(c *check.C) {
+	funcname := "TestNetworkDisconnectAll"
+
+	command.PouchRun("run", "-d", "--name", funcname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, funcname)
+
+	command.PouchRun("network", "disconnect", "bridge", funcname).Assert(c, icmd.Success)
+
+	output := command.PouchRun("exec", funcname, "ip", "-o", "addr").Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.Contains(output, "eth0"), check.Equals, false)
+
+	// disconnecting again fails
+	res := command.PouchRun("network", "disconnect", "bridge", funcname)
+	c.Assert(res.ExitCode, check.Equals, 2)
+
+	// the container still has no network after restart
+	command.PouchRun("restart", "-t", "1", funcname).Assert(c, icmd.Success)
+	output = command.PouchRun("exec", funcname, "ip", "-o", "addr").Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.Contains(output, "eth0"), check.Equals, false)
+}
+
+// TestNetworkEmbeddedDNS tests the containers on the same user defined
+// network resolve each other by name and alias, and the records are removed
+// when the container stops or disconnects.
+func (suite *PouchNetworkSuite) TestNetworkEmbeddedDNS(c *check.C) {
+	funcname := "TestNetworkEmbeddedDNS"
+	server, client := funcname+"-server", funcname+"-client"
+
+	command.PouchRun("network", "create", "--name", funcname, "-d", "bridge",
+		"--subnet", "192.168.107.0/24", "--gateway", "192.168.107.1").Assert(c, icmd.Success)
+	defer command.PouchRun("network", "remove", funcname)
+
+	command.PouchRun("run", "-d", "--name", server, "--net", funcname, "--network-alias", "db", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, server)
+	command.PouchRun("run", "-d", "--name", client, "--net", funcname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, client)
+
+	output := command.PouchRun("exec", client, "cat", "/etc/resolv.conf").Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.Contains(output, "127.0.0.11"), check.Equals, true)
+
+	command.PouchRun("exec", client, "ping", "-c", "1", server).Assert(c, icmd.Success)
+	command.PouchRun("exec", client, "ping", "-c", "1", "db").Assert(c, icmd.Success)
+
+	// alias is only supported on user defined network
+	res := command.PouchRun("run", "-d", "--name", funcname, "--network-alias", "db", busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, funcname)
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Contains(res.Stderr(), "user defined networks"), check.Equals, true)
+
+	// the records are removed once the container stops
+	command.PouchRun("stop", "-t", "1", server).Assert(c, icmd.Success)
+	res = command.PouchRun("exec", client, "nslookup", "db")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+
+	// the records are removed once the container disconnects
+	command.PouchRun("start", server).Assert(c, icmd.Success)
+	command.PouchRun("exec", client, "ping", "-c", "1", "db").Assert(c, icmd.Success)
+	command.PouchRun("network", "disconnect", funcname, server).Assert(c, icmd.Success)
+	res = command.PouchRun("exec", client, "nslookup", server)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+}
+
+// TestNetworkMacvlan tests creating macvlan network on the parent interface,
+// and the container gets the address from the ip range.
+func (suite *PouchNetworkSuite) TestNetworkMacvlan(c *check.C) {
+	funcname := "TestNetworkMacvlan"
+	parent := "pouchdummy0"
+
+	la := netlink.NewLinkAttrs()
+	la.Name = parent
+	c.Assert(netlink.LinkAdd(&netlink.Dummy{LinkAttrs: la}), check.IsNil)
+	defer netlink.LinkDel(&netlink.Dummy{LinkAttrs: la})
+
+	// subnet and gateway are required
+	res := command.PouchRun("network", "create", "-d", "macvlan", "-o", "parent="+parent, funcname)
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Contains(res.Stderr(), "subnet and gateway are required"), check.Equals, true)
+
+	// the parent interface must exist
+	res = command.PouchRun("network", "create", "-d", "macvlan", "--subnet", "192.168.108.0/24",
+		"--gateway", "192.168.108.1", "-o", "parent=nonexist0", funcname)
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Contains(res.Stderr(), "doesn't exist"), check.Equals, true)
+
+	command.PouchRun("network", "create", "-d", "macvlan", "--subnet", "192.168.108.0/24",
+		"--gateway", "192.168.108.1", "--ip-range", "192.168.108.128/25",
+		"-o", "parent="+parent, funcname).Assert(c, icmd.Success)
+	defer command.PouchRun("network", "remove", funcname)
+
+	command.PouchRun("run", "-d", "--name", funcname, "--net", funcname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, funcname)
+
+	output := command.PouchRun("inspect", "-f", "{{.NetworkSettings.Networks."+funcname+".IPAddress}}", funcname).Assert(c, icmd.Success).Stdout()
+	ip := net.ParseIP(strings.TrimSpace(output))
+	_, ipRange, _ := net.ParseCIDR("192.168.108.128/25")
+	c.Assert(ipRange.Contains(ip), check.Equals, true)
+
+	// the endpoint is removed after stop
+	command.PouchRun("stop", "-t", "1", funcname).Assert(c, icmd.Success)
+	output = command.PouchRun("network", "inspect", funcname).Assert(c, icmd.Success).Stdout()
+	result := []types.NetworkInspectResp{}
+	c.Assert(json.Unmarshal([]byte(output), &result), check.IsNil)
+	c.Assert(len(result[0].Containers), check.Equals, 0)
+}
+
+// TestNetworkHost tests host network is listed and inspected as the other
+// networks, and only one host network is allowed.
+func (suite *PouchNetworkSuite) TestNetworkHost(c *check.C) {
+	funcname := "TestNetworkHost"
+
+	output := command.PouchRun("network", "ls", "--filter", "driver=host").Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.Contains(output, "host"), check.Equals, true)
+
+	command.PouchRun("run", "-d", "--name", funcname, "--net", "host", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, funcname)
+
+	output = command.PouchRun("network", "inspect", "host").Assert(c, icmd.Success).Stdout()
+	result := []types.NetworkInspectResp{}
+	c.Assert(json.Unmarshal([]byte(output), &result), check.IsNil)
+	c.Assert(result[0].Driver, check.Equals, "host")
+	found := false
+	for _, ep := range result[0].Containers {
+		if ep.Name == funcname {
+			found = true
+		}
+	}
+	c.Assert(found, check.Equals, true)
+
+	res := command.PouchRun("network", "create", "-d", "host", funcname)
+	defer command.PouchRun("network", "remove", funcname)
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Contains(res.Stderr(), "only one network of host driver"), check.Equals, true)
+}
+
+// TestNetworkStaticAddress tests the static ip and mac address of container
+// are validated, kept across restarts and conflicts are reported.
+func (suite *PouchNetworkSuite) TestNetworkStaticAddress(c *check.C) {
+	funcname := "TestNetworkStaticAddress"
+	first, second := funcname+"-1", funcname+"-2"
+	ip, mac := "192.168.113.10", "02:42:c0:a8:71:0a"
+
+	command.PouchRun("network", "create", "--name", funcname, "-d", "bridge",
+		"--subnet", "192.168.113.0/24", "--gateway", "192.168.113.1").Assert(c, icmd.Success)
+	defer command.PouchRun("network", "remove", funcname)
+
+	// the address must be in the subnet and not be the gateway.
+	for _, invalid := range []string{"10.0.0.10", "192.168.113.1"} {
+		res := command.PouchRun("create", "--net", funcname, "--ip", invalid, busyboxImage, "top")
+		c.Assert(res.ExitCode, check.Equals, 1)
+	}
+	res := command.PouchRun("create", "--ip", ip, busyboxImage, "top")
+	c.Assert(res.ExitCode, check.Equals, 1)
+
+	command.PouchRun("run", "-d", "--name", first, "--net", funcname, "--ip", ip,
+		"--mac-address", mac, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, first)
+	command.PouchRun("restart", "-t", "1", first).Assert(c, icmd.Success)
+
+	output := command.PouchRun("inspect", first).Assert(c, icmd.Success).Stdout()
+	result := []types.ContainerJSON{}
+	c.Assert(json.Unmarshal([]byte(output), &result), check.IsNil)
+	ep := result[0].NetworkSettings.Networks[funcname]
+	c.Assert(ep, check.NotNil)
+	c.Assert(ep.IPAMConfig.IPV4Address, check.Equals, ip)
+	c.Assert(ep.IPAddress, check.Equals, ip)
+	c.Assert(ep.MacAddress, check.Equals, mac)
+
+	// the address held by another container can't be used.
+	command.PouchRun("create", "--name", second, "--net", funcname, "--ip", ip, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, second)
+	res = command.PouchRun("start", second)
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Contains(res.Stderr(), "already in use by container "+first), check.Equals, true)
+}
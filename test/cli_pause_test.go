@@ -91,3 +91,44 @@ func (suite *PouchPauseSuite) TestPauseInWrongWay(c *check.C) {
 		}
 	}
 }
+
+// TestPausedContainerStatus tests the paused container shows status Paused,
+// refuses exec, and can be stopped.
+func (suite *PouchPauseSuite) TestPausedContainerStatus(c *check.C) {
+	name := "TestPausedContainerStatus"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("pause", name).Assert(c, icmd.Success)
+
+	status, err := inspectFilter(name, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "paused")
+
+	res := command.PouchRun("ps", "--format", "{{.Names}} {{.Status}}").Assert(c, icmd.Success)
+	if out := res.Stdout(); !strings.Contains(out, name+" Up") || !strings.Contains(out, "(Paused)") {
+		c.Fatalf("expected container %s to be paused in ps, but got %s", name, out)
+	}
+
+	res = command.PouchRun("exec", name, "echo", "hi")
+	c.Assert(res.Error, check.NotNil)
+	if out := res.Combined(); !strings.Contains(out, "is paused") {
+		c.Fatalf("expected exec to fail with paused container, but got %s", out)
+	}
+
+	// unpause a not paused container names the current state
+	command.PouchRun("unpause", name).Assert(c, icmd.Success)
+	res = command.PouchRun("unpause", name)
+	c.Assert(res.Error, check.NotNil)
+	if out := res.Combined(); !strings.Contains(out, "status(running)") {
+		c.Fatalf("expected unpause to fail with the state, but got %s", out)
+	}
+
+	// stop unpauses the container first
+	command.PouchRun("pause", name).Assert(c, icmd.Success)
+	command.PouchRun("stop", "-t", "1", name).Assert(c, icmd.Success)
+
+	status, err = inspectFilter(name, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "stopped")
+}
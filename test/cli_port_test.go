@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchPortSuite is the test suite for port CLI.
+type PouchPortSuite struct{}
+
+func init() {
+	check.Suite(&PouchPortSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchPortSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchPortSuite) TearDownTest(c *check.C) {
+}
+
+// TestPort tests the port mappings of container are listed by port and ps.
+func (suite *PouchPortSuite) TestPort(c *check.C) {
+	name := "TestPort"
+
+	command.PouchRun("run", "-d", "--name", name,
+		"-p", "18080:80",
+		"-p", "127.0.0.1:18443:443/tcp",
+		"-p", "18000-18001:8000-8001",
+		busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("port", name)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, strings.Join([]string{
+		"443/tcp -> 127.0.0.1:18443",
+		"80/tcp -> 0.0.0.0:18080",
+		"8000/tcp -> 0.0.0.0:18000",
+		"8001/tcp -> 0.0.0.0:18001",
+	}, "\n"))
+
+	res = command.PouchRun("port", name, "443")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "127.0.0.1:18443")
+
+	res = command.PouchRun("port", name, "80/udp")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*no public port '80/udp' published.*")
+
+	res = command.PouchRun("ps", "--format", "{{.Ports}}", "--filter", "name="+name)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "127.0.0.1:18443->443/tcp"), check.Equals, true, check.Commentf(res.Stdout()))
+
+	// the ports are released after the container is stopped.
+	command.PouchRun("stop", "-t", "1", name).Assert(c, icmd.Success)
+	res = command.PouchRun("port", name)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "")
+}
+
+// TestPortPublishAll tests the exposed ports are published to random host
+// ports by --publish-all.
+func (suite *PouchPortSuite) TestPortPublishAll(c *check.C) {
+	name := "TestPortPublishAll"
+
+	command.PouchRun("run", "-d", "--name", name, "--expose", "80", "-P",
+		busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("port", name, "80")
+	res.Assert(c, icmd.Success)
+
+	addr := strings.TrimSpace(res.Stdout())
+	c.Assert(strings.HasPrefix(addr, "0.0.0.0:"), check.Equals, true, check.Commentf(addr))
+	c.Assert(addr, check.Not(check.Equals), "0.0.0.0:")
+
+	res = command.PouchRun("ps", "--format", "{{.Ports}}", "--filter", "name="+name)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), addr+"->80/tcp"), check.Equals, true, check.Commentf(res.Stdout()))
+}
+
+// TestPortAlreadyAllocated tests the container fails to start if its host
+// port is used by another container.
+func (suite *PouchPortSuite) TestPortAlreadyAllocated(c *check.C) {
+	name1 := "TestPortAlreadyAllocated1"
+	name2 := "TestPortAlreadyAllocated2"
+
+	command.PouchRun("run", "-d", "--name", name1, "-p", "18090:80", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name1)
+
+	command.PouchRun("create", "--name", name2, "-p", "18090:80", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name2)
+
+	res := command.PouchRun("start", name2)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*18090.*")
+}
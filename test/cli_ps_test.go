@@ -256,50 +256,121 @@ func (suite *PouchPsSuite) TestPsNoTrunc(c *check.C) {
 	c.Assert(kv[name].id, check.Equals, containerID)
 }
 
+// TestPsLast tests "pouch ps --last" and "pouch ps --latest" work.
+func (suite *PouchPsSuite) TestPsLast(c *check.C) {
+	names := []string{"ps-last-1", "ps-last-2", "ps-last-3"}
+	for _, name := range names {
+		command.PouchRun("create", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+		defer DelContainerForceMultyTime(c, name)
+	}
+
+	// the non-running containers are also shown
+	res := command.PouchRun("ps", "--last", "2", "--format", "{{.Names}}").Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, names[2]+"\n"+names[1])
+
+	res = command.PouchRun("ps", "-l", "--format", "{{.Names}}").Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, names[2])
+
+	res = command.PouchRun("ps", "-n", "-1")
+	c.Assert(res.Error, check.NotNil)
+}
+
+// TestPsFormat tests "pouch ps --format" work.
+func (suite *PouchPsSuite) TestPsFormat(c *check.C) {
+	name := "ps-format"
+
+	command.PouchRun("run", "-d", "--name", name, "-l", "team=infra", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	containerID, err := inspectFilter(name, ".ID")
+	c.Assert(err, check.IsNil)
+
+	res := command.PouchRun("ps", "-f", "name="+name, "--no-trunc",
+		"--format", `{{.ID}}\t{{.Command}}\t{{index .Labels "team"}}`).Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, containerID+"\t\"top\"\tinfra")
+
+	// the default table
+	res = command.PouchRun("ps", "-f", "name="+name).Assert(c, icmd.Success)
+	kv := psToKV(res.Combined())
+	c.Assert(kv[name].id, check.Equals, containerID[:6])
+	c.Assert(kv[name].command, check.Equals, `"top"`)
+	c.Assert(kv[name].created[len(kv[name].created)-1], check.Equals, "ago")
+
+	// invalid template
+	res = command.PouchRun("ps", "--format", "{{.Unknown}}")
+	c.Assert(res.Error, check.NotNil)
+}
+
+// TestPsTableFormat tests the template prefixed with "table" prints the
+// headers, and the color codes never leak into the piped output.
+func (suite *PouchPsSuite) TestPsTableFormat(c *check.C) {
+	name := "ps-table-format"
+
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("ps", "-f", "name="+name, "--format", `table {{.Names}}\t{{.Status}}`).Assert(c, icmd.Success)
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(lines, check.HasLen, 2)
+	c.Assert(strings.Fields(lines[0]), check.DeepEquals, []string{"NAMES", "STATUS"})
+	c.Assert(strings.HasPrefix(lines[1], name+"   Up "), check.Equals, true, check.Commentf(lines[1]))
+
+	// stdout is piped, so the status isn't colorized.
+	res = command.PouchRun("ps", "-f", "name="+name).Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "\x1b["), check.Equals, false)
+}
+
 // psTable represents the table of "pouch ps" result.
 type psTable struct {
 	id      string
-	name    string
-	status  []string
-	created []string
 	image   string
-	runtime string
+	command string
+	created []string
+	status  []string
+	ports   string
+	name    string
 }
 
 // psToKV parse "pouch ps" into key-value mapping.
 func psToKV(ps string) map[string]psTable {
-	// skip header
-	lines := strings.Split(ps, "\n")[1:]
+	lines := strings.Split(ps, "\n")
+
+	// the columns are aligned with the header, so the offsets of header
+	// fields are used to split the row.
+	var offsets []int
+	for _, field := range []string{"CONTAINER ID", "IMAGE", "COMMAND", "CREATED", "STATUS", "PORTS", "NAMES"} {
+		offsets = append(offsets, len([]rune(lines[0][:strings.Index(lines[0], field)])))
+	}
+	offsets = append(offsets, -1)
 
 	res := make(map[string]psTable)
-	for _, line := range lines {
+	for _, line := range lines[1:] {
 		if strings.TrimSpace(line) == "" {
 			continue
 		}
 
-		items := strings.Fields(line)
-
-		pst := psTable{}
-		pst.name = items[0]
-		pst.id = items[1]
-
-		if items[2] == "Up" {
-			pst.status = items[2:5]
-			pst.created = items[5:8]
-			pst.image = items[8]
-			pst.runtime = items[9]
-		} else if items[2] == "Stopped" || items[2] == "Exited" {
-			pst.status = items[2:6]
-			pst.created = items[6:9]
-			pst.image = items[9]
-			pst.runtime = items[10]
-		} else {
-			pst.status = items[2:3]
-			pst.created = items[3:6]
-			pst.image = items[6]
-			pst.runtime = items[7]
+		row := []rune(line)
+		column := func(i int) string {
+			begin, end := offsets[i], offsets[i+1]
+			if end == -1 || end > len(row) {
+				end = len(row)
+			}
+			if begin > len(row) {
+				return ""
+			}
+			return strings.TrimSpace(string(row[begin:end]))
+		}
+
+		pst := psTable{
+			id:      column(0),
+			image:   column(1),
+			command: column(2),
+			created: strings.Fields(column(3)),
+			status:  strings.Fields(column(4)),
+			ports:   column(5),
+			name:    column(6),
 		}
-		res[items[0]] = pst
+		res[pst.name] = pst
 	}
 	return res
 }
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
 
 	"github.com/go-check/check"
 	"github.com/gotestyourself/gotestyourself/icmd"
@@ -59,6 +63,29 @@ func (suite *PouchPullSuite) TestPullWorks(c *check.C) {
 	checkPull(busyboxDigestWithWrongTag, busyboxDigest)
 }
 
+// TestPullQuiet tests "pouch pull -q" only prints the image reference.
+func (suite *PouchPullSuite) TestPullQuiet(c *check.C) {
+	latest := environment.BusyboxRepo + ":latest"
+
+	res := command.PouchRun("pull", "-q", environment.BusyboxRepo).Assert(c, icmd.Success)
+	defer command.PouchRun("rmi", "-f", latest)
+
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, latest)
+
+	// pull unknown image should fail in quiet mode
+	command.PouchRun("pull", "-q", "unknown").Assert(c, icmd.Expected{ExitCode: 1})
+}
+
+// TestPullAllTagsWithTag tests "pouch pull -a" refuses the reference with tag.
+func (suite *PouchPullSuite) TestPullAllTagsWithTag(c *check.C) {
+	res := command.PouchRun("pull", "-a", environment.BusyboxRepo+":latest")
+	c.Assert(res.Error, check.NotNil)
+
+	if out := res.Combined(); !strings.Contains(out, "can't be used with --all-tags") {
+		c.Fatalf("unexpected output %s: should reject the reference with tag", out)
+	}
+}
+
 // TestPullInWrongWay pulls in wrong way.
 func (suite *PouchPullSuite) TestPullInWrongWay(c *check.C) {
 	// pull unknown images
@@ -72,4 +99,65 @@ func (suite *PouchPullSuite) TestPullInWrongWay(c *check.C) {
 		res := command.PouchRun("pull", busyboxImage, "-f")
 		c.Assert(res.Stderr(), check.NotNil)
 	}
+
+	// pull with uppercase repository name
+	{
+		res := command.PouchRun("pull", "BusyBox")
+		res.Assert(c, icmd.Expected{ExitCode: 1})
+		c.Assert(util.PartialEqual(res.Stderr(), "repository name must be lowercase"), check.IsNil)
+	}
+}
+
+// TestPullBareName tests the bare name is expanded by the default registry
+// and namespace of daemon.
+func (suite *PouchPullSuite) TestPullBareName(c *check.C) {
+	res := command.PouchRun("pull", "-q", "busybox")
+	res.Assert(c, icmd.Success)
+	defer command.PouchRun("rmi", "-f", "busybox:latest")
+
+	command.PouchRun("image", "inspect", "busybox").Assert(c, icmd.Success)
+}
+
+// TestPullCancelled tests Ctrl-C cancels the pull, and the image can be pulled again.
+func (suite *PouchPullSuite) TestPullCancelled(c *check.C) {
+	image := environment.HttpdRepo + ":" + environment.HttpdTag
+	command.PouchRun("rmi", "-f", image)
+
+	res := icmd.StartCmd(command.PouchCmd("pull", image))
+	c.Assert(res.Error, check.IsNil)
+
+	// wait for downloading layers
+	time.Sleep(2 * time.Second)
+	c.Assert(res.Cmd.Process.Signal(os.Interrupt), check.IsNil)
+
+	res = icmd.WaitOnCmd(10*time.Second, res)
+	res.Assert(c, icmd.Expected{ExitCode: 130})
+	c.Assert(util.PartialEqual(res.Stderr(), "pull cancelled"), check.IsNil)
+
+	// the cancelled pull should not block the next one
+	command.PouchRun("pull", image).Assert(c, icmd.Success)
+	command.PouchRun("rmi", "-f", image).Assert(c, icmd.Success)
+}
+
+// TestPullWithPlatform tests "pouch pull --platform" pulls the manifest of the platform.
+func (suite *PouchPullSuite) TestPullWithPlatform(c *check.C) {
+	latest := environment.BusyboxRepo + ":latest"
+
+	command.PouchRun("pull", "-q", "--platform", "linux/arm64", latest).Assert(c, icmd.Success)
+	defer command.PouchRun("rmi", "-f", latest)
+
+	image, err := apiClient.ImageInspect(context.Background(), latest)
+	c.Assert(err, check.IsNil)
+	c.Assert(image.Os, check.Equals, "linux")
+	c.Assert(image.Architecture, check.Equals, "arm64")
+
+	// the platform missing in manifest list should fail
+	res := command.PouchRun("pull", "--platform", "windows/amd64", latest)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Combined(), "available platforms"), check.IsNil)
+
+	// invalid platform should fail
+	res = command.PouchRun("pull", "--platform", "linux/arm64/v8/x", latest)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Combined(), "invalid platform"), check.IsNil)
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchPushSuite is the test suite for push CLI.
+type PouchPushSuite struct{}
+
+func init() {
+	check.Suite(&PouchPushSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchPushSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+}
+
+// TestPushNotExistImage tests pushing the image which doesn't exist locally.
+func (suite *PouchPushSuite) TestPushNotExistImage(c *check.C) {
+	res := command.PouchRun("push", "localhost:5000/pouch/notexist:latest")
+	res.Assert(c, icmd.Expected{ExitCode: 1})
+
+	if out := res.Combined(); !strings.Contains(out, "not found") {
+		c.Fatalf("unexpected output %s: should report image not found", out)
+	}
+}
+
+// TestPushDigestReference tests pushing the digest reference should fail.
+func (suite *PouchPushSuite) TestPushDigestReference(c *check.C) {
+	res := command.PouchRun("push", environment.BusyboxRepo+"@"+environment.BusyboxDigest)
+	c.Assert(res.Error, check.NotNil)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchRenameSuite is the test suite for rename CLI.
+type PouchRenameSuite struct{}
+
+func init() {
+	check.Suite(&PouchRenameSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchRenameSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchRenameSuite) TearDownTest(c *check.C) {
+}
+
+// TestRenameRunningContainer tests renaming a running container keeps it
+// running, and only the new name can be used.
+func (suite *PouchRenameSuite) TestRenameRunningContainer(c *check.C) {
+	oldName := "TestRenameRunningContainer"
+	newName := "TestRenameRunningContainerNew"
+
+	res := command.PouchRun("run", "-d", "--name", oldName, busyboxImage, "top").Assert(c, icmd.Success)
+	id := strings.TrimSpace(res.Stdout())
+	defer DelContainerForceMultyTime(c, id)
+
+	command.PouchRun("rename", oldName, newName).Assert(c, icmd.Success)
+
+	command.PouchRun("inspect", "--type", "container", oldName).Assert(c, icmd.Expected{ExitCode: 2})
+
+	status, err := inspectFilter(newName, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "running")
+
+	command.PouchRun("exec", newName, "echo", "hi").Assert(c, icmd.Success)
+}
+
+// TestRenameInWrongWay tests the invalid and conflicting names are refused.
+func (suite *PouchRenameSuite) TestRenameInWrongWay(c *check.C) {
+	name := "TestRenameInWrongWay"
+	holder := "TestRenameInWrongWayHolder"
+
+	command.PouchRun("create", "--name", name, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("create", "--name", holder, busyboxImage).Assert(c, icmd.Success)
+	holderID := strings.TrimSpace(res.Stdout())
+	defer DelContainerForceMultyTime(c, holder)
+
+	// name conflict reports the ID of container holding the name
+	res = command.PouchRun("rename", name, holder)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "is already in use by container"), check.IsNil)
+	c.Assert(util.PartialEqual(res.Stderr(), holderID), check.IsNil)
+
+	for _, invalid := range []string{"-foo", "foo/bar", "foo bar"} {
+		res = command.PouchRun("rename", name, invalid)
+		c.Assert(res.Error, check.NotNil)
+		c.Assert(util.PartialEqual(res.Stderr(), "invalid container name"), check.IsNil)
+	}
+
+	command.PouchRun("rename", "unknown", "foo").Assert(c, icmd.Expected{ExitCode: 2, Err: "not found"})
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"strings"
+	"time"
 
 	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
@@ -118,3 +119,21 @@ func (suite *PouchRestartSuite) TestPouchRestartMultiContainers(c *check.C) {
 		c.Fatalf("unexpected output: %s, expected: %s\n%s", out, containernames[0], containernames[1])
 	}
 }
+
+// TestPouchRestartAutoRemoveContainer is to verify the container with rm flag isn't removed by restart.
+func (suite *PouchRestartSuite) TestPouchRestartAutoRemoveContainer(c *check.C) {
+	name := "TestPouchRestartAutoRemoveContainer"
+
+	res := command.PouchRun("run", "-d", "--rm", "--name", name, busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	command.PouchRun("restart", "-t", "1", name).Assert(c, icmd.Success)
+
+	// the removal would be done in background, so wait for a while.
+	time.Sleep(time.Second)
+
+	running, err := inspectFilter(name, ".State.Running")
+	c.Assert(err, check.IsNil)
+	c.Assert(running, check.Equals, "true")
+}
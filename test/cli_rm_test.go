@@ -5,6 +5,7 @@ import (
 
 	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
 
 	"github.com/go-check/check"
 	"github.com/gotestyourself/gotestyourself/icmd"
@@ -66,3 +67,57 @@ func (suite *PouchRmSuite) TestContainerRmWithVolume(c *check.C) {
 	c.Assert(volumeNums, check.Equals, expectVolumeNums+1)
 	c.Assert(found, check.Equals, true)
 }
+
+// TestContainerRmRunning tests removing a running container needs flag force.
+func (suite *PouchRmSuite) TestContainerRmRunning(c *check.C) {
+	containerName := "rmRunning-test"
+
+	command.PouchRun("run", "-d", "--name", containerName, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, containerName)
+
+	res := command.PouchRun("rm", containerName)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "is running, cannot remove it without flag force"), check.IsNil)
+
+	res = command.PouchRun("rm", "-f", containerName).Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, containerName)
+
+	res = command.PouchRun("inspect", containerName)
+	c.Assert(util.PartialEqual(res.Stderr(), "not found"), check.IsNil)
+}
+
+// TestContainerRmByIDPrefix tests removing the container by ID and ID prefix.
+func (suite *PouchRmSuite) TestContainerRmByIDPrefix(c *check.C) {
+	for _, name := range []string{"rmByID-test", "rmByPrefix-test"} {
+		res := command.PouchRun("create", "--name", name, busyboxImage)
+		defer DelContainerForceMultyTime(c, name)
+		res.Assert(c, icmd.Success)
+
+		id := strings.TrimSpace(res.Stdout())
+		if name == "rmByPrefix-test" {
+			id = id[:12]
+		}
+
+		command.PouchRun("rm", id).Assert(c, icmd.Success)
+
+		res = command.PouchRun("inspect", name)
+		c.Assert(util.PartialEqual(res.Stderr(), "not found"), check.IsNil)
+	}
+}
+
+// TestContainerRmSharingNamespaces tests removing a container whose
+// namespaces are joined by other containers needs flag force.
+func (suite *PouchRmSuite) TestContainerRmSharingNamespaces(c *check.C) {
+	target, sharer := "rmSharingNamespaces-target", "rmSharingNamespaces-sharer"
+
+	command.PouchRun("create", "--name", target, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, target)
+	command.PouchRun("create", "--name", sharer, "--pid", "container:"+target, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, sharer)
+
+	res := command.PouchRun("rm", target)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "is sharing namespaces with containers ["+sharer+"]"), check.IsNil)
+
+	command.PouchRun("rm", "-f", target).Assert(c, icmd.Success)
+}
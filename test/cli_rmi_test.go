@@ -118,3 +118,37 @@ func (suite *PouchRmiSuite) TestRmiInWrongWay(c *check.C) {
 		c.Assert(res.Stderr(), check.NotNil, check.Commentf(tc.name))
 	}
 }
+
+// TestRmiOutput tests "pouch rmi" prints the untagged and deleted information.
+func (suite *PouchRmiSuite) TestRmiOutput(c *check.C) {
+	alias := "localhost:5000/testrmioutput:latest"
+
+	command.PouchRun("pull", helloworldImage).Assert(c, icmd.Success)
+	command.PouchRun("tag", helloworldImage, alias).Assert(c, icmd.Success)
+
+	// remove the alias should only untag
+	out := command.PouchRun("rmi", alias).Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.TrimSpace(out), check.Equals, "Untagged: "+alias)
+
+	// remove the final reference should delete the image
+	out = command.PouchRun("rmi", helloworldImage).Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.Contains(out, "Untagged: "+helloworldImage), check.Equals, true)
+	c.Assert(strings.Contains(out, "Deleted: sha256:"), check.Equals, true)
+}
+
+// TestRmiUsedByContainers tests "pouch rmi" lists the containers using the image.
+func (suite *PouchRmiSuite) TestRmiUsedByContainers(c *check.C) {
+	name := "TestRmiUsedByContainers"
+
+	command.PouchRun("pull", helloworldImage).Assert(c, icmd.Success)
+	command.PouchRun("create", "--name", name, helloworldImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("rmi", helloworldImage)
+	c.Assert(res.Error, check.NotNil)
+	if out := res.Combined(); !strings.Contains(out, name) {
+		c.Fatalf("unexpected output %s: should list the container %s", out, name)
+	}
+
+	command.PouchRun("rmi", "-f", helloworldImage).Assert(c, icmd.Success)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/test/command"
@@ -91,3 +92,39 @@ func (suite *PouchRunCPUSuite) TestRunWithCPULimit(c *check.C) {
 		checkFileContains(c, path, "1000")
 	}
 }
+
+// TestRunWithCPUs tests --cpus is converted into CFS quota and period.
+func (suite *PouchRunCPUSuite) TestRunWithCPUs(c *check.C) {
+	cname := "TestRunWithCPUs"
+	command.PouchRun("run", "-d", "--cpus", "0.5", "--name", cname,
+		busyboxImage, "sleep", "10000").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	output := command.PouchRun("inspect", "-f", "{{.HostConfig.NanoCpus}}", cname).Stdout()
+	c.Assert(strings.TrimSpace(output), check.Equals, "500000000")
+
+	containerID := command.PouchRun("inspect", "-f", "{{.ID}}", cname).Stdout()
+	{
+		path := fmt.Sprintf(
+			"/sys/fs/cgroup/cpu/default/%s/cpu.cfs_period_us", strings.TrimSpace(containerID))
+		checkFileContains(c, path, "100000")
+	}
+	{
+		path := fmt.Sprintf(
+			"/sys/fs/cgroup/cpu/default/%s/cpu.cfs_quota_us", strings.TrimSpace(containerID))
+		checkFileContains(c, path, "50000")
+	}
+}
+
+// TestRunWithCPUsConflict tests --cpus can't be used with --cpu-quota and --cpu-period.
+func (suite *PouchRunCPUSuite) TestRunWithCPUsConflict(c *check.C) {
+	cname := "TestRunWithCPUsConflict"
+	res := command.PouchRun("run", "-d", "--cpus", "1", "--cpu-quota", "50000",
+		"--name", cname, busyboxImage, "sleep", "10000")
+	defer DelContainerForceMultyTime(c, cname)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*Conflicting options.*")
+
+	res = command.PouchRun("run", "-d", "--cpus", "0.0001", busyboxImage, "sleep", "10000")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+}
@@ -209,3 +209,40 @@ func (suite *PouchRunDeviceSuite) TestRunDeviceReadWriteBpsIops(c *check.C) {
 	path = fmt.Sprintf("%s/%s/blkio.throttle.write_iops_device", commonDir, containerID)
 	checkFileContains(c, path, "1000")
 }
+
+// TestCreateDeviceNotExist tests creating container with nonexistent host
+// device fails and the device path is reported.
+func (suite *PouchRunDeviceSuite) TestCreateDeviceNotExist(c *check.C) {
+	name := "TestCreateDeviceNotExist"
+
+	res := command.PouchRun("create", "--name", name, "--device", "/dev/nonexistent:/dev/foo", busyboxImage)
+	defer DelContainerForceMultyTime(c, name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*/dev/nonexistent.*")
+
+	res = command.PouchRun("create", "--name", name, "--device-read-bps", "/dev/nonexistent:1mb", busyboxImage)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*/dev/nonexistent.*")
+}
+
+// TestRunDeviceCgroupRule tests --device-cgroup-rule is added into the
+// devices cgroup of container.
+func (suite *PouchRunDeviceSuite) TestRunDeviceCgroupRule(c *check.C) {
+	name := "TestRunDeviceCgroupRule"
+
+	command.PouchRun("run", "-d", "--name", name,
+		"--device-cgroup-rule", "c 7:* rwm",
+		busyboxImage, "sleep", "10000").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	output := command.PouchRun("inspect", "-f", "{{.HostConfig.DeviceCgroupRules}}", name).Stdout()
+	c.Assert(strings.TrimSpace(output), check.Equals, "[c 7:* rwm]")
+
+	containerID := strings.TrimSpace(command.PouchRun("inspect", "-f", "{{.ID}}", name).Stdout())
+	path := fmt.Sprintf("/sys/fs/cgroup/devices/default/%s/devices.list", containerID)
+	checkFileContains(c, path, "c 7:* rwm")
+
+	res := command.PouchRun("run", "--device-cgroup-rule", "c 7 rwm", busyboxImage, "true")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*invalid device cgroup rule.*")
+}
@@ -135,3 +135,53 @@ func (suite *PouchRunDNSSuite) TestRunWithDNSRepeatFlags(c *check.C) {
 	c.Assert(err, check.IsNil)
 	c.Assert(dnsSearch, check.Equals, "[mydomain mydomain2]")
 }
+
+// TestRunWithHostsFlags tests the hostname and extra hosts are written into
+// /etc/hostname and /etc/hosts of container.
+func (suite *PouchRunDNSSuite) TestRunWithHostsFlags(c *check.C) {
+	cname := "TestRunWithHostsFlags"
+
+	res := command.PouchRun("run", "-d", "--name", cname,
+		"--hostname", "myhost",
+		"--add-host", "foo:1.2.3.4",
+		"--add-host", "ipv6:2001:db8::1",
+		busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+
+	res = command.PouchRun("exec", cname, "cat", "/etc/hostname")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "myhost")
+
+	ip, err := inspectFilter(cname, ".NetworkSettings.Networks.bridge.IPAddress")
+	c.Assert(err, check.IsNil)
+
+	res = command.PouchRun("exec", cname, "cat", "/etc/hosts")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "1.2.3.4\tfoo"), check.Equals, true, check.Commentf(res.Stdout()))
+	c.Assert(strings.Contains(res.Stdout(), "2001:db8::1\tipv6"), check.Equals, true, check.Commentf(res.Stdout()))
+	c.Assert(strings.Contains(res.Stdout(), ip+"\tmyhost"), check.Equals, true, check.Commentf(res.Stdout()))
+
+	// test if the value is in inspect result
+	hostname, err := inspectFilter(cname, ".Config.Hostname")
+	c.Assert(err, check.IsNil)
+	c.Assert(hostname, check.Equals, "myhost")
+
+	extraHosts, err := inspectFilter(cname, ".HostConfig.ExtraHosts")
+	c.Assert(err, check.IsNil)
+	c.Assert(extraHosts, check.Equals, "[foo:1.2.3.4 ipv6:2001:db8::1]")
+}
+
+// TestCreateWithInvalidHostsFlags tests the invalid ip of extra hosts and dns
+// are rejected.
+func (suite *PouchRunDNSSuite) TestCreateWithInvalidHostsFlags(c *check.C) {
+	for _, args := range [][]string{
+		{"--add-host", "foo:1.2.3"},
+		{"--add-host", "foo"},
+		{"--dns", "example.com"},
+	} {
+		res := command.PouchRun(append(append([]string{"create"}, args...), busyboxImage)...)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0, check.Commentf("%v", args))
+		c.Assert(res.Stderr(), check.Matches, "(?s).*invalid.*", check.Commentf("%v", args))
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchRunHealthcheckSuite is the test suite for healthcheck of run CLI.
+type PouchRunHealthcheckSuite struct{}
+
+func init() {
+	check.Suite(&PouchRunHealthcheckSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchRunHealthcheckSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchRunHealthcheckSuite) TearDownTest(c *check.C) {
+}
+
+// waitHealthStatus waits until the health status of container is expected.
+func waitHealthStatus(c *check.C, name, expected string) {
+	var status string
+	for i := 0; i < 50; i++ {
+		var err error
+		status, err = inspectFilter(name, ".State.Health.Status")
+		c.Assert(err, check.IsNil)
+		if status == expected {
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	c.Fatalf("expected health status %s of container %s, got %s", expected, name, status)
+}
+
+// TestRunHealthcheck tests the transitions of health status.
+func (suite *PouchRunHealthcheckSuite) TestRunHealthcheck(c *check.C) {
+	name := "TestRunHealthcheck"
+
+	command.PouchRun("run", "-d", "--name", name,
+		"--health-cmd", "cat /tmp/healthy",
+		"--health-interval", "200ms",
+		"--health-retries", "2",
+		busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	waitHealthStatus(c, name, "unhealthy")
+	res := command.PouchRun("ps", "-a").Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "(unhealthy)"), check.Equals, true)
+
+	command.PouchRun("exec", name, "touch", "/tmp/healthy").Assert(c, icmd.Success)
+	waitHealthStatus(c, name, "healthy")
+	res = command.PouchRun("ps", "-a").Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "(healthy)"), check.Equals, true)
+
+	streak, err := inspectFilter(name, ".State.Health.FailingStreak")
+	c.Assert(err, check.IsNil)
+	c.Assert(streak, check.Equals, "0")
+}
+
+// TestRunNoHealthcheck tests --no-healthcheck conflicts with --health-* options.
+func (suite *PouchRunHealthcheckSuite) TestRunNoHealthcheck(c *check.C) {
+	name := "TestRunNoHealthcheck"
+
+	res := command.PouchRun("run", "-d", "--name", name, "--no-healthcheck",
+		"--health-cmd", "true", busyboxImage, "top")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Contains(res.Stderr(), "conflicts"), check.Equals, true)
+
+	command.PouchRun("run", "-d", "--name", name, "--no-healthcheck", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	output, err := inspectFilter(name, ".State.Health")
+	c.Assert(err, check.IsNil)
+	c.Assert(output, check.Equals, "<nil>")
+}
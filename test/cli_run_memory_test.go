@@ -200,6 +200,37 @@ func (suite *PouchRunMemorySuite) TestRunWithLimitedMemory(c *check.C) {
 	c.Assert(out, check.Equals, strconv.Itoa(expected))
 }
 
+// TestRunWithMemoryReservation is to verify the valid running container
+// with --memory-reservation
+func (suite *PouchRunMemorySuite) TestRunWithMemoryReservation(c *check.C) {
+	SkipIfFalse(c, environment.IsMemorySupport)
+
+	cname := "TestRunWithMemoryReservation"
+	expected := 50 * 1024 * 1024
+
+	res := command.PouchRun("run", "-d", "-m", "100m", "--memory-reservation", "50m",
+		"--name", cname, busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+
+	reservation, err := inspectFilter(cname, ".HostConfig.MemoryReservation")
+	c.Assert(err, check.IsNil)
+	c.Assert(reservation, check.Equals, strconv.Itoa(expected))
+
+	containerID, err := inspectFilter(cname, ".ID")
+	c.Assert(err, check.IsNil)
+	path := fmt.Sprintf(
+		"/sys/fs/cgroup/memory/default/%s/memory.soft_limit_in_bytes", containerID)
+	checkFileContains(c, path, strconv.Itoa(expected))
+
+	// memory reservation can't be larger than memory limit
+	cname = "TestRunWithMemoryReservationLargerThanMemory"
+	res = command.PouchRun("run", "-d", "-m", "50m", "--memory-reservation", "100m",
+		"--name", cname, busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, cname)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+}
+
 // TestRunMemoryOOM is to verify return value when a container is OOM.
 func (suite *PouchRunMemorySuite) TestRunMemoryOOM(c *check.C) {
 	SkipIfFalse(c, environment.IsMemorySupport)
@@ -258,4 +289,8 @@ func (suite *PouchRunMemorySuite) TestRunWithDefaultShm(c *check.C) {
 	res.Assert(c, icmd.Success)
 
 	c.Assert(util.PartialEqual(res.Stdout(), "65536"), check.IsNil)
+
+	shmSize, err := inspectFilter(cname, ".HostConfig.ShmSize")
+	c.Assert(err, check.IsNil)
+	c.Assert(shmSize, check.Equals, "67108864")
 }
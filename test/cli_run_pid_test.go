@@ -2,9 +2,11 @@ package main
 
 import (
 	"os"
+	"strings"
 
 	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
 
 	"github.com/go-check/check"
 	"github.com/gotestyourself/gotestyourself/icmd"
@@ -31,7 +33,6 @@ func (suite *PouchRunPidSuite) TearDownTest(c *check.C) {
 }
 
 // TestRunWithPIDMode is to verify --specific PID mode when running a container.
-// TODO: test container pid namespace mode.
 func (suite *PouchRunPidSuite) TestRunWithPIDMode(c *check.C) {
 	name := "test-run-with-pid-mode"
 
@@ -42,6 +43,47 @@ func (suite *PouchRunPidSuite) TestRunWithPIDMode(c *check.C) {
 	res.Assert(c, icmd.Success)
 }
 
+// TestRunWithPIDContainerMode tests joining the pid, ipc and network
+// namespaces of a running container.
+func (suite *PouchRunPidSuite) TestRunWithPIDContainerMode(c *check.C) {
+	target, name := "TestRunWithPIDContainerModeTarget", "TestRunWithPIDContainerMode"
+
+	res := command.PouchRun("run", "-d", "--name", target, busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, target)
+	res.Assert(c, icmd.Success)
+	id := strings.TrimSpace(res.Stdout())
+
+	res = command.PouchRun("run", "--name", name, "--pid", "container:"+target,
+		"--ipc", "container:"+target, "--net", "container:"+target, busyboxImage, "ps")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(res.Stdout(), "top"), check.IsNil)
+
+	for _, filter := range []string{".HostConfig.PidMode", ".HostConfig.IpcMode", ".HostConfig.NetworkMode"} {
+		mode, err := inspectFilter(name, filter)
+		c.Assert(err, check.IsNil)
+		c.Assert(mode, check.Equals, "container:"+id)
+	}
+}
+
+// TestRunWithPIDModeOfStoppedContainer tests joining the pid namespace of a
+// container which is not running fails.
+func (suite *PouchRunPidSuite) TestRunWithPIDModeOfStoppedContainer(c *check.C) {
+	target, name := "TestRunWithPIDModeOfStoppedContainerTarget", "TestRunWithPIDModeOfStoppedContainer"
+
+	command.PouchRun("create", "--name", target, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, target)
+
+	res := command.PouchRun("run", "--name", name, "--pid", "container:"+target, busyboxImage, "ps")
+	defer DelContainerForceMultyTime(c, name)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "container is not running, its state is created"), check.IsNil)
+
+	res = command.PouchRun("create", "--pid", "private", busyboxImage)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid pid mode"), check.IsNil)
+}
+
 // TestRunWithPidsLimit tests running container with --pids-limit flag.
 func (suite *PouchRunPidSuite) TestRunWithPidsLimit(c *check.C) {
 	// pids cgroup may not supported in inner ci
@@ -3,6 +3,8 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/exec"
 	"strings"
 	"time"
 
@@ -146,6 +148,56 @@ func (suite *PouchRunSuite) TestRunRestartPolicyNone(c *check.C) {
 	}
 }
 
+// TestRunRestartPolicyOnFailure is to verify restart policy on-failure stops after max retries.
+func (suite *PouchRunSuite) TestRunRestartPolicyOnFailure(c *check.C) {
+	name := "TestRunRestartPolicyOnFailure"
+	command.PouchRun("run", "-d", "--name", name, "--restart=on-failure:2",
+		busyboxImage, "sh", "-c", "exit 1").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	var count string
+	for i := 0; i < 50; i++ {
+		time.Sleep(200 * time.Millisecond)
+
+		var err error
+		count, err = inspectFilter(name, ".RestartCount")
+		c.Assert(err, check.IsNil)
+		if count == "2" {
+			break
+		}
+	}
+	c.Assert(count, check.Equals, "2")
+
+	// no more restart after max retries
+	time.Sleep(2 * time.Second)
+	count, err := inspectFilter(name, ".RestartCount")
+	c.Assert(err, check.IsNil)
+	c.Assert(count, check.Equals, "2")
+
+	status, err := inspectFilter(name, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "exited")
+}
+
+// TestRunRestartPolicyAlwaysStop is to verify the container stopped by API is not restarted by policy always.
+func (suite *PouchRunSuite) TestRunRestartPolicyAlwaysStop(c *check.C) {
+	name := "TestRunRestartPolicyAlwaysStop"
+	command.PouchRun("run", "-d", "--name", name, "--restart=always",
+		busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("stop", "-t", "1", name).Assert(c, icmd.Success)
+	time.Sleep(2 * time.Second)
+
+	status, err := inspectFilter(name, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "stopped")
+
+	count, err := inspectFilter(name, ".RestartCount")
+	c.Assert(err, check.IsNil)
+	c.Assert(count, check.Equals, "0")
+}
+
 // TestRunWithIPCMode is to verify --specific IPC mode when running a container.
 // TODO: test container ipc namespace mode.
 func (suite *PouchRunSuite) TestRunWithIPCMode(c *check.C) {
@@ -187,6 +239,48 @@ func (suite *PouchRunSuite) TestRunWithSysctls(c *check.C) {
 	}
 }
 
+// TestRunWithInvalidSysctls is to verify run container with the sysctl which
+// is not namespaced fails.
+func (suite *PouchRunSuite) TestRunWithInvalidSysctls(c *check.C) {
+	name := "run-invalid-sysctl"
+
+	res := command.PouchRun("run", "-d", "--name", name,
+		"--sysctl", "kernel.panic=1", busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid sysctl kernel.panic: it is not namespaced"), check.IsNil)
+}
+
+// TestRunWithInit is to verify run container with --init, the init is the
+// pid 1 of container and the command is the child of init.
+func (suite *PouchRunSuite) TestRunWithInit(c *check.C) {
+	SkipIfFalse(c, func() bool {
+		_, err := exec.LookPath("dumb-init")
+		return err == nil
+	})
+
+	name := "run-with-init"
+
+	res := command.PouchRun("run", "--name", name, "--init", busyboxImage, "ps", "-o", "pid,args")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Matches, "(?s).*\\s1 /dev/init -- ps -o pid,args.*")
+
+	init, err := inspectFilter(name, ".HostConfig.Init")
+	c.Assert(err, check.IsNil)
+	c.Assert(init, check.Equals, "true")
+
+	// the default of daemon is used if --init is not set
+	name = "run-without-init"
+	res = command.PouchRun("run", "--name", name, busyboxImage, "true")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	init, err = inspectFilter(name, ".HostConfig.Init")
+	c.Assert(err, check.IsNil)
+	c.Assert(init, check.Equals, "false")
+}
+
 // TestRunWithAppArmor is to verify run container with security option AppArmor.
 func (suite *PouchRunSuite) TestRunWithAppArmor(c *check.C) {
 	appArmor := "apparmor=unconfined"
@@ -316,6 +410,27 @@ func (suite *PouchRunSuite) TestRunWithExitCode(c *check.C) {
 	c.Assert(exitCode, check.Equals, "101")
 }
 
+// TestRunWithSigProxy is to verify the signal received by CLI is forwarded
+// to the init process of container.
+func (suite *PouchRunSuite) TestRunWithSigProxy(c *check.C) {
+	cname := "TestRunWithSigProxy"
+	res := icmd.StartCmd(command.PouchCmd("run", "--name", cname, busyboxImage,
+		"sh", "-c", "trap 'exit 3' INT; while true; do sleep 1; done"))
+	c.Assert(res.Error, check.IsNil)
+	defer DelContainerForceMultyTime(c, cname)
+
+	// wait for the trap to be installed
+	time.Sleep(3 * time.Second)
+	c.Assert(res.Cmd.Process.Signal(os.Interrupt), check.IsNil)
+
+	res = icmd.WaitOnCmd(10*time.Second, res)
+	res.Assert(c, icmd.Expected{ExitCode: 3})
+
+	exitCode, err := inspectFilter(cname, ".State.ExitCode")
+	c.Assert(err, check.IsNil)
+	c.Assert(exitCode, check.Equals, "3")
+}
+
 // TestRunWithRM is to verify the valid running container with rm flag
 func (suite *PouchRunSuite) TestRunWithRM(c *check.C) {
 	cname := "TestRunWithRM"
@@ -328,6 +443,45 @@ func (suite *PouchRunSuite) TestRunWithRM(c *check.C) {
 	c.Assert(util.PartialEqual(output, cname+": not found"), check.IsNil)
 }
 
+// TestRunWithRMExitCode is to verify the exit code of container with rm flag is returned.
+func (suite *PouchRunSuite) TestRunWithRMExitCode(c *check.C) {
+	cname := "TestRunWithRMExitCode"
+	res := command.PouchRun("run", "--rm", "--name", cname, busyboxImage, "sh", "-c", "exit 3")
+	defer DelContainerForceMultyTime(c, cname)
+	c.Assert(res.ExitCode, check.Equals, 3)
+
+	command.PouchRun("inspect", "--type", "container", cname).Assert(c, icmd.Expected{ExitCode: 2})
+}
+
+// TestRunWithRMDetach is to verify the detached container with rm flag is removed by pouchd after it is stopped.
+func (suite *PouchRunSuite) TestRunWithRMDetach(c *check.C) {
+	cname := "TestRunWithRMDetach"
+	command.PouchRun("run", "-d", "--rm", "--name", cname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	autoRemove, err := inspectFilter(cname, ".HostConfig.AutoRemove")
+	c.Assert(err, check.IsNil)
+	c.Assert(autoRemove, check.Equals, "true")
+
+	command.PouchRun("stop", "-t", "1", cname).Assert(c, icmd.Success)
+
+	removed := false
+	for i := 0; i < 50 && !removed; i++ {
+		removed = command.PouchRun("inspect", "--type", "container", cname).ExitCode == 2
+		time.Sleep(100 * time.Millisecond)
+	}
+	c.Assert(removed, check.Equals, true)
+}
+
+// TestRunWithRMAndRestartPolicy is to verify rm flag can't be used with restart policy.
+func (suite *PouchRunSuite) TestRunWithRMAndRestartPolicy(c *check.C) {
+	cname := "TestRunWithRMAndRestartPolicy"
+	res := command.PouchRun("run", "--rm", "--restart", "always", "--name", cname, busyboxImage, "true")
+	defer DelContainerForceMultyTime(c, cname)
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(util.PartialEqual(res.Stderr(), "can't use AutoRemove with restart policy"), check.IsNil)
+}
+
 // TestRunWithDisableNetworkFiles is to verify running container with disable-network-files flag.
 func (suite *PouchRunSuite) TestRunWithDisableNetworkFiles(c *check.C) {
 	// Run a container with disable-network-files flag
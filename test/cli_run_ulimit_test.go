@@ -52,3 +52,18 @@ func (suite *PouchRunUlimitSuite) TestRunWithUlimit(c *check.C) {
 	c.Assert(int(ul.Hard), check.Equals, 256)
 	c.Assert(int(ul.Soft), check.Equals, 256)
 }
+
+// TestRunWithUlimitSoftAndHard tests running container with both soft and
+// hard limits set by --ulimit.
+func (suite *PouchRunUlimitSuite) TestRunWithUlimitSoftAndHard(c *check.C) {
+	cname := "TestRunWithUlimitSoftAndHard"
+	res := command.PouchRun("run", "--ulimit", "nofile=1024:2048", "--name",
+		cname, busyboxImage, "sh", "-c", "ulimit -Sn; ulimit -Hn")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "1024\n2048\n")
+
+	res = command.PouchRun("run", "--ulimit", "nofile=2048:1024", busyboxImage, "true")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*soft limit must be less than or equal to hard limit.*")
+}
@@ -56,11 +56,48 @@ func (suite *PouchRunUserSuite) TestRunWithUserFail(c *check.C) {
 		name := fmt.Sprintf("%s-%d", namePrefix, idx)
 		res := command.PouchRun("run", "-d", "--name", name,
 			"--user", user, busyboxImage, "top")
-		c.Assert(res.Stderr(), check.NotNil)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0, check.Commentf(user))
+		c.Assert(res.Stderr(), check.Matches, "(?s).*unable to find.*", check.Commentf(user))
 		DelContainerForceMultyTime(c, name)
 	}
 }
 
+// TestRunWithUserIdentity is to verify the user and group given by name or
+// id are resolved against the image.
+func (suite *PouchRunUserSuite) TestRunWithUserIdentity(c *check.C) {
+	for user, expected := range map[string]string{
+		"nobody":      "uid=65534(nobody) gid=65534(nogroup)",
+		"daemon:mail": "uid=1(daemon) gid=8(mail)",
+		"1001:1002":   "uid=1001 gid=1002",
+	} {
+		res := command.PouchRun("run", "--rm", "--user", user, busyboxImage, "id")
+		res.Assert(c, icmd.Success)
+		c.Assert(strings.HasPrefix(strings.TrimSpace(res.Stdout()), expected), check.Equals, true,
+			check.Commentf("user %s: %s", user, res.Stdout()))
+	}
+}
+
+// TestRunWithGroupAdd is to verify the additional groups given by name or
+// gid are joined by the container process.
+func (suite *PouchRunUserSuite) TestRunWithGroupAdd(c *check.C) {
+	name := "run-group-add"
+
+	res := command.PouchRun("run", "--name", name, "--group-add", "mail",
+		"--group-add", "2000", busyboxImage, "id")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "8(mail)"), check.Equals, true, check.Commentf(res.Stdout()))
+	c.Assert(strings.Contains(res.Stdout(), "2000"), check.Equals, true, check.Commentf(res.Stdout()))
+
+	groups, err := inspectFilter(name, ".HostConfig.GroupAdd")
+	c.Assert(err, check.IsNil)
+	c.Assert(groups, check.Equals, "[mail 2000]")
+
+	res = command.PouchRun("create", "--group-add", "wrong-group", busyboxImage)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*invalid group-add.*")
+}
+
 // TestRunWithUser is to verify run container with user.
 func (suite *PouchRunUserSuite) TestRunWithAddUser(c *check.C) {
 	name := "run-user-admin"
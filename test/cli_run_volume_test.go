@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
@@ -578,3 +581,108 @@ func (suite *PouchRunVolumeSuite) TestRunWithVolumesOpts(c *check.C) {
 
 	c.Assert(found, check.Equals, true)
 }
+
+// TestRunWithMount tests running container with --mount of bind, volume and
+// tmpfs, and the resolved mounts are listed in inspect.
+func (suite *PouchRunVolumeSuite) TestRunWithMount(c *check.C) {
+	cname := "TestRunWithMount"
+	vname := "TestRunWithMountVolume"
+
+	hostdir, err := ioutil.TempDir("", cname)
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(hostdir)
+	c.Assert(ioutil.WriteFile(filepath.Join(hostdir, "hello"), []byte("world"), 0644), check.IsNil)
+
+	res := command.PouchRun("run", "--name", cname,
+		"--mount", "type=bind,source="+hostdir+",target=/bind,readonly",
+		"--mount", "type=volume,source="+vname+",target=/volume",
+		"--mount", "type=tmpfs,target=/tmp/cache,tmpfs-size=1m",
+		busyboxImage, "sh", "-c", "cat /bind/hello && ! touch /bind/foo 2>/dev/null && touch /volume/foo && grep /tmp/cache /proc/mounts")
+	defer func() {
+		DelContainerForceMultyTime(c, cname)
+		command.PouchRun("volume", "rm", vname)
+	}()
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "world"), check.Equals, true)
+	c.Assert(strings.Contains(res.Stdout(), "tmpfs /tmp/cache tmpfs"), check.Equals, true, check.Commentf(res.Stdout()))
+
+	// the named volume is created by mount.
+	command.PouchRun("volume", "inspect", vname).Assert(c, icmd.Success)
+
+	output := command.PouchRun("inspect", cname).Stdout()
+	result := []types.ContainerJSON{}
+	c.Assert(json.Unmarshal([]byte(output), &result), check.IsNil)
+
+	mounts := map[string]types.MountPoint{}
+	for _, m := range result[0].Mounts {
+		mounts[m.Destination] = m
+	}
+	c.Assert(mounts["/bind"].Type, check.Equals, "bind")
+	c.Assert(mounts["/bind"].Source, check.Equals, hostdir)
+	c.Assert(mounts["/bind"].RW, check.Equals, false)
+	c.Assert(mounts["/volume"].Type, check.Equals, "volume")
+	c.Assert(mounts["/volume"].Name, check.Equals, vname)
+	c.Assert(mounts["/volume"].RW, check.Equals, true)
+	c.Assert(mounts["/tmp/cache"].Type, check.Equals, "tmpfs")
+}
+
+// TestRunWithMountNotExistBind tests running container with --mount of bind
+// whose source doesn't exist fails.
+func (suite *PouchRunVolumeSuite) TestRunWithMountNotExistBind(c *check.C) {
+	cname := "TestRunWithMountNotExistBind"
+
+	res := command.PouchRun("run", "--name", cname,
+		"--mount", "type=bind,source=/tmp/pouch-not-exist-bind-source,target=/data",
+		busyboxImage, "true")
+	defer DelContainerForceMultyTime(c, cname)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*bind source path does not exist.*")
+}
+
+// TestRunWithTmpfs tests running container with --tmpfs mounts the tmpfs
+// with options.
+func (suite *PouchRunVolumeSuite) TestRunWithTmpfs(c *check.C) {
+	cname := "TestRunWithTmpfs"
+
+	res := command.PouchRun("run", "--name", cname,
+		"--tmpfs", "/run/data:rw,noexec,size=1m",
+		"--tmpfs", "/cache:ro",
+		busyboxImage, "sh", "-c", "touch /run/data/foo && ! touch /cache/foo 2>/dev/null && grep -E '/run/data|/cache' /proc/mounts")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "tmpfs /run/data tmpfs rw,nosuid,nodev,noexec"), check.Equals, true, check.Commentf(res.Stdout()))
+	c.Assert(strings.Contains(res.Stdout(), "size=1024k"), check.Equals, true, check.Commentf(res.Stdout()))
+	c.Assert(strings.Contains(res.Stdout(), "tmpfs /cache tmpfs ro"), check.Equals, true, check.Commentf(res.Stdout()))
+
+	tmpfs, err := inspectFilter(cname, ".HostConfig.Tmpfs")
+	c.Assert(err, check.IsNil)
+	c.Assert(tmpfs, check.Equals, "map[/cache:ro /run/data:rw,noexec,size=1m]")
+
+	res = command.PouchRun("run", "--tmpfs", "run", busyboxImage, "true")
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(strings.Contains(res.Stderr(), "destination must be an absolute path"), check.Equals, true, check.Commentf(res.Stderr()))
+}
+
+// TestRunWithReadOnly tests running container with --read-only, the rootfs
+// is read only and the volumes and tmpfs are still writable.
+func (suite *PouchRunVolumeSuite) TestRunWithReadOnly(c *check.C) {
+	cname := "TestRunWithReadOnly"
+
+	res := command.PouchRun("run", "--name", cname, "--read-only",
+		"-v", "/data", "--tmpfs", "/tmp",
+		busyboxImage, "sh", "-c", "! touch /foo 2>/dev/null && touch /data/foo /tmp/foo /dev/shm/foo")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "/tmp is not writable"), check.Equals, false)
+
+	readonly, err := inspectFilter(cname, ".HostConfig.ReadonlyRootfs")
+	c.Assert(err, check.IsNil)
+	c.Assert(readonly, check.Equals, "true")
+
+	// hint if /tmp is not writable
+	cname = "TestRunWithReadOnlyWithoutTmp"
+	res = command.PouchRun("create", "--name", cname, "--read-only", busyboxImage)
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "/tmp is not writable"), check.Equals, true, check.Commentf(res.Stdout()))
+}
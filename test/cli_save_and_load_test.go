@@ -1,10 +1,14 @@
 package main
 
 import (
+	"archive/tar"
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/test/command"
@@ -66,3 +70,170 @@ func (suite *PouchSaveLoadSuite) TestSaveLoadWorks(c *check.C) {
 	c.Assert(before[0].CreatedAt, check.Equals, after[0].CreatedAt)
 	c.Assert(before[0].Size, check.Equals, after[0].Size)
 }
+
+// TestSaveMultipleImages tests "pouch save" works with multiple images.
+func (suite *PouchSaveLoadSuite) TestSaveMultipleImages(c *check.C) {
+	command.PouchRun("pull", busyboxImage).Assert(c, icmd.Success)
+	command.PouchRun("pull", busyboxImage125).Assert(c, icmd.Success)
+
+	dir, err := ioutil.TempDir("", "TestSaveMultipleImages")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	tarFile := filepath.Join(dir, "images.tar")
+	digestRef := environment.BusyboxRepo + "@" + environment.Busybox125Digest
+	command.PouchRun("save", "-o", tarFile, busyboxImage, busyboxImage125, digestRef).Assert(c, icmd.Success)
+
+	f, err := os.Open(tarFile)
+	c.Assert(err, check.IsNil)
+	defer f.Close()
+
+	var (
+		manifests []struct {
+			Config   string
+			RepoTags []string
+			Layers   []string
+		}
+		files = map[string]bool{}
+	)
+
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, check.IsNil)
+		files[hdr.Name] = true
+
+		if hdr.Name == "manifest.json" {
+			c.Assert(json.NewDecoder(tr).Decode(&manifests), check.IsNil)
+		}
+	}
+
+	c.Assert(files["index.json"], check.Equals, true)
+	c.Assert(files["oci-layout"], check.Equals, true)
+
+	// the digest reference is the same image as busyboxImage125
+	var repoTags []string
+	for _, m := range manifests {
+		c.Assert(files[m.Config], check.Equals, true)
+		for _, layer := range m.Layers {
+			c.Assert(files[layer], check.Equals, true)
+		}
+		repoTags = append(repoTags, m.RepoTags...)
+	}
+	sort.Strings(repoTags)
+	c.Assert(len(manifests), check.Equals, 2)
+	c.Assert(repoTags, check.DeepEquals, []string{busyboxImage, busyboxImage125})
+}
+
+// TestSaveToStdout tests "pouch save" writes to STDOUT which isn't terminal.
+func (suite *PouchSaveLoadSuite) TestSaveToStdout(c *check.C) {
+	command.PouchRun("pull", busyboxImage).Assert(c, icmd.Success)
+
+	res := command.PouchRun("save", busyboxImage).Assert(c, icmd.Success)
+
+	tr := tar.NewReader(strings.NewReader(res.Stdout()))
+	found := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, check.IsNil)
+		if hdr.Name == "manifest.json" {
+			found = true
+		}
+	}
+	c.Assert(found, check.Equals, true)
+}
+
+// TestLoadQuiet tests "pouch load -q" keeps the references in tar archive.
+func (suite *PouchSaveLoadSuite) TestLoadQuiet(c *check.C) {
+	command.PouchRun("pull", busyboxImage125).Assert(c, icmd.Success)
+
+	dir, err := ioutil.TempDir("", "TestLoadQuiet")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	tarFile := filepath.Join(dir, "busybox.tar")
+	command.PouchRun("save", "-o", tarFile, busyboxImage125).Assert(c, icmd.Success)
+	command.PouchRun("rmi", busyboxImage125).Assert(c, icmd.Success)
+
+	res := command.PouchRun("load", "-q", "-i", tarFile).Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, busyboxImage125+"\n")
+
+	command.PouchRun("image", "inspect", busyboxImage125).Assert(c, icmd.Success)
+}
+
+// TestLoadCorruptedArchive tests "pouch load" reports the corrupted blob.
+func (suite *PouchSaveLoadSuite) TestLoadCorruptedArchive(c *check.C) {
+	command.PouchRun("pull", busyboxImage125).Assert(c, icmd.Success)
+
+	dir, err := ioutil.TempDir("", "TestLoadCorruptedArchive")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	tarFile := filepath.Join(dir, "busybox.tar")
+	command.PouchRun("save", "-o", tarFile, busyboxImage125).Assert(c, icmd.Success)
+
+	// corrupt the largest blob, which is the layer
+	corrupted := filepath.Join(dir, "corrupted.tar")
+	blob := corruptLargestBlob(c, tarFile, corrupted)
+
+	res := command.PouchRun("load", "-i", corrupted, "corrupted-busybox")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Contains(res.Stderr(), "blob sha256:"+filepath.Base(blob)+" is corrupted"), check.Equals, true, check.Commentf("got %s", res.Stderr()))
+}
+
+// corruptLargestBlob copies the tar archive and flips the first byte of the
+// largest blob, and returns the name of the blob.
+func corruptLargestBlob(c *check.C, src, dst string) string {
+	type entry struct {
+		hdr  *tar.Header
+		data []byte
+	}
+
+	in, err := os.Open(src)
+	c.Assert(err, check.IsNil)
+	defer in.Close()
+
+	var (
+		entries []entry
+		largest = -1
+	)
+
+	tr := tar.NewReader(in)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, check.IsNil)
+
+		data, err := ioutil.ReadAll(tr)
+		c.Assert(err, check.IsNil)
+		entries = append(entries, entry{hdr: hdr, data: data})
+
+		if strings.HasPrefix(hdr.Name, "blobs/sha256/") && len(data) > 0 &&
+			(largest == -1 || len(data) > len(entries[largest].data)) {
+			largest = len(entries) - 1
+		}
+	}
+	c.Assert(largest, check.Not(check.Equals), -1)
+	entries[largest].data[0] ^= 0xff
+
+	out, err := os.Create(dst)
+	c.Assert(err, check.IsNil)
+	defer out.Close()
+
+	tw := tar.NewWriter(out)
+	for _, e := range entries {
+		c.Assert(tw.WriteHeader(e.hdr), check.IsNil)
+		_, err := tw.Write(e.data)
+		c.Assert(err, check.IsNil)
+	}
+	c.Assert(tw.Close(), check.IsNil)
+	return entries[largest].hdr.Name
+}
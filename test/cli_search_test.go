@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+)
+
+// PouchSearchSuite is the test suite for search CLI.
+type PouchSearchSuite struct{}
+
+func init() {
+	check.Suite(&PouchSearchSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchSearchSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+}
+
+// TestSearchInvalidLimit tests "pouch search" refuses the limit out of range.
+func (suite *PouchSearchSuite) TestSearchInvalidLimit(c *check.C) {
+	for _, limit := range []string{"0", "101"} {
+		res := command.PouchRun("search", "--limit", limit, "busybox")
+		c.Assert(res.ExitCode, check.Equals, 1)
+		c.Assert(util.PartialEqual(res.Stderr(), "outside the range of [1, 100]"), check.IsNil)
+	}
+}
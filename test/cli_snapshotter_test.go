@@ -5,12 +5,14 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/alibaba/pouch/test/environment"
 
 	"github.com/containerd/containerd/mount"
 	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
 )
 
 // PouchSnapshotterSuite is the test suite for choose snapshotter feature.
@@ -129,6 +131,43 @@ func (suite *PouchSnapshotterSuite) TestAllowMultiSnapshotter(c *check.C) {
 	dcfg.KillDaemon()
 }
 
+// TestInvalidSnapshotter tests pouchd fails to start with the snapshotter
+// which isn't supported by containerd.
+func (suite *PouchSnapshotterSuite) TestInvalidSnapshotter(c *check.C) {
+	dcfg, err := StartDefaultDaemon("--snapshotter", "notexist")
+	if err == nil {
+		dcfg.KillDaemon()
+	}
+	c.Assert(err, check.NotNil)
+}
+
+// TestSwitchSnapshotterUnpackOnDemand tests the image pulled under one
+// snapshotter is unpacked for the other one when it's used by container.
+func (suite *PouchSnapshotterSuite) TestSwitchSnapshotterUnpackOnDemand(c *check.C) {
+	dcfg, err := StartDefaultDaemon("--snapshotter", "overlayfs", "--allow-multi-snapshotter")
+	c.Assert(err, check.IsNil)
+
+	RunWithSpecifiedDaemon(dcfg, "pull", busyboxImage).Assert(c, icmd.Success)
+	dcfg.KillDaemon()
+
+	// the native snapshotter is supported on any filesystem.
+	dcfg, err = StartDefaultDaemon("--snapshotter", "native", "--allow-multi-snapshotter")
+	c.Assert(err, check.IsNil)
+
+	res := RunWithSpecifiedDaemon(dcfg, "info", "--format", "{{.Driver}}").Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "native")
+
+	RunWithSpecifiedDaemon(dcfg, "run", "--rm", busyboxImage, "true").Assert(c, icmd.Success)
+
+	names, err := checkSnapshotsDir(dcfg.HomeDir, "native")
+	c.Assert(err, check.IsNil)
+	c.Assert(len(names) > 0, check.Equals, true)
+
+	// clean image
+	RunWithSpecifiedDaemon(dcfg, "rmi", busyboxImage).Assert(c, icmd.Success)
+	dcfg.KillDaemon()
+}
+
 // checkSnapshotsDir returns snapshots directory names by given snapshotter name
 func checkSnapshotsDir(homeDir string, snapshotter string) ([]string, error) {
 	const snapshotterPrefix = "io.containerd.snapshotter.v1."
@@ -6,6 +6,7 @@ import (
 
 	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
 
 	"github.com/go-check/check"
 	"github.com/gotestyourself/gotestyourself/icmd"
@@ -45,3 +46,45 @@ func (s *PouchStatsSuite) TestStatsNoStream(c *check.C) {
 		c.Fatalf("container name not present in the stats output, %s", res.Stdout())
 	}
 }
+
+// TestStatsFormat tests the stats are rendered with template, and all running
+// containers are displayed if no container is specified.
+func (s *PouchStatsSuite) TestStatsFormat(c *check.C) {
+	cname := "TestStatsFormat"
+	command.PouchRun("run", "-d", "--name", cname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	cmd := command.PouchCmd("stats", "--no-stream", "--format", "{{.Name}}:{{.PIDs}}")
+	res := icmd.StartCmd(cmd)
+	res = icmd.WaitOnCmd(5*time.Second, res)
+	res.Assert(c, icmd.Success)
+
+	if !strings.Contains(res.Stdout(), cname+":1\n") {
+		c.Fatalf("container stats not present in the formatted output, %s", res.Stdout())
+	}
+
+	res = command.PouchRun("stats", "--no-stream", "--format", "{{.Unknown}}", cname)
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "failed to execute format template"), check.IsNil)
+}
+
+// TestStatsExitedContainer tests the container exiting during streaming drops
+// out of the display.
+func (s *PouchStatsSuite) TestStatsExitedContainer(c *check.C) {
+	running := "TestStatsExitedContainerRunning"
+	exited := "TestStatsExitedContainerExited"
+	command.PouchRun("run", "-d", "--name", running, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, running)
+	command.PouchRun("run", "-d", "--name", exited, busyboxImage, "sleep", "2").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, exited)
+
+	cmd := command.PouchCmd("stats", "--format", "{{.Name}}", running, exited)
+	cmd.Timeout = 6 * time.Second
+	res := icmd.RunCmd(cmd)
+
+	// the last display only contains the running container
+	frames := strings.Split(res.Stdout(), "\033[2J\033[H")
+	c.Assert(len(frames) > 3, check.Equals, true)
+	c.Assert(strings.TrimSpace(frames[1]), check.Equals, running+"\n"+exited)
+	c.Assert(strings.TrimSpace(frames[len(frames)-1]), check.Equals, running)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchSystemDfSuite is the test suite for system df CLI.
+type PouchSystemDfSuite struct{}
+
+func init() {
+	check.Suite(&PouchSystemDfSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchSystemDfSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	PullImage(c, busyboxImage)
+}
+
+// TestSystemDf tests "pouch system df" reports the summary of images,
+// containers and volumes.
+func (suite *PouchSystemDfSuite) TestSystemDf(c *check.C) {
+	res := command.PouchRun("system", "df").Assert(c, icmd.Success)
+
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(len(lines), check.Equals, 4)
+	c.Assert(strings.Fields(lines[0]), check.DeepEquals, []string{"TYPE", "TOTAL", "ACTIVE", "SIZE", "RECLAIMABLE"})
+	c.Assert(strings.HasPrefix(lines[1], "Images"), check.Equals, true)
+	c.Assert(strings.HasPrefix(lines[2], "Containers"), check.Equals, true)
+	c.Assert(strings.HasPrefix(lines[3], "Local Volumes"), check.Equals, true)
+}
+
+// TestSystemDfVerbose tests "pouch system df -v" lists the size of the
+// container and volume.
+func (suite *PouchSystemDfSuite) TestSystemDfVerbose(c *check.C) {
+	volume, cname := "TestSystemDfVerbose", "TestSystemDfVerbose"
+
+	command.PouchRun("volume", "create", "--name", volume).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", volume)
+
+	command.PouchRun("create", "-v", volume+":/mnt", "--name", cname, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	mountpoint := command.PouchRun("volume", "inspect", "-f", "{{.Mountpoint}}", volume).Assert(c, icmd.Success).Stdout()
+	c.Assert(ioutil.WriteFile(filepath.Join(strings.TrimSpace(mountpoint), "foo"), make([]byte, 1024), 0644), check.IsNil)
+
+	res := command.PouchRun("system", "df", "-v").Assert(c, icmd.Success)
+	out := res.Stdout()
+
+	for _, section := range []string{"Images space usage:", "Containers space usage:", "Local Volumes space usage:"} {
+		c.Assert(strings.Contains(out, section), check.Equals, true, check.Commentf("missing %s in %s", section, out))
+	}
+
+	var foundContainer, foundVolume bool
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case fields[len(fields)-1] == cname:
+			foundContainer = true
+		case fields[0] == volume:
+			foundVolume = true
+			c.Assert(fields, check.DeepEquals, []string{volume, "local", "1", "1.00", "KB"})
+		}
+	}
+	c.Assert(foundContainer, check.Equals, true, check.Commentf(out))
+	c.Assert(foundVolume, check.Equals, true, check.Commentf(out))
+}
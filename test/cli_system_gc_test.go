@@ -0,0 +1,41 @@
+package main
+
+import (
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchSystemGCSuite is the test suite for system gc CLI.
+type PouchSystemGCSuite struct{}
+
+func init() {
+	check.Suite(&PouchSystemGCSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchSystemGCSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	PullImage(c, busyboxImage)
+}
+
+// TestSystemGC tests "pouch system gc" reports the reclaimed space, and
+// keeps the content of images and the rootfs of stopped containers.
+func (suite *PouchSystemGCSuite) TestSystemGC(c *check.C) {
+	cname := "TestSystemGC"
+
+	command.PouchRun("run", "--name", cname, busyboxImage, "sh", "-c", "echo hello > /foo").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	res := command.PouchRun("system", "gc").Assert(c, icmd.Success)
+	out := res.Stdout()
+	c.Assert(util.PartialEqual(out, "Deleted blobs:"), check.IsNil)
+	c.Assert(util.PartialEqual(out, "Total reclaimed space:"), check.IsNil)
+
+	command.PouchRun("start", "-a", cname).Assert(c, icmd.Success)
+	command.PouchRun("run", "--rm", busyboxImage, "true").Assert(c, icmd.Success)
+}
@@ -43,7 +43,7 @@ func (suite *PouchTopSuite) TestTopStoppedContainer(c *check.C) {
 	res = command.PouchRun("top", name)
 	c.Assert(res.Stderr(), check.NotNil)
 
-	expectString := " is not running or paused, cannot execute top command"
+	expectString := " is not running, cannot execute top command"
 	if out := res.Combined(); !strings.Contains(out, expectString) {
 		// FIXME(ziren): for debug top error info is empty
 		fmt.Printf("%+v", res)
@@ -108,3 +108,24 @@ func (suite *PouchTopSuite) TestTopContainerWithOptions(c *check.C) {
 		c.Fatalf("unexpected output %s expected %s", out, expectString)
 	}
 }
+
+// TestTopContainerWithFormatOptions is to verify the ps format options are
+// passed to ps, and the invalid options are refused.
+func (suite *PouchTopSuite) TestTopContainerWithFormatOptions(c *check.C) {
+	name := "TestTopContainerWithFormatOptions"
+
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("top", name, "-eo", "pid,comm,etime")
+	res.Assert(c, icmd.Success)
+
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(len(lines), check.Equals, 2)
+	c.Assert(strings.Fields(lines[0]), check.DeepEquals, []string{"PID", "COMMAND", "ELAPSED"})
+	c.Assert(strings.Fields(lines[1])[1], check.Equals, "top")
+
+	res = command.PouchRun("top", name, "--no-such-option")
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(util.PartialEqual(res.Stderr(), "failed to run ps command"), check.IsNil)
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/pkg/utils"
@@ -78,8 +80,6 @@ func (suite *PouchUpdateSuite) TestUpdateContainerNormalOption(c *check.C) {
 		"--env", "TEST2=", // update env to empty
 		"--env", "TEST3", // update to remove the env
 		"--env", "TEST4=bar4", // adding a new env
-		// label related update
-		"--label", "foo=bar",
 		name,
 	).Assert(c, icmd.Success)
 
@@ -229,16 +229,6 @@ func (suite *PouchUpdateSuite) TestUpdateContainerNormalOption(c *check.C) {
 			c.Fatalf("env TEST4=bar4 should be in container runtime env, while get: %v", output)
 		}
 	}
-
-	// test value check aboudoct labels
-	{
-		labels, err := inspectFilter(name, ".Config.Labels")
-		c.Assert(err, check.IsNil)
-
-		if !strings.Contains(labels, "foo:bar") {
-			c.Fatalf("expect 'foo:bar' in Labels, got: %s", labels)
-		}
-	}
 }
 
 // TestUpdateCpuMemoryFail is to verify the invalid value of updating container cpu and memory related flags will fail.
@@ -295,6 +285,37 @@ func (suite *PouchUpdateSuite) TestUpdateStoppedContainer(c *check.C) {
 	c.Assert(memory, check.Equals, "524288000")
 }
 
+// TestUpdateRestartPolicy is to verify the restart policy updated to a running container takes effect.
+func (suite *PouchUpdateSuite) TestUpdateRestartPolicy(c *check.C) {
+	name := "update-restart-policy"
+
+	res := command.PouchRun("run", "-d", "--name", name, busyboxImage, "sh", "-c", "sleep 2; exit 1")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	command.PouchRun("update", "--restart", "on-failure:1", name).Assert(c, icmd.Success)
+
+	// the restart policy is kept when updating other options.
+	command.PouchRun("update", "-m", "500M", name).Assert(c, icmd.Success)
+	policy, err := inspectFilter(name, ".HostConfig.RestartPolicy.Name")
+	c.Assert(err, check.IsNil)
+	c.Assert(policy, check.Equals, "on-failure")
+
+	var count string
+	for i := 0; i < 50; i++ {
+		time.Sleep(200 * time.Millisecond)
+
+		count, err = inspectFilter(name, ".RestartCount")
+		c.Assert(err, check.IsNil)
+		if count == "1" {
+			break
+		}
+	}
+	c.Assert(count, check.Equals, "1")
+
+	command.PouchRun("update", "--restart", "invalid", name).Assert(c, icmd.Expected{ExitCode: 1})
+}
+
 // TestUpdateContainerWithoutFlag is to verify the correctness of updating a container without any flag.
 func (suite *PouchUpdateSuite) TestUpdateContainerWithoutFlag(c *check.C) {
 	name := "update-container-without-flag"
@@ -596,3 +617,58 @@ func (suite *PouchUpdateSuite) TestUpdateAnnotation(c *check.C) {
 	checkContainerAnnotation(c, cname, "key1", "value1.new")
 	checkContainerAnnotation(c, cname, "key2", "value2.new")
 }
+
+// TestUpdateMultipleContainers is to verify the resources of all given
+// containers are updated at once and are kept after restart.
+func (suite *PouchUpdateSuite) TestUpdateMultipleContainers(c *check.C) {
+	names := []string{"TestUpdateMultipleContainers1", "TestUpdateMultipleContainers2"}
+	for _, name := range names {
+		command.PouchRun("run", "-d", "-m", "300M", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+		defer DelContainerForceMultyTime(c, name)
+	}
+
+	args := append([]string{"update", "-m", "500M", "--cpu-shares", "2000"}, names...)
+	res := command.PouchRun(args...).Assert(c, icmd.Success)
+	c.Assert(strings.Fields(res.Stdout()), check.DeepEquals, names)
+
+	for _, name := range names {
+		command.PouchRun("restart", name).Assert(c, icmd.Success)
+
+		memory, err := inspectFilter(name, ".HostConfig.Memory")
+		c.Assert(err, check.IsNil)
+		c.Assert(memory, check.Equals, "524288000")
+
+		shares, err := inspectFilter(name, ".HostConfig.CPUShares")
+		c.Assert(err, check.IsNil)
+		c.Assert(shares, check.Equals, "2000")
+	}
+
+	// the error of one container does not stop updating the others.
+	res = command.PouchRun("update", "-m", "400M", "TestUpdateMultipleContainersNone", names[0])
+	c.Assert(res.Error, check.NotNil)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, names[0])
+
+	memory, err := inspectFilter(names[0], ".HostConfig.Memory")
+	c.Assert(err, check.IsNil)
+	c.Assert(memory, check.Equals, "419430400")
+}
+
+// TestUpdateLabelsRejected is to verify the labels of container are immutable.
+func (suite *PouchUpdateSuite) TestUpdateLabelsRejected(c *check.C) {
+	name := "TestUpdateLabelsRejected"
+
+	command.PouchRun("run", "-d", "--name", name, "-l", "team=payments", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	err := apiClient.ContainerUpdate(context.Background(), name, &types.UpdateConfig{Label: []string{"team=infra"}})
+	c.Assert(err, check.NotNil)
+	c.Assert(err.Error(), check.Matches, "(?s).*labels of container are immutable.*")
+
+	// the update flag of labels is removed as well.
+	res := command.PouchRun("update", "--label", "team=infra", name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+
+	labels, err := inspectFilter(name, ".Config.Labels")
+	c.Assert(err, check.IsNil)
+	c.Assert(labels, check.Equals, "map[team:payments]")
+}
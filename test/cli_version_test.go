@@ -26,28 +26,53 @@ func (suite *PouchVersionSuite) SetUpTest(c *check.C) {
 }
 
 // TestPouchVersion is to verify pouch version.
-//
-// TODO: check more values if version functionality is ready.
 func (suite *PouchVersionSuite) TestPouchVersion(c *check.C) {
 	res := command.PouchRun("version").Assert(c, icmd.Success)
-	kv := versionToKV(res.Combined())
+	client, server := versionToKV(res.Combined())
 
-	c.Assert(kv["GoVersion"], check.Equals, runtime.Version())
-	c.Assert(kv["APIVersion"], check.Equals, version.APIVersion)
-	c.Assert(kv["Arch"], check.Equals, runtime.GOARCH)
-	c.Assert(kv["Os"], check.Equals, runtime.GOOS)
-	c.Assert(kv["Version"], check.Equals, version.Version)
+	for _, kv := range []map[string]string{client, server} {
+		c.Assert(kv["GoVersion"], check.Equals, runtime.Version())
+		c.Assert(kv["APIVersion"], check.Equals, version.APIVersion)
+		c.Assert(kv["OS/Arch"], check.Equals, runtime.GOOS+"/"+runtime.GOARCH)
+		c.Assert(kv["Version"], check.Equals, version.Version)
+	}
+	c.Assert(server["ContainerdVersion"], check.Not(check.Equals), "")
+	c.Assert(server["RuncVersion"], check.Not(check.Equals), "")
+}
+
+// TestPouchVersionFormat is to verify pouch version --format.
+func (suite *PouchVersionSuite) TestPouchVersionFormat(c *check.C) {
+	res := command.PouchRun("version", "--format", "{{.Client.Version}},{{.Server.Version}}").Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, version.Version+","+version.Version)
 }
 
-// versionToKV reads version string into key-value mapping.
-func versionToKV(version string) map[string]string {
-	res := make(map[string]string)
+// TestPouchVersionWithoutDaemon is to verify only the client version is
+// printed if daemon is unreachable.
+func (suite *PouchVersionSuite) TestPouchVersionWithoutDaemon(c *check.C) {
+	res := command.PouchRun("--host", "unix:///var/run/nonexistent-pouchd.sock", "version")
+	c.Assert(res.ExitCode, check.Equals, 125)
+	c.Assert(res.Stderr(), check.Matches, "(?s)Warning: failed to get version of daemon.*")
 
-	reg := regexp.MustCompile(`^\w+:`)
+	client, server := versionToKV(res.Stdout())
+	c.Assert(client["Version"], check.Equals, version.Version)
+	c.Assert(server, check.HasLen, 0)
+}
+
+// versionToKV reads the client and server parts of version string into
+// key-value mappings.
+func versionToKV(version string) (client, server map[string]string) {
+	client, server = make(map[string]string), make(map[string]string)
+	res := client
+
+	reg := regexp.MustCompile(`^\s*[\w/]+:`)
 
 	lines := strings.Split(version, "\n")
 	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
+		switch strings.TrimSpace(line) {
+		case "":
+			continue
+		case "Server:":
+			res = server
 			continue
 		}
 
@@ -56,5 +81,5 @@ func versionToKV(version string) map[string]string {
 			res[strings.TrimSpace(k)] = strings.TrimSpace(v)
 		}
 	}
-	return res
+	return client, server
 }
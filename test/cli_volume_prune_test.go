@@ -0,0 +1,64 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+	"github.com/alibaba/pouch/test/util"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchVolumePruneSuite is the test suite for volume prune CLI.
+type PouchVolumePruneSuite struct{}
+
+func init() {
+	check.Suite(&PouchVolumePruneSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchVolumePruneSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	PullImage(c, busyboxImage)
+}
+
+// TestVolumePrune tests "pouch volume prune" only removes the volumes not
+// used by any container, and the label filters work.
+func (suite *PouchVolumePruneSuite) TestVolumePrune(c *check.C) {
+	label := "prune=TestVolumePrune"
+	unused, used, keep := "TestVolumePruneUnused", "TestVolumePruneUsed", "TestVolumePruneKeep"
+
+	command.PouchRun("volume", "create", "--name", unused, "--label", label).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", unused)
+	command.PouchRun("volume", "create", "--name", used, "--label", label).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", used)
+	command.PouchRun("volume", "create", "--name", keep, "--label", label, "--label", "keep").Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", keep)
+
+	// the stopped container also uses the volume.
+	cname := "TestVolumePrune"
+	command.PouchRun("create", "-v", used+":/mnt", "--name", cname, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	mountpoint := command.PouchRun("volume", "inspect", "-f", "{{.Mountpoint}}", unused).Assert(c, icmd.Success).Stdout()
+	c.Assert(ioutil.WriteFile(filepath.Join(strings.TrimSpace(mountpoint), "foo"), make([]byte, 1024), 0644), check.IsNil)
+
+	res := command.PouchRun("volume", "prune", "-f", "--filter", "label="+label, "--filter", "label!=keep").Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "Deleted Volumes:\n"+unused+"\n\nTotal reclaimed space: 1.00 KB\n")
+
+	c.Assert(command.PouchRun("volume", "inspect", unused).ExitCode, check.Not(check.Equals), 0)
+	command.PouchRun("volume", "inspect", used).Assert(c, icmd.Success)
+	command.PouchRun("volume", "inspect", keep).Assert(c, icmd.Success)
+}
+
+// TestVolumePruneInvalidFilter tests "pouch volume prune" with invalid filter.
+func (suite *PouchVolumePruneSuite) TestVolumePruneInvalidFilter(c *check.C) {
+	res := command.PouchRun("volume", "prune", "-f", "--filter", "dangling=true")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid filter"), check.IsNil)
+}
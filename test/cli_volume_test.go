@@ -6,9 +6,11 @@ import (
 	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
 	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
 	"github.com/alibaba/pouch/test/request"
+	"github.com/alibaba/pouch/test/util"
 
 	"github.com/go-check/check"
 	"github.com/gotestyourself/gotestyourself/icmd"
@@ -152,7 +154,8 @@ func (suite *PouchVolumeSuite) TestVolumeUsingByContainer(c *check.C) {
 	defer DelContainerForceMultyTime(c, funcname)
 
 	ret := command.PouchRun("volume", "rm", volumeName)
-	c.Assert(ret.Stderr(), check.NotNil)
+	c.Assert(ret.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(ret.Stderr(), "it is used by containers"), check.IsNil)
 
 	command.PouchRun("rm", "-f", funcname).Assert(c, icmd.Success)
 	command.PouchRun("volume", "rm", volumeName).Assert(c, icmd.Success)
@@ -171,6 +174,41 @@ func (suite *PouchVolumeSuite) TestVolumePluginUsingByContainer(c *check.C) {
 	command.PouchRun("volume", "rm", volumeName).Assert(c, icmd.Success)
 }
 
+// TestVolumeLocalTmpfsMount tests the local volume with type, device and o
+// opts is mounted when the container starts and unmounted when it stops.
+func (suite *PouchVolumeSuite) TestVolumeLocalTmpfsMount(c *check.C) {
+	funcname := "TestVolumeLocalTmpfsMount"
+	volumeName := "volume_" + funcname
+
+	command.PouchRun("volume", "create", "--name", volumeName, "-o", "type=tmpfs,device=tmpfs,o=size=10m").Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", volumeName)
+
+	command.PouchRun("run", "-d", "-v", volumeName+":/mnt", "--name", funcname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, funcname)
+
+	res := command.PouchRun("exec", funcname, "df", "-k", "/mnt").Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(res.Stdout(), "tmpfs"), check.IsNil)
+	c.Assert(util.PartialEqual(res.Stdout(), "10240"), check.IsNil)
+
+	mountPoint := strings.TrimSpace(command.PouchRun("volume", "inspect", "-f", "{{.Mountpoint}}", volumeName).Assert(c, icmd.Success).Stdout())
+	command.PouchRun("stop", funcname).Assert(c, icmd.Success)
+	icmd.RunCommand("mountpoint", "-q", mountPoint).Assert(c, icmd.Expected{ExitCode: 1})
+}
+
+// TestVolumeLocalInvalidMountOpts tests the type, device and o opts of local
+// volume are validated when it's created.
+func (suite *PouchVolumeSuite) TestVolumeLocalInvalidMountOpts(c *check.C) {
+	volumeName := "volume_TestVolumeLocalInvalidMountOpts"
+
+	res := command.PouchRun("volume", "create", "--name", volumeName, "-o", "type=nfs,device=:/exports/x")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "addr of nfs server is required"), check.IsNil)
+
+	res = command.PouchRun("volume", "create", "--name", volumeName, "-o", "device=tmpfs")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "option type is required"), check.IsNil)
+}
+
 // TestVolumeBindReplaceMode tests the volume "direct replace(dr)" mode.
 func (suite *PouchVolumeSuite) TestVolumeBindReplaceMode(c *check.C) {
 	funcname := "TestVolumeBindReplaceMode"
@@ -306,6 +344,33 @@ func (suite *PouchVolumeSuite) TestVolumeListOptions(c *check.C) {
 	}
 }
 
+// TestVolumeListDanglingFilter tests the volume list with dangling filter,
+// and the quiet option only displays the names.
+func (suite *PouchVolumeSuite) TestVolumeListDanglingFilter(c *check.C) {
+	funcname := "TestVolumeListDanglingFilter"
+
+	used, dangling := "volume_"+funcname+"_used", "volume_"+funcname+"_dangling"
+	command.PouchRun("volume", "create", "--name", used).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", used)
+	command.PouchRun("volume", "create", "--name", dangling).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", dangling)
+
+	command.PouchRun("create", "-v", used+":/mnt", "--name", funcname, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, funcname)
+
+	names := strings.Fields(command.PouchRun("volume", "ls", "-q", "--filter", "dangling=true").Assert(c, icmd.Success).Stdout())
+	c.Assert(utils.StringInSlice(names, dangling), check.Equals, true)
+	c.Assert(utils.StringInSlice(names, used), check.Equals, false)
+
+	names = strings.Fields(command.PouchRun("volume", "ls", "-q", "--filter", "dangling=false").Assert(c, icmd.Success).Stdout())
+	c.Assert(utils.StringInSlice(names, used), check.Equals, true)
+	c.Assert(utils.StringInSlice(names, dangling), check.Equals, false)
+
+	res := command.PouchRun("volume", "ls", "--filter", "dangling=foo")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid dangling filter value"), check.IsNil)
+}
+
 // volumesToKV parse the output of "pouch volume list" into key-value pair
 func volumesToKV(volumes string) map[string][]string {
 	// skip header
@@ -110,3 +110,34 @@ func (suite *PouchWaitSuite) TestWaitBlockedExitRandom(c *check.C) {
 		c.Errorf("timeout waiting for `pouch wait` to exit")
 	}
 }
+
+// TestWaitMultipleContainers is to verify one exit code is printed per line
+// for each container in the given order.
+func (suite *PouchWaitSuite) TestWaitMultipleContainers(c *check.C) {
+	names := []string{"TestWaitMultipleContainers1", "TestWaitMultipleContainers2"}
+	command.PouchRun("run", "-d", "--name", names[0], busyboxImage, "sh", "-c", "sleep 1; exit 3").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, names[0])
+	command.PouchRun("run", "-d", "--name", names[1], busyboxImage, "sh", "-c", "exit 5").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, names[1])
+
+	res := command.PouchRun("wait", names[0], names[1])
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "3\n5\n")
+}
+
+// TestWaitTimeout is to verify wait aborts with exit code 124 if the container
+// does not stop in time.
+func (suite *PouchWaitSuite) TestWaitTimeout(c *check.C) {
+	name := "TestWaitTimeout"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	start := time.Now()
+	res := command.PouchRun("wait", "--timeout", "1s", name)
+	res.Assert(c, icmd.Expected{ExitCode: 124, Err: "timed out waiting for container " + name})
+	c.Assert(time.Since(start) < 5*time.Second, check.Equals, true)
+
+	status, err := inspectFilter(name, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "running")
+}
@@ -47,7 +47,7 @@ type Config struct {
 	// pid of pouchd
 	Pid int
 
-	// timeout for starting daemon
+	// timeout for starting or stopping daemon
 	timeout int64
 
 	// if Debug=true, dump daemon log when daemon failed to start
@@ -211,3 +211,23 @@ func (d *Config) KillDaemon() {
 		d.LogFile.Close()
 	}
 }
+
+// StopDaemon stops pouchd gracefully, and waits for pouchd to exit.
+func (d *Config) StopDaemon() error {
+	if d.Pid == 0 {
+		return nil
+	}
+
+	if err := syscall.Kill(d.Pid, syscall.SIGTERM); err != nil {
+		return fmt.Errorf("failed to stop pouchd: %v", err)
+	}
+	defer d.LogFile.Close()
+
+	exited := func() bool {
+		return syscall.Kill(d.Pid, 0) != nil
+	}
+	if !util.WaitTimeout(time.Duration(d.timeout)*time.Second, exited) {
+		return fmt.Errorf("failed to wait for pouchd %d to exit", d.Pid)
+	}
+	return nil
+}
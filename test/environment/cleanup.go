@@ -21,7 +21,7 @@ func PruneAllImages(apiClient client.ImageAPIClient) error {
 
 	for _, img := range images {
 		// force to remove the image
-		if err := apiClient.ImageRemove(ctx, img.ID, true); err != nil {
+		if _, err := apiClient.ImageRemove(ctx, img.ID, true); err != nil {
 			return errors.Wrap(err, fmt.Sprintf("fail to remove image (%s)", img.ID))
 		}
 	}
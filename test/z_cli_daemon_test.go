@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -173,6 +174,67 @@ func (suite *PouchDaemonSuite) TestDaemonNestObjectConflict(c *check.C) {
 	c.Assert(err, check.NotNil)
 }
 
+// TestDaemonValidateConfig tests pouchd --validate-config validates the
+// config file and flags without starting daemon.
+func (suite *PouchDaemonSuite) TestDaemonValidateConfig(c *check.C) {
+	path := "/tmp/pouch_validate.json"
+	cfg := struct {
+		Debug        bool     `json:"debug"`
+		CgroupDriver string   `json:"cgroup-driver"`
+		Labels       []string `json:"label"`
+	}{
+		CgroupDriver: "cgroupfs",
+		Labels:       []string{"a=b"},
+	}
+	c.Assert(CreateConfigFile(path, cfg), check.IsNil)
+	defer os.Remove(path)
+
+	icmd.RunCommand(daemon.PouchdBin, "--config-file="+path, "--validate-config").Assert(c, icmd.Expected{Out: "configuration OK"})
+
+	// the conflicting keys are named.
+	res := icmd.RunCommand(daemon.PouchdBin, "--config-file="+path, "--validate-config", "--debug")
+	res.Assert(c, icmd.Expected{ExitCode: 1, Err: "debug (from flag: true, from config file: false)"})
+
+	// the unknown keys are reported.
+	c.Assert(ioutil.WriteFile(path, []byte(`{"debgu": true}`), 0644), check.IsNil)
+	res = icmd.RunCommand(daemon.PouchdBin, "--config-file="+path, "--validate-config")
+	res.Assert(c, icmd.Expected{ExitCode: 1, Err: "found unknown keys in config file: debgu"})
+}
+
+// TestDaemonReloadConfig tests SIGHUP reloads the config file of daemon,
+// and the invalid config file keeps the previous configurations.
+func (suite *PouchDaemonSuite) TestDaemonReloadConfig(c *check.C) {
+	path := "/tmp/pouch_reload.json"
+	c.Assert(ioutil.WriteFile(path, []byte(`{"label": ["a=b"]}`), 0644), check.IsNil)
+	defer os.Remove(path)
+
+	dcfg, err := StartDefaultDaemon("--config-file=" + path)
+	c.Assert(err, check.IsNil)
+	defer dcfg.KillDaemon()
+
+	reload := func(content string) {
+		c.Assert(ioutil.WriteFile(path, []byte(content), 0644), check.IsNil)
+		c.Assert(syscall.Kill(dcfg.Pid, syscall.SIGHUP), check.IsNil)
+		// wait for the reload in daemon.
+		time.Sleep(time.Second)
+	}
+
+	reload(`{"label": ["c=d"], "registry-mirrors": ["https://mirror.example.com"], "cgroup-driver": "systemd"}`)
+	result := RunWithSpecifiedDaemon(dcfg, "info")
+	result.Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(result.Stdout(), "c=d"), check.IsNil)
+	c.Assert(util.PartialEqual(result.Stdout(), "https://mirror.example.com"), check.IsNil)
+	// the cgroup driver requires restarting daemon.
+	c.Assert(util.PartialEqual(result.Stdout(), "cgroupfs"), check.IsNil)
+
+	// the invalid mirror fails the whole reload.
+	reload(`{"label": ["e=f"], "registry-mirrors": ["mirror.example.com"]}`)
+	result = RunWithSpecifiedDaemon(dcfg, "info")
+	result.Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(result.Stdout(), "c=d"), check.IsNil)
+	c.Assert(strings.Contains(result.Stdout(), "e=f"), check.Equals, false)
+}
+
 // TestDaemonSliceFlagNotConflict tests start daemon with configure file contains slice flag will not conflicts with parameter.
 func (suite *PouchDaemonSuite) TestDaemonSliceFlagNotConflict(c *check.C) {
 	path := "/tmp/pouch_slice.json"
@@ -380,6 +442,32 @@ func (suite *PouchDaemonSuite) TestDaemonDefaultRegistry(c *check.C) {
 	defer dcfg.KillDaemon()
 }
 
+// TestDaemonRegistryMirrors tests the unavailable mirror falls back to the
+// upstream registry, and the mirrors and insecure registries are shown in info.
+func (suite *PouchDaemonSuite) TestDaemonRegistryMirrors(c *check.C) {
+	SkipIfFalse(c, environment.IsHubConnected)
+
+	dcfg, err := StartDefaultDaemonDebug(
+		"--registry-mirrors", "http://127.0.0.1:1",
+		"--insecure-registries", "lab-registry:5000")
+	c.Assert(err, check.IsNil)
+	defer dcfg.KillDaemon()
+
+	result := RunWithSpecifiedDaemon(dcfg, "info")
+	result.Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(result.Stdout(), "Registry Mirrors:\n http://127.0.0.1:1"), check.IsNil)
+	c.Assert(util.PartialEqual(result.Stdout(), "Insecure Registries:\n lab-registry:5000"), check.IsNil)
+
+	RunWithSpecifiedDaemon(dcfg, "pull", busyboxImage).Assert(c, icmd.Success)
+	RunWithSpecifiedDaemon(dcfg, "rmi", busyboxImage).Assert(c, icmd.Success)
+}
+
+// TestDaemonInvalidRegistryMirror tests the daemon refuses the invalid mirror.
+func (suite *PouchDaemonSuite) TestDaemonInvalidRegistryMirror(c *check.C) {
+	_, err := StartDefaultDaemon("--registry-mirrors", "mirror.example.com")
+	c.Assert(err, check.NotNil)
+}
+
 // TestDaemonCriEnabled tests enabling cri part in pouchd.
 func (suite *PouchDaemonSuite) TestDaemonCriEnabled(c *check.C) {
 	dcfg, err := StartDefaultDaemonDebug(
@@ -654,6 +742,208 @@ func (suite *PouchDaemonSuite) TestRecoverContainerWhenHostDown(c *check.C) {
 	}
 }
 
+// TestDaemonLiveRestore tests the containers are kept running when daemon is
+// stopped, and the container exited when daemon is down is reconciled.
+func (suite *PouchDaemonSuite) TestDaemonLiveRestore(c *check.C) {
+	dcfg, err := StartDefaultDaemonDebug()
+	if err != nil {
+		c.Skip("daemon start failed")
+	}
+	defer dcfg.KillDaemon()
+
+	RunWithSpecifiedDaemon(dcfg, "pull", busyboxImage).Assert(c, icmd.Success)
+
+	running, exited := "TestDaemonLiveRestore", "TestDaemonLiveRestoreExited"
+	ensureContainerNotExist(dcfg, running)
+	ensureContainerNotExist(dcfg, exited)
+	RunWithSpecifiedDaemon(dcfg, "run", "-d", "--name", running, "-p", "1235:80", busyboxImage, "top").Assert(c, icmd.Success)
+	defer ensureContainerNotExist(dcfg, running)
+	RunWithSpecifiedDaemon(dcfg, "run", "-d", "--name", exited, busyboxImage, "sh", "-c", "sleep 3; exit 3").Assert(c, icmd.Success)
+	defer ensureContainerNotExist(dcfg, exited)
+
+	c.Assert(dcfg.StopDaemon(), check.IsNil)
+	// the container exits when daemon is down.
+	time.Sleep(5 * time.Second)
+	c.Assert(dcfg.StartDaemon(), check.IsNil)
+
+	// ps, inspect, exec and stop work on the restored container.
+	result := RunWithSpecifiedDaemon(dcfg, "ps")
+	result.Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(result.Stdout(), running), check.IsNil)
+
+	status := RunWithSpecifiedDaemon(dcfg, "inspect", "-f", "{{.State.Status}}", running).Stdout()
+	c.Assert(strings.TrimSpace(status), check.Equals, "running")
+
+	RunWithSpecifiedDaemon(dcfg, "exec", running, "echo", "restored").Assert(c, icmd.Expected{Out: "restored"})
+	RunWithSpecifiedDaemon(dcfg, "stop", running).Assert(c, icmd.Success)
+	status = RunWithSpecifiedDaemon(dcfg, "inspect", "-f", "{{.State.Status}}", running).Stdout()
+	c.Assert(strings.TrimSpace(status), check.Equals, "stopped")
+
+	// the exit code of container exited when daemon is down is recorded.
+	state := RunWithSpecifiedDaemon(dcfg, "inspect", "-f", "{{.State.Status}} {{.State.ExitCode}}", exited).Stdout()
+	c.Assert(strings.TrimSpace(state), check.Equals, "exited 3")
+}
+
+// TestDaemonLiveRestoreVolumeMount tests the volume with mount opts is kept
+// mounted until the last restored container using it stops.
+func (suite *PouchDaemonSuite) TestDaemonLiveRestoreVolumeMount(c *check.C) {
+	dcfg, err := StartDefaultDaemonDebug()
+	if err != nil {
+		c.Skip("daemon start failed")
+	}
+	defer dcfg.KillDaemon()
+
+	RunWithSpecifiedDaemon(dcfg, "pull", busyboxImage).Assert(c, icmd.Success)
+
+	volumeName := "volume_TestDaemonLiveRestoreVolumeMount"
+	RunWithSpecifiedDaemon(dcfg, "volume", "create", "--name", volumeName, "-o", "type=tmpfs,device=tmpfs,o=size=10m").Assert(c, icmd.Success)
+	defer RunWithSpecifiedDaemon(dcfg, "volume", "rm", volumeName)
+
+	first, second := "TestDaemonLiveRestoreVolumeMount", "TestDaemonLiveRestoreVolumeMountSecond"
+	for _, name := range []string{first, second} {
+		ensureContainerNotExist(dcfg, name)
+		RunWithSpecifiedDaemon(dcfg, "run", "-d", "--name", name, "-v", volumeName+":/mnt", busyboxImage, "top").Assert(c, icmd.Success)
+		defer ensureContainerNotExist(dcfg, name)
+	}
+
+	c.Assert(dcfg.StopDaemon(), check.IsNil)
+	c.Assert(dcfg.StartDaemon(), check.IsNil)
+
+	mountPoint := strings.TrimSpace(RunWithSpecifiedDaemon(dcfg, "volume", "inspect", "-f", "{{.Mountpoint}}", volumeName).Assert(c, icmd.Success).Stdout())
+
+	// the volume is still used by the second container.
+	RunWithSpecifiedDaemon(dcfg, "stop", first).Assert(c, icmd.Success)
+	icmd.RunCommand("mountpoint", "-q", mountPoint).Assert(c, icmd.Success)
+	RunWithSpecifiedDaemon(dcfg, "exec", second, "df", "-k", "/mnt").Assert(c, icmd.Expected{Out: "tmpfs"})
+
+	RunWithSpecifiedDaemon(dcfg, "stop", second).Assert(c, icmd.Success)
+	icmd.RunCommand("mountpoint", "-q", mountPoint).Assert(c, icmd.Expected{ExitCode: 1})
+}
+
+// TestDaemonWithoutLiveRestore tests the containers are stopped with daemon
+// if live restore is disabled.
+func (suite *PouchDaemonSuite) TestDaemonWithoutLiveRestore(c *check.C) {
+	dcfg, err := StartDefaultDaemonDebug("--live-restore=false")
+	if err != nil {
+		c.Skip("daemon start failed")
+	}
+	defer dcfg.KillDaemon()
+
+	result := RunWithSpecifiedDaemon(dcfg, "info")
+	result.Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(result.Stdout(), "LiveRestoreEnabled: false"), check.IsNil)
+
+	RunWithSpecifiedDaemon(dcfg, "pull", busyboxImage).Assert(c, icmd.Success)
+
+	stopped, restarted := "TestDaemonWithoutLiveRestore", "TestDaemonWithoutLiveRestoreAlways"
+	ensureContainerNotExist(dcfg, stopped)
+	ensureContainerNotExist(dcfg, restarted)
+	RunWithSpecifiedDaemon(dcfg, "run", "-d", "--name", stopped, busyboxImage, "top").Assert(c, icmd.Success)
+	defer ensureContainerNotExist(dcfg, stopped)
+	RunWithSpecifiedDaemon(dcfg, "run", "-d", "--name", restarted, "--restart", "always", busyboxImage, "top").Assert(c, icmd.Success)
+	defer ensureContainerNotExist(dcfg, restarted)
+
+	c.Assert(dcfg.StopDaemon(), check.IsNil)
+	c.Assert(dcfg.StartDaemon(), check.IsNil)
+
+	status := RunWithSpecifiedDaemon(dcfg, "inspect", "-f", "{{.State.Status}}", stopped).Stdout()
+	c.Assert(strings.TrimSpace(status), check.Equals, "exited")
+
+	// the container stopped with daemon is restarted by restart policy.
+	running := func() bool {
+		status := RunWithSpecifiedDaemon(dcfg, "inspect", "-f", "{{.State.Status}}", restarted).Stdout()
+		return strings.TrimSpace(status) == "running"
+	}
+	c.Assert(util.WaitTimeout(10*time.Second, running), check.Equals, true)
+}
+
+// TestDaemonShutdownTimeout tests the containers receive SIGTERM before
+// SIGKILL when daemon is stopped, and the shutdown timeout is honored.
+func (suite *PouchDaemonSuite) TestDaemonShutdownTimeout(c *check.C) {
+	dcfg, err := StartDefaultDaemonDebug("--live-restore=false", "--shutdown-timeout=3")
+	if err != nil {
+		c.Skip("daemon start failed")
+	}
+	defer dcfg.KillDaemon()
+
+	RunWithSpecifiedDaemon(dcfg, "pull", busyboxImage).Assert(c, icmd.Success)
+
+	cname := "TestDaemonShutdownTimeout"
+	ensureContainerNotExist(dcfg, cname)
+	RunWithSpecifiedDaemon(dcfg, "run", "-d", "--name", cname, busyboxImage,
+		"sh", "-c", `trap "echo received SIGTERM" TERM; while true; do sleep 1; done`).Assert(c, icmd.Success)
+	defer ensureContainerNotExist(dcfg, cname)
+
+	start := time.Now()
+	c.Assert(dcfg.StopDaemon(), check.IsNil)
+	elapsed := time.Since(start)
+
+	// the container ignoring SIGTERM is killed after the shutdown timeout,
+	// rather than its default stop timeout 10s.
+	c.Assert(elapsed >= 3*time.Second, check.Equals, true, check.Commentf("shutdown takes %v", elapsed))
+	c.Assert(elapsed < 10*time.Second, check.Equals, true, check.Commentf("shutdown takes %v", elapsed))
+
+	c.Assert(dcfg.StartDaemon(), check.IsNil)
+	RunWithSpecifiedDaemon(dcfg, "logs", cname).Assert(c, icmd.Expected{Out: "received SIGTERM"})
+	state := RunWithSpecifiedDaemon(dcfg, "inspect", "-f", "{{.State.Status}} {{.State.ExitCode}}", cname).Stdout()
+	c.Assert(strings.TrimSpace(state), check.Equals, "exited 137")
+}
+
+// TestDaemonForceShutdown tests the second signal forces daemon to exit
+// without waiting for the containers to stop.
+func (suite *PouchDaemonSuite) TestDaemonForceShutdown(c *check.C) {
+	dcfg, err := StartDefaultDaemonDebug("--live-restore=false", "--shutdown-timeout=60")
+	if err != nil {
+		c.Skip("daemon start failed")
+	}
+	defer dcfg.KillDaemon()
+
+	RunWithSpecifiedDaemon(dcfg, "pull", busyboxImage).Assert(c, icmd.Success)
+
+	cname := "TestDaemonForceShutdown"
+	ensureContainerNotExist(dcfg, cname)
+	RunWithSpecifiedDaemon(dcfg, "run", "-d", "--name", cname, busyboxImage,
+		"sh", "-c", `trap "echo received SIGTERM" TERM; while true; do sleep 1; done`).Assert(c, icmd.Success)
+	defer ensureContainerNotExist(dcfg, cname)
+
+	c.Assert(syscall.Kill(dcfg.Pid, syscall.SIGTERM), check.IsNil)
+	time.Sleep(time.Second)
+	c.Assert(syscall.Kill(dcfg.Pid, syscall.SIGTERM), check.IsNil)
+
+	exited := func() bool {
+		return syscall.Kill(dcfg.Pid, 0) != nil
+	}
+	c.Assert(util.WaitTimeout(5*time.Second, exited), check.Equals, true)
+
+	// containerd is left running by the forced exit.
+	syscall.Kill(-dcfg.Pid, syscall.SIGKILL)
+	c.Assert(dcfg.StartDaemon(), check.IsNil)
+}
+
+// TestDaemonMetricsAddr tests the metrics are served on the metrics address
+// which doesn't serve the API.
+func (suite *PouchDaemonSuite) TestDaemonMetricsAddr(c *check.C) {
+	addr := "127.0.0.1:19323"
+	dcfg, err := StartDefaultDaemonDebug("--metrics-addr=" + addr)
+	if err != nil {
+		c.Skip("daemon start failed")
+	}
+	defer dcfg.KillDaemon()
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	c.Assert(err, check.IsNil)
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	c.Assert(err, check.IsNil)
+	c.Assert(resp.StatusCode, check.Equals, http.StatusOK)
+	c.Assert(strings.Contains(string(body), "pouch_containers"), check.Equals, true)
+
+	resp, err = http.Get("http://" + addr + "/_ping")
+	c.Assert(err, check.IsNil)
+	resp.Body.Close()
+	c.Assert(resp.StatusCode, check.Equals, http.StatusNotFound)
+}
+
 // TestDaemonWithSysyemdCgroupDriver tests start daemon with systemd cgroup driver
 func (suite *PouchDaemonSuite) TestDaemonWithSystemdCgroupDriver(c *check.C) {
 	SkipIfFalse(c, environment.SupportSystemdCgroupDriver)
@@ -0,0 +1,36 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareAPIVersion compares the api versions such as 1.24 and v1.25, it
+// returns -1, 0 or 1 if a is older than, the same as or newer than b.
+func CompareAPIVersion(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var an, bn int
+		if i < len(as) {
+			an, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			bn, _ = strconv.Atoi(bs[i])
+		}
+
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		}
+	}
+	return 0
+}
+
+// APIVersionLessThan returns true if the api version a is older than b.
+func APIVersionLessThan(a, b string) bool {
+	return CompareAPIVersion(a, b) < 0
+}
@@ -0,0 +1,27 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareAPIVersion(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.24", "1.24", 0},
+		{"v1.24", "1.24", 0},
+		{"1.24", "1.25", -1},
+		{"1.25", "1.24", 1},
+		{"1.9", "1.24", -1},
+		{"1.24", "1.24.0", 0},
+		{"2.0", "1.99", 1},
+	} {
+		assert.Equal(t, tc.expected, CompareAPIVersion(tc.a, tc.b), "%s vs %s", tc.a, tc.b)
+	}
+
+	assert.True(t, APIVersionLessThan("1.24", "1.25"))
+	assert.False(t, APIVersionLessThan("1.25", "1.25"))
+}
@@ -1,16 +1,47 @@
 package version
 
+import "runtime"
+
 // Version package values is auto-generated, the following values will be overwrited at build time.
 var (
-	// Version represents the version of pouchd.
+	// Version represents the version of pouch and pouchd.
 	Version = "1.3.0"
 
 	// BuildTime is the time when pouch binary is built
 	BuildTime = "unknown"
 
 	// APIVersion means the api version daemon serves
-	APIVersion = "1.24"
+	APIVersion = "1.25"
+
+	// MinAPIVersion is the oldest api version daemon still serves
+	MinAPIVersion = "1.24"
 
 	// GitCommit is the commit id to build Pouch
 	GitCommit = "unknown"
 )
+
+// Info is the build information of the binary.
+type Info struct {
+	Version       string
+	APIVersion    string
+	MinAPIVersion string
+	GitCommit     string
+	BuildTime     string
+	GoVersion     string
+	Os            string
+	Arch          string
+}
+
+// Get returns the build information of the running binary.
+func Get() Info {
+	return Info{
+		Version:       Version,
+		APIVersion:    APIVersion,
+		MinAPIVersion: MinAPIVersion,
+		GitCommit:     GitCommit,
+		BuildTime:     BuildTime,
+		GoVersion:     runtime.Version(),
+		Os:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+	}
+}